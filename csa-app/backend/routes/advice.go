@@ -0,0 +1,66 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/csa"
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type adviceRoutes struct {
+	csaSvc *csa.CsaService
+}
+
+//getTranslations returns the translation coverage of every locale
+func (r *adviceRoutes) getTranslations(c *gin.Context) {
+	coverage, err := r.csaSvc.AdviceCoverage()
+
+	if !CheckForError(c, err, "Error retrieving rule advice translations! Details => %s") {
+		c.JSON(http.StatusOK, gin.H{
+			"source":  model.SOURCE_LOCALE,
+			"locales": coverage,
+		})
+	}
+}
+
+//getCatalog returns the advice catalog of a locale
+func (r *adviceRoutes) getCatalog(c *gin.Context) {
+	locale := c.Param("locale")
+
+	catalog, err := model.LoadAdviceCatalog(*util.I18nDir, locale)
+	if err != nil {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Error retrieving [%s] rule advice! Details => %s", locale, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+//contributeTranslations merges the posted (partial) catalog of translated advice into the locale's catalog
+func (r *adviceRoutes) contributeTranslations(c *gin.Context) {
+	var contribution model.AdviceCatalog
+	if err := c.BindJSON(&contribution); err != nil {
+		return
+	}
+	contribution.Locale = c.Param("locale")
+
+	added, err := r.csaSvc.ContributeAdvice(&contribution)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error contributing [%s] rule advice! Details => %s", contribution.Locale, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"locale": contribution.Locale,
+		"added":  added,
+	})
+}
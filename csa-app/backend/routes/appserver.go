@@ -0,0 +1,38 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type appServerRoutes struct {
+	runRepo db.RunRepository
+}
+
+//getAppServerInventory returns the migration inventory of the run's applications, only that of the app when one is
+//given
+func (r *appServerRoutes) getAppServerInventory(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	inventories, err := report.AppServerInventoryReports(r.runRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error reporting app server resources for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"appServer": inventories,
+		})
+	}
+}
@@ -0,0 +1,38 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type configRoutes struct {
+	findingsRepo db.FindingRepository
+}
+
+//getConfigExternalization returns the hard-coded values the run's applications must externalize, only those of the
+//app when one is given
+func (r *configRoutes) getConfigExternalization(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	apps, err := report.ConfigExternalizationReports(r.findingsRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error reporting configuration to externalize for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"configExternalization": apps,
+		})
+	}
+}
@@ -0,0 +1,38 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type containerRoutes struct {
+	findingsRepo db.FindingRepository
+	runRepo      db.RunRepository
+}
+
+//getContainerReadiness returns the container readiness of the run's applications, only that of the app when one is given
+func (r *containerRoutes) getContainerReadiness(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	readiness, err := report.ContainerReadinessReports(r.findingsRepo, r.runRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error scoring container readiness for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"containerReadiness": readiness,
+		})
+	}
+}
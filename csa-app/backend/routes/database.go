@@ -0,0 +1,56 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type databaseRoutes struct {
+	findingsRepo db.FindingRepository
+	runRepo      db.RunRepository
+	slocRepo     db.SlocRepository
+}
+
+//getDatabaseCoupling returns the database coupling of the run's applications, only that of the app when one is given
+func (r *databaseRoutes) getDatabaseCoupling(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	couplings, err := report.DatabaseCouplingReports(r.findingsRepo, r.runRepo, r.slocRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error rating database coupling for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"databaseCoupling": couplings,
+		})
+	}
+}
+
+//getDatabaseInventory returns the database inventory of the run's applications, only that of the app when one is given
+func (r *databaseRoutes) getDatabaseInventory(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	inventories, err := report.DatabaseInventoryReports(r.findingsRepo, r.runRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error inventorying databases for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"databaseInventory": inventories,
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type dependencyRoutes struct {
+	runRepo        db.RunRepository
+	dependencyRepo db.DependencyRepository
+}
+
+//getDependencies returns the dependency graph of the run's applications, with the migration wave of each
+func (r *dependencyRoutes) getDependencies(c *gin.Context) {
+	runId := getId(c)
+
+	graph, err := report.DependencyGraphFor(r.runRepo, r.dependencyRepo, runId)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error retrieving dependencies for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, graph)
+	}
+}
+
+//getLibraries returns the third-party libraries the run's applications declare
+func (r *dependencyRoutes) getLibraries(c *gin.Context) {
+	runId := getId(c)
+
+	libraries, err := r.dependencyRepo.GetAppLibraries(runId)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error retrieving libraries for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{"libraries": libraries})
+	}
+}
@@ -0,0 +1,36 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type dispositionRoutes struct {
+	repositories *db.Repositories
+}
+
+//getDispositions returns the disposition recommended (by the default rules) for the run's applications, only that of
+//the app when one is given
+func (r *dispositionRoutes) getDispositions(c *gin.Context) {
+	runId := getId(c)
+	app := c.Query("app")
+
+	dispositions, err := report.Dispositions(r.repositories, runId, app, model.DefaultDispositionRules())
+
+	if !CheckForError(c, err, fmt.Sprintf("Error recommending dispositions for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"dispositions": dispositions,
+		})
+	}
+}
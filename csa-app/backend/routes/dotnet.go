@@ -0,0 +1,39 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type dotnetRoutes struct {
+	findingsRepo db.FindingRepository
+	runRepo      db.RunRepository
+}
+
+//getDotnetMigration returns the target frameworks and .NET 8 migration blockers of the run's .NET applications, only
+//those of the app when one is given
+func (r *dotnetRoutes) getDotnetMigration(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	migrations, err := report.DotnetMigrationReports(r.findingsRepo, r.runRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error evaluating .NET migration for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"dotnet": migrations,
+		})
+	}
+}
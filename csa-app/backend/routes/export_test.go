@@ -0,0 +1,20 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"csa-app/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+//JobRouter routes report job submissions to the job service given, I.E. one whose queue is full
+func JobRouter(jobSvc services.JobService) *gin.Engine {
+	router := gin.New()
+	jobRoutes := &jobRoutes{jobSvc}
+	router.POST("/api/runs/:id/reports/:report", jobRoutes.submitReportJob)
+	return router
+}
@@ -17,7 +17,9 @@ import (
 	"github.com/pkg/browser"
 
 	"csa-app/backend/services"
+	"csa-app/csa"
 	"csa-app/db"
+	"csa-app/report"
 	"csa-app/util"
 
 	"github.com/gin-gonic/contrib/static"
@@ -57,19 +59,66 @@ func SetupRouter(database *gorm.DB, useHttpFS bool) *gin.Engine {
 	appSvc := services.NewAppService(repositories)
 	dataSvc := services.NewDataService(repositories)
 	scoreSvc := services.NewScoringService(repositories)
-	ruleRoutes := &ruleRoutes{repositories.Rules}
+	ruleSvc := services.NewRuleService(repositories)
+	ruleRoutes := &ruleRoutes{repositories.Rules, ruleSvc}
 	runRoutes := &runRoutes{repositories.Run, scoreSvc, appSvc}
 	findingRoutes := &findingRoutes{repositories.Findings, appSvc, dataSvc}
 	slocRoutes := &slocRoutes{repositories.Sloc, dataSvc}
+	groupRoutes := &groupRoutes{repositories.Groups, repositories.Scoring}
+	scoreBinRoutes := &scoreBinRoutes{repositories.Scoring}
+	manifestRoutes := &manifestRoutes{repositories.Manifest}
+	techStackRoutes := &techStackRoutes{repositories.TechStack}
+	twelveFactorRoutes := &twelveFactorRoutes{repositories.Findings, repositories.Run}
+	containerRoutes := &containerRoutes{repositories.Findings, repositories.Run}
+	dotnetRoutes := &dotnetRoutes{repositories.Findings, repositories.Run}
+	databaseRoutes := &databaseRoutes{repositories.Findings, repositories.Run, repositories.Sloc}
+	kubernetesRoutes := &kubernetesRoutes{repositories.Findings}
+	appServerRoutes := &appServerRoutes{repositories.Run}
+	configRoutes := &configRoutes{repositories.Findings}
+	presentationRoutes := &presentationRoutes{repositories.Findings, repositories.Run, repositories.Sloc}
+	messagingRoutes := &messagingRoutes{repositories.Findings, repositories.Run}
+	triageRoutes := &triageRoutes{repositories}
+	dependencyRoutes := &dependencyRoutes{repositories.Run, repositories.Dependencies}
+	dispositionRoutes := &dispositionRoutes{repositories}
+	whatIfRoutes := &whatIfRoutes{repositories}
+	portfolioRoutes := &portfolioRoutes{repositories}
+	moduleRoutes := &moduleRoutes{repositories}
+	scoreExplanationRoutes := &scoreExplanationRoutes{repositories}
+	jobRoutes := &jobRoutes{services.NewJobService(repositories, *util.ReportWorkers)}
+	treemapRoutes := &treemapRoutes{report.NewTreemapReportService(repositories)}
+	adviceRoutes := &adviceRoutes{csa.NewCsaSvc(repositories)}
+
+	if *util.WatchRules {
+		go ruleSvc.WatchRules(*util.WatchRulesInterval)
+	}
 
 	api := router.Group("/api")
 	{
 		api.GET("/health", baseRoute)
 		api.GET("/rules", ruleRoutes.getAllRules)
+		api.PUT("/rules", ruleRoutes.saveRules)
+		api.DELETE("/rules/:name", ruleRoutes.deleteRule)
+		api.GET("/rules/status", ruleRoutes.getRulesStatus)
+		api.POST("/rules/reload", ruleRoutes.reloadRules)
 		api.GET("/runs", runRoutes.getRuns)
 		api.GET("/version", version)
 		api.GET("/analyze-runs", runRoutes.getAnalyzeRuns)
 		api.GET("/findings/:id", findingRoutes.getFinding)
+		api.GET("/groups", groupRoutes.getGroups)
+		api.POST("/groups", groupRoutes.createGroup)
+		api.DELETE("/groups/:name", groupRoutes.deleteGroup)
+		api.POST("/groups/:name/apps/:app", groupRoutes.addApplication)
+		api.DELETE("/groups/:name/apps/:app", groupRoutes.removeApplication)
+		api.GET("/score-bins", scoreBinRoutes.getScoreBins)
+		api.POST("/score-bins", scoreBinRoutes.createScoreBin)
+		api.PUT("/score-bins/:name", scoreBinRoutes.updateScoreBin)
+		api.DELETE("/score-bins/:name", scoreBinRoutes.deleteScoreBin)
+		api.GET("/jobs", jobRoutes.getJobs)
+		api.GET("/jobs/:job", jobRoutes.getJob)
+		api.GET("/jobs/:job/artifact", jobRoutes.getJobArtifact)
+		api.GET("/i18n", adviceRoutes.getTranslations)
+		api.GET("/i18n/:locale", adviceRoutes.getCatalog)
+		api.PUT("/i18n/:locale", adviceRoutes.contributeTranslations)
 
 		run := api.Group("runs/:id")
 		{
@@ -77,6 +126,30 @@ func SetupRouter(database *gorm.DB, useHttpFS bool) *gin.Engine {
 			run.GET("/scorecards", findingRoutes.getApplicationScoreCards)
 			run.GET("/findings", findingRoutes.getRunFindings)
 			run.GET("/apps", runRoutes.getApps)
+			run.GET("/groups", groupRoutes.getRollups)
+			run.GET("/domains", groupRoutes.getDomainRollups)
+			run.PUT("/domains", groupRoutes.assignDomains)
+			run.GET("/manifest", manifestRoutes.getManifest)
+			run.GET("/tech-stack", techStackRoutes.getTechStack)
+			run.GET("/twelve-factor", twelveFactorRoutes.getTwelveFactor)
+			run.GET("/container-readiness", containerRoutes.getContainerReadiness)
+			run.GET("/dotnet", dotnetRoutes.getDotnetMigration)
+			run.GET("/database-coupling", databaseRoutes.getDatabaseCoupling)
+			run.GET("/database-inventory", databaseRoutes.getDatabaseInventory)
+			run.GET("/kubernetes", kubernetesRoutes.getKubernetesManifests)
+			run.GET("/app-server", appServerRoutes.getAppServerInventory)
+			run.GET("/config-externalization", configRoutes.getConfigExternalization)
+			run.GET("/presentation-tier", presentationRoutes.getPresentationTier)
+			run.GET("/messaging", messagingRoutes.getMessaging)
+			run.GET("/triage", triageRoutes.getTriage)
+			run.PUT("/triage", triageRoutes.triageFindings)
+			run.GET("/dependencies", dependencyRoutes.getDependencies)
+			run.GET("/libraries", dependencyRoutes.getLibraries)
+			run.GET("/dispositions", dispositionRoutes.getDispositions)
+			run.GET("/what-if", whatIfRoutes.getWhatIf)
+			run.GET("/portfolio", portfolioRoutes.getPortfolio)
+			run.GET("/modules", moduleRoutes.getModuleScores)
+			run.POST("/reports/:report", jobRoutes.submitReportJob)
 			run.GET("/rule-metrics", ruleRoutes.getMetrics)
 			run.POST("/search", findingRoutes.searchFindingsPost)
 			summary := run.Group("/summary")
@@ -96,6 +169,20 @@ func SetupRouter(database *gorm.DB, useHttpFS bool) *gin.Engine {
 				app.GET("/languages", slocRoutes.getLanguagesForRunAndApplication)
 				app.GET("/apis", findingRoutes.getApiUsageForRunAndApplication)
 				app.GET("/findings", findingRoutes.getApplicationFindings)
+				app.GET("/treemap", treemapRoutes.getTreemap)
+				app.GET("/tech-stack", techStackRoutes.getTechStack)
+				app.GET("/twelve-factor", twelveFactorRoutes.getTwelveFactor)
+				app.GET("/container-readiness", containerRoutes.getContainerReadiness)
+				app.GET("/dotnet", dotnetRoutes.getDotnetMigration)
+				app.GET("/database-coupling", databaseRoutes.getDatabaseCoupling)
+				app.GET("/database-inventory", databaseRoutes.getDatabaseInventory)
+				app.GET("/kubernetes", kubernetesRoutes.getKubernetesManifests)
+				app.GET("/app-server", appServerRoutes.getAppServerInventory)
+				app.GET("/config-externalization", configRoutes.getConfigExternalization)
+				app.GET("/presentation-tier", presentationRoutes.getPresentationTier)
+				app.GET("/messaging", messagingRoutes.getMessaging)
+				app.GET("/modules", moduleRoutes.getModuleScores)
+				app.GET("/score/explanation", scoreExplanationRoutes.getScoreExplanation)
 				app.POST("/findings/scorecard/:card", findingRoutes.getAppFindings)
 				app.GET("/tags", runRoutes.getAppTags)
 				app.POST("/", runRoutes.updateApp)
@@ -117,12 +204,12 @@ func SetupRouter(database *gorm.DB, useHttpFS bool) *gin.Engine {
 }
 
 func baseRoute(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("CSA Server [%s] is up!", util.App.Model().Version)})
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("CSA Server [%s] is up!", util.App.Version())})
 }
 
 func version(c *gin.Context) {
-	fmt.Printf("CSA Version => %s\n", util.App.Model().Version)
-	c.JSON(http.StatusOK, gin.H{"version": util.App.Model().Version})
+	fmt.Printf("CSA Version => %s\n", util.App.Version())
+	c.JSON(http.StatusOK, gin.H{"version": util.App.Version()})
 }
 
 func getId(c *gin.Context) uint {
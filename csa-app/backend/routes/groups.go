@@ -0,0 +1,134 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type groupRoutes struct {
+	groupsRepo  db.AppGroupRepository
+	scoringRepo db.ScoringRepository
+}
+
+func (r *groupRoutes) getGroups(c *gin.Context) {
+	groups, err := r.groupsRepo.GetGroups()
+
+	if !CheckForError(c, err, "Error retrieving groups! Details => %s") {
+		c.JSON(http.StatusOK, gin.H{
+			"groups": groups,
+		})
+	}
+}
+
+func (r *groupRoutes) createGroup(c *gin.Context) {
+	var group model.AppGroup
+	if err := c.BindJSON(&group); err != nil {
+		return
+	}
+
+	if err := r.groupsRepo.SaveGroup(&group); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error saving group [%s]! Details => %s", group.Name, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"group": group,
+	})
+}
+
+func (r *groupRoutes) deleteGroup(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := r.groupsRepo.DeleteGroup(name); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error deleting group [%s]! Details => %s", name, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r *groupRoutes) addApplication(c *gin.Context) {
+	name := c.Param("name")
+	app := c.Param("app")
+
+	if err := r.groupsRepo.AddApplication(name, app); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error adding application [%s] to group [%s]! Details => %s", app, name, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r *groupRoutes) removeApplication(c *gin.Context) {
+	name := c.Param("name")
+	app := c.Param("app")
+
+	if err := r.groupsRepo.RemoveApplication(name, app); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error removing application [%s] from group [%s]! Details => %s", app, name, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r *groupRoutes) getRollups(c *gin.Context) {
+	runId := getId(c)
+	fmt.Printf("Getting group roll-ups for Run[%d]\n", runId)
+
+	rollups, err := r.groupsRepo.GetGroupRollups(runId)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error retrieving group roll-ups for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"groups": rollups,
+		})
+	}
+}
+
+func (r *groupRoutes) getDomainRollups(c *gin.Context) {
+	runId := getId(c)
+	fmt.Printf("Getting domain roll-ups for Run[%d]\n", runId)
+
+	rollups, err := r.groupsRepo.GetDomainRollups(runId)
+	if err == nil {
+		var bins []model.ScoreBin
+		bins, err = r.scoringRepo.GetScoreBins()
+		for i := range rollups {
+			rollups[i].Tier = model.ScoreBinName(bins, rollups[i].Score)
+		}
+	}
+
+	if !CheckForError(c, err, fmt.Sprintf("Error retrieving domain roll-ups for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"domains": rollups,
+		})
+	}
+}
+
+func (r *groupRoutes) assignDomains(c *gin.Context) {
+	runId := getId(c)
+
+	var mapping model.DomainMapping
+	if err := c.BindJSON(&mapping); err != nil {
+		return
+	}
+
+	assigned, err := r.groupsRepo.AssignDomains(runId, &mapping)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error assigning domains for run[%d]! Details => %s", runId, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"assigned": assigned,
+	})
+}
@@ -0,0 +1,93 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"csa-app/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+//Seconds clients are told to wait before submitting a report job again when the queue is full
+const REPORT_JOB_RETRY_AFTER = 30
+
+type jobRoutes struct {
+	jobSvc services.JobService
+}
+
+func (r *jobRoutes) submitReportJob(c *gin.Context) {
+	runId := getId(c)
+	reportName := c.Param("report")
+
+	params := make(map[string]string)
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&params); err != nil {
+			return
+		}
+	}
+
+	job, err := r.jobSvc.Submit(reportName, runId, params)
+	if errors.Is(err, services.ErrQueueFull) {
+		c.Header("Retry-After", strconv.Itoa(REPORT_JOB_RETRY_AFTER))
+		c.JSON(http.StatusServiceUnavailable, fmt.Sprintf("Unable to submit report job for run[%d]! Details => %s", runId, err.Error()))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error submitting report job for run[%d]! Details => %s", runId, err.Error()))
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/jobs/%d", job.ID))
+	c.JSON(http.StatusAccepted, gin.H{
+		"job": job,
+	})
+}
+
+func (r *jobRoutes) getJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"jobs": r.jobSvc.GetJobs(),
+	})
+}
+
+func (r *jobRoutes) getJob(c *gin.Context) {
+	job, found := r.lookupJob(c)
+	if found {
+		c.JSON(http.StatusOK, gin.H{
+			"job": job,
+		})
+	}
+}
+
+func (r *jobRoutes) getJobArtifact(c *gin.Context) {
+	job, found := r.lookupJob(c)
+	if !found {
+		return
+	}
+
+	if job.Status != services.JOB_COMPLETED {
+		c.JSON(http.StatusConflict, fmt.Sprintf("Job [%d] is %s! Artifact is only available once the job has completed", job.ID, job.Status))
+		return
+	}
+
+	c.FileAttachment(job.Artifact, filepath.Base(job.Artifact))
+}
+
+func (r *jobRoutes) lookupJob(c *gin.Context) (services.ReportJob, bool) {
+	id, _ := strconv.ParseUint(c.Param("job"), 10, 64)
+
+	job, found := r.jobSvc.GetJob(uint(id))
+	if !found {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Job [%s] does not exist", c.Param("job")))
+	}
+
+	return job, found
+}
@@ -0,0 +1,78 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes_test
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"csa-app/backend/routes"
+	"csa-app/backend/services"
+	"csa-app/db/test_support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportJobRoutes(t *testing.T) {
+
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	router := routes.SetupRouter(database, false)
+
+	req, _ := http.NewRequest("POST", "/api/runs/1/reports/unknown", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req, _ = http.NewRequest("GET", "/api/jobs/42", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest("GET", "/api/jobs", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "jobs")
+}
+
+//fullQueue is a job service whose queue is always full
+type fullQueue struct {
+	submitted int
+}
+
+func (q *fullQueue) Submit(reportName string, runId uint, params map[string]string) (services.ReportJob, error) {
+	q.submitted++
+	return services.ReportJob{}, services.ErrQueueFull
+}
+
+func (q *fullQueue) GetJob(id uint) (services.ReportJob, bool) {
+	return services.ReportJob{}, false
+}
+
+func (q *fullQueue) GetJobs() []services.ReportJob {
+	return nil
+}
+
+func TestReportJobQueueFull(t *testing.T) {
+
+	jobSvc := &fullQueue{}
+	router := routes.JobRouter(jobSvc)
+
+	req, _ := http.NewRequest("POST", "/api/runs/1/reports/findings", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 1, jobSvc.submitted)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Empty(t, w.Header().Get("Location"))
+}
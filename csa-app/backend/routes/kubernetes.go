@@ -0,0 +1,37 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type kubernetesRoutes struct {
+	findingsRepo db.FindingRepository
+}
+
+//getKubernetesManifests returns the issues of the run's Kubernetes manifests, only those of the app when one is given
+func (r *kubernetesRoutes) getKubernetesManifests(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	manifests, err := report.KubernetesManifestReports(r.findingsRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error reporting kubernetes manifests for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"kubernetes": manifests,
+		})
+	}
+}
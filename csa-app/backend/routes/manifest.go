@@ -0,0 +1,50 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type manifestRoutes struct {
+	manifestRepo db.ManifestRepository
+}
+
+//getManifest returns the files scanned by the run as json or, with format=csv, as a downloadable csv file
+func (r *manifestRoutes) getManifest(c *gin.Context) {
+	runId := getId(c)
+
+	entries, err := r.manifestRepo.GetManifest(runId, c.Query("app"))
+	if CheckForError(c, err, "Error retrieving scan manifest! Details => %s") {
+		return
+	}
+
+	if c.Query("format") != util.CSV {
+		c.JSON(http.StatusOK, gin.H{
+			"manifest": entries,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"run-%d-manifest.csv\"", runId))
+	c.Header("Content-Type", "text/csv")
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write([]string{"application", "path", "size", "sha256", "language", "rules applied", "third-party", "inaccessible"})
+	for _, entry := range entries {
+		_ = writer.Write([]string{entry.Application, entry.Path, fmt.Sprint(entry.Size), entry.Sha256, entry.Language,
+			fmt.Sprint(entry.RulesApplied), entry.ThirdParty, entry.Inaccessible})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,35 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type moduleRoutes struct {
+	repositories *db.Repositories
+}
+
+//getModuleScores returns the scores of the modules of the run's multi-module applications, only those of the app
+//when the route names one
+func (r *moduleRoutes) getModuleScores(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+
+	scores, err := report.ScoreRunModules(r.repositories, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error scoring the modules of run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"modules": scores,
+		})
+	}
+}
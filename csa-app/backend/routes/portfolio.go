@@ -0,0 +1,33 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type portfolioRoutes struct {
+	repositories *db.Repositories
+}
+
+//getPortfolio returns the ranking, totals, quartiles and findings by tag of all the run's applications
+func (r *portfolioRoutes) getPortfolio(c *gin.Context) {
+	runId := getId(c)
+
+	portfolio, err := report.BuildPortfolio(r.repositories, runId)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error building the portfolio of run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"portfolio": portfolio,
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type presentationRoutes struct {
+	findingsRepo db.FindingRepository
+	runRepo      db.RunRepository
+	slocRepo     db.SlocRepository
+}
+
+//getPresentationTier returns the presentation tier of the run's applications, only that of the app when one is given
+func (r *presentationRoutes) getPresentationTier(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	tiers, err := report.PresentationTierReports(r.findingsRepo, r.runRepo, r.slocRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error reporting presentation tier for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"presentationTier": tiers,
+		})
+	}
+}
@@ -8,12 +8,15 @@ package routes
 import (
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"csa-app/backend/services"
 	"csa-app/db"
 	"net/http"
+	"strings"
 )
 
 type ruleRoutes struct {
 	rulesRepo db.RuleRepository
+	ruleSvc   services.RuleService
 }
 
 func (r *ruleRoutes) getAllRules(c *gin.Context) {
@@ -35,3 +38,48 @@ func (r *ruleRoutes) getMetrics(c *gin.Context) {
 		})
 	}
 }
+
+//saveRules creates or updates the rules of the posted rule file (yaml, or json when the content type is json)
+func (r *ruleRoutes) saveRules(c *gin.Context) {
+	content, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error reading rules! Details => %s", err.Error()))
+		return
+	}
+
+	saved, errs := r.ruleSvc.SaveRules(content, strings.Contains(c.ContentType(), "json"))
+	if len(saved) == 0 && len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": errs,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules":  saved,
+		"errors": errs,
+		"status": r.ruleSvc.Status(),
+	})
+}
+
+func (r *ruleRoutes) deleteRule(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := r.ruleSvc.DeleteRule(name); err != nil {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Error deleting rule [%s]! Details => %s", name, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": r.ruleSvc.Status(),
+	})
+}
+
+//reloadRules imports the rules of the rules-dir
+func (r *ruleRoutes) reloadRules(c *gin.Context) {
+	c.JSON(http.StatusOK, r.ruleSvc.ReloadRules())
+}
+
+func (r *ruleRoutes) getRulesStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, r.ruleSvc.Status())
+}
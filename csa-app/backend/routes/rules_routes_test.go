@@ -6,10 +6,12 @@
 package routes_test
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"csa-app/backend/routes"
@@ -32,3 +34,50 @@ func TestRulesRouter(t *testing.T) {
 
 	assert.Equal(t, 200, w.Code)
 }
+
+func TestRulesHotReload(t *testing.T) {
+
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	router := routes.SetupRouter(database, false)
+
+	send := func(method string, url string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-yaml")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	rule := `name: hot-reload-jndi
+filetype: java$
+target: line
+type: regex
+effort: 3
+patterns:
+  - value: InitialContext
+`
+	w := send("PUT", "/api/rules", rule)
+	assert.Equal(t, 200, w.Code, w.Body.String())
+
+	var status struct {
+		Version uint     `json:"version"`
+		Changed []string `json:"changed"`
+	}
+	w = send("GET", "/api/rules/status", "")
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, uint(1), status.Version)
+	assert.Equal(t, []string{"hot-reload-jndi"}, status.Changed)
+
+	w = send("PUT", "/api/rules", strings.Replace(rule, "target: line", "target: nowhere", 1))
+	assert.Equal(t, 400, w.Code, "invalid rules are rejected")
+
+	w = send("DELETE", "/api/rules/hot-reload-jndi", "")
+	assert.Equal(t, 200, w.Code, w.Body.String())
+
+	w = send("DELETE", "/api/rules/hot-reload-jndi", "")
+	assert.Equal(t, 404, w.Code)
+}
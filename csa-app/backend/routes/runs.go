@@ -36,6 +36,9 @@ func (r *runRoutes) getApps(c *gin.Context) {
 	fmt.Printf("Getting apps for Run[%d]\n", runId)
 
 	apps, err := r.runsRepository.GetRunApps(runId)
+	if err == nil {
+		_, err = r.scoringSvc.ClassifyScores(apps)
+	}
 
 	if !CheckForError(c, err, fmt.Sprintf("Error retrieving apps for run[%d]! Details => %%s", runId)) {
 		c.JSON(http.StatusOK, gin.H{
@@ -0,0 +1,112 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type scoreBinRoutes struct {
+	scoringRepo db.ScoringRepository
+}
+
+func (r *scoreBinRoutes) getScoreBins(c *gin.Context) {
+	bins, err := r.scoringRepo.GetScoreBins()
+
+	if !CheckForError(c, err, "Error retrieving score bins! Details => %s") {
+		c.JSON(http.StatusOK, gin.H{
+			"scoreBins": bins,
+		})
+	}
+}
+
+func (r *scoreBinRoutes) createScoreBin(c *gin.Context) {
+	var bin model.ScoreBin
+	if err := c.BindJSON(&bin); err != nil {
+		return
+	}
+
+	bins, err := r.scoringRepo.GetScoreBins()
+	if CheckForError(c, err, "Error retrieving score bins! Details => %s") {
+		return
+	}
+	for _, existing := range bins {
+		if strings.EqualFold(existing.Name, bin.Name) {
+			c.JSON(http.StatusConflict, fmt.Sprintf("Score bin [%s] already exists!", existing.Name))
+			return
+		}
+	}
+
+	if err := r.scoringRepo.SaveScoreBin(&bin); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error saving score bin [%s]! Details => %s", bin.Name, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"scoreBin": bin,
+	})
+}
+
+//updateScoreBin replaces the bin named in the path. Renaming is allowed as long as the new name is free.
+func (r *scoreBinRoutes) updateScoreBin(c *gin.Context) {
+	name := c.Param("name")
+
+	var bin model.ScoreBin
+	if err := c.BindJSON(&bin); err != nil {
+		return
+	}
+
+	bins, err := r.scoringRepo.GetScoreBins()
+	if CheckForError(c, err, "Error retrieving score bins! Details => %s") {
+		return
+	}
+
+	var existing *model.ScoreBin
+	for i := range bins {
+		if strings.EqualFold(bins[i].Name, name) {
+			existing = &bins[i]
+		} else if strings.EqualFold(bins[i].Name, bin.Name) {
+			c.JSON(http.StatusConflict, fmt.Sprintf("Score bin [%s] already exists!", bins[i].Name))
+			return
+		}
+	}
+	if existing == nil {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Score bin [%s] does not exist!", name))
+		return
+	}
+
+	if bin.Name == "" {
+		bin.Name = existing.Name
+	}
+	bin.ID = existing.ID
+
+	if err := r.scoringRepo.SaveScoreBin(&bin); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error saving score bin [%s]! Details => %s", bin.Name, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scoreBin": bin,
+	})
+}
+
+func (r *scoreBinRoutes) deleteScoreBin(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := r.scoringRepo.DeleteScoreBin(name); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error deleting score bin [%s]! Details => %s", name, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
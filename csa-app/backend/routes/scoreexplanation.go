@@ -0,0 +1,34 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type scoreExplanationRoutes struct {
+	repositories *db.Repositories
+}
+
+//getScoreExplanation returns the rules and categories contributing to the app's score with the points each costs it
+func (r *scoreExplanationRoutes) getScoreExplanation(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+
+	explanations, err := report.ExplainScores(r.repositories, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error explaining the score of app [%s] of run[%d]! Details => %%s", app, runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"explanation": explanations[0],
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+
+	"github.com/gin-gonic/gin"
+)
+
+type techStackRoutes struct {
+	techStackRepo db.TechStackRepository
+}
+
+//getTechStack returns the technologies detected in the run's applications, only those of the app when one is given
+func (r *techStackRoutes) getTechStack(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	attributes, err := r.techStackRepo.GetTechStack(runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error retrieving tech stack for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"techStack": attributes,
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type treemapRoutes struct {
+	treemapSvc *report.TreemapReportService
+}
+
+//getTreemap returns the application's directory hierarchy sized by sloc with finding totals and density
+func (r *treemapRoutes) getTreemap(c *gin.Context) {
+	runId := getId(c)
+	appName := c.Param("app")
+
+	treemap, err := r.treemapSvc.BuildTreemap(runId, appName)
+	if !CheckForError(c, err, fmt.Sprintf("Error building treemap for app [%s] of run[%d]! Details => %%s", appName, runId)) {
+		c.JSON(http.StatusOK, treemap)
+	}
+}
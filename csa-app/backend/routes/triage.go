@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type triageRoutes struct {
+	repositories *db.Repositories
+}
+
+//triageRequest triages the findings with the ids as state (suppressed|false-positive|accepted|none)
+type triageRequest struct {
+	Findings []uint `json:"findings"`
+	State    string `json:"state"`
+	Reason   string `json:"reason"`
+}
+
+//getTriage returns the run's triaged findings, suppressed ones included
+func (r *triageRoutes) getTriage(c *gin.Context) {
+	runId := getId(c)
+
+	findings, err := r.repositories.Findings.GetTriagedFindings(runId, c.Query("app"))
+
+	if !CheckForError(c, err, fmt.Sprintf("Error retrieving triaged findings for run[%d]! Details => %%s", runId)) {
+		var dtos []*model.FindingDTO
+		for i := range findings {
+			dtos = append(dtos, findings[i].CreateDTO())
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"findings": dtos,
+		})
+	}
+}
+
+//triageFindings sets the triage of the run's findings and rescores the run
+func (r *triageRoutes) triageFindings(c *gin.Context) {
+	runId := getId(c)
+
+	var request triageRequest
+	if err := c.BindJSON(&request); err != nil {
+		return
+	}
+
+	err := model.ValidateTriage(request.State)
+	if err == nil && len(request.Findings) == 0 {
+		err = fmt.Errorf("no finding to triage")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Error triaging findings for run[%d]! Details => %s", runId, err.Error()))
+		return
+	}
+
+	triaged, err := report.TriageFindings(r.repositories, runId, request.Findings, request.State, request.Reason)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error triaging findings for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"triaged": triaged,
+		})
+	}
+}
@@ -0,0 +1,38 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type twelveFactorRoutes struct {
+	findingsRepo db.FindingRepository
+	runRepo      db.RunRepository
+}
+
+//getTwelveFactor returns the twelve factor checklist of the run's applications, only that of the app when one is given
+func (r *twelveFactorRoutes) getTwelveFactor(c *gin.Context) {
+	runId := getId(c)
+	app := c.Param("app")
+	if app == "" {
+		app = c.Query("app")
+	}
+
+	reports, err := report.TwelveFactorReports(r.findingsRepo, r.runRepo, runId, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error evaluating twelve factors for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"twelveFactor": reports,
+		})
+	}
+}
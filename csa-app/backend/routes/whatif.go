@@ -0,0 +1,44 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+type whatIfRoutes struct {
+	repositories *db.Repositories
+}
+
+//getWhatIf returns the scores of the run's applications if the findings matching any of the resolve queries were
+//resolved, only those of the app when one is given. Nothing is saved.
+func (r *whatIfRoutes) getWhatIf(c *gin.Context) {
+	runId := getId(c)
+	app := c.Query("app")
+
+	queries := c.QueryArray("resolve")
+
+	if _, err := model.ParseWhatIf(queries); err != nil {
+		msg := fmt.Sprintf("{error:\"Invalid what-if request! Details: %v\"}", err)
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	scores, err := report.SimulateWhatIf(r.repositories, runId, queries, app)
+
+	if !CheckForError(c, err, fmt.Sprintf("Error simulating what-if for run[%d]! Details => %%s", runId)) {
+		c.JSON(http.StatusOK, gin.H{
+			"whatIf": scores,
+		})
+	}
+}
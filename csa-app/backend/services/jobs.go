@@ -0,0 +1,236 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"csa-app/db"
+	"csa-app/report"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const JOB_QUEUED = "queued"
+const JOB_RUNNING = "running"
+const JOB_COMPLETED = "completed"
+const JOB_FAILED = "failed"
+
+const REPORT_JOB_QUEUE_SIZE = 100
+
+//ErrQueueFull is returned when REPORT_JOB_QUEUE_SIZE jobs are waiting already. The job isn't kept, it has to be
+//submitted again later.
+var ErrQueueFull = errors.New("report job queue is full")
+
+//Finished jobs, and their artifacts, are evicted once they are older than REPORT_JOB_TTL or when more than
+//REPORT_JOB_LIMIT jobs are kept, the oldest first
+const REPORT_JOB_TTL = 24 * time.Hour
+const REPORT_JOB_LIMIT = 500
+
+const FINDINGS_REPORT_JOB = "findings"
+const ADHOC_REPORT_JOB = "adhoc"
+
+type ReportJob struct {
+	ID         uint              `json:"id"`
+	Report     string            `json:"report"`
+	RunID      uint              `json:"runId"`
+	Params     map[string]string `json:"params,omitempty"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	Artifact   string            `json:"-"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	StartedAt  *time.Time        `json:"startedAt,omitempty"`
+	FinishedAt *time.Time        `json:"finishedAt,omitempty"`
+}
+
+//JobService runs report exports in the background so server requests don't block on them
+type JobService interface {
+	Submit(reportName string, runId uint, params map[string]string) (ReportJob, error)
+	GetJob(id uint) (ReportJob, bool)
+	GetJobs() []ReportJob
+}
+
+type reportJobService struct {
+	adhocReports    *report.AdhocReportService
+	findingsReports *report.FindingsReportService
+	queue           chan *ReportJob
+	jobs            map[uint]*ReportJob
+	nextId          uint
+	sync.Mutex
+}
+
+func NewJobService(repoMgr *db.Repositories, workers int) JobService {
+	jobService := &reportJobService{
+		adhocReports:    report.NewAdhocReportService(repoMgr),
+		findingsReports: report.NewFindingsReportService(repoMgr),
+		queue:           make(chan *ReportJob, REPORT_JOB_QUEUE_SIZE),
+		jobs:            make(map[uint]*ReportJob),
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		go jobService.worker()
+	}
+
+	return jobService
+}
+
+func (jobService *reportJobService) Submit(reportName string, runId uint, params map[string]string) (ReportJob, error) {
+
+	if reportName != FINDINGS_REPORT_JOB && reportName != ADHOC_REPORT_JOB {
+		return ReportJob{}, fmt.Errorf("unknown report [%s]! Must be one of (%s|%s)", reportName, FINDINGS_REPORT_JOB, ADHOC_REPORT_JOB)
+	}
+
+	jobService.Lock()
+	defer jobService.Unlock()
+
+	jobService.evict(time.Now())
+	job := &ReportJob{ID: jobService.nextId + 1, Report: reportName, RunID: runId, Params: params, Status: JOB_QUEUED, CreatedAt: time.Now()}
+
+	//Workers wait for the lock before running the job, so it is registered before it runs
+	select {
+	case jobService.queue <- job:
+	default:
+		return ReportJob{}, ErrQueueFull
+	}
+
+	jobService.nextId = job.ID
+	jobService.jobs[job.ID] = job
+
+	return *job, nil
+}
+
+func (jobService *reportJobService) GetJob(id uint) (ReportJob, bool) {
+	jobService.Lock()
+	job, found := jobService.jobs[id]
+	jobService.Unlock()
+
+	if !found {
+		return ReportJob{}, false
+	}
+
+	return jobService.snapshot(job), true
+}
+
+func (jobService *reportJobService) GetJobs() []ReportJob {
+	jobService.Lock()
+	jobs := make([]ReportJob, 0, len(jobService.jobs))
+	for _, job := range jobService.jobs {
+		jobs = append(jobs, *job)
+	}
+	jobService.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs
+}
+
+func (jobService *reportJobService) worker() {
+	for job := range jobService.queue {
+
+		jobService.Lock()
+		started := time.Now()
+		job.Status = JOB_RUNNING
+		job.StartedAt = &started
+		jobService.Unlock()
+
+		log.Infof("Running report job [%d] (%s) for run [%d]", job.ID, job.Report, job.RunID)
+
+		artifact, err := jobService.run(job)
+		jobService.finish(job, artifact, err)
+	}
+}
+
+func (jobService *reportJobService) run(job *ReportJob) (artifact string, err error) {
+
+	//Report generation must never take the server down with it
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("report job panicked: %v", r)
+		}
+	}()
+
+	//Artifacts are named after their job, so concurrent jobs never write to (or evict) each other's files
+	switch job.Report {
+	case FINDINGS_REPORT_JOB:
+		name := fmt.Sprintf("job-%d-%s", job.ID, report.FindingsReportName(job.RunID, job.Params["app"]))
+		artifact, _, err = jobService.findingsReports.ExportFindings(job.RunID, job.Params["app"], name)
+	case ADHOC_REPORT_JOB:
+		groupBy := job.Params["group-by"]
+		if groupBy == "" {
+			groupBy = "category"
+		}
+		format := job.Params["format"]
+		if format == "" {
+			format = "csv"
+		}
+		name := fmt.Sprintf("job-%d-%s", job.ID, report.AdhocReportName(job.RunID, groupBy))
+		artifact, err = jobService.adhocReports.ExportAdhocReport(job.RunID, job.Params["query"], groupBy, format, name)
+	}
+
+	return
+}
+
+func (jobService *reportJobService) finish(job *ReportJob, artifact string, err error) {
+	jobService.Lock()
+	defer jobService.Unlock()
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Artifact = artifact
+
+	if err != nil {
+		log.Errorf("Report job [%d] failed! Details: %v", job.ID, err)
+		job.Status = JOB_FAILED
+		job.Error = err.Error()
+	} else {
+		job.Status = JOB_COMPLETED
+	}
+}
+
+//evict drops the finished jobs older than REPORT_JOB_TTL, then the oldest finished ones while more than REPORT_JOB_LIMIT
+//jobs are kept, removing their artifacts. Queued and running jobs are never evicted. Must be called holding the lock.
+func (jobService *reportJobService) evict(now time.Time) {
+
+	var finished []*ReportJob
+	for _, job := range jobService.jobs {
+		if job.FinishedAt == nil {
+			continue
+		}
+		if now.Sub(*job.FinishedAt) > REPORT_JOB_TTL {
+			jobService.remove(job)
+			continue
+		}
+		finished = append(finished, job)
+	}
+
+	sort.Slice(finished, func(i, j int) bool { return finished[i].ID < finished[j].ID })
+	for i := 0; len(jobService.jobs) >= REPORT_JOB_LIMIT && i < len(finished); i++ {
+		jobService.remove(finished[i])
+	}
+}
+
+func (jobService *reportJobService) remove(job *ReportJob) {
+	delete(jobService.jobs, job.ID)
+	if job.Artifact != "" {
+		if err := os.Remove(job.Artifact); err != nil && !os.IsNotExist(err) {
+			log.Warnf("Unable to remove artifact [%s] of report job [%d]! Details: %v", job.Artifact, job.ID, err)
+		}
+	}
+}
+
+func (jobService *reportJobService) snapshot(job *ReportJob) ReportJob {
+	jobService.Lock()
+	defer jobService.Unlock()
+	return *job
+}
@@ -0,0 +1,68 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvictReportJobs(t *testing.T) {
+
+	dir := t.TempDir()
+	now := time.Now()
+	expired := now.Add(-REPORT_JOB_TTL - time.Minute)
+	recent := now.Add(-time.Minute)
+
+	artifact := filepath.Join(dir, "job-1-12-findings.csv")
+	assert.NoError(t, os.WriteFile(artifact, []byte("id\n"), 0644))
+
+	jobService := &reportJobService{jobs: map[uint]*ReportJob{
+		1: {ID: 1, Status: JOB_COMPLETED, FinishedAt: &expired, Artifact: artifact},
+		2: {ID: 2, Status: JOB_FAILED, FinishedAt: &recent},
+		3: {ID: 3, Status: JOB_RUNNING},
+	}}
+
+	jobService.evict(now)
+
+	assert.NotContains(t, jobService.jobs, uint(1), "expired jobs are evicted")
+	assert.NoFileExists(t, artifact, "along with their artifact")
+	assert.Contains(t, jobService.jobs, uint(2))
+	assert.Contains(t, jobService.jobs, uint(3))
+
+	for id := uint(10); id < 10+REPORT_JOB_LIMIT; id++ {
+		jobService.jobs[id] = &ReportJob{ID: id, Status: JOB_COMPLETED, FinishedAt: &recent}
+	}
+
+	jobService.evict(now)
+
+	assert.Len(t, jobService.jobs, REPORT_JOB_LIMIT-1, "room is made for the next job")
+	assert.NotContains(t, jobService.jobs, uint(2), "the oldest finished jobs go first")
+	assert.Contains(t, jobService.jobs, uint(3), "unfinished jobs are kept")
+}
+
+func TestSubmitToFullQueue(t *testing.T) {
+
+	jobService := &reportJobService{queue: make(chan *ReportJob, 1), jobs: make(map[uint]*ReportJob)}
+
+	job, err := jobService.Submit(FINDINGS_REPORT_JOB, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), job.ID)
+	assert.Equal(t, JOB_QUEUED, job.Status)
+
+	_, err = jobService.Submit(ADHOC_REPORT_JOB, 1, nil)
+	assert.ErrorIs(t, err, ErrQueueFull)
+	assert.Len(t, jobService.GetJobs(), 1, "jobs that couldn't be queued aren't kept")
+
+	<-jobService.queue
+	job, err = jobService.Submit(ADHOC_REPORT_JOB, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), job.ID)
+}
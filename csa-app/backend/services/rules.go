@@ -0,0 +1,237 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//RulesStatus describes the rules last applied by the server
+type RulesStatus struct {
+	Version    uint      `json:"version"`
+	Dir        string    `json:"dir"`
+	Rules      int       `json:"rules"`
+	Changed    []string  `json:"changed"`
+	Errors     []string  `json:"errors,omitempty"`
+	ReloadedAt time.Time `json:"reloadedAt"`
+}
+
+//RuleService applies rule changes to a running server. Runs load their rules when they start, so a change applies to
+//the runs started after it while in-flight runs finish with the rules they loaded.
+type RuleService interface {
+	SaveRules(content []byte, isJson bool) ([]string, []model.RuleFileError)
+	DeleteRule(name string) error
+	ReloadRules() RulesStatus
+	Status() RulesStatus
+	WatchRules(interval time.Duration)
+}
+
+type ruleService struct {
+	rulesRepo    db.RuleRepository
+	fileUtil     *util.FileUtil
+	status       RulesStatus
+	fingerprints map[string]string
+	sync.Mutex
+}
+
+func NewRuleService(repoMgr *db.Repositories) RuleService {
+	return &ruleService{
+		rulesRepo:    repoMgr.Rules,
+		fileUtil:     util.NewFileUtil(),
+		status:       RulesStatus{Dir: model.GetRulesDir(true)},
+		fingerprints: make(map[string]string),
+	}
+}
+
+//SaveRules validates the rules of a yaml or json rule file and creates or updates them. Nothing is saved when any of
+//them is invalid.
+func (ruleSvc *ruleService) SaveRules(content []byte, isJson bool) ([]string, []model.RuleFileError) {
+
+	rules, errs := model.ValidateRuleFile(bytes.NewReader(content), isJson)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if len(rules) == 0 {
+		return nil, []model.RuleFileError{{Message: "no rules found"}}
+	}
+
+	ruleSvc.Lock()
+	defer ruleSvc.Unlock()
+
+	var names []string
+	for _, rule := range rules {
+		if err := ruleSvc.rulesRepo.UpsertRule(rule); err != nil {
+			errs = append(errs, model.RuleFileError{Message: fmt.Sprintf("rule [%s] could not be saved: %v", rule.Name, err)})
+			continue
+		}
+		names = append(names, rule.Name)
+	}
+
+	ruleSvc.rulesChanged(names, ruleFileErrors("", errs))
+
+	return names, errs
+}
+
+func (ruleSvc *ruleService) DeleteRule(name string) error {
+
+	ruleSvc.Lock()
+	defer ruleSvc.Unlock()
+
+	if err := ruleSvc.rulesRepo.DeleteRule(name); err != nil {
+		return err
+	}
+
+	ruleSvc.rulesChanged([]string{name}, nil)
+
+	return nil
+}
+
+//ReloadRules imports the rule files of the --rules-dir, like `rules import` does. Rule files that fail validation are
+//skipped and reported. Rules whose file was removed are kept; delete them explicitly.
+func (ruleSvc *ruleService) ReloadRules() RulesStatus {
+
+	ruleSvc.Lock()
+	defer ruleSvc.Unlock()
+
+	ruleSvc.reload(ruleSvc.fingerprint(), true)
+
+	return ruleSvc.status
+}
+
+func (ruleSvc *ruleService) Status() RulesStatus {
+
+	ruleSvc.Lock()
+	defer ruleSvc.Unlock()
+
+	return ruleSvc.status
+}
+
+//WatchRules polls the --rules-dir and reloads its rules when a rule file is added or modified
+func (ruleSvc *ruleService) WatchRules(interval time.Duration) {
+
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ruleSvc.Lock()
+	ruleSvc.fingerprints = ruleSvc.fingerprint()
+	ruleSvc.Unlock()
+
+	log.Infof("Watching rules-dir [%s] for rule changes every %v", model.GetRulesDir(true), interval)
+
+	for range time.Tick(interval) {
+		ruleSvc.Lock()
+		if fingerprints := ruleSvc.fingerprint(); ruleSvc.modified(fingerprints) {
+			ruleSvc.reload(fingerprints, false)
+			log.Infof("Reloaded rules-dir [%s] (version %d): [%d] rule(s) changed, [%d] error(s)",
+				ruleSvc.status.Dir, ruleSvc.status.Version, len(ruleSvc.status.Changed), len(ruleSvc.status.Errors))
+		}
+		ruleSvc.Unlock()
+	}
+}
+
+//reload imports the rule files, all of them or only those modified since the last reload. Callers hold the lock.
+func (ruleSvc *ruleService) reload(fingerprints map[string]string, all bool) {
+
+	var names []string
+	var errs []string
+
+	for file, fingerprint := range fingerprints {
+
+		if !all && ruleSvc.fingerprints[file] == fingerprint {
+			continue
+		}
+
+		reader, err := os.Open(file)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", file, err))
+			continue
+		}
+		rules, fileErrs := model.ValidateRuleFile(reader, strings.HasSuffix(file, util.JSON))
+		reader.Close()
+
+		if len(fileErrs) > 0 {
+			//Keep the rules of the previous version of the file until it is fixed
+			errs = append(errs, ruleFileErrors(file, fileErrs)...)
+			continue
+		}
+
+		for _, rule := range rules {
+			if err := ruleSvc.rulesRepo.UpsertRule(rule); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: rule [%s] could not be saved: %v", file, rule.Name, err))
+				continue
+			}
+			names = append(names, rule.Name)
+		}
+	}
+
+	ruleSvc.fingerprints = fingerprints
+	ruleSvc.rulesChanged(names, errs)
+}
+
+//rulesChanged bumps the rules version and drops the compiled patterns of the previous version. Callers hold the lock.
+func (ruleSvc *ruleService) rulesChanged(names []string, errs []string) {
+
+	util.ResetRegexCache()
+
+	ruleSvc.status.Version++
+	ruleSvc.status.Changed = names
+	ruleSvc.status.Errors = errs
+	ruleSvc.status.ReloadedAt = time.Now()
+
+	if rules, err := ruleSvc.rulesRepo.GetRules(); err == nil {
+		ruleSvc.status.Rules = len(rules)
+	}
+}
+
+//fingerprint maps each rule file of the --rules-dir to its size and modification time
+func (ruleSvc *ruleService) fingerprint() map[string]string {
+
+	fingerprints := make(map[string]string)
+	for _, file := range ruleSvc.fileUtil.GetFileList(model.GetRulesDir(true), "(json|yaml|yml)") {
+		if model.IsRuleTestFile(file.Name) {
+			continue
+		}
+		if info, err := os.Stat(file.FQN); err == nil {
+			fingerprints[file.FQN] = fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+		}
+	}
+
+	return fingerprints
+}
+
+func (ruleSvc *ruleService) modified(fingerprints map[string]string) bool {
+	for file, fingerprint := range fingerprints {
+		if ruleSvc.fingerprints[file] != fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+//ruleFileErrors formats rule file errors the way `rules validate` prints them
+func ruleFileErrors(file string, errs []model.RuleFileError) (messages []string) {
+	for _, err := range errs {
+		if file == "" {
+			messages = append(messages, err.Error())
+		} else {
+			messages = append(messages, fmt.Sprintf("%s:%d:%d: %s", file, err.Line, err.Column, err.Message))
+		}
+	}
+	return messages
+}
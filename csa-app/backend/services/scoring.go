@@ -15,43 +15,91 @@ import (
 
 type ScoringService interface {
 	GetPortfolioScore(runId uint, scoreModel *model.ScoringModel) (*model.PortfolioScore, error)
+	ClassifyScores(apps []model.Application) ([]model.ScoreBin, error)
 }
 
 func (svc *RepoService) GetPortfolioScore(runId uint, scoreModel *model.ScoringModel) (*model.PortfolioScore, error) {
 	portfolioScore := &model.PortfolioScore{}
 
+	run, err := svc.repositoryMgr.Run.GetRun(runId)
+	if err != nil {
+		return portfolioScore, err
+	}
+
+	//Rescore with the scorer the run was scored with, percentiles depend on every app of the run
+	scorer, err := model.RunScorer(&run)
+	if err != nil {
+		return portfolioScore, err
+	}
+
 	//Get Applications from DB
 	apps, err := svc.repositoryMgr.Run.GetRunApps(runId)
+	if err != nil {
+		return portfolioScore, err
+	}
 
-	var lastmodel string
+	models := make(map[string]*model.ScoringModel)
+	var rescored []*model.Application
 
-	if err == nil {
-		for _, app := range apps {
-			if !app.ScoreModified {
-				if app.OriginalScore == -1 {
-					app.OriginalScore = app.Score
-				}
-				//Quasi Rescore App based on front-end request...doesn't change the raw score. Just how the final normalize score looks.
-				//This does not update the Normalized score in the DB which is calculated using the standard 1 min 10 max reverse = false.
-				log.Debugf("App [%s] has current score [%v] rawScore [%d]\n", app.Name, app.Score, app.RawScore)
-
-				if scoreModel == nil {
-					var sm *model.ScoringModel
-					if lastmodel == "" || lastmodel != app.ScoringModel || sm == nil {
-						sm, err = svc.repositoryMgr.Scoring.GetModelByName(app.ScoringModel)
-						if err != nil {
-							return portfolioScore, fmt.Errorf("unable to retrieve scoring model [%s] for app [%s]. details: %s", app.ScoringModel, app.Name, err.Error())
-						}
-						lastmodel = app.ScoringModel
+	for i := range apps {
+		app := &apps[i]
+		if !app.ScoreModified {
+			if app.OriginalScore == -1 {
+				app.OriginalScore = app.Score
+			}
+			//Quasi Rescore App based on front-end request...doesn't change the raw score. Just how the final normalize score looks.
+			//This does not update the Normalized score in the DB which is calculated using the standard 1 min 10 max reverse = false.
+			log.Debugf("App [%s] has current score [%v] rawScore [%d]\n", app.Name, app.Score, app.RawScore)
+
+			app.Model = scoreModel
+			if scoreModel == nil {
+				if _, found := models[app.ScoringModel]; !found {
+					models[app.ScoringModel], err = svc.repositoryMgr.Scoring.GetModelByName(app.ScoringModel)
+					if err != nil {
+						return portfolioScore, fmt.Errorf("unable to retrieve scoring model [%s] for app [%s]. details: %s", app.ScoringModel, app.Name, err.Error())
 					}
-					err = app.CalculateScore(sm)
-				} else {
-					err = app.CalculateScore(scoreModel)
 				}
+				app.Model = models[app.ScoringModel]
 			}
-			portfolioScore.AddApplicationScore(app)
+			rescored = append(rescored, app)
+		}
+	}
+
+	if run.Scorer == model.FORMULA_SCORER {
+		tagTotals, err := svc.repositoryMgr.Findings.GetAppTagTotals(runId)
+		if err != nil {
+			return portfolioScore, err
+		}
+		for _, app := range rescored {
+			app.TagTotals = tagTotals[app.Name]
 		}
 	}
 
+	err = scorer.Score(rescored)
+
+	bins, binErr := svc.ClassifyScores(apps)
+	if binErr != nil {
+		return portfolioScore, binErr
+	}
+	portfolioScore.ScoreBins = bins
+
+	for _, app := range apps {
+		portfolioScore.AddApplicationScore(app)
+	}
+
 	return portfolioScore, err
 }
+
+//ClassifyScores sets the score bin (tier) of each application, returning the bins
+func (svc *RepoService) ClassifyScores(apps []model.Application) ([]model.ScoreBin, error) {
+	bins, err := svc.repositoryMgr.Scoring.GetScoreBins()
+	if err != nil {
+		return bins, fmt.Errorf("unable to retrieve score bins. details: %s", err.Error())
+	}
+
+	for i := range apps {
+		apps[i].ScoreBin = model.ScoreBinFor(bins, apps[i].Score)
+	}
+
+	return bins, nil
+}
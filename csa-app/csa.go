@@ -80,18 +80,29 @@ func main() {
 
 	debug.SetMaxThreads(100000)
 
-	util.App.HelpFlag.Short('h')
-	util.App.Version(Version)
-	util.App.Author("Steve Woods (VMware)")
+	util.App.SetVersion(Version)
 
 	var run = model.NewRun()
 
+	//Completion scripts are sourced by the shell so nothing else may be written to std out
+	if run.Command == util.CompletionCmd.FullCommand() {
+		run.Cleanup()
+		util.PrintCompletionScript(*util.CompletionShell)
+	}
+
+	defer util.InitTracing(Version)()
+
 	procsAndThreads()
 
+	switch run.Command {
+	case util.AnalyzeCmd.FullCommand(), util.CsaCmd.FullCommand(), util.TestRulesCmd.FullCommand():
+		run.OpenWorkspace()
+	}
+
 	run.DB = db.OpenDB(run)
 	defer run.Cleanup()
 
-	switch *util.Profile {
+	switch *util.PerfProfile {
 	case "cpu":
 		defer profile.Start().Stop()
 	case "mem":
@@ -116,6 +127,17 @@ func main() {
 		reportService := report.NewReportSvc(repoMgr)
 		reportService.ListReports(util.ReportType)
 		os.Exit(0)
+	case util.ListReportsCmd.FullCommand():
+		reportService := report.NewReportSvc(repoMgr)
+		reportService.ListReports(util.ListReportType)
+		os.Exit(0)
+	case util.DbInfoCmd.FullCommand():
+		headers, data, err := db.DatabaseInfo(run)
+		if err != nil {
+			util.App.Fatalf("Unable to describe the database! Details: %v\n", err)
+		}
+		report.NewReportSvc(repoMgr).DisplayReport(headers, data, "CSA Database", false)
+		os.Exit(0)
 	case util.ExportRulesCmd.FullCommand():
 		repoMgr.Rules.ExportRules()
 		os.Exit(0)
@@ -129,7 +151,82 @@ func main() {
 		repoMgr.Rules.DeleteAllRules()
 		os.Exit(0)
 	case util.ValidateRuleCmd.FullCommand():
-		repoMgr.Rules.ValidateRule(*util.ValidateRuleName, run)
+		if !repoMgr.Rules.ValidateRule(*util.ValidateRuleName, run) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case util.RuleSchemaCmd.FullCommand():
+		fmt.Print(model.RULE_SCHEMA)
+		os.Exit(0)
+	case util.RuleProfilesCmd.FullCommand():
+		profiles, err := model.AvailableProfiles(*util.ProfilesDir)
+		if err != nil {
+			util.App.Fatalf("Unable to load rule profiles! Details: %v\n", err)
+		}
+		headers := []string{"name", "extends", "scoring-model", "excluded rules/tags", "weights", "description"}
+		var data [][]string
+		for _, profile := range profiles {
+			data = append(data, profile.Row())
+		}
+		report.NewReportSvc(repoMgr).DisplayReport(headers, data, "Rule Profiles", false)
+		os.Exit(0)
+	case util.I18nExtractCmd.FullCommand():
+		if err := csa.NewCsaSvc(repoMgr).ExtractAdvice(); err != nil {
+			util.App.Fatalf("Unable to extract rule advice! Details: %v\n", err)
+		}
+		os.Exit(0)
+	case util.I18nStatusCmd.FullCommand():
+		if err := csa.NewCsaSvc(repoMgr).AdviceStatus(); err != nil {
+			util.App.Fatalf("Unable to determine rule advice translations! Details: %v\n", err)
+		}
+		os.Exit(0)
+	case util.I18nContributeCmd.FullCommand():
+		added := 0
+		contribution, err := model.ReadAdviceCatalog(*util.I18nContribution)
+		if err == nil {
+			added, err = csa.NewCsaSvc(repoMgr).ContributeAdvice(contribution)
+		}
+		if err != nil {
+			util.App.Fatalf("Unable to contribute rule advice! Details: %v\n", err)
+		}
+		fmt.Printf("Added [%d] [%s] translations to [%s]\n", added, contribution.Locale, model.AdviceCatalogFile(*util.I18nDir, contribution.Locale))
+		os.Exit(0)
+	case util.DiffUpstreamCmd.FullCommand():
+		if !csa.NewCsaSvc(repoMgr).DiffUpstreamRules(*util.DiffUpstreamPack) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case util.ListTagsCmd.FullCommand():
+		report.NewTagService(repoMgr).ListTags(*util.TagProblemsOnly)
+		os.Exit(0)
+	case util.CreateTagCmd.FullCommand():
+		report.NewTagService(repoMgr).CreateTag(*util.CreateTagName, *util.TagDescription)
+		os.Exit(0)
+	case util.RenameTagCmd.FullCommand():
+		report.NewTagService(repoMgr).RenameTag(*util.RenameTagFrom, *util.RenameTagTo)
+		os.Exit(0)
+	case util.MergeTagsCmd.FullCommand():
+		report.NewTagService(repoMgr).MergeTags(*util.MergeTagsFrom, *util.MergeTagsInto)
+		os.Exit(0)
+	case util.RetagCmd.FullCommand():
+		report.NewTagService(repoMgr).RetagFindings(*util.RetagRunId)
+		os.Exit(0)
+	case util.LintRulesCmd.FullCommand():
+		if !csa.NewCsaSvc(repoMgr).LintRules(*util.LintRulesPath, *util.LintDoubleCounts) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case util.TestRulesCmd.FullCommand():
+		path := *util.TestRulesPath
+		if path == "" {
+			path = model.GetRulesDir(true)
+		}
+		passed := csa.NewCsaSvc(repoMgr).TestRules(path, run)
+		//Inline fixtures live in the workspace which deferred cleanup won't get to
+		run.Cleanup()
+		if !passed {
+			os.Exit(1)
+		}
 		os.Exit(0)
 	case util.ExportModelsCmd.FullCommand():
 		repoMgr.Scoring.ExportModels()
@@ -146,6 +243,39 @@ func main() {
 	case util.ValidateModelCmd.FullCommand():
 		repoMgr.Scoring.ValidateModel(*util.ValidateModelName, run)
 		os.Exit(0)
+	case util.ScoreCmd.FullCommand():
+		report.NewScoreService(repoMgr).Rescore(*util.ScoreRunId, *util.ScoreScorer, *util.ScoreFormula, *util.ScoreExpression, *util.ScoreNormalize, *util.ScoreSimulate)
+		os.Exit(0)
+	case util.WhatIfCmd.FullCommand():
+		report.NewWhatIfService(repoMgr).RunWhatIf(*util.WhatIfRunId, *util.WhatIfResolve, *util.WhatIfApp, *util.WhatIfFormat)
+		os.Exit(0)
+	case util.ListScoreBinsCmd.FullCommand():
+		report.NewScoreBinService(repoMgr).ListScoreBins()
+		os.Exit(0)
+	case util.CreateScoreBinCmd.FullCommand():
+		report.NewScoreBinService(repoMgr).CreateScoreBin(*util.CreateScoreBinName, *util.CreateScoreBinMin, *util.CreateScoreBinMax, *util.CreateScoreBinColor)
+		os.Exit(0)
+	case util.UpdateScoreBinCmd.FullCommand():
+		report.NewScoreBinService(repoMgr).UpdateScoreBin(*util.UpdateScoreBinName, *util.UpdateScoreBinMin, *util.UpdateScoreBinMax, *util.UpdateScoreBinColor)
+		os.Exit(0)
+	case util.DeleteScoreBinCmd.FullCommand():
+		report.NewScoreBinService(repoMgr).DeleteScoreBin(*util.DeleteScoreBinName)
+		os.Exit(0)
+	case util.SetTriageCmd.FullCommand():
+		report.NewTriageService(repoMgr).SetTriage(*util.SetTriageRunId, *util.SetTriageFindings, *util.SetTriageState, *util.SetTriageReason)
+		os.Exit(0)
+	case util.ListTriageCmd.FullCommand():
+		report.NewTriageService(repoMgr).ListTriage(*util.ListTriageRunId, *util.ListTriageApp, *util.ListTriageFormat)
+		os.Exit(0)
+	case util.RecordOutcomeCmd.FullCommand():
+		report.NewCalibrationService(repoMgr).RecordOutcome(*util.RecordOutcomeRunId, *util.RecordOutcomeApp, *util.RecordOutcomeDays, *util.RecordOutcomeNote)
+		os.Exit(0)
+	case util.ListOutcomesCmd.FullCommand():
+		report.NewCalibrationService(repoMgr).ListOutcomes(*util.ListOutcomesModel, *util.ListOutcomesFormat)
+		os.Exit(0)
+	case util.CalibrateOutcomesCmd.FullCommand():
+		report.NewCalibrationService(repoMgr).Calibrate(*util.CalibrateOutcomesModel, *util.CalibrateOutcomesFormat)
+		os.Exit(0)
 	case util.ExportBinsCmd.FullCommand():
 		repoMgr.Bins.ExportBins()
 		os.Exit(0)
@@ -159,6 +289,24 @@ func main() {
 	case util.DeleteAllBinsCmd.FullCommand():
 		repoMgr.Bins.DeleteAllBins(true)
 		os.Exit(0)
+	case util.ListGroupsCmd.FullCommand():
+		report.NewGroupService(repoMgr).ListGroups()
+		os.Exit(0)
+	case util.CreateGroupCmd.FullCommand():
+		report.NewGroupService(repoMgr).CreateGroup(*util.CreateGroupName, *util.CreateGroupType, *util.CreateGroupParent)
+		os.Exit(0)
+	case util.DeleteGroupCmd.FullCommand():
+		report.NewGroupService(repoMgr).DeleteGroup(*util.DeleteGroupName)
+		os.Exit(0)
+	case util.AddGroupAppCmd.FullCommand():
+		report.NewGroupService(repoMgr).AddApplication(*util.AddGroupAppGroup, *util.AddGroupAppName)
+		os.Exit(0)
+	case util.RemoveGroupAppCmd.FullCommand():
+		report.NewGroupService(repoMgr).RemoveApplication(*util.RemoveGroupAppGroup, *util.RemoveGroupAppName)
+		os.Exit(0)
+	case util.GroupReportCmd.FullCommand():
+		report.NewGroupService(repoMgr).RollupReport(*util.GroupReportRunId)
+		os.Exit(0)
 	case util.GitCmd.FullCommand():
 		run.SetPaths(*util.GitPath)
 		fmt.Printf("\nTarget of Git Forensics => %s\n\n", run.Target)
@@ -168,15 +316,173 @@ func main() {
 		gitReportService.RunGitReports(run)
 	case util.AnalyzeCmd.FullCommand():
 		run.SetPaths(*util.Path)
+		if *util.ExplainRule != "" {
+			explained := csa.NewCsaSvc(repoMgr).ExplainRule(*util.ExplainRule, *util.Path, run)
+			run.Cleanup()
+			if !explained {
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
 		run.SetRequestedReports(util.ReportsFlag, "1,2,3,4,5")
 		run.ValidateRun()
 		csaService := csa.NewCsaSvc(repoMgr)
 		csaService.PerformAnalysis(run)
+		gate := model.NewQualityGate(*util.FailBelow, *util.FailOnTags)
+		if gate.Enabled() && !*util.WriteConfigsOnly && !csaService.EvaluateGate(run, gate) {
+			run.Cleanup()
+			os.Exit(util.GATE_FAILED_EXIT_CODE)
+		}
 	case util.NatLangCmd.FullCommand():
 		run.SetPaths(*util.NatLangPath)
 		run.ValidateRun()
 		naturalLanguageService := natural.NewNaturalLanguageService(repoMgr.Run)
 		naturalLanguageService.LangProcess(run)
+	case util.AdhocReportCmd.FullCommand():
+		adminMode = true
+		adhocReportService := report.NewAdhocReportService(repoMgr)
+		adhocReportService.RunAdhocReport(*util.AdhocQuery, *util.AdhocGroupBy, *util.AdhocFormat, *util.AdhocRunId)
+	case util.CompareReportCmd.FullCommand():
+		adminMode = true
+		baselineDB, err := db.OpenSqliteDB(*util.CompareDbPath)
+		if err != nil {
+			util.App.Fatalf("Unable to open baseline database [%s]! Details: %v", *util.CompareDbPath, err)
+		}
+		defer baselineDB.Close()
+		compareReportService := report.NewCompareReportService(repoMgr, db.NewRepositoriesManager(baselineDB))
+		compareReportService.RunComparison(*util.CompareRunId, *util.CompareBaseRunId, *util.CompareFormat)
+	case util.FindingsReportCmd.FullCommand():
+		adminMode = true
+		findingsReportService := report.NewFindingsReportService(repoMgr)
+		findingsReportService.RunFindingsReport(*util.FindingsReportRunId, *util.FindingsReportApp)
+	case util.ThirdPartyReportCmd.FullCommand():
+		adminMode = true
+		thirdPartyReportService := report.NewThirdPartyReportService(repoMgr)
+		thirdPartyReportService.RunThirdPartyReport(*util.ThirdPartyReportRunId)
+	case util.ResolvedReportCmd.FullCommand():
+		adminMode = true
+		resolvedReportService := report.NewResolvedReportService(repoMgr)
+		resolvedReportService.RunResolvedReport(*util.ResolvedReportRunId, *util.ResolvedReportApp, *util.ResolvedReportFormat)
+	case util.TreemapReportCmd.FullCommand():
+		adminMode = true
+		treemapReportService := report.NewTreemapReportService(repoMgr)
+		treemapReportService.RunTreemapReport(*util.TreemapReportRunId, *util.TreemapReportApp)
+	case util.CoverageReportCmd.FullCommand():
+		adminMode = true
+		coverageReportService := report.NewCoverageReportService(repoMgr)
+		coverageReportService.RunCoverageReport(*util.CoverageReportRunId, *util.CoverageReportFormat, *util.CoverageReportUnmatched)
+	case util.PatchReportCmd.FullCommand():
+		adminMode = true
+		patchReportService := report.NewPatchReportService(repoMgr)
+		patchReportService.RunPatchReport(*util.PatchReportRunId, *util.PatchReportApp)
+	case util.SlowRulesReportCmd.FullCommand():
+		adminMode = true
+		slowRulesReportService := report.NewSlowRulesReportService(repoMgr)
+		slowRulesReportService.RunSlowRulesReport(*util.SlowRulesReportRunId, *util.SlowRulesReportTop, *util.SlowRulesReportFormat)
+	case util.DomainReportCmd.FullCommand():
+		adminMode = true
+		domainReportService := report.NewDomainReportService(repoMgr)
+		domainReportService.RunDomainReport(*util.DomainReportRunId, *util.DomainReportMapping, *util.DomainReportFormat)
+	case util.TechStackReportCmd.FullCommand():
+		adminMode = true
+		techStackReportService := report.NewTechStackReportService(repoMgr)
+		techStackReportService.RunTechStackReport(*util.TechStackReportRunId, *util.TechStackReportApp, *util.TechStackReportFormat)
+	case util.TwelveFactorReportCmd.FullCommand():
+		adminMode = true
+		twelveFactorReportService := report.NewTwelveFactorReportService(repoMgr)
+		twelveFactorReportService.RunTwelveFactorReport(*util.TwelveFactorReportRunId, *util.TwelveFactorReportApp, *util.TwelveFactorReportFormat)
+	case util.ContainerReportCmd.FullCommand():
+		adminMode = true
+		containerReportService := report.NewContainerReportService(repoMgr)
+		containerReportService.RunContainerReport(*util.ContainerReportRunId, *util.ContainerReportApp, *util.ContainerReportFormat)
+	case util.DotnetReportCmd.FullCommand():
+		adminMode = true
+		dotnetReportService := report.NewDotnetReportService(repoMgr)
+		dotnetReportService.RunDotnetReport(*util.DotnetReportRunId, *util.DotnetReportApp, *util.DotnetReportFormat)
+	case util.WindowsReportCmd.FullCommand():
+		adminMode = true
+		appReportService := report.NewAppReportService(repoMgr)
+		appReportService.RunAppReport(report.WindowsReport, *util.WindowsReportRunId, *util.WindowsReportApp, *util.WindowsReportFormat)
+	case util.DatabaseReportCmd.FullCommand():
+		adminMode = true
+		databaseReportService := report.NewDatabaseReportService(repoMgr)
+		databaseReportService.RunDatabaseReport(*util.DatabaseReportRunId, *util.DatabaseReportApp, *util.DatabaseReportFormat)
+	case util.DatabaseInventoryReportCmd.FullCommand():
+		adminMode = true
+		databaseReportService := report.NewDatabaseReportService(repoMgr)
+		databaseReportService.RunDatabaseInventoryReport(*util.DatabaseInventoryReportRunId, *util.DatabaseInventoryReportApp, *util.DatabaseInventoryReportFormat)
+	case util.KubernetesReportCmd.FullCommand():
+		adminMode = true
+		kubernetesReportService := report.NewKubernetesReportService(repoMgr)
+		kubernetesReportService.RunKubernetesReport(*util.KubernetesReportRunId, *util.KubernetesReportApp, *util.KubernetesReportFormat)
+	case util.AppServerReportCmd.FullCommand():
+		adminMode = true
+		appServerReportService := report.NewAppServerReportService(repoMgr)
+		appServerReportService.RunAppServerReport(*util.AppServerReportRunId, *util.AppServerReportApp, *util.AppServerReportFormat)
+	case util.ConfigReportCmd.FullCommand():
+		adminMode = true
+		configReportService := report.NewConfigReportService(repoMgr)
+		configReportService.RunConfigReport(*util.ConfigReportRunId, *util.ConfigReportApp, *util.ConfigReportFormat)
+	case util.PresentationReportCmd.FullCommand():
+		adminMode = true
+		presentationReportService := report.NewPresentationReportService(repoMgr)
+		presentationReportService.RunPresentationReport(*util.PresentationReportRunId, *util.PresentationReportApp, *util.PresentationReportFormat)
+	case util.MessagingReportCmd.FullCommand():
+		adminMode = true
+		messagingReportService := report.NewMessagingReportService(repoMgr)
+		messagingReportService.RunMessagingReport(*util.MessagingReportRunId, *util.MessagingReportApp, *util.MessagingReportFormat)
+	case util.BatchReportCmd.FullCommand():
+		adminMode = true
+		appReportService := report.NewAppReportService(repoMgr)
+		appReportService.RunAppReport(report.BatchReport, *util.BatchReportRunId, *util.BatchReportApp, *util.BatchReportFormat)
+	case util.NativeReportCmd.FullCommand():
+		adminMode = true
+		appReportService := report.NewAppReportService(repoMgr)
+		appReportService.RunAppReport(report.NativeReport, *util.NativeReportRunId, *util.NativeReportApp, *util.NativeReportFormat)
+	case util.SbomReportCmd.FullCommand():
+		adminMode = true
+		sbomReportService := report.NewSbomReportService(repoMgr)
+		sbomReportService.RunSbomReport(*util.SbomReportRunId, *util.SbomReportApp, *util.SbomReportLicenseDB, *util.SbomReportMavenRepo)
+	case util.EstimateReportCmd.FullCommand():
+		adminMode = true
+		estimateReportService := report.NewEstimateReportService(repoMgr)
+		estimateReportService.RunEstimateReport(*util.EstimateReportRunId, *util.EstimateReportApp, *util.EstimateReportModel, *util.EstimateReportFormat)
+	case util.DuplicatesReportCmd.FullCommand():
+		adminMode = true
+		duplicatesReportService := report.NewDuplicatesReportService(repoMgr)
+		duplicatesReportService.RunDuplicatesReport(*util.DuplicatesReportRunId, *util.DuplicatesReportMinApps, *util.DuplicatesReportFormat)
+	case util.DependencyReportCmd.FullCommand():
+		adminMode = true
+		dependencyReportService := report.NewDependencyReportService(repoMgr)
+		dependencyReportService.RunDependencyReport(*util.DependencyReportRunId, *util.DependencyReportFormat)
+	case util.SecurityReportCmd.FullCommand():
+		adminMode = true
+		securityReportService := report.NewSecurityReportService(repoMgr)
+		securityReportService.RunSecurityReport(*util.SecurityReportRunId, *util.SecurityReportApp, *util.SecurityReportFormat)
+	case util.JdkReportCmd.FullCommand():
+		adminMode = true
+		jdkReportService := report.NewJdkReportService(repoMgr)
+		jdkReportService.RunJdkReport(*util.JdkReportRunId, *util.JdkReportApp, *util.JdkReportFormat)
+	case util.DispositionReportCmd.FullCommand():
+		adminMode = true
+		dispositionReportService := report.NewDispositionReportService(repoMgr)
+		dispositionReportService.RunDispositionReport(*util.DispositionReportRunId, *util.DispositionReportApp, *util.DispositionReportRules, *util.DispositionReportFormat)
+	case util.ModuleReportCmd.FullCommand():
+		adminMode = true
+		moduleReportService := report.NewModuleReportService(repoMgr)
+		moduleReportService.RunModuleReport(*util.ModuleReportRunId, *util.ModuleReportApp, *util.ModuleReportFormat)
+	case util.PortfolioReportCmd.FullCommand():
+		adminMode = true
+		portfolioReportService := report.NewPortfolioReportService(repoMgr)
+		portfolioReportService.RunPortfolioReport(*util.PortfolioReportRunId, *util.PortfolioReportTop, *util.PortfolioReportFormat)
+	case util.ExplainReportCmd.FullCommand():
+		adminMode = true
+		explanationReportService := report.NewScoreExplanationReportService(repoMgr)
+		explanationReportService.RunScoreExplanationReport(*util.ExplainReportRunId, *util.ExplainReportApp, *util.ExplainReportTop, *util.ExplainReportFormat)
+	case util.PlanReportCmd.FullCommand():
+		adminMode = true
+		planReportService := report.NewPlanReportService(repoMgr)
+		planReportService.RunPlanReport(*util.PlanReportRunId, *util.PlanReportApp, *util.PlanReportTemplate, *util.PlanReportFormat, *util.PlanReportOwners)
 	case util.CsaCmd.FullCommand():
 		adminMode = true
 		port := util.CsaPort
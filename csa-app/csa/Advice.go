@@ -0,0 +1,133 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//applyLocale loads the advice catalog of the requested locale. Findings then record the translated advice.
+func (csaService *CsaService) applyLocale(run *model.Run) {
+
+	if *util.Locale == "" || *util.Locale == model.SOURCE_LOCALE {
+		return
+	}
+
+	catalog, err := model.LoadAdviceCatalog(*util.I18nDir, *util.Locale)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Rule advice will not be localized! Details: %v\n", err)
+		return
+	}
+
+	run.Advice = catalog
+	fmt.Printf("Using [%s] rule advice\n", catalog.Locale)
+}
+
+//sourceAdvice is the advice of the rules in the database, which translations are made from
+func (csaService *CsaService) sourceAdvice() (*model.AdviceCatalog, error) {
+
+	rules, err := csaService.ruleRepository.GetRules()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve rules: %v", err)
+	}
+
+	return model.ExtractAdvice(rules), nil
+}
+
+//ExtractAdvice writes the source catalog to the i18n dir
+func (csaService *CsaService) ExtractAdvice() error {
+
+	source, err := csaService.sourceAdvice()
+	if err != nil {
+		return err
+	}
+
+	if err = source.Save(*util.I18nDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote the advice of [%d] rules to [%s]\n", len(source.Rules), model.AdviceCatalogFile(*util.I18nDir, source.Locale))
+
+	return nil
+}
+
+//AdviceStatus lists the translation coverage of every locale with a catalog
+func (csaService *CsaService) AdviceStatus() error {
+
+	coverage, err := csaService.AdviceCoverage()
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"locale", "translated", "total", "coverage"}
+	var data [][]string
+	for _, locale := range coverage {
+		data = append(data, []string{locale.Locale, fmt.Sprint(locale.Translated), fmt.Sprint(locale.Total), fmt.Sprintf("%.2f%%", locale.Percent)})
+	}
+
+	csaService.reportService.DisplayReport(headers, data, "Rule Advice Translations", false)
+
+	return nil
+}
+
+func (csaService *CsaService) AdviceCoverage() ([]model.LocaleCoverage, error) {
+
+	source, err := csaService.sourceAdvice()
+	if err != nil {
+		return nil, err
+	}
+
+	var coverage []model.LocaleCoverage
+	for _, locale := range model.AvailableLocales(*util.I18nDir) {
+		if locale == model.SOURCE_LOCALE {
+			continue
+		}
+
+		catalog, err := model.LoadAdviceCatalog(*util.I18nDir, locale)
+		if err != nil {
+			return nil, err
+		}
+		coverage = append(coverage, catalog.Coverage(source))
+	}
+
+	return coverage, nil
+}
+
+//ContributeAdvice merges translated advice into the catalog of its locale, creating the catalog if needed
+func (csaService *CsaService) ContributeAdvice(contribution *model.AdviceCatalog) (added int, err error) {
+
+	if err = model.ValidateLocale(contribution.Locale); err != nil {
+		return 0, err
+	}
+
+	if contribution.Locale == model.SOURCE_LOCALE {
+		return 0, fmt.Errorf("[%s] advice is maintained in the rules themselves", model.SOURCE_LOCALE)
+	}
+
+	source, err := csaService.sourceAdvice()
+	if err != nil {
+		return 0, err
+	}
+
+	catalog, err := model.ReadAdviceCatalog(model.AdviceCatalogFile(*util.I18nDir, contribution.Locale))
+	if os.IsNotExist(err) {
+		catalog, err = model.NewAdviceCatalog(contribution.Locale), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	catalog.Locale = contribution.Locale
+
+	if added, err = catalog.Contribute(source, contribution); err != nil {
+		return 0, err
+	}
+
+	return added, catalog.Save(*util.I18nDir)
+}
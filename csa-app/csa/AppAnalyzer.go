@@ -7,11 +7,14 @@ package csa
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"csa-app/db"
 	"csa-app/model"
@@ -24,23 +27,41 @@ func (csaService *CsaService) analyzeApp(run *model.Run, app *model.Application,
 	run.StartActivity(fmt.Sprintf("%s-analysis", app.Name))
 	waitGroup := sync.WaitGroup{}
 
+	//Findings are attributed to the module holding their file
+	app.Modules = model.DetectModules(run.ID, app)
+
+	//Copybooks are analyzed as the COBOL they hold
+	app.Copybooks = model.ResolveCopybooks(app)
+
 	util.InitializeSpinners(len(run.Applications))
 
-	modCnt := util.GetModCount(run.Files)
+	var errorsMux sync.Mutex
+	var carriedCnt int32
 
-	if *util.Verbose {
-		fmt.Printf("Got Mod Count [%d]\n", modCnt)
-	}
+	baseline := csaService.incrementalBaseline(run, app)
+	checkpoint := csaService.checkpoint(app)
 
 	for i := range app.Files {
+		idx := i
 		waitGroup.Add(1)
-		go func(idx int) {
+		csaService.filePool.Submit(func() {
 			defer waitGroup.Done()
 			if *util.Verbose {
 				util.WriteLog(fmt.Sprintf("Analyzing - %s", app.Name), "Scanning Files...   Filename: %s\n", app.Files[idx].FQN)
 			}
 
-			err := csaService.analyzeFile(run, app, app.Files[idx], csaService.saveChan)
+			var err error
+			read := false
+			switch {
+			case checkpoint != nil && checkpoint.Complete(app.Files[idx].FQN):
+				//Analyzed before the run was interrupted
+			case baseline != nil && csaService.carryForward(run, app, app.Files[idx], baseline, csaService.saveChan):
+				atomic.AddInt32(&carriedCnt, 1)
+			default:
+				err = csaService.analyzeFile(run, app, app.Files[idx], csaService.saveChan)
+				read = true
+			}
+			csaService.fileAnalyzed(app, app.Files[idx], read)
 
 			if err != nil {
 				if *util.FailFast {
@@ -48,30 +69,39 @@ func (csaService *CsaService) analyzeApp(run *model.Run, app *model.Application,
 					csaService.stopRun(run)
 					os.Exit(2)
 				} else {
+					errorsMux.Lock()
 					errors = append(errors, err)
+					errorsMux.Unlock()
 				}
 			} else {
 				run.FileAnalyzed()
-				modResult := run.AnalyzedCnt % modCnt
-				if modResult == 0 {
-					util.WriteLogWithToken("Analyzing", fmt.Sprintf("%2.f%%", float64(run.AnalyzedCnt)/float64(run.Files)*100), "Filename: %s...done\n!", app.Files[idx].FQN)
-				}
 			}
-		}(i)
+		})
 	}
 
 	waitGroup.Wait()
 
-	//Ensure we always get a percent complete message even if we have very few files in an app!
-	util.WriteLogWithToken("Analyzing", fmt.Sprintf("%2.f%%", float64(run.AnalyzedCnt)/float64(run.Files)*100), "App: %s...done\n!", app.Name)
+	if baseline != nil {
+		util.WriteLogWithToken("Incremental", " ", "App: %s...[%d] of [%d] files unchanged since Run [%d]. Their findings were carried forward",
+			app.Name, carriedCnt, len(app.Files), baseline.RunID)
+	}
 
-	run.StopActivity(fmt.Sprintf("%s-analysis", app.Name), fmt.Sprintf("Analyzing - %s...done!", app.Name), true)
+	run.StopActivity(fmt.Sprintf("%s-analysis", app.Name), fmt.Sprintf("Analyzing - %s...done!", app.Name), !*util.Quiet)
 
 	return
 }
 
 func (csaService *CsaService) analyzeFile(run *model.Run, app *model.Application, file *util.FileInfo, output chan<- interface{}) error {
 
+	//Encrypted files are skipped rather than failing the run
+	if reason := util.InaccessibleReason(file.FQN); reason != "" {
+		if *util.Verbose {
+			util.WriteLog("Analyzing", "Skipping file [%s]: %s\n", file.FQN, reason)
+		}
+		csaService.recordInaccessibleFile(run, app, file, reason, output)
+		return nil
+	}
+
 	var rulesForFile []model.Rule
 	var rulesUsed []string
 
@@ -80,9 +110,29 @@ func (csaService *CsaService) analyzeFile(run *model.Run, app *model.Application
 	fileNameAnalyzed := false
 	hasContentRules := false
 
+	//Loaded at most once and only when a rule has a content exclusion
+	var contents []byte
+	loadContents := func() []byte {
+		if contents == nil {
+			contents, _ = ioutil.ReadFile(file.FQN)
+		}
+		return contents
+	}
+
 	for i := range app.Rules {
 
-		if app.Rules[i].Applies(file.GetCleanedExt(), file.Name) {
+		//Composite rules are evaluated once every file has been analyzed
+		if app.Rules[i].IsComposite() {
+			continue
+		}
+
+		if app.Rules[i].Applies(file.GetCleanedExt(), file.Name) && app.Rules[i].AppliesToPath(app.Path, file.FQN) {
+			if app.Rules[i].ExcludedFrom(file.FQN, loadContents) {
+				if *util.Verbose {
+					util.WriteLog("Analyzing", "Rule [%s] is excluded from file [%s|%s|%s]\n", app.Rules[i].Name, file.Name, file.Ext, file.FQN)
+				}
+				continue
+			}
 			rulesUsed = append(rulesUsed, app.Rules[i].Name)
 			//Rule applies to this file!
 			if *util.Verbose {
@@ -91,7 +141,7 @@ func (csaService *CsaService) analyzeFile(run *model.Run, app *model.Application
 			if app.Rules[i].Target == model.LINE_TARGET {
 				rulesForFile = append(rulesForFile, app.Rules[i])
 				wasAnalyzed = true
-			} else if app.Rules[i].Target == model.CONTENTS_TARGET {
+			} else if app.Rules[i].Target == model.CONTENTS_TARGET || app.Rules[i].Target == model.MULTILINE_TARGET {
 				rulesForFile = append(rulesForFile, app.Rules[i])
 				hasContentRules = true
 				wasAnalyzed = true
@@ -115,6 +165,7 @@ func (csaService *CsaService) analyzeFile(run *model.Run, app *model.Application
 	}
 
 	findings += fileFindings
+	sent := findings
 
 	if wasAnalyzed || fileNameAnalyzed {
 		var msg string
@@ -156,8 +207,11 @@ func (csaService *CsaService) analyzeFile(run *model.Run, app *model.Application
 		output <- fileFinding
 
 		run.AddFindings(1)
+		sent++
 	}
 
+	csaService.recordManifestEntry(run, app, file, len(rulesUsed), sent, output)
+
 	if *util.Verbose {
 		util.WriteLog("Analyzing", "************ FILE [%s] FINDINGS [%d] ***************\n", file.Name, findings)
 	}
@@ -174,38 +228,78 @@ func (csaService *CsaService) saveFindingToDb(id string, work <-chan interface{}
 	run := args[0].(*model.Run)
 	run.StartActivity("saving")
 
+	//Findings are saved in batches. Batches and checkpoints are committed every checkpoint interval, so an
+	//interrupted run can be resumed
 	tx := db.StartGormTransaction()
-	defer tx.Commit()
+	committed := time.Now()
 
-	for w := range work {
-		target := w.(model.Finding)
+	var findings []*model.Finding
+	var entries []*model.ManifestEntry
 
-		if db.SaveFindingTransacted(tx, &target) {
-			csaService.findingsSaved++
-			if *util.TxtIndexingEnabled {
-				jointWorker <- target
-			}
+	flush := func() {
+		if len(findings) > 0 {
+			if err := db.SaveFindingsTransacted(tx, findings); err != nil {
+				util.TrackError("Saving", fmt.Errorf("error saving [%d] findings of file [%s]...: %v", len(findings), findings[0].Fqn, err))
 
-			if csaService.analysisDone {
-				modCnt := util.GetModCount(run.Findings)
-				if csaService.findingsSaved%modCnt == 0 {
-					util.WriteLogWithToken("Saving", fmt.Sprintf("%2.f%%", float64(csaService.findingsSaved)/float64(run.Findings)*100), "Finding: %d saved\n!", target.ID)
+				if *util.FailFast {
+					tx.Rollback()
+					fmt.Println("Error saving finding during run! Fail Fast Enabled! Stopping Run!")
+					util.WriteLog("Saving...failed!", "Failed saving findings of file [%s]", findings[0].Fqn)
+					csaService.stopRun(run)
+					os.Exit(2)
+				}
+			} else {
+				csaService.findingsSaved += len(findings)
+				for _, target := range findings {
+					if csaService.findingStream != nil {
+						csaService.findingStream.Write(target)
+					}
+					if *util.TxtIndexingEnabled {
+						jointWorker <- *target
+					}
 				}
-			}
-		} else {
 
-			util.TrackError("Saving", fmt.Errorf("error saving finding for file [%s]", target.Fqn))
+				if csaService.analysisDone {
+					util.WriteLogWithToken("Saving", fmt.Sprintf("%2.f%%", float64(csaService.findingsSaved)/float64(run.Findings)*100), "Finding: %d saved\n!", findings[len(findings)-1].ID)
+				}
+			}
+		}
 
-			if *util.FailFast {
-				tx.Rollback()
-				fmt.Println("Error saving finding during run! Fail Fast Enabled! Stopping Run!")
-				util.WriteLog("Saving...failed!", "Failed saving finding for file [%s]", target.Fqn)
-				csaService.stopRun(run)
-				os.Exit(2)
+		//Files are checkpointed after their findings
+		for _, entry := range entries {
+			if !db.SaveManifestEntryTransacted(tx, entry) {
+				util.TrackError("Saving", fmt.Errorf("error saving manifest entry for file [%s]", entry.Path))
 			}
 		}
 
+		findings, entries = nil, nil
 	}
+
+	for w := range work {
+		switch target := w.(type) {
+		case *model.ManifestEntry:
+			entries = append(entries, target)
+		case model.Finding:
+			findings = append(findings, &target)
+		}
+
+		if len(findings) >= util.SaveBatchSize() {
+			flush()
+		}
+
+		if time.Since(committed) >= time.Duration(*util.CheckpointInterval)*time.Second {
+			flush()
+			if err := tx.Commit().Error; err != nil {
+				util.TrackError("Saving", fmt.Errorf("error committing checkpoint: %v", err))
+			}
+			tx = db.StartGormTransaction()
+			committed = time.Now()
+		}
+	}
+
+	flush()
+	tx.Commit()
+
 	//We are done saving!
 	result <- true
 }
@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+
+	"csa-app/model"
+)
+
+//detectAppServerResources reads the datasources, JNDI bindings, work managers and security realms of each
+//application's WebSphere, WebLogic and JBoss descriptors and persists them, recording a finding for each so they
+//weigh in the application's effort and score
+func (csaService *CsaService) detectAppServerResources(run *model.Run) {
+
+	var resources []*model.AppServerResource
+	for _, app := range run.Applications {
+		resources = append(resources, model.DetectAppServerResources(run.ID, app, model.AppServerExtractors)...)
+	}
+
+	if len(resources) == 0 {
+		return
+	}
+
+	run.StartActivity("app-server")
+
+	msg := fmt.Sprintf("App Server Resources [%d]...done!", len(resources))
+
+	if err := csaService.runRepository.SaveAppServerResources(resources); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Saving app server resources failed! Details: %v\n", err)
+		msg = "App Server Resources...failed!"
+	}
+
+	apps := make(map[string]*model.Application)
+	for _, app := range run.Applications {
+		apps[app.Name] = app
+	}
+
+	for _, resource := range resources {
+		finding := resource.Finding()
+		finding.Module = apps[resource.Application].ModuleOf(finding.Fqn)
+		if err := csaService.findingRepository.SaveFinding(&finding); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Saving app server finding [%s] failed! Details: %v\n", finding.Value, err)
+			msg = "App Server Resources...failed!"
+			continue
+		}
+		run.AddFindings(1)
+	}
+
+	run.StopActivityLF("app-server", msg, false, true)
+}
@@ -0,0 +1,119 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//evaluateCompositeRules records a finding wherever a composite rule's condition holds. It runs once every finding of
+//the (single-pattern) rules the conditions reference has been saved.
+func (csaService *CsaService) evaluateCompositeRules(run *model.Run) {
+
+	run.StartActivity("composite")
+
+	msg := "Composite Rules...done!"
+
+	for _, app := range run.Applications {
+		if err := csaService.evaluateAppCompositeRules(run, app); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Evaluating composite rules for App [%s] failed! Details: %v\n", app.Name, err)
+			msg = "Composite Rules...failed!"
+		}
+	}
+
+	run.StopActivityLF("composite", msg, false, true)
+}
+
+func (csaService *CsaService) evaluateAppCompositeRules(run *model.Run, app *model.Application) error {
+
+	var composites []*model.Rule
+	for i := range app.Rules {
+		if app.Rules[i].IsComposite() {
+			composites = append(composites, &app.Rules[i])
+		}
+	}
+
+	if len(composites) == 0 {
+		return nil
+	}
+
+	findings, err := csaService.findingRepository.GetAppFindings(run.ID, app.Name)
+	if err != nil {
+		return err
+	}
+
+	appMatched := make(map[string]bool)
+	fileMatched := make(map[string]map[string]bool)
+	matched := func(fqn string, rule string) {
+		appMatched[rule] = true
+		if fileMatched[fqn] == nil {
+			fileMatched[fqn] = make(map[string]bool)
+		}
+		fileMatched[fqn][rule] = true
+	}
+
+	for _, finding := range findings {
+		matched(finding.Fqn, finding.Rule)
+	}
+
+	output := make(chan interface{})
+	collected := make(chan []model.Finding)
+
+	go func() {
+		var composite []model.Finding
+		for item := range output {
+			if finding, ok := item.(model.Finding); ok {
+				composite = append(composite, finding)
+			}
+		}
+		collected <- composite
+	}()
+
+	for _, rule := range composites {
+		pattern := model.Pattern{Value: rule.Condition}
+
+		if rule.Target == model.APPLICATION_TARGET {
+			if rule.ConditionMet(func(name string) bool { return appMatched[name] }) {
+				appFile := util.NewFileInfo(app.Name, app.Path, app.Name, "", app.Path, "", true)
+				csaService.handleRuleMatched(run, app, appFile, 0, rule.Condition, *rule, pattern, output, "", nil)
+				matched(app.Path, rule.Name)
+			}
+			continue
+		}
+
+		for _, file := range app.Files {
+			if !rule.Applies(file.GetCleanedExt(), file.Name) || !rule.AppliesToPath(app.Path, file.FQN) {
+				continue
+			}
+
+			fqn := file.FQN
+			if rule.ExcludedFrom(fqn, func() []byte { data, _ := ioutil.ReadFile(fqn); return data }) {
+				continue
+			}
+
+			if rule.ConditionMet(func(name string) bool { return fileMatched[fqn][name] }) {
+				csaService.handleRuleMatched(run, app, file, 0, rule.Condition, *rule, pattern, output, "", nil)
+				matched(fqn, rule.Name)
+			}
+		}
+	}
+
+	close(output)
+
+	for _, finding := range <-collected {
+		if err = csaService.findingRepository.SaveFinding(&finding); err != nil {
+			return err
+		}
+		run.AddFindings(1)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//summarizeCopybooks reports how many of the copybooks the run's COBOL programs copy were resolved, logging those that
+//weren't as their findings are missing
+func (csaService *CsaService) summarizeCopybooks(run *model.Run) {
+
+	resolved, unresolved := 0, 0
+	for _, app := range run.Applications {
+		for _, reference := range app.Copybooks {
+			if reference.File != "" {
+				resolved++
+				continue
+			}
+			unresolved++
+			util.WriteLog("Copybooks", "Copybook [%s] copied by [%s] of App [%s] was not found!\n", reference.Copybook, reference.Program, app.Name)
+		}
+	}
+
+	if resolved+unresolved == 0 {
+		return
+	}
+
+	run.StartActivity("copybooks")
+	run.StopActivityLF("copybooks", fmt.Sprintf("Copybooks [%d resolved, %d unresolved]...done!", resolved, unresolved), false, true)
+}
@@ -8,10 +8,13 @@ package csa
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/antchfx/xmlquery"
 	"csa-app/db"
+	"csa-app/events"
 	"csa-app/model"
 	"csa-app/report"
 	"csa-app/util"
@@ -26,10 +29,17 @@ type CsaService struct {
 	reportDataRepository db.ReportDataRepository
 	slocRepository       db.SlocRepository
 	scoringRepository    db.ScoringRepository
+	manifestRepository   db.ManifestRepository
+	techStackRepository  db.TechStackRepository
+	dependencyRepository db.DependencyRepository
 	reportService        *report.ReportService
 	fileUtil             *util.FileUtil
-	saveChan             chan interface{} // = make(chan interface{}, *util.MaxBuffer)
+	saveChan             chan interface{} // = make(chan interface{}, util.FindingQueueSize())
 	indexChan            chan interface{}
+	filePool             *util.WorkerPool
+	progress             *scanProgressReporter
+	checkpoints          map[string]*model.ScanCheckpoint
+	checkpointsMux       sync.Mutex
 	saveDone             chan interface{} //  = make(chan interface{}, *util.MaxBuffer)
 	indexDone            chan interface{} //  = make(chan interface{}, *util.MaxBuffer)
 	analysisDone         bool             //= false
@@ -40,10 +50,13 @@ type CsaService struct {
 	yamlMux              sync.Mutex
 	xmlDocs              map[string](*xmlquery.Node)
 	xmlMux               sync.Mutex
+	warnedDeprecated     sync.Map
+	findingStream        *FindingStream
+	events               *events.Bus
 }
 
 func NewCsaSvc(mgr *db.Repositories) *CsaService {
-	return NewCsaService(mgr.Rules, mgr.Run, mgr.Findings, mgr.Reports, mgr.Sloc, mgr.Scoring, report.NewReportSvc(mgr))
+	return NewCsaService(mgr.Rules, mgr.Run, mgr.Findings, mgr.Reports, mgr.Sloc, mgr.Scoring, mgr.Manifest, mgr.TechStack, mgr.Dependencies, report.NewReportSvc(mgr))
 }
 
 func NewCsaService(ruleRepository db.RuleRepository,
@@ -52,6 +65,9 @@ func NewCsaService(ruleRepository db.RuleRepository,
 	reportDataRepository db.ReportDataRepository,
 	slocRepository db.SlocRepository,
 	scoringRepo db.ScoringRepository,
+	manifestRepository db.ManifestRepository,
+	techStackRepository db.TechStackRepository,
+	dependencyRepository db.DependencyRepository,
 	reportService *report.ReportService) *CsaService {
 
 	return &CsaService{
@@ -61,10 +77,13 @@ func NewCsaService(ruleRepository db.RuleRepository,
 		reportDataRepository: reportDataRepository,
 		slocRepository:       slocRepository,
 		scoringRepository:    scoringRepo,
+		manifestRepository:   manifestRepository,
+		techStackRepository:  techStackRepository,
+		dependencyRepository: dependencyRepository,
 		reportService:        reportService,
 		fileUtil:             util.NewFileUtil(),
-		saveChan:             make(chan interface{}, *util.MaxBuffer),
-		indexChan:            make(chan interface{}, *util.MaxBuffer),
+		saveChan:             make(chan interface{}, util.FindingQueueSize()),
+		indexChan:            make(chan interface{}, util.FindingQueueSize()),
 		saveDone:             make(chan interface{}),
 		indexDone:            make(chan interface{}),
 		analysisDone:         false,
@@ -79,11 +98,22 @@ func NewCsaService(ruleRepository db.RuleRepository,
 
 func (csaService *CsaService) PerformAnalysis(run *model.Run) {
 
+	csaService.openFindingStream()
+	if *util.JoinRun != 0 {
+		csaService.joinRun(run)
+		csaService.closeFindingStream()
+		return
+	}
 	csaService.startRun(run)
+	csaService.openEventBus(run)
+	csaService.publishRunStarted(run)
+	endTrace := run.StartTrace(util.ANALYZE_CMD)
 	csaService.gatherFiles(run)
 	if !util.ProcessHadErrors("gathering") {
 		if !*util.WriteConfigsOnly {
 			if len(run.Applications) > 0 {
+				csaService.distribute(run)
+				csaService.resumeCheckpoints(run)
 				saveWorkerCnt, indexWorkerCnt := csaService.startWorkers(run)
 				if *util.SerialAppAnalysis {
 					csaService.SerialAnalysis(run)
@@ -91,14 +121,30 @@ func (csaService *CsaService) PerformAnalysis(run *model.Run) {
 					csaService.concurrentAnalysis(run)
 				}
 				csaService.waitForSavingAndIndexingToComplete(run, saveWorkerCnt, indexWorkerCnt)
+				csaService.setRunStatus(run, model.RUN_ANALYZED)
+				csaService.evaluateCompositeRules(run)
 				csaService.generateSloc(run)
+				csaService.saveModules(run)
+				csaService.summarizeCopybooks(run)
+				csaService.detectDependencies(run)
+				csaService.detectTechStacks(run)
+				csaService.detectDotnetProjects(run)
+				csaService.detectAppServerResources(run)
+				csaService.trackLifecycles(run)
 				csaService.scoreApps(run)
 				csaService.generateReports(run)
 			}
 		}
 	}
 
+	run.Status = model.RUN_COMPLETE
 	csaService.stopRun(run)
+	csaService.reportInaccessibleInputs()
+	reportStalledPatterns(run)
+	endTrace()
+	csaService.closeFindingStream()
+	csaService.publishRunFinished(run)
+	csaService.closeEventBus(run)
 
 	if util.HasErrors() && *util.DisplayErrors {
 		util.DumpErrors(run.ID)
@@ -109,25 +155,69 @@ func (csaService *CsaService) PerformAnalysis(run *model.Run) {
 
 }
 
+//reportStalledPatterns lists the rules whose patterns were disabled for exceeding the regex timeout or only partially
+//matched for exceeding the regex input limit. Their findings may be incomplete.
+func reportStalledPatterns(run *model.Run) {
+
+	if stalled := util.StalledRegexes(); len(stalled) > 0 {
+		fmt.Printf("Warning: [%d] pattern(s) exceeded the regex timeout of %v and were disabled! Findings of their rules may be incomplete:\n", len(stalled), *util.RegexTimeout)
+		for _, pattern := range stalled {
+			fmt.Printf("\t%s (rules: %s)\n", pattern, strings.Join(rulesWithPattern(run, pattern), ", "))
+		}
+	}
+
+	if truncated := util.TruncatedRegexTargets(); truncated > 0 {
+		fmt.Printf("Warning: [%d] line(s)/file(s) were larger than the regex input limit of %d KB! Only their start was matched.\n", truncated, *util.RegexMaxInput)
+	}
+}
+
+func rulesWithPattern(run *model.Run, pattern string) (rules []string) {
+
+	found := make(map[string]bool)
+
+	for _, app := range run.Applications {
+		for _, rule := range app.Rules {
+			for _, p := range rule.Patterns {
+				if p.Pattern == pattern && !found[rule.Name] {
+					found[rule.Name] = true
+					rules = append(rules, rule.Name)
+				}
+			}
+		}
+	}
+
+	sort.Strings(rules)
+
+	return rules
+}
+
 func (csaService *CsaService) concurrentAnalysis(run *model.Run) {
 
 	var errors []error
+	var errorsMux sync.Mutex
 
 	run.StartActivity("analysis")
 	waitGroup := sync.WaitGroup{}
+	csaService.startFilePool()
 
 	apps := run.AppsOrdered()
 	util.InitializeSpinners(len(apps))
-	//app analysis
+	csaService.startProgress(run)
+	//app analysis, the files of all apps share the pool's workers
 	for i := range apps {
 		waitGroup.Add(1)
 		go func(idx int) {
 			defer waitGroup.Done()
-			errors = append(errors, csaService.analyzeApp(run, apps[idx], csaService.saveChan)...)
+			appErrors := csaService.analyzeApp(run, apps[idx], csaService.saveChan)
+			errorsMux.Lock()
+			errors = append(errors, appErrors...)
+			errorsMux.Unlock()
 		}(i)
 	}
 
 	waitGroup.Wait()
+	csaService.filePool.Close()
+	csaService.stopProgress()
 
 	msg := "done!"
 
@@ -149,11 +239,15 @@ func (csaService *CsaService) SerialAnalysis(run *model.Run) {
 
 	apps := run.AppsOrdered()
 	util.InitializeSpinners(1)
+	csaService.startFilePool()
+	csaService.startProgress(run)
 
 	//app analysis
 	for i := range apps {
 		errors = append(errors, csaService.analyzeApp(run, apps[i], csaService.saveChan)...)
 	}
+	csaService.filePool.Close()
+	csaService.stopProgress()
 
 	msg := "done!"
 
@@ -167,7 +261,40 @@ func (csaService *CsaService) SerialAnalysis(run *model.Run) {
 	close(csaService.saveChan)
 }
 
+//startFilePool starts the workers analyzing files. Files are queued up to twice the workers, so apps can't queue
+//more files than the workers get through
+func (csaService *CsaService) startFilePool() {
+	workers := util.AnalysisWorkers()
+	if *util.Verbose {
+		fmt.Printf("[%d] analysis workers started. Findings queue holds [%d] findings\n", workers, util.FindingQueueSize())
+	}
+	csaService.filePool = util.NewWorkerPool(workers, workers*2)
+}
+
+func (csaService *CsaService) openFindingStream() {
+	if *util.NdjsonOutput != "" {
+		stream, err := NewFindingStream(*util.NdjsonOutput, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to open ndjson findings stream [%s]! Details: %v\n", *util.NdjsonOutput, err)
+			os.Exit(1)
+		}
+		csaService.findingStream = stream
+	}
+}
+
+func (csaService *CsaService) closeFindingStream() {
+	if csaService.findingStream != nil {
+		csaService.findingStream.Close()
+	}
+}
+
 func (csaService *CsaService) startRun(run *model.Run) {
+	if *util.Resume != 0 {
+		csaService.resumeRun(run)
+		return
+	}
+
+	run.Status = model.RUN_ANALYZING
 	err := csaService.runRepository.StartRun(run)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error Starting Analysis Run! Details: %v", err)
@@ -175,6 +302,12 @@ func (csaService *CsaService) startRun(run *model.Run) {
 	}
 }
 
+func (csaService *CsaService) setRunStatus(run *model.Run, status string) {
+	if err := csaService.runRepository.SetRunStatus(run, status); err != nil {
+		fmt.Fprintf(os.Stderr, "Error Recording Run Status! Details: %v", err)
+	}
+}
+
 func (csaService *CsaService) stopRun(run *model.Run) {
 	err := csaService.runRepository.StopRun(run)
 	if err != nil {
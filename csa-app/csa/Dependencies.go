@@ -0,0 +1,43 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//detectDependencies finds the jars, endpoints, queues and databases each application provides, consumes or shares and
+//persists them, the dependency graph between the applications being built from them. The third-party libraries each
+//application declares are persisted too, those of its poms and gradle build files with the versions their parent poms
+//and BOMs resolve.
+func (csaService *CsaService) detectDependencies(run *model.Run) {
+
+	run.StartActivity("dependencies")
+
+	msg := "Dependencies...done!"
+	mavenRepo, gradleHome := model.LocalRepositories(*util.MavenRepo)
+
+	for _, app := range run.Applications {
+		interfaces := model.DetectInterfaces(run.ID, app, model.InterfaceExtractors)
+		if err := csaService.dependencyRepository.SaveAppInterfaces(interfaces); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Saving interfaces for App [%s] failed! Details: %v\n", app.Name, err)
+			msg = "Dependencies...failed!"
+		}
+
+		app.Libraries = model.DetectLibraries(run.ID, app, model.LibraryParsers)
+		app.Libraries = append(app.Libraries, model.ResolveMavenLibraries(run.ID, app, mavenRepo, gradleHome)...)
+		if err := csaService.dependencyRepository.SaveAppLibraries(app.Libraries); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Saving libraries for App [%s] failed! Details: %v\n", app.Name, err)
+			msg = "Dependencies...failed!"
+		}
+	}
+
+	run.StopActivityLF("dependencies", msg, false, true)
+}
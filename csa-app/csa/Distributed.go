@@ -0,0 +1,264 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Time between the coordinator's looks at the shards of a distributed run
+const SHARD_POLL_INTERVAL = 5 * time.Second
+
+//Applications a worker of a distributed run analyzes at once. Their files share the worker's analysis workers.
+const SHARDS_PER_WORKER = 2
+
+//distribute has the applications of the run analyzed by the workers joining it (csa analyze --join <run id>) and waits
+//for them to be done. The coordinator then restores the findings the workers saved like those of a resumed run and
+//analyzes whatever files were left, so the rest of the run (composite rules, scoring, reports) sees a single analysis.
+func (csaService *CsaService) distribute(run *model.Run) {
+
+	if !*util.Distribute {
+		return
+	}
+
+	requirePostgres("--distribute")
+	run.StartActivity("distributing")
+
+	shards, err := csaService.runRepository.GetRunShards(run.ID)
+	if err == nil && len(shards) == 0 {
+		//A resumed coordinator keeps the shards it recorded before
+		err = csaService.runRepository.SaveRunShards(model.NewRunShards(run))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to record the shards of Run [%d]! Details: %v\n", run.ID, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Run [%d] is distributed by application. Start workers, with the options of this run, with:\n\tcsa analyze --join %d\n", run.ID, run.ID)
+
+	watch := model.NewShardWatch(time.Duration(*util.ShardTimeout) * time.Second)
+	ticker := time.NewTicker(SHARD_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		shards, err = csaService.runRepository.GetRunShards(run.ID)
+		if err != nil {
+			util.TrackError("Distributing", err)
+			continue
+		}
+
+		if abandoned := watch.Abandoned(shards, time.Now()); len(abandoned) > 0 {
+			if err = csaService.runRepository.ReleaseRunShards(abandoned); err != nil {
+				util.TrackError("Distributing", err)
+			} else {
+				fmt.Printf("\n[%d] application(s) of a worker without a heartbeat for %ds were released to the other workers\n", len(abandoned), *util.ShardTimeout)
+			}
+		}
+
+		byStatus, workers := model.ShardTotals(shards)
+		util.WriteLogWithToken("Distributing", fmt.Sprintf("%d/%d apps analyzed, %d analyzing by %d worker(s)", byStatus[model.SHARD_DONE], len(shards), byStatus[model.SHARD_CLAIMED], workers),
+			"[%d] of [%d] applications analyzed\n", byStatus[model.SHARD_DONE], len(shards))
+
+		if byStatus[model.SHARD_DONE] == len(shards) {
+			break
+		}
+	}
+
+	run.StopActivityLF("distributing", "\nDistributing...done!", false, true)
+}
+
+//joinRun analyzes applications of the distributed run given by --join as one of its workers, claiming them one at a
+//time until none are left. The findings are saved to the run, which the coordinator completes.
+func (csaService *CsaService) joinRun(run *model.Run) {
+
+	requirePostgres("--join")
+
+	distributed, err := csaService.runRepository.GetRun(*util.JoinRun)
+	if err != nil || distributed.ID == 0 {
+		fmt.Fprintf(os.Stderr, "Run [%d] does not exist! Unable to join it.\n", *util.JoinRun)
+		os.Exit(1)
+	}
+
+	shards, err := csaService.runRepository.GetRunShards(distributed.ID)
+	if err != nil || len(shards) == 0 || distributed.Status != model.RUN_ANALYZING {
+		fmt.Fprintf(os.Stderr, "Run [%d] isn't a distributed run being analyzed (status [%s]) and can't be joined!\n", distributed.ID, distributed.Status)
+		os.Exit(1)
+	}
+
+	run.SetPaths(distributed.Target)
+	run.ID = distributed.ID
+	run.Alias = distributed.Alias
+	run.StartTime = time.Now()
+
+	worker := workerName()
+	fmt.Printf("Joining Run [%d] of [%s] as worker [%s]...\n", run.ID, run.Target, worker)
+
+	//The applications are found like the coordinator found them, then analyzed as they are claimed
+	csaService.gatherFiles(run)
+	if util.ProcessHadErrors("gathering") {
+		os.Exit(1)
+	}
+
+	apps := make(map[string]*model.Application)
+	for _, app := range run.Applications {
+		apps[app.Name] = app
+	}
+	for _, shard := range shards {
+		if apps[shard.Application] == nil {
+			fmt.Fprintf(os.Stderr, "Application [%s] of Run [%d] wasn't found by this worker! Give workers the options of the coordinator.\n", shard.Application, run.ID)
+			os.Exit(1)
+		}
+	}
+	run.Applications = nil
+
+	saveWorkerCnt, indexWorkerCnt := csaService.startWorkers(run)
+	run.StartActivity("analysis")
+	csaService.startFilePool()
+	csaService.startProgress(run)
+
+	heartbeat := newShardHeartbeat(csaService, worker)
+
+	var errors []error
+	var mux sync.Mutex
+	waitGroup := sync.WaitGroup{}
+
+	for i := 0; i < SHARDS_PER_WORKER; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for {
+				shard, err := csaService.runRepository.ClaimRunShard(run.ID, worker)
+				if err != nil {
+					util.TrackError("Analysis", fmt.Errorf("unable to claim an application of Run [%d]: %v", run.ID, err))
+					return
+				} else if shard == nil {
+					return
+				}
+
+				app := apps[shard.Application]
+				heartbeat.hold(shard.ID)
+				run.Lock()
+				run.Applications = append(run.Applications, app)
+				run.Unlock()
+
+				//Files an earlier worker of the application saved aren't analyzed again
+				checkpoint, err := csaService.appCheckpoint(run, app)
+				if err != nil {
+					util.TrackError("Checkpoints", fmt.Errorf("unable to restore the checkpoint of App [%s]: %v", app.Name, err))
+				} else {
+					csaService.setCheckpoint(app, checkpoint)
+				}
+
+				csaService.progress.progress.AddApp(app)
+				appErrors := csaService.analyzeApp(run, app, csaService.saveChan)
+				mux.Lock()
+				errors = append(errors, appErrors...)
+				mux.Unlock()
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+	csaService.filePool.Close()
+	csaService.stopProgress()
+
+	msg := "done!"
+	if util.ProcessHadErrors("Analysis") {
+		msg = "errors!"
+	}
+	run.StopActivityLF("analysis", fmt.Sprintf("Analyzing...%s", msg), false, true)
+
+	csaService.analysisDone = true
+	close(csaService.saveChan)
+	csaService.waitForSavingAndIndexingToComplete(run, saveWorkerCnt, indexWorkerCnt)
+
+	//Shards are done once their findings are saved
+	held := heartbeat.stop()
+	if err := csaService.runRepository.SetRunShardsDone(held, worker); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to mark the applications analyzed by worker [%s] done! Details: %v\n", worker, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Worker [%s] analyzed [%d] application(s) of Run [%d] with [%d] findings\n", worker, len(held), run.ID, run.Findings)
+
+	if util.HasErrors() && *util.DisplayErrors {
+		util.DumpErrors(run.ID)
+	}
+}
+
+//shardHeartbeat touches the shards held by the worker a few times per --shard-timeout
+type shardHeartbeat struct {
+	held []uint
+	done chan bool
+	sync.Mutex
+}
+
+func newShardHeartbeat(csaService *CsaService, worker string) *shardHeartbeat {
+
+	heartbeat := &shardHeartbeat{done: make(chan bool)}
+
+	interval := time.Duration(*util.ShardTimeout) * time.Second / 4
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				heartbeat.Lock()
+				held := append([]uint(nil), heartbeat.held...)
+				heartbeat.Unlock()
+				if err := csaService.runRepository.TouchRunShards(held, worker); err != nil {
+					util.TrackError("Heartbeat", err)
+				}
+			case <-heartbeat.done:
+				return
+			}
+		}
+	}()
+
+	return heartbeat
+}
+
+func (heartbeat *shardHeartbeat) hold(id uint) {
+	heartbeat.Lock()
+	heartbeat.held = append(heartbeat.held, id)
+	heartbeat.Unlock()
+}
+
+//stop stops the heartbeat and returns the shards held
+func (heartbeat *shardHeartbeat) stop() []uint {
+	close(heartbeat.done)
+	heartbeat.Lock()
+	defer heartbeat.Unlock()
+	return heartbeat.held
+}
+
+//workerName identifies the worker in the shards it claims
+func workerName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+//requirePostgres exits unless the database is postgres, the only one the processes of a distributed run can share
+func requirePostgres(option string) {
+	if *util.DB != util.POSTGRES {
+		fmt.Fprintf(os.Stderr, "%s requires a postgres database shared by the coordinator and its workers (--db %s)\n", option, util.POSTGRES)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,39 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+
+	"csa-app/model"
+)
+
+//detectDotnetProjects finds the .NET projects of each application with the frameworks they target and persists them,
+//the dotnet report telling what migrating them to .NET 8 takes
+func (csaService *CsaService) detectDotnetProjects(run *model.Run) {
+
+	var projects []*model.DotnetProject
+	for _, app := range run.Applications {
+		app.DotnetProjects = model.DetectDotnetProjects(run.ID, app)
+		projects = append(projects, app.DotnetProjects...)
+	}
+
+	if len(projects) == 0 {
+		return
+	}
+
+	run.StartActivity("dotnet")
+
+	msg := fmt.Sprintf(".NET Projects [%d]...done!", len(projects))
+
+	if err := csaService.runRepository.SaveDotnetProjects(projects); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Saving .NET projects failed! Details: %v\n", err)
+		msg = ".NET Projects...failed!"
+	}
+
+	run.StopActivityLF("dotnet", msg, false, true)
+}
@@ -53,55 +53,102 @@ func (csaService *CsaService) processFile(run *model.Run, app *model.Application
 		}
 
 		defer closeFile(inFile)
+		defer file.ReleaseContext()
 
-		line := 0
 		sloc := 0
-		scanner := bufio.NewScanner(inFile)
-		buf := make([]byte, run.LineBufferSize)
-		scanner.Buffer(buf, util.MAX_LINE_BUFFER_SIZE)
+		size, overlap := util.ChunkSizes()
+		reader := util.NewChunkReader(inFile, run.LineBufferSize, size, overlap)
 
 		midComment := false
 		process := true
-		contents := ""
 
-		for scanner.Scan() {
+		//Findings per rule in this file, handed to script rules
+		hits := make(map[string]int)
 
-			//Count line regardless if comment
-			line++
-			curLine := scanner.Text()
+		//Patterns of the contents rules already matched in earlier chunks of the file
+		matched := make(map[string]map[int]bool)
 
-			//Only accumulate if we have content rules to process
-			if hasContentRules {
-				contents += curLine + "\n"
-			}
+		//Files larger than the chunk size are read and matched a chunk at a time
+		for chunk := reader.Next(); chunk != nil; chunk = reader.Next() {
 
-			if redactComments {
-				curLine, process, midComment = util.HandleComments(curLine, midComment, lang)
-			}
+			file.SetContextLines(chunk.FirstLine, chunk.Lines)
+
+			for n := chunk.Owned[0]; n < chunk.Owned[1]; n++ {
+
+				//Count line regardless if comment
+				line := chunk.FirstLine + n
+				curLine := chunk.Lines[n]
 
-			if process && len(strings.TrimSpace(curLine)) > 0 {
-				sloc++
-				for i := range rules {
-					if rules[i].Target == model.LINE_TARGET {
-						findingCnt += csaService.processPatterns(run, app, file, line, curLine, rules[i], output)
-						if *util.Verbose {
-							util.WriteLog("Analyzing", "### Rule: %s Hit: %d times on File: %s Line: %d ###\n", rules[i].Name, findingCnt, file.Name, line)
+				if redactComments {
+					curLine, process, midComment = util.HandleComments(curLine, midComment, lang)
+				}
+
+				if process && len(strings.TrimSpace(curLine)) > 0 {
+					sloc++
+					for i := range rules {
+						if rules[i].Target == model.LINE_TARGET {
+							ruleHits := csaService.processPatterns(run, app, file, line, curLine, rules[i], output)
+							hits[rules[i].Name] += ruleHits
+							findingCnt += ruleHits
+							if *util.Verbose {
+								util.WriteLog("Analyzing", "### Rule: %s Hit: %d times on File: %s Line: %d ###\n", rules[i].Name, findingCnt, file.Name, line)
+							}
 						}
 					}
 				}
 			}
-		}
 
-		//Only reprocess if necessary
-		if hasContentRules {
+			//Only reprocess if necessary
+			if !hasContentRules {
+				continue
+			}
+
 			for i := range rules {
-				if rules[i].Target == model.CONTENTS_TARGET {
-					findingCnt += csaService.processPatterns(run, app, file, 0, contents, rules[i], output)
+				if rules[i].Target == model.MULTILINE_TARGET {
+					ruleHits := csaService.processMultilinePatterns(run, app, file, chunk, rules[i], output)
+					hits[rules[i].Name] += ruleHits
+					findingCnt += ruleHits
+				} else if rules[i].Target == model.CONTENTS_TARGET && !rules[i].IsScript() {
+					var ruleHits int
+					if chunk.Whole() {
+						ruleHits = csaService.processPatterns(run, app, file, 0, chunk.Text(), rules[i], output)
+					} else {
+						if matched[rules[i].Name] == nil {
+							matched[rules[i].Name] = make(map[int]bool)
+						}
+						ruleHits = csaService.processContentsChunk(run, app, file, chunk, rules[i], matched[rules[i].Name], output)
+					}
+					hits[rules[i].Name] += ruleHits
+					findingCnt += ruleHits
 					if *util.Verbose {
 						util.WriteLog("Analyzing", "### Rule: %s Hit: %d times on File: %s  ###\n", rules[i].Name, findingCnt, file.Name)
 					}
 				}
 			}
+
+			if !chunk.Final {
+				continue
+			}
+
+			//Scripts run last so they can see the findings of every other rule. They are given the file's contents, so
+			//aren't run on files read in chunks
+			if !chunk.Whole() {
+				if *util.Verbose {
+					util.WriteLog("Analyzing", "Script rules skipped on file [%s] read in chunks\n", file.FQN)
+				}
+				continue
+			}
+
+			input := &model.ScriptInput{Path: file.FQN, Name: file.Name, Ext: file.Ext, Content: chunk.Text(), Hits: hits}
+			for i := range rules {
+				if rules[i].IsScript() {
+					findingCnt += csaService.processScript(run, app, file, input, rules[i], output)
+				}
+			}
+		}
+
+		if err := reader.Err(); err != nil {
+			util.TrackError("Analysis", fmt.Errorf("failed reading file [%s]. Details: %v", file.FQN, err))
 		}
 
 		//Create an info finding for each file with SLOC info!
@@ -116,7 +163,8 @@ func (csaService *CsaService) processFile(run *model.Run, app *model.Application
 			Effort:      0,
 			Readiness:   0,
 			Criticality: "none",
-			Application: file.Dir}
+			Application: file.Dir,
+			ThirdParty:  file.ThirdParty}
 
 		fileFinding.AddTag(model.INFO_FINDING)
 		fileFinding.AddTag(model.SLOC_CATEGORY)
@@ -163,16 +211,31 @@ func (csaService *CsaService) handleRuleMatched(run *model.Run, app *model.Appli
 		criticality = pattern.Criticality
 	}
 
+	severity := rule.Severity
+	if pattern.Severity != "" {
+		severity = pattern.Severity
+	}
+
 	category := rule.Category
 	if pattern.Category != "" {
 		category = pattern.Category
 	}
 
+	confidence := rule.Confidence
+	if pattern.Confidence != "" {
+		confidence = pattern.Confidence
+	}
+	confidence = model.FindingConfidence(confidence, target, rule.Target == model.LINE_TARGET && finding == nil)
+
 	note := ""
 	if !matchHasImpact {
 		note = fmt.Sprintf("Original effort [%d] of finding was squashed by rule impact setting of [%s]", effort, rule.Impact)
 		effort = 0
 		readiness = 0
+	} else if model.ConfidenceRank(confidence) < model.ConfidenceRank(*util.MinConfidence) {
+		note = fmt.Sprintf("Original effort [%d] of finding was squashed by its [%s] confidence, below the minimum confidence of [%s]", effort, confidence, *util.MinConfidence)
+		effort = 0
+		readiness = 0
 	}
 
 	data := model.Finding{
@@ -189,7 +252,10 @@ func (csaService *CsaService) handleRuleMatched(run *model.Run, app *model.Appli
 		Result:      result,
 		Readiness:   readiness,
 		Criticality: criticality,
-		Application: file.Dir}
+		Severity:    strings.ToLower(severity),
+		Confidence:  confidence,
+		Application: file.Dir,
+		ThirdParty:  file.ThirdParty}
 
 	if finding != nil {
 		data.Filename = finding.Filename
@@ -197,11 +263,21 @@ func (csaService *CsaService) handleRuleMatched(run *model.Run, app *model.Appli
 		data.Ext = finding.Ext
 		data.Advice = finding.Advice
 		data.Line = finding.Line
+		data.EndLine = finding.EndLine
 		data.Value = finding.Value
+		data.Context = finding.Context
+		data.LineSha = finding.LineSha
 	} else {
 		data.SetValue(target)
+		data.Context = file.Context(line, *util.ContextLines)
 	}
 
+	if data.LineSha == "" {
+		data.LineSha = model.HashLine(target)
+	}
+
+	data.Module = app.ModuleOf(data.Fqn)
+
 	if data.Advice == "" {
 		data.Advice = rule.Advice
 
@@ -230,6 +306,9 @@ func (csaService *CsaService) handleRuleMatched(run *model.Run, app *model.Appli
 		data.AddRecipe(pattern.Recipe)
 	}
 
+	//Weighted once the finding carries all its tags
+	run.Weights.Apply(&data)
+
 	//Send finding to save worker
 	output <- data
 }
@@ -296,49 +375,12 @@ func (csaService *CsaService) processPatterns(run *model.Run, app *model.Applica
 			csaService.RunPlugin(run, app, file, line, target, rule, rule.Patterns[i], output)
 			// RunPlugin(run, app, file, line, target, rule, pattern, output, Value, file.FQN)
 		} else {
-			matchFunc := func() (bool, string) {
-				return rule.Patterns[i].Match(target)
-			}
-
-			if rule.Patterns[i].Type == model.XPATH_MATCH_TYPE {
-				csaService.xmlMux.Lock()
-
-				if csaService.xmlDocs[file.FQN] == nil {
-					if rawData, err := ioutil.ReadFile(file.FQN); err == nil {
-						if xml, err := xmlquery.Parse(bytes.NewReader(rawData)); err == nil {
-							csaService.xmlDocs[file.FQN] = xml
-						}
-					}
-				}
-
-				csaService.xmlMux.Unlock()
-
-				matchFunc = func() (bool, string) {
-					return rule.Patterns[i].MatchXml(csaService.xmlDocs[file.FQN])
-				}
-			} else if rule.Patterns[i].Type == model.YAMLPATH_MATCH_TYPE {
-				csaService.yamlMux.Lock()
-
-				if csaService.yamlDocs[file.FQN] == nil {
-					if rawData, err := ioutil.ReadFile(file.FQN); err == nil {
-						var node yaml.Node
-						err = yaml.Unmarshal(rawData, &node)
-
-						if err == nil {
-							csaService.yamlDocs[file.FQN] = &node
-						}
-					}
-				}
-
-				csaService.yamlMux.Unlock()
-
-				matchFunc = func() (bool, string) {
-					return rule.Patterns[i].MatchYaml(csaService.yamlDocs[file.FQN])
-				}
-			}
+			matchFunc := csaService.patternMatcher(file, target, &rule.Patterns[i])
 
 			// if value, ok := path.String(root); ok
+			matchStart := time.Now()
 			ok, result := matchFunc()
+			rule.Metric.AccumulatePattern(rule.Patterns[i].Value, time.Since(matchStart))
 			if ok && !rule.Negative {
 				if len(result) > 0 {
 					target = regexp.MustCompile(`\r?\n`).ReplaceAllString(result, " ")
@@ -367,6 +409,191 @@ func (csaService *CsaService) processPatterns(run *model.Run, app *model.Applica
 	return findings
 }
 
+//processContentsChunk matches the patterns of a contents rule against a chunk of a file read in chunks. A pattern is
+//matched once per file like against the whole contents: patterns already matched in earlier chunks are skipped and
+//negative patterns are only recorded after the final chunk. XPath, YAML path and plugin patterns are evaluated once,
+//against the final chunk, as they read the file themselves.
+func (csaService *CsaService) processContentsChunk(run *model.Run, app *model.Application, file *util.FileInfo, chunk *util.LineChunk, rule model.Rule, matched map[int]bool, output chan<- interface{}) int {
+
+	findings := 0
+
+	start := time.Now()
+
+	cnt := int64(0)
+	pcnt := int64(0)
+
+	for i := range rule.Patterns {
+		if matched[i] {
+			continue
+		}
+
+		switch rule.Patterns[i].Type {
+		case model.PLUGIN_MATCH_TYPE:
+			if chunk.Final {
+				csaService.RunPlugin(run, app, file, 0, chunk.Text(), rule, rule.Patterns[i], output)
+				matched[i] = true
+				pcnt++
+			}
+			continue
+		case model.XPATH_MATCH_TYPE, model.YAMLPATH_MATCH_TYPE:
+			if !chunk.Final {
+				continue
+			}
+		}
+
+		target := chunk.Text()
+		matchStart := time.Now()
+		ok, result := csaService.patternMatcher(file, target, &rule.Patterns[i])()
+		rule.Metric.AccumulatePattern(rule.Patterns[i].Value, time.Since(matchStart))
+		pcnt++
+
+		if ok {
+			matched[i] = true
+			if !rule.Negative {
+				if len(result) > 0 {
+					target = regexp.MustCompile(`\r?\n`).ReplaceAllString(result, " ")
+				}
+
+				csaService.handleRuleMatched(run, app, file, 0, target, rule, rule.Patterns[i], output, result, nil)
+
+				findings++
+				cnt++
+			}
+		} else if chunk.Final && rule.Negative {
+			csaService.handleRuleMatched(run, app, file, 0, target, rule, rule.Patterns[i], output, "", nil)
+
+			findings++
+			cnt++
+		}
+	}
+
+	run.AddFindings(findings)
+	rule.Metric.Accumulate(pcnt, cnt, time.Since(start))
+
+	return findings
+}
+
+//patternMatcher returns the match of the pattern against the target, or against the file's document for XPath and
+//YAML path patterns
+func (csaService *CsaService) patternMatcher(file *util.FileInfo, target string, pattern *model.Pattern) func() (bool, string) {
+	matchFunc := func() (bool, string) {
+		return pattern.Match(target)
+	}
+
+	if pattern.Type == model.XPATH_MATCH_TYPE {
+		csaService.xmlMux.Lock()
+
+		if csaService.xmlDocs[file.FQN] == nil {
+			if rawData, err := ioutil.ReadFile(file.FQN); err == nil {
+				if xml, err := xmlquery.Parse(bytes.NewReader(rawData)); err == nil {
+					csaService.xmlDocs[file.FQN] = xml
+				}
+			}
+		}
+
+		csaService.xmlMux.Unlock()
+
+		matchFunc = func() (bool, string) {
+			return pattern.MatchXml(csaService.xmlDocs[file.FQN])
+		}
+	} else if pattern.Type == model.YAMLPATH_MATCH_TYPE {
+		csaService.yamlMux.Lock()
+
+		if csaService.yamlDocs[file.FQN] == nil {
+			if rawData, err := ioutil.ReadFile(file.FQN); err == nil {
+				var node yaml.Node
+				err = yaml.Unmarshal(rawData, &node)
+
+				if err == nil {
+					csaService.yamlDocs[file.FQN] = &node
+				}
+			}
+		}
+
+		csaService.yamlMux.Unlock()
+
+		matchFunc = func() (bool, string) {
+			return pattern.MatchYaml(csaService.yamlDocs[file.FQN])
+		}
+	}
+
+	return matchFunc
+}
+
+//processMultilinePatterns records a finding for every match of the rule's patterns in the chunk of the file contents
+//starting on a line the chunk owns, each spanning the lines from where the match starts to where it ends
+func (csaService *CsaService) processMultilinePatterns(run *model.Run, app *model.Application, file *util.FileInfo, chunk *util.LineChunk, rule model.Rule, output chan<- interface{}) int {
+
+	findings := 0
+
+	start := time.Now()
+
+	cnt := int64(0)
+	pcnt := int64(0)
+
+	for i := range rule.Patterns {
+		matchStart := time.Now()
+		matches := rule.Patterns[i].MatchRanges(chunk.Text())
+		rule.Metric.AccumulatePattern(rule.Patterns[i].Value, time.Since(matchStart))
+
+		for _, match := range matches {
+			//Matches starting in the overlap with another chunk are that chunk's
+			match.Line += chunk.FirstLine - 1
+			match.EndLine += chunk.FirstLine - 1
+			if !chunk.Owns(match.Line) {
+				continue
+			}
+
+			value := regexp.MustCompile(`\r?\n\s*`).ReplaceAllString(match.Value, " ")
+
+			matched := &model.Finding{Filename: file.Name, Fqn: file.FQN, Ext: file.Ext, Line: match.Line, EndLine: match.EndLine,
+				Context: file.Context(match.Line, *util.ContextLines)}
+			matched.SetValue(value)
+
+			csaService.handleRuleMatched(run, app, file, match.Line, value, rule, rule.Patterns[i], output, "", matched)
+
+			findings++
+			cnt++
+		}
+
+		pcnt++
+	}
+
+	run.AddFindings(findings)
+	rule.Metric.Accumulate(pcnt, cnt, time.Since(start))
+
+	return findings
+}
+
+func (csaService *CsaService) processScript(run *model.Run, app *model.Application, file *util.FileInfo, input *model.ScriptInput, rule model.Rule, output chan<- interface{}) int {
+
+	start := time.Now()
+
+	result, err := rule.RunScript(input)
+	if err != nil {
+		util.TrackError("Analysis", fmt.Errorf("script rule [%s] failed on file [%s]. Details: %v", rule.Name, file.FQN, err))
+		if *util.Verbose {
+			_, _ = fmt.Fprintf(os.Stderr, "Script rule [%s] failed on file [%s]. Details: %s\n", rule.Name, file.FQN, err.Error())
+		}
+		rule.Metric.Accumulate(1, 0, time.Since(start))
+		return 0
+	}
+
+	findings := 0
+	if result.Matched != rule.Negative {
+		//The script's effort is handed over as the pattern's so the usual impact handling applies
+		pattern := model.Pattern{Value: rule.Script, Effort: result.Effort}
+		csaService.handleRuleMatched(run, app, file, 0, result.Value, rule, pattern, output, result.Value, nil)
+		input.Hits[rule.Name]++
+		findings++
+	}
+
+	run.AddFindings(findings)
+	rule.Metric.Accumulate(1, int64(findings), time.Since(start))
+
+	return findings
+}
+
 func (csaService *CsaService) scoreApps(run *model.Run) {
 
 	run.StartActivity("scoring")
@@ -378,19 +605,32 @@ func (csaService *CsaService) scoreApps(run *model.Run) {
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Unabled to obtain application scores! Details: %v\n", err)
 	} else {
+		var scored []*model.Application
 		for i := range run.Applications {
 			for _, details := range appDetails {
 				if run.Applications[i].Name == details.Application {
 					run.Applications[i].MergeDetails(details)
-					err = run.Applications[i].CalculateScore(nil)
+					scored = append(scored, run.Applications[i])
 					break
 				}
 			}
+		}
 
-			if err != nil {
-				_, _ = fmt.Fprintf(os.Stderr, "Scoring App [%s] failed! Details: %s\n", run.Applications[i].Name, err.Error())
-				failed = true
-			}
+		var tagTotals map[string]model.TagTotals
+		if tagTotals, err = csaService.findingRepository.GetAppTagTotals(run.ID); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Unabled to obtain application tag totals! Details: %v\n", err)
+		}
+		for _, app := range scored {
+			app.TagTotals = tagTotals[app.Name]
+			//Container readiness is scored apart from the cloud score, whatever the scorer
+			app.ContainerScore = model.EvaluateContainerReadiness(app.Name, app.TagTotals, model.ContainerConcerns).Score
+		}
+
+		//The scorer was validated when the run started
+		scorer, _ := model.RunScorer(run)
+		if err = scorer.Score(scored); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Scoring with the [%s] scorer failed! Details: %s\n", scorer.Name(), err.Error())
+			failed = true
 		}
 	}
 
@@ -414,12 +654,146 @@ func (csaService *CsaService) getRules(run *model.Run, config *model.Application
 	//Include overrules anything
 	if config.RuleIncludeTags != "" {
 		fmt.Printf("Using only rules with tags [%s]\n", config.RuleIncludeTags)
-		return csaService.ruleRepository.GetRulesForRunRestricted(run, strings.Split(config.RuleIncludeTags, ","), false)
+		rules, err = csaService.ruleRepository.GetRulesForRunRestricted(run, strings.Split(config.RuleIncludeTags, ","), false)
 	} else if config.RuleExcludeTags != "" {
 		fmt.Printf("Using only rules without tags [%s]\n", config.RuleExcludeTags)
-		return csaService.ruleRepository.GetRulesForRunRestricted(run, strings.Split(config.RuleExcludeTags, ","), true)
+		rules, err = csaService.ruleRepository.GetRulesForRunRestricted(run, strings.Split(config.RuleExcludeTags, ","), true)
+	} else {
+		rules, err = csaService.ruleRepository.GetRulesForRun(run)
+	}
+
+	if err == nil && run.TargetJdk > 0 {
+		rules = append(rules, csaService.jdkRules(run, rules)...)
+	}
+
+	if err == nil && run.Profile != nil {
+		rules = run.Profile.Apply(rules)
+	}
+
+	if err == nil && run.Advice != nil {
+		run.Advice.Localize(rules)
 	}
-	return csaService.ruleRepository.GetRulesForRun(run)
+
+	//Overrides are applied last so an engagement's advice wins over translations
+	if err == nil && run.Overrides != nil {
+		run.Overrides.Apply(rules)
+	}
+
+	//Once per run, not once per application
+	for i := range rules {
+		if notice := rules[i].DeprecationNotice(); notice != "" {
+			if _, warned := csaService.warnedDeprecated.LoadOrStore(rules[i].Name, true); !warned {
+				fmt.Fprintf(os.Stderr, "%s. Its findings are flagged in reports\n", notice)
+			}
+		}
+	}
+
+	return rules, err
+}
+
+//jdkRules are the catalog rules for the run's target JDK. Imported rules of the same name win.
+func (csaService *CsaService) jdkRules(run *model.Run, loaded []model.Rule) (rules []model.Rule) {
+
+	names := make(map[string]bool)
+	for i := range loaded {
+		names[loaded[i].Name] = true
+	}
+
+	generated := model.JdkRules(run.TargetJdk)
+	for i := range generated {
+		if !names[generated[i].Name] {
+			rules = append(rules, generated[i])
+		}
+	}
+
+	for i := range rules {
+		rules[i].CompilePatterns()
+		rules[i].Metric = &model.RuleMetric{Rule: rules[i].Name, RunID: run.ID, RuleCriticality: rules[i].Criticality}
+	}
+
+	return rules
+}
+
+//applyProfile resolves the run's target platform profile. The profile's scoring model is used by every application
+//that doesn't name its own unless one was requested on the command line.
+func (csaService *CsaService) applyProfile(run *model.Run, runConfig *model.RunConfig) {
+
+	if runConfig.Profile == "" {
+		return
+	}
+
+	profile, err := model.LoadRuleProfile(runConfig.Profile, *util.ProfilesDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load rule profile! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	run.Profile = profile
+	fmt.Printf("Using rule profile [%s] %s\n", profile.Name, profile.Description)
+
+	if profile.ScoringModel != "" && util.IsCmdFlagDefaulted(util.ANALYZE_CMD, util.SCORING_MODEL_FLAG) {
+		for _, app := range runConfig.Applications {
+			if app.ScoringModel == runConfig.ScoringModel {
+				app.ScoringModel = profile.ScoringModel
+			}
+		}
+		runConfig.ScoringModel = profile.ScoringModel
+	}
+}
+
+//applyScorer records the scorer the run's applications are scored with and the normalization of their raw scores
+func (csaService *CsaService) applyScorer(run *model.Run, runConfig *model.RunConfig) {
+
+	if err := model.ValidateNormalization(runConfig.Normalize); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to score the run! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	run.Normalize = strings.ToLower(runConfig.Normalize)
+	if run.Normalize != "" && run.Normalize != model.NORMALIZE_NONE {
+		fmt.Printf("Normalizing raw scores by [%s]\n", run.Normalize)
+	}
+
+	if runConfig.ScoringFormula != "" {
+		formula, err := model.LoadScoringFormula(runConfig.ScoringFormula)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to load scoring formula! Details: %v\n", err)
+			os.Exit(1)
+		}
+
+		run.Scorer = model.FORMULA_SCORER
+		run.ScoringFormula = formula.Formula
+		fmt.Printf("Scoring applications with formula [%s] %s\n", formula.Name, formula.Description)
+		return
+	}
+
+	scorer, err := model.GetScorer(runConfig.Scorer)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to score the run! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	run.Scorer = scorer.Name()
+	if scorer.Name() != model.DEFAULT_SCORER {
+		fmt.Printf("Scoring applications with the [%s] scorer\n", scorer.Name())
+	}
+}
+
+//applyTargetJdk records the JDK the run's applications are upgraded to. The catalog rules for it are added to each
+//application's rules.
+func (csaService *CsaService) applyTargetJdk(run *model.Run, runConfig *model.RunConfig) {
+
+	if runConfig.TargetJdk == 0 {
+		return
+	}
+
+	if err := model.ValidateTargetJdk(runConfig.TargetJdk); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to target the JDK! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	run.TargetJdk = runConfig.TargetJdk
+	fmt.Printf("Targeting JDK [%d] with [%d] rules for deprecated/removed JDK APIs\n", run.TargetJdk, len(model.JdkRules(run.TargetJdk)))
 }
 
 func (csaService *CsaService) gatherSLOCForApp(run *model.Run, app *model.Application) {
@@ -445,6 +819,14 @@ func (csaService *CsaService) gatherSLOCForApp(run *model.Run, app *model.Applic
 			}
 		}
 
+		if len(app.Modules) > 0 {
+			sloc := make(map[string]int, len(clocData.Files))
+			for fqn, file := range clocData.Files {
+				sloc[fqn] = int(file.Code)
+			}
+			app.CountModuleSloc(sloc)
+		}
+
 		for _, langTotal := range appTotal {
 			_ = csaService.slocRepository.CreateSlocData(&model.RunSloc{RunID: run.ID, Application: app.Name, Lang: langTotal.Name,
 				TotalFiles: len(langTotal.Files), BlankLines: int(langTotal.Blanks),
@@ -458,13 +840,14 @@ func (csaService *CsaService) gatherSLOCForApp(run *model.Run, app *model.Applic
 }
 
 func (csaService *CsaService) generateReports(run *model.Run) {
+	run.StartActivity("reports")
 	if *util.OutputReports {
-		run.StartActivity("reports")
 		csaService.reportService.GenerateReports(run)
 		run.StopActivityLF("reports", "\nReport Generation...done!", false, true)
 	} else {
 		csaService.reportService.GenerateClocReport(run, true)
 		csaService.genAppCSAResults(run)
+		run.StopActivityLF("reports", "", false, false)
 	}
 }
 
@@ -511,12 +894,17 @@ func (csaService *CsaService) genRuleMetrics(run *model.Run) {
 
 func (csaService *CsaService) genAppCSAResults(run *model.Run) {
 
-	headers := []string{"name", "files analyzed", "files ignored", "sloc cnt", "# findings", "scoring-model", "score", "recommendation"}
+	bins, err := csaService.scoringRepository.GetScoreBins()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to retrieve score bins! Details: %v\n", err)
+	}
+
+	headers := []string{"name", "files analyzed", "files ignored", "sloc cnt", "# findings", "critical/high", "new", "recurring", "resolved", "scoring-model", "score", "tier", "recommendation"}
 	var data [][]string
 
 	for _, app := range run.Applications {
 		line := []string{app.Name, fmt.Sprint(len(app.Files)), fmt.Sprint(len(app.IgnoredFiles)),
-			fmt.Sprint(app.SlocCnt), fmt.Sprint(app.CIFindings), app.ScoringModel, fmt.Sprintf("%2.2f", app.Score), app.Recommendation}
+			fmt.Sprint(app.SlocCnt), fmt.Sprint(app.CIFindings), fmt.Sprintf("%d/%d", app.CriticalCnt, app.HighCnt), fmt.Sprint(app.NewCnt), fmt.Sprint(app.RecurringCnt), fmt.Sprint(app.ResolvedCnt), app.ScoringModel, fmt.Sprintf("%2.2f", app.Score), model.ScoreBinName(bins, app.Score), app.Recommendation}
 		data = append(data, line)
 
 		if *util.DisplayIgnoredFiles {
@@ -543,6 +931,13 @@ func (csaService *CsaService) gatherFiles(run *model.Run) {
 		return
 	}
 
+	csaService.applyProfile(run, runConfig)
+	csaService.applyLocale(run)
+	csaService.applyOverrides(run, runConfig)
+	csaService.applyTagWeights(run, runConfig)
+	csaService.applyScorer(run, runConfig)
+	csaService.applyTargetJdk(run, runConfig)
+
 	if len(runConfig.Applications) > 0 {
 		run.SetAlias(runConfig.Alias)
 		run.StartActivity("gathering")
@@ -575,6 +970,7 @@ func (csaService *CsaService) UpdateRunWithApplications(run *model.Run, rc *mode
 		newApp.FilesCnt = cnt
 		filesCnt += cnt
 		newApp.Rules, err = csaService.getRules(run, rc.Applications[i])
+		newApp.Fingerprint = model.RulesFingerprint(run, newApp.Rules, *util.MinConfidence)
 		if err != nil {
 			util.TrackError("gathering", fmt.Errorf("error getting rules for app [%s]. details: %s\n", newApp.Name, err.Error()))
 		} else {
@@ -0,0 +1,65 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+const STDOUT_STREAM = "-"
+
+//Streams findings as newline delimited json (one object per line) as they are saved so external pipelines can consume them during the run
+type FindingStream struct {
+	file    *os.File
+	encoder *json.Encoder
+	sync.Mutex
+}
+
+//NewFindingStream streams to the target file, or to stdout when the target is STDOUT_STREAM. Only the stream writes to
+//stdout, the process' os.Stdout is left untouched.
+func NewFindingStream(target string, stdout io.Writer) (*FindingStream, error) {
+
+	stream := &FindingStream{}
+
+	if target == STDOUT_STREAM {
+		stream.encoder = json.NewEncoder(stdout)
+		return stream, nil
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return nil, err
+	}
+	stream.file = file
+	stream.encoder = json.NewEncoder(file)
+
+	return stream, nil
+}
+
+func (stream *FindingStream) Write(finding *model.Finding) {
+	stream.Lock()
+	defer stream.Unlock()
+
+	if err := stream.encoder.Encode(finding.CreateDTO()); err != nil {
+		util.TrackError("Streaming", fmt.Errorf("error streaming finding [%d]. details: %s", finding.ID, err.Error()))
+	}
+}
+
+func (stream *FindingStream) Close() {
+	stream.Lock()
+	defer stream.Unlock()
+
+	if stream.file != nil {
+		closeFile(stream.file)
+	}
+}
@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//incrementalBaseline loads what the last scan of the application at the same path found. It is nil unless the run is
+//incremental and the application was scanned there before.
+func (csaService *CsaService) incrementalBaseline(run *model.Run, app *model.Application) *model.IncrementalBaseline {
+
+	if !*util.Incremental {
+		return nil
+	}
+
+	previousApp, err := csaService.runRepository.GetPreviousAppAtPath(run.ID, app.Name, app.Path)
+	if err != nil {
+		util.TrackError("Incremental", fmt.Errorf("unable to find the previous scan of App [%s]: %v", app.Name, err))
+		return nil
+	}
+
+	if previousApp == nil {
+		util.WriteLogWithToken("Incremental", " ", "App [%s] was never analyzed at [%s]. Analyzing every file", app.Name, app.Path)
+		return nil
+	}
+
+	//The findings carried forward would be those of other rules or weighed differently
+	if previousApp.Fingerprint == "" {
+		util.WriteLogWithToken("Incremental", " ", "Run [%d] didn't record the rules App [%s] was analyzed with. Analyzing every file", previousApp.RunID, app.Name)
+		return nil
+	}
+	if changed := model.FingerprintChanges(previousApp.Fingerprint, app.Fingerprint); len(changed) > 0 {
+		util.WriteLogWithToken("Incremental", " ", "The %s of App [%s] changed since Run [%d]. Analyzing every file", strings.Join(changed, ", "), app.Name, previousApp.RunID)
+		return nil
+	}
+
+	manifest, err := csaService.manifestRepository.GetManifest(previousApp.RunID, app.Name)
+	if err != nil {
+		util.TrackError("Incremental", fmt.Errorf("unable to load the scan manifest of App [%s] from Run [%d]: %v", app.Name, previousApp.RunID, err))
+		return nil
+	}
+
+	findings, err := csaService.findingRepository.GetAppFileFindings(previousApp.RunID, app.Name)
+	if err != nil {
+		util.TrackError("Incremental", fmt.Errorf("unable to load the findings of App [%s] from Run [%d]: %v", app.Name, previousApp.RunID, err))
+		return nil
+	}
+
+	return model.NewIncrementalBaseline(previousApp.RunID, manifest, findings, app.Rules)
+}
+
+//carryForward copies the baseline's findings of the file into the run when the file hasn't changed since. It returns
+//false when the file has to be analyzed.
+func (csaService *CsaService) carryForward(run *model.Run, app *model.Application, file *util.FileInfo, baseline *model.IncrementalBaseline, output chan<- interface{}) bool {
+
+	entry, err := model.NewManifestEntry(run.ID, app, file, "", 0)
+	if err != nil {
+		return false
+	}
+
+	previous, unchanged := baseline.Unchanged(entry)
+	if !unchanged {
+		return false
+	}
+
+	carried := baseline.CarryForward(run.ID, file.FQN)
+	for i := range carried {
+		finding := carried[i]
+		finding.Module = app.ModuleOf(finding.Fqn)
+
+		csaService.restoreFinding(app, &finding, baseline.ImpactsApp(finding.Rule))
+		output <- finding
+	}
+
+	run.AddFindings(len(carried))
+
+	entry.Language = previous.Language
+	entry.RulesApplied = previous.RulesApplied
+	entry.Findings = len(carried)
+	output <- entry
+
+	if *util.Verbose {
+		util.WriteLog("Incremental", "File [%s] is unchanged since Run [%d]. [%d] findings carried forward\n", file.FQN, baseline.RunID, len(carried))
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//trackLifecycles matches each application's findings against the app's previous run marking them new or recurring,
+//carrying the triage of recurring findings forward, and counting the previous findings that were resolved
+func (csaService *CsaService) trackLifecycles(run *model.Run) {
+
+	run.StartActivity("lifecycle")
+
+	failed := false
+
+	for _, app := range run.Applications {
+		if err := csaService.trackAppLifecycle(run, app); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Lifecycle tracking for App [%s] failed! Details: %v\n", app.Name, err)
+			failed = true
+		}
+	}
+
+	if failed {
+		run.StopActivityLF("lifecycle", "Lifecycle Tracking...failed!", false, true)
+	} else {
+		run.StopActivityLF("lifecycle", "Lifecycle Tracking...done!", false, true)
+	}
+}
+
+func (csaService *CsaService) trackAppLifecycle(run *model.Run, app *model.Application) error {
+
+	current, err := csaService.findingRepository.GetAppFindings(run.ID, app.Name)
+	if err != nil {
+		return err
+	}
+
+	previousApp, err := csaService.runRepository.GetPreviousApp(run.ID, app.Name)
+	if err != nil {
+		return err
+	}
+
+	//First time the app has been analyzed. Everything is new!
+	if previousApp == nil {
+		app.NewCnt = len(current)
+		return csaService.findingRepository.SetFindingLifecycles(run.ID, app.Name, nil)
+	}
+
+	previous, err := csaService.findingRepository.GetAppFindings(previousApp.RunID, app.Name)
+	if err != nil {
+		return err
+	}
+
+	util.WriteLogWithToken("Lifecycle", " ", "Matching [%d] findings of App [%s] against [%d] findings of Run [%d]",
+		len(current), app.Name, len(previous), previousApp.RunID)
+
+	match := model.MatchFindings(previous, previousApp.Path, current, app.Path, *util.LifecycleTolerance)
+
+	app.BaselineRunID = previousApp.RunID
+	app.RecurringCnt = len(match.Previous)
+	app.NewCnt = len(current) - app.RecurringCnt
+	app.ResolvedCnt = len(match.Resolved)
+
+	if err = csaService.findingRepository.SetFindingLifecycles(run.ID, app.Name, match.Previous); err != nil {
+		return err
+	}
+
+	//Triage decisions stick to the finding as long as it recurs
+	return csaService.findingRepository.CarryFindingTriage(model.CarryForwardTriage(previous, match.Previous))
+}
@@ -0,0 +1,63 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//recordManifestEntry lists the file in the scan manifest. The entry is saved after the file's findings and checkpoints
+//the file for resuming the run.
+func (csaService *CsaService) recordManifestEntry(run *model.Run, app *model.Application, file *util.FileInfo, rulesApplied int, findings int, output chan<- interface{}) {
+
+	language := ""
+	if lang, ok := csaService.fileUtil.GetLangForFileExt(file.GetCleanedExt()); ok {
+		language = lang.Name
+	}
+
+	entry, err := model.NewManifestEntry(run.ID, app, file, language, rulesApplied)
+	if err != nil {
+		util.TrackError("Manifest", fmt.Errorf("unable to hash file [%s] for the scan manifest: %v", file.FQN, err))
+	}
+	entry.Findings = findings
+
+	output <- entry
+}
+
+//recordInaccessibleFile lists a file skipped because it is encrypted in the scan manifest and the run summary
+func (csaService *CsaService) recordInaccessibleFile(run *model.Run, app *model.Application, file *util.FileInfo, reason string, output chan<- interface{}) {
+
+	util.TrackInaccessible(file.FQN, reason)
+
+	entry, err := model.NewManifestEntry(run.ID, app, file, "", 0)
+	if err != nil {
+		util.TrackError("Manifest", fmt.Errorf("unable to hash file [%s] for the scan manifest: %v", file.FQN, err))
+	}
+	entry.Inaccessible = reason
+
+	output <- entry
+}
+
+//reportInaccessibleInputs lists the inputs that were skipped because they are encrypted so decrypted copies can be requested
+func (csaService *CsaService) reportInaccessibleInputs() {
+
+	inputs := util.InaccessibleInputs()
+	if len(inputs) == 0 {
+		return
+	}
+
+	headers := []string{"path", "reason"}
+	var data [][]string
+	for _, input := range inputs {
+		data = append(data, []string{input.Path, input.Reason})
+	}
+
+	csaService.reportService.DisplayReport(headers, data, "Inaccessible Inputs", false)
+	fmt.Printf("[%d] encrypted input(s) were skipped! Request decrypted copies to analyze them.\n\n", len(inputs))
+}
@@ -0,0 +1,38 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+
+	"csa-app/model"
+)
+
+//saveModules persists the modules of the run's multi-module applications, with their sloc, so their findings can be
+//scored per module
+func (csaService *CsaService) saveModules(run *model.Run) {
+
+	var modules []*model.AppModule
+	for _, app := range run.Applications {
+		modules = append(modules, app.Modules...)
+	}
+
+	if len(modules) == 0 {
+		return
+	}
+
+	run.StartActivity("modules")
+
+	msg := fmt.Sprintf("Modules [%d]...done!", len(modules))
+
+	if err := csaService.runRepository.SaveModules(modules); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Saving application modules failed! Details: %v\n", err)
+		msg = "Modules...failed!"
+	}
+
+	run.StopActivityLF("modules", msg, false, true)
+}
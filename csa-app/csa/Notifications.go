@@ -0,0 +1,84 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"csa-app/events"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//runNotifier publishes a run's lifecycle events to the configured subscribers
+type runNotifier struct {
+	bus *events.Bus
+}
+
+func (csaService *CsaService) openEventBus(run *model.Run) {
+
+	bus, err := events.NewBusFromFlags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to configure run notifications! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !bus.HasSubscribers() {
+		bus.Close()
+		return
+	}
+
+	csaService.events = bus
+	run.Listener = &runNotifier{bus: bus}
+}
+
+func (csaService *CsaService) publishRunStarted(run *model.Run) {
+	csaService.events.Publish(runEvent(events.RUN_STARTED, run))
+}
+
+func (csaService *CsaService) publishRunFinished(run *model.Run) {
+	event := runEvent(events.RUN_FINISHED, run)
+	event.Elapsed = run.Runtime
+	event.Failed = util.HasErrors()
+	csaService.events.Publish(event)
+}
+
+//closeEventBus waits for queued events to be delivered
+func (csaService *CsaService) closeEventBus(run *model.Run) {
+	run.Listener = nil
+	csaService.events.Close()
+}
+
+func (n *runNotifier) ActivityStopped(run *model.Run, name string, elapsed time.Duration) {
+	event := runEvent(events.PHASE_COMPLETED, run)
+	event.Phase = name
+	event.Elapsed = elapsed.String()
+	n.bus.Publish(event)
+}
+
+func (n *runNotifier) FindingsAdded(run *model.Run, total int) {
+	if n.bus.ThresholdCrossed(total) {
+		event := runEvent(events.FINDING_THRESHOLD, run)
+		event.Findings = total
+		event.Threshold = n.bus.Threshold()
+		n.bus.Publish(event)
+	}
+}
+
+//runEvent captures the run's current counts. Counters are read without the run's lock, they are informational only.
+func runEvent(eventType events.EventType, run *model.Run) events.Event {
+	return events.Event{
+		Type:         eventType,
+		RunID:        run.ID,
+		Alias:        run.Alias,
+		Target:       run.Target,
+		Applications: len(run.Applications),
+		Files:        run.Files,
+		Findings:     run.Findings,
+	}
+}
@@ -0,0 +1,111 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+const PROGRESS_INTERVAL = time.Second
+
+//scanProgressReporter reports the progress of the analysis every PROGRESS_INTERVAL: on a console line rewritten in
+//place or, with --quiet, as a json object per line on std err. Verbose runs log every file instead.
+type scanProgressReporter struct {
+	progress *model.ScanProgress
+	out      io.Writer
+	json     bool
+	stop     chan bool
+	done     chan bool
+	width    int
+}
+
+func (csaService *CsaService) startProgress(run *model.Run) {
+
+	reporter := &scanProgressReporter{progress: model.NewScanProgress(run.AppsOrdered(), time.Now()), out: os.Stdout,
+		json: *util.Quiet, stop: make(chan bool), done: make(chan bool)}
+	if reporter.json {
+		reporter.out = os.Stderr
+	}
+	csaService.progress = reporter
+
+	if *util.Verbose && !reporter.json {
+		close(reporter.done)
+		return
+	}
+
+	go func() {
+		defer close(reporter.done)
+		ticker := time.NewTicker(PROGRESS_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				reporter.report(reporter.progress.Snapshot(time.Now()))
+			case <-reporter.stop:
+				return
+			}
+		}
+	}()
+}
+
+//fileAnalyzed counts the file as analyzed. bytes is what was read of it, 0 for files whose findings were carried over.
+func (csaService *CsaService) fileAnalyzed(app *model.Application, file *util.FileInfo, read bool) {
+	var bytes int64
+	if read {
+		if info, err := os.Stat(file.FQN); err == nil {
+			bytes = info.Size()
+		}
+	}
+	csaService.progress.progress.FileAnalyzed(app.Name, bytes)
+}
+
+//stopProgress reports the final progress of the analysis
+func (csaService *CsaService) stopProgress() {
+	reporter := csaService.progress
+	select {
+	case <-reporter.done:
+	default:
+		close(reporter.stop)
+		<-reporter.done
+	}
+
+	if *util.Verbose && !reporter.json {
+		return
+	}
+
+	snapshot := reporter.progress.Snapshot(time.Now())
+	snapshot.Event = "analyzed"
+	reporter.report(snapshot)
+	if !reporter.json {
+		fmt.Fprintln(reporter.out)
+	}
+}
+
+func (reporter *scanProgressReporter) report(snapshot model.ProgressSnapshot) {
+	if reporter.json {
+		if err := json.NewEncoder(reporter.out).Encode(snapshot); err != nil {
+			util.TrackError("Progress", err)
+		}
+		return
+	}
+
+	//The line is padded to overwrite the rest of a longer previous one
+	line := fmt.Sprintf("Analyzing...%s", snapshot)
+	padding := reporter.width - len(line)
+	if padding < 0 {
+		padding = 0
+	}
+	reporter.width = len(line)
+	fmt.Fprintf(reporter.out, "%s%s\r", line, strings.Repeat(" ", padding))
+}
@@ -0,0 +1,48 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//EvaluateGate checks the run's scored applications against the quality gate, listing the violations and writing the
+//result to <run>-gate.json in the output dir for CI to pick up. It tells whether the run passed.
+func (csaService *CsaService) EvaluateGate(run *model.Run, gate *model.QualityGate) bool {
+
+	result := gate.Evaluate(run.ID, run.Applications)
+
+	name := fmt.Sprintf("%d-gate", run.ID)
+	util.WriteStructToFile(result, name, *util.OutputDir, util.JSON, true)
+
+	var checks []string
+	if gate.FailBelow > 0 {
+		checks = append(checks, fmt.Sprintf("%s %.2f", model.FAIL_BELOW_GATE, gate.FailBelow))
+	}
+	if len(gate.FailOnTags) > 0 {
+		checks = append(checks, fmt.Sprintf("%s %s", model.FAIL_ON_TAG_GATE, strings.Join(gate.FailOnTags, ",")))
+	}
+
+	if result.Passed {
+		fmt.Printf("Quality gate [%s] passed. Result written to [%s%s%s.%s]\n", strings.Join(checks, "; "), *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return true
+	}
+
+	headers := []string{"gate", "application", "violation"}
+	var data [][]string
+	for _, violation := range result.Violations {
+		data = append(data, []string{violation.Gate, violation.Application, violation.Describe(*gate)})
+	}
+
+	csaService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Quality Gate [%s] Failed", run.ID, strings.Join(checks, "; ")), false)
+	fmt.Printf("Quality gate failed. Result written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+
+	return false
+}
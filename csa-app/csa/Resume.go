@@ -0,0 +1,134 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//resumeRun continues the interrupted analysis run given by --resume instead of starting a new one. It analyzes the
+//run's target again with the run's id and alias.
+func (csaService *CsaService) resumeRun(run *model.Run) {
+
+	interrupted, err := csaService.runRepository.GetRun(*util.Resume)
+	if err != nil || interrupted.ID == 0 {
+		fmt.Fprintf(os.Stderr, "Run [%d] does not exist! Unable to resume it.\n", *util.Resume)
+		os.Exit(1)
+	}
+
+	if interrupted.Command != util.ANALYZE_CMD || interrupted.Status != model.RUN_ANALYZING {
+		fmt.Fprintf(os.Stderr, "Run [%d] wasn't interrupted while analyzing (status [%s]) and can't be resumed! Start a new run instead.\n",
+			interrupted.ID, interrupted.Status)
+		os.Exit(1)
+	}
+
+	run.SetPaths(interrupted.Target)
+	run.ID = interrupted.ID
+	run.CreatedAt = interrupted.CreatedAt
+	run.Alias = interrupted.Alias
+	run.Status = interrupted.Status
+	run.StartTime = time.Now()
+
+	fmt.Printf("Resuming Run [%d] of [%s]...\n", run.ID, run.Target)
+}
+
+//resumeCheckpoints sorts the files of each application of a resumed run into those analyzed before it was interrupted
+//and those to analyze again, whose saved findings and manifest entries are dropped
+func (csaService *CsaService) resumeCheckpoints(run *model.Run) {
+
+	if *util.Resume == 0 && !*util.Distribute {
+		return
+	}
+
+	run.StartActivity("checkpoints")
+
+	msg := "Checkpoints...done!"
+
+	for _, app := range run.Applications {
+		checkpoint, err := csaService.appCheckpoint(run, app)
+		if err != nil {
+			util.TrackError("Checkpoints", fmt.Errorf("unable to restore the checkpoint of App [%s]: %v", app.Name, err))
+			fmt.Fprintf(os.Stderr, "Restoring the checkpoint of App [%s] failed! Details: %v\n", app.Name, err)
+			msg = "Checkpoints...failed!"
+			continue
+		}
+
+		util.WriteLogWithToken("Checkpoints", " ", "App [%s]: [%d] of [%d] files were already analyzed in Run [%d]",
+			app.Name, checkpoint.CompleteFiles(), len(app.Files), run.ID)
+		csaService.setCheckpoint(app, checkpoint)
+	}
+
+	run.StopActivityLF("checkpoints", msg, false, true)
+}
+
+//checkpoint returns the application's checkpoint, nil unless the run was resumed or distributed
+func (csaService *CsaService) checkpoint(app *model.Application) *model.ScanCheckpoint {
+	csaService.checkpointsMux.Lock()
+	defer csaService.checkpointsMux.Unlock()
+	return csaService.checkpoints[app.Name]
+}
+
+func (csaService *CsaService) setCheckpoint(app *model.Application, checkpoint *model.ScanCheckpoint) {
+	csaService.checkpointsMux.Lock()
+	defer csaService.checkpointsMux.Unlock()
+	if csaService.checkpoints == nil {
+		csaService.checkpoints = make(map[string]*model.ScanCheckpoint)
+	}
+	csaService.checkpoints[app.Name] = checkpoint
+}
+
+func (csaService *CsaService) appCheckpoint(run *model.Run, app *model.Application) (*model.ScanCheckpoint, error) {
+
+	manifest, err := csaService.manifestRepository.GetManifest(run.ID, app.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := csaService.findingRepository.GetAppFileFindings(run.ID, app.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := model.NewScanCheckpoint(run.ID, app.Path, manifest, findings, app.Rules)
+
+	if err = csaService.findingRepository.DeleteFindings(checkpoint.StaleFindings); err != nil {
+		return nil, err
+	}
+
+	if err = csaService.manifestRepository.DeleteManifestEntries(checkpoint.StaleEntries); err != nil {
+		return nil, err
+	}
+
+	//The app's tags and the matches of rules impacting it once are restored from the findings kept
+	for i := range checkpoint.Findings {
+		csaService.restoreFinding(app, &checkpoint.Findings[i], checkpoint.ImpactsApp(checkpoint.Findings[i].Rule))
+	}
+	run.AddFindings(len(checkpoint.Findings))
+
+	return checkpoint, nil
+}
+
+//restoreFinding accounts for a finding of a file that isn't analyzed by the run as if it had matched
+func (csaService *CsaService) restoreFinding(app *model.Application, finding *model.Finding, impactsApp bool) {
+
+	//Matches of rules impacting the app once in analyzed files don't count again when the restored match did
+	if finding.Effort != 0 && impactsApp {
+		app.Lock()
+		app.MatchedRules[finding.Rule]++
+		app.Unlock()
+	}
+
+	for _, tag := range finding.Tags {
+		if tag.Value != model.INFO_FINDING && tag.Value != model.FILE_FINDING {
+			app.AssociateTag(model.ApplicationTag{Value: tag.Value})
+		}
+	}
+}
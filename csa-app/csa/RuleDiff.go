@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//DiffUpstreamRules lists how the rules of the upstream pack differ from the local rules and adopts the changes
+//selected with --adopt/--adopt-added/--adopt-modified/--adopt-removed. Returns false if the pack could not be read
+//or a change failed to be adopted.
+func (csaService *CsaService) DiffUpstreamRules(pack string) bool {
+
+	upstream, errs := model.ReadRulePack(pack)
+	for _, err := range errs {
+		fmt.Printf("Skipping invalid upstream rule: %s\n", err)
+	}
+
+	if len(upstream) == 0 {
+		fmt.Printf("Found No valid rules in upstream pack [%s]\n", pack)
+		return false
+	}
+
+	local, err := csaService.ruleRepository.GetRules()
+	if err != nil {
+		fmt.Printf("Unable to retrieve local rules! Details: %v\n", err)
+		return false
+	}
+
+	changes := model.DiffRules(local, upstream)
+	if len(changes) == 0 {
+		fmt.Printf("Local rules are up to date with upstream pack [%s]\n", pack)
+		return true
+	}
+
+	adopt := make(map[string]bool)
+	for _, name := range *util.AdoptRules {
+		adopt[name] = true
+	}
+
+	headers := []string{"rule", "change", "fields", "adopted"}
+	var data [][]string
+	var selected []model.RuleChange
+	counts := make(map[string]int)
+
+	for _, change := range changes {
+		counts[change.Change]++
+		adopted := adopt[change.Rule] ||
+			(change.Change == model.RULE_ADDED && *util.AdoptAdded) ||
+			(change.Change == model.RULE_MODIFIED && *util.AdoptModified) ||
+			(change.Change == model.RULE_REMOVED && *util.AdoptRemoved)
+		delete(adopt, change.Rule)
+		if adopted {
+			selected = append(selected, change)
+		}
+		data = append(data, []string{change.Rule, change.Change, strings.Join(change.Fields, ","), fmt.Sprintf("%t", adopted)})
+	}
+
+	csaService.reportService.DisplayReport(headers, data, fmt.Sprintf("Upstream Rule Changes [%s]", pack), false)
+	fmt.Printf("[%d] added, [%d] removed, [%d] modified\n", counts[model.RULE_ADDED], counts[model.RULE_REMOVED], counts[model.RULE_MODIFIED])
+
+	for name := range adopt {
+		fmt.Printf("Rule [%s] is unchanged upstream. Nothing to adopt!\n", name)
+	}
+
+	ok := true
+	for _, change := range selected {
+		if change.Change == model.RULE_REMOVED {
+			err = csaService.ruleRepository.DeleteRule(change.Rule)
+		} else {
+			err = csaService.ruleRepository.UpsertRule(*change.Upstream)
+		}
+		if err != nil {
+			fmt.Printf("Failed adopting %s rule [%s]! Details: %v\n", change.Change, change.Rule, err)
+			ok = false
+		}
+	}
+
+	if len(selected) > 0 {
+		fmt.Printf("Adopted [%d] of [%d] upstream rule changes\n", len(selected), len(changes))
+	}
+
+	return ok
+}
@@ -0,0 +1,158 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+const EXPLAIN_RULE_APP = "explain-rule"
+
+//ExplainRule is a dry run of a single rule against path (a file or directory). It shows the lines the rule would match
+//with the effort each finding would carry and the files its exclusions (unless) skip. The run's profile and rule
+//overrides are honored. Nothing is written to the database. Returns false when the rule can't be explained.
+func (csaService *CsaService) ExplainRule(name string, path string, run *model.Run) bool {
+
+	if strings.HasPrefix(path, "~") {
+		path = strings.Replace(path, "~", run.Homepath, 1)
+	}
+
+	rule, err := csaService.ruleRepository.GetRuleByName(name)
+	if err != nil || rule.Name == "" {
+		fmt.Printf("Rule [%s] not found! Details: %v\n", name, err)
+		return false
+	}
+
+	if rule.IsComposite() {
+		fmt.Printf("Rule [%s] is a composite rule (%s) evaluated from other rules' findings and can't be explained on its own\n",
+			rule.Name, rule.Condition)
+		return false
+	}
+
+	rules := []model.Rule{rule}
+
+	if *util.RuleProfile != "" {
+		profile, err := model.LoadRuleProfile(*util.RuleProfile, *util.ProfilesDir)
+		if err != nil {
+			fmt.Printf("Unable to load rule profile! Details: %v\n", err)
+			return false
+		}
+		if rules = profile.Apply(rules); len(rules) == 0 {
+			fmt.Printf("Rule [%s] is dropped by rule profile [%s] and would not be used\n", name, profile.Name)
+			return true
+		}
+	}
+
+	if *util.RuleOverrides != "" {
+		overrides, err := model.LoadRuleOverrides(*util.RuleOverrides)
+		if err != nil {
+			fmt.Printf("Unable to load rule overrides! Details: %v\n", err)
+			return false
+		}
+		overrides.Apply(rules)
+	}
+
+	explained := &rules[0]
+	explained.CompilePatterns()
+	explained.Metric = &model.RuleMetric{Rule: explained.Name, RuleCriticality: explained.Criticality}
+
+	app := model.NewApplication(&model.ApplicationConfig{Name: EXPLAIN_RULE_APP, Path: path})
+	app.Rules = rules
+
+	var matches [][]string
+	var exclusions [][]string
+	filesChecked, filesMatched, effort := 0, 0, 0
+
+	for _, file := range csaService.fileUtil.GetFileList(path, ".*") {
+
+		if !explained.Applies(file.GetCleanedExt(), file.Name) || !explained.AppliesToPath(path, file.FQN) {
+			continue
+		}
+		filesChecked++
+
+		relPath := explainedPath(path, file.FQN)
+
+		loadContents := func() []byte {
+			contents, _ := ioutil.ReadFile(file.FQN)
+			return contents
+		}
+		if exclusion := explained.ExcludedBy(file.FQN, loadContents); exclusion != nil {
+			target := exclusion.Target
+			if target == "" {
+				target = model.CONTENTS_TARGET
+			}
+			exclusions = append(exclusions, []string{relPath, target, exclusion.Pattern})
+			continue
+		}
+
+		findings, err := csaService.explainFile(run, app, explained.Name, &file)
+		if err != nil {
+			fmt.Printf("Unable to analyze file [%s]! Details: %v\n", file.FQN, err)
+			return false
+		}
+
+		if len(findings) > 0 {
+			filesMatched++
+		}
+		for _, finding := range findings {
+			effort += finding.Effort
+			matches = append(matches, []string{relPath, strconv.Itoa(finding.Line), finding.Pattern, finding.Value, strconv.Itoa(finding.Effort)})
+		}
+	}
+
+	if len(exclusions) > 0 {
+		csaService.reportService.DisplayReport([]string{"file", "target", "exclusion"}, exclusions,
+			fmt.Sprintf("Files Excluded from Rule [%s]", explained.Name), false)
+	}
+
+	if len(matches) > 0 {
+		csaService.reportService.DisplayReport([]string{"file", "line", "pattern", "value", "effort"}, matches,
+			fmt.Sprintf("Matches of Rule [%s]", explained.Name), false)
+	}
+
+	fmt.Printf("Rule [%s] applies to [%d] files under [%s]: [%d] excluded, [%d] matched with [%d] findings for a total effort of [%d]\n",
+		explained.Name, filesChecked, path, len(exclusions), filesMatched, len(matches), effort)
+
+	return true
+}
+
+//explainFile analyzes the file with only the explained rule and returns the rule's findings
+func (csaService *CsaService) explainFile(run *model.Run, app *model.Application, ruleName string, file *util.FileInfo) ([]model.Finding, error) {
+
+	output := make(chan interface{})
+	collected := make(chan []model.Finding)
+
+	go func() {
+		var findings []model.Finding
+		for item := range output {
+			//Anything else is bookkeeping (analyzed file/sloc findings)
+			if finding, ok := item.(model.Finding); ok && finding.Rule == ruleName {
+				findings = append(findings, finding)
+			}
+		}
+		collected <- findings
+	}()
+
+	err := csaService.analyzeFile(run, app, file, output)
+	close(output)
+	findings := <-collected
+
+	return findings, err
+}
+
+func explainedPath(root string, fqn string) string {
+	if rel, err := filepath.Rel(root, fqn); err == nil && rel != "." {
+		return rel
+	}
+	return filepath.Base(fqn)
+}
@@ -0,0 +1,64 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/model"
+)
+
+//LintRules reports the overlapping rule patterns of the rules in path (a rule pack .tgz or directory of rule files) or,
+//when no path is given, of the rules in the database. Returns false if the rules could not be read or rules sharing a
+//tag double count the same matches.
+func (csaService *CsaService) LintRules(path string, doubleCountingOnly bool) bool {
+
+	var rules []model.Rule
+	if path != "" {
+		var errs []string
+		rules, errs = model.ReadRulePack(path)
+		for _, err := range errs {
+			fmt.Printf("Skipping invalid rule: %s\n", err)
+		}
+	} else {
+		var err error
+		if rules, err = csaService.ruleRepository.GetRules(); err != nil {
+			fmt.Printf("Unable to retrieve rules! Details: %v\n", err)
+			return false
+		}
+		path = "database"
+	}
+
+	if len(rules) == 0 {
+		fmt.Printf("Found No rules to lint in [%s]\n", path)
+		return false
+	}
+
+	headers := []string{"rule", "pattern", "overlap", "other rule", "other pattern", "shared tags", "sample match"}
+	var data [][]string
+	doubleCounted := 0
+
+	for _, overlap := range model.LintRules(rules) {
+		if len(overlap.SharedTags) > 0 {
+			doubleCounted++
+		} else if doubleCountingOnly {
+			continue
+		}
+		data = append(data, []string{overlap.Rule, overlap.Pattern, overlap.Overlap, overlap.OtherRule, overlap.OtherPattern,
+			strings.Join(overlap.SharedTags, ","), overlap.Sample})
+	}
+
+	if len(data) == 0 {
+		fmt.Printf("No overlapping patterns found in the [%d] rules of [%s]\n", len(rules), path)
+		return true
+	}
+
+	csaService.reportService.DisplayReport(headers, data, fmt.Sprintf("Overlapping Rule Patterns [%s]", path), false)
+	fmt.Printf("[%d] overlapping pattern pairs, [%d] of them double count matches against a shared tag\n", len(data), doubleCounted)
+
+	return doubleCounted == 0
+}
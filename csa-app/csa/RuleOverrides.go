@@ -0,0 +1,47 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"csa-app/model"
+)
+
+//applyOverrides loads the engagement's rule overrides and records them with the run, so its scores can be traced back
+//to the effort and advice they were computed with
+func (csaService *CsaService) applyOverrides(run *model.Run, runConfig *model.RunConfig) {
+
+	if runConfig.RuleOverrides == "" {
+		return
+	}
+
+	overrides, err := model.LoadRuleOverrides(runConfig.RuleOverrides)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load rule overrides! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	recorded, err := json.Marshal(overrides)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to record rule overrides! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	run.Overrides = overrides
+	run.RuleOverrides = string(recorded)
+	fmt.Printf("Using rule overrides [%s] for [%d] rules\n", overrides.Name, len(overrides.Rules))
+
+	//Flag overrides that will never apply, I.E. a misspelled rule name
+	if rules, err := csaService.ruleRepository.GetRules(); err == nil {
+		if unknown := overrides.Apply(rules); len(unknown) > 0 {
+			fmt.Fprintf(os.Stderr, "Rule overrides [%s] name unknown rules: %s\n", overrides.Name, strings.Join(unknown, ", "))
+		}
+	}
+}
@@ -0,0 +1,203 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+const RULE_TEST_APP = "rule-test"
+
+//TestRules runs the rule tests found at path (a test file or a directory of them) reporting each failed assertion.
+//Rules are read from the rule file next to each test file so they can be tested before they are imported.
+//Returns false when any test fails.
+func (csaService *CsaService) TestRules(path string, run *model.Run) bool {
+
+	if strings.HasPrefix(path, "~") {
+		path = strings.Replace(path, "~", run.Homepath, 1)
+	}
+
+	testFiles := []string{path}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		testFiles = nil
+		for _, file := range csaService.fileUtil.GetFileList(path, "(yaml|yml)") {
+			if model.IsRuleTestFile(file.Name) {
+				testFiles = append(testFiles, file.FQN)
+			}
+		}
+	}
+
+	if len(testFiles) == 0 {
+		fmt.Printf("Found No Rule test files @ [%s]\n", path)
+		return true
+	}
+
+	passed, failed := 0, 0
+	for _, testFile := range testFiles {
+		p, f := csaService.runRuleTestFile(run, testFile)
+		passed += p
+		failed += f
+	}
+
+	fmt.Printf("\nRule tests: [%d] passed, [%d] failed\n", passed, failed)
+
+	return failed == 0
+}
+
+func (csaService *CsaService) runRuleTestFile(run *model.Run, testFile string) (passed int, failed int) {
+
+	rules, err := loadRulesUnderTest(testFile)
+	if err != nil {
+		fmt.Printf("FAIL %s: %v\n", testFile, err)
+		return 0, 1
+	}
+
+	reader, err := os.Open(testFile)
+	if err != nil {
+		fmt.Printf("FAIL %s: %v\n", testFile, err)
+		return 0, 1
+	}
+	defer reader.Close()
+
+	suite, err := model.LoadRuleTests(reader, rules)
+	if err != nil {
+		fmt.Printf("FAIL %s: %v\n", testFile, err)
+		return 0, 1
+	}
+
+	for _, test := range suite.Tests {
+		msg := csaService.runRuleTest(run, filepath.Dir(testFile), rules, test)
+		if msg == "" {
+			passed++
+			if *util.Verbose {
+				fmt.Printf("PASS %s [%s|%s]\n", testFile, test.Rule, test.Name)
+			}
+		} else {
+			failed++
+			fmt.Printf("FAIL %s [%s|%s]: %s\n", testFile, test.Rule, test.Name, msg)
+		}
+	}
+
+	return passed, failed
+}
+
+//runRuleTest analyzes the fixture with only the rule under test and checks the lines it matched
+func (csaService *CsaService) runRuleTest(run *model.Run, testDir string, rules []model.Rule, test *model.RuleTestCase) string {
+
+	var rule *model.Rule
+	for i := range rules {
+		if rules[i].Name == test.Rule {
+			rule = &rules[i]
+			break
+		}
+	}
+
+	if rule == nil {
+		return fmt.Sprintf("rule [%s] not found in the rule file", test.Rule)
+	}
+
+	fixture, err := ruleTestFixture(testDir, test)
+	if err != nil {
+		return err.Error()
+	}
+
+	name := filepath.Base(fixture)
+	if test.Filename != "" {
+		name = test.Filename
+	}
+
+	app := model.NewApplication(&model.ApplicationConfig{Name: RULE_TEST_APP, Path: filepath.Dir(fixture)})
+	app.Rules = []model.Rule{*rule}
+	file := util.NewFileInfo(RULE_TEST_APP, fixture, name, filepath.Ext(name), filepath.Dir(fixture), "", true)
+
+	output := make(chan interface{})
+	matched := make(chan []int)
+
+	go func() {
+		var lines []int
+		for item := range output {
+			//Anything else is bookkeeping (analyzed file/sloc findings)
+			if finding, ok := item.(model.Finding); ok && finding.Rule == rule.Name {
+				lines = append(lines, finding.Line)
+			}
+		}
+		matched <- lines
+	}()
+
+	err = csaService.analyzeFile(run, app, file, output)
+	close(output)
+	lines := <-matched
+
+	if err != nil {
+		return fmt.Sprintf("unable to analyze fixture [%s]: %v", fixture, err)
+	}
+
+	return test.Check(lines)
+}
+
+//ruleTestFixture returns the fixture file for the test. Inline content is written to the run's workspace.
+func ruleTestFixture(testDir string, test *model.RuleTestCase) (string, error) {
+
+	if test.File != "" {
+		fixture := test.File
+		if !filepath.IsAbs(fixture) {
+			fixture = filepath.Join(testDir, fixture)
+		}
+		if _, err := os.Stat(fixture); err != nil {
+			return "", fmt.Errorf("fixture [%s] is not readable: %v", fixture, err)
+		}
+		return fixture, nil
+	}
+
+	if util.RunWorkspace == nil {
+		return "", fmt.Errorf("no workspace to write the inline fixture to")
+	}
+
+	dir, err := util.RunWorkspace.Dir(filepath.Join("rule-tests", test.Rule, test.Name))
+	if err != nil {
+		return "", err
+	}
+
+	fixture := filepath.Join(dir, filepath.Base(test.Filename))
+	if err = ioutil.WriteFile(fixture, []byte(test.Content), 0644); err != nil {
+		return "", fmt.Errorf("unable to write inline fixture: %v", err)
+	}
+
+	return fixture, nil
+}
+
+func loadRulesUnderTest(testFile string) ([]model.Rule, error) {
+
+	for _, ruleFile := range model.RuleFileForTest(testFile) {
+		reader, err := os.Open(ruleFile)
+		if err != nil {
+			continue
+		}
+
+		rules, errs := model.ValidateRuleFile(reader, strings.HasSuffix(ruleFile, util.JSON))
+		reader.Close()
+
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("rule file [%s] is invalid! %s:%d:%d: %s", ruleFile, ruleFile, errs[0].Line, errs[0].Column, errs[0].Message)
+		}
+
+		for i := range rules {
+			rules[i].CompilePatterns()
+			rules[i].Metric = &model.RuleMetric{Rule: rules[i].Name, RuleCriticality: rules[i].Criticality}
+		}
+
+		return rules, nil
+	}
+
+	return nil, fmt.Errorf("no rule file found for test file (expected one of %s)", strings.Join(model.RuleFileForTest(testFile), ", "))
+}
@@ -0,0 +1,39 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"csa-app/model"
+)
+
+//applyTagWeights loads the tag weights and records them with the run, so its scores can be traced back to the weights
+//the effort of its findings was scaled by
+func (csaService *CsaService) applyTagWeights(run *model.Run, runConfig *model.RunConfig) {
+
+	if runConfig.TagWeights == "" {
+		return
+	}
+
+	weights, err := model.LoadTagWeights(runConfig.TagWeights)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load tag weights! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	recorded, err := json.Marshal(weights)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to record tag weights! Details: %v\n", err)
+		os.Exit(1)
+	}
+
+	run.Weights = weights
+	run.TagWeights = string(recorded)
+	fmt.Printf("Using tag weights [%s] for [%d] tags\n", weights.Name, len(weights.Weights))
+}
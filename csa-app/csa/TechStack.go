@@ -0,0 +1,37 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"fmt"
+	"os"
+
+	"csa-app/model"
+)
+
+//detectTechStacks identifies the frameworks, servers, build tools and runtimes of each application and persists them,
+//attaching the versions found to the findings about those technologies
+func (csaService *CsaService) detectTechStacks(run *model.Run) {
+
+	run.StartActivity("techstack")
+
+	msg := "Tech Stack...done!"
+
+	for _, app := range run.Applications {
+		app.TechStack = model.DetectTechStack(run.ID, app, model.TechDetectors)
+		if err := csaService.techStackRepository.SaveTechStack(app.TechStack); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Saving tech stack for App [%s] failed! Details: %v\n", app.Name, err)
+			msg = "Tech Stack...failed!"
+		}
+		versions := model.TechVersionsByTag(app.TechStack, model.TechDetectors)
+		if err := csaService.findingRepository.SetFindingTechVersions(run.ID, app.Name, versions); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Attaching tech versions to findings of App [%s] failed! Details: %v\n", app.Name, err)
+			msg = "Tech Stack...failed!"
+		}
+	}
+
+	run.StopActivityLF("techstack", msg, false, true)
+}
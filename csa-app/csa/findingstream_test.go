@@ -0,0 +1,33 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package csa
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"csa-app/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingStreamToStdoutLeavesProcessStdoutAlone(t *testing.T) {
+
+	stdout := os.Stdout
+	var out bytes.Buffer
+
+	stream, err := NewFindingStream(STDOUT_STREAM, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, stdout, os.Stdout)
+
+	stream.Write(&model.Finding{Application: "orders", Rule: "java-jms", Line: 12})
+	stream.Close()
+
+	var finding map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &finding))
+	assert.Equal(t, "orders", finding["application"])
+}
@@ -26,6 +26,12 @@ func SaveFindingTransacted(db *gorm.DB, finding *model.Finding) bool {
 	return !CheckDBError(false, "SaveFinding", "DB Error!", err)
 }
 
+//SaveManifestEntryTransacted checkpoints a file, committed with the findings saved before it
+func SaveManifestEntryTransacted(db *gorm.DB, entry *model.ManifestEntry) bool {
+	err := db.Save(entry).Error
+	return !CheckDBError(false, "SaveManifestEntry", "DB Error!", err)
+}
+
 func SaveMetrics(metrics []model.KV) {
 	for _, metric := range metrics {
 		SaveMetric(metric)
@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db
+
+import (
+	"fmt"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//DatabaseInfo describes the database csa is connected to and how much it holds, as report rows
+func DatabaseInfo(run *model.Run) (headers []string, data [][]string, err error) {
+
+	var version string
+	if err = database.DB().QueryRow(getDBVersion()).Scan(&version); err != nil {
+		return nil, nil, err
+	}
+
+	location := run.DbPath
+	if *util.DB == util.POSTGRES {
+		location = *util.DBName
+	}
+
+	data = [][]string{
+		{"engine", fmt.Sprintf("%s-%s", *util.DB, version)},
+		{"location", location},
+	}
+
+	tables := []struct {
+		name  string
+		value interface{}
+	}{
+		{"runs", &model.Run{}},
+		{"applications", &model.Application{}},
+		{"findings", &model.Finding{}},
+		{"rules", &model.Rule{}},
+		{"scoring models", &model.ScoringModel{}},
+		{"bins", &model.Bin{}},
+		{"score bins", &model.ScoreBin{}},
+	}
+
+	for _, table := range tables {
+		cnt := 0
+		if err = database.Model(table.value).Count(&cnt).Error; err != nil {
+			return nil, nil, err
+		}
+		data = append(data, []string{table.name, fmt.Sprint(cnt)})
+	}
+
+	return []string{"property", "value"}, data, nil
+}
@@ -13,24 +13,29 @@ import (
 	"regexp"
 	"strings"
 
+	"csa-app/model"
+	"csa-app/util"
 	"github.com/jinzhu/gorm"
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
-	"csa-app/model"
-	"csa-app/util"
 )
 
 const sqllite_driver string = "sqlite3"
 const postgres_driver string = "postgres"
 
 type Repositories struct {
-	Rules    RuleRepository
-	Findings FindingRepository
-	Run      RunRepository
-	Sloc     SlocRepository
-	Reports  ReportDataRepository
-	Bins     BinRepository
-	Scoring  ScoringRepository
+	Rules        RuleRepository
+	Findings     FindingRepository
+	Run          RunRepository
+	Sloc         SlocRepository
+	Reports      ReportDataRepository
+	Bins         BinRepository
+	Scoring      ScoringRepository
+	Groups       AppGroupRepository
+	Manifest     ManifestRepository
+	Tags         TagRepository
+	TechStack    TechStackRepository
+	Dependencies DependencyRepository
 }
 
 type OrmRepository struct {
@@ -70,12 +75,35 @@ func OpenDB(run *model.Run) *gorm.DB {
 	defer rows.Close()
 	for rows.Next() {
 		rows.Scan(&version)
-		fmt.Printf("CSA: %s DBEngine: %s-%s\tDBName: %s\n", util.App.Model().Version, *util.DB, version, *util.DBName)
+		fmt.Printf("CSA: %s DBEngine: %s-%s\tDBName: %s\n", util.App.Version(), *util.DB, version, *util.DBName)
 	}
 
 	return DB
 }
 
+// OpenSqliteDB opens an additional (read-only) sqlite csa database, such as a scan taken on a different machine, for comparison.
+func OpenSqliteDB(path string) (*gorm.DB, error) {
+
+	path, _ = filepath.Abs(path)
+
+	if !util.Exists(path) {
+		return nil, fmt.Errorf("database [%s] does not exist", path)
+	}
+
+	DB, err := gorm.Open(sqllite_driver, fmt.Sprintf("file:%s?mode=ro&_fk=true&_timeout=10000", path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !DB.HasTable(&model.Run{}) || !DB.HasTable(&model.Application{}) {
+		DB.Close()
+		return nil, fmt.Errorf("[%s] is not a csa database", path)
+	}
+
+	return DB, nil
+}
+
 func StartGormTransaction() *gorm.DB {
 	return database.Begin()
 }
@@ -165,9 +193,11 @@ func checkAndCreateDBDir() {
 func createSchema(database *gorm.DB) error {
 	//Create Run
 	db := database.AutoMigrate(model.Run{}, model.ReportRef{}, model.ReportHeader{}, model.ReportData{}, model.Rule{},
-		model.Recipe{}, &model.Pattern{}, model.Tag{}, model.Finding{}, model.FindingTag{}, model.FindingRecipe{},
+		model.Recipe{}, model.Exclusion{}, &model.Pattern{}, model.Tag{}, model.Finding{}, model.FindingTag{}, model.FindingRecipe{},
 		model.RunSloc{}, model.RuleMetric{}, model.Application{}, model.ApplicationTag{}, model.Bin{}, model.BinTag{},
-		model.ScoringModel{})
+		model.ScoringModel{}, model.AppGroup{}, model.AppGroupMember{},
+		model.ManifestEntry{}, model.TaxonomyTag{}, model.ScoreBin{}, model.TechAttribute{}, model.AppInterface{}, model.AppModule{}, model.AppLibrary{}, model.DotnetProject{},
+		model.AppServerResource{}, model.MigrationOutcome{}, model.RunShard{})
 
 	return db.Error
 }
@@ -183,6 +213,7 @@ func PopulateInitialData(run *model.Run, ruleRepository RuleRepository, binRepo
 	if count < 1 {
 		PopulateReportHeaders()
 	}
+	PopulateLicenseHeaders()
 
 	database.Find(&model.Rule{}).Count(&count)
 	if count < 1 {
@@ -212,6 +243,13 @@ func PopulateInitialData(run *model.Run, ruleRepository RuleRepository, binRepo
 			scoringRepo.LoadModels()
 		}
 	}
+
+	database.Find(&model.ScoreBin{}).Count(&count)
+	if count < 1 {
+		if run.Command != util.DeleteScoreBinCmd.FullCommand() {
+			scoringRepo.LoadScoreBins()
+		}
+	}
 }
 
 func CheckDBForError(shutdown bool, location string, msg string) bool {
@@ -249,26 +287,36 @@ func ExecuteRawSQL(sql string) error {
 
 func NewRepositoriesManager(db *gorm.DB) *Repositories {
 	return &Repositories{
-		Rules:    NewRuleRepository(db),
-		Sloc:     NewSlocRepository(db),
-		Findings: NewFindingRepository(db),
-		Run:      NewRunRepository(db),
-		Reports:  NewReportDataRepository(db),
-		Bins:     NewBinRepository(db),
-		Scoring:  NewScoringRepository(db),
+		Rules:        NewRuleRepository(db),
+		Sloc:         NewSlocRepository(db),
+		Findings:     NewFindingRepository(db),
+		Run:          NewRunRepository(db),
+		Reports:      NewReportDataRepository(db),
+		Bins:         NewBinRepository(db),
+		Scoring:      NewScoringRepository(db),
+		Groups:       NewAppGroupRepository(db),
+		Manifest:     NewManifestRepository(db),
+		Tags:         NewTagRepository(db),
+		TechStack:    NewTechStackRepository(db),
+		Dependencies: NewDependencyRepository(db),
 	}
 }
 
 func NewRepositoriesManagerForRun(run *model.Run) *Repositories {
 
 	repos := &Repositories{
-		Rules:    NewRuleRepositoryForRun(run),
-		Sloc:     NewSlocRepositoryForRun(run),
-		Findings: NewFindingRepositoryForRun(run),
-		Run:      NewRunRepositoryForRun(run),
-		Reports:  NewReportDataRepositoryForRun(run),
-		Bins:     NewBinRepositoryForRun(run),
-		Scoring:  NewScoringRepositoryForRun(run),
+		Rules:        NewRuleRepositoryForRun(run),
+		Sloc:         NewSlocRepositoryForRun(run),
+		Findings:     NewFindingRepositoryForRun(run),
+		Run:          NewRunRepositoryForRun(run),
+		Reports:      NewReportDataRepositoryForRun(run),
+		Bins:         NewBinRepositoryForRun(run),
+		Scoring:      NewScoringRepositoryForRun(run),
+		Groups:       NewAppGroupRepositoryForRun(run),
+		Manifest:     NewManifestRepositoryForRun(run),
+		Tags:         NewTagRepositoryForRun(run),
+		TechStack:    NewTechStackRepositoryForRun(run),
+		Dependencies: NewDependencyRepositoryForRun(run),
 	}
 
 	PopulateInitialData(run, repos.Rules, repos.Bins, repos.Scoring, run.DB)
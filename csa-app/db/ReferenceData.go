@@ -165,6 +165,16 @@ func PopulateReportHeaders() {
 
 }
 
+//PopulateLicenseHeaders adds the license columns of the third-party import report, including to databases created
+//before licenses were resolved
+func PopulateLicenseHeaders() {
+	for i, name := range []string{model.THIRD_PARTY_LICENSE_HEADER, model.THIRD_PARTY_LICENSE_TYPE_HEADER, model.THIRD_PARTY_LICENSE_SOURCE_HEADER} {
+		header := model.ReportHeader{ReportID: model.THIRD_PARTY_REPORT_ID, Name: name, Position: i + 2}
+		database.Where(&header).FirstOrCreate(&header)
+		CheckDBForError(true, "PopulateLicenseHeaders", "Error populating License Headers for ThirdParty Report!")
+	}
+}
+
 func GetHeadersForReport(reportId int) []model.ReportHeader {
 	var headers []model.ReportHeader
 	database.Where(&model.ReportHeader{ReportID: reportId}).Find(&headers)
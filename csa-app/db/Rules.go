@@ -148,6 +148,16 @@ func DeleteRecipes(recipes []model.Recipe) {
 	}
 }
 
+func DeleteExclusions(exclusions []model.Exclusion) {
+
+	for _, exclusion := range exclusions {
+		CheckDBError(false,
+			"Import Rules",
+			fmt.Sprintf("Failed Deleting Exclusion [%s]", exclusion.Pattern),
+			database.Delete(&exclusion).Error)
+	}
+}
+
 func DeleteTags(tags []model.Tag) {
 
 	for _, tag := range tags {
@@ -34,6 +34,10 @@ type ScoringRepository interface {
 	DeleteAllModels(notify bool) error
 	LoadModels()
 	ValidateModel(filename string, run *model.Run)
+	GetScoreBins() ([]model.ScoreBin, error)
+	SaveScoreBin(bin *model.ScoreBin) error
+	DeleteScoreBin(name string) error
+	LoadScoreBins()
 }
 
 func NewScoringRepository(db *gorm.DB) ScoringRepository {
@@ -329,6 +333,54 @@ func (repo *OrmRepository) LoadModels() {
 	}
 }
 
+func (repo *OrmRepository) GetScoreBins() ([]model.ScoreBin, error) {
+	var bins []model.ScoreBin
+	res := repo.dbconn.Order("min_score").Find(&bins)
+	return bins, res.Error
+}
+
+//SaveScoreBin creates the bin or updates the bin with its id or, without an id, its name. The bins must not overlap
+//once it is saved.
+func (repo *OrmRepository) SaveScoreBin(bin *model.ScoreBin) error {
+
+	bins, err := repo.GetScoreBins()
+	if err != nil {
+		return err
+	}
+
+	others := []model.ScoreBin{*bin}
+	for _, existing := range bins {
+		if (bin.ID != 0 && existing.ID == bin.ID) || (bin.ID == 0 && strings.EqualFold(existing.Name, bin.Name)) {
+			bin.ID = existing.ID
+			bin.CreatedAt = existing.CreatedAt
+			continue
+		}
+		others = append(others, existing)
+	}
+
+	if err = model.ValidateScoreBins(others); err != nil {
+		return err
+	}
+
+	return repo.dbconn.Save(bin).Error
+}
+
+func (repo *OrmRepository) DeleteScoreBin(name string) error {
+	res := repo.dbconn.Where("lower(name) = ?", strings.ToLower(name)).Delete(&model.ScoreBin{})
+	if res.Error == nil && res.RowsAffected == 0 {
+		return fmt.Errorf("score bin [%s] does not exist", name)
+	}
+	return res.Error
+}
+
+func (repo *OrmRepository) LoadScoreBins() {
+	for _, bin := range model.DefaultScoreBins() {
+		if err := repo.SaveScoreBin(&bin); err != nil {
+			util.App.Fatalf("Error saving score bin [%s]! Details: %s", bin.Name, err.Error())
+		}
+	}
+}
+
 func BootStrapModels(dir string, templatesDir string) ([]model.ScoringModel, error) {
 
 	models, err := getAllResourcesBasedModels(dir)
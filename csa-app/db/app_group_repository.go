@@ -0,0 +1,163 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db
+
+import (
+	"fmt"
+
+	"csa-app/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type AppGroupRepository interface {
+	GetGroups() ([]model.AppGroup, error)
+	GetGroup(name string) (model.AppGroup, error)
+	SaveGroup(group *model.AppGroup) error
+	DeleteGroup(name string) error
+	AddApplication(groupName string, appName string) error
+	RemoveApplication(groupName string, appName string) error
+	GetGroupRollups(runId uint) ([]model.GroupRollup, error)
+	AssignDomains(runId uint, mapping *model.DomainMapping) (int, error)
+	GetDomainRollups(runId uint) ([]model.DomainRollup, error)
+}
+
+func NewAppGroupRepository(db *gorm.DB) AppGroupRepository {
+	return &OrmRepository{
+		dbconn: db,
+	}
+}
+
+func NewAppGroupRepositoryForRun(run *model.Run) AppGroupRepository {
+	return &OrmRepository{
+		dbconn: run.DB,
+	}
+}
+
+func (repo *OrmRepository) GetGroups() ([]model.AppGroup, error) {
+	var groups []model.AppGroup
+	res := repo.dbconn.Preload("Applications").Order("name").Find(&groups)
+	return groups, res.Error
+}
+
+func (repo *OrmRepository) GetGroup(name string) (model.AppGroup, error) {
+	var group model.AppGroup
+	res := repo.dbconn.Where(&model.AppGroup{Name: name}).Preload("Applications").First(&group)
+	if res.RecordNotFound() {
+		return group, fmt.Errorf("group [%s] does not exist", name)
+	}
+	return group, res.Error
+}
+
+func (repo *OrmRepository) SaveGroup(group *model.AppGroup) error {
+	err := group.Validate()
+	if err != nil {
+		return err
+	}
+
+	if group.Parent != "" {
+		parent, err := repo.GetGroup(group.Parent)
+		if err != nil {
+			return err
+		}
+		if parent.Type != model.BUSINESS_UNIT_GROUP {
+			return fmt.Errorf("parent group [%s] of [%s] must be a %s", parent.Name, group.Name, model.BUSINESS_UNIT_GROUP)
+		}
+	}
+
+	return repo.dbconn.Save(group).Error
+}
+
+func (repo *OrmRepository) DeleteGroup(name string) error {
+	group, err := repo.GetGroup(name)
+	if err != nil {
+		return err
+	}
+
+	var children int
+	repo.dbconn.Model(&model.AppGroup{}).Where(&model.AppGroup{Parent: name}).Count(&children)
+	if children > 0 {
+		return fmt.Errorf("group [%s] still has [%d] portfolio(s) and cannot be deleted", name, children)
+	}
+
+	return repo.dbconn.Delete(&group).Error
+}
+
+func (repo *OrmRepository) AddApplication(groupName string, appName string) error {
+	group, err := repo.GetGroup(groupName)
+	if err != nil {
+		return err
+	}
+
+	if group.Type != model.PORTFOLIO_GROUP {
+		return fmt.Errorf("applications can only be added to a %s! group [%s] is a %s", model.PORTFOLIO_GROUP, group.Name, group.Type)
+	}
+
+	if group.HasApplication(appName) {
+		return nil
+	}
+
+	return repo.dbconn.Create(&model.AppGroupMember{AppGroupID: group.ID, Application: appName}).Error
+}
+
+func (repo *OrmRepository) RemoveApplication(groupName string, appName string) error {
+	group, err := repo.GetGroup(groupName)
+	if err != nil {
+		return err
+	}
+
+	res := repo.dbconn.Where(&model.AppGroupMember{AppGroupID: group.ID, Application: appName}).Delete(&model.AppGroupMember{})
+	if res.Error == nil && res.RowsAffected == 0 {
+		return fmt.Errorf("application [%s] is not a member of group [%s]", appName, groupName)
+	}
+	return res.Error
+}
+
+func (repo *OrmRepository) GetGroupRollups(runId uint) ([]model.GroupRollup, error) {
+	groups, err := repo.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := repo.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.RollupGroups(groups, apps), nil
+}
+
+//AssignDomains sets the business domain of the run's applications named in the mapping, returning how many were assigned.
+//Applications the mapping doesn't name keep their domain.
+func (repo *OrmRepository) AssignDomains(runId uint, mapping *model.DomainMapping) (int, error) {
+	if err := mapping.Validate(); err != nil {
+		return 0, err
+	}
+
+	tx := repo.dbconn.Begin()
+
+	assigned := 0
+	for _, domain := range mapping.DomainNames() {
+		res := tx.Model(&model.Application{}).Where("run_id = ? and name in (?)", runId, mapping.Domains[domain]).
+			UpdateColumn("business_domain", domain)
+		if res.Error != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("unable to assign domain [%s]: %v", domain, res.Error)
+		}
+		assigned += int(res.RowsAffected)
+	}
+
+	return assigned, tx.Commit().Error
+}
+
+func (repo *OrmRepository) GetDomainRollups(runId uint) ([]model.DomainRollup, error) {
+	apps, err := repo.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.RollupDomains(apps), nil
+}
@@ -0,0 +1,123 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/jinzhu/gorm"
+)
+
+//Bind variables of a statement. Sqlite binds at most 999
+const MAX_BIND_VARS = 999
+
+//insertRows inserts new rows of a model without associations (I.E. finding tags, report data) with multi-row insert
+//statements of up to the batch size rows. Their ids aren't read back.
+func insertRows(tx *gorm.DB, rows interface{}) error {
+
+	slice := reflect.ValueOf(rows)
+	if slice.Len() == 0 {
+		return nil
+	}
+
+	first := tx.NewScope(slice.Index(0).Interface())
+	var columns []string
+	for _, field := range insertedFields(first) {
+		columns = append(columns, first.Quote(field.DBName))
+	}
+
+	perStatement := util.SaveBatchSize()
+	if perStatement > MAX_BIND_VARS/len(columns) {
+		perStatement = MAX_BIND_VARS / len(columns)
+	}
+
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+	now := gorm.NowFunc()
+
+	for start := 0; start < slice.Len(); start += perStatement {
+		end := start + perStatement
+		if end > slice.Len() {
+			end = slice.Len()
+		}
+
+		var values []string
+		var vars []interface{}
+		for i := start; i < end; i++ {
+			scope := tx.NewScope(slice.Index(i).Interface())
+			for _, field := range insertedFields(scope) {
+				if (field.Name == "CreatedAt" || field.Name == "UpdatedAt") && field.IsBlank {
+					_ = field.Set(now)
+				}
+				vars = append(vars, field.Field.Interface())
+			}
+			values = append(values, placeholder)
+		}
+
+		statement := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", first.QuotedTableName(), strings.Join(columns, ","), strings.Join(values, ","))
+		if err := tx.Exec(statement, vars...).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//insertedFields are the columns of the row, its auto incremented primary key excluded
+func insertedFields(scope *gorm.Scope) (fields []*gorm.Field) {
+	for _, field := range scope.Fields() {
+		if field.IsNormal && !field.IsIgnored && !field.IsPrimaryKey {
+			fields = append(fields, field)
+		}
+	}
+	return
+}
+
+//SaveFindingsTransacted saves the findings, then their tags and recipes with multi-row statements
+func SaveFindingsTransacted(tx *gorm.DB, findings []*model.Finding) error {
+
+	var tags []*model.FindingTag
+	var recipes []*model.FindingRecipe
+
+	for _, finding := range findings {
+		if err := tx.Set("gorm:save_associations", false).Create(finding).Error; err != nil {
+			return err
+		}
+
+		for i := range finding.Tags {
+			finding.Tags[i].FindingID = finding.ID
+			tags = append(tags, &finding.Tags[i])
+		}
+
+		for i := range finding.Recipes {
+			finding.Recipes[i].FindingID = finding.ID
+			recipes = append(recipes, &finding.Recipes[i])
+		}
+	}
+
+	if err := insertRows(tx, tags); err != nil {
+		return err
+	}
+
+	return insertRows(tx, recipes)
+}
+
+//batches splits the rows in batches of the batch size
+func batches(rows int) (bounds [][2]int) {
+	size := util.SaveBatchSize()
+	for start := 0; start < rows; start += size {
+		end := start + size
+		if end > rows {
+			end = rows
+		}
+		bounds = append(bounds, [2]int{start, end})
+	}
+	return
+}
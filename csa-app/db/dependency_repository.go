@@ -0,0 +1,73 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db
+
+import (
+	"csa-app/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type DependencyRepository interface {
+	SaveAppInterfaces(interfaces []*model.AppInterface) error
+	GetAppInterfaces(runId uint) ([]model.AppInterface, error)
+	SaveAppLibraries(libraries []*model.AppLibrary) error
+	GetAppLibraries(runId uint) ([]model.AppLibrary, error)
+}
+
+func NewDependencyRepository(db *gorm.DB) DependencyRepository {
+	return &OrmRepository{
+		dbconn: db,
+	}
+}
+
+func NewDependencyRepositoryForRun(run *model.Run) DependencyRepository {
+	return &OrmRepository{
+		dbconn: run.DB,
+	}
+}
+
+func (repo *OrmRepository) SaveAppInterfaces(interfaces []*model.AppInterface) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, appInterface := range interfaces {
+		if err := tx.Create(appInterface).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// GetAppInterfaces returns the jars, endpoints, queues and databases the run's applications provide, consume or share
+func (repo *OrmRepository) GetAppInterfaces(runId uint) ([]model.AppInterface, error) {
+	interfaces := []model.AppInterface{}
+	res := repo.dbconn.Where("run_id = ?", runId).Order("application, kind, direction, name").Find(&interfaces)
+	return interfaces, res.Error
+}
+
+func (repo *OrmRepository) SaveAppLibraries(libraries []*model.AppLibrary) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, library := range libraries {
+		if err := tx.Create(library).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// GetAppLibraries returns the third-party libraries the run's applications declare
+func (repo *OrmRepository) GetAppLibraries(runId uint) ([]model.AppLibrary, error) {
+	libraries := []model.AppLibrary{}
+	res := repo.dbconn.Where("run_id = ?", runId).Order("application, ecosystem, name").Find(&libraries)
+	return libraries, res.Error
+}
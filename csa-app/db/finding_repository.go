@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,8 +38,42 @@ type FindingRepository interface {
 	GetFindingsDTOForRun(runid uint) ([]*model.FindingDTO, error)
 	GetFindingsDTOForRunAppLevel(runId uint, app string, card string, tags []string, includeFF bool) ([]*model.FindingDTO, error)
 	GetTagsForApp(runId uint, app string) ([]string, error)
+	GetFindingAggregates(runId uint, criteria *model.Criteria, groupBy string) ([]model.FindingAggregate, error)
+	GetFindingEffortCounts(runId uint, criteria *model.Criteria, groupBy string) (map[string]map[int]int, error)
+	GetThirdPartySummary(runId uint) ([]model.ThirdPartySummary, error)
+	GetAppFindings(runId uint, app string) ([]model.Finding, error)
+	GetAppFileFindings(runId uint, app string) ([]model.Finding, error)
+	DeleteFindings(ids []uint) error
+	SetFindingLifecycles(runId uint, app string, previous map[uint]uint) error
+	SetFindingTechVersions(runId uint, app string, versions map[string]string) error
+	SetFindingTriage(runId uint, ids []uint, triage model.FindingTriage) (int64, error)
+	CarryFindingTriage(triaged map[uint]model.FindingTriage) error
+	GetTriagedFindings(runId uint, app string) ([]model.Finding, error)
+	GetScoredFindings(runId uint) ([]model.Finding, error)
+	GetSecurityFindings(runId uint, app string) ([]model.Finding, error)
+	GetJdkFindings(runId uint, app string) ([]model.Finding, error)
+	GetResolvedFindings(runId uint, app string) ([]model.Finding, error)
+	GetFileStats(runId uint, app string) ([]model.FileStats, error)
+	GetAppTagTotals(runId uint) (map[string]model.TagTotals, error)
+	GetAppCategoryEffort(runId uint) (map[string]map[string]int, error)
+	GetWhatIfTotals(runId uint, whatIf []*model.Criteria) (map[string]*model.WhatIfTotals, error)
+	GetModuleDetails(runId uint) (map[string]map[string]model.ApplicationDetails, error)
+	GetModuleTagTotals(runId uint) (map[string]map[string]model.TagTotals, error)
+	GetScoreContributions(runId uint, app string) (map[string][]*model.ScoreContribution, error)
 }
 
+//Ids deleted by a statement. Sqlite binds at most 999 variables
+const ID_CHUNK_SIZE = 500
+
+//Findings outside of vendored/third-party code (null for findings recorded before third-party detection)
+const FIRST_PARTY_CLAUSE = "coalesce(third_party, '') = ''"
+
+//Findings not suppressed nor marked false positives by triage, which are kept for auditing only
+const UNSUPPRESSED_CLAUSE = "coalesce(triage, '') not in ('" + model.TRIAGE_SUPPRESSED + "', '" + model.TRIAGE_FALSE_POSITIVE + "')"
+
+//Findings counting against the app's score
+const SCORED_CLAUSE = FIRST_PARTY_CLAUSE + " and " + UNSUPPRESSED_CLAUSE
+
 func NewFindingRepository(db *gorm.DB) FindingRepository {
 	return &OrmRepository{
 		dbconn: db,
@@ -98,9 +133,9 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRun(runid uint) (findin
 	rows, err := findingRepository.dbconn.Table("findings").
 		Select("findings.id, findings.run_id, findings.filename, findings.fqn, findings.ext, findings.line, findings.rule, "+
 			"findings.pattern, findings.value, findings.advice, findings.effort, findings.readiness, findings.category, "+
-			"findings.criticality, findings.application, finding_tags.value as tag, finding_recipes.uri as recipe_uri").
+			"findings.criticality, coalesce(findings.severity, ''), findings.application, finding_tags.value as tag, finding_recipes.uri as recipe_uri").
 		Joins("left join finding_tags on findings.id = finding_tags.finding_id left join finding_recipes on findings.id = finding_recipes.finding_id").
-		Where("run_id = ?", runid).Order("findings.id asc").Rows()
+		Where("run_id = ? and "+UNSUPPRESSED_CLAUSE, runid).Order("findings.id asc").Rows()
 
 	lastFinding := &model.FindingDTO{ID: 0}
 	var tagList []string
@@ -113,10 +148,10 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRun(runid uint) (findin
 
 	for rows.Next() {
 		var id, run uint
-		var filename, fqn, ext, rule, pattern, value, advice, cat, crit, app, tag, recipe string
+		var filename, fqn, ext, rule, pattern, value, advice, cat, crit, sev, app, tag, recipe string
 		var line, effort, readiness int
 		var tagExists, rcpExists bool
-		rows.Scan(&id, &run, &filename, &fqn, &ext, &line, &rule, &pattern, &value, &advice, &effort, &readiness, &cat, &crit, &app, &tag, &recipe)
+		rows.Scan(&id, &run, &filename, &fqn, &ext, &line, &rule, &pattern, &value, &advice, &effort, &readiness, &cat, &crit, &sev, &app, &tag, &recipe)
 
 		if lastFinding.ID == id {
 			if tag != "" {
@@ -151,7 +186,7 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRun(runid uint) (findin
 			tagList = nil
 			rcpList = nil
 			//new finding
-			newFinding := &model.FindingDTO{ID: id, RunID: run, Filename: filename, Fqn: fqn, Ext: ext, Rule: rule, Pattern: pattern, Value: value, Line: line, Category: cat, Effort: effort, Readiness: readiness, Advice: advice, Application: app}
+			newFinding := &model.FindingDTO{ID: id, RunID: run, Filename: filename, Fqn: fqn, Ext: ext, Rule: rule, Pattern: pattern, Value: value, Line: line, Category: cat, Severity: sev, Effort: effort, Readiness: readiness, Advice: advice, Application: app}
 			findings = append(findings, newFinding)
 			lastFinding = newFinding
 
@@ -188,7 +223,7 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRunAppLevel(runId uint,
 		}
 	}
 
-	whereClause := "findings.run_id = ? and findings.application = ? and effort >= ? and effort <= ?"
+	whereClause := "findings.run_id = ? and findings.application = ? and effort >= ? and effort <= ? and " + UNSUPPRESSED_CLAUSE
 
 	if !includeFF {
 		whereClause += " and findings.category !='" + model.FILE_ANALYZED_CATEGORY + "'"
@@ -200,9 +235,9 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRunAppLevel(runId uint,
 
 		rows, err = findingRepository.dbconn.Table("findings").
 			Select(
-				"findings.id, findings.run_id, findings.filename, findings.fqn, findings.ext, findings.line, "+
+				"findings.id, findings.run_id, findings.filename, findings.fqn, findings.ext, findings.line, coalesce(findings.end_line, 0), "+
 					"findings.rule, findings.pattern, findings.value, findings.advice, "+levelCaseFragment()+
-					"findings.effort, findings.readiness, findings.note, findings.category, findings.criticality, findings.application, "+
+					"findings.effort, findings.readiness, findings.note, findings.category, findings.criticality, coalesce(findings.severity, ''), coalesce(findings.context, ''), findings.application, "+
 					"finding_tags.value as tag, finding_recipes.uri as recipe_uri").
 			Joins("left join finding_tags on findings.id = finding_tags.finding_id "+
 				"left join finding_recipes on findings.id = finding_recipes.finding_id").
@@ -214,9 +249,9 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRunAppLevel(runId uint,
 
 		rows, err = findingRepository.dbconn.Table("findings").
 			Select(
-				"findings.id, findings.run_id, findings.filename, findings.fqn, findings.ext, findings.line, "+
+				"findings.id, findings.run_id, findings.filename, findings.fqn, findings.ext, findings.line, coalesce(findings.end_line, 0), "+
 					"findings.rule, findings.pattern, findings.value, findings.advice, "+levelCaseFragment()+
-					"findings.effort, findings.readiness, findings.note, findings.category, findings.criticality, findings.application, "+
+					"findings.effort, findings.readiness, findings.note, findings.category, findings.criticality, coalesce(findings.severity, ''), coalesce(findings.context, ''), findings.application, "+
 					"finding_tags.value as tag, finding_recipes.uri as recipe_uri").
 			Joins("left join finding_tags on findings.id = finding_tags.finding_id "+
 				"left join finding_recipes on findings.id = finding_recipes.finding_id").
@@ -237,11 +272,11 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRunAppLevel(runId uint,
 	for rows.Next() {
 
 		var id, run uint
-		var filename, fqn, ext, rule, pattern, value, advice, cat, crit, note, app, tag, recipe, level string
-		var line, effort, readiness int
+		var filename, fqn, ext, rule, pattern, value, advice, cat, crit, sev, context, note, app, tag, recipe, level string
+		var line, endLine, effort, readiness int
 		var tagExists, rcpExists bool
 
-		rows.Scan(&id, &run, &filename, &fqn, &ext, &line, &rule, &pattern, &value, &advice, &level, &effort, &readiness, &note, &cat, &crit, &app, &tag, &recipe)
+		rows.Scan(&id, &run, &filename, &fqn, &ext, &line, &endLine, &rule, &pattern, &value, &advice, &level, &effort, &readiness, &note, &cat, &crit, &sev, &context, &app, &tag, &recipe)
 
 		if lastFinding.ID == id {
 			if tag != "" {
@@ -279,8 +314,8 @@ func (findingRepository *OrmRepository) GetFindingsDTOForRunAppLevel(runId uint,
 			//new finding
 			newFinding := &model.FindingDTO{
 				ID: id, RunID: run, Filename: filename, Fqn: fqn, Ext: ext, Rule: rule,
-				Pattern: pattern, Value: value, Line: line, Category: cat, Level: level,
-				Effort: effort, Readiness: readiness, Note: note, Advice: advice, Application: app,
+				Pattern: pattern, Value: value, Line: line, EndLine: endLine, Category: cat, Severity: sev, Level: level,
+				Effort: effort, Readiness: readiness, Note: note, Advice: advice, Context: context, Application: app,
 			}
 
 			findings = append(findings, newFinding)
@@ -353,8 +388,9 @@ func (findingRepository *OrmRepository) GetApplicationDetailsForRun(runid uint,
 
 	var applicationScores []model.ApplicationDetails
 
+	//Findings in vendored/third-party code are reported separately and do not count against the app
 	res := findingRepository.dbconn.Model(&model.Finding{}).
-		Where(&model.Finding{RunID: runid}).
+		Where(&model.Finding{RunID: runid}).Where(SCORED_CLAUSE).
 		Select("application, count(*) as findings, sum(effort) as raw_score").Group("application").
 		Order("raw_score desc, application asc").
 		Scan(&applicationScores)
@@ -366,7 +402,7 @@ func (findingRepository *OrmRepository) GetApplicationDetailsForRun(runid uint,
 	if err == nil {
 
 		rows, err := findingRepository.dbconn.Model(&model.Finding{}).
-			Select("application, count(*) as ciFindings").Where("run_id = ? and effort <> 0", runid).Group("application").Rows()
+			Select("application, count(*) as ciFindings").Where("run_id = ? and effort <> 0 and "+SCORED_CLAUSE, runid).Group("application").Rows()
 
 		if err == nil {
 			for rows.Next() {
@@ -381,7 +417,7 @@ func (findingRepository *OrmRepository) GetApplicationDetailsForRun(runid uint,
 			}
 
 			rows, err := findingRepository.dbconn.Model(&model.Finding{}).
-				Select("application, count(*) as crits").Where("run_id = ? and effort > ?", runid, model.CRIT_SCORE_THRESHOLD).Group("application").Rows()
+				Select("application, count(*) as crits").Where("run_id = ? and effort > ? and "+SCORED_CLAUSE, runid, model.CRIT_SCORE_THRESHOLD).Group("application").Rows()
 
 			if err == nil {
 				for rows.Next() {
@@ -394,6 +430,24 @@ func (findingRepository *OrmRepository) GetApplicationDetailsForRun(runid uint,
 					log.Debugf("Found app [%s] with [%d] crit findings", app, crits)
 					updateCritCount(applicationScores, app, crits)
 				}
+
+				rows, err := findingRepository.dbconn.Model(&model.Finding{}).
+					Select("application, severity, count(*) as severities").
+					Where("run_id = ? and severity in (?) and "+SCORED_CLAUSE, runid, []string{model.SEVERITY_CRITICAL, model.SEVERITY_HIGH}).
+					Group("application, severity").Rows()
+
+				if err == nil {
+					for rows.Next() {
+						var severities int
+						var app, severity string
+						err = rows.Scan(&app, &severity, &severities)
+						if err != nil {
+							return applicationScores, err
+						}
+						log.Debugf("Found app [%s] with [%d] %s severity findings", app, severities, severity)
+						updateSeverityCount(applicationScores, app, severity, severities)
+					}
+				}
 			}
 		}
 
@@ -454,7 +508,7 @@ func (findingRepository *OrmRepository) GetScoreCards(runid uint) ([]model.AppSc
 
 	res := findingRepository.dbconn.Model(&model.Finding{}).
 		Select("application, count(*) total").
-		Where(&model.Finding{RunID: runid}).
+		Where(&model.Finding{RunID: runid}).Where(UNSUPPRESSED_CLAUSE).
 		Group("application").
 		Order("total desc").
 		Scan(&scorecards)
@@ -483,7 +537,7 @@ func (findingRepository *OrmRepository) GetScoreCard(runid uint, app string, tag
 	var res *gorm.DB
 	findings := []model.Finding{}
 
-	whereClause := "findings.run_id = ? and findings.application = ?"
+	whereClause := "findings.run_id = ? and findings.application = ? and " + UNSUPPRESSED_CLAUSE
 
 	if !includeFF {
 		whereClause += " and findings.category !='" + model.FILE_ANALYZED_CATEGORY + "'"
@@ -539,7 +593,7 @@ func (findingRepository *OrmRepository) GetScoreCardDetails(runId uint, app stri
 
 	details := []model.ScoreCardDetail{}
 
-	whereClause := "run_id = ? and application =  ? and effort >= ? and effort <= ?"
+	whereClause := "run_id = ? and application =  ? and effort >= ? and effort <= ? and " + UNSUPPRESSED_CLAUSE
 
 	selectClause := "application, category, pattern, effort, " + levelCaseFragment() +
 		"count(*) as count, effort * count(*) as total"
@@ -577,6 +631,384 @@ func (findingRepository *OrmRepository) GetTagsForApp(runId uint, app string) ([
 	return tags, err
 }
 
+func (findingRepository *OrmRepository) GetFindingAggregates(runId uint, criteria *model.Criteria, groupBy string) (aggregates []model.FindingAggregate, err error) {
+
+	aggregateFragment := "count(distinct findings.id) as findings, sum(findings.effort) as effort, " +
+		"count(distinct findings.application) as applications, count(distinct findings.fqn) as files"
+
+	query, err := findingRepository.findingAggregateQuery(runId, criteria, groupBy, aggregateFragment, "")
+
+	if err != nil {
+		return
+	}
+
+	rows, err := query.Order("effort desc").Rows()
+
+	if err != nil {
+		log.Errorf("Error retrieving finding aggregates! Details: %v", err)
+		return
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var group sql.NullString
+		var effort sql.NullInt64
+		aggregate := model.FindingAggregate{}
+		err = rows.Scan(&group, &aggregate.Findings, &effort, &aggregate.Applications, &aggregate.Files)
+		if err != nil {
+			return
+		}
+		aggregate.Group = group.String
+		aggregate.Effort = int(effort.Int64)
+		aggregates = append(aggregates, aggregate)
+	}
+
+	return
+}
+
+//GetFindingEffortCounts returns, for each group, the number of findings at each effort value. Used to convert
+//effort into an organization's own estimation scale which cannot simply be summed in sql.
+func (findingRepository *OrmRepository) GetFindingEffortCounts(runId uint, criteria *model.Criteria, groupBy string) (counts map[string]map[int]int, err error) {
+
+	query, err := findingRepository.findingAggregateQuery(runId, criteria, groupBy, "findings.effort, count(distinct findings.id) as findings", "findings.effort")
+
+	if err != nil {
+		return
+	}
+
+	rows, err := query.Rows()
+
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+
+	counts = make(map[string]map[int]int)
+	for rows.Next() {
+		var group sql.NullString
+		var effort, findings int
+		err = rows.Scan(&group, &effort, &findings)
+		if err != nil {
+			return
+		}
+		if _, found := counts[group.String]; !found {
+			counts[group.String] = make(map[int]int)
+		}
+		counts[group.String][effort] += findings
+	}
+
+	return
+}
+
+func (findingRepository *OrmRepository) GetThirdPartySummary(runId uint) (summaries []model.ThirdPartySummary, err error) {
+
+	rows, err := findingRepository.dbconn.Model(&model.Finding{}).
+		Select("application, third_party, count(distinct fqn) as files, "+
+			"sum(case when category in (?) then 0 else 1 end) as findings, sum(effort) as effort",
+			[]string{model.FILE_ANALYZED_CATEGORY, model.SLOC_CATEGORY}).
+		Where("run_id = ? and not ("+FIRST_PARTY_CLAUSE+") and "+UNSUPPRESSED_CLAUSE, runId).
+		Group("application, third_party").
+		Order("application, effort desc").Rows()
+
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var effort sql.NullInt64
+		summary := model.ThirdPartySummary{}
+		err = rows.Scan(&summary.Application, &summary.Path, &summary.Files, &summary.Findings, &effort)
+		if err != nil {
+			return
+		}
+		summary.Effort = int(effort.Int64)
+		summaries = append(summaries, summary)
+	}
+
+	return
+}
+
+//GetAppFindings returns the rule findings (no file/sloc bookkeeping findings) of an application
+func (findingRepository *OrmRepository) GetAppFindings(runId uint, app string) ([]model.Finding, error) {
+	findings := []model.Finding{}
+	res := findingRepository.dbconn.Where("run_id = ? and application = ? and category not in (?)",
+		runId, app, []string{model.FILE_ANALYZED_CATEGORY, model.SLOC_CATEGORY}).Order("id").Find(&findings)
+	return findings, res.Error
+}
+
+//GetAppFileFindings returns the findings of an application's files, the file bookkeeping findings included, with their
+//tags and recipes
+func (findingRepository *OrmRepository) GetAppFileFindings(runId uint, app string) ([]model.Finding, error) {
+	findings := []model.Finding{}
+	res := findingRepository.dbconn.Where("run_id = ? and application = ? and category <> ?",
+		runId, app, model.SLOC_CATEGORY).Preload("Recipes").Preload("Tags").Order("id").Find(&findings)
+	return findings, res.Error
+}
+
+//DeleteFindings removes the findings with their tags and recipes. I.E. those of files analyzed again by a resumed run
+func (findingRepository *OrmRepository) DeleteFindings(ids []uint) error {
+
+	tx := findingRepository.dbconn.Begin()
+
+	for _, chunk := range idChunks(ids) {
+		for _, table := range []interface{}{&model.FindingTag{}, &model.FindingRecipe{}} {
+			if err := tx.Where("finding_id in (?)", chunk).Delete(table).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		if err := tx.Where("id in (?)", chunk).Delete(&model.Finding{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//idChunks splits the ids so queries stay below the bind variable limit of the databases
+func idChunks(ids []uint) (chunks [][]uint) {
+	for len(ids) > ID_CHUNK_SIZE {
+		chunks = append(chunks, ids[:ID_CHUNK_SIZE])
+		ids = ids[ID_CHUNK_SIZE:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return
+}
+
+//SetFindingLifecycles marks the application's findings recurring when they matched a previous finding and new otherwise
+func (findingRepository *OrmRepository) SetFindingLifecycles(runId uint, app string, previous map[uint]uint) error {
+
+	tx := findingRepository.dbconn.Begin()
+
+	err := tx.Model(&model.Finding{}).
+		Where("run_id = ? and application = ? and category not in (?)",
+			runId, app, []string{model.FILE_ANALYZED_CATEGORY, model.SLOC_CATEGORY}).
+		UpdateColumns(map[string]interface{}{"lifecycle": model.FINDING_NEW, "previous_id": 0}).Error
+
+	for id, previousId := range previous {
+		if err != nil {
+			break
+		}
+		err = tx.Model(&model.Finding{}).Where("id = ?", id).
+			UpdateColumns(map[string]interface{}{"lifecycle": model.FINDING_RECURRING, "previous_id": previousId}).Error
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+//SetFindingTechVersions attaches to the application's findings the technology version (I.E. Spring 4.3.9.RELEASE) of
+//their tags, versions maps a tag to its version. A finding with tags of several technologies gets the first in tag order.
+func (findingRepository *OrmRepository) SetFindingTechVersions(runId uint, app string, versions map[string]string) error {
+
+	tags := make([]string, 0, len(versions))
+	for tag := range versions {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	tx := findingRepository.dbconn.Begin()
+
+	for _, tag := range tags {
+		err := tx.Model(&model.Finding{}).
+			Where("run_id = ? and application = ? and coalesce(tech_version, '') = ''", runId, app).
+			Where("id in (?)", tx.Table("finding_tags").Select("finding_id").Where("lower(value) = ?", tag).SubQuery()).
+			UpdateColumn("tech_version", versions[tag]).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//SetFindingTriage sets the triage of the run's findings with the ids, returning how many were triaged. The none state
+//clears it.
+func (findingRepository *OrmRepository) SetFindingTriage(runId uint, ids []uint, triage model.FindingTriage) (int64, error) {
+
+	columns := map[string]interface{}{"triage": triage.State, "triage_note": triage.Reason, "triaged_at": triage.TriagedAt}
+	if triage.State == model.TRIAGE_NONE {
+		columns = map[string]interface{}{"triage": "", "triage_note": "", "triaged_at": nil}
+	}
+
+	res := findingRepository.dbconn.Model(&model.Finding{}).
+		Where("run_id = ? and id in (?)", runId, ids).
+		UpdateColumns(columns)
+
+	return res.RowsAffected, res.Error
+}
+
+//CarryFindingTriage sets the triage of findings carried forward from the findings of a previous run they matched
+func (findingRepository *OrmRepository) CarryFindingTriage(triaged map[uint]model.FindingTriage) error {
+
+	tx := findingRepository.dbconn.Begin()
+
+	for id, triage := range triaged {
+		err := tx.Model(&model.Finding{}).Where("id = ?", id).
+			UpdateColumns(map[string]interface{}{"triage": triage.State, "triage_note": triage.Reason, "triaged_at": triage.TriagedAt}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//GetTriagedFindings returns the run's triaged findings, suppressed ones included, for auditing. An empty app returns
+//those of every application in the run.
+func (findingRepository *OrmRepository) GetTriagedFindings(runId uint, app string) ([]model.Finding, error) {
+
+	query := findingRepository.dbconn.Where("run_id = ? and coalesce(triage, '') <> ''", runId)
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+
+	findings := []model.Finding{}
+	res := query.Order("application, fqn, line").Preload("Tags").Find(&findings)
+	return findings, res.Error
+}
+
+//GetScoredFindings returns the rule findings of the run counting against the applications' scores, without tags
+func (findingRepository *OrmRepository) GetScoredFindings(runId uint) ([]model.Finding, error) {
+	findings := []model.Finding{}
+	res := findingRepository.dbconn.Where("run_id = ? and category not in (?) and "+SCORED_CLAUSE,
+		runId, []string{model.FILE_ANALYZED_CATEGORY, model.SLOC_CATEGORY}).Order("id").Find(&findings)
+	return findings, res.Error
+}
+
+//GetSecurityFindings returns the run's security findings (categorized or tagged security, or mapped to a CWE) not
+//suppressed by triage, with their security tags only. An empty app returns those of every application.
+func (findingRepository *OrmRepository) GetSecurityFindings(runId uint, app string) ([]model.Finding, error) {
+
+	securityTags := "finding_tags.value = ? or finding_tags.value like ?"
+
+	var tags []model.FindingTag
+	query := findingRepository.dbconn.Table("finding_tags").Select("finding_tags.finding_id, finding_tags.value").
+		Joins("inner join findings on findings.id = finding_tags.finding_id").
+		Where("findings.run_id = ? and ("+securityTags+")", runId, model.SECURITY_TAG, model.CWE_TAG_PREFIX+"%")
+	if app != "" {
+		query = query.Where("findings.application = ?", app)
+	}
+	if err := query.Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	tagged := make(map[uint][]model.FindingTag)
+	for _, tag := range tags {
+		tagged[tag.FindingID] = append(tagged[tag.FindingID], tag)
+	}
+
+	findings := []model.Finding{}
+	query = findingRepository.dbconn.Where("run_id = ? and "+UNSUPPRESSED_CLAUSE, runId)
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+	res := query.Where("lower(category) = ? or id in (select finding_tags.finding_id from finding_tags inner join findings on findings.id = finding_tags.finding_id where findings.run_id = ? and ("+securityTags+"))",
+		model.SECURITY_TAG, runId, model.SECURITY_TAG, model.CWE_TAG_PREFIX+"%").Order("application, fqn, line").Find(&findings)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	for i := range findings {
+		findings[i].Tags = tagged[findings[i].ID]
+	}
+
+	return findings, nil
+}
+
+//GetJdkFindings returns the findings of the rules generated from the JDK API catalog. An empty app returns the findings
+//of every application in the run.
+func (findingRepository *OrmRepository) GetJdkFindings(runId uint, app string) ([]model.Finding, error) {
+	findings := []model.Finding{}
+	query := findingRepository.dbconn.Where("run_id = ? and category in (?) and "+UNSUPPRESSED_CLAUSE,
+		runId, []string{model.JDK_REMOVED_CATEGORY, model.JDK_DEPRECATED_CATEGORY})
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+	res := query.Order("application, rule, fqn, line").Find(&findings)
+	return findings, res.Error
+}
+
+//GetResolvedFindings returns the findings of each application's baseline run that no longer show up in the run.
+//An empty app returns the resolved findings of every application in the run.
+func (findingRepository *OrmRepository) GetResolvedFindings(runId uint, app string) ([]model.Finding, error) {
+
+	var apps []model.Application
+	query := findingRepository.dbconn.Where("run_id = ? and baseline_run_id > 0", runId)
+	if app != "" {
+		query = query.Where("name = ?", app)
+	}
+
+	if err := query.Order("name").Find(&apps).Error; err != nil {
+		return nil, err
+	}
+
+	resolved := []model.Finding{}
+	for _, application := range apps {
+		var findings []model.Finding
+		res := findingRepository.dbconn.
+			Where("run_id = ? and application = ? and category not in (?)",
+				application.BaselineRunID, application.Name, []string{model.FILE_ANALYZED_CATEGORY, model.SLOC_CATEGORY}).
+			Where("id not in (?)", findingRepository.dbconn.Model(&model.Finding{}).Select("previous_id").
+				Where("run_id = ? and application = ? and previous_id > 0", runId, application.Name).SubQuery()).
+			Order("fqn, line").Preload("Tags").Find(&findings)
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		resolved = append(resolved, findings...)
+	}
+
+	return resolved, nil
+}
+
+//GetFileStats returns the sloc, rule finding count and effort of every file analyzed for an application
+func (findingRepository *OrmRepository) GetFileStats(runId uint, app string) (stats []model.FileStats, err error) {
+
+	bookkeeping := []string{model.FILE_ANALYZED_CATEGORY, model.SLOC_CATEGORY}
+
+	rows, err := findingRepository.dbconn.Model(&model.Finding{}).
+		Select("fqn, max(case when category = ? then value else null end) as sloc, "+
+			"sum(case when category in (?) then 0 else 1 end) as findings, "+
+			"sum(case when category in (?) then 0 else effort end) as effort",
+			model.SLOC_CATEGORY, bookkeeping, bookkeeping).
+		Where("run_id = ? and application = ?", runId, app).
+		Group("fqn").
+		Order("fqn").Rows()
+
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var sloc sql.NullString
+		var effort sql.NullInt64
+		file := model.FileStats{}
+		if err = rows.Scan(&file.Fqn, &sloc, &file.Findings, &effort); err != nil {
+			return
+		}
+		file.Sloc, _ = strconv.Atoi(sloc.String)
+		file.Effort = int(effort.Int64)
+		stats = append(stats, file)
+	}
+
+	return
+}
+
 /*
  PRIVATE API ------------------------------------------------------------------------------------------------------------
 */
@@ -603,6 +1035,20 @@ func updateCritCount(scores []model.ApplicationDetails, app string, crits int) {
 	}
 }
 
+func updateSeverityCount(scores []model.ApplicationDetails, app string, severity string, cnt int) {
+	for idx := range scores {
+		if scores[idx].Application == app {
+			switch severity {
+			case model.SEVERITY_CRITICAL:
+				scores[idx].CriticalSeverity = cnt
+			case model.SEVERITY_HIGH:
+				scores[idx].HighSeverity = cnt
+			}
+			return
+		}
+	}
+}
+
 //TODO Pull this stuff up into the scoring service and orchestrate accross the repos!
 func addSlocCnt(findingRepository *OrmRepository, runId uint, scores []model.ApplicationDetails) {
 
@@ -630,7 +1076,7 @@ func addSlocCnt(findingRepository *OrmRepository, runId uint, scores []model.App
 //For now this is setup to update an existing set of scorecards with additional criticality details
 func addFindingsByCriticality(findingRepository *OrmRepository, criticality string, runId uint, bottomScore int, topScore int, cards []model.AppScoreCard) error {
 
-	whereClause := "run_id = ? and effort >= ? and effort <= ?"
+	whereClause := "run_id = ? and effort >= ? and effort <= ? and " + UNSUPPRESSED_CLAUSE
 	rows, err := findingRepository.dbconn.Model(&model.Finding{}).
 		Select("application, count(*) cnt").Where(whereClause, runId, bottomScore, topScore).Group("application").Rows()
 
@@ -737,3 +1183,392 @@ func levelCaseFragment() string {
 		model.High_criticality,
 		level)
 }
+
+func criterionColumn(key string) string {
+	switch key {
+	case model.CRITERION_LEVEL, model.CRITERION_EFFORT:
+		return "findings.effort"
+	case model.CRITERION_TAG:
+		return "finding_tags.value"
+	case model.CRITERION_SEVERITY:
+		//Findings of runs that predate severities have none
+		return "coalesce(findings.severity, '')"
+	case model.CRITERION_MODULE:
+		//Findings of single module applications and of runs that predate modules have none
+		return "coalesce(findings.module, '')"
+	}
+	return "findings." + key
+}
+
+//findingAggregateQuery builds the grouped (and filtered) findings query shared by the aggregate reports
+func (findingRepository *OrmRepository) findingAggregateQuery(runId uint, criteria *model.Criteria, groupBy string, aggregateFragment string, extraGroup string) (*gorm.DB, error) {
+
+	groupBy = strings.ToLower(groupBy)
+
+	if !model.IsCriterionKey(groupBy) {
+		return nil, fmt.Errorf("unknown group-by field [%s]! Must be one of (%s)", groupBy, strings.Join(model.CriterionKeys(), "|"))
+	}
+
+	whereClause := "findings.run_id = ? and findings.category not in (?) and " + findingRepository.unsuppressedClause()
+	args := []interface{}{runId, []string{model.FILE_ANALYZED_CATEGORY, model.SLOC_CATEGORY}}
+
+	for _, criterion := range criteria.Criterion() {
+		clause, clauseArgs := criterionClause(criterion)
+		whereClause += " and " + clause
+		args = append(args, clauseArgs...)
+	}
+
+	if extraGroup != "" {
+		extraGroup = ", " + extraGroup
+	}
+
+	query := findingRepository.dbconn.Table("findings")
+
+	switch groupBy {
+	case model.CRITERION_TAG:
+		query = query.Select("finding_tags.value as grp, " + aggregateFragment).
+			Joins("inner join finding_tags on finding_tags.finding_id = findings.id").
+			Group("finding_tags.value" + extraGroup)
+	case model.CRITERION_LEVEL:
+		query = query.Select(levelCaseFragment() + aggregateFragment).Group("level" + extraGroup)
+	default:
+		column := criterionColumn(groupBy)
+		query = query.Select(column + " as grp, " + aggregateFragment).Group(column + extraGroup)
+	}
+
+	return query.Where(whereClause, args...), nil
+}
+
+//unsuppressedClause is UNSUPPRESSED_CLAUSE unless the findings table predates triage, I.E. the baseline database of
+//`report compare` which is opened read-only and never migrated. None of its findings can be suppressed.
+func (findingRepository *OrmRepository) unsuppressedClause() string {
+	if !findingRepository.dbconn.Dialect().HasColumn("findings", "triage") {
+		return "1 = 1"
+	}
+	return UNSUPPRESSED_CLAUSE
+}
+
+//criterionClause translates a single query criterion into a where clause fragment and its arguments
+func criterionClause(criterion model.Criterion) (string, []interface{}) {
+
+	value := criterion.Value()
+	operator := criterion.MatchType()
+
+	if operator == model.LIKE_MATCH {
+		operator = "like"
+		value = "%" + value + "%"
+	}
+
+	switch criterion.Key() {
+	case model.CRITERION_TAG:
+		if criterion.MatchType() == model.NOT_EQUAL_MATCH {
+			return "not exists (select 1 from finding_tags where finding_tags.finding_id = findings.id and finding_tags.value = ?)",
+				[]interface{}{strings.ToLower(value)}
+		}
+		return "exists (select 1 from finding_tags where finding_tags.finding_id = findings.id and finding_tags.value " + operator + " ?)",
+			[]interface{}{strings.ToLower(value)}
+	case model.CRITERION_LEVEL:
+		bottom, top := getEffortBand(value)
+		if criterion.MatchType() == model.NOT_EQUAL_MATCH {
+			return "findings.effort not between ? and ?", []interface{}{bottom, top}
+		}
+		return "findings.effort between ? and ?", []interface{}{bottom, top}
+	case model.CRITERION_EFFORT, model.CRITERION_READINESS:
+		number, _ := strconv.Atoi(value)
+		return criterionColumn(criterion.Key()) + " " + operator + " ?", []interface{}{number}
+	}
+
+	return criterionColumn(criterion.Key()) + " " + operator + " ?", []interface{}{value}
+}
+
+//GetAppTagTotals returns the number and effort of each application's first party findings by tag
+func (findingRepository *OrmRepository) GetAppTagTotals(runId uint) (map[string]model.TagTotals, error) {
+
+	rows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, finding_tags.value, count(distinct findings.id), sum(findings.effort)").
+		Joins("inner join finding_tags on finding_tags.finding_id = findings.id").
+		Where("findings.run_id = ? and "+SCORED_CLAUSE, runId).
+		Group("findings.application, finding_tags.value").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[string]model.TagTotals)
+	for rows.Next() {
+		var app, tag string
+		var effort sql.NullInt64
+		total := model.TagTotal{}
+		if err = rows.Scan(&app, &tag, &total.Findings, &effort); err != nil {
+			return nil, err
+		}
+		total.Effort = int(effort.Int64)
+
+		if totals[app] == nil {
+			totals[app] = make(model.TagTotals)
+		}
+		totals[app][tag] = total
+	}
+
+	return totals, rows.Err()
+}
+
+//GetAppCategoryEffort returns the effort of each application's first party findings by category. Positive findings,
+//whose effort is negative, take no effort.
+func (findingRepository *OrmRepository) GetAppCategoryEffort(runId uint) (map[string]map[string]int, error) {
+
+	rows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, findings.category, sum(findings.effort)").
+		Where("findings.run_id = ? and findings.effort > 0 and "+SCORED_CLAUSE, runId).
+		Group("findings.application, findings.category").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	efforts := make(map[string]map[string]int)
+	for rows.Next() {
+		var app, category string
+		var effort sql.NullInt64
+		if err = rows.Scan(&app, &category, &effort); err != nil {
+			return nil, err
+		}
+
+		if efforts[app] == nil {
+			efforts[app] = make(map[string]int)
+		}
+		efforts[app][category] = int(effort.Int64)
+	}
+
+	return efforts, rows.Err()
+}
+
+//GetWhatIfTotals returns the totals of each application's scored findings matching any of the what-if criteria, those
+//a what-if resolves
+func (findingRepository *OrmRepository) GetWhatIfTotals(runId uint, whatIf []*model.Criteria) (map[string]*model.WhatIfTotals, error) {
+
+	var matches []string
+	var args []interface{}
+	for _, criteria := range whatIf {
+		var clauses []string
+		for _, criterion := range criteria.Criterion() {
+			clause, clauseArgs := criterionClause(criterion)
+			clauses = append(clauses, clause)
+			args = append(args, clauseArgs...)
+		}
+		if len(clauses) == 0 {
+			clauses = append(clauses, "1 = 1")
+		}
+		matches = append(matches, "("+strings.Join(clauses, " and ")+")")
+	}
+	if len(matches) == 0 {
+		return map[string]*model.WhatIfTotals{}, nil
+	}
+
+	whereClause := "findings.run_id = ? and " + SCORED_CLAUSE + " and (" + strings.Join(matches, " or ") + ")"
+	args = append([]interface{}{runId}, args...)
+
+	rows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, count(*), sum(findings.effort), "+
+			"sum(case when findings.effort <> 0 then 1 else 0 end), "+
+			"sum(case when findings.effort > ? then 1 else 0 end), "+
+			"sum(case when findings.severity = ? then 1 else 0 end), "+
+			"sum(case when findings.severity = ? then 1 else 0 end)", model.CRIT_SCORE_THRESHOLD, model.SEVERITY_CRITICAL, model.SEVERITY_HIGH).
+		Where(whereClause, args...).Group("findings.application").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[string]*model.WhatIfTotals)
+	for rows.Next() {
+		var app string
+		var effort sql.NullInt64
+		total := &model.WhatIfTotals{Tags: make(model.TagTotals)}
+		if err = rows.Scan(&app, &total.Findings, &effort, &total.CIFindings, &total.NumCrits, &total.CriticalCnt, &total.HighCnt); err != nil {
+			return nil, err
+		}
+		total.RawScore = int(effort.Int64)
+		totals[app] = total
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagRows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, finding_tags.value, count(distinct findings.id), sum(findings.effort)").
+		Joins("inner join finding_tags on finding_tags.finding_id = findings.id").
+		Where(whereClause, args...).
+		Group("findings.application, finding_tags.value").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var app, tag string
+		var effort sql.NullInt64
+		total := model.TagTotal{}
+		if err = tagRows.Scan(&app, &tag, &total.Findings, &effort); err != nil {
+			return nil, err
+		}
+		total.Effort = int(effort.Int64)
+		if totals[app] != nil {
+			totals[app].Tags[tag] = total
+		}
+	}
+
+	return totals, tagRows.Err()
+}
+
+//GetModuleDetails returns the details of the first party findings of each module of the run's multi-module
+//applications, by application and module
+func (findingRepository *OrmRepository) GetModuleDetails(runId uint) (map[string]map[string]model.ApplicationDetails, error) {
+
+	rows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, findings.module, count(*), sum(findings.effort), "+
+			"sum(case when findings.effort <> 0 then 1 else 0 end), "+
+			"sum(case when findings.effort > ? then 1 else 0 end), "+
+			"sum(case when findings.severity = ? then 1 else 0 end), "+
+			"sum(case when findings.severity = ? then 1 else 0 end)", model.CRIT_SCORE_THRESHOLD, model.SEVERITY_CRITICAL, model.SEVERITY_HIGH).
+		Where("findings.run_id = ? and coalesce(findings.module, '') <> '' and "+SCORED_CLAUSE, runId).
+		Group("findings.application, findings.module").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	details := make(map[string]map[string]model.ApplicationDetails)
+	for rows.Next() {
+		var module string
+		var effort sql.NullInt64
+		moduleDetails := model.ApplicationDetails{}
+		if err = rows.Scan(&moduleDetails.Application, &module, &moduleDetails.Findings, &effort, &moduleDetails.CIFindings,
+			&moduleDetails.NumCrits, &moduleDetails.CriticalSeverity, &moduleDetails.HighSeverity); err != nil {
+			return nil, err
+		}
+		moduleDetails.RawScore = int(effort.Int64)
+
+		if details[moduleDetails.Application] == nil {
+			details[moduleDetails.Application] = make(map[string]model.ApplicationDetails)
+		}
+		details[moduleDetails.Application][module] = moduleDetails
+	}
+
+	return details, rows.Err()
+}
+
+//GetModuleTagTotals returns the number and effort of the first party findings of each module of the run's
+//multi-module applications by tag, by application and module
+func (findingRepository *OrmRepository) GetModuleTagTotals(runId uint) (map[string]map[string]model.TagTotals, error) {
+
+	rows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, findings.module, finding_tags.value, count(distinct findings.id), sum(findings.effort)").
+		Joins("inner join finding_tags on finding_tags.finding_id = findings.id").
+		Where("findings.run_id = ? and coalesce(findings.module, '') <> '' and "+SCORED_CLAUSE, runId).
+		Group("findings.application, findings.module, finding_tags.value").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[string]map[string]model.TagTotals)
+	for rows.Next() {
+		var app, module, tag string
+		var effort sql.NullInt64
+		total := model.TagTotal{}
+		if err = rows.Scan(&app, &module, &tag, &total.Findings, &effort); err != nil {
+			return nil, err
+		}
+		total.Effort = int(effort.Int64)
+
+		if totals[app] == nil {
+			totals[app] = make(map[string]model.TagTotals)
+		}
+		if totals[app][module] == nil {
+			totals[app][module] = make(model.TagTotals)
+		}
+		totals[app][module][tag] = total
+	}
+
+	return totals, rows.Err()
+}
+
+//GetScoreContributions returns the totals of each application's scored findings by rule, with the totals a what-if
+//resolving them would resolve. An empty app returns the contributions of every application.
+func (findingRepository *OrmRepository) GetScoreContributions(runId uint, app string) (map[string][]*model.ScoreContribution, error) {
+
+	whereClause := "findings.run_id = ? and " + SCORED_CLAUSE
+	args := []interface{}{runId}
+	if app != "" {
+		whereClause += " and findings.application = ?"
+		args = append(args, app)
+	}
+
+	rows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, findings.rule, findings.category, count(*), sum(findings.effort), "+
+			"sum(case when findings.effort <> 0 then 1 else 0 end), "+
+			"sum(case when findings.effort > ? then 1 else 0 end), "+
+			"sum(case when findings.severity = ? then 1 else 0 end), "+
+			"sum(case when findings.severity = ? then 1 else 0 end)", model.CRIT_SCORE_THRESHOLD, model.SEVERITY_CRITICAL, model.SEVERITY_HIGH).
+		Where(whereClause, args...).
+		Group("findings.application, findings.rule, findings.category").Order("findings.application, findings.rule").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contributions := make(map[string][]*model.ScoreContribution)
+	byRule := make(map[string]*model.ScoreContribution)
+	for rows.Next() {
+		var application string
+		var effort sql.NullInt64
+		contribution := &model.ScoreContribution{Totals: &model.WhatIfTotals{Tags: make(model.TagTotals)}}
+		if err = rows.Scan(&application, &contribution.Rule, &contribution.Category, &contribution.Totals.Findings, &effort,
+			&contribution.Totals.CIFindings, &contribution.Totals.NumCrits, &contribution.Totals.CriticalCnt, &contribution.Totals.HighCnt); err != nil {
+			return nil, err
+		}
+		contribution.Totals.RawScore = int(effort.Int64)
+		contribution.Findings = contribution.Totals.Findings
+		contribution.Effort = contribution.Totals.RawScore
+
+		contributions[application] = append(contributions[application], contribution)
+		byRule[application+"/"+contribution.Rule+"/"+contribution.Category] = contribution
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagRows, err := findingRepository.dbconn.Table("findings").
+		Select("findings.application, findings.rule, findings.category, finding_tags.value, count(distinct findings.id), sum(findings.effort)").
+		Joins("inner join finding_tags on finding_tags.finding_id = findings.id").
+		Where(whereClause, args...).
+		Group("findings.application, findings.rule, findings.category, finding_tags.value").Rows()
+
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var application, rule, category, tag string
+		var effort sql.NullInt64
+		total := model.TagTotal{}
+		if err = tagRows.Scan(&application, &rule, &category, &tag, &total.Findings, &effort); err != nil {
+			return nil, err
+		}
+		total.Effort = int(effort.Int64)
+		if contribution := byRule[application+"/"+rule+"/"+category]; contribution != nil {
+			contribution.Totals.Tags[tag] = total
+		}
+	}
+
+	return contributions, tagRows.Err()
+}
@@ -65,6 +65,33 @@ func TestSaveAndRetrieveFindings(t *testing.T) {
 
 }
 
+func TestSaveFindingsTransacted(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findings := []*model.Finding{
+		createASampleFindingWithPatternAndTag(23, "app-1", 1, "some category", "", "tag1"),
+		createASampleFindingWithPatternAndTag(23, "app-1", 2, "some category", "", "tag2"),
+	}
+
+	tx := database.Begin()
+	assert.Nil(t, db.SaveFindingsTransacted(tx, findings))
+	assert.Nil(t, tx.Commit().Error)
+
+	findingsFromDb, err := db.NewFindingRepository(database).GetAppFileFindings(23, "app-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(findingsFromDb))
+	for i, finding := range findingsFromDb {
+		assert.Equal(t, findings[i].ID, finding.ID)
+		assert.Equal(t, 2, len(finding.Recipes))
+		assert.Equal(t, 1, len(finding.Tags))
+		assert.Equal(t, findings[i].Tags[0].Value, finding.Tags[0].Value)
+	}
+}
+
 func TestGetApplicationScores(t *testing.T) {
 
 	_, dir, database, err := db_test_support.OpenTestDb()
@@ -97,6 +124,185 @@ func TestGetApplicationScores(t *testing.T) {
 
 }
 
+func TestThirdPartyFindingsExcludedFromAppScore(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findingRepository := db.NewFindingRepository(database)
+	findingRepository.SaveFinding(createASampleFinding(22, "app-1", 2, "some category"))
+	findingRepository.SaveFinding(createASampleFinding(22, "app-1", 3, "some category"))
+
+	vendored := createASampleFinding(22, "app-1", 40, "some category")
+	vendored.ThirdParty = "vendor"
+	findingRepository.SaveFinding(vendored)
+
+	appScores, _ := findingRepository.GetApplicationDetailsForRun(22, 10, 1, false)
+
+	assert.Equal(t, 1, len(appScores))
+	assert.Equal(t, 5, appScores[0].RawScore)
+	assert.Equal(t, 2, appScores[0].Findings)
+
+	summaries, err := findingRepository.GetThirdPartySummary(22)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(summaries))
+	assert.Equal(t, "vendor", summaries[0].Path)
+	assert.Equal(t, 1, summaries[0].Findings)
+	assert.Equal(t, 40, summaries[0].Effort)
+}
+
+func TestCarryFindingTriage(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findingRepository := db.NewFindingRepository(database)
+	suppressed := createASampleFinding(23, "app-1", 2, "some category")
+	findingRepository.SaveFinding(suppressed)
+	findingRepository.SaveFinding(createASampleFinding(23, "app-1", 3, "some category"))
+
+	triagedAt, _ := time.Parse(time.RFC3339, "2021-02-01T00:00:00Z")
+	err = findingRepository.CarryFindingTriage(map[uint]model.FindingTriage{
+		suppressed.ID: {State: model.TRIAGE_SUPPRESSED, Reason: "generated code", TriagedAt: &triagedAt},
+	})
+	assert.NoError(t, err)
+
+	triaged, err := findingRepository.GetTriagedFindings(23, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(triaged))
+	assert.Equal(t, suppressed.ID, triaged[0].ID)
+	assert.Equal(t, model.TRIAGE_SUPPRESSED, triaged[0].Triage)
+	assert.Equal(t, "generated code", triaged[0].TriageNote)
+	assert.True(t, triagedAt.Equal(*triaged[0].TriagedAt))
+}
+
+func TestSuppressedFindingsLeftOutOfAggregates(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findingRepository := db.NewFindingRepository(database)
+	findingRepository.SaveFinding(createASampleFinding(24, "app-1", 2, "some category"))
+
+	suppressed := createASampleFinding(24, "app-1", 30, "some category")
+	suppressed.Triage = model.TRIAGE_SUPPRESSED
+	findingRepository.SaveFinding(suppressed)
+
+	falsePositive := createASampleFinding(24, "app-1", 40, "some category")
+	falsePositive.Triage = model.TRIAGE_FALSE_POSITIVE
+	findingRepository.SaveFinding(falsePositive)
+
+	accepted := createASampleFinding(24, "app-1", 5, "some category")
+	accepted.Triage = model.TRIAGE_ACCEPTED
+	findingRepository.SaveFinding(accepted)
+
+	vendored := createASampleFinding(24, "app-1", 50, "some category")
+	vendored.ThirdParty = "vendor"
+	findingRepository.SaveFinding(vendored)
+
+	criteria, _ := model.ParseCriteria("")
+	aggregates, err := findingRepository.GetFindingAggregates(24, criteria, model.CRITERION_RULE)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(aggregates))
+	assert.Equal(t, 3, aggregates[0].Findings)
+	assert.Equal(t, 57, aggregates[0].Effort)
+
+	//Third party findings don't count against the score either
+	totals, err := findingRepository.GetAppTagTotals(24)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, totals["app-1"]["default tag"].Findings)
+	assert.Equal(t, 7, totals["app-1"]["default tag"].Effort)
+}
+
+func TestModuleDetails(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findingRepository := db.NewFindingRepository(database)
+	for _, module := range []string{"billing", "billing", model.ROOT_MODULE, ""} {
+		finding := createASampleFinding(26, "app-1", 3, "some category")
+		finding.Module = module
+		finding.Severity = model.SEVERITY_HIGH
+		findingRepository.SaveFinding(finding)
+	}
+
+	details, err := findingRepository.GetModuleDetails(26)
+	assert.NoError(t, err)
+	assert.Len(t, details["app-1"], 2, "findings without a module are left out")
+	assert.Equal(t, 2, details["app-1"]["billing"].Findings)
+	assert.Equal(t, 6, details["app-1"]["billing"].RawScore)
+	assert.Equal(t, 2, details["app-1"]["billing"].HighSeverity)
+	assert.Equal(t, 1, details["app-1"][model.ROOT_MODULE].Findings)
+
+	totals, err := findingRepository.GetModuleTagTotals(26)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, totals["app-1"]["billing"]["default tag"].Findings)
+}
+
+func TestScoreContributions(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findingRepository := db.NewFindingRepository(database)
+	findingRepository.SaveFinding(createASampleFindingWithPatternAndTagAndRule(27, "app-1", 3, "ejb", "", "", "ejb-remote"))
+	findingRepository.SaveFinding(createASampleFindingWithPatternAndTagAndRule(27, "app-1", 5, "ejb", "", "", "ejb-remote"))
+	findingRepository.SaveFinding(createASampleFindingWithPatternAndTagAndRule(27, "app-1", 1, "logging", "", "", "log4j"))
+	findingRepository.SaveFinding(createASampleFindingWithPatternAndTagAndRule(27, "app-2", 2, "logging", "", "", "log4j"))
+
+	contributions, err := findingRepository.GetScoreContributions(27, "app-1")
+	assert.NoError(t, err)
+	assert.Len(t, contributions, 1, "only the app's contributions are returned")
+	assert.Len(t, contributions["app-1"], 2)
+
+	ejb := contributions["app-1"][0]
+	assert.Equal(t, "ejb-remote", ejb.Rule)
+	assert.Equal(t, "ejb", ejb.Category)
+	assert.Equal(t, 2, ejb.Findings)
+	assert.Equal(t, 8, ejb.Effort)
+	assert.Equal(t, 8, ejb.Totals.RawScore)
+	assert.Equal(t, 2, ejb.Totals.Tags["default tag"].Findings)
+
+	contributions, err = findingRepository.GetScoreContributions(27, "")
+	assert.NoError(t, err)
+	assert.Len(t, contributions, 2)
+}
+
+func TestAggregatesOfDatabasePredatingTriage(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findingRepository := db.NewFindingRepository(database)
+	findingRepository.SaveFinding(createASampleFinding(25, "app-1", 2, "some category"))
+	findingRepository.SaveFinding(createASampleFinding(25, "app-1", 3, "some category"))
+
+	//A baseline database of `report compare` is never migrated
+	assert.NoError(t, database.Exec("drop index if exists idx_findings_triage").Error)
+	assert.NoError(t, database.Exec("alter table findings drop column triage").Error)
+
+	criteria, _ := model.ParseCriteria("")
+	aggregates, err := findingRepository.GetFindingAggregates(25, criteria, model.CRITERION_RULE)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(aggregates))
+	assert.Equal(t, 2, aggregates[0].Findings)
+	assert.Equal(t, 5, aggregates[0].Effort)
+}
+
 func TestTopApisInFindings(t *testing.T) {
 
 	_, dir, database, err := db_test_support.OpenTestDb()
@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db
+
+import (
+	"csa-app/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type ManifestRepository interface {
+	SaveManifest(entries []*model.ManifestEntry) error
+	GetManifest(runId uint, app string) ([]model.ManifestEntry, error)
+	DeleteManifestEntries(ids []uint) error
+}
+
+func NewManifestRepository(db *gorm.DB) ManifestRepository {
+	return &OrmRepository{
+		dbconn: db,
+	}
+}
+
+func NewManifestRepositoryForRun(run *model.Run) ManifestRepository {
+	return &OrmRepository{
+		dbconn: run.DB,
+	}
+}
+
+func (repo *OrmRepository) SaveManifest(entries []*model.ManifestEntry) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, entry := range entries {
+		if err := tx.Create(entry).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//GetManifest returns the files scanned by the run. An empty app returns the files of every application.
+func (repo *OrmRepository) GetManifest(runId uint, app string) ([]model.ManifestEntry, error) {
+	entries := []model.ManifestEntry{}
+
+	query := repo.dbconn.Where("run_id = ?", runId)
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+
+	res := query.Order("application, path").Find(&entries)
+	return entries, res.Error
+}
+
+//DeleteManifestEntries removes the entries of files analyzed again by a resumed run
+func (repo *OrmRepository) DeleteManifestEntries(ids []uint) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, chunk := range idChunks(ids) {
+		if err := tx.Where("id in (?)", chunk).Delete(&model.ManifestEntry{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
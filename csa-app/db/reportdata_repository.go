@@ -12,6 +12,7 @@ import (
 
 type ReportDataRepository interface {
 	SaveReportData(reportData *model.ReportData) error
+	SaveReportDataBatch(reportData []*model.ReportData) error
 }
 
 func NewReportDataRepository(db *gorm.DB) ReportDataRepository {
@@ -30,3 +31,20 @@ func (reportDataRepository *OrmRepository) SaveReportData(reportData *model.Repo
 	res := reportDataRepository.dbconn.Create(reportData)
 	return res.Error
 }
+
+//SaveReportDataBatch inserts the report data a batch per transaction, with multi-row statements
+func (reportDataRepository *OrmRepository) SaveReportDataBatch(reportData []*model.ReportData) error {
+
+	for _, batch := range batches(len(reportData)) {
+		tx := reportDataRepository.dbconn.Begin()
+		if err := insertRows(tx, reportData[batch[0]:batch[1]]); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
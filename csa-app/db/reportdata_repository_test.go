@@ -52,3 +52,27 @@ func TestSaveAndRetrieveReportData(t *testing.T) {
 	assert.Nil(t, err)
 
 }
+
+func TestSaveReportDataBatch(t *testing.T) {
+
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var reportData []*model.ReportData
+	for i := 0; i < 3; i++ {
+		reportData = append(reportData, &model.ReportData{RunID: 4, ReportID: 2, Data1: "data1", Data2: "data2"})
+	}
+
+	reportDataRepository := db.NewReportDataRepository(database)
+	assert.Nil(t, reportDataRepository.SaveReportDataBatch(reportData))
+	assert.Nil(t, reportDataRepository.SaveReportDataBatch(nil))
+
+	var saved []model.ReportData
+	database.Where("run_id = ?", 4).Find(&saved)
+	assert.Equal(t, 3, len(saved))
+	assert.Equal(t, "data2", saved[2].Data2)
+	assert.False(t, saved[0].CreatedAt.IsZero())
+}
@@ -25,6 +25,7 @@ import (
 type RuleRepository interface {
 	SaveRules(rules []model.Rule) ([]model.Rule, error)
 	SaveRule(rule model.Rule) (model.Rule, error)
+	UpsertRule(rule model.Rule) error
 	GetRuleByName(name string) (model.Rule, error)
 	GetRules() ([]model.Rule, error)
 	GetRulesForRun(run *model.Run) ([]model.Rule, error)
@@ -34,7 +35,7 @@ type RuleRepository interface {
 	LoadRules()
 	DeleteRule(ruleName string) error
 	DeleteAllRules() error
-	ValidateRule(filename string, run *model.Run)
+	ValidateRule(filename string, run *model.Run) bool
 	GetRuleMetrics(runId uint) ([]model.RuleMetric, error)
 }
 
@@ -54,7 +55,7 @@ func NewRuleRepositoryForRun(run *model.Run) RuleRepository {
 
 func (ruleRepository *OrmRepository) GetRules() ([]model.Rule, error) {
 	var rules []model.Rule
-	resp := ruleRepository.dbconn.Preload("Patterns").Preload("Recipes").Preload("Tags").Find(&rules)
+	resp := ruleRepository.dbconn.Preload("Patterns").Preload("Recipes").Preload("Tags").Preload("Unless").Find(&rules)
 	return rules, resp.Error
 }
 
@@ -84,9 +85,33 @@ func (ruleRepository *OrmRepository) SaveRule(rule model.Rule) (model.Rule, erro
 	return rule, res.Error
 }
 
+//UpsertRule updates the rule with the same name in place, removing its dropped patterns, recipes, tags and
+//exclusions, or creates it when there is none
+func (ruleRepository *OrmRepository) UpsertRule(rule model.Rule) error {
+	existingRule, _ := ruleRepository.GetRuleByName(rule.Name)
+	if existingRule.Name == rule.Name {
+		if *util.Verbose {
+			fmt.Printf("Rule [%s] exists! Updating!", rule.Name)
+		}
+		deletedPatterns, deletedRecipes, deletedTags, deletedExclusions := existingRule.UpdateRule(rule)
+		DeletePatterns(deletedPatterns)
+		DeleteRecipes(deletedRecipes)
+		DeleteTags(deletedTags)
+		DeleteExclusions(deletedExclusions)
+		_, err := ruleRepository.SaveRule(existingRule)
+		return err
+	}
+
+	if *util.Verbose {
+		fmt.Printf("Rule [%s] does not exist! Creating!", rule.Name)
+	}
+	_, err := ruleRepository.SaveRule(rule)
+	return err
+}
+
 func (ruleRepository *OrmRepository) GetRuleByName(name string) (model.Rule, error) {
 	var rule model.Rule
-	res := ruleRepository.dbconn.Where(&model.Rule{Name: name}).Preload("Patterns").Preload("Recipes").Preload("Tags").Find(&rule)
+	res := ruleRepository.dbconn.Where(&model.Rule{Name: name}).Preload("Patterns").Preload("Recipes").Preload("Tags").Preload("Unless").Find(&rule)
 	return rule, res.Error
 }
 
@@ -97,7 +122,8 @@ func (ruleRepository *OrmRepository) GetRulesForRun(run *model.Run) ([]model.Rul
 	}
 	for i := range rules {
 		rules[i].CompilePatterns()
-		rules[i].Metric = &model.RuleMetric{Rule: rules[i].Name, RunID: run.ID, RuleCriticality: rules[i].Criticality}
+		rules[i].Metric = &model.RuleMetric{Rule: rules[i].Name, RunID: run.ID, RuleCriticality: rules[i].Criticality,
+			RuleDeprecated: rules[i].Deprecated, RuleReplacedBy: rules[i].ReplacedBy}
 	}
 
 	return rules, nil
@@ -142,7 +168,8 @@ func (ruleRepository *OrmRepository) GetRulesForRunRestricted(run *model.Run, ta
 	//Compile the remaining rules and add metrics!
 	for i := range restrictedRules {
 		restrictedRules[i].CompilePatterns()
-		restrictedRules[i].Metric = &model.RuleMetric{Rule: restrictedRules[i].Name, RunID: run.ID, RuleCriticality: restrictedRules[i].Criticality}
+		restrictedRules[i].Metric = &model.RuleMetric{Rule: restrictedRules[i].Name, RunID: run.ID, RuleCriticality: restrictedRules[i].Criticality,
+			RuleDeprecated: restrictedRules[i].Deprecated, RuleReplacedBy: restrictedRules[i].ReplacedBy}
 	}
 
 	return restrictedRules, nil
@@ -191,41 +218,32 @@ func (ruleRepository *OrmRepository) ExportRules() {
 	fmt.Printf("Successfully exported [%d] rules @ [%s]", exportCnt, rulesDir)
 }
 
-func (RuleRepository *OrmRepository) ValidateRule(filename string, run *model.Run) {
-	fmt.Printf("Validating Rule File [%s]\n\n", filename)
+//ValidateRule checks a rule file (or every rule file in a directory) against the rule schema reporting errors by line.
+//Returns false when any rule is invalid.
+func (ruleRepository *OrmRepository) ValidateRule(filename string, run *model.Run) bool {
 
-	var decoder util.FileDecoder
-	reader, err := util.OpenFileAtPath(filename, run.Homepath)
-	if err != nil {
-		util.App.Fatalf("Error reading file. Details: %v\n", err)
+	if strings.HasPrefix(filename, "~") {
+		filename = strings.Replace(filename, "~", run.Homepath, 1)
 	}
 
-	if strings.HasSuffix(filepath.Ext(filename), util.JSON) {
-		decoder = json.NewDecoder(reader)
-	} else {
-		decoder = yaml.NewDecoder(reader)
+	files := []string{filename}
+	if info, err := os.Stat(filename); err == nil && info.IsDir() {
+		files = nil
+		for _, file := range ruleRepository.fileUtil.GetFileList(filename, "(json|yaml|yml)") {
+			if !model.IsRuleTestFile(file.Name) {
+				files = append(files, file.FQN)
+			}
+		}
 	}
 
-	rule, err := extractRule(decoder)
-
-	if err == nil {
-		fmt.Printf("Rule [%s] is VALID!\n\tTarget: %s\n\tType: %s\n\tFileType: %s\n\tPattern Cnt: %d\n",
-			rule.Name, rule.Target, rule.Type, rule.FileType, len(rule.Patterns))
-		fmt.Print("\nUnmarshalled Rule Dump =>\n\n")
-
-		spew.Config.DisablePointerAddresses = true
-		spew.Config.DisableMethods = true
-		spew.Config.Indent = "\t"
-		spew.Config.DisableCapacities = true
-		spew.Config.DisablePointerMethods = true
-		spew.Config.MaxDepth = 3
-		spew.Config.SortKeys = true
-
-		spew.Dump(rule)
-	} else {
-		util.App.Errorf("%v", err)
+	valid := true
+	for _, file := range files {
+		if !validateRuleFile(file) {
+			valid = false
+		}
 	}
 
+	return valid
 }
 
 func (ruleRepository *OrmRepository) ImportRules() {
@@ -240,6 +258,11 @@ func (ruleRepository *OrmRepository) ImportRules() {
 	if len(files) > 0 {
 		for _, file := range files {
 
+			//Rule tests live next to the rules but are not rules!
+			if model.IsRuleTestFile(file.Name) {
+				continue
+			}
+
 			if *util.Verbose {
 				fmt.Printf("Importing Rule File [%s]...", file.Name)
 			}
@@ -319,16 +342,19 @@ func (ruleRepository *OrmRepository) LoadRules() {
 func (ruleRepository *OrmRepository) DeleteRule(ruleName string) error {
 	existingRule, _ := ruleRepository.GetRuleByName(ruleName)
 
-	if existingRule.Name == *util.DeleteRulesName {
-		fmt.Printf("Deleting rule [%s]...", existingRule.Name)
-
-		CheckDBError(true,
-			"Delete Rule",
-			fmt.Sprintf("Failed Deleting Rule [%s]", existingRule.Name),
-			database.Delete(&existingRule).Error)
-		fmt.Print("done!\n")
+	if existingRule.Name != ruleName {
+		return fmt.Errorf("rule [%s] not found", ruleName)
 	}
-	return database.Delete(existingRule).Error
+
+	fmt.Printf("Deleting rule [%s]...", existingRule.Name)
+	err := database.Delete(&existingRule).Error
+	CheckDBError(true,
+		"Delete Rule",
+		fmt.Sprintf("Failed Deleting Rule [%s]", existingRule.Name),
+		err)
+	fmt.Print("done!\n")
+
+	return err
 }
 
 func (ruleRepository *OrmRepository) GetRuleMetrics(runId uint) ([]model.RuleMetric, error) {
@@ -341,6 +367,46 @@ func (ruleRepository *OrmRepository) GetRuleMetrics(runId uint) ([]model.RuleMet
 PRIVATE API -------------------------------------------------------------------------------------------------------------
 */
 
+func validateRuleFile(filename string) bool {
+	fmt.Printf("Validating Rule File [%s]\n", filename)
+
+	reader, err := util.OpenFileAtPath(filename, "")
+	if err != nil {
+		util.App.Errorf("Error reading file. Details: %v\n", err)
+		return false
+	}
+	defer reader.Close()
+
+	rules, errs := model.ValidateRuleFile(reader, strings.HasSuffix(filepath.Ext(filename), util.JSON))
+
+	for _, err := range errs {
+		fmt.Printf("%s:%d:%d: %s\n", filename, err.Line, err.Column, err.Message)
+	}
+
+	for _, rule := range rules {
+		fmt.Printf("Rule [%s] is VALID!\n\tTarget: %s\n\tType: %s\n\tFileType: %s\n\tPattern Cnt: %d\n",
+			rule.Name, rule.Target, rule.Type, rule.FileType, len(rule.Patterns))
+
+		if *util.Verbose {
+			fmt.Print("\nUnmarshalled Rule Dump =>\n\n")
+
+			spew.Config.DisablePointerAddresses = true
+			spew.Config.DisableMethods = true
+			spew.Config.Indent = "\t"
+			spew.Config.DisableCapacities = true
+			spew.Config.DisablePointerMethods = true
+			spew.Config.MaxDepth = 3
+			spew.Config.SortKeys = true
+
+			spew.Dump(rule)
+		}
+	}
+
+	fmt.Println()
+
+	return len(errs) == 0
+}
+
 func (ruleRepository *OrmRepository) unMarshalAndSaveRule(decoder util.FileDecoder, newRules *[]model.Rule) (cnt int, stop bool) {
 
 	importOne := *util.ImportRuleName != ""
@@ -359,23 +425,7 @@ func (ruleRepository *OrmRepository) unMarshalAndSaveRule(decoder util.FileDecod
 		if *util.ReplaceRulesFlag {
 			*newRules = append(*newRules, rule)
 		} else {
-			//Check to see if rule already exists!
-			existingRule, _ := ruleRepository.GetRuleByName(rule.Name)
-			if existingRule.Name == rule.Name {
-				if *util.Verbose {
-					fmt.Printf("Rule [%s] exists! Updating!", rule.Name)
-				}
-				deletedPatterns, deletedRecipes, deletedTags := existingRule.UpdateRule(rule)
-				DeletePatterns(deletedPatterns)
-				DeleteRecipes(deletedRecipes)
-				DeleteTags(deletedTags)
-				ruleRepository.SaveRule(existingRule)
-			} else {
-				if *util.Verbose {
-					fmt.Printf("Rule [%s] does not exist! Creating!", rule.Name)
-				}
-				ruleRepository.SaveRule(rule)
-			}
+			ruleRepository.UpsertRule(rule)
 		}
 
 		if *util.Verbose {
@@ -25,6 +25,25 @@ type RunRepository interface {
 	UpdateApp(app *model.Application) error
 	GetApp(runId uint, appName string) (*model.Application, error)
 	GetAppByID(runId uint, appId uint) (*model.Application, error)
+	GetPreviousApp(runId uint, appName string) (*model.Application, error)
+	GetPreviousAppAtPath(runId uint, appName string, path string) (*model.Application, error)
+	SetRunStatus(run *model.Run, status string) error
+	SaveScores(run *model.Run, apps []*model.Application) error
+	SaveAppDetails(apps []*model.Application) error
+	SaveModules(modules []*model.AppModule) error
+	GetRunModules(runId uint, app string) ([]model.AppModule, error)
+	SaveDotnetProjects(projects []*model.DotnetProject) error
+	GetDotnetProjects(runId uint, app string) ([]model.DotnetProject, error)
+	SaveAppServerResources(resources []*model.AppServerResource) error
+	GetAppServerResources(runId uint, app string) ([]model.AppServerResource, error)
+	SaveOutcome(outcome *model.MigrationOutcome) error
+	GetOutcomes() ([]model.MigrationOutcome, error)
+	SaveRunShards(shards []*model.RunShard) error
+	GetRunShards(runId uint) ([]model.RunShard, error)
+	ClaimRunShard(runId uint, worker string) (*model.RunShard, error)
+	TouchRunShards(ids []uint, worker string) error
+	ReleaseRunShards(ids []uint) error
+	SetRunShardsDone(ids []uint, worker string) error
 }
 
 func NewRunRepository(db *gorm.DB) RunRepository {
@@ -51,6 +70,12 @@ func (runRepository *OrmRepository) StopRun(r *model.Run) error {
 	return err
 }
 
+//SetRunStatus records how far the run got, so an interrupted run can be resumed
+func (runRepository *OrmRepository) SetRunStatus(r *model.Run, status string) error {
+	r.Status = status
+	return runRepository.dbconn.Model(r).UpdateColumn("status", status).Error
+}
+
 func (repo *OrmRepository) GetRuns() ([]model.Run, error) {
 	var runs []model.Run
 
@@ -114,6 +139,27 @@ func (repo *OrmRepository) GetAppByID(runId uint, appId uint) (*model.Applicatio
 	return app, response.Error
 }
 
+//GetPreviousApp returns the application as analyzed by the most recent earlier run or nil when it was never analyzed before
+func (repo *OrmRepository) GetPreviousApp(runId uint, appName string) (*model.Application, error) {
+	app := &model.Application{}
+	response := repo.dbconn.Where("name = ? and run_id < ?", appName, runId).Order("run_id desc").First(app)
+	if response.RecordNotFound() {
+		return nil, nil
+	}
+	return app, response.Error
+}
+
+//GetPreviousAppAtPath returns the application as analyzed at the same path by the most recent earlier run or nil when
+//it was never analyzed there before
+func (repo *OrmRepository) GetPreviousAppAtPath(runId uint, appName string, path string) (*model.Application, error) {
+	app := &model.Application{}
+	response := repo.dbconn.Where("name = ? and path = ? and run_id < ?", appName, path, runId).Order("run_id desc").First(app)
+	if response.RecordNotFound() {
+		return nil, nil
+	}
+	return app, response.Error
+}
+
 func (repo *OrmRepository) UpdateApp(updateRequestApp *model.Application) error {
 	app, err := repo.GetAppByID(updateRequestApp.RunID, updateRequestApp.ID)
 	if err == nil {
@@ -124,6 +170,48 @@ func (repo *OrmRepository) UpdateApp(updateRequestApp *model.Application) error
 	return err
 }
 
+//SaveScores records the scores and recommendations of the run's applications along with the scorer (and formula) and
+//normalization of the run
+func (repo *OrmRepository) SaveScores(run *model.Run, apps []*model.Application) error {
+
+	tx := repo.dbconn.Begin()
+
+	err := tx.Model(&model.Run{}).Where("id = ?", run.ID).
+		UpdateColumns(map[string]interface{}{"scorer": run.Scorer, "scoring_formula": run.ScoringFormula, "normalize": run.Normalize}).Error
+
+	for i := 0; err == nil && i < len(apps); i++ {
+		err = tx.Model(&model.Application{}).Where("id = ?", apps[i].ID).
+			UpdateColumns(map[string]interface{}{"score": apps[i].Score, "recommendation": apps[i].Recommendation}).Error
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+//SaveAppDetails records the finding counts, raw score and container score of the applications, which change when
+//findings are triaged
+func (repo *OrmRepository) SaveAppDetails(apps []*model.Application) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, app := range apps {
+		err := tx.Model(&model.Application{}).Where("id = ?", app.ID).
+			UpdateColumns(map[string]interface{}{"findings": app.Findings, "ci_findings": app.CIFindings, "info_findings": app.InfoFindings,
+				"raw_score": app.RawScore, "num_crits": app.NumCrits, "critical_cnt": app.CriticalCnt, "high_cnt": app.HighCnt,
+				"findings_ratio": app.FindingsRatio, "container_score": app.ContainerScore}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
 func (repo *OrmRepository) binApp(app *model.Application) {
 	var bins []model.Bin
 	res := repo.dbconn.Preload("Tags").Find(&bins)
@@ -140,3 +228,195 @@ func (repo *OrmRepository) binApp(app *model.Application) {
 		}
 	}
 }
+
+//SaveModules records the modules of a multi-module application
+func (repo *OrmRepository) SaveModules(modules []*model.AppModule) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, module := range modules {
+		if err := tx.Create(module).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//GetRunModules returns the modules of the run's multi-module applications. An empty app returns the modules of every
+//application.
+func (repo *OrmRepository) GetRunModules(runId uint, app string) ([]model.AppModule, error) {
+	modules := []model.AppModule{}
+
+	query := repo.dbconn.Where("run_id = ?", runId)
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+
+	res := query.Order("application, module").Find(&modules)
+	return modules, res.Error
+}
+
+//SaveDotnetProjects records the .NET projects of the run's applications
+func (repo *OrmRepository) SaveDotnetProjects(projects []*model.DotnetProject) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, project := range projects {
+		if err := tx.Create(project).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//GetDotnetProjects returns the .NET projects of the run's applications. An empty app returns the projects of every
+//application.
+func (repo *OrmRepository) GetDotnetProjects(runId uint, app string) ([]model.DotnetProject, error) {
+	projects := []model.DotnetProject{}
+
+	query := repo.dbconn.Where("run_id = ?", runId)
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+
+	res := query.Order("application, project").Find(&projects)
+	return projects, res.Error
+}
+
+//SaveAppServerResources records the application server resources of the run's applications
+func (repo *OrmRepository) SaveAppServerResources(resources []*model.AppServerResource) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, resource := range resources {
+		if err := tx.Create(resource).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//GetAppServerResources returns the application server resources of the run's applications. An empty app returns the
+//resources of every application.
+func (repo *OrmRepository) GetAppServerResources(runId uint, app string) ([]model.AppServerResource, error) {
+	resources := []model.AppServerResource{}
+
+	query := repo.dbconn.Where("run_id = ?", runId)
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+
+	res := query.Order("application, descriptor, line").Find(&resources)
+	return resources, res.Error
+}
+
+//SaveOutcome records the actual effort of migrating an application of a run, replacing the outcome recorded before
+func (repo *OrmRepository) SaveOutcome(outcome *model.MigrationOutcome) error {
+
+	tx := repo.dbconn.Begin()
+
+	if err := tx.Where("run_id = ? and application = ?", outcome.RunID, outcome.Application).Delete(model.MigrationOutcome{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Create(outcome).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+//GetOutcomes returns the outcomes recorded for the applications of every run
+func (repo *OrmRepository) GetOutcomes() ([]model.MigrationOutcome, error) {
+	outcomes := []model.MigrationOutcome{}
+	res := repo.dbconn.Order("run_id, application").Find(&outcomes)
+	return outcomes, res.Error
+}
+
+//SaveRunShards records the shards of a distributed run
+func (repo *OrmRepository) SaveRunShards(shards []*model.RunShard) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, shard := range shards {
+		if err := tx.Create(shard).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//GetRunShards returns the shards of a distributed run in the order they are claimed
+func (repo *OrmRepository) GetRunShards(runId uint) ([]model.RunShard, error) {
+	shards := []model.RunShard{}
+	res := repo.dbconn.Where("run_id = ?", runId).Order("files desc, id").Find(&shards)
+	return shards, res.Error
+}
+
+//ClaimRunShard claims the next pending shard of the run for the worker or returns nil when none are left. Workers
+//race for a shard by updating it only while it is pending: the worker whose update changed it won it.
+func (repo *OrmRepository) ClaimRunShard(runId uint, worker string) (*model.RunShard, error) {
+
+	for {
+		shard := &model.RunShard{}
+		res := repo.dbconn.Where("run_id = ? and status = ?", runId, model.SHARD_PENDING).Order("files desc, id").First(shard)
+		if res.RecordNotFound() {
+			return nil, nil
+		} else if res.Error != nil {
+			return nil, res.Error
+		}
+
+		res = repo.dbconn.Model(&model.RunShard{}).Where("id = ? and status = ?", shard.ID, model.SHARD_PENDING).
+			UpdateColumns(map[string]interface{}{"status": model.SHARD_CLAIMED, "worker": worker, "claims": gorm.Expr("claims + 1"), "updated_at": gorm.NowFunc()})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		if res.RowsAffected == 1 {
+			shard.Status = model.SHARD_CLAIMED
+			shard.Worker = worker
+			shard.Claims++
+			return shard, nil
+		}
+	}
+}
+
+//TouchRunShards is the heartbeat of the worker holding the claimed shards
+func (repo *OrmRepository) TouchRunShards(ids []uint, worker string) error {
+	return repo.setRunShards(ids, worker, map[string]interface{}{"updated_at": gorm.NowFunc()})
+}
+
+//ReleaseRunShards puts claimed shards back to be claimed by another worker
+func (repo *OrmRepository) ReleaseRunShards(ids []uint) error {
+	return repo.setRunShards(ids, "", map[string]interface{}{"status": model.SHARD_PENDING, "updated_at": gorm.NowFunc()})
+}
+
+//SetRunShardsDone marks the shards claimed by the worker done once their findings are saved
+func (repo *OrmRepository) SetRunShardsDone(ids []uint, worker string) error {
+	return repo.setRunShards(ids, worker, map[string]interface{}{"status": model.SHARD_DONE, "updated_at": gorm.NowFunc()})
+}
+
+//setRunShards updates the shards still claimed, by the worker unless it is empty. Shards released and claimed by
+//another worker are left to it.
+func (repo *OrmRepository) setRunShards(ids []uint, worker string, columns map[string]interface{}) error {
+	for _, chunk := range idChunks(ids) {
+		query := repo.dbconn.Model(&model.RunShard{}).Where("id in (?) and status = ?", chunk, model.SHARD_CLAIMED)
+		if worker != "" {
+			query = query.Where("worker = ?", worker)
+		}
+		if err := query.UpdateColumns(columns).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
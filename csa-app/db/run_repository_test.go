@@ -85,3 +85,43 @@ func createRun(database *gorm.DB, complete bool) (*model.Run, error) {
 	return run, err
 
 }
+
+func TestClaimRunShards(t *testing.T) {
+
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	runRepo := db.NewRunRepository(database)
+	assert.Nil(t, runRepo.SaveRunShards([]*model.RunShard{
+		{RunID: 31, Application: "orders", Files: 5, Status: model.SHARD_PENDING},
+		{RunID: 31, Application: "billing", Files: 2, Status: model.SHARD_PENDING},
+	}))
+
+	orders, err := runRepo.ClaimRunShard(31, "host-a:1")
+	assert.Nil(t, err)
+	assert.Equal(t, "orders", orders.Application, "the largest shard first")
+
+	billing, _ := runRepo.ClaimRunShard(31, "host-b:2")
+	assert.Equal(t, "billing", billing.Application)
+
+	none, err := runRepo.ClaimRunShard(31, "host-a:1")
+	assert.Nil(t, err)
+	assert.Nil(t, none)
+
+	//host-b stopped: its shard is released and claimed by host-a
+	assert.Nil(t, runRepo.ReleaseRunShards([]uint{billing.ID}))
+	billing, _ = runRepo.ClaimRunShard(31, "host-a:1")
+	assert.Equal(t, 2, billing.Claims)
+
+	assert.Nil(t, runRepo.SetRunShardsDone([]uint{billing.ID}, "host-b:2"), "shards of another worker are left alone")
+	assert.Nil(t, runRepo.SetRunShardsDone([]uint{orders.ID}, "host-a:1"))
+
+	shards, err := runRepo.GetRunShards(31)
+	assert.Nil(t, err)
+	assert.Equal(t, model.SHARD_DONE, shards[0].Status)
+	assert.Equal(t, model.SHARD_CLAIMED, shards[1].Status)
+	assert.Equal(t, "host-a:1", shards[1].Worker)
+}
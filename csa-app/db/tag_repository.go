@@ -0,0 +1,205 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type TagRepository interface {
+	GetTaxonomy() ([]model.TaxonomyTag, error)
+	SaveTaxonomyTag(tag *model.TaxonomyTag) error
+	GetTagUsage() ([]model.TagUsage, error)
+	RenameTag(from string, to string) error
+	RetagFindings(runId uint, rules []model.Rule) (int, error)
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &OrmRepository{
+		dbconn: db,
+	}
+}
+
+func NewTagRepositoryForRun(run *model.Run) TagRepository {
+	return &OrmRepository{
+		dbconn: run.DB,
+	}
+}
+
+func (repo *OrmRepository) GetTaxonomy() ([]model.TaxonomyTag, error) {
+	var taxonomy []model.TaxonomyTag
+	res := repo.dbconn.Order("name").Find(&taxonomy)
+	return taxonomy, res.Error
+}
+
+func (repo *OrmRepository) SaveTaxonomyTag(tag *model.TaxonomyTag) error {
+	if err := model.ValidateTagName(tag.Name); err != nil {
+		return err
+	}
+	return repo.dbconn.Save(tag).Error
+}
+
+//GetTagUsage counts the rules and findings (of every run) using each tag
+func (repo *OrmRepository) GetTagUsage() ([]model.TagUsage, error) {
+
+	ruleTags, err := repo.countTags("select value, count(distinct rule_id) from tags group by value " +
+		"union all select tag, count(distinct rule_id) from patterns where tag <> '' group by tag")
+	if err != nil {
+		return nil, err
+	}
+
+	findingTags, err := repo.countTags("select value, count(*) from finding_tags group by value")
+	if err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := repo.GetTaxonomy()
+	if err != nil {
+		return nil, err
+	}
+
+	return model.BuildTagUsage(ruleTags, findingTags, taxonomy), nil
+}
+
+//RenameTag renames a tag everywhere it is used: rule, pattern, finding, application and bin tags as well as the taxonomy.
+//Renaming to a tag already in use merges the two; owners carrying both keep a single one.
+func (repo *OrmRepository) RenameTag(from string, to string) error {
+
+	if err := model.ValidateTagName(to); err != nil {
+		return err
+	}
+
+	tx := repo.dbconn.Begin()
+
+	updates := []struct{ table, column, owner string }{
+		{"tags", "value", "rule_id"},
+		{"finding_tags", "value", "finding_id"},
+		{"application_tags", "value", "application_id"},
+		{"bin_tags", "name", "bin_id"},
+	}
+
+	for _, update := range updates {
+		err := tx.Exec(fmt.Sprintf("update %s set %s = ? where lower(%s) = ?", update.table, update.column, update.column),
+			to, strings.ToLower(from)).Error
+		if err == nil {
+			err = tx.Exec(fmt.Sprintf("delete from %s where %s = ? and id not in (select min(id) from %s where %s = ? group by %s)",
+				update.table, update.column, update.table, update.column, update.owner), to, to).Error
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("unable to rename tag [%s] in %s: %v", from, update.table, err)
+		}
+	}
+
+	if err := tx.Exec("update patterns set tag = ? where lower(tag) = ?", to, strings.ToLower(from)).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("unable to rename tag [%s] in patterns: %v", from, err)
+	}
+
+	var existing int
+	var err error
+	tx.Model(&model.TaxonomyTag{}).Where("name = ?", to).Count(&existing)
+	if existing > 0 && strings.ToLower(from) != to {
+		err = tx.Exec("delete from taxonomy_tags where name = ?", strings.ToLower(from)).Error
+	} else {
+		err = tx.Exec("update taxonomy_tags set name = ? where name = ?", to, strings.ToLower(from)).Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("unable to rename tag [%s] in the taxonomy: %v", from, err)
+	}
+
+	return tx.Commit().Error
+}
+
+//RetagFindings replaces the tags of the run's findings with those their rules carry now and the tags of its applications
+//with those of their findings. Findings of rules that no longer exist keep their tags. Returns the number of findings
+//retagged.
+func (repo *OrmRepository) RetagFindings(runId uint, rules []model.Rule) (int, error) {
+
+	byName := make(map[string]*model.Rule)
+	for i := range rules {
+		byName[rules[i].Name] = &rules[i]
+	}
+
+	var findings []model.Finding
+	if err := repo.dbconn.Where("run_id = ?", runId).Find(&findings).Error; err != nil {
+		return 0, err
+	}
+
+	tx := repo.dbconn.Begin()
+
+	retagged := 0
+	for _, finding := range findings {
+		rule, found := byName[finding.Rule]
+		if !found {
+			continue
+		}
+
+		if err := tx.Where("finding_id = ?", finding.ID).Delete(&model.FindingTag{}).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		for _, tag := range rule.TagsFor(finding.Pattern) {
+			if err := tx.Create(&model.FindingTag{FindingID: finding.ID, Value: tag}).Error; err != nil {
+				tx.Rollback()
+				return 0, err
+			}
+		}
+		retagged++
+	}
+
+	//Application tags are the tags of the application's findings
+	var apps []model.Application
+	if err := tx.Where("run_id = ?", runId).Find(&apps).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	for _, app := range apps {
+		var tags []string
+		err := tx.Table("finding_tags").Joins("inner join findings on findings.id = finding_tags.finding_id").
+			Where("findings.run_id = ? and findings.application = ?", runId, app.Name).
+			Pluck("distinct finding_tags.value", &tags).Error
+		if err == nil {
+			err = tx.Where("application_id = ?", app.ID).Delete(&model.ApplicationTag{}).Error
+		}
+		for i := 0; err == nil && i < len(tags); i++ {
+			err = tx.Create(&model.ApplicationTag{ApplicationID: app.ID, Value: tags[i]}).Error
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	return retagged, tx.Commit().Error
+}
+
+func (repo *OrmRepository) countTags(query string) (map[string]int, error) {
+
+	rows, err := repo.dbconn.Raw(query).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var tag string
+		var cnt int
+		if err := rows.Scan(&tag, &cnt); err != nil {
+			return nil, err
+		}
+		counts[tag] += cnt
+	}
+
+	return counts, rows.Err()
+}
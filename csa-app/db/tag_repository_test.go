@@ -0,0 +1,60 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db_test
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"csa-app/db"
+	"csa-app/db/test_support"
+	"csa-app/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameAndRetagTags(t *testing.T) {
+	_, dir, database, err := db_test_support.OpenTestDb()
+	defer os.RemoveAll(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repoMgr := db.NewRepositoriesManager(database)
+
+	rule := model.Rule{Name: "java-jms", Target: model.LINE_TARGET, Type: model.REGEX_MATCH_TYPE,
+		Tags:     []model.Tag{{Value: "messaging"}, {Value: "mesaging"}},
+		Patterns: []model.Pattern{{Value: "javax.jms", Tag: "jms"}}}
+	_, err = repoMgr.Rules.SaveRule(rule)
+	assert.NoError(t, err)
+
+	finding := &model.Finding{RunID: 30, Rule: "java-jms", Pattern: "javax.jms", Application: "orders", Category: "jms",
+		Criticality: "low", Tags: []model.FindingTag{{Value: "mesaging"}}}
+	assert.NoError(t, database.Create(finding).Error)
+	assert.NoError(t, repoMgr.Tags.SaveTaxonomyTag(&model.TaxonomyTag{Name: "messaging"}))
+
+	usage, err := repoMgr.Tags.GetTagUsage()
+	assert.NoError(t, err)
+	assert.Len(t, usage, 3)
+	assert.Equal(t, model.TagUsage{Tag: "mesaging", Rules: 1, Findings: 1, Similar: []string{"messaging"}}, usage[1])
+
+	//Merging keeps a single tag on the rule
+	assert.NoError(t, repoMgr.Tags.RenameTag("mesaging", "messaging"))
+	saved, _ := repoMgr.Rules.GetRuleByName("java-jms")
+	assert.Len(t, saved.Tags, 1)
+
+	taxonomy, _ := repoMgr.Tags.GetTaxonomy()
+	assert.Len(t, taxonomy, 1)
+
+	retagged, err := repoMgr.Tags.RetagFindings(30, []model.Rule{saved})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, retagged)
+
+	findings, _ := repoMgr.Findings.GetFindingsByTag(30, "jms")
+	assert.Len(t, findings, 1)
+
+	assert.Error(t, repoMgr.Tags.RenameTag("jms", "Java Messaging"))
+}
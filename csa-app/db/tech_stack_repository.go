@@ -0,0 +1,56 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package db
+
+import (
+	"csa-app/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type TechStackRepository interface {
+	SaveTechStack(attributes []*model.TechAttribute) error
+	GetTechStack(runId uint, app string) ([]model.TechAttribute, error)
+}
+
+func NewTechStackRepository(db *gorm.DB) TechStackRepository {
+	return &OrmRepository{
+		dbconn: db,
+	}
+}
+
+func NewTechStackRepositoryForRun(run *model.Run) TechStackRepository {
+	return &OrmRepository{
+		dbconn: run.DB,
+	}
+}
+
+func (repo *OrmRepository) SaveTechStack(attributes []*model.TechAttribute) error {
+
+	tx := repo.dbconn.Begin()
+
+	for _, attribute := range attributes {
+		if err := tx.Create(attribute).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//GetTechStack returns the technologies detected by the run. An empty app returns those of every application.
+func (repo *OrmRepository) GetTechStack(runId uint, app string) ([]model.TechAttribute, error) {
+	attributes := []model.TechAttribute{}
+
+	query := repo.dbconn.Where("run_id = ?", runId)
+	if app != "" {
+		query = query.Where("application = ?", app)
+	}
+
+	res := query.Order("application, category, name").Find(&attributes)
+	return attributes, res.Error
+}
@@ -0,0 +1,224 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package events
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type EventType string
+
+const (
+	RUN_STARTED       EventType = "run-started"
+	PHASE_COMPLETED   EventType = "phase-completed"
+	FINDING_THRESHOLD EventType = "finding-threshold"
+	RUN_FINISHED      EventType = "run-finished"
+)
+
+var EventTypes = []EventType{RUN_STARTED, PHASE_COMPLETED, FINDING_THRESHOLD, RUN_FINISHED}
+
+const EVENT_BUFFER = 256
+
+//Event describes a moment in a run's lifecycle. Fields not relevant to the event type are left empty.
+type Event struct {
+	Type         EventType `json:"type"`
+	Time         time.Time `json:"time"`
+	RunID        uint      `json:"runId"`
+	Alias        string    `json:"alias"`
+	Target       string    `json:"target"`
+	Phase        string    `json:"phase,omitempty"`
+	Elapsed      string    `json:"elapsed,omitempty"`
+	Applications int       `json:"applications"`
+	Files        int       `json:"files"`
+	Findings     int       `json:"findings"`
+	Threshold    int       `json:"threshold,omitempty"`
+	Failed       bool      `json:"failed,omitempty"`
+}
+
+//Summary is a one line, human readable description of the event used by chat/email subscribers
+func (e Event) Summary() string {
+	switch e.Type {
+	case RUN_STARTED:
+		return fmt.Sprintf("csa run [%d] %s started analyzing [%s]", e.RunID, e.Alias, e.Target)
+	case PHASE_COMPLETED:
+		return fmt.Sprintf("csa run [%d] %s completed %s in %s", e.RunID, e.Alias, e.Phase, e.Elapsed)
+	case FINDING_THRESHOLD:
+		return fmt.Sprintf("csa run [%d] %s crossed %d findings", e.RunID, e.Alias, e.Threshold)
+	case RUN_FINISHED:
+		status := "finished"
+		if e.Failed {
+			status = "finished with errors"
+		}
+		return fmt.Sprintf("csa run [%d] %s %s in %s. Apps: %d Files: %d Findings: %d", e.RunID, e.Alias, status, e.Elapsed, e.Applications, e.Files, e.Findings)
+	}
+	return fmt.Sprintf("csa run [%d] %s: %s", e.RunID, e.Alias, e.Type)
+}
+
+//Subscriber receives the events it accepts. Notify is called from the bus' dispatcher, one event at a time.
+type Subscriber interface {
+	Name() string
+	Accepts(eventType EventType) bool
+	Notify(event Event) error
+}
+
+//Bus delivers events to subscribers in the background so a slow webhook never stalls analysis.
+//Delivery failures are reported on std err and never fail the run. Events published while the buffer is full are
+//dropped (and counted) rather than blocking the publisher.
+type Bus struct {
+	subscribers []Subscriber
+	queue       chan Event
+	done        chan struct{}
+	threshold   int
+	crossed     int32
+	dropped     int64
+	closed      bool
+	sync.Mutex
+}
+
+func NewBus(findingThreshold int, subscribers ...Subscriber) *Bus {
+
+	bus := &Bus{
+		subscribers: subscribers,
+		queue:       make(chan Event, EVENT_BUFFER),
+		done:        make(chan struct{}),
+		threshold:   findingThreshold,
+	}
+
+	go bus.dispatch()
+
+	return bus
+}
+
+func (bus *Bus) HasSubscribers() bool {
+	return bus != nil && len(bus.subscribers) > 0
+}
+
+//Publish queues the event for delivery. Publishing to a nil or closed bus is a no-op, publishing to a full one drops
+//the event.
+func (bus *Bus) Publish(event Event) {
+	if !bus.HasSubscribers() {
+		return
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	bus.Lock()
+	defer bus.Unlock()
+
+	if bus.closed {
+		return
+	}
+
+	select {
+	case bus.queue <- event:
+	default:
+		atomic.AddInt64(&bus.dropped, 1)
+	}
+}
+
+//Dropped is the number of events discarded because the buffer was full
+func (bus *Bus) Dropped() int64 {
+	if bus == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&bus.dropped)
+}
+
+//ThresholdCrossed is true exactly once: the first time the finding total reaches the configured threshold
+func (bus *Bus) ThresholdCrossed(findings int) bool {
+	if bus == nil || bus.threshold <= 0 || findings < bus.threshold {
+		return false
+	}
+	return atomic.CompareAndSwapInt32(&bus.crossed, 0, 1)
+}
+
+func (bus *Bus) Threshold() int {
+	return bus.threshold
+}
+
+//Close delivers all queued events and stops the dispatcher
+func (bus *Bus) Close() {
+	if bus == nil {
+		return
+	}
+
+	bus.Lock()
+	if !bus.closed {
+		bus.closed = true
+		close(bus.queue)
+	}
+	bus.Unlock()
+
+	<-bus.done
+
+	if dropped := bus.Dropped(); dropped > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "Dropped %d event(s) published while the event buffer was full!\n", dropped)
+	}
+}
+
+func (bus *Bus) dispatch() {
+	defer close(bus.done)
+
+	for event := range bus.queue {
+		for _, subscriber := range bus.subscribers {
+			if !subscriber.Accepts(event.Type) {
+				continue
+			}
+			if err := subscriber.Notify(event); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Unable to deliver [%s] event to %s! Details: %v\n", event.Type, subscriber.Name(), err)
+			}
+		}
+	}
+}
+
+//ParseEventTypes parses a comma delimited list of event types. "all" selects every event type.
+func ParseEventTypes(list string) (map[EventType]bool, error) {
+
+	selected := make(map[EventType]bool)
+
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if name == "all" {
+			for _, eventType := range EventTypes {
+				selected[eventType] = true
+			}
+			continue
+		}
+
+		known := false
+		for _, eventType := range EventTypes {
+			if string(eventType) == name {
+				selected[eventType] = true
+				known = true
+			}
+		}
+
+		if !known {
+			return nil, fmt.Errorf("unknown event type [%s]. Valid values: all, %s", name, joinEventTypes())
+		}
+	}
+
+	return selected, nil
+}
+
+func joinEventTypes() string {
+	var names []string
+	for _, eventType := range EventTypes {
+		names = append(names, string(eventType))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,48 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package events
+
+import (
+	"fmt"
+
+	"csa-app/util"
+)
+
+//NewBusFromFlags subscribes every notification integration configured on the command line
+func NewBusFromFlags() (*Bus, error) {
+
+	eventTypes, err := ParseEventTypes(*util.NotifyOn)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscribers []Subscriber
+
+	for _, url := range *util.NotifyWebhooks {
+		subscribers = append(subscribers, NewWebhookSubscriber(url, eventTypes))
+	}
+
+	if *util.NotifySlack != "" {
+		subscribers = append(subscribers, NewSlackSubscriber(*util.NotifySlack, eventTypes))
+	}
+
+	if len(*util.NotifyEmails) > 0 {
+		if *util.NotifySmtpServer == "" {
+			return nil, fmt.Errorf("--notify-email requires --smtp-server")
+		}
+		subscribers = append(subscribers, NewEmailSubscriber(*util.NotifySmtpServer, *util.NotifySmtpFrom, *util.NotifyEmails,
+			*util.NotifySmtpUser, *util.NotifySmtpPassword, eventTypes))
+	}
+
+	if *util.NotifyMetricsFile != "" {
+		if err = metricsDir(*util.NotifyMetricsFile); err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, NewMetricsSubscriber(*util.NotifyMetricsFile))
+	}
+
+	return NewBus(*util.FindingThreshold, subscribers...), nil
+}
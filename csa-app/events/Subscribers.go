@@ -0,0 +1,225 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const NOTIFY_TIMEOUT = 10 * time.Second
+
+//filter limits a subscriber to the event types it was configured for. Empty accepts everything.
+type filter map[EventType]bool
+
+func (f filter) Accepts(eventType EventType) bool {
+	return len(f) == 0 || f[eventType]
+}
+
+//WebhookSubscriber POSTs every accepted event as json
+type WebhookSubscriber struct {
+	filter
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSubscriber(url string, eventTypes map[EventType]bool) *WebhookSubscriber {
+	return &WebhookSubscriber{filter: eventTypes, url: url, client: &http.Client{Timeout: NOTIFY_TIMEOUT}}
+}
+
+func (w *WebhookSubscriber) Name() string {
+	return fmt.Sprintf("webhook [%s]", w.url)
+}
+
+func (w *WebhookSubscriber) Notify(event Event) error {
+	return postJson(w.client, w.url, event)
+}
+
+//SlackSubscriber posts the event summary to a Slack incoming webhook
+type SlackSubscriber struct {
+	filter
+	url    string
+	client *http.Client
+}
+
+func NewSlackSubscriber(url string, eventTypes map[EventType]bool) *SlackSubscriber {
+	return &SlackSubscriber{filter: eventTypes, url: url, client: &http.Client{Timeout: NOTIFY_TIMEOUT}}
+}
+
+func (s *SlackSubscriber) Name() string {
+	return "slack"
+}
+
+func (s *SlackSubscriber) Notify(event Event) error {
+	return postJson(s.client, s.url, map[string]string{"text": event.Summary()})
+}
+
+func postJson(client *http.Client, url string, payload interface{}) error {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s responded %s", url, resp.Status)
+	}
+
+	return nil
+}
+
+//EmailSubscriber mails the event summary through an smtp server (host:port). Auth is only used when a user is set.
+type EmailSubscriber struct {
+	filter
+	server   string
+	from     string
+	to       []string
+	user     string
+	password string
+}
+
+func NewEmailSubscriber(server, from string, to []string, user, password string, eventTypes map[EventType]bool) *EmailSubscriber {
+	return &EmailSubscriber{filter: eventTypes, server: server, from: from, to: to, user: user, password: password}
+}
+
+func (e *EmailSubscriber) Name() string {
+	return fmt.Sprintf("email [%s]", strings.Join(e.to, ","))
+}
+
+func (e *EmailSubscriber) Notify(event Event) error {
+
+	var auth smtp.Auth
+	if e.user != "" {
+		host, _, err := net.SplitHostPort(e.server)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", e.user, e.password, host)
+	}
+
+	return smtp.SendMail(e.server, auth, e.from, e.to, e.message(event))
+}
+
+func (e *EmailSubscriber) message(event Event) []byte {
+
+	details, _ := json.MarshalIndent(event, "", "  ")
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", event.Summary())
+	fmt.Fprintf(&msg, "Date: %s\r\n", event.Time.Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(event.Summary())
+	msg.WriteString("\r\n\r\n")
+	msg.Write(details)
+	msg.WriteString("\r\n")
+
+	return msg.Bytes()
+}
+
+//MetricsSubscriber maintains run metrics in a Prometheus text format file (I.E. for the node_exporter textfile
+//collector). The file is rewritten atomically on every event.
+type MetricsSubscriber struct {
+	path    string
+	events  map[EventType]int
+	phases  map[string]float64
+	last    Event
+	started time.Time
+}
+
+func NewMetricsSubscriber(path string) *MetricsSubscriber {
+	return &MetricsSubscriber{path: path, events: make(map[EventType]int), phases: make(map[string]float64)}
+}
+
+func (m *MetricsSubscriber) Name() string {
+	return fmt.Sprintf("metrics [%s]", m.path)
+}
+
+func (m *MetricsSubscriber) Accepts(eventType EventType) bool {
+	return true
+}
+
+func (m *MetricsSubscriber) Notify(event Event) error {
+
+	m.events[event.Type]++
+	m.last = event
+
+	switch event.Type {
+	case RUN_STARTED:
+		m.started = event.Time
+	case PHASE_COMPLETED:
+		if elapsed, err := time.ParseDuration(event.Elapsed); err == nil {
+			m.phases[event.Phase] = elapsed.Seconds()
+		}
+	}
+
+	tmp := m.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, m.render(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, m.path)
+}
+
+func (m *MetricsSubscriber) render() []byte {
+
+	var out bytes.Buffer
+	labels := fmt.Sprintf("run=\"%d\",alias=%q", m.last.RunID, m.last.Alias)
+
+	out.WriteString("# HELP csa_run_events_total Run lifecycle events published\n# TYPE csa_run_events_total counter\n")
+	for _, eventType := range EventTypes {
+		fmt.Fprintf(&out, "csa_run_events_total{%s,type=%q} %d\n", labels, eventType, m.events[eventType])
+	}
+
+	out.WriteString("# HELP csa_run_findings Findings recorded by the run\n# TYPE csa_run_findings gauge\n")
+	fmt.Fprintf(&out, "csa_run_findings{%s} %d\n", labels, m.last.Findings)
+
+	out.WriteString("# HELP csa_run_files Files analyzed by the run\n# TYPE csa_run_files gauge\n")
+	fmt.Fprintf(&out, "csa_run_files{%s} %d\n", labels, m.last.Files)
+
+	if !m.started.IsZero() {
+		out.WriteString("# HELP csa_run_start_time_seconds Unix time the run started\n# TYPE csa_run_start_time_seconds gauge\n")
+		fmt.Fprintf(&out, "csa_run_start_time_seconds{%s} %d\n", labels, m.started.Unix())
+	}
+
+	if len(m.phases) > 0 {
+		var phases []string
+		for phase := range m.phases {
+			phases = append(phases, phase)
+		}
+		sort.Strings(phases)
+
+		out.WriteString("# HELP csa_run_phase_seconds Time each run phase took\n# TYPE csa_run_phase_seconds gauge\n")
+		for _, phase := range phases {
+			fmt.Fprintf(&out, "csa_run_phase_seconds{%s,phase=%q} %g\n", labels, phase, m.phases[phase])
+		}
+	}
+
+	return out.Bytes()
+}
+
+//metricsDir makes sure the metrics file can be written before the run starts
+func metricsDir(path string) error {
+	return os.MkdirAll(filepath.Dir(path), 0755)
+}
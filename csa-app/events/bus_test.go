@@ -0,0 +1,141 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package events_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"csa-app/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusDeliversAcceptedEvents(t *testing.T) {
+
+	var received []events.Event
+	var slack []map[string]string
+	var lock sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.URL.Path == "/slack" {
+			var msg map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+			slack = append(slack, msg)
+			return
+		}
+		var event events.Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	finished, err := events.ParseEventTypes("run-finished")
+	assert.NoError(t, err)
+
+	bus := events.NewBus(0,
+		events.NewWebhookSubscriber(server.URL+"/hook", nil),
+		events.NewSlackSubscriber(server.URL+"/slack", finished))
+
+	bus.Publish(events.Event{Type: events.RUN_STARTED, RunID: 7, Alias: "petclinic"})
+	bus.Publish(events.Event{Type: events.RUN_FINISHED, RunID: 7, Alias: "petclinic", Elapsed: "3s", Findings: 42})
+	bus.Close()
+
+	//Publishing after close is dropped
+	bus.Publish(events.Event{Type: events.RUN_STARTED})
+
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, events.RUN_STARTED, received[0].Type)
+	assert.Equal(t, 42, received[1].Findings)
+	assert.False(t, received[1].Time.IsZero())
+
+	assert.Equal(t, 1, len(slack))
+	assert.Contains(t, slack[0]["text"], "Findings: 42")
+}
+
+type blockingSubscriber struct {
+	release chan struct{}
+}
+
+func (s *blockingSubscriber) Name() string                  { return "blocking" }
+func (s *blockingSubscriber) Accepts(events.EventType) bool { return true }
+func (s *blockingSubscriber) Notify(events.Event) error {
+	<-s.release
+	return nil
+}
+
+func TestPublishDropsEventsWhenBufferIsFull(t *testing.T) {
+
+	subscriber := &blockingSubscriber{release: make(chan struct{})}
+	bus := events.NewBus(0, subscriber)
+
+	//One event is held by the dispatcher, the buffer holds the next EVENT_BUFFER
+	published := events.EVENT_BUFFER + 11
+	for i := 0; i < published; i++ {
+		bus.Publish(events.Event{Type: events.PHASE_COMPLETED})
+	}
+
+	assert.True(t, bus.Dropped() >= 10)
+	assert.True(t, bus.Dropped() <= 11)
+
+	close(subscriber.release)
+	bus.Close()
+}
+
+func TestThresholdCrossedOnce(t *testing.T) {
+
+	bus := events.NewBus(10)
+	defer bus.Close()
+
+	assert.False(t, bus.ThresholdCrossed(9))
+	assert.True(t, bus.ThresholdCrossed(12))
+	assert.False(t, bus.ThresholdCrossed(15))
+
+	disabled := events.NewBus(0)
+	defer disabled.Close()
+	assert.False(t, disabled.ThresholdCrossed(100))
+}
+
+func TestParseEventTypes(t *testing.T) {
+
+	all, err := events.ParseEventTypes("all")
+	assert.NoError(t, err)
+	assert.Equal(t, len(events.EventTypes), len(all))
+
+	selected, err := events.ParseEventTypes(" Run-Started, finding-threshold ")
+	assert.NoError(t, err)
+	assert.True(t, selected[events.RUN_STARTED])
+	assert.False(t, selected[events.RUN_FINISHED])
+
+	_, err = events.ParseEventTypes("run-exploded")
+	assert.Error(t, err)
+}
+
+func TestMetricsSubscriber(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "csa-metrics")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "csa.prom")
+	bus := events.NewBus(0, events.NewMetricsSubscriber(path))
+	bus.Publish(events.Event{Type: events.RUN_STARTED, RunID: 3, Alias: "app"})
+	bus.Publish(events.Event{Type: events.PHASE_COMPLETED, RunID: 3, Alias: "app", Phase: "analysis", Elapsed: "1.5s", Findings: 5})
+	bus.Close()
+
+	metrics, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(metrics), `csa_run_phase_seconds{run="3",alias="app",phase="analysis"} 1.5`)
+	assert.Contains(t, string(metrics), `csa_run_findings{run="3",alias="app"} 5`)
+	assert.Contains(t, string(metrics), `csa_run_events_total{run="3",alias="app",type="run-started"} 1`)
+}
@@ -20,9 +20,7 @@ var (
 
 func main() {
 
-	util.App.HelpFlag.Short('?')
-	util.App.Version(Version)
-	util.App.Author("Steve Woods (Pivotal)")
+	util.App.SetVersion(Version)
 
 	// Begin DB Initialization
 	var run = model.Run{}
@@ -64,6 +64,9 @@ func AnalyzeFile(file util.FileInfo, language *util.Language, opts *util.ClocOpt
 	for scanner.Scan() {
 		lineOrg := scanner.Text()
 		line := strings.TrimSpace(lineOrg)
+		if language.Name == "COBOL" {
+			line = strings.TrimSpace(util.CobolSourceArea(lineOrg))
+		}
 
 		if len(strings.TrimSpace(line)) == 0 {
 			clocFile.Blanks++
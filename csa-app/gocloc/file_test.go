@@ -180,3 +180,36 @@ func main() {
 		t.Errorf("invalid logic. code=%v", clocFile.Code)
 	}
 }
+
+func TestAnalayzeFile4CobolWithSequenceNumbers(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "tmp.cbl")
+	if err != nil {
+		t.Logf("ioutil.TempFile() error. err=[%v]", err)
+		return
+	}
+	defer os.Remove(tmpfile.Name())
+
+	_, _ = tmpfile.Write([]byte(`000100 IDENTIFICATION DIVISION.
+000200*PRINTS THE NIGHTLY ORDER REPORT
+000300 PROGRAM-ID. ORDRPT.
+000400
+      * NO SEQUENCE NUMBER
+       PROCEDURE DIVISION.
+           DISPLAY 'DONE'. *> INLINE
+`))
+
+	language := util.NewLanguage("COBOL", []string{"*", "/"}, "", "")
+	clocOpts := util.NewClocOptions()
+	clocFile := AnalyzeFile(*util.NewFileInfo("", tmpfile.Name(), tmpfile.Name(), "", "", "", true), language, clocOpts)
+	tmpfile.Close()
+
+	if clocFile.Blanks != 1 {
+		t.Errorf("invalid logic. blanks=%v", clocFile.Blanks)
+	}
+	if clocFile.Comments != 2 {
+		t.Errorf("invalid logic. comments=%v", clocFile.Comments)
+	}
+	if clocFile.Code != 4 {
+		t.Errorf("invalid logic. code=%v", clocFile.Code)
+	}
+}
@@ -107,3 +107,20 @@ func TestGetShebangStartDot(t *testing.T) {
 		t.Errorf("invalid logic. lang=[%v] shebang=[%v]", lang, s)
 	}
 }
+
+func TestGetFileTypeOfScripts(t *testing.T) {
+	opts := util.NewClocOptions()
+
+	for path, lang := range map[string]string{"src/server.mjs": "JavaScript", "src/App.tsx": "TypeScript", "src/db.cts": "TypeScript", "app.pyw": "Python"} {
+		ext, ok := util.GetFileType(path, opts)
+		if !ok || util.Exts[ext] != lang {
+			t.Errorf("invalid logic. path=[%v] ext=[%v] lang=[%v]", path, ext, util.Exts[ext])
+		}
+	}
+
+	for _, path := range []string{"public/jquery-3.6.0.min.js", "dist/main.bundle.js", "build/static/js/2.chunk.js"} {
+		if ext, ok := util.GetFileType(path, opts); ok {
+			t.Errorf("invalid logic. generated path=[%v] ext=[%v]", path, ext)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//SOURCE_LOCALE is the locale rule advice is written in. Its catalog is the template translations are made from
+const SOURCE_LOCALE = "en"
+const ADVICE_CATALOG_PREFIX = "advice."
+
+var localeRegex = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+//RuleAdvice is the advice of a rule and of its patterns (by pattern value) in one locale
+type RuleAdvice struct {
+	Advice   string            `json:"advice,omitempty" yaml:"advice,omitempty"`
+	Patterns map[string]string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+}
+
+//AdviceCatalog holds the advice of the rules translated into a locale. Catalogs live in the i18n dir as
+//advice.<locale>.yaml so advice can be translated without touching the rule files.
+type AdviceCatalog struct {
+	Locale string                 `json:"locale" yaml:"locale"`
+	Rules  map[string]*RuleAdvice `json:"rules" yaml:"rules"`
+}
+
+//LocaleCoverage is how much of the source advice a locale's catalog translates
+type LocaleCoverage struct {
+	Locale     string  `json:"locale"`
+	Translated int     `json:"translated"`
+	Total      int     `json:"total"`
+	Percent    float64 `json:"percent"`
+}
+
+func NewAdviceCatalog(locale string) *AdviceCatalog {
+	return &AdviceCatalog{Locale: locale, Rules: make(map[string]*RuleAdvice)}
+}
+
+func ValidateLocale(locale string) error {
+	if !localeRegex.MatchString(locale) {
+		return fmt.Errorf("invalid locale [%s]. Expected a language code optionally followed by a region. I.E. de or pt-BR", locale)
+	}
+	return nil
+}
+
+//ExtractAdvice builds the source catalog from the advice of the rules themselves
+func ExtractAdvice(rules []Rule) *AdviceCatalog {
+
+	catalog := NewAdviceCatalog(SOURCE_LOCALE)
+
+	for i := range rules {
+		advice := &RuleAdvice{Advice: rules[i].Advice}
+		for _, pattern := range rules[i].Patterns {
+			if pattern.Advice != "" {
+				if advice.Patterns == nil {
+					advice.Patterns = make(map[string]string)
+				}
+				advice.Patterns[pattern.Value] = pattern.Advice
+			}
+		}
+		if advice.Advice != "" || len(advice.Patterns) > 0 {
+			catalog.Rules[rules[i].Name] = advice
+		}
+	}
+
+	return catalog
+}
+
+func AdviceCatalogFile(dir string, locale string) string {
+	return filepath.Join(dir, ADVICE_CATALOG_PREFIX+locale+".yaml")
+}
+
+//LoadAdviceCatalog reads the catalog of the locale falling back to its language. I.E. pt-BR falls back to pt
+func LoadAdviceCatalog(dir string, locale string) (*AdviceCatalog, error) {
+
+	if err := ValidateLocale(locale); err != nil {
+		return nil, err
+	}
+
+	catalog, err := ReadAdviceCatalog(AdviceCatalogFile(dir, locale))
+	if os.IsNotExist(err) && strings.Contains(locale, "-") {
+		catalog, err = ReadAdviceCatalog(AdviceCatalogFile(dir, strings.Split(locale, "-")[0]))
+	}
+
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no advice catalog for locale [%s] in [%s]", locale, dir)
+	}
+
+	return catalog, err
+}
+
+//ReadAdviceCatalog reads a catalog file. Catalogs are yaml (json being a subset of it)
+func ReadAdviceCatalog(file string) (*AdviceCatalog, error) {
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := NewAdviceCatalog("")
+	if err = yaml.UnmarshalStrict(data, catalog); err != nil {
+		return nil, fmt.Errorf("advice catalog [%s] is invalid! Details: %v", file, err)
+	}
+
+	if catalog.Rules == nil {
+		catalog.Rules = make(map[string]*RuleAdvice)
+	}
+
+	return catalog, nil
+}
+
+//AvailableLocales returns the locales with a catalog in the dir, sorted
+func AvailableLocales(dir string) []string {
+
+	var locales []string
+
+	files, _ := filepath.Glob(filepath.Join(dir, ADVICE_CATALOG_PREFIX+"*.yaml"))
+	for _, file := range files {
+		locale := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), ADVICE_CATALOG_PREFIX), ".yaml")
+		if ValidateLocale(locale) == nil {
+			locales = append(locales, locale)
+		}
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+func (c *AdviceCatalog) Save(dir string) error {
+
+	if err := ValidateLocale(c.Locale); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(AdviceCatalogFile(dir, c.Locale), data, 0644)
+}
+
+//Localize replaces the advice of the rules (and their patterns) with the catalog's translations. Advice without a
+//translation is left as is. Returns the number of rules localized.
+func (c *AdviceCatalog) Localize(rules []Rule) int {
+
+	localized := 0
+
+	for i := range rules {
+		advice, found := c.Rules[rules[i].Name]
+		if !found {
+			continue
+		}
+
+		if advice.Advice != "" {
+			rules[i].Advice = advice.Advice
+		}
+
+		for j := range rules[i].Patterns {
+			if translation := advice.Patterns[rules[i].Patterns[j].Value]; translation != "" && rules[i].Patterns[j].Advice != "" {
+				rules[i].Patterns[j].Advice = translation
+			}
+		}
+
+		localized++
+	}
+
+	return localized
+}
+
+//Contribute merges translations into the catalog. Every translation must be of advice found in the source catalog.
+func (c *AdviceCatalog) Contribute(source *AdviceCatalog, contribution *AdviceCatalog) (added int, err error) {
+
+	for name, advice := range contribution.Rules {
+		sourceAdvice, found := source.Rules[name]
+		if !found {
+			return 0, fmt.Errorf("rule [%s] has no advice to translate", name)
+		}
+		if advice.Advice != "" && sourceAdvice.Advice == "" {
+			return 0, fmt.Errorf("rule [%s] has no rule level advice to translate", name)
+		}
+		for value := range advice.Patterns {
+			if _, found = sourceAdvice.Patterns[value]; !found {
+				return 0, fmt.Errorf("rule [%s] pattern [%s] has no advice to translate", name, value)
+			}
+		}
+	}
+
+	for name, advice := range contribution.Rules {
+		existing, found := c.Rules[name]
+		if !found {
+			existing = &RuleAdvice{}
+			c.Rules[name] = existing
+		}
+
+		if advice.Advice != "" {
+			existing.Advice = advice.Advice
+			added++
+		}
+
+		for value, translation := range advice.Patterns {
+			if translation == "" {
+				continue
+			}
+			if existing.Patterns == nil {
+				existing.Patterns = make(map[string]string)
+			}
+			existing.Patterns[value] = translation
+			added++
+		}
+	}
+
+	return added, nil
+}
+
+//Coverage counts the source advice (rule and pattern level) the catalog translates
+func (c *AdviceCatalog) Coverage(source *AdviceCatalog) LocaleCoverage {
+
+	coverage := LocaleCoverage{Locale: c.Locale}
+
+	for name, sourceAdvice := range source.Rules {
+		advice := c.Rules[name]
+		if sourceAdvice.Advice != "" {
+			coverage.Total++
+			if advice != nil && advice.Advice != "" {
+				coverage.Translated++
+			}
+		}
+		for value := range sourceAdvice.Patterns {
+			coverage.Total++
+			if advice != nil && advice.Patterns[value] != "" {
+				coverage.Translated++
+			}
+		}
+	}
+
+	if coverage.Total > 0 {
+		coverage.Percent = float64(coverage.Translated*10000/coverage.Total) / 100
+	}
+
+	return coverage
+}
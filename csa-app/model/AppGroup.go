@@ -0,0 +1,129 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const BUSINESS_UNIT_GROUP = "business-unit"
+const PORTFOLIO_GROUP = "portfolio"
+
+//AppGroup places applications into a business unit -> portfolio -> app hierarchy. Applications are members of
+//portfolios and portfolios name the business unit they belong to as their parent.
+type AppGroup struct {
+	ID           uint              `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt    time.Time         `json:"-" yaml:"-"`
+	UpdatedAt    time.Time         `json:"-" yaml:"-"`
+	Name         string            `gorm:"type:text;unique_index;not null" json:"name" yaml:"name"`
+	Type         string            `gorm:"type:text;not null" json:"type" yaml:"type"`
+	Parent       string            `gorm:"type:text" json:"parent,omitempty" yaml:"parent,omitempty"`
+	Applications []*AppGroupMember `gorm:"foreignkey:AppGroupID" json:"applications,omitempty" yaml:"applications,omitempty"`
+}
+
+type AppGroupMember struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	UpdatedAt   time.Time `json:"-" yaml:"-"`
+	AppGroupID  uint      `gorm:"index;not null" sql:"type:bigint REFERENCES app_groups(id) ON DELETE CASCADE" json:"-" yaml:"-"`
+	Application string    `gorm:"type:text;not null" json:"name" yaml:"name"`
+}
+
+//GroupRollup is the aggregate of all applications found beneath a group for a single run
+type GroupRollup struct {
+	Name         string  `json:"name"`
+	Type         string  `json:"type"`
+	Parent       string  `json:"parent,omitempty"`
+	Applications int     `json:"applications"`
+	Findings     int     `json:"findings"`
+	Effort       int     `json:"effort"`
+	SlocCnt      int     `json:"slocCnt"`
+	Score        float64 `json:"score"`
+}
+
+func (g *AppGroup) Validate() error {
+	switch g.Type {
+	case BUSINESS_UNIT_GROUP:
+		if g.Parent != "" {
+			return fmt.Errorf("group [%s] is a %s and cannot have a parent", g.Name, BUSINESS_UNIT_GROUP)
+		}
+		if len(g.Applications) > 0 {
+			return fmt.Errorf("group [%s] is a %s! applications must be added to a %s", g.Name, BUSINESS_UNIT_GROUP, PORTFOLIO_GROUP)
+		}
+	case PORTFOLIO_GROUP:
+	default:
+		return fmt.Errorf("group [%s] has invalid type [%s]! valid types are [%s, %s]", g.Name, g.Type, BUSINESS_UNIT_GROUP, PORTFOLIO_GROUP)
+	}
+	return nil
+}
+
+func (g *AppGroup) HasApplication(name string) bool {
+	for _, member := range g.Applications {
+		if member.Application == name {
+			return true
+		}
+	}
+	return false
+}
+
+//RollupGroups aggregates the applications of a run up to every portfolio and business unit. Scores are averaged
+//across applications while findings, effort and sloc are summed.
+func RollupGroups(groups []AppGroup, apps []Application) []GroupRollup {
+
+	appsByName := make(map[string]Application)
+	for _, app := range apps {
+		appsByName[app.Name] = app
+	}
+
+	//Business units collect the applications of all of their portfolios
+	members := make(map[string]map[string]bool)
+	for _, group := range groups {
+		if _, found := members[group.Name]; !found {
+			members[group.Name] = make(map[string]bool)
+		}
+		for _, member := range group.Applications {
+			members[group.Name][member.Application] = true
+			if group.Parent != "" {
+				if _, found := members[group.Parent]; !found {
+					members[group.Parent] = make(map[string]bool)
+				}
+				members[group.Parent][member.Application] = true
+			}
+		}
+	}
+
+	var rollups []GroupRollup
+	for _, group := range groups {
+		rollup := GroupRollup{Name: group.Name, Type: group.Type, Parent: group.Parent}
+		totalScore := 0.0
+		for name := range members[group.Name] {
+			app, found := appsByName[name]
+			if !found {
+				continue
+			}
+			rollup.Applications++
+			rollup.Findings += app.Findings
+			rollup.Effort += app.RawScore
+			rollup.SlocCnt += app.SlocCnt
+			totalScore += app.Score
+		}
+		if rollup.Applications > 0 {
+			rollup.Score = totalScore / float64(rollup.Applications)
+		}
+		rollups = append(rollups, rollup)
+	}
+
+	sort.Slice(rollups, func(i, j int) bool {
+		if rollups[i].Type != rollups[j].Type {
+			return rollups[i].Type == BUSINESS_UNIT_GROUP
+		}
+		return rollups[i].Name < rollups[j].Name
+	})
+
+	return rollups
+}
@@ -0,0 +1,385 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const APP_SERVER_TAG = "app-server"
+
+const APP_SERVER_WEBSPHERE = "websphere"
+const APP_SERVER_WEBLOGIC = "weblogic"
+const APP_SERVER_JBOSS = "jboss"
+
+//Kinds of server resources
+const APP_SERVER_DATASOURCE = "datasource"
+const APP_SERVER_JNDI = "jndi"
+const APP_SERVER_WORK_MANAGER = "work-manager"
+const APP_SERVER_SECURITY_REALM = "security-realm"
+
+//AppServerResource is a resource an application server provides an application with, as configured (or bound) by a
+//descriptor of the application: a datasource, a JNDI binding, a work manager or a security realm. Descriptor is
+//relative to the application root, Line is the line of the element the resource was read from.
+type AppServerResource struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	RunID       uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application string    `gorm:"index;not null" json:"application" yaml:"application"`
+	Server      string    `gorm:"type:text;not null" json:"server" yaml:"server"`
+	Kind        string    `gorm:"type:text;not null" json:"kind" yaml:"kind"`
+	Name        string    `gorm:"type:text" json:"name" yaml:"name"`
+	Jndi        string    `gorm:"type:text" json:"jndi,omitempty" yaml:"jndi,omitempty"`
+	Detail      string    `gorm:"type:text" json:"detail,omitempty" yaml:"detail,omitempty"`
+	Descriptor  string    `gorm:"type:text" json:"descriptor" yaml:"descriptor"`
+	Line        int       `json:"line" yaml:"line"`
+	Fqn         string    `gorm:"-" json:"-" yaml:"-"`
+}
+
+//AppServerExtractor reads the resources of a kind from the descriptors matching Files (globs matched against lower
+//cased file names) whose document element is one of Roots (any when empty). A resource is read from every Element,
+//anywhere in the document, below Parent when given. Name, Jndi and Detail list where their value is, the first
+//non-empty one winning: "@attribute", a child element path ("jdbc-driver-params/url") or "." for the element's text.
+type AppServerExtractor struct {
+	Server  string
+	Kind    string
+	Files   []string
+	Roots   []string
+	Element string
+	Parent  string
+	Name    []string
+	Jndi    []string
+	Detail  []string
+}
+
+//AppServerKind is what a kind of resource becomes off the application server, and the effort of the structured
+//finding recorded for every resource of the kind
+type AppServerKind struct {
+	Kind        string
+	Description string
+	Replacement string
+	Effort      int
+}
+
+var AppServerKinds = []AppServerKind{
+	{Kind: APP_SERVER_DATASOURCE, Description: "Server managed datasource", Effort: 8,
+		Replacement: "Configure the connection pool in the application (I.E. spring.datasource) and bind the database as a service"},
+	{Kind: APP_SERVER_JNDI, Description: "JNDI binding of a server resource", Effort: 3,
+		Replacement: "Inject the resource from application configuration or environment variables instead of looking it up"},
+	{Kind: APP_SERVER_WORK_MANAGER, Description: "Server work manager or managed executor", Effort: 5,
+		Replacement: "Use an executor (thread pool) configured by the application, I.E. a Spring TaskExecutor"},
+	{Kind: APP_SERVER_SECURITY_REALM, Description: "Server security realm or domain", Effort: 8,
+		Replacement: "Authenticate against an identity provider (OAuth2/OIDC, LDAP) configured by the application"},
+}
+
+var AppServerExtractors = []AppServerExtractor{
+	//WebSphere Liberty server configuration
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_DATASOURCE, Files: []string{"server.xml"}, Roots: []string{"server"}, Element: "dataSource",
+		Name: []string{"@id", "@jndiName"}, Jndi: []string{"@jndiName"},
+		Detail: []string{"properties/@URL", "properties/@url", "properties.oracle/@URL", "properties.db2.jcc/@serverName", "properties/@serverName", "properties/@databaseName", "@jdbcDriverRef"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_JNDI, Files: []string{"server.xml"}, Roots: []string{"server"}, Element: "jndiEntry",
+		Name: []string{"@jndiName", "@id"}, Jndi: []string{"@jndiName"}, Detail: []string{"@value"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_JNDI, Files: []string{"server.xml"}, Roots: []string{"server"}, Element: "jndiURLEntry",
+		Name: []string{"@jndiName", "@id"}, Jndi: []string{"@jndiName"}, Detail: []string{"@value"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_WORK_MANAGER, Files: []string{"server.xml"}, Roots: []string{"server"}, Element: "managedExecutorService",
+		Name: []string{"@id", "@jndiName"}, Jndi: []string{"@jndiName"}, Detail: []string{"@concurrencyPolicyRef"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_WORK_MANAGER, Files: []string{"server.xml"}, Roots: []string{"server"}, Element: "managedScheduledExecutorService",
+		Name: []string{"@id", "@jndiName"}, Jndi: []string{"@jndiName"}, Detail: []string{"@concurrencyPolicyRef"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_SECURITY_REALM, Files: []string{"server.xml"}, Roots: []string{"server"}, Element: "basicRegistry",
+		Name: []string{"@realm", "@id"}, Detail: []string{"@id"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_SECURITY_REALM, Files: []string{"server.xml"}, Roots: []string{"server"}, Element: "ldapRegistry",
+		Name: []string{"@realm", "@id"}, Detail: []string{"@host", "@baseDN"}},
+	//WebSphere bindings
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_JNDI, Files: []string{"ibm-web-bnd.xml", "ibm-ejb-jar-bnd.xml", "ibm-application-bnd.xml"}, Element: "resource-ref",
+		Name: []string{"@name"}, Jndi: []string{"@binding-name"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_JNDI, Files: []string{"ibm-web-bnd.xml", "ibm-ejb-jar-bnd.xml", "ibm-application-bnd.xml"}, Element: "resource-env-ref",
+		Name: []string{"@name"}, Jndi: []string{"@binding-name"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_JNDI, Files: []string{"ibm-web-bnd.xml", "ibm-ejb-jar-bnd.xml"}, Element: "ejb-ref",
+		Name: []string{"@name"}, Jndi: []string{"@binding-name"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_JNDI, Files: []string{"ibm-web-bnd.xml", "ibm-ejb-jar-bnd.xml"}, Element: "message-destination-ref",
+		Name: []string{"@name"}, Jndi: []string{"@binding-name"}},
+	{Server: APP_SERVER_WEBSPHERE, Kind: APP_SERVER_JNDI, Files: []string{"ibm-web-bnd.xmi", "ibm-ejb-jar-bnd.xmi"}, Element: "resRefBindings",
+		Name: []string{"@jndiName"}, Jndi: []string{"@jndiName"}},
+
+	//WebLogic deployment descriptors
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_JNDI, Files: []string{"weblogic.xml", "weblogic-ejb-jar.xml"}, Element: "resource-description",
+		Name: []string{"res-ref-name"}, Jndi: []string{"jndi-name"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_JNDI, Files: []string{"weblogic.xml", "weblogic-ejb-jar.xml"}, Element: "resource-env-description",
+		Name: []string{"resource-env-ref-name"}, Jndi: []string{"jndi-name"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_JNDI, Files: []string{"weblogic.xml", "weblogic-ejb-jar.xml"}, Element: "ejb-reference-description",
+		Name: []string{"ejb-ref-name"}, Jndi: []string{"jndi-name"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_WORK_MANAGER, Files: []string{"weblogic.xml", "weblogic-application.xml", "weblogic-ejb-jar.xml"}, Element: "work-manager",
+		Name: []string{"name"}, Detail: []string{"max-threads-constraint/count", "min-threads-constraint/count"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_SECURITY_REALM, Files: []string{"weblogic-application.xml"}, Element: "security",
+		Name: []string{"realm-name"}},
+	//WebLogic domain configuration and JDBC modules
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_DATASOURCE, Files: []string{"config.xml"}, Roots: []string{"domain"}, Element: "jdbc-system-resource",
+		Name: []string{"name"}, Detail: []string{"descriptor-file-name"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_DATASOURCE, Files: []string{"*-jdbc.xml"}, Roots: []string{"jdbc-data-source"}, Element: "jdbc-data-source",
+		Name: []string{"name"}, Jndi: []string{"jdbc-data-source-params/jndi-name"}, Detail: []string{"jdbc-driver-params/url"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_JNDI, Files: []string{"config.xml"}, Roots: []string{"domain"}, Element: "foreign-jndi-provider",
+		Name: []string{"name"}, Detail: []string{"provider-url"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_WORK_MANAGER, Files: []string{"config.xml"}, Roots: []string{"domain"}, Element: "work-manager", Parent: "self-tuning",
+		Name: []string{"name"}, Detail: []string{"target"}},
+	{Server: APP_SERVER_WEBLOGIC, Kind: APP_SERVER_SECURITY_REALM, Files: []string{"config.xml"}, Roots: []string{"domain"}, Element: "realm", Parent: "security-configuration",
+		Name: []string{"name"}},
+
+	//JBoss/WildFly datasources, server configuration and deployment descriptors
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_DATASOURCE, Files: []string{"*-ds.xml", "standalone*.xml", "domain.xml"}, Element: "datasource",
+		Name: []string{"@pool-name", "@jndi-name"}, Jndi: []string{"@jndi-name"}, Detail: []string{"connection-url"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_DATASOURCE, Files: []string{"*-ds.xml", "standalone*.xml", "domain.xml"}, Element: "xa-datasource",
+		Name: []string{"@pool-name", "@jndi-name"}, Jndi: []string{"@jndi-name"}, Detail: []string{"xa-datasource-property"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_JNDI, Files: []string{"standalone*.xml", "domain.xml"}, Element: "simple", Parent: "bindings",
+		Name: []string{"@name"}, Jndi: []string{"@name"}, Detail: []string{"@value"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_JNDI, Files: []string{"standalone*.xml", "domain.xml"}, Element: "lookup", Parent: "bindings",
+		Name: []string{"@name"}, Jndi: []string{"@name"}, Detail: []string{"@lookup"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_WORK_MANAGER, Files: []string{"standalone*.xml", "domain.xml"}, Element: "managed-executor-service",
+		Name: []string{"@name"}, Jndi: []string{"@jndi-name"}, Detail: []string{"@max-threads"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_SECURITY_REALM, Files: []string{"standalone*.xml", "domain.xml"}, Element: "security-domain", Parent: "security-domains",
+		Name: []string{"@name"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_SECURITY_REALM, Files: []string{"standalone*.xml", "domain.xml"}, Element: "security-realm", Parent: "security-realms",
+		Name: []string{"@name"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_JNDI, Files: []string{"jboss-web.xml", "jboss-ejb3.xml"}, Element: "resource-ref",
+		Name: []string{"res-ref-name"}, Jndi: []string{"jndi-name", "lookup-name"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_JNDI, Files: []string{"jboss-web.xml", "jboss-ejb3.xml"}, Element: "resource-env-ref",
+		Name: []string{"resource-env-ref-name"}, Jndi: []string{"jndi-name", "lookup-name"}},
+	{Server: APP_SERVER_JBOSS, Kind: APP_SERVER_SECURITY_REALM, Files: []string{"jboss-web.xml"}, Roots: []string{"jboss-web"}, Element: "security-domain",
+		Name: []string{"."}},
+}
+
+//AppServerInventory is the migration inventory of an application: the server resources its descriptors configure or
+//bind, what they amount to by kind and the effort of replacing them
+type AppServerInventory struct {
+	Application string              `json:"application"`
+	Servers     []string            `json:"servers"`
+	Kinds       map[string]int      `json:"kinds"`
+	Effort      int                 `json:"effort"`
+	Resources   []AppServerResource `json:"resources"`
+}
+
+//AppServerInventories groups the resources by application, in the order of the applications
+func AppServerInventories(resources []AppServerResource) []AppServerInventory {
+
+	var inventories []AppServerInventory
+	index := make(map[string]int)
+
+	for _, resource := range resources {
+		i, found := index[resource.Application]
+		if !found {
+			i = len(inventories)
+			index[resource.Application] = i
+			inventories = append(inventories, AppServerInventory{Application: resource.Application, Kinds: make(map[string]int)})
+		}
+
+		inventory := &inventories[i]
+		if !containsString(inventory.Servers, resource.Server) {
+			inventory.Servers = append(inventory.Servers, resource.Server)
+		}
+		inventory.Kinds[resource.Kind]++
+		inventory.Effort += AppServerKindOf(resource.Kind).Effort
+		inventory.Resources = append(inventory.Resources, resource)
+	}
+
+	for i := range inventories {
+		sort.Strings(inventories[i].Servers)
+	}
+	sort.SliceStable(inventories, func(i, j int) bool { return inventories[i].Application < inventories[j].Application })
+
+	return inventories
+}
+
+//xmlElement is an element of a parsed descriptor, with the line it starts on. Names are local, I.E. without their
+//namespace prefix.
+type xmlElement struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	line     int
+	parent   *xmlElement
+	children []*xmlElement
+}
+
+//DetectAppServerResources reads the datasources, JNDI bindings, work managers and security realms of the
+//application's server descriptors. Third-party (vendored) files and descriptors that don't parse are skipped.
+func DetectAppServerResources(runId uint, app *Application, extractors []AppServerExtractor) []*AppServerResource {
+
+	var resources []*AppServerResource
+	documents := make(map[string]*xmlElement)
+	contents := make(map[string]string)
+
+	for _, file := range app.Files {
+		if file.ThirdParty != "" {
+			continue
+		}
+
+		for _, extractor := range extractors {
+			detector := TechDetector{Files: extractor.Files}
+			if !detector.matchesFile(file.Name) {
+				continue
+			}
+
+			root, parsed := documents[file.FQN]
+			if !parsed {
+				root = parseXmlElements(readDetectionFile(file.FQN, contents))
+				documents[file.FQN] = root
+			}
+			if root == nil || (len(extractor.Roots) > 0 && !containsString(extractor.Roots, root.name)) {
+				continue
+			}
+
+			root.walk(func(element *xmlElement) {
+				if element.name != extractor.Element || (extractor.Parent != "" && (element.parent == nil || element.parent.name != extractor.Parent)) {
+					return
+				}
+				resource := &AppServerResource{RunID: runId, Application: app.Name, Server: extractor.Server, Kind: extractor.Kind,
+					Name: element.first(extractor.Name), Jndi: element.first(extractor.Jndi), Detail: element.first(extractor.Detail),
+					Descriptor: relativeEvidence(app, file), Line: element.line, Fqn: file.FQN}
+				if resource.Name != "" || resource.Jndi != "" {
+					resources = append(resources, resource)
+				}
+			})
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Descriptor != resources[j].Descriptor {
+			return resources[i].Descriptor < resources[j].Descriptor
+		}
+		return resources[i].Line < resources[j].Line
+	})
+
+	return resources
+}
+
+//Finding is the structured finding recorded for the resource, tagged app-server, its server and kind, its value the
+//resource's name and JNDI name
+func (r *AppServerResource) Finding() Finding {
+
+	kind := AppServerKindOf(r.Kind)
+
+	value := r.Name
+	if r.Jndi != "" && r.Jndi != r.Name {
+		value += " => " + r.Jndi
+	}
+
+	return Finding{RunID: r.RunID, Application: r.Application, Filename: filepath.Base(r.Descriptor),
+		Fqn: r.Fqn, Ext: filepath.Ext(r.Descriptor), Line: r.Line, Rule: APP_SERVER_TAG + "-" + r.Kind, Pattern: r.Kind, Value: value,
+		Advice: kind.Description + ". " + kind.Replacement, Effort: kind.Effort, Category: APP_SERVER_TAG, Confidence: CONFIDENCE_HIGH,
+		Tags: []FindingTag{{Value: APP_SERVER_TAG}, {Value: r.Server}, {Value: APP_SERVER_TAG + "-" + r.Kind}}}
+}
+
+//AppServerKindOf returns the kind of resource, an empty one for unknown kinds
+func AppServerKindOf(kind string) AppServerKind {
+	for _, known := range AppServerKinds {
+		if known.Kind == kind {
+			return known
+		}
+	}
+	return AppServerKind{Kind: kind}
+}
+
+//parseXmlElements parses the document into its elements, nil when it isn't XML
+func parseXmlElements(content string) *xmlElement {
+
+	if content == "" {
+		return nil
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	var root, current *xmlElement
+	line, counted := 1, int64(0)
+	for {
+		offset := decoder.InputOffset()
+		line += strings.Count(content[counted:offset], "\n")
+		counted = offset
+		token, err := decoder.Token()
+		if err != nil {
+			if err != io.EOF {
+				return nil
+			}
+			return root
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: t.Name.Local, attrs: make(map[string]string), parent: current, line: line}
+			for _, attr := range t.Attr {
+				element.attrs[attr.Name.Local] = attr.Value
+			}
+			if current == nil {
+				root = element
+			} else {
+				current.children = append(current.children, element)
+			}
+			current = element
+		case xml.EndElement:
+			if current != nil {
+				current = current.parent
+			}
+		case xml.CharData:
+			if current != nil {
+				current.text += string(t)
+			}
+		}
+	}
+}
+
+func (e *xmlElement) walk(visit func(element *xmlElement)) {
+	visit(e)
+	for _, child := range e.children {
+		child.walk(visit)
+	}
+}
+
+//first returns the first non-empty value of the paths: "@attribute", "child/grandchild", "child/@attribute" or "."
+func (e *xmlElement) first(paths []string) string {
+	for _, path := range paths {
+		if value := strings.TrimSpace(e.value(strings.Split(path, "/"))); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (e *xmlElement) value(steps []string) string {
+	step := steps[0]
+	switch {
+	case step == ".":
+		return e.text
+	case strings.HasPrefix(step, "@"):
+		return e.attrs[step[1:]]
+	}
+
+	for _, child := range e.children {
+		if child.name != step {
+			continue
+		}
+		if len(steps) == 1 {
+			return child.text
+		}
+		if value := child.value(steps[1:]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -32,23 +32,27 @@ type ApplicationConfig struct {
 	DirExcludeRegex  string           `json:"dir-exclude-regex,omitempty" yaml:"dir-exclude-regex,omitempty"`
 	IncludeFileRegex string           `json:"include-file-regex,omitempty" yaml:"include-file-regex,omitempty"`
 	ExcludeFileRegex string           `json:"exclude-file-regex,omitempty" yaml:"exclude-file-regex,omitempty"`
+	ThirdPartyPaths  []string         `json:"third-party-paths,omitempty" yaml:"third-party-paths,omitempty"`
 	Files            []*util.FileInfo `json:"-" yaml:"-"`
 	IgnoredFiles     []*util.FileInfo `json:"-" yaml:"-"`
 	FileUtil         *util.FileUtil   `json:"-" yaml:"-"`
+	filter           *util.PathFilter
+	portfolio        string //directory of the portfolio the app was discovered in, whose .csaignore applies to the app
 }
 
 type configFileConfig struct {
-	Name             string  `json:"name,required" yaml:"name"`
-	Category         string  `json:"category,omitempty" yaml:"category,omitempty"`
-	Criticality      string  `json:"criticality,omitempty" yaml:"criticality,omitempty"`
-	BusinessDomain   string  `json:"business-domain,omitempty" yaml:"business-domain,omitempty"`
-	BusinessValue    float64 `json:"business-value,omitempty" yaml:"business-value,omitempty"`
-	ScoringModel     string  `json:"scoring-model,omitempty" yaml:"scoring-model,omitempty"`
-	RuleIncludeTags  string  `json:"rule-include-tags" yaml:"rule-include-tags"`
-	RuleExcludeTags  string  `json:"rule-exclude-tags" yaml:"rule-exclude-tags"`
-	DirExcludeRegex  string  `json:"dir-exclude-regex" yaml:"dir-exclude-regex"`
-	IncludeFileRegex string  `json:"include-file-regex" yaml:"include-file-regex"`
-	ExcludeFileRegex string  `json:"exclude-file-regex" yaml:"exclude-file-regex"`
+	Name             string   `json:"name,required" yaml:"name"`
+	Category         string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Criticality      string   `json:"criticality,omitempty" yaml:"criticality,omitempty"`
+	BusinessDomain   string   `json:"business-domain,omitempty" yaml:"business-domain,omitempty"`
+	BusinessValue    float64  `json:"business-value,omitempty" yaml:"business-value,omitempty"`
+	ScoringModel     string   `json:"scoring-model,omitempty" yaml:"scoring-model,omitempty"`
+	RuleIncludeTags  string   `json:"rule-include-tags" yaml:"rule-include-tags"`
+	RuleExcludeTags  string   `json:"rule-exclude-tags" yaml:"rule-exclude-tags"`
+	DirExcludeRegex  string   `json:"dir-exclude-regex" yaml:"dir-exclude-regex"`
+	IncludeFileRegex string   `json:"include-file-regex" yaml:"include-file-regex"`
+	ExcludeFileRegex string   `json:"exclude-file-regex" yaml:"exclude-file-regex"`
+	ThirdPartyPaths  []string `json:"third-party-paths,omitempty" yaml:"third-party-paths,omitempty"`
 }
 
 func NewApplicationConfig(runConfig *RunConfig) *ApplicationConfig {
@@ -82,10 +86,17 @@ func (c *ApplicationConfig) AddFile(f os.FileInfo, path string) {
 		filepath.Base(path),
 		"",
 		true)
+	fInfo.ThirdParty = c.FileUtil.ThirdPartyRoot(c.Path, path, c.ThirdPartyPaths)
 
 	if !isArchive {
 
 		if c.FileUtil.FileShouldBeProcessed(f.Name()) {
+			if fInfo.ThirdParty == "" && util.IsGeneratedFile(path) {
+				//Generated sources are attributed to themselves
+				if rel, err := filepath.Rel(c.Path, path); err == nil {
+					fInfo.ThirdParty = filepath.ToSlash(rel)
+				}
+			}
 			c.Files = append(c.Files, fInfo)
 			util.WriteLog("Gathering Files", "Found File [%s]\n", f.Name())
 		} else {
@@ -101,6 +112,18 @@ func (c *ApplicationConfig) AddFile(f os.FileInfo, path string) {
 }
 
 func (c *ApplicationConfig) GatherFiles() error {
+
+	var err error
+	if c.filter, err = util.NewPathFilter(c.Path, *util.IncludeGlobs, *util.ExcludeGlobs); err != nil {
+		return err
+	}
+
+	if c.portfolio != "" {
+		if err = c.filter.AddIgnoreFile(c.portfolio); err != nil {
+			return err
+		}
+	}
+
 	return c.gatherFilesOnPath(c.Path)
 }
 
@@ -146,6 +169,10 @@ func (c *ApplicationConfig) MergeRunConfig(runConfig *RunConfig) {
 		if c.RuleIncludeTags == "" {
 			c.ExcludeFileRegex = ac.ExcludeFileRegex
 		}
+
+		if len(c.ThirdPartyPaths) == 0 {
+			c.ThirdPartyPaths = ac.ThirdPartyPaths
+		}
 	}
 
 	if c.ScoringModel == "" {
@@ -203,6 +230,10 @@ func (c *ApplicationConfig) Merge(mergeConfig *configFileConfig) {
 		c.ExcludeFileRegex = mergeConfig.ExcludeFileRegex
 	}
 
+	if len(mergeConfig.ThirdPartyPaths) > 0 {
+		c.ThirdPartyPaths = mergeConfig.ThirdPartyPaths
+	}
+
 	if mergeConfig.BusinessDomain != "" {
 		c.BusinessDomain = mergeConfig.BusinessDomain
 	}
@@ -248,6 +279,14 @@ func (c *ApplicationConfig) gatherFilesOnPath(targetPath string) error {
 				if c.FileUtil.DirIsExcluded(f.Name()) {
 					return filepath.SkipDir
 				}
+				if c.filter.Excluded(path, true) {
+					util.WriteLog("Gathering Files", "Directory [%s] within app [%s] matched the excluded globs...Excluding from analysis\n", path, c.Name)
+					return filepath.SkipDir
+				}
+				//The globs of a .csaignore apply to the directory it is in and beneath
+				return c.filter.AddIgnoreFile(path)
+			} else if c.filter.Excluded(path, false) {
+				util.WriteLog("Gathering Files", "File [%s] within app [%s] matched the excluded globs...Excluding from analysis\n", path, c.Name)
 			} else {
 				c.AddFile(f, path)
 			}
@@ -295,7 +334,7 @@ func (c *ApplicationConfig) CheckForLocalAppConfig() {
 			c.BusinessValue, c.ScoringModel,
 			c.RuleIncludeTags, c.RuleExcludeTags,
 			c.DirExcludeRegex, c.IncludeFileRegex,
-			c.ExcludeFileRegex}
+			c.ExcludeFileRegex, c.ThirdPartyPaths}
 
 		format := util.YAML
 		if *util.OutputFormatJson {
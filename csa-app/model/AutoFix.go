@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"csa-app/util"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+//FileFix is a file of an application along with its contents once the replacements of the rules it matched are applied
+type FileFix struct {
+	Path     string
+	Original string
+	Fixed    string
+	Fixes    int
+}
+
+//IsFixable is true when the rule or one of its patterns carries a replacement template
+func (r *Rule) IsFixable() bool {
+
+	if r.Replace != "" {
+		return true
+	}
+
+	for i := range r.Patterns {
+		if r.Patterns[i].Replace != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Fix applies the replacement template (the pattern's or else the rule's) to every match of the compiled regex pattern
+//in the target. Templates follow regexp.Expand, I.E. $1 or ${name} for capture groups.
+func (p *Pattern) Fix(rule *Rule, target string) (string, bool) {
+
+	replace := p.Replace
+	if replace == "" {
+		replace = rule.Replace
+	}
+
+	if replace == "" || p.compiledRegex == nil || !util.MatchRegex(p.compiledRegex, target) {
+		return target, false
+	}
+
+	fixed := p.compiledRegex.ReplaceAllString(target, replace)
+
+	return fixed, fixed != target
+}
+
+//FixFile applies the replacements of the (compiled) rules to the parts of the contents their findings matched. Line
+//rules only fix the lines they were found on, multiline and contents rules fix the whole contents. Rules are applied in
+//name order so a file is always fixed the same way. Findings of rules without a replacement are ignored.
+func FixFile(path string, contents string, findings []Finding, rules map[string]*Rule) *FileFix {
+
+	fix := &FileFix{Path: path, Original: contents}
+
+	byRule := make(map[string][]Finding)
+	for _, finding := range findings {
+		if rule, found := rules[finding.Rule]; found && rule.IsFixable() {
+			byRule[finding.Rule] = append(byRule[finding.Rule], finding)
+		}
+	}
+
+	names := make([]string, 0, len(byRule))
+	for name := range byRule {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := strings.SplitAfter(contents, "\n")
+	for _, name := range names {
+		rule := rules[name]
+		for i := range rule.Patterns {
+			pattern := &rule.Patterns[i]
+
+			if rule.Target != LINE_TARGET {
+				joined := strings.Join(lines, "")
+				if fixed, changed := pattern.Fix(rule, joined); changed {
+					lines = strings.SplitAfter(fixed, "\n")
+					fix.Fixes++
+				}
+				continue
+			}
+
+			for _, finding := range byRule[name] {
+				if finding.Pattern != pattern.Value || finding.Line < 1 || finding.Line > len(lines) {
+					continue
+				}
+				line := lines[finding.Line-1]
+				eol := line[len(strings.TrimRight(line, "\r\n")):]
+				if fixed, changed := pattern.Fix(rule, strings.TrimSuffix(line, eol)); changed {
+					lines[finding.Line-1] = fixed + eol
+					fix.Fixes++
+				}
+			}
+		}
+	}
+
+	fix.Fixed = strings.Join(lines, "")
+
+	return fix
+}
+
+//UnifiedDiff returns the fix as a unified diff (git style a/ and b/ paths) or an empty string when nothing changed
+func (f *FileFix) UnifiedDiff() (string, error) {
+
+	if f.Fixed == f.Original {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(f.Original),
+		B:        difflib.SplitLines(f.Fixed),
+		FromFile: "a/" + f.Path,
+		ToFile:   "b/" + f.Path,
+		Context:  3,
+	})
+}
@@ -0,0 +1,169 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//Domain of the applications that were given none
+const UNASSIGNED_DOMAIN = "unassigned"
+
+//DomainMapping assigns applications to business domains. I.E.
+//
+//  domains:
+//    payments: [billing-api, ledger]
+//    logistics: [tracking]
+type DomainMapping struct {
+	Domains map[string][]string `json:"domains" yaml:"domains"`
+}
+
+//DomainRollup is the aggregate of the applications of a business domain for a single run. Domains are ranked by
+//their score, the best scoring domain first.
+type DomainRollup struct {
+	Rank          int     `json:"rank"`
+	Domain        string  `json:"domain"`
+	Applications  int     `json:"applications"`
+	Score         float64 `json:"score"`
+	WeightedScore float64 `json:"slocWeightedScore"`
+	MinScore      float64 `json:"minScore"`
+	MaxScore      float64 `json:"maxScore"`
+	LowestApp     string  `json:"lowestScoringApp"`
+	Findings      int     `json:"findings"`
+	Effort        int     `json:"effort"`
+	SlocCnt       int     `json:"slocCnt"`
+	Tier          string  `json:"tier,omitempty"`
+}
+
+//LoadDomainMapping reads a (yaml|json) domain mapping file
+func LoadDomainMapping(file string) (*DomainMapping, error) {
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	//yaml is a superset of json
+	mapping := &DomainMapping{}
+	if err = yaml.UnmarshalStrict(data, mapping); err != nil {
+		return nil, fmt.Errorf("domain mapping file [%s] is invalid! Details: %v", file, err)
+	}
+
+	return mapping, mapping.Validate()
+}
+
+//Validate checks that every domain is named and that no application is mapped to more than one domain
+func (m *DomainMapping) Validate() error {
+
+	if len(m.Domains) == 0 {
+		return fmt.Errorf("domain mapping has no domains")
+	}
+
+	domainOf := make(map[string]string)
+	for _, domain := range m.DomainNames() {
+		if strings.TrimSpace(domain) == "" {
+			return fmt.Errorf("domain mapping has a domain without a name")
+		}
+		for _, app := range m.Domains[domain] {
+			if other, found := domainOf[app]; found && other != domain {
+				return fmt.Errorf("application [%s] is mapped to domains [%s] and [%s]", app, other, domain)
+			}
+			domainOf[app] = domain
+		}
+	}
+
+	return nil
+}
+
+//DomainOf returns the domain the application is mapped to
+func (m *DomainMapping) DomainOf(app string) (string, bool) {
+	for domain, apps := range m.Domains {
+		for _, name := range apps {
+			if name == app {
+				return domain, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (m *DomainMapping) DomainNames() []string {
+	names := make([]string, 0, len(m.Domains))
+	for domain := range m.Domains {
+		names = append(names, domain)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//RollupDomains aggregates the applications of a run by their business domain. Scores are averaged across applications,
+//as a plain and a sloc weighted average, while findings, effort and sloc are summed. Domains with the same score share
+//a rank.
+func RollupDomains(apps []Application) []DomainRollup {
+
+	byDomain := make(map[string]*DomainRollup)
+	totalScores := make(map[string]float64)
+	weightedScores := make(map[string]float64)
+
+	for _, app := range apps {
+		domain := strings.TrimSpace(app.BusinessDomain)
+		if domain == "" {
+			domain = UNASSIGNED_DOMAIN
+		}
+
+		rollup, found := byDomain[domain]
+		if !found {
+			rollup = &DomainRollup{Domain: domain, MinScore: app.Score, MaxScore: app.Score, LowestApp: app.Name}
+			byDomain[domain] = rollup
+		}
+
+		rollup.Applications++
+		rollup.Findings += app.Findings
+		rollup.Effort += app.RawScore
+		rollup.SlocCnt += app.SlocCnt
+		totalScores[domain] += app.Score
+		weightedScores[domain] += app.Score * float64(app.SlocCnt)
+
+		if app.Score < rollup.MinScore {
+			rollup.MinScore = app.Score
+			rollup.LowestApp = app.Name
+		}
+		if app.Score > rollup.MaxScore {
+			rollup.MaxScore = app.Score
+		}
+	}
+
+	rollups := make([]DomainRollup, 0, len(byDomain))
+	for domain, rollup := range byDomain {
+		rollup.Score = totalScores[domain] / float64(rollup.Applications)
+		rollup.WeightedScore = rollup.Score
+		if rollup.SlocCnt > 0 {
+			rollup.WeightedScore = weightedScores[domain] / float64(rollup.SlocCnt)
+		}
+		rollups = append(rollups, *rollup)
+	}
+
+	sort.Slice(rollups, func(i, j int) bool {
+		if rollups[i].Score != rollups[j].Score {
+			return rollups[i].Score > rollups[j].Score
+		}
+		return rollups[i].Domain < rollups[j].Domain
+	})
+
+	for i := range rollups {
+		rollups[i].Rank = i + 1
+		if i > 0 && rollups[i].Score == rollups[i-1].Score {
+			rollups[i].Rank = rollups[i-1].Rank
+		}
+	}
+
+	return rollups
+}
@@ -0,0 +1,145 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Category of the calibration of the default factor, from all the outcomes
+const DEFAULT_FACTOR = "default"
+
+//MigrationOutcome is the actual effort, in person-days, the migration of an application of a run took. Outcomes feed
+//back into the estimation model (see Calibrate).
+type MigrationOutcome struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"recordedAt" yaml:"recordedAt"`
+	RunID       uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application string    `gorm:"index;not null" json:"application" yaml:"application"`
+	ActualDays  float64   `json:"actualDays" yaml:"actualDays"`
+	Note        string    `gorm:"type:text" json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+//CalibrationSample is a migrated application: its actual person-days and the effort of its findings by category
+type CalibrationSample struct {
+	Application string
+	ActualDays  float64
+	Effort      map[string]int
+}
+
+//CategoryCalibration is how far the estimates of a category were off. A ratio above 1 means the category took longer
+//than estimated.
+type CategoryCalibration struct {
+	Category      string  `json:"category"`
+	Applications  int     `json:"applications"`
+	Effort        int     `json:"effort"`
+	EstimatedDays float64 `json:"estimatedDays"`
+	ActualDays    float64 `json:"actualDays"`
+	Ratio         float64 `json:"ratio"`
+}
+
+func (o *MigrationOutcome) Validate() error {
+	if o.Application == "" {
+		return fmt.Errorf("migration outcome needs an application")
+	}
+	if o.ActualDays <= 0 || math.IsNaN(o.ActualDays) || math.IsInf(o.ActualDays, 0) {
+		return fmt.Errorf("actual person-days [%v] of application [%s] must be positive", o.ActualDays, o.Application)
+	}
+	return nil
+}
+
+//Calibrate scales the productivity factors of the model by how far its estimates of the migrated applications were
+//off. Each application's actual days are shared among its categories in proportion to their estimates (the middle of
+//their range), so a category taking longer than estimated across applications gets a higher factor. The default factor
+//is scaled by the ratio of all actual to all estimated days, so categories no application had are calibrated too. The
+//last calibration is that overall one.
+func Calibrate(base *EstimationModel, samples []CalibrationSample) (*EstimationModel, []CategoryCalibration, error) {
+
+	byCategory := make(map[string]*CategoryCalibration)
+	overall := &CategoryCalibration{Category: DEFAULT_FACTOR}
+
+	for _, sample := range samples {
+		estimated := make(map[string]float64)
+		total := 0.0
+		for category, effort := range sample.Effort {
+			if effort <= 0 {
+				continue
+			}
+			factor := base.Factor(category)
+			estimated[category] = float64(effort) * (factor.MinDays + factor.MaxDays) / 2
+			total += estimated[category]
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		overall.Applications++
+		overall.EstimatedDays += total
+		overall.ActualDays += sample.ActualDays
+
+		for category, days := range estimated {
+			name := base.categoryName(category)
+			calibration, found := byCategory[name]
+			if !found {
+				calibration = &CategoryCalibration{Category: name}
+				byCategory[name] = calibration
+			}
+			calibration.Applications++
+			calibration.Effort += sample.Effort[category]
+			calibration.EstimatedDays += days
+			calibration.ActualDays += sample.ActualDays * days / total
+			overall.Effort += sample.Effort[category]
+		}
+	}
+
+	if overall.Applications == 0 {
+		return nil, nil, fmt.Errorf("no migrated application has estimated effort to calibrate with")
+	}
+
+	calibrated := &EstimationModel{Name: base.Name + "-calibrated", Currency: base.Currency, DayRate: base.DayRate,
+		Categories: make(map[string]ProductivityFactor)}
+
+	overall.Ratio = overall.ActualDays / overall.EstimatedDays
+	calibrated.Default = base.Default.scale(overall.Ratio)
+
+	for category, factor := range base.Categories {
+		calibrated.Categories[category] = factor.scale(overall.Ratio)
+	}
+
+	calibrations := make([]CategoryCalibration, 0, len(byCategory)+1)
+	for category, calibration := range byCategory {
+		calibration.Ratio = calibration.ActualDays / calibration.EstimatedDays
+		calibrated.Categories[category] = base.Factor(category).scale(calibration.Ratio)
+		calibrations = append(calibrations, *calibration)
+	}
+
+	sort.Slice(calibrations, func(i, j int) bool { return calibrations[i].Category < calibrations[j].Category })
+
+	return calibrated, append(calibrations, *overall), nil
+}
+
+//categoryName is the name the model gives the category, the category itself when the model has no factor for it
+func (m *EstimationModel) categoryName(category string) string {
+	for name := range m.Categories {
+		if strings.EqualFold(name, category) {
+			return name
+		}
+	}
+	return category
+}
+
+func (f ProductivityFactor) scale(ratio float64) ProductivityFactor {
+	return ProductivityFactor{MinDays: roundDays(f.MinDays * ratio), MaxDays: roundDays(f.MaxDays * ratio)}
+}
+
+func roundDays(days float64) float64 {
+	return math.Round(days*10000) / 10000
+}
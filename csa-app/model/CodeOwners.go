@@ -0,0 +1,149 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const UNASSIGNED_OWNER = "unassigned"
+
+//Locations checked (relative to an application's root) for a CODEOWNERS file, in github's order of precedence
+var CodeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+type codeOwnersEntry struct {
+	pattern string
+	owners  []string
+}
+
+//CodeOwners maps application paths to owners using the CODEOWNERS format: one `<pattern> <owner>...` per line
+//where the last matching pattern wins
+type CodeOwners struct {
+	entries []codeOwnersEntry
+}
+
+func ParseCodeOwners(in io.Reader) (*CodeOwners, error) {
+
+	owners := &CodeOwners{}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		owners.entries = append(owners.entries, codeOwnersEntry{pattern: fields[0], owners: fields[1:]})
+	}
+
+	return owners, scanner.Err()
+}
+
+func LoadCodeOwners(file string) (*CodeOwners, error) {
+
+	in, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	return ParseCodeOwners(in)
+}
+
+//FindCodeOwners loads the CODEOWNERS file of an application, returning nil when it has none
+func FindCodeOwners(appPath string) (*CodeOwners, error) {
+
+	for _, location := range CodeOwnersLocations {
+		file := filepath.Join(appPath, filepath.FromSlash(location))
+		if _, err := os.Stat(file); err == nil {
+			return LoadCodeOwners(file)
+		}
+	}
+
+	return nil, nil
+}
+
+//Owners returns the owners of a path relative to the application root. A matching entry without owners
+//explicitly leaves the path unowned.
+func (c *CodeOwners) Owners(relPath string) []string {
+
+	if c == nil {
+		return nil
+	}
+
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "/")
+	for i := len(c.entries) - 1; i >= 0; i-- {
+		if matchPathPattern(c.entries[i].pattern, relPath) {
+			return c.entries[i].owners
+		}
+	}
+
+	return nil
+}
+
+//matchPathPattern implements the commonly used subset of CODEOWNERS (gitignore) patterns: anchored (/x) and
+//unanchored patterns, directory patterns (x/), `*`/`?` globs and `**` segments. It is shared by rule path conditions.
+func matchPathPattern(pattern string, relPath string) bool {
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if pattern == "*" || pattern == "**" || pattern == "" {
+		return true
+	}
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	patternSegs := strings.Split(pattern, "/")
+	pathSegs := strings.Split(relPath, "/")
+	if dirOnly {
+		//a directory pattern must match a parent directory, never the file itself
+		pathSegs = pathSegs[:len(pathSegs)-1]
+	}
+
+	if anchored {
+		return matchSegments(patternSegs, pathSegs)
+	}
+
+	for start := range pathSegs {
+		if matchSegments(patternSegs, pathSegs[start:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//matchSegments reports whether the pattern matches a prefix of the path, so a matched directory owns everything beneath it
+func matchSegments(patternSegs []string, pathSegs []string) bool {
+
+	if len(patternSegs) == 0 {
+		return true
+	}
+
+	if patternSegs[0] == "**" {
+		for skip := 0; skip <= len(pathSegs); skip++ {
+			if matchSegments(patternSegs[1:], pathSegs[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(pathSegs) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(patternSegs[0], pathSegs[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(patternSegs[1:], pathSegs[1:])
+}
@@ -0,0 +1,141 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//Condition is the boolean expression of a composite rule. Operands are the names of other rules which are true
+//when that rule matched within the file/application the composite rule is evaluated for.
+//I.E. "persistence-xml AND NOT (datasource-jndi OR datasource-env)"
+type Condition interface {
+	Eval(matched func(rule string) bool) bool
+	Rules() []string
+	String() string
+}
+
+type ruleOperand string
+type notCondition struct{ operand Condition }
+type andCondition struct{ left, right Condition }
+type orCondition struct{ left, right Condition }
+
+func (r ruleOperand) Eval(matched func(rule string) bool) bool { return matched(string(r)) }
+func (r ruleOperand) Rules() []string                          { return []string{string(r)} }
+func (r ruleOperand) String() string                           { return string(r) }
+
+func (n notCondition) Eval(matched func(rule string) bool) bool { return !n.operand.Eval(matched) }
+func (n notCondition) Rules() []string                          { return n.operand.Rules() }
+func (n notCondition) String() string                           { return "NOT " + n.operand.String() }
+
+func (a andCondition) Eval(matched func(rule string) bool) bool {
+	return a.left.Eval(matched) && a.right.Eval(matched)
+}
+func (a andCondition) Rules() []string { return append(a.left.Rules(), a.right.Rules()...) }
+func (a andCondition) String() string  { return fmt.Sprintf("(%s AND %s)", a.left, a.right) }
+
+func (o orCondition) Eval(matched func(rule string) bool) bool {
+	return o.left.Eval(matched) || o.right.Eval(matched)
+}
+func (o orCondition) Rules() []string { return append(o.left.Rules(), o.right.Rules()...) }
+func (o orCondition) String() string  { return fmt.Sprintf("(%s OR %s)", o.left, o.right) }
+
+var conditionToken = regexp.MustCompile(`\(|\)|[^\s()]+`)
+
+type conditionParser struct {
+	tokens []string
+	pos    int
+}
+
+//ParseCondition parses a composite rule condition. NOT binds tighter than AND which binds tighter than OR.
+func ParseCondition(expression string) (Condition, error) {
+
+	parser := &conditionParser{tokens: conditionToken.FindAllString(expression, -1)}
+	if len(parser.tokens) == 0 {
+		return nil, fmt.Errorf("condition is empty")
+	}
+
+	condition, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if parser.pos < len(parser.tokens) {
+		return nil, fmt.Errorf("unexpected [%s] in condition [%s]", parser.tokens[parser.pos], expression)
+	}
+
+	return condition, nil
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionParser) isKeyword(keyword string) bool {
+	return strings.EqualFold(p.peek(), keyword)
+}
+
+func (p *conditionParser) parseOr() (Condition, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.isKeyword("OR") {
+		p.pos++
+		var right Condition
+		if right, err = p.parseAnd(); err == nil {
+			left = orCondition{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *conditionParser) parseAnd() (Condition, error) {
+	left, err := p.parseNot()
+	for err == nil && p.isKeyword("AND") {
+		p.pos++
+		var right Condition
+		if right, err = p.parseNot(); err == nil {
+			left = andCondition{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *conditionParser) parseNot() (Condition, error) {
+
+	token := p.peek()
+
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("condition ends unexpectedly")
+	case strings.EqualFold(token, "NOT"):
+		p.pos++
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{operand}, nil
+	case token == "(":
+		p.pos++
+		condition, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing [)] in condition")
+		}
+		p.pos++
+		return condition, nil
+	case token == ")" || strings.EqualFold(token, "AND") || strings.EqualFold(token, "OR"):
+		return nil, fmt.Errorf("expected a rule name but found [%s]", token)
+	}
+
+	p.pos++
+	return ruleOperand(token), nil
+}
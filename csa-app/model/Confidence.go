@@ -0,0 +1,65 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Confidence communicates how certain a match is a real finding. I.E. definite api usage is high, a match in a comment low.
+const CONFIDENCE_LOW = "low"
+const CONFIDENCE_MEDIUM = "medium"
+const CONFIDENCE_HIGH = "high"
+
+//Confidences in ascending order of certainty
+var Confidences = []string{CONFIDENCE_LOW, CONFIDENCE_MEDIUM, CONFIDENCE_HIGH}
+
+//Line prefixes of single line comments and comment block continuations across the languages rules are written for
+var commentPrefixes = []string{"//", "/*", "*", "#", "<!--", "<%--", "--", "'", "rem "}
+
+//ConfidenceRank orders confidences by certainty. Unset confidences are high, unknown ones rank below low.
+func ConfidenceRank(confidence string) int {
+	if confidence == "" {
+		confidence = CONFIDENCE_HIGH
+	}
+	for i, c := range Confidences {
+		if strings.EqualFold(c, confidence) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+//ValidateConfidence accepts an unset confidence or one of the known confidences
+func ValidateConfidence(confidence string) error {
+	if confidence != "" && ConfidenceRank(confidence) == 0 {
+		return fmt.Errorf("Confidence must be (%s) but was: %s", strings.Join(Confidences, "|"), confidence)
+	}
+	return nil
+}
+
+//IsCommentLine is true when the line is (part of) a comment
+func IsCommentLine(line string) bool {
+	line = strings.ToLower(strings.TrimSpace(line))
+	for _, prefix := range commentPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+//FindingConfidence is the confidence of a match, the declared one (high when unset) unless the matched line is a comment
+func FindingConfidence(declared string, line string, lineTarget bool) string {
+	if lineTarget && IsCommentLine(line) {
+		return CONFIDENCE_LOW
+	}
+	if declared == "" {
+		return CONFIDENCE_HIGH
+	}
+	return strings.ToLower(declared)
+}
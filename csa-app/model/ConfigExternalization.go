@@ -0,0 +1,157 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//Findings of hard-coded, environment specific values that must be externalized are tagged externalize-config
+const CONFIG_EXTERNALIZE_TAG = "externalize-config"
+
+//Kind of the findings of externalize-config rules that aren't one of ConfigKinds
+const CONFIG_OTHER_KIND = "other"
+
+//Credentials are never reported in clear
+const CONFIG_MASKED_VALUE = "****"
+
+//ConfigKind is a kind of hard-coded value, found by the rules flagging it. Value picks the value out of the line of
+//a finding, its first group if it has one.
+type ConfigKind struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Rules       []string       `json:"rules"`
+	Masked      bool           `json:"masked"`
+	Value       *regexp.Regexp `json:"-"`
+}
+
+//ConfigItem is a hard-coded value of an application, on the given line of its file, and the environment variable that
+//could hold it (from its key, when qualified)
+type ConfigItem struct {
+	Kind     string `json:"kind"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Key      string `json:"key,omitempty"`
+	Value    string `json:"value"`
+	Variable string `json:"variable,omitempty"`
+	Rule     string `json:"rule"`
+	Effort   int    `json:"effort"`
+}
+
+//ConfigExternalization is the configuration an application must externalize before it is deployed to the cloud
+type ConfigExternalization struct {
+	Application string         `json:"application"`
+	Items       []ConfigItem   `json:"items"`
+	Kinds       map[string]int `json:"kinds"`
+	Effort      int            `json:"effort"`
+}
+
+var ConfigKinds = []ConfigKind{
+	{Name: "url", Description: "Urls of services (databases, brokers, apis)", Rules: []string{"config-hardcoded-url", "config-hardcoded-url-xml", "hardcode-uri"},
+		Value: regexp.MustCompile(`(?:https?|ftps?|sftp|file|ldaps?|amqps?|wss?|tcp|t3s?|iiop|mongodb(?:\+srv)?|rediss?|nats)://[^\s"'<>,;)]*|jdbc:[^\s"'<>;]+`)},
+	{Name: "host", Description: "Hosts and ip addresses of services", Rules: []string{"config-hardcoded-host", "java-hardIP"},
+		Value: regexp.MustCompile(`[=:>"']\s*["']?([A-Za-z][\w\-]*(?:\.[\w\-]+)+(?::[0-9]+)?|localhost(?::[0-9]+)?|[0-9]{1,3}(?:\.[0-9]{1,3}){3}(?::[0-9]+)?|[\w\-]+:[0-9]+)`)},
+	{Name: "path", Description: "Paths of the server's filesystem", Rules: []string{"config-hardcoded-path", "config-hardcoded-path-code", "script-hardcoded-path"},
+		Value: regexp.MustCompile(`(?:file:(?://)?)?(?:/(?:home|opt|var|etc|usr|srv|mnt|data|app|apps|log|logs|tmp|export|nfs|share|shared|u0[0-9])/|[A-Za-z]:(?:\\\\|\\|/)|\\\\)[^\s"',;<>]*`)},
+	{Name: "credential", Description: "Passwords, secrets, tokens and keys", Rules: []string{"config-credential", "config-credential-code"}, Masked: true,
+		Value: regexp.MustCompile(`(?i:password|passwd|pwd|secret|key|token|credentials?)["']?\s*[=:]\s*["']?([^\s"',;]+)`)},
+}
+
+var configKeyRegex = regexp.MustCompile(`([\w.\-\[\]]+)["']?\s*[=:>]\s*["']?$`)
+var configVariableRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+//ConfigItemOf is the item of the finding of a rule of the kind (nil for CONFIG_OTHER_KIND), its key and value picked
+//out of the finding's line
+func ConfigItemOf(finding *Finding, kind *ConfigKind) ConfigItem {
+
+	item := ConfigItem{Kind: CONFIG_OTHER_KIND, File: finding.Fqn, Line: finding.Line, Value: strings.TrimSpace(finding.Value),
+		Rule: finding.Rule, Effort: finding.Effort}
+	if kind == nil {
+		return item
+	}
+	item.Kind = kind.Name
+
+	if match := kind.Value.FindStringSubmatchIndex(finding.Value); match != nil {
+		start, end := match[0], match[1]
+		if len(match) > 2 && match[2] >= 0 {
+			start, end = match[2], match[3]
+		}
+		item.Value = finding.Value[start:end]
+		if key := configKeyRegex.FindStringSubmatch(finding.Value[:start]); key != nil {
+			item.Key = key[1]
+			//Keys like url or value, of yaml maps and xml elements, don't name the setting
+			if strings.ContainsAny(item.Key, "._-") {
+				item.Variable = ConfigVariable(item.Key)
+			}
+		}
+	}
+
+	if kind.Masked {
+		item.Value = CONFIG_MASKED_VALUE
+	}
+
+	return item
+}
+
+//ConfigVariable is the environment variable (spring's relaxed binding of the key), I.E. SPRING_DATASOURCE_URL for
+//spring.datasource.url
+func ConfigVariable(key string) string {
+	return strings.Trim(strings.ToUpper(configVariableRegex.ReplaceAllString(key, "_")), "_")
+}
+
+//GroupConfigItems groups the findings of the externalize-config rules by application, an item per line and kind of
+//value, sorted by file and line. The applications are sorted by name.
+func GroupConfigItems(findings []Finding, kinds []ConfigKind) []ConfigExternalization {
+
+	kindByRule := make(map[string]*ConfigKind)
+	for i := range kinds {
+		for _, rule := range kinds[i].Rules {
+			kindByRule[rule] = &kinds[i]
+		}
+	}
+
+	apps := make(map[string]*ConfigExternalization)
+	seen := make(map[string]bool)
+	for i := range findings {
+		finding := &findings[i]
+
+		item := ConfigItemOf(finding, kindByRule[finding.Rule])
+
+		//Several rules can flag the same value
+		key := fmt.Sprintf("%s|%s|%d|%s", finding.Application, item.File, item.Line, item.Kind)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		app, found := apps[finding.Application]
+		if !found {
+			app = &ConfigExternalization{Application: finding.Application, Items: []ConfigItem{}, Kinds: make(map[string]int)}
+			apps[finding.Application] = app
+		}
+		app.Items = append(app.Items, item)
+		app.Kinds[item.Kind]++
+		app.Effort += item.Effort
+	}
+
+	grouped := []ConfigExternalization{}
+	for _, app := range apps {
+		sort.SliceStable(app.Items, func(i, j int) bool {
+			if app.Items[i].File != app.Items[j].File {
+				return app.Items[i].File < app.Items[j].File
+			}
+			return app.Items[i].Line < app.Items[j].Line
+		})
+		grouped = append(grouped, *app)
+	}
+
+	sort.Slice(grouped, func(i, j int) bool { return grouped[i].Application < grouped[j].Application })
+
+	return grouped
+}
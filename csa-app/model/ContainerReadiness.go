@@ -0,0 +1,108 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+//Findings of a concern at which it costs half its weight. Each further finding costs less, the penalty approaching
+//the weight.
+const CONTAINER_HALF_PENALTY_FINDINGS = 3.0
+
+//ContainerConcern is something keeping an application from running in a container, found by the tags of its findings.
+//The weights of the concerns add up to the maximum score. Blockers keep it from running in a container at all until
+//they are dealt with.
+type ContainerConcern struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Weight      float64  `json:"weight"`
+	Blocker     bool     `json:"blocker"`
+}
+
+//ConcernResult is what a concern costs an application
+type ConcernResult struct {
+	Concern  string   `json:"concern"`
+	Findings int      `json:"findings"`
+	Effort   int      `json:"effort"`
+	Penalty  float64  `json:"penalty"`
+	Blocker  bool     `json:"blocker"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+//ContainerReadiness is how container ready an application is, a 0-10 score apart from its cloud score. Containerized
+//applications already come with a Dockerfile. Blocked is true when it has findings of a blocker.
+type ContainerReadiness struct {
+	Application   string          `json:"application"`
+	Score         float64         `json:"score"`
+	Containerized bool            `json:"containerized"`
+	Blocked       bool            `json:"blocked"`
+	Concerns      []ConcernResult `json:"concerns"`
+}
+
+var containerizedTags = []string{"docker", "dockerfile"}
+
+var ContainerConcerns = []ContainerConcern{
+	{Name: "filesystem", Description: "Writes to the local filesystem, which is lost with the container",
+		Tags: []string{"file", "filesystem", "writefile", "appendfile", "createnewfile", "fopen", "fputs", "fputcsv", "log2file", "fileappender"}, Weight: 3},
+	{Name: "ports", Description: "Hard-coded ports and addresses",
+		Tags: []string{"port", "port-usage", "hard-ip", "hardcoded-uri"}, Weight: 1},
+	{Name: "os", Description: "Operating system specific calls",
+		Tags: []string{"os", "process-launch", "windows-registry", "windows-service", "windows-auth", "windows-principal", "windows-desktop", "windows-forms", "windows-wpf", "eventlog", "sudo"}, Weight: 2},
+	{Name: "native", Description: "Native libraries, JNI and JNA",
+		Tags: []string{"jni", "native", "loadlibrary", "dl", "native-library", "jna"}, Weight: 2, Blocker: true},
+	{Name: "startup", Description: "Hints of a slow startup, I.E. a full profile application server",
+		Tags: []string{"ejb", "mdb", "full-profile", "app-server", "ear", "weblogic", "websphere", "corba"}, Weight: 1},
+	{Name: "image", Description: "Dockerfile anti-patterns: root user, unpinned or full OS base images, baked-in secrets, no HEALTHCHECK",
+		Tags: []string{"dockerfile-root-user", "dockerfile-latest-tag", "dockerfile-secret", "dockerfile-base-image", "dockerfile-healthcheck"}, Weight: 1},
+}
+
+//EvaluateContainerReadiness scores how container ready an application is from the totals of its findings by tag
+func EvaluateContainerReadiness(app string, tagTotals TagTotals, concerns []ContainerConcern) ContainerReadiness {
+
+	//Rule tags aren't consistently cased
+	totals := make(TagTotals)
+	for tag, total := range tagTotals {
+		tag = strings.ToLower(tag)
+		totals[tag] = TagTotal{Findings: totals[tag].Findings + total.Findings, Effort: totals[tag].Effort + total.Effort}
+	}
+
+	readiness := ContainerReadiness{Application: app, Score: DEFAULT_MAX_SCORE}
+
+	for _, tag := range containerizedTags {
+		if totals[tag].Findings > 0 {
+			readiness.Containerized = true
+		}
+	}
+
+	for _, concern := range concerns {
+		result := ConcernResult{Concern: concern.Name, Blocker: concern.Blocker}
+		for _, tag := range concern.Tags {
+			if total, found := totals[tag]; found && total.Findings > 0 {
+				result.Findings += total.Findings
+				result.Effort += total.Effort
+				result.Tags = append(result.Tags, tag)
+			}
+		}
+		sort.Strings(result.Tags)
+
+		if result.Findings > 0 {
+			result.Penalty = concern.Weight * float64(result.Findings) / (float64(result.Findings) + CONTAINER_HALF_PENALTY_FINDINGS)
+			readiness.Score -= result.Penalty
+			readiness.Blocked = readiness.Blocked || concern.Blocker
+		}
+
+		readiness.Concerns = append(readiness.Concerns, result)
+	}
+
+	if readiness.Score < DEFAULT_MIN_SCORE {
+		readiness.Score = DEFAULT_MIN_SCORE
+	}
+
+	return readiness
+}
@@ -0,0 +1,128 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"csa-app/util"
+)
+
+//Extensions of the files holding COBOL programs
+var cobolProgramExtensions = []string{".cbl", ".cob"}
+
+//Extensions of copybook members by preference. Members exported from a PDS often have none.
+var copybookExtensions = []string{".cpy", "", ".copy", ".cbl", ".cob"}
+
+var copyStatement = regexp.MustCompile(`(?i)(?:^|\s)COPY\s+["']?([A-Z0-9@#$][\w@#$-]*)["']?`)
+var sqlInclude = regexp.MustCompile(`(?i)\bEXEC\s+SQL\s+INCLUDE\s+([A-Z0-9@#$][\w@#$-]*)`)
+
+//Copybooks the DB2 precompiler provides
+var sqlCopybooks = map[string]bool{"SQLCA": true, "SQLDA": true}
+
+//CopybookReference is a copybook a COBOL program (or copybook) copies, File being the file of the application it
+//resolves to, relative to the application root. Unresolved copybooks have none.
+type CopybookReference struct {
+	Program  string `json:"program"`
+	Copybook string `json:"copybook"`
+	File     string `json:"file,omitempty"`
+}
+
+//ResolveCopybooks resolves the copybooks the COBOL programs of the application copy (COPY, EXEC SQL INCLUDE), and
+//those the copybooks copy in turn, to the files of the application named like them. Copybooks without a COBOL
+//extension get the .cpy one, so the COBOL rules are applied to them.
+func ResolveCopybooks(app *Application) []CopybookReference {
+
+	members := make(map[string]*util.FileInfo)
+	preference := make(map[string]int)
+	var queue []*util.FileInfo
+	for _, file := range app.Files {
+		ext := strings.ToLower(filepath.Ext(file.Name))
+		member := strings.ToUpper(strings.TrimSuffix(file.Name, filepath.Ext(file.Name)))
+		for i, copybookExt := range copybookExtensions {
+			if ext != copybookExt {
+				continue
+			}
+			if current, found := preference[member]; !found || i < current {
+				members[member] = file
+				preference[member] = i
+			}
+		}
+		if file.ThirdParty == "" && isCobolProgram(ext) {
+			queue = append(queue, file)
+		}
+	}
+
+	var references []CopybookReference
+	contents := make(map[string]string)
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+		if visited[file.FQN] {
+			continue
+		}
+		visited[file.FQN] = true
+
+		program := relativeEvidence(app, file)
+		for _, copybook := range copiedCopybooks(readDetectionFile(file.FQN, contents)) {
+			reference := CopybookReference{Program: program, Copybook: copybook}
+			if member, found := members[copybook]; found {
+				reference.File = relativeEvidence(app, member)
+				if ext := strings.ToLower(filepath.Ext(member.Name)); !isCobolProgram(ext) && ext != ".cpy" {
+					member.Ext = ".cpy"
+				}
+				queue = append(queue, member)
+			}
+			references = append(references, reference)
+		}
+	}
+
+	sort.SliceStable(references, func(i, j int) bool {
+		if references[i].Program != references[j].Program {
+			return references[i].Program < references[j].Program
+		}
+		return references[i].Copybook < references[j].Copybook
+	})
+
+	return references
+}
+
+//copiedCopybooks returns the (upper cased) copybooks the COBOL source copies, once each. Comment lines are skipped.
+func copiedCopybooks(content string) []string {
+
+	var copybooks []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(util.CobolSourceArea(line))
+		if strings.HasPrefix(line, "*") || strings.HasPrefix(line, "/") {
+			continue
+		}
+		for _, statement := range []*regexp.Regexp{copyStatement, sqlInclude} {
+			for _, match := range statement.FindAllStringSubmatch(line, -1) {
+				copybook := strings.ToUpper(match[1])
+				if !seen[copybook] && !sqlCopybooks[copybook] {
+					seen[copybook] = true
+					copybooks = append(copybooks, copybook)
+				}
+			}
+		}
+	}
+
+	return copybooks
+}
+
+func isCobolProgram(ext string) bool {
+	for _, programExt := range cobolProgramExtensions {
+		if ext == programExt {
+			return true
+		}
+	}
+	return false
+}
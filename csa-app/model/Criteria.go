@@ -5,6 +5,38 @@
 
 package model
 
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const CRITERION_TAG string = "tag"
+const CRITERION_CATEGORY string = "category"
+const CRITERION_RULE string = "rule"
+const CRITERION_PATTERN string = "pattern"
+const CRITERION_EFFORT string = "effort"
+const CRITERION_READINESS string = "readiness"
+const CRITERION_LEVEL string = "level"
+const CRITERION_CRITICALITY string = "criticality"
+const CRITERION_SEVERITY string = "severity"
+const CRITERION_APPLICATION string = "application"
+const CRITERION_FILENAME string = "filename"
+const CRITERION_EXT string = "ext"
+const CRITERION_MODULE string = "module"
+
+const EXACT_MATCH string = "="
+const NOT_EQUAL_MATCH string = "!="
+const LIKE_MATCH string = "~"
+const GREATER_MATCH string = ">"
+const GREATER_EQUAL_MATCH string = ">="
+const LESS_MATCH string = "<"
+const LESS_EQUAL_MATCH string = "<="
+
+var criterionExpr = regexp.MustCompile(`^\s*([A-Za-z-]+)\s*(>=|<=|!=|=|>|<|~)\s*(.+?)\s*$`)
+var andExpr = regexp.MustCompile(`(?i)\s+AND\s+`)
+
 //Criterion and Groups at this level are always logically ANDed!
 type Criteria struct {
 	criterion       []Criterion
@@ -13,7 +45,7 @@ type Criteria struct {
 
 //An individual criterion
 type Criterion struct {
-	key       string //Allowed values: Tag, Category, RuleName, Effort, RunID, Level, Criticality, Application
+	key       string //Allowed values: Tag, Category, RuleName, Effort, RunID, Level, Criticality, Severity, Application
 	value     string
 	matchType string //Exact, CI-Exact, Like
 }
@@ -23,3 +55,88 @@ type CriterionGroup struct {
 	criterion []Criterion
 	operation string //And or Or
 }
+
+//ParseCriteria builds criteria from a query such as "tag=filesystem AND effort>3". Terms are always ANDed!
+func ParseCriteria(query string) (*Criteria, error) {
+
+	criteria := &Criteria{}
+
+	if strings.TrimSpace(query) == "" {
+		return criteria, nil
+	}
+
+	for _, term := range andExpr.Split(strings.TrimSpace(query), -1) {
+		parts := criterionExpr.FindStringSubmatch(term)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid query term [%s]! Expected <field><op><value> where op is one of (%s|%s|%s|%s|%s|%s|%s)",
+				term, EXACT_MATCH, NOT_EQUAL_MATCH, LIKE_MATCH, GREATER_MATCH, GREATER_EQUAL_MATCH, LESS_MATCH, LESS_EQUAL_MATCH)
+		}
+
+		err := criteria.Add(parts[1], parts[2], strings.Trim(parts[3], "\"'"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return criteria, nil
+}
+
+func (c *Criteria) Add(key string, matchType string, value string) error {
+
+	key = strings.ToLower(key)
+
+	if !IsCriterionKey(key) {
+		return fmt.Errorf("unknown query field [%s]! Must be one of (%s)", key, strings.Join(CriterionKeys(), "|"))
+	}
+
+	if matchType != EXACT_MATCH && matchType != NOT_EQUAL_MATCH && matchType != LIKE_MATCH {
+		if !criterionIsNumeric(key) {
+			return fmt.Errorf("operator [%s] is only supported for numeric fields (%s|%s)", matchType, CRITERION_EFFORT, CRITERION_READINESS)
+		}
+	}
+
+	if criterionIsNumeric(key) {
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("value [%s] for field [%s] must be an integer", value, key)
+		}
+	}
+
+	c.criterion = append(c.criterion, Criterion{key: key, value: value, matchType: matchType})
+
+	return nil
+}
+
+func (c *Criteria) Criterion() []Criterion {
+	return c.criterion
+}
+
+func (c Criterion) Key() string {
+	return c.key
+}
+
+func (c Criterion) Value() string {
+	return c.value
+}
+
+func (c Criterion) MatchType() string {
+	return c.matchType
+}
+
+func CriterionKeys() []string {
+	return []string{CRITERION_TAG, CRITERION_CATEGORY, CRITERION_RULE, CRITERION_PATTERN, CRITERION_EFFORT, CRITERION_READINESS,
+		CRITERION_LEVEL, CRITERION_CRITICALITY, CRITERION_SEVERITY, CRITERION_APPLICATION, CRITERION_FILENAME, CRITERION_EXT,
+		CRITERION_MODULE}
+}
+
+func IsCriterionKey(key string) bool {
+	for _, k := range CriterionKeys() {
+		if k == strings.ToLower(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func criterionIsNumeric(key string) bool {
+	return key == CRITERION_EFFORT || key == CRITERION_READINESS
+}
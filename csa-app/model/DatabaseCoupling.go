@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+//How tightly an application is coupled to its database
+const DB_COUPLING_NONE = "none"
+const DB_COUPLING_LOW = "low"
+const DB_COUPLING_MEDIUM = "medium"
+const DB_COUPLING_HIGH = "high"
+
+//Lines of database code (SQL, PL/SQL) from which the business logic in the database tier alone makes the coupling
+//medium or high
+const DB_COUPLING_MEDIUM_CODE_LINES = 1000
+const DB_COUPLING_HIGH_CODE_LINES = 10000
+
+//SLOC languages of the database tier
+var databaseLanguages = []string{"SQL", "PLSQL"}
+
+//DatabaseConstruct is a database construct (stored logic, database links, file access...) found by the tags of the
+//findings of the database code. Blockers keep the database from moving to a managed database as is.
+type DatabaseConstruct struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Blocker     bool     `json:"blocker"`
+}
+
+//DatabaseConstructResult is a construct found in an application
+type DatabaseConstructResult struct {
+	Construct string   `json:"construct"`
+	Findings  int      `json:"findings"`
+	Effort    int      `json:"effort"`
+	Blocker   bool     `json:"blocker"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+//DatabaseCoupling is how tightly an application is coupled to its database: the volume of the code of its database
+//tier (CodeLines, Files) and the constructs found in it. Routines counts the procedures, functions, packages and
+//triggers it creates.
+type DatabaseCoupling struct {
+	Application string                    `json:"application"`
+	Coupling    string                    `json:"coupling"`
+	Files       int                       `json:"files"`
+	CodeLines   int                       `json:"codeLines"`
+	Routines    int                       `json:"routines"`
+	Findings    int                       `json:"findings"`
+	Effort      int                       `json:"effort"`
+	Constructs  []DatabaseConstructResult `json:"constructs"`
+}
+
+var DatabaseConstructs = []DatabaseConstruct{
+	{Name: "stored-logic", Description: "Stored procedures, functions, packages and triggers",
+		Tags: []string{"stored-procedure", "plsql-package", "trigger"}},
+	{Name: "vendor-sql", Description: "Vendor specific SQL (Oracle, T-SQL)",
+		Tags: []string{"oracle-sql", "tsql"}},
+	{Name: "db-links", Description: "Database links and linked servers",
+		Tags: []string{"db-link", "linked-server"}, Blocker: true},
+	{Name: "file-access", Description: "Files of the database server (UTL_FILE, BULK INSERT)",
+		Tags: []string{"utl-file", "bulk-insert", "db-file"}, Blocker: true},
+	{Name: "os-commands", Description: "Operating system commands and hosted code (xp_cmdshell, CLR, Java)",
+		Tags: []string{"xp-cmdshell", "os-command", "clr", "java-stored-procedure"}, Blocker: true},
+	{Name: "network", Description: "Network calls (UTL_HTTP, UTL_SMTP, Database Mail)",
+		Tags: []string{"db-network"}, Blocker: true},
+	{Name: "jobs", Description: "Jobs scheduled in the database (DBMS_SCHEDULER, SQL Server Agent)",
+		Tags: []string{"db-scheduler"}},
+}
+
+//EvaluateDatabaseCoupling rates the coupling of the application to its database from the SLOC of its database code
+//and the totals of its findings by tag. Blockers make it high, as does a large database tier; stored logic, vendor
+//SQL or a sizeable database tier make it medium.
+func EvaluateDatabaseCoupling(app string, slocs []RunSloc, tagTotals TagTotals, constructs []DatabaseConstruct) DatabaseCoupling {
+
+	//Rule tags aren't consistently cased
+	totals := make(TagTotals)
+	for tag, total := range tagTotals {
+		tag = strings.ToLower(tag)
+		totals[tag] = TagTotal{Findings: totals[tag].Findings + total.Findings, Effort: totals[tag].Effort + total.Effort}
+	}
+
+	coupling := DatabaseCoupling{Application: app, Coupling: DB_COUPLING_NONE, Constructs: []DatabaseConstructResult{}}
+
+	for _, sloc := range slocs {
+		if sloc.Application == app && isDatabaseLanguage(sloc.Lang) {
+			coupling.Files += sloc.TotalFiles
+			coupling.CodeLines += sloc.CodeLines
+		}
+	}
+
+	blocked := false
+	for _, construct := range constructs {
+		result := DatabaseConstructResult{Construct: construct.Name, Blocker: construct.Blocker}
+		for _, tag := range construct.Tags {
+			if total, found := totals[tag]; found && total.Findings > 0 {
+				result.Findings += total.Findings
+				result.Effort += total.Effort
+				result.Tags = append(result.Tags, tag)
+			}
+		}
+		sort.Strings(result.Tags)
+
+		if result.Findings > 0 {
+			coupling.Findings += result.Findings
+			coupling.Effort += result.Effort
+			coupling.Constructs = append(coupling.Constructs, result)
+			blocked = blocked || construct.Blocker
+		}
+		if construct.Name == "stored-logic" {
+			coupling.Routines = result.Findings
+		}
+	}
+
+	switch {
+	case blocked || coupling.CodeLines >= DB_COUPLING_HIGH_CODE_LINES:
+		coupling.Coupling = DB_COUPLING_HIGH
+	case coupling.Findings > 0 || coupling.CodeLines >= DB_COUPLING_MEDIUM_CODE_LINES:
+		coupling.Coupling = DB_COUPLING_MEDIUM
+	case coupling.CodeLines > 0:
+		coupling.Coupling = DB_COUPLING_LOW
+	}
+
+	return coupling
+}
+
+func isDatabaseLanguage(lang string) bool {
+	for _, language := range databaseLanguages {
+		if strings.EqualFold(lang, language) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//Findings naming a database driver, dialect, url or connection string are tagged database-connection
+const DB_CONNECTION_TAG = "database-connection"
+
+//Vendor of the connections whose line doesn't name one
+const DB_VENDOR_UNKNOWN = "unknown"
+
+//Licensing of the database vendors: commercial databases are the ones migration waves are usually planned around
+const DB_LICENSE_COMMERCIAL = "commercial"
+const DB_LICENSE_OPEN_SOURCE = "open-source"
+const DB_LICENSE_EMBEDDED = "embedded"
+
+//DatabaseVendor is a database vendor, found by the Keywords of the lines of the connections naming it
+type DatabaseVendor struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	License     string   `json:"license"`
+	Keywords    []string `json:"keywords"`
+}
+
+//DatabaseConnectionKind is the kind of connection (driver, url, dialect...) the findings of a rule name, and how
+//its value is picked out of the line
+type DatabaseConnectionKind struct {
+	Rule  string
+	Kind  string
+	Value *regexp.Regexp
+}
+
+//DatabaseConnection is a driver, url, dialect or connection string of an application, where it is first named and
+//how many times. Credentials are masked out of its Value.
+type DatabaseConnection struct {
+	Vendor string `json:"vendor"`
+	Kind   string `json:"kind"`
+	Value  string `json:"value"`
+	XA     bool   `json:"xa"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Uses   int    `json:"uses"`
+}
+
+//DatabaseVendorUsage is the use an application makes of a database vendor: its distinct connections, how many times
+//they are named and whether any is XA
+type DatabaseVendorUsage struct {
+	Vendor      string `json:"vendor"`
+	License     string `json:"license"`
+	Connections int    `json:"connections"`
+	Uses        int    `json:"uses"`
+	XA          bool   `json:"xa"`
+}
+
+//DatabaseInventory is the databases an application connects to. Primary is the vendor it connects to most, the
+//commercial ones first, which places the application in a migration wave.
+type DatabaseInventory struct {
+	Application string                `json:"application"`
+	Primary     string                `json:"primary"`
+	XA          bool                  `json:"xa"`
+	Vendors     []DatabaseVendorUsage `json:"vendors"`
+	Connections []DatabaseConnection  `json:"connections"`
+}
+
+//Vendors in order of precedence: a line naming several is the first's
+var DatabaseVendors = []DatabaseVendor{
+	{Name: "oracle", Description: "Oracle Database", License: DB_LICENSE_COMMERCIAL, Keywords: []string{"oracle"}},
+	{Name: "db2", Description: "IBM Db2 (LUW, z/OS, i)", License: DB_LICENSE_COMMERCIAL, Keywords: []string{"db2", "as400"}},
+	{Name: "sybase", Description: "SAP ASE (Sybase)", License: DB_LICENSE_COMMERCIAL, Keywords: []string{"sybase"}},
+	{Name: "sqlserver", Description: "Microsoft SQL Server", License: DB_LICENSE_COMMERCIAL,
+		Keywords: []string{"sqlserver", "sql_server", "sqlclient", "jtds", "initial catalog", "trusted_connection"}},
+	{Name: "informix", Description: "IBM Informix", License: DB_LICENSE_COMMERCIAL, Keywords: []string{"informix"}},
+	{Name: "hana", Description: "SAP HANA", License: DB_LICENSE_COMMERCIAL, Keywords: []string{"jdbc:sap:", "com.sap.db", "dialect.hana"}},
+	{Name: "teradata", Description: "Teradata", License: DB_LICENSE_COMMERCIAL, Keywords: []string{"teradata"}},
+	{Name: "mariadb", Description: "MariaDB", License: DB_LICENSE_OPEN_SOURCE, Keywords: []string{"mariadb"}},
+	{Name: "mysql", Description: "MySQL", License: DB_LICENSE_OPEN_SOURCE, Keywords: []string{"mysql"}},
+	{Name: "postgresql", Description: "PostgreSQL", License: DB_LICENSE_OPEN_SOURCE, Keywords: []string{"postgres", "npgsql"}},
+	{Name: "h2", Description: "H2", License: DB_LICENSE_EMBEDDED, Keywords: []string{"jdbc:h2:", "org.h2.", "h2dialect", "h2platform"}},
+	{Name: "hsqldb", Description: "HyperSQL", License: DB_LICENSE_EMBEDDED, Keywords: []string{"hsql"}},
+	{Name: "derby", Description: "Apache Derby", License: DB_LICENSE_EMBEDDED, Keywords: []string{"derby"}},
+}
+
+var DatabaseConnectionKinds = []DatabaseConnectionKind{
+	{Rule: "db-jdbc-driver", Kind: "driver", Value: regexp.MustCompile(`\b(?:[a-z]\w*\.)+\w*(?:Driver|DataSource|jdbcDriver)\b`)},
+	{Rule: "db-jdbc-url", Kind: "url", Value: regexp.MustCompile(`jdbc:[^\s"'<>]+`)},
+	{Rule: "db-dialect", Kind: "dialect", Value: regexp.MustCompile(`\b(?:[a-z]\w*\.)+\w+(?:Dialect|Platform)\b|[A-Z][\w\-]*\s*"?\s*/?>?\s*$`)},
+	{Rule: "db-connection-string", Kind: "connection-string", Value: regexp.MustCompile(`(?i)"[^"]*\b(?:data source|server|host)\s*=[^"]*"`)},
+}
+
+//XA data sources (and the XA settings of connection strings)
+var xaRegex = regexp.MustCompile(`(?i)xa_?-?datasource|\bxa\b|enlist\s*=\s*true`)
+
+//Passwords of connection strings and urls, and the user/password of Oracle thin urls
+var credentialRegexes = []struct {
+	regex   *regexp.Regexp
+	replace string
+}{
+	{regex: regexp.MustCompile(`(?i)\b(password|pwd)\s*=\s*[^;&"'\s]*`), replace: "${1}=***"},
+	{regex: regexp.MustCompile(`(jdbc:oracle:\w+:)[^/@:\s"']+/[^@\s"']+@`), replace: "${1}***/***@"},
+}
+
+//DatabaseConnectionOf is the connection named by the finding of a database-connection rule: its value picked out of
+//the line (else the line), its kind (of the rule), vendor (of the line) and whether it is XA
+func DatabaseConnectionOf(finding *Finding, vendors []DatabaseVendor, kinds []DatabaseConnectionKind) DatabaseConnection {
+
+	line := strings.TrimSpace(finding.Value)
+	connection := DatabaseConnection{Vendor: DB_VENDOR_UNKNOWN, Kind: finding.Rule, Value: line, XA: xaRegex.MatchString(line),
+		File: finding.Fqn, Line: finding.Line, Uses: 1}
+
+	for _, kind := range kinds {
+		if kind.Rule == finding.Rule {
+			connection.Kind = kind.Kind
+			if value := kind.Value.FindString(line); value != "" {
+				connection.Value = strings.TrimSpace(strings.Trim(strings.TrimRight(value, " />"), `"`))
+			}
+			break
+		}
+	}
+
+	for _, credential := range credentialRegexes {
+		connection.Value = credential.regex.ReplaceAllString(connection.Value, credential.replace)
+	}
+
+	lower := strings.ToLower(line)
+	for _, vendor := range vendors {
+		for _, keyword := range vendor.Keywords {
+			if strings.Contains(lower, keyword) {
+				connection.Vendor = vendor.Name
+				return connection
+			}
+		}
+	}
+
+	return connection
+}
+
+//EvaluateDatabaseInventory inventories the databases the application connects to from the findings of its
+//database-connection rules. A connection is listed once per vendor, kind and value, where it is first named.
+//Vendors are sorted commercial first, then by uses; connections by vendor, kind and value.
+func EvaluateDatabaseInventory(app string, findings []Finding, vendors []DatabaseVendor, kinds []DatabaseConnectionKind) DatabaseInventory {
+
+	inventory := DatabaseInventory{Application: app, Vendors: []DatabaseVendorUsage{}, Connections: []DatabaseConnection{}}
+
+	sorted := make([]Finding, 0, len(findings))
+	for _, finding := range findings {
+		if finding.Application == app {
+			sorted = append(sorted, finding)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Fqn != sorted[j].Fqn {
+			return sorted[i].Fqn < sorted[j].Fqn
+		}
+		return sorted[i].Line < sorted[j].Line
+	})
+
+	byValue := make(map[string]int)
+	for i := range sorted {
+		connection := DatabaseConnectionOf(&sorted[i], vendors, kinds)
+		key := connection.Vendor + "|" + connection.Kind + "|" + connection.Value
+		if index, found := byValue[key]; found {
+			inventory.Connections[index].Uses++
+			continue
+		}
+		byValue[key] = len(inventory.Connections)
+		inventory.Connections = append(inventory.Connections, connection)
+	}
+
+	sort.SliceStable(inventory.Connections, func(i, j int) bool {
+		a, b := inventory.Connections[i], inventory.Connections[j]
+		if a.Vendor != b.Vendor {
+			return a.Vendor < b.Vendor
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Value < b.Value
+	})
+
+	licenses := map[string]string{DB_VENDOR_UNKNOWN: ""}
+	for _, vendor := range vendors {
+		licenses[vendor.Name] = vendor.License
+	}
+
+	byVendor := make(map[string]int)
+	for _, connection := range inventory.Connections {
+		index, found := byVendor[connection.Vendor]
+		if !found {
+			index = len(inventory.Vendors)
+			byVendor[connection.Vendor] = index
+			inventory.Vendors = append(inventory.Vendors, DatabaseVendorUsage{Vendor: connection.Vendor, License: licenses[connection.Vendor]})
+		}
+		usage := &inventory.Vendors[index]
+		usage.Connections++
+		usage.Uses += connection.Uses
+		usage.XA = usage.XA || connection.XA
+		inventory.XA = inventory.XA || connection.XA
+	}
+
+	sort.SliceStable(inventory.Vendors, func(i, j int) bool {
+		a, b := inventory.Vendors[i], inventory.Vendors[j]
+		if (a.License == DB_LICENSE_COMMERCIAL) != (b.License == DB_LICENSE_COMMERCIAL) {
+			return a.License == DB_LICENSE_COMMERCIAL
+		}
+		if (a.Vendor == DB_VENDOR_UNKNOWN) != (b.Vendor == DB_VENDOR_UNKNOWN) {
+			return b.Vendor == DB_VENDOR_UNKNOWN
+		}
+		return a.Uses > b.Uses
+	})
+
+	if len(inventory.Vendors) > 0 {
+		inventory.Primary = inventory.Vendors[0].Vendor
+	}
+
+	return inventory
+}
+
+//ConnectsToDatabase is whether the application names a database driver, url, dialect or connection string
+func (d *DatabaseInventory) ConnectsToDatabase() bool {
+	return len(d.Connections) > 0
+}
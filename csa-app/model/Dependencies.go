@@ -0,0 +1,518 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DEPENDENCY_JAR = "jar"
+const DEPENDENCY_HTTP = "http"
+const DEPENDENCY_QUEUE = "queue"
+const DEPENDENCY_DATABASE = "database"
+
+const INTERFACE_PROVIDES = "provides"
+const INTERFACE_CONSUMES = "consumes"
+
+//The application references the interface without it being known whether it provides or consumes it, I.E. a queue
+//configured in a properties file or a database
+const INTERFACE_SHARES = "shares"
+
+//AppInterface is something an application provides to, consumes from or shares with other applications: a jar, an
+//http (REST/SOAP) endpoint, a queue/topic or a database. Applications are dependent when their interfaces meet.
+type AppInterface struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	RunID       uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application string    `gorm:"index;not null" json:"application" yaml:"application"`
+	Kind        string    `gorm:"type:text;not null" json:"kind" yaml:"kind"`
+	Direction   string    `gorm:"type:text;not null" json:"direction" yaml:"direction"`
+	Name        string    `gorm:"type:text;not null" json:"name" yaml:"name"`
+	Evidence    string    `gorm:"type:text" json:"evidence" yaml:"evidence"`
+}
+
+//InterfaceExtractor finds the interfaces of an application in the files matching Files (globs matched against lower
+//cased file names). Names are captured by the first non empty group of each match of Pattern, by Names when the
+//content needs more than a regex, or are the file's name itself (FileName). Third-party files are only looked at
+//when ThirdParty is set, I.E. for the jars an application bundles.
+type InterfaceExtractor struct {
+	Kind       string
+	Direction  string
+	Files      []string
+	Pattern    *regexp.Regexp
+	Names      func(content string) []string
+	FileName   bool
+	ThirdParty bool
+}
+
+//DependencyEdge is From depending on To, through the interfaces named Names. Shared edges (a queue or database both
+//use) have no direction, From and To are then in name order.
+type DependencyEdge struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Kind     string   `json:"kind"`
+	Shared   bool     `json:"shared"`
+	Names    []string `json:"names"`
+	Evidence []string `json:"evidence"`
+}
+
+//DependencyNode is an application of the graph. Applications of a wave only depend on applications of earlier waves,
+//or on each other when their dependencies are circular.
+type DependencyNode struct {
+	Application string `json:"application"`
+	DependsOn   int    `json:"dependsOn"`
+	Dependents  int    `json:"dependents"`
+	Shared      int    `json:"shared"`
+	Wave        int    `json:"wave"`
+}
+
+type DependencyGraph struct {
+	Applications []DependencyNode `json:"applications"`
+	Edges        []DependencyEdge `json:"edges"`
+}
+
+//JVM languages sharing the Java annotations and APIs
+var jvmSources = []string{"*.java", "*.kt", "*.scala"}
+var configFiles = []string{"*.properties", "*.yml", "*.yaml"}
+var callerFiles = []string{"*.java", "*.kt", "*.scala", "*.properties", "*.yml", "*.yaml", "*.js", "*.ts", "*.cs", "*.config", "*.json"}
+
+var InterfaceExtractors = []InterfaceExtractor{
+	//Jars: those an app builds (provides) and those it bundles or declares (consumes)
+	{Kind: DEPENDENCY_JAR, Direction: INTERFACE_PROVIDES, Files: mavenDescriptors, Names: pomArtifactId},
+	{Kind: DEPENDENCY_JAR, Direction: INTERFACE_PROVIDES, Files: []string{"settings.gradle", "settings.gradle.kts"},
+		Pattern: regexp.MustCompile(`rootProject\.name\s*=\s*['"]([^'"]+)['"]`)},
+	{Kind: DEPENDENCY_JAR, Direction: INTERFACE_CONSUMES, Files: []string{"*.jar"}, FileName: true, ThirdParty: true},
+	{Kind: DEPENDENCY_JAR, Direction: INTERFACE_CONSUMES, Files: mavenDescriptors,
+		Pattern: regexp.MustCompile(`(?s)<dependency>.*?<artifactId>([^<]+)</artifactId>.*?</dependency>`)},
+	{Kind: DEPENDENCY_JAR, Direction: INTERFACE_CONSUMES, Files: gradleDescriptors,
+		Pattern: regexp.MustCompile(`(?:implementation|compile|api|runtimeOnly|compileOnly)\s*\(?\s*['"][^:'"]+:([^:'"]+)`)},
+
+	//Http (REST/SOAP) endpoints, by path or service name
+	{Kind: DEPENDENCY_HTTP, Direction: INTERFACE_PROVIDES, Files: jvmSources,
+		Pattern: regexp.MustCompile(`@(?:Request|Get|Post|Put|Delete|Patch)Mapping\(\s*(?:(?:value|path)\s*=\s*)?[{\[]?\s*"([^"]+)"|@Path\(\s*"([^"]+)"`)},
+	{Kind: DEPENDENCY_HTTP, Direction: INTERFACE_PROVIDES, Files: jvmSources,
+		Pattern: regexp.MustCompile(`@WebService\([^)]*serviceName\s*=\s*"([^"]+)"`)},
+	{Kind: DEPENDENCY_HTTP, Direction: INTERFACE_PROVIDES, Files: []string{"*.wsdl"},
+		Pattern: regexp.MustCompile(`<(?:wsdl:)?service\s+name\s*=\s*"([^"]+)"`)},
+	{Kind: DEPENDENCY_HTTP, Direction: INTERFACE_PROVIDES, Files: configFiles,
+		Pattern: regexp.MustCompile(`(?m)^\s*(?:server\.(?:servlet\.)?context-path|spring\.application\.name)\s*[=:]\s*([\w/.-]+)|(?s)spring:\s*\n\s*application:\s*\n\s*name:\s*([\w.-]+)`)},
+	{Kind: DEPENDENCY_HTTP, Direction: INTERFACE_CONSUMES, Files: callerFiles,
+		Pattern: regexp.MustCompile(`(https?://[^\s"'<>,;]+)`)},
+	{Kind: DEPENDENCY_HTTP, Direction: INTERFACE_CONSUMES, Files: jvmSources,
+		Pattern: regexp.MustCompile(`@FeignClient\(\s*(?:(?:name|value)\s*=\s*)?"([^"]+)"`)},
+	{Kind: DEPENDENCY_HTTP, Direction: INTERFACE_CONSUMES, Files: append(append([]string{}, jvmSources...), configFiles...),
+		Pattern: regexp.MustCompile(`lb://([\w.-]+)`)},
+
+	//Queues/topics, produced to and listened to
+	{Kind: DEPENDENCY_QUEUE, Direction: INTERFACE_CONSUMES, Files: jvmSources,
+		Pattern: regexp.MustCompile(`@(?:JmsListener|SqsListener)\([^)]*destination\s*=\s*"([^"]+)"|@RabbitListener\([^)]*queues\s*=\s*[{\[]?\s*"([^"]+)"|@KafkaListener\([^)]*topics\s*=\s*[{\[]?\s*"([^"]+)"`)},
+	{Kind: DEPENDENCY_QUEUE, Direction: INTERFACE_PROVIDES, Files: jvmSources,
+		Pattern: regexp.MustCompile(`\w*[Tt]emplate\.(?:convertAndSend|send)\(\s*"([^"]+)"`)},
+	{Kind: DEPENDENCY_QUEUE, Direction: INTERFACE_SHARES, Files: configFiles,
+		Pattern: regexp.MustCompile(`(?m)^\s*[\w.-]*(?:queue|topic|destination)[\w.-]*\s*[=:]\s*([\w./-]+)\s*$`)},
+
+	//Databases (schemas), by name
+	{Kind: DEPENDENCY_DATABASE, Direction: INTERFACE_SHARES, Files: append([]string{"*.xml"}, callerFiles...),
+		Pattern: regexp.MustCompile(`(jdbc:[a-z0-9]+:[^\s"'<>]+)`)},
+	{Kind: DEPENDENCY_DATABASE, Direction: INTERFACE_SHARES, Files: []string{"*.config", "*.json", "*.cs"},
+		Pattern: regexp.MustCompile(`(?i)(?:Initial Catalog|Database)\s*=\s*([^;"'<>]+)`)},
+	{Kind: DEPENDENCY_DATABASE, Direction: INTERFACE_SHARES, Files: configFiles,
+		Pattern: regexp.MustCompile(`default[_-]schema\s*[=:]\s*(\w+)`)},
+}
+
+//Pom sections whose artifactIds aren't the project's own
+var pomForeignSections = []*regexp.Regexp{
+	regexp.MustCompile(`(?s)<parent>.*?</parent>`),
+	regexp.MustCompile(`(?s)<dependencyManagement>.*?</dependencyManagement>`),
+	regexp.MustCompile(`(?s)<dependencies>.*?</dependencies>`),
+	regexp.MustCompile(`(?s)<build>.*?</build>`),
+	regexp.MustCompile(`(?s)<profiles>.*?</profiles>`),
+	regexp.MustCompile(`(?s)<reporting>.*?</reporting>`),
+}
+
+var pomArtifactIdPattern = regexp.MustCompile(`<artifactId>([^<]+)</artifactId>`)
+var jarVersionPattern = regexp.MustCompile(`-\d[\w.-]*$`)
+var genericPathPattern = regexp.MustCompile(`^(api|apis|rest|services?|ws|soap|web|app|static|public|resources|health|actuator|v\d+)$`)
+var ipPattern = regexp.MustCompile(`^\d+(\.\d+){3}$`)
+var dbNamePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i);database(?:Name)?=([^;]+)`),         //sqlserver
+	regexp.MustCompile(`@(?://)?[^:/]+(?::\d+)?[:/]([\w.$-]+)$`), //oracle SID or service
+	regexp.MustCompile(`//[^/]+/([^?;/]+)`),                      //host[:port]/database
+}
+
+//pomArtifactId returns the pom's own artifactId
+func pomArtifactId(content string) []string {
+	for _, section := range pomForeignSections {
+		content = section.ReplaceAllString(content, "")
+	}
+	if match := pomArtifactIdPattern.FindStringSubmatch(content); match != nil {
+		return []string{match[1]}
+	}
+	return nil
+}
+
+//DetectInterfaces finds the interfaces of an application, each once with the file that gave it away
+func DetectInterfaces(runId uint, app *Application, extractors []InterfaceExtractor) []*AppInterface {
+
+	found := make(map[string]*AppInterface)
+	contents := make(map[string]string)
+
+	for _, extractor := range extractors {
+		detector := TechDetector{Files: extractor.Files}
+
+		for _, file := range app.Files {
+			if (file.ThirdParty != "" && !extractor.ThirdParty) || !detector.matchesFile(file.Name) {
+				continue
+			}
+
+			var names []string
+			switch {
+			case extractor.FileName:
+				names = []string{file.Name}
+			case extractor.Names != nil:
+				names = extractor.Names(readDetectionFile(file.FQN, contents))
+			default:
+				for _, match := range extractor.Pattern.FindAllStringSubmatch(readDetectionFile(file.FQN, contents), -1) {
+					for _, group := range match[1:] {
+						if group != "" {
+							names = append(names, group)
+							break
+						}
+					}
+				}
+			}
+
+			for _, name := range names {
+				for _, normalized := range InterfaceNames(extractor.Kind, name) {
+					key := extractor.Kind + "/" + extractor.Direction + "/" + normalized
+					if _, exists := found[key]; !exists {
+						found[key] = &AppInterface{RunID: runId, Application: app.Name, Kind: extractor.Kind, Direction: extractor.Direction,
+							Name: normalized, Evidence: relativeEvidence(app, file)}
+					}
+				}
+			}
+		}
+	}
+
+	interfaces := make([]*AppInterface, 0, len(found))
+	for _, appInterface := range found {
+		interfaces = append(interfaces, appInterface)
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		a, b := interfaces[i], interfaces[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Direction != b.Direction {
+			return a.Direction < b.Direction
+		}
+		return a.Name < b.Name
+	})
+
+	return interfaces
+}
+
+//InterfaceNames normalizes a name found by an extractor into the names applications are matched by. I.E. the jar
+//orders-client-1.2.jar is orders-client, the url http://orders-svc:8080/orders/42 both orders-svc and orders. Names
+//that can't tell applications apart (placeholders, localhost, /api...) are dropped.
+func InterfaceNames(kind string, name string) []string {
+
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, "${") || strings.Contains(name, "#{") || strings.Contains(name, "*") {
+		return nil
+	}
+
+	switch kind {
+	case DEPENDENCY_JAR:
+		name = jarVersionPattern.ReplaceAllString(strings.TrimSuffix(strings.ToLower(name), ".jar"), "")
+		return []string{name}
+	case DEPENDENCY_HTTP:
+		return httpNames(name)
+	case DEPENDENCY_DATABASE:
+		return databaseNames(name)
+	}
+
+	name = strings.ToLower(name)
+	if name == "true" || name == "false" || ipPattern.MatchString(name) || strings.Trim(name, "0123456789") == "" {
+		return nil
+	}
+	return []string{name}
+}
+
+func httpNames(name string) []string {
+
+	var names []string
+	path := name
+
+	if strings.Contains(name, "://") {
+		endpoint, err := url.Parse(name)
+		if err != nil {
+			return nil
+		}
+		host := strings.ToLower(endpoint.Hostname())
+		if label := strings.Split(host, ".")[0]; host != "localhost" && label != "www" && !ipPattern.MatchString(host) {
+			names = append(names, label)
+		}
+		path = endpoint.Path
+	}
+
+	for _, segment := range strings.Split(strings.ToLower(path), "/") {
+		//Empty or path variables
+		if segment == "" || strings.HasPrefix(segment, "{") {
+			continue
+		}
+		if !genericPathPattern.MatchString(segment) && !strings.Contains(segment, ".") {
+			names = append(names, segment)
+		}
+		//Only the first meaningful segment names the service
+		if !genericPathPattern.MatchString(segment) {
+			break
+		}
+	}
+
+	return names
+}
+
+func databaseNames(name string) []string {
+
+	if !strings.HasPrefix(strings.ToLower(name), "jdbc:") {
+		return []string{strings.ToLower(name)}
+	}
+
+	//In memory databases aren't shared
+	if strings.Contains(name, ":mem:") {
+		return nil
+	}
+
+	for _, pattern := range dbNamePatterns {
+		if match := pattern.FindStringSubmatch(name); match != nil {
+			return []string{strings.ToLower(match[1])}
+		}
+	}
+
+	return nil
+}
+
+//BuildDependencyGraph connects the applications whose interfaces meet: consumers depend on the providers of what they
+//consume, applications sharing a queue or database without a known direction share an (undirected) edge
+func BuildDependencyGraph(apps []string, interfaces []AppInterface) *DependencyGraph {
+
+	type participant struct {
+		direction string
+		evidence  string
+	}
+
+	//kind/name => app => how the app references it
+	references := make(map[string]map[string]participant)
+	var keys []string
+	for _, appInterface := range interfaces {
+		key := appInterface.Kind + "/" + appInterface.Name
+		if references[key] == nil {
+			references[key] = make(map[string]participant)
+			keys = append(keys, key)
+		}
+		if current, found := references[key][appInterface.Application]; !found || current.direction == INTERFACE_SHARES {
+			references[key][appInterface.Application] = participant{appInterface.Direction, appInterface.Application + ": " + appInterface.Evidence}
+		}
+	}
+	sort.Strings(keys)
+
+	edges := make(map[string]*DependencyEdge)
+	var edgeKeys []string
+	addEdge := func(from string, to string, kind string, shared bool, name string, evidence ...string) {
+		key := fmt.Sprintf("%s|%s|%s|%v", from, to, kind, shared)
+		edge, found := edges[key]
+		if !found {
+			edge = &DependencyEdge{From: from, To: to, Kind: kind, Shared: shared}
+			edges[key] = edge
+			edgeKeys = append(edgeKeys, key)
+		}
+		edge.Names = appendUnique(edge.Names, name)
+		for _, e := range evidence {
+			edge.Evidence = appendUnique(edge.Evidence, e)
+		}
+	}
+
+	for _, key := range keys {
+		kind, name := key[:strings.Index(key, "/")], key[strings.Index(key, "/")+1:]
+		participants := references[key]
+
+		var names []string
+		for app := range participants {
+			names = append(names, app)
+		}
+		sort.Strings(names)
+
+		for i, a := range names {
+			for _, b := range names[i+1:] {
+				pa, pb := participants[a], participants[b]
+				switch {
+				case pa.direction == INTERFACE_CONSUMES && pb.direction == INTERFACE_PROVIDES:
+					addEdge(a, b, kind, false, name, pa.evidence, pb.evidence)
+				case pb.direction == INTERFACE_CONSUMES && pa.direction == INTERFACE_PROVIDES:
+					addEdge(b, a, kind, false, name, pb.evidence, pa.evidence)
+				case pa.direction == INTERFACE_SHARES || pb.direction == INTERFACE_SHARES || kind == DEPENDENCY_QUEUE:
+					//Both consuming (or producing to) a queue still ties them together
+					addEdge(a, b, kind, true, name, pa.evidence, pb.evidence)
+				}
+			}
+		}
+	}
+
+	graph := &DependencyGraph{}
+	sort.Strings(edgeKeys)
+	for _, key := range edgeKeys {
+		edge := edges[key]
+		sort.Strings(edge.Names)
+		sort.Strings(edge.Evidence)
+		graph.Edges = append(graph.Edges, *edge)
+	}
+
+	waves := dependencyWaves(apps, graph.Edges)
+	for _, app := range apps {
+		node := DependencyNode{Application: app, Wave: waves[app]}
+		dependsOn := make(map[string]bool)
+		dependents := make(map[string]bool)
+		shared := make(map[string]bool)
+		for _, edge := range graph.Edges {
+			switch {
+			case edge.Shared && edge.From == app:
+				shared[edge.To] = true
+			case edge.Shared && edge.To == app:
+				shared[edge.From] = true
+			case edge.From == app:
+				dependsOn[edge.To] = true
+			case edge.To == app:
+				dependents[edge.From] = true
+			}
+		}
+		node.DependsOn, node.Dependents, node.Shared = len(dependsOn), len(dependents), len(shared)
+		graph.Applications = append(graph.Applications, node)
+	}
+
+	return graph
+}
+
+//dependencyWaves puts each application in the wave after the last wave of the applications it depends on, starting at
+//1. Applications depending on each other, directly or not, end up in the same wave.
+func dependencyWaves(apps []string, edges []DependencyEdge) map[string]int {
+
+	dependencies := make(map[string][]string)
+	for _, edge := range edges {
+		if !edge.Shared {
+			dependencies[edge.From] = append(dependencies[edge.From], edge.To)
+		}
+	}
+
+	//Tarjan's strongly connected components, which come out dependencies first
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	component := make(map[string]int)
+	var components [][]string
+
+	var connect func(app string)
+	connect = func(app string) {
+		index[app] = len(index)
+		lowLink[app] = index[app]
+		stack = append(stack, app)
+		onStack[app] = true
+
+		for _, dependency := range dependencies[app] {
+			if _, visited := index[dependency]; !visited {
+				connect(dependency)
+				if lowLink[dependency] < lowLink[app] {
+					lowLink[app] = lowLink[dependency]
+				}
+			} else if onStack[dependency] && index[dependency] < lowLink[app] {
+				lowLink[app] = index[dependency]
+			}
+		}
+
+		if lowLink[app] == index[app] {
+			var members []string
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				component[member] = len(components)
+				members = append(members, member)
+				if member == app {
+					break
+				}
+			}
+			components = append(components, members)
+		}
+	}
+
+	for _, app := range apps {
+		if _, visited := index[app]; !visited {
+			connect(app)
+		}
+	}
+
+	waves := make(map[string]int)
+	componentWaves := make([]int, len(components))
+	for i, members := range components {
+		wave := 1
+		for _, member := range members {
+			for _, dependency := range dependencies[member] {
+				if c := component[dependency]; c != i && componentWaves[c]+1 > wave {
+					wave = componentWaves[c] + 1
+				}
+			}
+		}
+		componentWaves[i] = wave
+		for _, member := range members {
+			waves[member] = wave
+		}
+	}
+
+	return waves
+}
+
+//WriteDot writes the graph in graphviz dot format. Shared edges are dashed and undirected.
+func (g *DependencyGraph) WriteDot(out io.Writer) error {
+
+	if _, err := fmt.Fprintln(out, "digraph dependencies {"); err != nil {
+		return err
+	}
+
+	for _, node := range g.Applications {
+		if _, err := fmt.Fprintf(out, "  %q [label=%q];\n", node.Application, fmt.Sprintf("%s\nwave %d", node.Application, node.Wave)); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range g.Edges {
+		attributes := fmt.Sprintf("label=%q", edge.Kind+": "+strings.Join(edge.Names, ", "))
+		if edge.Shared {
+			attributes += ", dir=none, style=dashed"
+		}
+		if _, err := fmt.Fprintf(out, "  %q -> %q [%s];\n", edge.From, edge.To, attributes); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(out, "}")
+	return err
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
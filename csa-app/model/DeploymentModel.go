@@ -0,0 +1,60 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"strings"
+)
+
+//Deployment models of an application, which drive its replatforming strategy: embedded server applications run as a
+//process of their own, external server ones (WAR/EAR) are deployed to an application server. Hybrid applications are
+//both, I.E. a Spring Boot application packaged as a WAR.
+const DEPLOYMENT_EMBEDDED = "embedded-server"
+const DEPLOYMENT_EXTERNAL = "external-server"
+const DEPLOYMENT_HYBRID = "hybrid"
+const DEPLOYMENT_UNKNOWN = "unknown"
+
+//Tags of the findings giving the deployment model away: the deployment-model rules and the descriptors of
+//application servers
+var embeddedServerTags = []string{"embedded-server"}
+var externalServerTags = []string{"external-server", "app-server"}
+
+//DeploymentModel is how an application is deployed, with the findings of either model it was classified by
+type DeploymentModel struct {
+	Application string `json:"application"`
+	Model       string `json:"model"`
+	Embedded    int    `json:"embedded"`
+	External    int    `json:"external"`
+}
+
+//ClassifyDeployment classifies the application as embedded server, external server, hybrid (both) or unknown
+//(neither, I.E. a library or a non Java application) from the totals of its findings by tag
+func ClassifyDeployment(app string, tagTotals TagTotals) DeploymentModel {
+
+	deployment := DeploymentModel{Application: app, Model: DEPLOYMENT_UNKNOWN}
+
+	//Rule tags aren't consistently cased
+	for tag, total := range tagTotals {
+		tag = strings.ToLower(tag)
+		if containsString(embeddedServerTags, tag) {
+			deployment.Embedded += total.Findings
+		}
+		if containsString(externalServerTags, tag) {
+			deployment.External += total.Findings
+		}
+	}
+
+	switch {
+	case deployment.Embedded > 0 && deployment.External > 0:
+		deployment.Model = DEPLOYMENT_HYBRID
+	case deployment.Embedded > 0:
+		deployment.Model = DEPLOYMENT_EMBEDDED
+	case deployment.External > 0:
+		deployment.Model = DEPLOYMENT_EXTERNAL
+	}
+
+	return deployment
+}
@@ -0,0 +1,356 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"csa-app/util"
+	"gopkg.in/yaml.v2"
+
+	"github.com/Knetic/govaluate"
+)
+
+//The 6R dispositions an application can be recommended
+const DISPOSITION_REHOST = "rehost"
+const DISPOSITION_REPLATFORM = "replatform"
+const DISPOSITION_REFACTOR = "refactor"
+const DISPOSITION_REPURCHASE = "repurchase"
+const DISPOSITION_RETIRE = "retire"
+const DISPOSITION_RETAIN = "retain"
+
+//Applications no disposition rule holds for
+const DISPOSITION_NONE = "none"
+
+const DEFAULT_DISPOSITION_RULES = "default"
+
+var Dispositions = []string{DISPOSITION_REHOST, DISPOSITION_REPLATFORM, DISPOSITION_REFACTOR, DISPOSITION_REPURCHASE, DISPOSITION_RETIRE, DISPOSITION_RETAIN}
+
+//Variables the conditions of disposition rules can use. tech("<name>") is true when the technology was detected in
+//the application (see the tech stack) and tag("<tag>") is the number of its findings carrying the tag.
+const (
+	DISPOSITION_SCORE           = "score"
+	DISPOSITION_RAW_SCORE       = "raw_score"
+	DISPOSITION_FINDINGS        = "findings"
+	DISPOSITION_SLOC            = "sloc"
+	DISPOSITION_BLOCKERS        = "blockers"
+	DISPOSITION_CRITICAL        = "critical"
+	DISPOSITION_HIGH            = "high"
+	DISPOSITION_BUSINESS_VALUE  = "business_value"
+	DISPOSITION_STATEFUL        = "stateful"
+	DISPOSITION_CONTAINER_SCORE = "container_score"
+	DISPOSITION_CONTAINERIZED   = "containerized"
+	DISPOSITION_TWELVE_FACTOR   = "twelve_factor"
+)
+
+//The twelve factor that is failed by stateful applications
+const processesFactor = 6
+
+//DispositionRule recommends its disposition for the applications its condition (When) holds for
+type DispositionRule struct {
+	Disposition string `json:"disposition" yaml:"disposition"`
+	When        string `json:"when" yaml:"when"`
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+//DispositionRules are evaluated in order, the first rule holding for an application decides its disposition
+type DispositionRules struct {
+	Name        string            `json:"name" yaml:"name"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Rules       []DispositionRule `json:"rules" yaml:"rules"`
+}
+
+//DispositionFacts are what is known of an application when recommending its disposition
+type DispositionFacts struct {
+	Application    string
+	Score          float64
+	RawScore       int
+	Findings       int
+	Sloc           int
+	Blockers       int //Findings with an effort above the critical threshold
+	Critical       int
+	High           int
+	BusinessValue  float64 //-1 when unknown
+	Stateful       bool
+	ContainerScore float64
+	Containerized  bool
+	TwelveFactor   float64
+	Tech           []string
+	Tags           TagTotals
+}
+
+//Disposition recommended for an application, with the facts the deciding rule relied on
+type Disposition struct {
+	Application string   `json:"application"`
+	Disposition string   `json:"disposition"`
+	Reason      string   `json:"reason,omitempty"`
+	When        string   `json:"when,omitempty"`
+	Score       float64  `json:"score"`
+	Stateful    bool     `json:"stateful"`
+	Blockers    int      `json:"blockers"`
+	Tech        []string `json:"tech,omitempty"`
+	Factors     []string `json:"factors"`
+}
+
+//DefaultDispositionRules retire and repurchase costly applications of little business value (when it is known), retain
+//those with critical blockers and no cloud suitability, refactor poorly suited or stateful applications with blockers,
+//replatform those on application servers, keeping state or short of cloud ready and rehost the rest
+func DefaultDispositionRules() *DispositionRules {
+	return &DispositionRules{
+		Name:        DEFAULT_DISPOSITION_RULES,
+		Description: "score, tech stack, statefulness and blockers",
+		Rules: []DispositionRule{
+			{Disposition: DISPOSITION_RETIRE, When: "business_value >= 0 && business_value < 2 && score < 5",
+				Reason: "Of little business value and costly to migrate"},
+			{Disposition: DISPOSITION_REPURCHASE, When: "business_value >= 0 && business_value < 4 && score < 3",
+				Reason: "Of modest business value and poorly suited to the cloud, consider a SaaS/COTS replacement"},
+			{Disposition: DISPOSITION_RETAIN, When: "score < 2 && critical > 0",
+				Reason: "Critical blockers and no cloud suitability, keep it where it runs until it can be rewritten"},
+			{Disposition: DISPOSITION_REFACTOR, When: "score < 5 || (stateful && blockers > 0)",
+				Reason: "Blockers (or state) that need code changes before it can run in the cloud"},
+			{Disposition: DISPOSITION_REPLATFORM, When: "score < 8 || stateful || tech('WebSphere') || tech('WebLogic') || tech('JBoss/WildFly') || tech('EJB')",
+				Reason: "Needs a new runtime (or platform services) but little code change"},
+			{Disposition: DISPOSITION_REHOST, When: "true",
+				Reason: "Cloud ready as is"},
+		},
+	}
+}
+
+//LoadDispositionRules reads the rules from a yaml/json file, an empty file returns the default rules
+func LoadDispositionRules(file string) (*DispositionRules, error) {
+
+	if file == "" {
+		return DefaultDispositionRules(), nil
+	}
+
+	reader, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read disposition rules [%s]: %v", file, err)
+	}
+	defer reader.Close()
+
+	var decoder util.FileDecoder
+	if strings.HasSuffix(file, util.JSON) {
+		decoder = json.NewDecoder(reader)
+	} else {
+		decoder = yaml.NewDecoder(reader)
+	}
+
+	rules := &DispositionRules{}
+	if err = decoder.Decode(rules); err != nil {
+		return nil, fmt.Errorf("unable to decode disposition rules [%s]: %v", file, err)
+	}
+
+	return rules, rules.Validate()
+}
+
+func (d *DispositionRules) Validate() error {
+
+	if d.Name == "" {
+		return fmt.Errorf("disposition rules must have a name")
+	}
+
+	if len(d.Rules) == 0 {
+		return fmt.Errorf("disposition rules [%s] have no rules", d.Name)
+	}
+
+	known := (&DispositionFacts{}).variables()
+	for i, rule := range d.Rules {
+		if !isDisposition(rule.Disposition) {
+			return fmt.Errorf("disposition rules [%s] rule #%d disposition [%s] is invalid. Valid dispositions are %s",
+				d.Name, i+1, rule.Disposition, strings.Join(Dispositions, ", "))
+		}
+
+		expression, err := (&DispositionFacts{}).compile(rule.When, nil)
+		if err != nil {
+			return fmt.Errorf("disposition rules [%s] rule #%d condition [%s] is invalid! Details: %v", d.Name, i+1, rule.When, err)
+		}
+
+		for _, variable := range expression.Vars() {
+			if _, found := known[variable]; !found {
+				return fmt.Errorf("disposition rules [%s] rule #%d uses unknown variable [%s]. Valid variables are %s",
+					d.Name, i+1, variable, strings.Join(sortedVariables(known), ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func isDisposition(disposition string) bool {
+	for _, valid := range Dispositions {
+		if disposition == valid {
+			return true
+		}
+	}
+	return false
+}
+
+//NewDispositionFacts gathers the facts of an application from its scores, the technologies detected in it and the
+//totals of its findings by tag. Applications failing the processes factor are stateful.
+func NewDispositionFacts(app *Application, tech []TechAttribute, tags TagTotals) *DispositionFacts {
+
+	facts := &DispositionFacts{
+		Application:   app.Name,
+		Score:         app.Score,
+		RawScore:      app.RawScore,
+		Findings:      app.Findings,
+		Sloc:          app.SlocCnt,
+		Blockers:      app.NumCrits,
+		Critical:      app.CriticalCnt,
+		High:          app.HighCnt,
+		BusinessValue: app.BusinessValue,
+		Tags:          tags,
+	}
+
+	names := make(map[string]bool)
+	for _, attribute := range tech {
+		if attribute.Application == app.Name {
+			names[attribute.Name] = true
+		}
+	}
+	facts.Tech = sortedKeys(names)
+
+	factors := EvaluateTwelveFactors(app.Name, tags, TwelveFactors)
+	facts.TwelveFactor = factors.Score
+	for _, factor := range factors.Factors {
+		if factor.Number == processesFactor {
+			facts.Stateful = factor.Status == FACTOR_FAIL
+		}
+	}
+
+	container := EvaluateContainerReadiness(app.Name, tags, ContainerConcerns)
+	facts.ContainerScore = container.Score
+	facts.Containerized = container.Containerized
+
+	return facts
+}
+
+//Recommend the disposition of the first rule holding for the application. The factors are the variables, technologies
+//and tags the rule's condition relied on.
+func (d *DispositionRules) Recommend(facts *DispositionFacts) (Disposition, error) {
+
+	disposition := Disposition{Application: facts.Application, Disposition: DISPOSITION_NONE, Score: facts.Score,
+		Stateful: facts.Stateful, Blockers: facts.Blockers, Tech: facts.Tech, Factors: []string{}}
+
+	variables := facts.variables()
+	for _, rule := range d.Rules {
+
+		var used []string
+		expression, err := facts.compile(rule.When, &used)
+		if err != nil {
+			return disposition, err
+		}
+
+		result, err := expression.Evaluate(variables)
+		if err != nil {
+			return disposition, fmt.Errorf("disposition rule [%s] failed for application [%s]: %v", rule.When, facts.Application, err)
+		}
+
+		if holds, ok := result.(bool); !ok || !holds {
+			continue
+		}
+
+		for _, variable := range expression.Vars() {
+			used = append(used, fmt.Sprintf("%s=%s", variable, factValue(variables[variable])))
+		}
+
+		disposition.Disposition = rule.Disposition
+		disposition.Reason = rule.Reason
+		disposition.When = rule.When
+		disposition.Factors = uniqueFactors(used)
+		break
+	}
+
+	return disposition, nil
+}
+
+func (f *DispositionFacts) variables() map[string]interface{} {
+	return map[string]interface{}{
+		DISPOSITION_SCORE:           f.Score,
+		DISPOSITION_RAW_SCORE:       f.RawScore,
+		DISPOSITION_FINDINGS:        f.Findings,
+		DISPOSITION_SLOC:            f.Sloc,
+		DISPOSITION_BLOCKERS:        f.Blockers,
+		DISPOSITION_CRITICAL:        f.Critical,
+		DISPOSITION_HIGH:            f.High,
+		DISPOSITION_BUSINESS_VALUE:  f.BusinessValue,
+		DISPOSITION_STATEFUL:        f.Stateful,
+		DISPOSITION_CONTAINER_SCORE: f.ContainerScore,
+		DISPOSITION_CONTAINERIZED:   f.Containerized,
+		DISPOSITION_TWELVE_FACTOR:   f.TwelveFactor,
+	}
+}
+
+//compile the condition with the tech and tag functions of the application. The calls of either with a truthy result
+//are appended to used.
+func (f *DispositionFacts) compile(condition string, used *[]string) (*govaluate.EvaluableExpression, error) {
+
+	record := func(factor string) {
+		if used != nil {
+			*used = append(*used, factor)
+		}
+	}
+
+	functions := util.NewExpression(nil).Functions
+
+	functions["tech"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return false, fmt.Errorf("tech function needs one technology name")
+		}
+		name := fmt.Sprint(args[0])
+		for _, tech := range f.Tech {
+			if strings.EqualFold(tech, name) {
+				record(fmt.Sprintf("tech=%s", tech))
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	functions["tag"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return 0.0, fmt.Errorf("tag function needs one tag")
+		}
+		tag := fmt.Sprint(args[0])
+		findings := 0
+		for name, total := range f.Tags {
+			if strings.EqualFold(name, tag) {
+				findings += total.Findings
+			}
+		}
+		if findings > 0 {
+			record(fmt.Sprintf("tag %s=%d", strings.ToLower(tag), findings))
+		}
+		return float64(findings), nil
+	}
+
+	return govaluate.NewEvaluableExpressionWithFunctions(condition, functions)
+}
+
+func factValue(value interface{}) string {
+	if v, ok := value.(float64); ok {
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprint(value)
+}
+
+func uniqueFactors(factors []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, factor := range factors {
+		if !seen[factor] {
+			seen[factor] = true
+			unique = append(unique, factor)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
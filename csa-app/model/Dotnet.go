@@ -0,0 +1,216 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Target framework (TFM) .NET Framework applications are migrated to
+const DOTNET_MIGRATION_TARGET = "net8.0"
+
+var dotnetProjectFiles = []string{"*.csproj", "*.vbproj", "*.fsproj"}
+
+var projectSdk = regexp.MustCompile(`<Project\s[^>]*\bSdk\s*=\s*"([^"]+)"`)
+var targetFrameworks = regexp.MustCompile(`<TargetFrameworks?>\s*([^<]+?)\s*</TargetFrameworks?>`)
+var targetFrameworkVersion = regexp.MustCompile(`<TargetFrameworkVersion>\s*v?([0-9.]+)\s*</TargetFrameworkVersion>`)
+var dotnetFrameworkMoniker = regexp.MustCompile(`^net[1-4][0-9]*$`)
+
+//DotnetProject is a .NET project (csproj, vbproj, fsproj) of an application, its file relative to the application
+//root. Sdk is the MSBuild SDK of SDK-style projects, legacy (.NET Framework) projects have none. TargetFrameworks are
+//the target framework monikers (net48, net8.0...) the project builds for, separated by ;.
+type DotnetProject struct {
+	ID               uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt        time.Time `json:"-" yaml:"-"`
+	RunID            uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application      string    `gorm:"index;not null" json:"application" yaml:"application"`
+	Project          string    `gorm:"type:text" json:"project" yaml:"project"`
+	Sdk              string    `gorm:"type:text" json:"sdk,omitempty" yaml:"sdk,omitempty"`
+	TargetFrameworks string    `gorm:"type:text" json:"targetFrameworks" yaml:"targetFrameworks"`
+}
+
+//DotnetBlocker is something keeping a .NET Framework application from running on DOTNET_MIGRATION_TARGET, found by
+//the tags of its findings, with what it is usually replaced by
+type DotnetBlocker struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Replacement string   `json:"replacement"`
+	Tags        []string `json:"tags"`
+}
+
+//DotnetBlockerResult is a blocker found in an application
+type DotnetBlockerResult struct {
+	Blocker     string   `json:"blocker"`
+	Replacement string   `json:"replacement"`
+	Findings    int      `json:"findings"`
+	Effort      int      `json:"effort"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+//DotnetMigration is what migrating the .NET projects of an application to DOTNET_MIGRATION_TARGET takes. Framework
+//is true when one of its projects targets the .NET Framework, LegacyProjects counts those that aren't SDK-style.
+type DotnetMigration struct {
+	Application      string                `json:"application"`
+	Projects         int                   `json:"projects"`
+	LegacyProjects   int                   `json:"legacyProjects"`
+	TargetFrameworks []string              `json:"targetFrameworks"`
+	Framework        bool                  `json:"framework"`
+	Findings         int                   `json:"findings"`
+	Effort           int                   `json:"effort"`
+	Blockers         []DotnetBlockerResult `json:"blockers"`
+}
+
+var DotnetBlockers = []DotnetBlocker{
+	{Name: "web-forms", Description: "ASP.NET Web Forms pages", Replacement: "Razor Pages or Blazor",
+		Tags: []string{"asp-web-form"}},
+	{Name: "system-web", Description: "System.Web, ASP.NET on IIS", Replacement: "ASP.NET Core, middleware instead of modules and handlers",
+		Tags: []string{"system-web", "asp", "mvc"}},
+	{Name: "wcf", Description: "WCF services and bindings", Replacement: "CoreWCF, gRPC or HTTP APIs",
+		Tags: []string{"windows-wcf"}},
+	{Name: "workflow", Description: "Windows Workflow Foundation", Replacement: "CoreWF or a workflow engine",
+		Tags: []string{"windows-workflow-service"}},
+	{Name: "remoting", Description: ".NET Remoting", Replacement: "gRPC or HTTP APIs",
+		Tags: []string{"remoting"}},
+	{Name: "app-domains", Description: "Application domains", Replacement: "AssemblyLoadContext or separate processes",
+		Tags: []string{"app-domain"}},
+	{Name: "enterprise-services", Description: "COM+ (Enterprise Services)", Replacement: "Plain classes and TransactionScope",
+		Tags: []string{"com+"}},
+	{Name: "system-drawing", Description: "System.Drawing (GDI+)", Replacement: "ImageSharp or SkiaSharp",
+		Tags: []string{"system-drawing"}},
+	{Name: "desktop", Description: "Windows Forms and WPF", Replacement: "net8.0-windows, which only runs on Windows, or a web front-end",
+		Tags: []string{"windows-desktop", "windows-forms", "windows-wpf"}},
+	{Name: "iis", Description: "IIS modules, ISAPI filters and IIS administration", Replacement: "Kestrel and ASP.NET Core middleware",
+		Tags: []string{"iis", "iis-module", "isapi-filter"}},
+	{Name: "registry", Description: "Windows registry", Replacement: "Configuration providers (environment, appsettings.json)",
+		Tags: []string{"windows-registry"}},
+	{Name: "windows-auth", Description: "Windows authentication and principals", Replacement: "OpenID Connect, or Negotiate authentication",
+		Tags: []string{"windows-auth", "windows-principal"}},
+	{Name: "windows-apis", Description: "Win32 P/Invoke, WMI, Windows services, event log and MSMQ", Replacement: "Portable .NET APIs, worker services and a message broker",
+		Tags: []string{"windows-interop", "wmi", "windows-service", "eventlog", "msmq"}},
+}
+
+//DetectDotnetProjects lists the .NET projects of the application with the frameworks they target. Projects in third
+//party code are skipped.
+func DetectDotnetProjects(runId uint, app *Application) []*DotnetProject {
+
+	detector := TechDetector{Files: dotnetProjectFiles}
+	contents := make(map[string]string)
+
+	var projects []*DotnetProject
+	for _, file := range app.Files {
+		if file.ThirdParty != "" || !detector.matchesFile(file.Name) {
+			continue
+		}
+
+		content := readDetectionFile(file.FQN, contents)
+		project := &DotnetProject{RunID: runId, Application: app.Name, Project: relativeEvidence(app, file)}
+		if match := projectSdk.FindStringSubmatch(content); match != nil {
+			project.Sdk = match[1]
+		}
+		project.TargetFrameworks = strings.Join(projectTargetFrameworks(content), ";")
+		projects = append(projects, project)
+	}
+
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Project < projects[j].Project })
+	return projects
+}
+
+//projectTargetFrameworks reads the target frameworks of a project file, those of SDK-style projects
+//(TargetFramework(s)) and legacy ones (TargetFrameworkVersion v4.7.2 => net472), once each
+func projectTargetFrameworks(content string) []string {
+
+	var frameworks []string
+	seen := make(map[string]bool)
+	add := func(framework string) {
+		if framework = strings.ToLower(strings.TrimSpace(framework)); framework != "" && !strings.Contains(framework, "$(") && !seen[framework] {
+			seen[framework] = true
+			frameworks = append(frameworks, framework)
+		}
+	}
+
+	for _, match := range targetFrameworks.FindAllStringSubmatch(content, -1) {
+		for _, framework := range strings.Split(match[1], ";") {
+			add(framework)
+		}
+	}
+	for _, match := range targetFrameworkVersion.FindAllStringSubmatch(content, -1) {
+		add("net" + strings.ReplaceAll(match[1], ".", ""))
+	}
+
+	return frameworks
+}
+
+//Frameworks returns the target frameworks of the project
+func (p *DotnetProject) Frameworks() []string {
+	if p.TargetFrameworks == "" {
+		return nil
+	}
+	return strings.Split(p.TargetFrameworks, ";")
+}
+
+//IsDotnetFramework is true for the target framework monikers of the .NET Framework (net48, net472, net35...), as
+//opposed to those of .NET (net8.0), .NET Core (netcoreapp3.1) and .NET Standard
+func IsDotnetFramework(framework string) bool {
+	return dotnetFrameworkMoniker.MatchString(strings.ToLower(framework))
+}
+
+//EvaluateDotnetMigration finds the blockers of migrating the application's projects to DOTNET_MIGRATION_TARGET from
+//the totals of its findings by tag
+func EvaluateDotnetMigration(app string, projects []DotnetProject, tagTotals TagTotals, blockers []DotnetBlocker) DotnetMigration {
+
+	//Rule tags aren't consistently cased
+	totals := make(TagTotals)
+	for tag, total := range tagTotals {
+		tag = strings.ToLower(tag)
+		totals[tag] = TagTotal{Findings: totals[tag].Findings + total.Findings, Effort: totals[tag].Effort + total.Effort}
+	}
+
+	migration := DotnetMigration{Application: app, TargetFrameworks: []string{}, Blockers: []DotnetBlockerResult{}}
+
+	seen := make(map[string]bool)
+	for _, project := range projects {
+		if project.Application != app {
+			continue
+		}
+		migration.Projects++
+		if project.Sdk == "" {
+			migration.LegacyProjects++
+		}
+		for _, framework := range project.Frameworks() {
+			if IsDotnetFramework(framework) {
+				migration.Framework = true
+			}
+			if !seen[framework] {
+				seen[framework] = true
+				migration.TargetFrameworks = append(migration.TargetFrameworks, framework)
+			}
+		}
+	}
+	sort.Strings(migration.TargetFrameworks)
+
+	for _, blocker := range blockers {
+		result := DotnetBlockerResult{Blocker: blocker.Name, Replacement: blocker.Replacement}
+		for _, tag := range blocker.Tags {
+			if total, found := totals[tag]; found && total.Findings > 0 {
+				result.Findings += total.Findings
+				result.Effort += total.Effort
+				result.Tags = append(result.Tags, tag)
+			}
+		}
+		sort.Strings(result.Tags)
+
+		if result.Findings > 0 {
+			migration.Findings += result.Findings
+			migration.Effort += result.Effort
+			migration.Blockers = append(migration.Blockers, result)
+		}
+	}
+
+	return migration
+}
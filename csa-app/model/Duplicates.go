@@ -0,0 +1,122 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+//DuplicateFinding is a finding occurring, identically, in several applications. I.E. a utility class or library copied
+//from app to app. Fixed once and shared, it takes the effort of a single application (DedupedEffort) rather than that
+//of every copy (Effort).
+type DuplicateFinding struct {
+	Rule          string   `json:"rule"`
+	Filename      string   `json:"filename"`
+	Value         string   `json:"value"`
+	Sha           string   `json:"sha"`
+	Findings      int      `json:"findings"`
+	Effort        int      `json:"effort"`
+	DedupedEffort int      `json:"dedupedEffort"`
+	Applications  []string `json:"applications"`
+}
+
+//DuplicateSummary rolls the portfolio's effort up counting each duplicate once
+type DuplicateSummary struct {
+	Findings          int `json:"findings"`
+	Effort            int `json:"effort"`
+	Duplicates        int `json:"duplicates"`
+	DuplicateFindings int `json:"duplicateFindings"`
+	DuplicateEffort   int `json:"duplicateEffort"`
+	DedupedEffort     int `json:"dedupedEffort"`
+}
+
+//Saved is the effort not spent fixing the copies of duplicates
+func (s DuplicateSummary) Saved() int {
+	return s.Effort - s.DedupedEffort
+}
+
+//FindDuplicates groups the findings by rule, file name and matched value, returning the groups found in at least
+//minApps applications, the most effort saved first, along with the portfolio's effort rollup. Files are compared by
+//name as copies rarely sit at the same path in each application, values without their surrounding whitespace.
+func FindDuplicates(findings []Finding, minApps int) ([]DuplicateFinding, DuplicateSummary) {
+
+	type group struct {
+		duplicate *DuplicateFinding
+		appEffort map[string]int
+	}
+
+	summary := DuplicateSummary{}
+	groups := make(map[string]*group)
+	var keys []string
+
+	for i := range findings {
+		finding := &findings[i]
+
+		summary.Findings++
+		summary.Effort += finding.Effort
+
+		value := strings.TrimSpace(finding.Value)
+		sum := sha256.Sum256([]byte(value))
+		sha := hex.EncodeToString(sum[:])
+
+		key := finding.Rule + "|" + finding.Filename + "|" + sha
+		g, found := groups[key]
+		if !found {
+			g = &group{duplicate: &DuplicateFinding{Rule: finding.Rule, Filename: finding.Filename, Value: value, Sha: sha},
+				appEffort: make(map[string]int)}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+
+		g.duplicate.Findings++
+		g.duplicate.Effort += finding.Effort
+		g.appEffort[finding.Application] += finding.Effort
+	}
+
+	var duplicates []DuplicateFinding
+	summary.DedupedEffort = summary.Effort
+
+	for _, key := range keys {
+		g := groups[key]
+		if len(g.appEffort) < minApps {
+			continue
+		}
+
+		for app, effort := range g.appEffort {
+			g.duplicate.Applications = append(g.duplicate.Applications, app)
+			if effort > g.duplicate.DedupedEffort {
+				g.duplicate.DedupedEffort = effort
+			}
+		}
+		sort.Strings(g.duplicate.Applications)
+
+		summary.Duplicates++
+		summary.DuplicateFindings += g.duplicate.Findings
+		summary.DuplicateEffort += g.duplicate.Effort
+		summary.DedupedEffort -= g.duplicate.Effort - g.duplicate.DedupedEffort
+
+		duplicates = append(duplicates, *g.duplicate)
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		saved := func(d DuplicateFinding) int { return d.Effort - d.DedupedEffort }
+		if saved(duplicates[i]) != saved(duplicates[j]) {
+			return saved(duplicates[i]) > saved(duplicates[j])
+		}
+		if len(duplicates[i].Applications) != len(duplicates[j].Applications) {
+			return len(duplicates[i].Applications) > len(duplicates[j].Applications)
+		}
+		if duplicates[i].Rule != duplicates[j].Rule {
+			return duplicates[i].Rule < duplicates[j].Rule
+		}
+		return duplicates[i].Filename < duplicates[j].Filename
+	})
+
+	return duplicates, summary
+}
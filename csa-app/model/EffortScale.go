@@ -0,0 +1,177 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"csa-app/util"
+	"gopkg.in/yaml.v2"
+)
+
+const FIBONACCI_EFFORT_SCALE = "fibonacci"
+const TSHIRT_EFFORT_SCALE = "t-shirt"
+
+//EffortScale re-expresses csa effort in an organization's own estimation language (story points, t-shirt sizes...).
+//It is applied when reports are exported and never changes the effort stored with findings.
+type EffortScale struct {
+	Name  string        `json:"name" yaml:"name"`
+	Unit  string        `json:"unit,omitempty" yaml:"unit,omitempty"`
+	Bands []*EffortBand `json:"bands" yaml:"bands"`
+}
+
+type EffortBand struct {
+	Start int    `json:"start" yaml:"start"`
+	End   int    `json:"end" yaml:"end"`
+	Value string `json:"value" yaml:"value"`
+}
+
+func PresetEffortScales() map[string]*EffortScale {
+	return map[string]*EffortScale{
+		FIBONACCI_EFFORT_SCALE: {Name: FIBONACCI_EFFORT_SCALE, Unit: "story points", Bands: []*EffortBand{
+			{Start: math.MinInt32, End: 0, Value: "0"},
+			{Start: 1, End: 1, Value: "1"},
+			{Start: 2, End: 2, Value: "2"},
+			{Start: 3, End: 3, Value: "3"},
+			{Start: 4, End: 5, Value: "5"},
+			{Start: 6, End: 7, Value: "8"},
+			{Start: 8, End: 9, Value: "13"},
+			{Start: 10, End: 12, Value: "21"},
+			{Start: 13, End: math.MaxInt32, Value: "34"}}},
+		TSHIRT_EFFORT_SCALE: {Name: TSHIRT_EFFORT_SCALE, Unit: "size", Bands: []*EffortBand{
+			{Start: math.MinInt32, End: 1, Value: "XS"},
+			{Start: 2, End: 3, Value: "S"},
+			{Start: 4, End: 6, Value: "M"},
+			{Start: 7, End: 9, Value: "L"},
+			{Start: 10, End: math.MaxInt32, Value: "XL"}}},
+	}
+}
+
+//LoadEffortScale returns the named preset or reads the scale from a yaml/json file
+func LoadEffortScale(nameOrPath string) (*EffortScale, error) {
+
+	if preset, found := PresetEffortScales()[strings.ToLower(nameOrPath)]; found {
+		return preset, nil
+	}
+
+	reader, err := os.Open(nameOrPath)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] is neither a preset (%s|%s) nor a readable effort scale file: %v", nameOrPath, FIBONACCI_EFFORT_SCALE, TSHIRT_EFFORT_SCALE, err)
+	}
+	defer reader.Close()
+
+	var decoder util.FileDecoder
+	if strings.HasSuffix(nameOrPath, util.JSON) {
+		decoder = json.NewDecoder(reader)
+	} else {
+		decoder = yaml.NewDecoder(reader)
+	}
+
+	scale := &EffortScale{}
+	if err = decoder.Decode(scale); err != nil {
+		return nil, fmt.Errorf("unable to decode effort scale [%s]: %v", nameOrPath, err)
+	}
+
+	return scale, scale.Validate()
+}
+
+func (s *EffortScale) Validate() error {
+
+	if s.Name == "" {
+		return fmt.Errorf("effort scale must have a name")
+	}
+
+	if len(s.Bands) == 0 {
+		return fmt.Errorf("effort scale [%s] must contain 1 or more bands", s.Name)
+	}
+
+	bands := make([]*EffortBand, len(s.Bands))
+	copy(bands, s.Bands)
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Start < bands[j].Start })
+
+	for i, band := range bands {
+		if band.Start > band.End {
+			return fmt.Errorf("effort scale [%s] band [%s] starts (%d) after it ends (%d)", s.Name, band.Value, band.Start, band.End)
+		}
+		if i > 0 && band.Start <= bands[i-1].End {
+			return fmt.Errorf("effort scale [%s] bands [%s] and [%s] overlap", s.Name, bands[i-1].Value, band.Value)
+		}
+	}
+
+	return nil
+}
+
+//Convert maps a single effort onto the scale. Efforts outside of every band are returned as is.
+func (s *EffortScale) Convert(effort int) string {
+	for _, band := range s.Bands {
+		if effort >= band.Start && effort <= band.End {
+			return band.Value
+		}
+	}
+	return strconv.Itoa(effort)
+}
+
+//IsNumeric is true when every band maps to a number, meaning converted efforts can be added together
+func (s *EffortScale) IsNumeric() bool {
+	for _, band := range s.Bands {
+		if _, err := strconv.ParseFloat(band.Value, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+//Total converts a count of findings per effort. Numeric scales are summed, otherwise the count per value is listed.
+func (s *EffortScale) Total(counts map[int]int) string {
+
+	if s.IsNumeric() {
+		total := 0.0
+		for effort, cnt := range counts {
+			value, _ := strconv.ParseFloat(s.Convert(effort), 64)
+			total += value * float64(cnt)
+		}
+		return strconv.FormatFloat(total, 'f', -1, 64)
+	}
+
+	totals := make(map[string]int)
+	for effort, cnt := range counts {
+		totals[s.Convert(effort)] += cnt
+	}
+
+	var parts []string
+	for _, band := range s.Bands {
+		if cnt, found := totals[band.Value]; found {
+			parts = append(parts, fmt.Sprintf("%s:%d", band.Value, cnt))
+			delete(totals, band.Value)
+		}
+	}
+
+	//Anything left fell outside of the bands
+	var others []string
+	for value := range totals {
+		others = append(others, value)
+	}
+	sort.Strings(others)
+	for _, value := range others {
+		parts = append(parts, fmt.Sprintf("%s:%d", value, totals[value]))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+//Label is the report column heading for converted effort
+func (s *EffortScale) Label() string {
+	if s.Unit != "" {
+		return s.Unit
+	}
+	return s.Name
+}
@@ -0,0 +1,164 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"csa-app/util"
+	"gopkg.in/yaml.v2"
+)
+
+const DEFAULT_ESTIMATION_MODEL = "default"
+
+//EstimationModel converts effort points into a range of person-days, and a cost when a day rate is given. How many
+//days a point takes depends on the category of the finding, categories without a factor use the default one.
+type EstimationModel struct {
+	Name       string                        `json:"name" yaml:"name"`
+	Currency   string                        `json:"currency,omitempty" yaml:"currency,omitempty"`
+	DayRate    float64                       `json:"dayRate,omitempty" yaml:"day-rate,omitempty"`
+	Default    ProductivityFactor            `json:"default" yaml:"default"`
+	Categories map[string]ProductivityFactor `json:"categories,omitempty" yaml:"categories,omitempty"`
+}
+
+//ProductivityFactor is the person-days an effort point takes, at best (MinDays) and at worst (MaxDays)
+type ProductivityFactor struct {
+	MinDays float64 `json:"minDays" yaml:"min-days"`
+	MaxDays float64 `json:"maxDays" yaml:"max-days"`
+}
+
+//Estimate is the person-days (and cost) range of the effort of an application's findings of a category. An empty
+//category is the application's total, an empty application the portfolio's.
+type Estimate struct {
+	Application string  `json:"application,omitempty"`
+	Category    string  `json:"category,omitempty"`
+	Effort      int     `json:"effort"`
+	MinDays     float64 `json:"minDays"`
+	MaxDays     float64 `json:"maxDays"`
+	MinCost     float64 `json:"minCost,omitempty"`
+	MaxCost     float64 `json:"maxCost,omitempty"`
+}
+
+//DefaultEstimationModel takes half an hour to an hour per effort point, without a cost
+func DefaultEstimationModel() *EstimationModel {
+	return &EstimationModel{Name: DEFAULT_ESTIMATION_MODEL, Default: ProductivityFactor{MinDays: 0.0625, MaxDays: 0.125}}
+}
+
+//LoadEstimationModel reads the model from a yaml/json file, an empty file returns the default model
+func LoadEstimationModel(file string) (*EstimationModel, error) {
+
+	if file == "" {
+		return DefaultEstimationModel(), nil
+	}
+
+	reader, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read estimation model [%s]: %v", file, err)
+	}
+	defer reader.Close()
+
+	var decoder util.FileDecoder
+	if strings.HasSuffix(file, util.JSON) {
+		decoder = json.NewDecoder(reader)
+	} else {
+		decoder = yaml.NewDecoder(reader)
+	}
+
+	estimationModel := &EstimationModel{}
+	if err = decoder.Decode(estimationModel); err != nil {
+		return nil, fmt.Errorf("unable to decode estimation model [%s]: %v", file, err)
+	}
+
+	return estimationModel, estimationModel.Validate()
+}
+
+func (m *EstimationModel) Validate() error {
+
+	if m.Name == "" {
+		return fmt.Errorf("estimation model must have a name")
+	}
+
+	if m.DayRate < 0 {
+		return fmt.Errorf("estimation model [%s] day rate [%v] must not be negative", m.Name, m.DayRate)
+	}
+
+	if err := m.Default.validate(m.Name, "default"); err != nil {
+		return err
+	}
+
+	for category, factor := range m.Categories {
+		if err := factor.validate(m.Name, category); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f ProductivityFactor) validate(name string, category string) error {
+	if f.MinDays < 0 || f.MaxDays < 0 {
+		return fmt.Errorf("estimation model [%s] factor [%s] days must not be negative", name, category)
+	}
+	if f.MinDays > f.MaxDays {
+		return fmt.Errorf("estimation model [%s] factor [%s] min days (%v) are above its max days (%v)", name, category, f.MinDays, f.MaxDays)
+	}
+	return nil
+}
+
+//Factor returns the category's productivity factor, the default one when it has none
+func (m *EstimationModel) Factor(category string) ProductivityFactor {
+	for name, factor := range m.Categories {
+		if strings.EqualFold(name, category) {
+			return factor
+		}
+	}
+	return m.Default
+}
+
+//Estimate converts the effort of an application by category into estimates, one per category ordered by name,
+//followed by the application's total
+func (m *EstimationModel) Estimate(app string, effortByCategory map[string]int) []Estimate {
+
+	categories := make([]string, 0, len(effortByCategory))
+	for category := range effortByCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	total := Estimate{Application: app}
+	estimates := make([]Estimate, 0, len(categories)+1)
+
+	for _, category := range categories {
+		factor := m.Factor(category)
+		effort := effortByCategory[category]
+		estimate := m.withCost(Estimate{Application: app, Category: category, Effort: effort,
+			MinDays: float64(effort) * factor.MinDays, MaxDays: float64(effort) * factor.MaxDays})
+		estimates = append(estimates, estimate)
+
+		total.Add(estimate)
+	}
+
+	return append(estimates, total)
+}
+
+func (m *EstimationModel) withCost(estimate Estimate) Estimate {
+	estimate.MinCost = estimate.MinDays * m.DayRate
+	estimate.MaxCost = estimate.MaxDays * m.DayRate
+	return estimate
+}
+
+//Add accumulates another estimate into this one
+func (e *Estimate) Add(other Estimate) {
+	e.Effort += other.Effort
+	e.MinDays += other.MinDays
+	e.MaxDays += other.MaxDays
+	e.MinCost += other.MinCost
+	e.MaxCost += other.MaxCost
+}
@@ -0,0 +1,66 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"csa-app/util"
+)
+
+//Exclusion suppresses a rule for an entire file ("match X unless Y"). The pattern (regex) is matched against the
+//file's contents (default) or, with a file target, against the file's path. I.E. skip test classes.
+type Exclusion struct {
+	ID            uint           `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt     time.Time      `json:"-" yaml:"-"`
+	UpdatedAt     time.Time      `json:"-" yaml:"-"`
+	RuleID        uint           `sql:"type:bigint REFERENCES rules(id) ON DELETE CASCADE" json:"-"  yaml:"-"`
+	Rule          Rule           `gorm:"foreignkey:RuleID" json:"-"  yaml:"-"`
+	Target        string         `gorm:"type:text" json:"target,omitempty" yaml:"target,omitempty"`
+	Pattern       string         `gorm:"type:text" json:"pattern" yaml:"pattern"`
+	compiledRegex *regexp.Regexp `gorm:"-" json:"-" yaml:"-"`
+}
+
+func (e *Exclusion) IsValid() error {
+
+	if e.Target != "" && e.Target != FILE_TARGET && e.Target != CONTENTS_TARGET {
+		return fmt.Errorf("Exclusion Target must be either (%s|%s) but was: %s", FILE_TARGET, CONTENTS_TARGET, e.Target)
+	}
+
+	if e.Pattern == "" {
+		return fmt.Errorf("Exclusion Pattern is required!")
+	}
+
+	if _, err := regexp.Compile(e.Pattern); err != nil {
+		return fmt.Errorf("Exclusion Pattern [%s] is not a valid regex! Details: %v", e.Pattern, err)
+	}
+
+	return nil
+}
+
+func (e *Exclusion) compile() {
+	e.compiledRegex = util.MustCompileRegex(e.Pattern)
+}
+
+func (e *Exclusion) NeedsContents() bool {
+	return e.Target != FILE_TARGET
+}
+
+//Excludes is true when the exclusion applies to the file. contents is only consulted for content exclusions.
+func (e *Exclusion) Excludes(fqn string, contents []byte) bool {
+	if e.compiledRegex == nil {
+		e.compile()
+	}
+
+	if e.Target == FILE_TARGET {
+		return e.compiledRegex.MatchString(filepath.ToSlash(fqn))
+	}
+
+	return e.compiledRegex.Match(contents)
+}
@@ -0,0 +1,126 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+//Globs is a list of path patterns stored as a single (newline delimited) column
+type Globs []string
+
+func (g Globs) Value() (driver.Value, error) {
+	return strings.Join(g, "\n"), nil
+}
+
+func (g *Globs) Scan(value interface{}) error {
+
+	var joined string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		joined = v
+	case []byte:
+		joined = string(v)
+	default:
+		return fmt.Errorf("unable to scan %T into Globs", value)
+	}
+
+	*g = nil
+	if joined != "" {
+		*g = strings.Split(joined, "\n")
+	}
+
+	return nil
+}
+
+func (g Globs) Matches(relPath string) bool {
+	for _, pattern := range g {
+		if matchPathPattern(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+//AppliesToPath checks the rule's file conditions (paths, excludepaths, minsize, maxsize and maxdepth) against a file
+//of the application rooted at appPath. The file is only stat'ed when the rule has a size condition.
+func (r *Rule) AppliesToPath(appPath string, fqn string) bool {
+
+	if !r.hasFileConditions() {
+		return true
+	}
+
+	relPath := filepath.Base(fqn)
+	if rel, err := filepath.Rel(appPath, fqn); err == nil && rel != "." {
+		relPath = filepath.ToSlash(rel)
+	}
+
+	if len(r.Paths) > 0 && !r.Paths.Matches(relPath) {
+		return false
+	}
+
+	if r.ExcludePaths.Matches(relPath) {
+		return false
+	}
+
+	if r.MaxDepth > 0 && strings.Count(relPath, "/") > r.MaxDepth {
+		return false
+	}
+
+	if r.MinSize > 0 || r.MaxSize > 0 {
+		info, err := os.Stat(fqn)
+		if err != nil {
+			return false
+		}
+		if info.Size() < r.MinSize || (r.MaxSize > 0 && info.Size() > r.MaxSize) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) hasFileConditions() bool {
+	return len(r.Paths) > 0 || len(r.ExcludePaths) > 0 || r.MinSize > 0 || r.MaxSize > 0 || r.MaxDepth > 0
+}
+
+func (r *Rule) sameFileConditions(other *Rule) bool {
+	return strings.Join(r.Paths, "\n") == strings.Join(other.Paths, "\n") &&
+		strings.Join(r.ExcludePaths, "\n") == strings.Join(other.ExcludePaths, "\n") &&
+		r.MinSize == other.MinSize && r.MaxSize == other.MaxSize && r.MaxDepth == other.MaxDepth
+}
+
+func (r *Rule) fileConditionsAreValid() error {
+
+	for _, patterns := range []Globs{r.Paths, r.ExcludePaths} {
+		for _, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("Rule path patterns cannot be empty")
+			}
+			for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+				if _, err := path.Match(segment, ""); err != nil {
+					return fmt.Errorf("Rule path pattern [%s] is invalid! Details: %v", pattern, err)
+				}
+			}
+		}
+	}
+
+	if r.MinSize < 0 || r.MaxSize < 0 || r.MaxDepth < 0 {
+		return fmt.Errorf("Rule MinSize, MaxSize and MaxDepth cannot be negative")
+	}
+
+	if r.MaxSize > 0 && r.MinSize > r.MaxSize {
+		return fmt.Errorf("Rule MinSize [%d] cannot be larger than MaxSize [%d]", r.MinSize, r.MaxSize)
+	}
+
+	return nil
+}
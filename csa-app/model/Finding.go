@@ -6,6 +6,8 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"strings"
 	"time"
 )
@@ -30,11 +32,24 @@ type Finding struct {
 	Value       string          `gorm:"type:text;"`
 	Note        string          `gorm:"type:text;" json:",omitempty" yaml:",omitempty"`
 	Advice      string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Context     string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	LineSha     string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`   //sha256 of the full matched line
+	EndLine     int             `gorm:"type:bigint" json:",omitempty" yaml:",omitempty"` //Last line of a multiline match
 	Effort      int             `gorm:"type:bigint" json:"effort" yaml:"effort"`
 	Readiness   int             `gorm:"type:bigint" json:"readiness" yaml:"readiness,omitempty"`
 	Category    string          `gorm:"index;not null" json:",omitempty" yaml:",omitempty"`
 	Criticality string          `gorm:"index;not null" json:",omitempty" yaml:",omitempty"`
+	Severity    string          `gorm:"type:text;index" json:",omitempty" yaml:",omitempty"`
+	Confidence  string          `gorm:"type:text;index" json:",omitempty" yaml:",omitempty"`
 	Application string          `gorm:"index;not null" json:",omitempty" yaml:",omitempty"`
+	ThirdParty  string          `gorm:"type:text;index" json:",omitempty" yaml:",omitempty"`
+	Module      string          `gorm:"type:text;index" json:",omitempty" yaml:",omitempty"` //Module of a multi-module application
+	Lifecycle   string          `gorm:"type:text;index" json:",omitempty" yaml:",omitempty"`
+	PreviousID  uint            `gorm:"index" json:",omitempty" yaml:",omitempty"`
+	TechVersion string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Version of the technology the finding is about
+	Triage      string          `gorm:"type:text;index" json:",omitempty" yaml:",omitempty"`
+	TriageNote  string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Why the finding was triaged
+	TriagedAt   *time.Time      `json:",omitempty" yaml:",omitempty"`
 	Tags        []FindingTag    `gorm:"foreignkey:FindingID" json:",omitempty" yaml:",omitempty"`
 	Recipes     []FindingRecipe `gorm:"foreignkey:FindingID" json:",omitempty" yaml:",omitempty"`
 	Result      string           `gorm:"type:text;"`
@@ -52,12 +67,22 @@ type FindingDTO struct {
 	Value       string   `json:"value" yaml:"value"`
 	Advice      string   `json:"advice" yaml:"advice"`
 	Note        string   `json:"note,omitempty" yaml:"note,omitempty"`
+	Context     string   `json:"context,omitempty" yaml:"context,omitempty"`
+	Sha         string   `json:"sha,omitempty" yaml:"sha,omitempty"`
+	EndLine     int      `json:"endLine,omitempty" yaml:"endLine,omitempty"`
 	Level       string   `json:"level" yaml:"level"`
 	Effort      int      `json:"effort" yaml:"effort"`
 	Readiness   int      `json:"readiness" yaml:"readiness,omitempty"`
 	Category    string   `json:"category" yaml:"category,omitempty"`
 	Criticality string   `json:"criticality" yaml:"criticality,omitempty"`
+	Severity    string   `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Confidence  string   `json:"confidence,omitempty" yaml:"confidence,omitempty"`
 	Application string   `json:"application" yaml:"domain,omitempty"`
+	ThirdParty  string   `json:"thirdParty,omitempty" yaml:"thirdParty,omitempty"`
+	Lifecycle   string   `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	TechVersion string   `json:"techVersion,omitempty" yaml:"techVersion,omitempty"`
+	Triage      string   `json:"triage,omitempty" yaml:"triage,omitempty"`
+	TriageNote  string   `json:"triageNote,omitempty" yaml:"triageNote,omitempty"`
 	Tags        []string `json:"tags" yaml:"tags,omitempty"`
 	Recipes     []string `json:"recipes" yaml:"recipes,omitempty"`
 }
@@ -70,6 +95,21 @@ func (f *Finding) SetValue(value string) {
 	}
 }
 
+//HashLine is the sha256 of a matched line, so the same finding can be recognized across runs and files
+func HashLine(line string) string {
+	sum := sha256.Sum256([]byte(line))
+	return hex.EncodeToString(sum[:])
+}
+
+//Sha is the hash of the finding's full matched line. Findings recorded before lines were hashed fall back to the hash
+//of their (truncated) value.
+func (f *Finding) Sha() string {
+	if f.LineSha != "" {
+		return f.LineSha
+	}
+	return HashLine(f.Value)
+}
+
 func (f *Finding) AddRecipe(recipe string) {
 	f.Recipes = append(f.Recipes, FindingRecipe{URI: recipe})
 }
@@ -101,11 +141,21 @@ func (f *Finding) CreateDTO() *FindingDTO {
 	dto.Category = f.Category
 	dto.Note = f.Note
 	dto.Advice = f.Advice
+	dto.Context = f.Context
+	dto.Sha = f.LineSha
+	dto.EndLine = f.EndLine
 	dto.Effort = f.Effort
 	dto.Readiness = f.Readiness
 	dto.Value = f.Value
 	dto.Pattern = f.Pattern
 	dto.Criticality = f.Criticality
+	dto.Severity = f.Severity
+	dto.Confidence = f.Confidence
+	dto.ThirdParty = f.ThirdParty
+	dto.Lifecycle = f.Lifecycle
+	dto.TechVersion = f.TechVersion
+	dto.Triage = f.Triage
+	dto.TriageNote = f.TriageNote
 
 	for _, tag := range f.Tags {
 		dto.AddTag(tag.Value)
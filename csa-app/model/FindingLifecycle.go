@@ -0,0 +1,86 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+const FINDING_NEW = "new"
+const FINDING_RECURRING = "recurring"
+const FINDING_RESOLVED = "resolved"
+
+//LifecycleMatch is the outcome of matching an application's findings against the same application in a previous run
+type LifecycleMatch struct {
+	Previous map[uint]uint //current finding id => matched previous finding id
+	Resolved []Finding     //previous findings without a match in the current run
+}
+
+//MatchFindings pairs current findings with previous ones. Findings match when they come from the same rule and file
+//(relative to the application root) and either sit within lineTolerance lines of each other or matched the same value.
+//Identical values are preferred over nearby lines so code that merely moved is still recognized.
+func MatchFindings(previous []Finding, previousRoot string, current []Finding, currentRoot string, lineTolerance int) *LifecycleMatch {
+
+	match := &LifecycleMatch{Previous: make(map[uint]uint)}
+
+	candidates := make(map[string][]*Finding)
+	for i := range previous {
+		key := lifecycleKey(&previous[i], previousRoot)
+		candidates[key] = append(candidates[key], &previous[i])
+	}
+
+	matched := make(map[uint]bool)
+
+	for i := range current {
+		finding := &current[i]
+
+		var best *Finding
+		bestDistance := 0
+		bestSameValue := false
+
+		for _, candidate := range candidates[lifecycleKey(finding, currentRoot)] {
+			if matched[candidate.ID] {
+				continue
+			}
+
+			distance := finding.Line - candidate.Line
+			if distance < 0 {
+				distance = -distance
+			}
+			sameValue := finding.Value == candidate.Value
+
+			if !sameValue && distance > lineTolerance {
+				continue
+			}
+
+			if best == nil || (sameValue && !bestSameValue) || (sameValue == bestSameValue && distance < bestDistance) {
+				best, bestDistance, bestSameValue = candidate, distance, sameValue
+			}
+		}
+
+		if best != nil {
+			matched[best.ID] = true
+			match.Previous[finding.ID] = best.ID
+		}
+	}
+
+	for _, finding := range previous {
+		if !matched[finding.ID] {
+			match.Resolved = append(match.Resolved, finding)
+		}
+	}
+
+	return match
+}
+
+func lifecycleKey(finding *Finding, root string) string {
+	path := finding.Fqn
+	if rel, err := filepath.Rel(root, finding.Fqn); root != "" && err == nil && !strings.HasPrefix(rel, "..") {
+		path = rel
+	}
+	return finding.Rule + "|" + filepath.ToSlash(path)
+}
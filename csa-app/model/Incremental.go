@@ -0,0 +1,169 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+//IncrementalBaseline is what the last scan of an application at the same path found: the hash of each file it read
+//and the findings of each file. Incremental runs carry the findings of the files that haven't changed since forward
+//instead of analyzing them again.
+type IncrementalBaseline struct {
+	RunID     uint
+	entries   map[string]ManifestEntry
+	findings  map[string][]Finding
+	appImpact map[string]bool
+}
+
+//NewIncrementalBaseline indexes the scan manifest and the findings of the earlier run. Files it couldn't read
+//(encrypted...) aren't part of the baseline, so they are always analyzed again. Findings of the app's composite rules
+//are left out, they are evaluated again once every file has been analyzed.
+func NewIncrementalBaseline(runId uint, manifest []ManifestEntry, findings []Finding, rules []Rule) *IncrementalBaseline {
+
+	baseline := &IncrementalBaseline{
+		RunID:     runId,
+		entries:   make(map[string]ManifestEntry),
+		findings:  make(map[string][]Finding),
+		appImpact: appImpactRules(rules),
+	}
+
+	composites := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.IsComposite() {
+			composites[rule.Name] = true
+		}
+	}
+
+	for _, entry := range manifest {
+		if entry.Inaccessible == "" && entry.Sha256 != "" {
+			baseline.entries[entry.Path] = entry
+		}
+	}
+
+	for _, finding := range findings {
+		if composites[finding.Rule] {
+			continue
+		}
+		baseline.findings[finding.Fqn] = append(baseline.findings[finding.Fqn], finding)
+	}
+
+	return baseline
+}
+
+//Unchanged returns the baseline's entry of the file when its content is the same as when the baseline was scanned
+func (baseline *IncrementalBaseline) Unchanged(entry *ManifestEntry) (ManifestEntry, bool) {
+	previous, found := baseline.entries[entry.Path]
+	return previous, found && entry.Sha256 != "" && previous.Sha256 == entry.Sha256 && previous.Size == entry.Size
+}
+
+//CarryForward copies the baseline's findings of the file into the run. The copies are new findings (their lifecycle
+//is tracked again) carrying the same tags and recipes.
+func (baseline *IncrementalBaseline) CarryForward(runId uint, fqn string) []Finding {
+
+	var carried []Finding
+
+	for _, finding := range baseline.findings[fqn] {
+		finding.ID = 0
+		finding.CreatedAt = time.Time{}
+		finding.UpdatedAt = time.Time{}
+		finding.RunID = runId
+		finding.Lifecycle = ""
+		finding.PreviousID = 0
+
+		tags := make([]FindingTag, 0, len(finding.Tags))
+		for _, tag := range finding.Tags {
+			tags = append(tags, FindingTag{Value: tag.Value})
+		}
+		finding.Tags = tags
+
+		recipes := make([]FindingRecipe, 0, len(finding.Recipes))
+		for _, recipe := range finding.Recipes {
+			recipes = append(recipes, FindingRecipe{URI: recipe.URI})
+		}
+		finding.Recipes = recipes
+
+		carried = append(carried, finding)
+	}
+
+	return carried
+}
+
+//ImpactsApp is true for the rules whose effort counts once per application, on their first match
+func (baseline *IncrementalBaseline) ImpactsApp(rule string) bool {
+	return baseline.appImpact[rule]
+}
+
+//appImpactRules are the rules whose effort counts once per application
+func appImpactRules(rules []Rule) map[string]bool {
+	appImpact := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Impact == APP_IMPACT {
+			appImpact[rule.Name] = true
+		}
+	}
+	return appImpact
+}
+
+//RulesFingerprint identifies the rules an application is analyzed with and the options weighing their findings. Each
+//part is recorded as <part>=<hash>, so an incremental run can tell which of them changed since its baseline.
+func RulesFingerprint(run *Run, rules []Rule, minConfidence string) string {
+
+	ordered := make([]*Rule, len(rules))
+	for i := range rules {
+		ordered[i] = &rules[i]
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	definitions, _ := json.Marshal(ordered)
+
+	var profile []byte
+	if run.Profile != nil {
+		profile, _ = json.Marshal(run.Profile)
+	}
+
+	return strings.Join([]string{
+		"rules=" + fingerprintOf(string(definitions)),
+		"rule-overrides=" + fingerprintOf(run.RuleOverrides),
+		"tag-weights=" + fingerprintOf(run.TagWeights),
+		"profile=" + fingerprintOf(string(profile)),
+		"min-confidence=" + minConfidence,
+	}, " ")
+}
+
+func fingerprintOf(value string) string {
+	if value == "" {
+		return "none"
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+//FingerprintChanges names the parts of the current rules fingerprint that differ from the previous one
+func FingerprintChanges(previous string, current string) (changed []string) {
+
+	recorded := make(map[string]string)
+	for _, part := range strings.Fields(previous) {
+		if i := strings.Index(part, "="); i > 0 {
+			recorded[part[:i]] = part[i+1:]
+		}
+	}
+
+	for _, part := range strings.Fields(current) {
+		if i := strings.Index(part, "="); i > 0 && recorded[part[:i]] != part[i+1:] {
+			changed = append(changed, part[:i])
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,233 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Findings of APIs removed from (or encapsulated by) the target JDK
+const JDK_REMOVED_CATEGORY = "jdk-removed"
+
+//Findings of APIs deprecated, but still present, in the target JDK
+const JDK_DEPRECATED_CATEGORY = "jdk-deprecated"
+
+//Every rule generated from the catalog is tagged
+const JDK_TAG = "jdk"
+
+//Rules generated from the catalog are named jdk-<api>
+const JDK_RULE_PREFIX = "jdk-"
+
+//Oldest JDK an upgrade can target
+const MIN_TARGET_JDK = 8
+
+//JdkApi is a JDK API that was deprecated and/or removed by a JDK release
+type JdkApi struct {
+	Name        string //I.E. jaxb, names the generated rule jdk-jaxb
+	Description string
+	Pattern     string //Regex matched against the lines of java files
+	Deprecated  int    //Release the API was deprecated in, 0 if it never was
+	Removed     int    //Release the API was removed (or strongly encapsulated) in, 0 if it still ships
+	Replacement string
+	Effort      int
+}
+
+//JdkApiCatalog is the built-in catalog of the JDK APIs an upgrade most often trips over
+var JdkApiCatalog = []JdkApi{
+	{Name: "jaxb", Description: "JAXB (javax.xml.bind)", Pattern: `\bjavax\.xml\.bind\b`, Deprecated: 9, Removed: 11,
+		Replacement: "Add jakarta.xml.bind-api and a JAXB runtime (I.E. org.glassfish.jaxb:jaxb-runtime) as dependencies", Effort: 20},
+	{Name: "jax-ws", Description: "JAX-WS and SAAJ (javax.xml.ws, javax.jws, javax.xml.soap)", Pattern: `\bjavax\.(xml\.ws|jws|xml\.soap)\b`, Deprecated: 9, Removed: 11,
+		Replacement: "Add jakarta.xml.ws-api and a JAX-WS runtime (I.E. com.sun.xml.ws:jaxws-rt) as dependencies", Effort: 20},
+	{Name: "activation", Description: "JavaBeans Activation Framework (javax.activation)", Pattern: `\bjavax\.activation\b`, Deprecated: 9, Removed: 11,
+		Replacement: "Add jakarta.activation-api as a dependency", Effort: 5},
+	{Name: "common-annotations", Description: "Common Annotations (javax.annotation)", Pattern: `\bjavax\.annotation\.(PostConstruct|PreDestroy|Resources?|Generated)\b`, Deprecated: 9, Removed: 11,
+		Replacement: "Add jakarta.annotation-api as a dependency", Effort: 5},
+	{Name: "corba", Description: "CORBA (org.omg, javax.rmi.CORBA, javax.activity)", Pattern: `\b(org\.omg\.(CORBA|CosNaming|PortableServer|PortableInterceptor|DynamicAny|IOP|Messaging)|javax\.rmi\.CORBA|javax\.activity)\b`, Deprecated: 9, Removed: 11,
+		Replacement: "The JDK has no replacement. Move to REST/gRPC or a standalone ORB (I.E. GlassFish CORBA)", Effort: 50},
+	{Name: "javafx", Description: "JavaFX (javafx)", Pattern: `\bjavafx\.[a-z]`, Removed: 11,
+		Replacement: "Add the OpenJFX modules (org.openjfx) as dependencies", Effort: 10},
+	{Name: "run-finalizers-on-exit", Description: "Runtime/System.runFinalizersOnExit", Pattern: `\brunFinalizersOnExit\s*\(`, Deprecated: 2, Removed: 11,
+		Replacement: "Use shutdown hooks (Runtime.addShutdownHook)", Effort: 3},
+	{Name: "sun-misc-base64", Description: "sun.misc.BASE64Encoder/BASE64Decoder", Pattern: `\bsun\.misc\.BASE64(En|De)coder\b`, Removed: 9,
+		Replacement: "Use java.util.Base64", Effort: 3},
+	{Name: "javadoc-doclet", Description: "Old doclet API (com.sun.javadoc)", Pattern: `\bcom\.sun\.javadoc\b`, Deprecated: 9, Removed: 13,
+		Replacement: "Use the jdk.javadoc.doclet API", Effort: 10},
+	{Name: "pack200", Description: "Pack200 (java.util.jar.Pack200)", Pattern: `\bjava\.util\.jar\.Pack200\b`, Deprecated: 11, Removed: 14,
+		Replacement: "Use jar/zip compression or an external Pack200 library", Effort: 5},
+	{Name: "nashorn", Description: "Nashorn JavaScript engine (jdk.nashorn)", Pattern: `\bjdk\.nashorn\.|getEngineByName\(\s*"(nashorn|Nashorn|javascript|JavaScript|js)"\s*\)`, Deprecated: 11, Removed: 15,
+		Replacement: "Use GraalJS or the standalone Nashorn (org.openjdk.nashorn:nashorn-core)", Effort: 20},
+	{Name: "rmi-activation", Description: "RMI Activation (java.rmi.activation)", Pattern: `\bjava\.rmi\.activation\b`, Deprecated: 15, Removed: 17,
+		Replacement: "Use another remoting technology (I.E. REST/gRPC)", Effort: 20},
+	{Name: "internals", Description: "JDK internal APIs (sun.*, com.sun.*)", Pattern: `\b(sun\.(security|nio\.ch|reflect|net|awt|font|util|rmi|tools)|com\.sun\.(org\.apache|crypto\.provider|jndi\.ldap|net\.ssl\.internal|tools\.javac))\.`, Deprecated: 9, Removed: 17,
+		Replacement: "Use the supported API. As a last resort open the packages with --add-opens/--add-exports", Effort: 10},
+	{Name: "security-manager", Description: "Security Manager (java.lang.SecurityManager)", Pattern: `\b(System\.(get|set)SecurityManager|java\.lang\.SecurityManager|AccessController\.doPrivileged)\b`, Deprecated: 17, Removed: 24,
+		Replacement: "Remove the security manager. Isolate the application with the platform (containers, network policies) instead", Effort: 10},
+	{Name: "applet", Description: "Applet API (java.applet, javax.swing.JApplet)", Pattern: `\b(java\.applet|javax\.swing\.JApplet)\b`, Deprecated: 9,
+		Replacement: "Rewrite the applet as a web or desktop application", Effort: 20},
+	{Name: "finalize", Description: "Object.finalize", Pattern: `\bvoid\s+finalize\s*\(\s*\)`, Deprecated: 9,
+		Replacement: "Use try-with-resources or java.lang.ref.Cleaner", Effort: 3},
+	{Name: "wrapper-constructors", Description: "Primitive wrapper constructors (new Integer(..))", Pattern: `\bnew\s+(Integer|Long|Short|Byte|Double|Float|Character|Boolean)\s*\(`, Deprecated: 9,
+		Replacement: "Use valueOf (I.E. Integer.valueOf) or autoboxing", Effort: 1},
+	{Name: "thread-stop", Description: "Thread.stop/suspend/resume", Pattern: `\b(Thread\.currentThread\(\)|[Tt]hread)\.(stop|suspend|resume)\(\s*\)`, Deprecated: 2, Removed: 20,
+		Replacement: "Stop threads cooperatively, I.E. with Thread.interrupt", Effort: 5},
+}
+
+//JdkUsage is the use of a catalog API by an application
+type JdkUsage struct {
+	Application string `json:"application"`
+	Api         string `json:"api"`
+	Description string `json:"description"`
+	Status      string `json:"status"` //removed|deprecated
+	Release     int    `json:"release"`
+	Findings    int    `json:"findings"`
+	Files       int    `json:"files"`
+	Effort      int    `json:"effort"`
+	Replacement string `json:"replacement"`
+}
+
+//ValidateTargetJdk checks the target is a JDK feature release. 0 disables the catalog.
+func ValidateTargetJdk(target int) error {
+	if target != 0 && target < MIN_TARGET_JDK {
+		return fmt.Errorf("Target JDK [%d] is unsupported, the oldest JDK targeted is %d", target, MIN_TARGET_JDK)
+	}
+	return nil
+}
+
+//Status of the API in the target JDK, empty when the target is unaffected by it. The release is the one that
+//deprecated or removed it.
+func (api *JdkApi) Status(target int) (status string, release int) {
+	switch {
+	case api.Removed > 0 && api.Removed <= target:
+		return JDK_REMOVED_CATEGORY, api.Removed
+	case api.Deprecated > 0 && api.Deprecated <= target:
+		return JDK_DEPRECATED_CATEGORY, api.Deprecated
+	}
+	return "", 0
+}
+
+//Advice given with the API's findings when targeting the JDK
+func (api *JdkApi) Advice(target int) string {
+	status, release := api.Status(target)
+	if status == JDK_REMOVED_CATEGORY {
+		return fmt.Sprintf("%s was removed in JDK %s. %s", api.Description, JdkRelease(release), api.Replacement)
+	}
+	if api.Removed > 0 {
+		return fmt.Sprintf("%s is deprecated since JDK %s and removed in JDK %s. %s", api.Description, JdkRelease(release), JdkRelease(api.Removed), api.Replacement)
+	}
+	return fmt.Sprintf("%s is deprecated since JDK %s. %s", api.Description, JdkRelease(release), api.Replacement)
+}
+
+//JdkRelease formats a release the way it is referred to. Releases before 5 kept the 1.x numbering.
+func JdkRelease(release int) string {
+	if release < 5 {
+		return fmt.Sprintf("1.%d", release)
+	}
+	return strconv.Itoa(release)
+}
+
+//Rule matching the API's use in java (and Kotlin, Scala) files. Removed APIs break the upgrade (high severity), deprecated ones only
+//warn (low severity, a fraction of the effort).
+func (api *JdkApi) Rule(target int) Rule {
+	status, _ := api.Status(target)
+
+	severity, effort := "high", api.Effort
+	if status == JDK_DEPRECATED_CATEGORY {
+		severity, effort = "low", (api.Effort+4)/5
+	}
+
+	return Rule{
+		Name:     JDK_RULE_PREFIX + api.Name,
+		FileType: "(java|kt|scala)$",
+		Target:   LINE_TARGET,
+		Type:     REGEX_MATCH_TYPE,
+		Advice:   api.Advice(target),
+		Effort:   effort,
+		Category: status,
+		Severity: severity,
+		Tags:     []Tag{{Value: JDK_TAG}, {Value: status}},
+		Patterns: []Pattern{{Value: api.Pattern, Tag: api.Name}},
+	}
+}
+
+//JdkRules generates a rule for every catalog API deprecated or removed by the target JDK
+func JdkRules(target int) []Rule {
+	var rules []Rule
+	for i := range JdkApiCatalog {
+		if status, _ := JdkApiCatalog[i].Status(target); status != "" {
+			rules = append(rules, JdkApiCatalog[i].Rule(target))
+		}
+	}
+	return rules
+}
+
+//JdkApiByRule returns the catalog API a generated rule matches
+func JdkApiByRule(rule string) *JdkApi {
+	if !strings.HasPrefix(rule, JDK_RULE_PREFIX) {
+		return nil
+	}
+	for i := range JdkApiCatalog {
+		if JDK_RULE_PREFIX+JdkApiCatalog[i].Name == rule {
+			return &JdkApiCatalog[i]
+		}
+	}
+	return nil
+}
+
+//SummarizeJdkUsage summarizes the findings of the catalog rules by application and API, the removed APIs of each
+//application first
+func SummarizeJdkUsage(findings []Finding, target int) []JdkUsage {
+
+	usages := make(map[string]*JdkUsage)
+	files := make(map[string]map[string]bool)
+
+	for i := range findings {
+		api := JdkApiByRule(findings[i].Rule)
+		if api == nil {
+			continue
+		}
+		status, release := api.Status(target)
+		if status == "" {
+			continue
+		}
+
+		key := findings[i].Application + "/" + api.Name
+		usage, found := usages[key]
+		if !found {
+			usage = &JdkUsage{Application: findings[i].Application, Api: api.Name, Description: api.Description,
+				Status: strings.TrimPrefix(status, JDK_RULE_PREFIX), Release: release, Replacement: api.Replacement}
+			usages[key] = usage
+			files[key] = make(map[string]bool)
+		}
+		usage.Findings++
+		usage.Effort += findings[i].Effort
+		files[key][findings[i].Fqn] = true
+	}
+
+	result := make([]JdkUsage, 0, len(usages))
+	for key, usage := range usages {
+		usage.Files = len(files[key])
+		result = append(result, *usage)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Application != b.Application {
+			return a.Application < b.Application
+		}
+		if a.Status != b.Status {
+			return a.Status == "removed"
+		}
+		if a.Findings != b.Findings {
+			return a.Findings > b.Findings
+		}
+		return a.Api < b.Api
+	})
+
+	return result
+}
@@ -0,0 +1,123 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+)
+
+//Findings of the Kubernetes manifest and Helm chart rules are tagged kubernetes, apart from the application's code
+const KUBERNETES_TAG = "kubernetes"
+
+//Issue of the findings of kubernetes rules that aren't one of KubernetesIssues
+const KUBERNETES_OTHER_ISSUE = "other"
+
+//KubernetesIssue is an issue of Kubernetes manifests, found by the rules flagging it
+type KubernetesIssue struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Rules       []string `json:"rules"`
+}
+
+//KubernetesIssueResult is an issue found in a manifest, on the given lines
+type KubernetesIssueResult struct {
+	Issue    string `json:"issue"`
+	Findings int    `json:"findings"`
+	Effort   int    `json:"effort"`
+	Lines    []int  `json:"lines,omitempty"`
+}
+
+//KubernetesManifest is a Kubernetes manifest (or Helm chart template, values) of an application and its issues
+type KubernetesManifest struct {
+	Application string                  `json:"application"`
+	Manifest    string                  `json:"manifest"`
+	Findings    int                     `json:"findings"`
+	Effort      int                     `json:"effort"`
+	Issues      []KubernetesIssueResult `json:"issues"`
+}
+
+var KubernetesIssues = []KubernetesIssue{
+	{Name: "resource-limits", Description: "Containers without CPU and memory limits", Rules: []string{"k8s-resource-limits"}},
+	{Name: "host-path", Description: "hostPath volumes, tying pods to the node's filesystem", Rules: []string{"k8s-host-path"}},
+	{Name: "privileged", Description: "Privileged containers and host namespaces", Rules: []string{"k8s-privileged"}},
+	{Name: "image-registry", Description: "Images pulled from a hard-coded registry", Rules: []string{"k8s-image-registry"}},
+}
+
+//GroupKubernetesFindings groups the findings of the kubernetes rules by manifest and issue, the manifests with the
+//most effort first. Findings of rules not in issues are grouped under KUBERNETES_OTHER_ISSUE.
+func GroupKubernetesFindings(findings []Finding, issues []KubernetesIssue) []KubernetesManifest {
+
+	issueByRule := make(map[string]string)
+	for _, issue := range issues {
+		for _, rule := range issue.Rules {
+			issueByRule[rule] = issue.Name
+		}
+	}
+
+	order := make(map[string]int)
+	for i, issue := range issues {
+		order[issue.Name] = i
+	}
+	order[KUBERNETES_OTHER_ISSUE] = len(issues)
+
+	manifests := make(map[string]*KubernetesManifest)
+	var keys []string
+	for _, finding := range findings {
+		key := finding.Application + "|" + finding.Fqn
+		manifest, found := manifests[key]
+		if !found {
+			manifest = &KubernetesManifest{Application: finding.Application, Manifest: finding.Fqn, Issues: []KubernetesIssueResult{}}
+			manifests[key] = manifest
+			keys = append(keys, key)
+		}
+
+		issue, found := issueByRule[finding.Rule]
+		if !found {
+			issue = KUBERNETES_OTHER_ISSUE
+		}
+
+		var result *KubernetesIssueResult
+		for i := range manifest.Issues {
+			if manifest.Issues[i].Issue == issue {
+				result = &manifest.Issues[i]
+			}
+		}
+		if result == nil {
+			manifest.Issues = append(manifest.Issues, KubernetesIssueResult{Issue: issue})
+			result = &manifest.Issues[len(manifest.Issues)-1]
+		}
+
+		result.Findings++
+		result.Effort += finding.Effort
+		if finding.Line > 0 {
+			result.Lines = append(result.Lines, finding.Line)
+		}
+		manifest.Findings++
+		manifest.Effort += finding.Effort
+	}
+
+	grouped := []KubernetesManifest{}
+	for _, key := range keys {
+		manifest := manifests[key]
+		sort.SliceStable(manifest.Issues, func(i, j int) bool { return order[manifest.Issues[i].Issue] < order[manifest.Issues[j].Issue] })
+		for i := range manifest.Issues {
+			sort.Ints(manifest.Issues[i].Lines)
+		}
+		grouped = append(grouped, *manifest)
+	}
+
+	sort.SliceStable(grouped, func(i, j int) bool {
+		if grouped[i].Effort != grouped[j].Effort {
+			return grouped[i].Effort > grouped[j].Effort
+		}
+		if grouped[i].Application != grouped[j].Application {
+			return grouped[i].Application < grouped[j].Application
+		}
+		return grouped[i].Manifest < grouped[j].Manifest
+	})
+
+	return grouped
+}
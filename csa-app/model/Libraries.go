@@ -0,0 +1,729 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Package ecosystems, as named by package urls (https://github.com/package-url/purl-spec)
+const ECOSYSTEM_PYPI = "pypi"
+const ECOSYSTEM_NPM = "npm"
+const ECOSYSTEM_NUGET = "nuget"
+const ECOSYSTEM_GOLANG = "golang"
+const ECOSYSTEM_GEM = "gem"
+const ECOSYSTEM_COMPOSER = "composer"
+
+//AppLibrary is a third-party library an application declares in its build or package manager files. Version is the
+//version (range) as declared, exact when read from a lock file. Dev libraries are only needed to build or test it.
+type AppLibrary struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	RunID       uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application string    `gorm:"index;not null" json:"application" yaml:"application"`
+	Ecosystem   string    `gorm:"type:text;not null" json:"ecosystem" yaml:"ecosystem"`
+	Name        string    `gorm:"type:text;not null" json:"name" yaml:"name"`
+	Version     string    `gorm:"type:text" json:"version" yaml:"version"`
+	Dev         bool      `json:"dev" yaml:"dev"`
+	Evidence    string    `gorm:"type:text" json:"evidence" yaml:"evidence"`
+}
+
+//LibraryParser reads the libraries declared by the files matching Files (globs matched against lower cased file
+//names). Parse only fills the name, version and dev flag of the libraries.
+type LibraryParser struct {
+	Ecosystem string
+	Files     []string
+	Parse     func(content string) []AppLibrary
+}
+
+//LibraryParsers read the libraries of the supported package managers. Lock files come first, the exact versions they
+//pin winning over the ranges of the files they were locked from.
+var LibraryParsers = []LibraryParser{
+	{Ecosystem: ECOSYSTEM_NPM, Files: []string{"package-lock.json", "npm-shrinkwrap.json"}, Parse: parsePackageLock},
+	{Ecosystem: ECOSYSTEM_NPM, Files: []string{"yarn.lock"}, Parse: parseYarnLock},
+	{Ecosystem: ECOSYSTEM_NPM, Files: []string{"package.json"}, Parse: parsePackageJson},
+	{Ecosystem: ECOSYSTEM_PYPI, Files: []string{"poetry.lock"}, Parse: parsePoetryLock},
+	{Ecosystem: ECOSYSTEM_PYPI, Files: []string{"pipfile.lock"}, Parse: parsePipfileLock},
+	{Ecosystem: ECOSYSTEM_PYPI, Files: []string{"requirements*.txt"}, Parse: parseRequirements},
+	{Ecosystem: ECOSYSTEM_PYPI, Files: []string{"pyproject.toml"}, Parse: parsePyproject},
+	{Ecosystem: ECOSYSTEM_PYPI, Files: []string{"pipfile"}, Parse: parsePipfile},
+	{Ecosystem: ECOSYSTEM_NUGET, Files: []string{"packages.lock.json"}, Parse: parseNugetLock},
+	{Ecosystem: ECOSYSTEM_NUGET, Files: []string{"packages.config"}, Parse: parsePackagesConfig},
+	{Ecosystem: ECOSYSTEM_NUGET, Files: dotnetProjectFiles, Parse: parsePackageReferences},
+	{Ecosystem: ECOSYSTEM_NUGET, Files: []string{"directory.build.props"}, Parse: parsePackageReferences},
+	{Ecosystem: ECOSYSTEM_GOLANG, Files: []string{"go.mod"}, Parse: parseGoMod},
+	{Ecosystem: ECOSYSTEM_GEM, Files: []string{"gemfile.lock", "gems.locked"}, Parse: parseGemfileLock},
+	{Ecosystem: ECOSYSTEM_GEM, Files: []string{"gemfile", "gems.rb"}, Parse: parseGemfile},
+	{Ecosystem: ECOSYSTEM_COMPOSER, Files: []string{"composer.lock"}, Parse: parseComposerLock},
+	{Ecosystem: ECOSYSTEM_COMPOSER, Files: []string{"composer.json"}, Parse: parseComposerJson},
+}
+
+var exactVersion = regexp.MustCompile(`^v?\d[\w.+!-]*$`)
+var pypiSeparators = regexp.MustCompile(`[-_.]+`)
+var requirement = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+var tomlHeader = regexp.MustCompile(`^\[\[?\s*([^\[\]]+?)\s*\]\]?\s*(?:#.*)?$`)
+var tomlKeyValue = regexp.MustCompile(`^["']?([A-Za-z0-9][\w.-]*)["']?\s*=\s*(.*)$`)
+var tomlString = regexp.MustCompile(`^["']([^"']*)["']`)
+var tomlInlineVersion = regexp.MustCompile(`\bversion\s*=\s*["']([^"']*)["']`)
+var tomlQuoted = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+var poetryGroup = regexp.MustCompile(`^tool\.poetry\.group\.[\w.-]+\.dependencies$`)
+var poetryLockPackage = regexp.MustCompile(`(?m)^\[\[package\]\]\s*$`)
+var yarnLockEntry = regexp.MustCompile(`(?m)^"?((?:@[^@/\s"]+/)?[^@\s"]+)@([^\n]*):\s*\n\s+version:?\s+"?([^"\s]+)"?`)
+var localYarnPackage = regexp.MustCompile(`\b(workspace|link|file|portal):`)
+var packageReference = regexp.MustCompile(`(?s)<PackageReference\s([^>]*?)(?:/>|>(.*?)</PackageReference>)`)
+var packagesConfigEntry = regexp.MustCompile(`<package\s([^>]*?)/?>`)
+var xmlAttribute = regexp.MustCompile(`([\w.:-]+)\s*=\s*"([^"]*)"`)
+var xmlChildElement = regexp.MustCompile(`<([\w.]+)>\s*([^<]*?)\s*</`)
+var goModDirective = regexp.MustCompile(`^(require|replace)\b\s*(.*)$`)
+var gemfileGem = regexp.MustCompile(`^gem\s*\(?\s*["']([^"']+)["']((?:\s*,\s*["'][^"']*["'])*)(.*)$`)
+var gemfileGroup = regexp.MustCompile(`^group\s*\(?(.*?)\)?\s+do\b`)
+var gemfileLocalGem = regexp.MustCompile(`\bpath\s*(:|=>)`)
+var gemfileDevGroup = regexp.MustCompile(`:(development|test)\b|["'](development|test)["']`)
+var gemLockSpec = regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)$`)
+var gemPlatform = regexp.MustCompile(`-(x86|x64|arm|aarch64|java|universal|mingw|mswin)\S*$`)
+var poetryLockKey = regexp.MustCompile(`(?m)^(name|version|category)\s*=\s*"([^"]*)"`)
+
+//PackageURL identifies the library by a package url, versioned when its version is exact. The @ of npm scopes is
+//percent encoded (pkg:npm/%40angular/core).
+func (l *AppLibrary) PackageURL() string {
+	purl := "pkg:" + l.Ecosystem + "/" + strings.Replace(l.Name, "@", "%40", 1)
+	if version := strings.TrimPrefix(l.Version, "=="); exactVersion.MatchString(version) {
+		purl += "@" + version
+	}
+	return purl
+}
+
+//DetectLibraries finds the third-party libraries the application declares, each once with the file that declared it
+func DetectLibraries(runId uint, app *Application, parsers []LibraryParser) []*AppLibrary {
+
+	found := make(map[string]*AppLibrary)
+	contents := make(map[string]string)
+
+	for _, parser := range parsers {
+		detector := TechDetector{Files: parser.Files}
+
+		for _, file := range app.Files {
+			if file.ThirdParty != "" || !detector.matchesFile(file.Name) {
+				continue
+			}
+
+			for _, library := range parser.Parse(readDetectionFile(file.FQN, contents)) {
+				key := parser.Ecosystem + "/" + library.Name
+				if existing, exists := found[key]; exists {
+					if existing.Version == "" {
+						existing.Version = library.Version
+					}
+					existing.Dev = existing.Dev || library.Dev
+					continue
+				}
+				found[key] = &AppLibrary{RunID: runId, Application: app.Name, Ecosystem: parser.Ecosystem, Name: library.Name,
+					Version: library.Version, Dev: library.Dev, Evidence: relativeEvidence(app, file)}
+			}
+		}
+	}
+
+	libraries := make([]*AppLibrary, 0, len(found))
+	for _, library := range found {
+		libraries = append(libraries, library)
+	}
+	sort.Slice(libraries, func(i, j int) bool {
+		if libraries[i].Ecosystem != libraries[j].Ecosystem {
+			return libraries[i].Ecosystem < libraries[j].Ecosystem
+		}
+		return libraries[i].Name < libraries[j].Name
+	})
+
+	return libraries
+}
+
+//pypiName normalizes a python package name (PEP 503): lower cased, runs of -, _ and . replaced by -
+func pypiName(name string) string {
+	return pypiSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+//pypiVersion returns the version of a requirement specifier, exact versions (==1.2) without their operator. Any
+//version (*) is no version.
+func pypiVersion(specifier string) string {
+	specifier = strings.TrimSpace(specifier)
+	if i := strings.Index(specifier, ";"); i >= 0 {
+		specifier = strings.TrimSpace(specifier[:i])
+	}
+	if strings.HasPrefix(specifier, "@") || specifier == "*" {
+		return ""
+	}
+	if version := strings.TrimSpace(strings.TrimLeft(specifier, "=")); strings.HasPrefix(specifier, "==") && exactVersion.MatchString(version) {
+		return version
+	}
+	return strings.Join(strings.Fields(specifier), "")
+}
+
+//parseRequirements reads a pip requirements file. Options (-r, -e, --index-url...), urls and paths are skipped.
+func parseRequirements(content string) []AppLibrary {
+
+	var libraries []AppLibrary
+	content = strings.ReplaceAll(content, "\\\n", " ")
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.Contains(line, "://") ||
+			strings.HasPrefix(line, ".") || strings.HasPrefix(line, "/") {
+			continue
+		}
+		if match := requirement.FindStringSubmatch(line); match != nil {
+			libraries = append(libraries, AppLibrary{Name: pypiName(match[1]), Version: pypiVersion(match[2])})
+		}
+	}
+
+	return libraries
+}
+
+//parsePyproject reads the dependencies of a pyproject.toml, poetry's ([tool.poetry.*dependencies]) and PEP 621's
+//([project] dependencies and optional-dependencies). Poetry's dev dependencies and groups are dev libraries.
+func parsePyproject(content string) []AppLibrary {
+
+	tables := tomlTables(content)
+	names := make([]string, 0, len(tables))
+	for table := range tables {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+
+	var libraries []AppLibrary
+	for _, table := range names {
+		lines := tables[table]
+		dev := table == "tool.poetry.dev-dependencies" || poetryGroup.MatchString(table)
+		switch {
+		case table == "tool.poetry.dependencies" || dev:
+			for _, library := range tomlLibraries(lines, dev) {
+				if library.Name != "python" {
+					libraries = append(libraries, library)
+				}
+			}
+		case table == "project" || table == "project.optional-dependencies":
+			text := strings.Join(lines, "\n")
+			for _, array := range tomlArrays(text, table == "project") {
+				for _, line := range array {
+					libraries = append(libraries, parseRequirements(line)...)
+				}
+			}
+		}
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parsePipfile reads the [packages] and [dev-packages] of a Pipfile
+func parsePipfile(content string) []AppLibrary {
+
+	tables := tomlTables(content)
+	libraries := append(tomlLibraries(tables["packages"], false), tomlLibraries(tables["dev-packages"], true)...)
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parsePipfileLock reads the pinned default and develop packages of a Pipfile.lock
+func parsePipfileLock(content string) []AppLibrary {
+
+	type lockedPackages map[string]struct {
+		Version string `json:"version"`
+	}
+	var lock struct {
+		Default lockedPackages `json:"default"`
+		Develop lockedPackages `json:"develop"`
+	}
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil
+	}
+
+	var libraries []AppLibrary
+	for name, pkg := range lock.Default {
+		libraries = append(libraries, AppLibrary{Name: pypiName(name), Version: pypiVersion(pkg.Version)})
+	}
+	for name, pkg := range lock.Develop {
+		libraries = append(libraries, AppLibrary{Name: pypiName(name), Version: pypiVersion(pkg.Version), Dev: true})
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parsePoetryLock reads the pinned packages of a poetry.lock. Packages are dev ones when their (pre poetry 1.5)
+//category says so.
+func parsePoetryLock(content string) []AppLibrary {
+
+	var libraries []AppLibrary
+	for _, block := range poetryLockPackage.Split(content, -1)[1:] {
+		//The package's own keys come before its sub tables
+		if i := strings.Index(block, "\n["); i >= 0 {
+			block = block[:i]
+		}
+		keys := make(map[string]string)
+		for _, match := range poetryLockKey.FindAllStringSubmatch(block, -1) {
+			keys[match[1]] = match[2]
+		}
+		if keys["name"] != "" {
+			libraries = append(libraries, AppLibrary{Name: pypiName(keys["name"]), Version: keys["version"], Dev: keys["category"] == "dev"})
+		}
+	}
+
+	return libraries
+}
+
+//parsePackageJson reads the dependencies, optional and dev dependencies of a package.json. Local packages (file:,
+//link:, workspace:) aren't third-party libraries.
+func parsePackageJson(content string) []AppLibrary {
+
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil
+	}
+
+	var libraries []AppLibrary
+	add := func(dependencies map[string]string, dev bool) {
+		for name, version := range dependencies {
+			if local := strings.SplitN(version, ":", 2)[0]; local == "file" || local == "link" || local == "workspace" {
+				continue
+			}
+			if version == "*" || version == "latest" {
+				version = ""
+			}
+			libraries = append(libraries, AppLibrary{Name: name, Version: version, Dev: dev})
+		}
+	}
+	add(manifest.Dependencies, false)
+	add(manifest.OptionalDependencies, false)
+	add(manifest.DevDependencies, true)
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parsePackageLock reads the installed packages of a package-lock.json, from its packages (lockfile version 2 and
+//up) or dependencies (version 1). Only the packages installed at the top of node_modules are read, nested ones being
+//other versions of the same libraries.
+func parsePackageLock(content string) []AppLibrary {
+
+	type lockedPackage struct {
+		Version string `json:"version"`
+		Dev     bool   `json:"dev"`
+		Link    bool   `json:"link"`
+	}
+	var lock struct {
+		Packages     map[string]lockedPackage `json:"packages"`
+		Dependencies map[string]lockedPackage `json:"dependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil
+	}
+
+	var libraries []AppLibrary
+	if len(lock.Packages) > 0 {
+		for path, pkg := range lock.Packages {
+			name := strings.TrimPrefix(path, "node_modules/")
+			if name == path || strings.Contains(name, "node_modules/") || pkg.Link {
+				continue
+			}
+			libraries = append(libraries, AppLibrary{Name: name, Version: pkg.Version, Dev: pkg.Dev})
+		}
+	} else {
+		for name, pkg := range lock.Dependencies {
+			libraries = append(libraries, AppLibrary{Name: name, Version: pkg.Version, Dev: pkg.Dev})
+		}
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parseYarnLock reads the resolved packages of a yarn.lock, classic (v1) and berry (v2+) alike. Yarn doesn't tell
+//dev libraries apart.
+func parseYarnLock(content string) []AppLibrary {
+
+	var libraries []AppLibrary
+	seen := make(map[string]bool)
+	for _, match := range yarnLockEntry.FindAllStringSubmatch(strings.ReplaceAll(content, "\r\n", "\n"), -1) {
+		if !seen[match[1]] && !localYarnPackage.MatchString(match[2]) {
+			seen[match[1]] = true
+			libraries = append(libraries, AppLibrary{Name: match[1], Version: match[3]})
+		}
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parseNugetLock reads the resolved packages of a NuGet packages.lock.json, those of every target framework. Project
+//references aren't third-party libraries.
+func parseNugetLock(content string) []AppLibrary {
+
+	var lock struct {
+		Dependencies map[string]map[string]struct {
+			Type     string `json:"type"`
+			Resolved string `json:"resolved"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil
+	}
+
+	var libraries []AppLibrary
+	seen := make(map[string]bool)
+	for _, packages := range lock.Dependencies {
+		for name, pkg := range packages {
+			if !seen[name] && pkg.Type != "Project" {
+				seen[name] = true
+				libraries = append(libraries, AppLibrary{Name: name, Version: pkg.Resolved})
+			}
+		}
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parsePackagesConfig reads the packages of a (.NET Framework) packages.config
+func parsePackagesConfig(content string) []AppLibrary {
+
+	var libraries []AppLibrary
+	for _, match := range packagesConfigEntry.FindAllStringSubmatch(content, -1) {
+		attributes := xmlAttributes(match[1])
+		if attributes["id"] != "" {
+			libraries = append(libraries, AppLibrary{Name: attributes["id"], Version: attributes["version"],
+				Dev: strings.EqualFold(attributes["developmentDependency"], "true")})
+		}
+	}
+
+	return libraries
+}
+
+//parsePackageReferences reads the PackageReference items of an MSBuild project or props file, their version set by
+//an attribute or a child element. Packages with all their assets private (analyzers, build tools) are dev libraries.
+//Versions set by properties ($(...)) aren't resolved.
+func parsePackageReferences(content string) []AppLibrary {
+
+	var libraries []AppLibrary
+	for _, match := range packageReference.FindAllStringSubmatch(content, -1) {
+		attributes := xmlAttributes(match[1])
+		for _, child := range xmlChildElement.FindAllStringSubmatch(match[2], -1) {
+			attributes[child[1]] = child[2]
+		}
+		if attributes["Include"] == "" {
+			continue
+		}
+		version := attributes["Version"]
+		if override := attributes["VersionOverride"]; override != "" {
+			version = override
+		}
+		if strings.Contains(version, "$(") {
+			version = ""
+		}
+		libraries = append(libraries, AppLibrary{Name: attributes["Include"], Version: version,
+			Dev: strings.EqualFold(attributes["PrivateAssets"], "all")})
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parseGoMod reads the modules required by a go.mod, the indirect ones (required by other modules) included. Modules
+//replaced by a local directory aren't third-party libraries.
+func parseGoMod(content string) []AppLibrary {
+
+	var required []AppLibrary
+	local := make(map[string]bool)
+	block := ""
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		directive, entry := block, line
+		if block == "" {
+			match := goModDirective.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			if directive, entry = match[1], strings.TrimSpace(match[2]); entry == "(" {
+				block = directive
+				continue
+			}
+		} else if line == ")" {
+			block = ""
+			continue
+		}
+
+		switch fields := strings.Fields(entry); {
+		case directive == "require" && len(fields) == 2:
+			required = append(required, AppLibrary{Name: strings.Trim(fields[0], `"`), Version: fields[1]})
+		case directive == "replace":
+			if parts := strings.SplitN(entry, "=>", 2); len(parts) == 2 && len(fields) > 0 {
+				if target := strings.TrimSpace(parts[1]); strings.HasPrefix(target, ".") || strings.HasPrefix(target, "/") {
+					local[fields[0]] = true
+				}
+			}
+		}
+	}
+
+	var libraries []AppLibrary
+	for _, library := range required {
+		if !local[library.Name] {
+			libraries = append(libraries, library)
+		}
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parseGemfileLock reads the gems resolved by a Gemfile.lock, those of rubygems and git sources. The gems of local
+//paths aren't third-party libraries and platform suffixes (nokogiri 1.15.4-x86_64-linux) aren't part of versions.
+func parseGemfileLock(content string) []AppLibrary {
+
+	var libraries []AppLibrary
+	source := ""
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if line != "" && !strings.HasPrefix(line, " ") {
+			source = strings.TrimSpace(line)
+			continue
+		}
+		if source != "GEM" && source != "GIT" {
+			continue
+		}
+		if match := gemLockSpec.FindStringSubmatch(line); match != nil {
+			libraries = append(libraries, AppLibrary{Name: match[1], Version: gemPlatform.ReplaceAllString(match[2], "")})
+		}
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parseGemfile reads the gems of a Gemfile, those of its development and test groups (blocks or group: options) being
+//dev libraries. Gems of local paths aren't third-party libraries.
+func parseGemfile(content string) []AppLibrary {
+
+	var libraries []AppLibrary
+	var blocks []bool
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		if match := gemfileGroup.FindStringSubmatch(line); match != nil {
+			blocks = append(blocks, gemfileDevGroup.MatchString(match[1]))
+			continue
+		}
+		if strings.HasSuffix(line, " do") {
+			//Other blocks (source, platforms...) are part of the group they are nested in
+			blocks = append(blocks, len(blocks) > 0 && blocks[len(blocks)-1])
+			continue
+		}
+		if line == "end" && len(blocks) > 0 {
+			blocks = blocks[:len(blocks)-1]
+			continue
+		}
+
+		match := gemfileGem.FindStringSubmatch(line)
+		if match == nil || gemfileLocalGem.MatchString(match[3]) {
+			continue
+		}
+		var requirements []string
+		for _, quoted := range tomlQuoted.FindAllStringSubmatch(match[2], -1) {
+			requirements = append(requirements, strings.Join(strings.Fields(quoted[1]+quoted[2]), ""))
+		}
+		dev := len(blocks) > 0 && blocks[len(blocks)-1]
+		if options := match[3]; strings.Contains(options, "group") {
+			dev = gemfileDevGroup.MatchString(options)
+		}
+		libraries = append(libraries, AppLibrary{Name: match[1], Version: strings.Join(requirements, ","), Dev: dev})
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parseComposerLock reads the installed packages of a composer.lock, those of packages-dev being dev libraries.
+//Packages installed from a local path aren't third-party libraries.
+func parseComposerLock(content string) []AppLibrary {
+
+	type lockedPackage struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Dist    struct {
+			Type string `json:"type"`
+		} `json:"dist"`
+	}
+	var lock struct {
+		Packages    []lockedPackage `json:"packages"`
+		PackagesDev []lockedPackage `json:"packages-dev"`
+	}
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil
+	}
+
+	var libraries []AppLibrary
+	add := func(packages []lockedPackage, dev bool) {
+		for _, pkg := range packages {
+			if pkg.Name != "" && pkg.Dist.Type != "path" {
+				libraries = append(libraries, AppLibrary{Name: pkg.Name, Version: pkg.Version, Dev: dev})
+			}
+		}
+	}
+	add(lock.Packages, false)
+	add(lock.PackagesDev, true)
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//parseComposerJson reads the packages a composer.json requires. Platform packages (php, ext-*, lib-*...) have no
+//vendor and aren't libraries.
+func parseComposerJson(content string) []AppLibrary {
+
+	var manifest struct {
+		Require    map[string]string `json:"require"`
+		RequireDev map[string]string `json:"require-dev"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil
+	}
+
+	var libraries []AppLibrary
+	add := func(packages map[string]string, dev bool) {
+		for name, version := range packages {
+			if !strings.Contains(name, "/") {
+				continue
+			}
+			if version == "*" {
+				version = ""
+			}
+			libraries = append(libraries, AppLibrary{Name: strings.ToLower(name), Version: version, Dev: dev})
+		}
+	}
+	add(manifest.Require, false)
+	add(manifest.RequireDev, true)
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//xmlAttributes returns the attributes of an xml start tag by name
+func xmlAttributes(tag string) map[string]string {
+	attributes := make(map[string]string)
+	for _, match := range xmlAttribute.FindAllStringSubmatch(tag, -1) {
+		attributes[match[1]] = match[2]
+	}
+	return attributes
+}
+
+//tomlTables splits a toml document in the key/value lines of each of its tables. Lines of arrays of tables
+//([[name]]) are added to the table of that name.
+func tomlTables(content string) map[string][]string {
+
+	tables := make(map[string][]string)
+	table := ""
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if match := tomlHeader.FindStringSubmatch(trimmed); match != nil {
+			table = strings.ReplaceAll(match[1], "\"", "")
+			continue
+		}
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			tables[table] = append(tables[table], trimmed)
+		}
+	}
+
+	return tables
+}
+
+//tomlLibraries reads the libraries of table lines of the form name = "version" or name = { version = "..." }
+func tomlLibraries(lines []string, dev bool) []AppLibrary {
+
+	var libraries []AppLibrary
+	for _, line := range lines {
+		match := tomlKeyValue.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		version := ""
+		if value := tomlString.FindStringSubmatch(match[2]); value != nil {
+			version = value[1]
+		} else if value := tomlInlineVersion.FindStringSubmatch(match[2]); value != nil {
+			version = value[1]
+		}
+		if version == "*" {
+			version = ""
+		}
+		libraries = append(libraries, AppLibrary{Name: pypiName(match[1]), Version: version, Dev: dev})
+	}
+
+	return libraries
+}
+
+//tomlArrays returns the strings of the arrays of a table, only those of the dependencies key when dependenciesOnly
+func tomlArrays(text string, dependenciesOnly bool) [][]string {
+
+	var arrays [][]string
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		match := tomlKeyValue.FindStringSubmatch(lines[i])
+		if match == nil || (dependenciesOnly && match[1] != "dependencies") || !strings.HasPrefix(strings.TrimSpace(match[2]), "[") {
+			continue
+		}
+
+		value := match[2]
+		for !tomlArrayClosed(value) && i+1 < len(lines) {
+			i++
+			value += "\n" + lines[i]
+		}
+
+		var array []string
+		for _, quoted := range tomlQuoted.FindAllStringSubmatch(value, -1) {
+			array = append(array, quoted[1]+quoted[2])
+		}
+		arrays = append(arrays, array)
+	}
+
+	return arrays
+}
+
+//tomlArrayClosed is true once the brackets of the array value, outside of its strings, are balanced
+func tomlArrayClosed(value string) bool {
+	depth := 0
+	quote := rune(0)
+	for _, c := range value {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+func sortLibraries(libraries []AppLibrary) {
+	sort.SliceStable(libraries, func(i, j int) bool { return libraries[i].Name < libraries[j].Name })
+}
@@ -0,0 +1,492 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"csa-app/util"
+	"gopkg.in/yaml.v2"
+)
+
+//License types, by the obligations they put on the applications using them
+const LICENSE_PERMISSIVE = "permissive"
+const LICENSE_WEAK_COPYLEFT = "weak-copyleft"
+const LICENSE_STRONG_COPYLEFT = "strong-copyleft"
+const LICENSE_PROPRIETARY = "proprietary"
+const LICENSE_UNKNOWN = "unknown"
+
+//Where a license was resolved from
+const LICENSE_SOURCE_MAVEN = "maven-repo"
+const LICENSE_SOURCE_GRADLE = "gradle-cache"
+const LICENSE_SOURCE_DB = "license-db"
+
+//Parent poms followed looking for a dependency's license
+const MAX_LICENSE_POM_DEPTH = 5
+
+//LicenseEntry maps the packages (or group id) starting with Package to a license, preferably an SPDX id
+type LicenseEntry struct {
+	Package string `json:"package" yaml:"package"`
+	License string `json:"license" yaml:"license"`
+}
+
+//LicenseDB is an offline license database
+type LicenseDB struct {
+	Licenses []LicenseEntry `json:"licenses" yaml:"licenses"`
+}
+
+//ResolvedLicense is the license of an imported package
+type ResolvedLicense struct {
+	Package string `json:"package"`
+	License string `json:"license"`
+	Type    string `json:"type"`
+	Source  string `json:"source"`
+}
+
+//Dependency is a dependency declared by a pom or gradle build file
+type Dependency struct {
+	Group    string
+	Artifact string
+	Version  string
+}
+
+//DefaultLicenseDB holds the licenses of widely used libraries. Entries of a --license-db file take precedence.
+var DefaultLicenseDB = LicenseDB{Licenses: []LicenseEntry{
+	{Package: "org.apache", License: "Apache-2.0"},
+	{Package: "org.springframework", License: "Apache-2.0"},
+	{Package: "com.google", License: "Apache-2.0"},
+	{Package: "com.fasterxml", License: "Apache-2.0"},
+	{Package: "io.netty", License: "Apache-2.0"},
+	{Package: "io.micrometer", License: "Apache-2.0"},
+	{Package: "net.sf.ehcache", License: "Apache-2.0"},
+	{Package: "org.ehcache", License: "Apache-2.0"},
+	{Package: "org.mongodb", License: "Apache-2.0"},
+	{Package: "org.quartz", License: "Apache-2.0"},
+	{Package: "org.thymeleaf", License: "Apache-2.0"},
+	{Package: "freemarker", License: "Apache-2.0"},
+	{Package: "org.yaml.snakeyaml", License: "Apache-2.0"},
+	{Package: "com.zaxxer", License: "Apache-2.0"},
+	{Package: "org.jasypt", License: "Apache-2.0"},
+	{Package: "org.hibernate.validator", License: "Apache-2.0"},
+	{Package: "org.slf4j", License: "MIT"},
+	{Package: "org.mockito", License: "MIT"},
+	{Package: "org.bouncycastle", License: "MIT"},
+	{Package: "redis.clients", License: "MIT"},
+	{Package: "org.postgresql", License: "BSD-2-Clause"},
+	{Package: "org.hsqldb", License: "BSD-3-Clause"},
+	{Package: "com.jcraft", License: "BSD-3-Clause"},
+	{Package: "org.dom4j", License: "BSD-3-Clause"},
+	{Package: "org.json", License: "JSON"},
+	{Package: "ch.qos.logback", License: "EPL-1.0 OR LGPL-2.1"},
+	{Package: "junit", License: "EPL-1.0"},
+	{Package: "org.junit", License: "EPL-2.0"},
+	{Package: "org.aspectj", License: "EPL-2.0"},
+	{Package: "org.eclipse.jetty", License: "EPL-2.0 OR Apache-2.0"},
+	{Package: "org.h2", License: "MPL-2.0 OR EPL-1.0"},
+	{Package: "com.lowagie", License: "MPL-1.1"},
+	{Package: "org.hibernate", License: "LGPL-2.1"},
+	{Package: "org.mariadb", License: "LGPL-2.1"},
+	{Package: "org.jfree", License: "LGPL-2.1"},
+	{Package: "net.sf.jasperreports", License: "LGPL-3.0"},
+	{Package: "com.mysql", License: "GPL-2.0"},
+	{Package: "com.itextpdf", License: "AGPL-3.0"},
+	{Package: "com.ibm.websphere", License: "Proprietary"},
+	{Package: "com.ibm.mq", License: "Proprietary"},
+	{Package: "weblogic", License: "Proprietary"},
+	{Package: "oracle.jdbc", License: "Proprietary"},
+	{Package: "pkg:pypi/django", License: "BSD-3-Clause"},
+	{Package: "pkg:pypi/flask", License: "BSD-3-Clause"},
+	{Package: "pkg:pypi/werkzeug", License: "BSD-3-Clause"},
+	{Package: "pkg:pypi/jinja2", License: "BSD-3-Clause"},
+	{Package: "pkg:pypi/celery", License: "BSD-3-Clause"},
+	{Package: "pkg:pypi/numpy", License: "BSD-3-Clause"},
+	{Package: "pkg:pypi/pandas", License: "BSD-3-Clause"},
+	{Package: "pkg:pypi/requests", License: "Apache-2.0"},
+	{Package: "pkg:pypi/boto3", License: "Apache-2.0"},
+	{Package: "pkg:pypi/sqlalchemy", License: "MIT"},
+	{Package: "pkg:pypi/redis", License: "MIT"},
+	{Package: "pkg:pypi/pyyaml", License: "MIT"},
+	{Package: "pkg:pypi/gunicorn", License: "MIT"},
+	{Package: "pkg:pypi/psycopg2", License: "LGPL-3.0"},
+	{Package: "pkg:pypi/psycopg2-binary", License: "LGPL-3.0"},
+	{Package: "pkg:pypi/mysqlclient", License: "GPL-2.0"},
+	{Package: "pkg:pypi/pyqt5", License: "GPL-3.0"},
+	{Package: "pkg:npm/express", License: "MIT"},
+	{Package: "pkg:npm/express-session", License: "MIT"},
+	{Package: "pkg:npm/lodash", License: "MIT"},
+	{Package: "pkg:npm/react", License: "MIT"},
+	{Package: "pkg:npm/@angular/core", License: "MIT"},
+	{Package: "pkg:npm/axios", License: "MIT"},
+	{Package: "pkg:npm/mongoose", License: "MIT"},
+	{Package: "pkg:npm/pg", License: "MIT"},
+	{Package: "pkg:npm/mysql2", License: "MIT"},
+	{Package: "pkg:npm/typescript", License: "Apache-2.0"},
+	{Package: "pkg:npm/sharp", License: "Apache-2.0"},
+	{Package: "pkg:nuget/Newtonsoft.Json", License: "MIT"},
+	{Package: "pkg:nuget/Microsoft.EntityFrameworkCore", License: "MIT"},
+	{Package: "pkg:nuget/EntityFramework", License: "Apache-2.0"},
+	{Package: "pkg:nuget/Dapper", License: "Apache-2.0"},
+	{Package: "pkg:nuget/AutoMapper", License: "MIT"},
+	{Package: "pkg:nuget/Serilog", License: "Apache-2.0"},
+	{Package: "pkg:nuget/log4net", License: "Apache-2.0"},
+	{Package: "pkg:nuget/NLog", License: "BSD-3-Clause"},
+	{Package: "pkg:nuget/Npgsql", License: "PostgreSQL"},
+	{Package: "pkg:nuget/itext7", License: "AGPL-3.0"},
+	{Package: "pkg:golang/github.com/gin-gonic/gin", License: "MIT"},
+	{Package: "pkg:golang/github.com/labstack/echo/v4", License: "MIT"},
+	{Package: "pkg:golang/github.com/gorilla/mux", License: "BSD-3-Clause"},
+	{Package: "pkg:golang/github.com/lib/pq", License: "MIT"},
+	{Package: "pkg:golang/github.com/jackc/pgx/v5", License: "MIT"},
+	{Package: "pkg:golang/github.com/go-sql-driver/mysql", License: "MPL-2.0"},
+	{Package: "pkg:golang/gorm.io/gorm", License: "MIT"},
+	{Package: "pkg:golang/google.golang.org/grpc", License: "Apache-2.0"},
+	{Package: "pkg:golang/github.com/spf13/cobra", License: "Apache-2.0"},
+	{Package: "pkg:golang/github.com/hashicorp/vault/api", License: "MPL-2.0"},
+	{Package: "pkg:gem/rails", License: "MIT"},
+	{Package: "pkg:gem/puma", License: "BSD-3-Clause"},
+	{Package: "pkg:gem/sidekiq", License: "LGPL-3.0"},
+	{Package: "pkg:gem/devise", License: "MIT"},
+	{Package: "pkg:gem/pg", License: "BSD-2-Clause"},
+	{Package: "pkg:gem/mysql2", License: "MIT"},
+	{Package: "pkg:gem/nokogiri", License: "MIT"},
+	{Package: "pkg:composer/laravel/framework", License: "MIT"},
+	{Package: "pkg:composer/symfony/symfony", License: "MIT"},
+	{Package: "pkg:composer/symfony/framework-bundle", License: "MIT"},
+	{Package: "pkg:composer/symfony/http-kernel", License: "MIT"},
+	{Package: "pkg:composer/guzzlehttp/guzzle", License: "MIT"},
+	{Package: "pkg:composer/monolog/monolog", License: "MIT"},
+	{Package: "pkg:composer/doctrine/orm", License: "MIT"},
+	{Package: "pkg:composer/phpmailer/phpmailer", License: "LGPL-2.1"},
+	{Package: "pkg:composer/phpunit/phpunit", License: "BSD-3-Clause"},
+}}
+
+var proprietaryLicense = regexp.MustCompile(`PROPRIETARY|COMMERCIAL`)
+var strongCopyleftLicense = regexp.MustCompile(`AGPL|AFFERO|SSPL|SERVER SIDE PUBLIC`)
+var weakCopyleftLicense = regexp.MustCompile(`LGPL|LESSER GENERAL|LIBRARY GENERAL|\bMPL\b|MOZILLA|\bEPL\b|ECLIPSE PUBLIC|CDDL|COMMON DEVELOPMENT|\bCPL\b|COMMON PUBLIC`)
+var gplLicense = regexp.MustCompile(`\bGPL|GENERAL PUBLIC`)
+var permissiveLicense = regexp.MustCompile(`APACHE|\bMIT\b|\bBSD|\bISC\b|ZLIB|UNLICENSE|CC0|PUBLIC DOMAIN|\bEDL\b|ECLIPSE DISTRIBUTION|\bJSON\b|BOUNCY CASTLE`)
+var licenseChoice = regexp.MustCompile(`(?i)\s+OR\s+|\s*/\s*`)
+
+var importedPackage = regexp.MustCompile(`[A-Za-z_$][\w$]*(?:\.[A-Za-z_$*][\w$]*)+`)
+var pomLicenseName = regexp.MustCompile(`(?s)<licenses>\s*<license>.*?<name>\s*([^<]+?)\s*</name>`)
+var pomParent = regexp.MustCompile(`(?s)<parent>.*?<groupId>\s*([^<\s]+)\s*</groupId>.*?<artifactId>\s*([^<\s]+)\s*</artifactId>.*?<version>\s*([^<\s]+)\s*</version>.*?</parent>`)
+var pomDependency = regexp.MustCompile(`(?s)<dependency>\s*(.*?)</dependency>`)
+var pomElement = regexp.MustCompile(`<(groupId|artifactId|version)>\s*([^<\s]+)\s*</`)
+var pomProperty = regexp.MustCompile(`(?s)<properties>(.*?)</properties>`)
+var pomPropertyValue = regexp.MustCompile(`<([\w.-]+)>\s*([^<\s]+)\s*</`)
+var gradleDependency = regexp.MustCompile(`['"]([\w.-]+):([\w.-]+):([\w.${}-]+)['"]`)
+
+//licenseTypeRank orders license types by obligations, unknown ones last
+var licenseTypeRank = map[string]int{LICENSE_PERMISSIVE: 1, LICENSE_WEAK_COPYLEFT: 2, LICENSE_STRONG_COPYLEFT: 3, LICENSE_PROPRIETARY: 3}
+
+//LicenseType classifies a license (SPDX id or name as found in poms). With a choice of licenses (I.E. EPL-1.0 OR
+//LGPL-2.1) the one with the fewest obligations applies. GPL with the classpath exception is a weak copyleft.
+func LicenseType(license string) string {
+
+	best := LICENSE_UNKNOWN
+	for _, choice := range licenseChoice.Split(license, -1) {
+		choiceType := singleLicenseType(strings.ToUpper(choice))
+		if best == LICENSE_UNKNOWN || (choiceType != LICENSE_UNKNOWN && licenseTypeRank[choiceType] < licenseTypeRank[best]) {
+			best = choiceType
+		}
+	}
+
+	return best
+}
+
+func singleLicenseType(license string) string {
+	switch {
+	case proprietaryLicense.MatchString(license):
+		return LICENSE_PROPRIETARY
+	case strongCopyleftLicense.MatchString(license):
+		return LICENSE_STRONG_COPYLEFT
+	case weakCopyleftLicense.MatchString(license):
+		return LICENSE_WEAK_COPYLEFT
+	case gplLicense.MatchString(license):
+		if strings.Contains(license, "CLASSPATH") {
+			return LICENSE_WEAK_COPYLEFT
+		}
+		return LICENSE_STRONG_COPYLEFT
+	case permissiveLicense.MatchString(license):
+		return LICENSE_PERMISSIVE
+	}
+	return LICENSE_UNKNOWN
+}
+
+//IsLicenseRisk is true for the license types that are migration/compliance risks: copyleft licenses may oblige the
+//application to be distributed under the same terms, proprietary ones need to be licensed for the new platform
+func IsLicenseRisk(licenseType string) bool {
+	return licenseType == LICENSE_STRONG_COPYLEFT || licenseType == LICENSE_WEAK_COPYLEFT || licenseType == LICENSE_PROPRIETARY
+}
+
+//LoadLicenseDB reads a (yaml|json) license database. Its entries take precedence over (and are added to) the defaults.
+func LoadLicenseDB(file string) (*LicenseDB, error) {
+
+	licenseDB := &LicenseDB{}
+	if file != "" {
+		reader, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read license db [%s]: %v", file, err)
+		}
+		defer reader.Close()
+
+		var decoder util.FileDecoder
+		if strings.HasSuffix(file, util.JSON) {
+			decoder = json.NewDecoder(reader)
+		} else {
+			decoder = yaml.NewDecoder(reader)
+		}
+
+		if err = decoder.Decode(licenseDB); err != nil {
+			return nil, fmt.Errorf("unable to decode license db [%s]: %v", file, err)
+		}
+
+		for _, entry := range licenseDB.Licenses {
+			if entry.Package == "" || entry.License == "" {
+				return nil, fmt.Errorf("license db [%s] entries must have a package and a license", file)
+			}
+		}
+	}
+
+	licenseDB.Licenses = append(licenseDB.Licenses, DefaultLicenseDB.Licenses...)
+	return licenseDB, nil
+}
+
+//LicenseResolver resolves the licenses of imported packages from the licenses of the dependencies the applications
+//declare, as found in their poms (in the local maven repository or gradle cache), falling back to a license db
+type LicenseResolver struct {
+	dependencies map[string]ResolvedLicense
+	licenseDB    map[string]string
+}
+
+func NewLicenseResolver(licenseDB *LicenseDB) *LicenseResolver {
+	resolver := &LicenseResolver{dependencies: make(map[string]ResolvedLicense), licenseDB: make(map[string]string)}
+	for _, entry := range licenseDB.Licenses {
+		//The first entry of a package wins, I.E. a --license-db entry over a default one
+		if _, found := resolver.licenseDB[entry.Package]; !found {
+			resolver.licenseDB[entry.Package] = entry.License
+		}
+	}
+	return resolver
+}
+
+//AddDependencies resolves the licenses of the dependencies declared by the application's poms and gradle build files
+//from the poms of the local maven repository and gradle cache. Dependencies without a local pom are left to the db.
+func (r *LicenseResolver) AddDependencies(app *Application, mavenRepo string, gradleHome string) {
+
+	//The versions resolved from parent poms and BOMs find the dependency's own pom
+	var dependencies []Dependency
+	for _, library := range app.Libraries {
+		if library.Ecosystem == ECOSYSTEM_MAVEN && library.Version != "" {
+			coordinates := strings.SplitN(library.Name, "/", 2)
+			dependencies = append(dependencies, Dependency{Group: coordinates[0], Artifact: coordinates[1], Version: library.Version})
+		}
+	}
+
+	contents := make(map[string]string)
+	for _, dependency := range append(dependencies, DeclaredDependencies(app, contents)...) {
+		if _, found := r.dependencies[dependency.Group]; found {
+			continue
+		}
+		if license, source := DependencyLicense(dependency, mavenRepo, gradleHome); license != "" {
+			r.dependencies[dependency.Group] = ResolvedLicense{Package: dependency.Group, License: license,
+				Type: LicenseType(license), Source: source}
+		}
+	}
+}
+
+//Resolve returns the license of the package imported by the value of a third-party import finding, that of the
+//dependency (or db entry) with the longest matching group/package
+func (r *LicenseResolver) Resolve(value string) (ResolvedLicense, bool) {
+
+	pkg := ImportedPackage(value)
+	if pkg == "" {
+		return ResolvedLicense{}, false
+	}
+
+	return r.resolvePackage(pkg)
+}
+
+//ResolveLibrary returns the license of a declared library, from the db entry of its unversioned (and unencoded)
+//package url, I.E. pkg:pypi/requests, pkg:npm/@angular/core, pkg:nuget/Newtonsoft.Json,
+//pkg:golang/github.com/lib/pq, pkg:gem/rails or pkg:composer/laravel/framework. Maven libraries resolve like the
+//imports of their group, from the license of the dependency's pom first.
+func (r *LicenseResolver) ResolveLibrary(library *AppLibrary) (ResolvedLicense, bool) {
+	if library.Ecosystem == ECOSYSTEM_MAVEN {
+		return r.resolvePackage(strings.SplitN(library.Name, "/", 2)[0])
+	}
+	return r.resolvePackage("pkg:" + library.Ecosystem + "/" + library.Name)
+}
+
+func (r *LicenseResolver) resolvePackage(pkg string) (ResolvedLicense, bool) {
+
+	if group := longestPrefix(pkg, r.dependencies); group != "" {
+		return r.dependencies[group], true
+	}
+
+	licenses := make(map[string]ResolvedLicense, len(r.licenseDB))
+	for prefix, license := range r.licenseDB {
+		licenses[prefix] = ResolvedLicense{Package: prefix, License: license, Type: LicenseType(license), Source: LICENSE_SOURCE_DB}
+	}
+	if prefix := longestPrefix(pkg, licenses); prefix != "" {
+		return licenses[prefix], true
+	}
+
+	return ResolvedLicense{}, false
+}
+
+func longestPrefix(pkg string, licenses map[string]ResolvedLicense) string {
+	longest := ""
+	for prefix := range licenses {
+		if (pkg == prefix || strings.HasPrefix(pkg, prefix+".")) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	return longest
+}
+
+//ImportedPackage returns the qualified name imported by a line, I.E. org.apache.commons.lang3.StringUtils for
+//"import org.apache.commons.lang3.StringUtils;"
+func ImportedPackage(value string) string {
+	value = strings.TrimSpace(value)
+	for _, keyword := range []string{"import", "static"} {
+		value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), keyword))
+	}
+	return importedPackage.FindString(value)
+}
+
+//DeclaredDependencies returns the dependencies declared by the application's own poms and gradle build files.
+//Versions defined by a pom's properties are resolved.
+func DeclaredDependencies(app *Application, contents map[string]string) []Dependency {
+
+	var dependencies []Dependency
+	seen := make(map[Dependency]bool)
+	add := func(dependency Dependency) {
+		if dependency.Group != "" && dependency.Artifact != "" && !seen[dependency] {
+			seen[dependency] = true
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	maven := TechDetector{Files: mavenDescriptors}
+	gradle := TechDetector{Files: gradleDescriptors}
+
+	for _, file := range app.Files {
+		if file.ThirdParty != "" {
+			continue
+		}
+
+		switch {
+		case maven.matchesFile(file.Name):
+			content := readDetectionFile(file.FQN, contents)
+			properties := make(map[string]string)
+			if match := pomProperty.FindStringSubmatch(content); match != nil {
+				for _, property := range pomPropertyValue.FindAllStringSubmatch(match[1], -1) {
+					properties[property[1]] = property[2]
+				}
+			}
+			for _, match := range pomDependency.FindAllStringSubmatch(content, -1) {
+				dependency := Dependency{}
+				for _, element := range pomElement.FindAllStringSubmatch(match[1], -1) {
+					switch element[1] {
+					case "groupId":
+						dependency.Group = element[2]
+					case "artifactId":
+						dependency.Artifact = element[2]
+					case "version":
+						dependency.Version = element[2]
+					}
+				}
+				if strings.HasPrefix(dependency.Version, "${") {
+					dependency.Version = properties[strings.TrimSuffix(strings.TrimPrefix(dependency.Version, "${"), "}")]
+				}
+				add(dependency)
+			}
+		case gradle.matchesFile(file.Name):
+			for _, match := range gradleDependency.FindAllStringSubmatch(readDetectionFile(file.FQN, contents), -1) {
+				version := match[3]
+				if strings.Contains(version, "$") {
+					version = ""
+				}
+				add(Dependency{Group: match[1], Artifact: match[2], Version: version})
+			}
+		}
+	}
+
+	return dependencies
+}
+
+//DependencyLicense reads the license of a dependency from its pom, or that of its parents, in the local maven
+//repository or gradle cache. Without a version the (lexically) last version found is used.
+func DependencyLicense(dependency Dependency, mavenRepo string, gradleHome string) (license string, source string) {
+
+	for depth := 0; depth < MAX_LICENSE_POM_DEPTH; depth++ {
+
+		pom, pomSource := localPom(dependency, mavenRepo, gradleHome)
+		if pom == "" {
+			return "", ""
+		}
+
+		data, err := ioutil.ReadFile(pom)
+		if err != nil {
+			return "", ""
+		}
+
+		if match := pomLicenseName.FindSubmatch(data); match != nil {
+			return string(match[1]), pomSource
+		}
+
+		match := pomParent.FindSubmatch(data)
+		if match == nil {
+			return "", ""
+		}
+		dependency = Dependency{Group: string(match[1]), Artifact: string(match[2]), Version: string(match[3])}
+	}
+
+	return "", ""
+}
+
+func localPom(dependency Dependency, mavenRepo string, gradleHome string) (string, string) {
+
+	if mavenRepo != "" {
+		dir := filepath.Join(mavenRepo, filepath.FromSlash(strings.ReplaceAll(dependency.Group, ".", "/")), dependency.Artifact)
+		if pom := latestPom(filepath.Join(dir, versionOrAny(dependency.Version), dependency.Artifact+"-*.pom")); pom != "" {
+			return pom, LICENSE_SOURCE_MAVEN
+		}
+	}
+
+	if gradleHome != "" {
+		dir := filepath.Join(gradleHome, "caches", "modules-2", "files-2.1", dependency.Group, dependency.Artifact)
+		if pom := latestPom(filepath.Join(dir, versionOrAny(dependency.Version), "*", dependency.Artifact+"-*.pom")); pom != "" {
+			return pom, LICENSE_SOURCE_GRADLE
+		}
+	}
+
+	return "", ""
+}
+
+func versionOrAny(version string) string {
+	if version == "" {
+		return "*"
+	}
+	return version
+}
+
+func latestPom(pattern string) string {
+	poms, _ := filepath.Glob(pattern)
+	if len(poms) == 0 {
+		return ""
+	}
+	sort.Strings(poms)
+	return poms[len(poms)-1]
+}
@@ -0,0 +1,451 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const ECOSYSTEM_MAVEN = "maven"
+
+//Parent poms and BOMs followed resolving a pom
+const MAX_POM_DEPTH = 10
+
+//Gradle configurations of the libraries only needed to build or test the application
+var gradleDevConfiguration = regexp.MustCompile(`^(test\w*|\w*[tT]est(Implementation|CompileOnly|RuntimeOnly|Compile|Runtime)|compileOnly|annotationProcessor|kapt|developmentOnly)$`)
+
+var gradleDeclaration = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*(?:(platform|enforcedPlatform)\s*\(\s*)?['"]([\w.-]+):([\w.-]+)(?::([^'"@:]+))?(?::[\w.-]+)?(?:@\w+)?['"]`)
+var gradleVariable = regexp.MustCompile(`(?m)^\s*(?:ext\.|def\s+|val\s+|set\(\s*["'])?([\w.]+)["']?\s*[=,]\s*['"]([^'"$]+)['"]`)
+var gradleReference = regexp.MustCompile(`\$\{?(?:project\.|ext\.|rootProject\.ext\.)?([\w.]+)\}?`)
+var gradleBootPlugin = regexp.MustCompile(`id\s*\(?\s*['"]org\.springframework\.boot['"]\s*\)?\s*version\s*['"]([^'"]+)['"]`)
+var gradleProperty = regexp.MustCompile(`(?m)^\s*([\w.-]+)\s*[=:]\s*(\S+)\s*$`)
+var mavenReference = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+//mavenPom is the part of a pom the dependencies are resolved from
+type mavenPom struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Parent     struct {
+		GroupID      string  `xml:"groupId"`
+		ArtifactID   string  `xml:"artifactId"`
+		Version      string  `xml:"version"`
+		RelativePath *string `xml:"relativePath"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Managed      []mavenDependency `xml:"dependencyManagement>dependencies>dependency"`
+	Dependencies []mavenDependency `xml:"dependencies>dependency"`
+}
+
+type mavenDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+	Type       string `xml:"type"`
+}
+
+func (d *mavenDependency) key() string {
+	return d.GroupID + "/" + d.ArtifactID
+}
+
+//effectivePom is a pom with the properties, managed versions and dependencies it inherits from its parents and
+//imports from BOMs, its properties interpolated
+type effectivePom struct {
+	group        string
+	artifact     string
+	version      string
+	properties   map[string]string
+	managed      map[string]mavenDependency
+	dependencies []mavenDependency
+}
+
+//MavenResolver resolves the dependencies declared by the poms and gradle build files of an application to their
+//versions. Parent poms and BOMs are looked up in the application, then in the local maven repository and gradle cache.
+type MavenResolver struct {
+	app        *Application
+	mavenRepo  string
+	gradleHome string
+	contents   map[string]string
+	modules    map[string]string
+	resolved   map[string]*effectivePom
+}
+
+//LocalRepositories returns the local maven repository (mavenRepo, defaulting to ~/.m2/repository) and gradle home
+//(GRADLE_USER_HOME, defaulting to ~/.gradle) parent poms, BOMs and licenses are looked up in
+func LocalRepositories(mavenRepo string) (string, string) {
+	gradleHome := os.Getenv("GRADLE_USER_HOME")
+	if home, err := os.UserHomeDir(); err == nil {
+		if mavenRepo == "" {
+			mavenRepo = filepath.Join(home, ".m2", "repository")
+		}
+		if gradleHome == "" {
+			gradleHome = filepath.Join(home, ".gradle")
+		}
+	}
+	return mavenRepo, gradleHome
+}
+
+func NewMavenResolver(app *Application, mavenRepo string, gradleHome string) *MavenResolver {
+
+	resolver := &MavenResolver{app: app, mavenRepo: mavenRepo, gradleHome: gradleHome, contents: make(map[string]string),
+		modules: make(map[string]string), resolved: make(map[string]*effectivePom)}
+
+	//The application's own poms, by coordinates, so parents and BOMs of a multi-module build are found in it
+	maven := TechDetector{Files: mavenDescriptors}
+	for _, file := range app.Files {
+		if file.ThirdParty != "" || !maven.matchesFile(file.Name) {
+			continue
+		}
+		if pom, ok := resolver.parse(file.FQN); ok {
+			group := pom.GroupID
+			if group == "" {
+				group = pom.Parent.GroupID
+			}
+			resolver.modules[group+"/"+pom.ArtifactID] = file.FQN
+		}
+	}
+
+	return resolver
+}
+
+//ResolveMavenLibraries lists the libraries declared by the application's poms and gradle build files with their
+//resolved versions, once each with the file that declared it. The application's own modules aren't libraries.
+func ResolveMavenLibraries(runId uint, app *Application, mavenRepo string, gradleHome string) []*AppLibrary {
+
+	resolver := NewMavenResolver(app, mavenRepo, gradleHome)
+	maven := TechDetector{Files: mavenDescriptors}
+	gradle := TechDetector{Files: gradleDescriptors}
+
+	found := make(map[string]*AppLibrary)
+	for _, file := range app.Files {
+		if file.ThirdParty != "" {
+			continue
+		}
+
+		var libraries []AppLibrary
+		switch {
+		case maven.matchesFile(file.Name):
+			libraries = resolver.PomLibraries(file.FQN)
+		case gradle.matchesFile(file.Name):
+			libraries = resolver.GradleLibraries(file.FQN)
+		default:
+			continue
+		}
+
+		for _, library := range libraries {
+			if _, module := resolver.modules[library.Name]; module {
+				continue
+			}
+			if existing, exists := found[library.Name]; exists {
+				if existing.Version == "" {
+					existing.Version = library.Version
+				}
+				existing.Dev = existing.Dev && library.Dev
+				continue
+			}
+			found[library.Name] = &AppLibrary{RunID: runId, Application: app.Name, Ecosystem: ECOSYSTEM_MAVEN, Name: library.Name,
+				Version: library.Version, Dev: library.Dev, Evidence: relativeEvidence(app, file)}
+		}
+	}
+
+	libraries := make([]*AppLibrary, 0, len(found))
+	for _, library := range found {
+		libraries = append(libraries, library)
+	}
+	sort.Slice(libraries, func(i, j int) bool { return libraries[i].Name < libraries[j].Name })
+
+	return libraries
+}
+
+//PomLibraries returns the dependencies of the pom, inherited ones included, their versions resolved from the pom's
+//properties and managed versions. Test dependencies are dev libraries.
+func (r *MavenResolver) PomLibraries(fqn string) []AppLibrary {
+
+	pom := r.effective(fqn, 0)
+	if pom == nil {
+		return nil
+	}
+
+	var libraries []AppLibrary
+	for _, dependency := range pom.dependencies {
+		if dependency.Scope == "import" || dependency.Type == "pom" {
+			continue
+		}
+		managed := pom.managed[dependency.key()]
+		version := dependency.Version
+		if version == "" {
+			version = managed.Version
+		}
+		scope := dependency.Scope
+		if scope == "" {
+			scope = managed.Scope
+		}
+		libraries = append(libraries, AppLibrary{Name: dependency.key(), Version: resolvedVersion(version), Dev: scope == "test"})
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//GradleLibraries returns the dependencies a gradle build file declares in the group:artifact:version notation.
+//Versions are resolved from the file's variables and gradle.properties, and, for those without a version, from the
+//platforms (BOMs) it imports, the Spring Boot plugin importing spring-boot-dependencies.
+func (r *MavenResolver) GradleLibraries(fqn string) []AppLibrary {
+
+	content := readDetectionFile(fqn, r.contents)
+
+	variables := make(map[string]string)
+	for _, dir := range []string{r.app.Path, filepath.Dir(fqn)} {
+		for _, match := range gradleProperty.FindAllStringSubmatch(readDetectionFile(filepath.Join(dir, "gradle.properties"), r.contents), -1) {
+			variables[match[1]] = match[2]
+		}
+	}
+	for _, match := range gradleVariable.FindAllStringSubmatch(content, -1) {
+		variables[match[1]] = match[2]
+	}
+	interpolate := func(value string) string {
+		return gradleReference.ReplaceAllStringFunc(value, func(reference string) string {
+			if variable, found := variables[gradleReference.FindStringSubmatch(reference)[1]]; found {
+				return variable
+			}
+			return reference
+		})
+	}
+
+	managed := make(map[string]mavenDependency)
+	importBom := func(group string, artifact string, version string) {
+		if bom := r.locate(group, artifact, version, ""); bom != "" {
+			if pom := r.effective(bom, 1); pom != nil {
+				for key, dependency := range pom.managed {
+					if _, found := managed[key]; !found {
+						managed[key] = dependency
+					}
+				}
+			}
+		}
+	}
+
+	type declaration struct {
+		library       AppLibrary
+		configuration string
+	}
+	var declarations []declaration
+	for _, match := range gradleDeclaration.FindAllStringSubmatch(content, -1) {
+		version := interpolate(match[5])
+		if match[2] != "" {
+			importBom(match[3], match[4], version)
+			continue
+		}
+		if match[1] == "classpath" || match[1] == "id" {
+			continue
+		}
+		declarations = append(declarations, declaration{configuration: match[1],
+			library: AppLibrary{Name: match[3] + "/" + match[4], Version: version, Dev: gradleDevConfiguration.MatchString(match[1])}})
+	}
+	if match := gradleBootPlugin.FindStringSubmatch(content); match != nil {
+		importBom("org.springframework.boot", "spring-boot-dependencies", interpolate(match[1]))
+	}
+
+	var libraries []AppLibrary
+	for _, declaration := range declarations {
+		library := declaration.library
+		if library.Version == "" {
+			library.Version = managed[library.Name].Version
+		}
+		library.Version = resolvedVersion(library.Version)
+		libraries = append(libraries, library)
+	}
+
+	sortLibraries(libraries)
+	return libraries
+}
+
+//effective resolves the pom, nil when it can't be read or parents (BOMs) nest deeper than MAX_POM_DEPTH
+func (r *MavenResolver) effective(fqn string, depth int) *effectivePom {
+
+	if resolved, found := r.resolved[fqn]; found {
+		return resolved
+	}
+	if depth > MAX_POM_DEPTH {
+		return nil
+	}
+
+	pom, ok := r.parse(fqn)
+	if !ok {
+		return nil
+	}
+	//Guards against cycles
+	r.resolved[fqn] = nil
+
+	effective := &effectivePom{group: pom.GroupID, artifact: pom.ArtifactID, version: pom.Version,
+		properties: make(map[string]string), managed: make(map[string]mavenDependency)}
+
+	var parent *effectivePom
+	if pom.Parent.ArtifactID != "" {
+		relativePath := "../pom.xml"
+		if pom.Parent.RelativePath != nil {
+			relativePath = *pom.Parent.RelativePath
+		}
+		if location := r.locate(pom.Parent.GroupID, pom.Parent.ArtifactID, pom.Parent.Version, filepath.Join(filepath.Dir(fqn), relativePath)); location != "" {
+			parent = r.effective(location, depth+1)
+		}
+		if effective.group == "" {
+			effective.group = pom.Parent.GroupID
+		}
+		if effective.version == "" {
+			effective.version = pom.Parent.Version
+		}
+	}
+
+	if parent != nil {
+		for name, value := range parent.properties {
+			effective.properties[name] = value
+		}
+	}
+	for _, property := range pom.Properties.Entries {
+		effective.properties[property.XMLName.Local] = strings.TrimSpace(property.Value)
+	}
+	for _, prefix := range []string{"project.", "pom.", ""} {
+		effective.properties[prefix+"groupId"] = effective.group
+		effective.properties[prefix+"artifactId"] = effective.artifact
+		effective.properties[prefix+"version"] = effective.version
+	}
+	effective.properties["project.parent.version"] = pom.Parent.Version
+	effective.properties["project.parent.groupId"] = pom.Parent.GroupID
+
+	//Versions managed by the pom win over inherited ones, which win over those imported from BOMs
+	var boms []mavenDependency
+	for _, dependency := range pom.Managed {
+		dependency = effective.interpolate(dependency)
+		if dependency.Scope == "import" && dependency.Type == "pom" {
+			boms = append(boms, dependency)
+			continue
+		}
+		if _, found := effective.managed[dependency.key()]; !found {
+			effective.managed[dependency.key()] = dependency
+		}
+	}
+	if parent != nil {
+		for key, dependency := range parent.managed {
+			if _, found := effective.managed[key]; !found {
+				effective.managed[key] = dependency
+			}
+		}
+	}
+	for _, bom := range boms {
+		location := r.locate(bom.GroupID, bom.ArtifactID, bom.Version, "")
+		if location == "" {
+			continue
+		}
+		if imported := r.effective(location, depth+1); imported != nil {
+			for key, dependency := range imported.managed {
+				if _, found := effective.managed[key]; !found {
+					effective.managed[key] = dependency
+				}
+			}
+		}
+	}
+
+	for _, dependency := range pom.Dependencies {
+		effective.dependencies = append(effective.dependencies, effective.interpolate(dependency))
+	}
+	if parent != nil {
+		effective.dependencies = append(effective.dependencies, parent.dependencies...)
+	}
+
+	r.resolved[fqn] = effective
+	return effective
+}
+
+//locate finds the pom of the coordinates: at the relative path (of a parent) when it's that pom, among the
+//application's poms, then in the local maven repository and gradle cache
+func (r *MavenResolver) locate(group string, artifact string, version string, relativePath string) string {
+
+	if relativePath != "" {
+		if strings.HasSuffix(relativePath, string(filepath.Separator)) || filepath.Ext(relativePath) != ".xml" {
+			relativePath = filepath.Join(relativePath, "pom.xml")
+		}
+		if pom, ok := r.parse(relativePath); ok && pom.ArtifactID == artifact {
+			return relativePath
+		}
+	}
+
+	if fqn, found := r.modules[group+"/"+artifact]; found {
+		return fqn
+	}
+
+	if version == "" || strings.Contains(version, "${") {
+		return ""
+	}
+	pom, _ := localPom(Dependency{Group: group, Artifact: artifact, Version: version}, r.mavenRepo, r.gradleHome)
+	return pom
+}
+
+func (r *MavenResolver) parse(fqn string) (*mavenPom, bool) {
+
+	content, found := r.contents[fqn]
+	if !found {
+		if data, err := ioutil.ReadFile(fqn); err == nil {
+			content = string(data)
+		}
+		r.contents[fqn] = content
+	}
+	if content == "" {
+		return nil, false
+	}
+
+	pom := &mavenPom{}
+	if err := xml.Unmarshal([]byte(content), pom); err != nil {
+		return nil, false
+	}
+	return pom, true
+}
+
+//interpolate replaces the ${property} references of the dependency's coordinates with the pom's properties
+func (p *effectivePom) interpolate(dependency mavenDependency) mavenDependency {
+	dependency.GroupID = p.property(dependency.GroupID)
+	dependency.ArtifactID = p.property(dependency.ArtifactID)
+	dependency.Version = p.property(dependency.Version)
+	return dependency
+}
+
+func (p *effectivePom) property(value string) string {
+	value = strings.TrimSpace(value)
+	//Properties may refer to other properties
+	for i := 0; i < MAX_POM_DEPTH && strings.Contains(value, "${"); i++ {
+		replaced := mavenReference.ReplaceAllStringFunc(value, func(reference string) string {
+			if property, found := p.properties[reference[2:len(reference)-1]]; found {
+				return property
+			}
+			return reference
+		})
+		if replaced == value {
+			break
+		}
+		value = replaced
+	}
+	return value
+}
+
+//resolvedVersion drops versions left unresolved (I.E. a property defined in a pom that wasn't found)
+func resolvedVersion(version string) string {
+	if strings.Contains(version, "$") {
+		return ""
+	}
+	return version
+}
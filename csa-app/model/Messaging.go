@@ -0,0 +1,254 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//Findings naming a queue, topic or exchange (in code or configuration) are tagged messaging-destination
+const MESSAGING_DESTINATION_TAG = "messaging-destination"
+
+//Technology of the destinations of rules of no technology whose line doesn't name one
+const MESSAGING_UNKNOWN_TECHNOLOGY = "unknown"
+
+//Kind of the destinations that aren't obviously a queue, topic or exchange
+const MESSAGING_DESTINATION_KIND = "destination"
+
+//MessagingTechnology is a messaging technology (broker or client api), found by the tags of the findings of its rules.
+//The destinations of its Rules are its own, those of other rules are its when their line holds one of its Keywords.
+type MessagingTechnology struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Rules       []string `json:"rules"`
+	Keywords    []string `json:"keywords"`
+	Kind        string   `json:"kind"`
+}
+
+//MessagingUsage is the use an application makes of a messaging technology: its findings, their effort and the number
+//of its destinations
+type MessagingUsage struct {
+	Technology   string `json:"technology"`
+	Findings     int    `json:"findings"`
+	Effort       int    `json:"effort"`
+	Destinations int    `json:"destinations"`
+}
+
+//MessagingDestination is a queue, topic or exchange of an application, where it is first named and how many times
+type MessagingDestination struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Technology string `json:"technology"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Rule       string `json:"rule"`
+	Uses       int    `json:"uses"`
+}
+
+//MessagingInventory is the messaging an application uses, which has to be bound to (and provisioned on) a broker
+//before it is replatformed
+type MessagingInventory struct {
+	Application  string                 `json:"application"`
+	Technologies []MessagingUsage       `json:"technologies"`
+	Destinations []MessagingDestination `json:"destinations"`
+}
+
+//Technologies in order of precedence: specific brokers before the JMS api many of them implement
+var MessagingTechnologies = []MessagingTechnology{
+	{Name: "ibm-mq", Description: "IBM MQ (MQSeries)", Tags: []string{"ibm-mq"}, Kind: "queue",
+		Rules: []string{"messaging-ibm-mq-destination", "messaging-ibm-mq-definition"}, Keywords: []string{"ibm.mq", "mqseries", "MQQueue", "queue:///"}},
+	{Name: "tibco", Description: "TIBCO EMS", Tags: []string{"tibco"}, Kind: MESSAGING_DESTINATION_KIND,
+		Rules: []string{"messaging-tibco-destination"}, Keywords: []string{"tibjms", "tibco"}},
+	{Name: "activemq", Description: "ActiveMQ", Tags: []string{"activemq"}, Kind: MESSAGING_DESTINATION_KIND,
+		Keywords: []string{"activemq"}},
+	{Name: "kafka", Description: "Apache Kafka", Tags: []string{"kafka"}, Kind: "topic",
+		Rules: []string{"messaging-kafka-destination", "messaging-kafka-config"}, Keywords: []string{"kafka"}},
+	{Name: "rabbitmq", Description: "RabbitMQ (AMQP)", Tags: []string{"rabbitmq"}, Kind: "queue",
+		Rules: []string{"messaging-rabbitmq-destination"}, Keywords: []string{"rabbit", "amqp"}},
+	{Name: "jms", Description: "JMS api and message driven beans", Tags: []string{"jms", "mdb"}, Kind: MESSAGING_DESTINATION_KIND,
+		Rules: []string{"messaging-jms-destination", "messaging-jms-destination-xml"}, Keywords: []string{"jms"}},
+}
+
+//Arguments, attributes and settings naming a destination
+const destinationKeywords = `(?:destination(?:Name|Lookup)?|topics?(?:Name|Pattern)?|queues?(?:Name)?|baseQueueName|routing-key|propertyValue|value)`
+
+//The name of a destination is the (quoted) value of an argument or attribute naming it, else the value of a setting
+//naming it, else the first string of the line, else the first argument or the text of the element
+var destinationNameRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b` + destinationKeywords + `["']?\s*[=:]\s*[{\[(]?\s*["']([^"']+)["']`),
+	regexp.MustCompile(`(?i)\b` + destinationKeywords + `["']?\s*[=:]\s*([^\s"',;#]+)\s*(?:#.*)?$`),
+	regexp.MustCompile(`["']([^"']+)["']`),
+	regexp.MustCompile(`\(\s*([\w.\-/:]+)`),
+	regexp.MustCompile(`>\s*([^<\s]+)\s*<`),
+}
+
+var destinationKindRegexes = []struct {
+	kind  string
+	regex *regexp.Regexp
+}{
+	{kind: "exchange", regex: regexp.MustCompile(`(?i)exchange`)},
+	{kind: "topic", regex: regexp.MustCompile(`(?i)topic`)},
+	{kind: "queue", regex: regexp.MustCompile(`(?i)queue|\bQ(LOCAL|L|REMOTE|R|ALIAS|A|MODEL|M)\b`)},
+}
+
+//MessagingDestinationOf is the destination named by the finding of a messaging-destination rule: its name picked out
+//of the line, its technology (of the rule, else of the line) and kind (of the line, else of the technology)
+func MessagingDestinationOf(finding *Finding, technologies []MessagingTechnology) MessagingDestination {
+
+	line := strings.TrimSpace(finding.Value)
+	destination := MessagingDestination{Name: line, Technology: MESSAGING_UNKNOWN_TECHNOLOGY, Kind: MESSAGING_DESTINATION_KIND,
+		File: finding.Fqn, Line: finding.Line, Rule: finding.Rule, Uses: 1}
+
+	for _, regex := range destinationNameRegexes {
+		if match := regex.FindStringSubmatch(line); match != nil {
+			destination.Name = match[1]
+			break
+		}
+	}
+
+	technology := messagingTechnologyOf(finding.Rule, line, technologies)
+	if technology != nil {
+		destination.Technology = technology.Name
+		destination.Kind = technology.Kind
+	}
+
+	for _, kind := range destinationKindRegexes {
+		if kind.regex.MatchString(line) {
+			destination.Kind = kind.kind
+			break
+		}
+	}
+
+	return destination
+}
+
+//EvaluateMessaging inventories the messaging the application uses from the totals of its findings by tag and the
+//findings of its messaging-destination rules. A destination is listed once per technology, kind and name, where it
+//is first named. Technologies are sorted by findings, destinations by technology and name.
+func EvaluateMessaging(app string, tagTotals TagTotals, findings []Finding, technologies []MessagingTechnology) MessagingInventory {
+
+	inventory := MessagingInventory{Application: app, Technologies: []MessagingUsage{}, Destinations: []MessagingDestination{}}
+
+	//Several rules can name the same destination on a line, the one of the most specific technology is kept
+	sorted := make([]Finding, 0, len(findings))
+	for _, finding := range findings {
+		if finding.Application == app {
+			sorted = append(sorted, finding)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Fqn != sorted[j].Fqn {
+			return sorted[i].Fqn < sorted[j].Fqn
+		}
+		return sorted[i].Line < sorted[j].Line
+	})
+
+	byLine := make(map[string]int)
+	var named []MessagingDestination
+	for i := range sorted {
+		destination := MessagingDestinationOf(&sorted[i], technologies)
+		key := fmt.Sprintf("%s|%d", destination.File, destination.Line)
+		if index, found := byLine[key]; found {
+			if messagingTechnologyRank(destination.Technology, technologies) < messagingTechnologyRank(named[index].Technology, technologies) {
+				named[index] = destination
+			}
+			continue
+		}
+		byLine[key] = len(named)
+		named = append(named, destination)
+	}
+
+	byName := make(map[string]int)
+	for _, destination := range named {
+		key := destination.Technology + "|" + destination.Kind + "|" + destination.Name
+		if index, found := byName[key]; found {
+			inventory.Destinations[index].Uses++
+			continue
+		}
+		byName[key] = len(inventory.Destinations)
+		inventory.Destinations = append(inventory.Destinations, destination)
+	}
+
+	sort.SliceStable(inventory.Destinations, func(i, j int) bool {
+		if inventory.Destinations[i].Technology != inventory.Destinations[j].Technology {
+			return inventory.Destinations[i].Technology < inventory.Destinations[j].Technology
+		}
+		return inventory.Destinations[i].Name < inventory.Destinations[j].Name
+	})
+
+	//Rule tags aren't consistently cased
+	totals := make(TagTotals)
+	for tag, total := range tagTotals {
+		tag = strings.ToLower(tag)
+		totals[tag] = TagTotal{Findings: totals[tag].Findings + total.Findings, Effort: totals[tag].Effort + total.Effort}
+	}
+
+	destinations := make(map[string]int)
+	for _, destination := range inventory.Destinations {
+		destinations[destination.Technology]++
+	}
+
+	for _, technology := range technologies {
+		usage := MessagingUsage{Technology: technology.Name, Destinations: destinations[technology.Name]}
+		for _, tag := range technology.Tags {
+			usage.Findings += totals[tag].Findings
+			usage.Effort += totals[tag].Effort
+		}
+		if usage.Findings > 0 || usage.Destinations > 0 {
+			inventory.Technologies = append(inventory.Technologies, usage)
+		}
+	}
+	if destinations[MESSAGING_UNKNOWN_TECHNOLOGY] > 0 {
+		inventory.Technologies = append(inventory.Technologies, MessagingUsage{Technology: MESSAGING_UNKNOWN_TECHNOLOGY,
+			Destinations: destinations[MESSAGING_UNKNOWN_TECHNOLOGY]})
+	}
+
+	sort.SliceStable(inventory.Technologies, func(i, j int) bool {
+		return inventory.Technologies[i].Findings > inventory.Technologies[j].Findings
+	})
+
+	return inventory
+}
+
+//UsesMessaging is whether the application uses a messaging technology or names a destination
+func (m *MessagingInventory) UsesMessaging() bool {
+	return len(m.Technologies) > 0
+}
+
+func messagingTechnologyOf(rule string, line string, technologies []MessagingTechnology) *MessagingTechnology {
+
+	for i := range technologies {
+		for _, technologyRule := range technologies[i].Rules {
+			if technologyRule == rule {
+				return &technologies[i]
+			}
+		}
+	}
+
+	line = strings.ToLower(line)
+	for i := range technologies {
+		for _, keyword := range technologies[i].Keywords {
+			if strings.Contains(line, strings.ToLower(keyword)) {
+				return &technologies[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+func messagingTechnologyRank(name string, technologies []MessagingTechnology) int {
+	for i, technology := range technologies {
+		if technology.Name == name {
+			return i
+		}
+	}
+	return len(technologies)
+}
@@ -0,0 +1,176 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"csa-app/util"
+)
+
+//Module of the files of a multi-module application that are not part of any of its (sub) modules
+const ROOT_MODULE = "."
+
+//Build files marking the directory holding them as a module
+var moduleBuildFiles = []string{"pom.xml", "build.gradle", "build.gradle.kts"}
+
+//AppModule is a Maven/Gradle module of an application holding several, its directory relative to the
+//application root. Applications with a single module have none.
+type AppModule struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	RunID       uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application string    `gorm:"index;not null" json:"application" yaml:"application"`
+	Module      string    `gorm:"type:text" json:"module" yaml:"module"`
+	BuildFile   string    `gorm:"type:text" json:"buildFile,omitempty" yaml:"buildFile,omitempty"`
+	SlocCnt     int       `json:"slocCnt" yaml:"slocCnt"`
+	FilesCnt    int       `json:"filesCnt" yaml:"filesCnt"`
+}
+
+//ModuleScore is the score of a module of an application, scored like an application of its own from its findings
+type ModuleScore struct {
+	Application    string  `json:"application"`
+	Module         string  `json:"module"`
+	Findings       int     `json:"findings"`
+	RawScore       int     `json:"rawScore"`
+	SlocCnt        int     `json:"slocCnt"`
+	Score          float64 `json:"score"`
+	Tier           string  `json:"tier,omitempty"`
+	Recommendation string  `json:"recommendation"`
+	AppScore       float64 `json:"appScore"`
+}
+
+//DetectModules lists the modules of the application, the directories below its root holding a Maven or Gradle build
+//file, plus the root module holding the rest of its files. Build files in third party code are not modules. An
+//application without such directories has no modules.
+func DetectModules(runId uint, app *Application) []*AppModule {
+
+	buildFiles := make(map[string]string)
+	for _, files := range [][]*util.FileInfo{app.Files, app.IgnoredFiles} {
+		for _, file := range files {
+			if file.ThirdParty != "" || !isModuleBuildFile(filepath.Base(file.FQN)) {
+				continue
+			}
+			dir, err := filepath.Rel(app.Path, filepath.Dir(file.FQN))
+			if err != nil || strings.HasPrefix(dir, "..") {
+				continue
+			}
+			dir = filepath.ToSlash(dir)
+			if current, found := buildFiles[dir]; !found || filepath.Base(file.FQN) < current {
+				buildFiles[dir] = filepath.Base(file.FQN)
+			}
+		}
+	}
+
+	rootBuildFile := buildFiles[ROOT_MODULE]
+	delete(buildFiles, ROOT_MODULE)
+	if len(buildFiles) == 0 {
+		return nil
+	}
+
+	modules := []*AppModule{{RunID: runId, Application: app.Name, Module: ROOT_MODULE, BuildFile: rootBuildFile}}
+	for dir, buildFile := range buildFiles {
+		modules = append(modules, &AppModule{RunID: runId, Application: app.Name, Module: dir, BuildFile: buildFile})
+	}
+	sort.Slice(modules[1:], func(i, j int) bool { return modules[i+1].Module < modules[j+1].Module })
+
+	return modules
+}
+
+func isModuleBuildFile(name string) bool {
+	for _, buildFile := range moduleBuildFiles {
+		if name == buildFile {
+			return true
+		}
+	}
+	return false
+}
+
+//ModuleOf returns the innermost of the application's modules holding the file, the root module when none does. It
+//returns nothing for applications without modules.
+func (app *Application) ModuleOf(fqn string) string {
+
+	if len(app.Modules) == 0 {
+		return ""
+	}
+
+	rel, err := filepath.Rel(app.Path, fqn)
+	if err != nil {
+		return ROOT_MODULE
+	}
+	rel = filepath.ToSlash(rel)
+
+	module := ROOT_MODULE
+	for _, candidate := range app.Modules {
+		if strings.HasPrefix(rel, candidate.Module+"/") && len(candidate.Module) > len(module) {
+			module = candidate.Module
+		}
+	}
+
+	return module
+}
+
+//CountModuleSloc adds the lines of code of each file to its module
+func (app *Application) CountModuleSloc(sloc map[string]int) {
+
+	byModule := make(map[string]*AppModule)
+	for _, module := range app.Modules {
+		byModule[module.Module] = module
+	}
+
+	for fqn, code := range sloc {
+		if module := byModule[app.ModuleOf(fqn)]; module != nil {
+			module.SlocCnt += code
+			module.FilesCnt++
+		}
+	}
+}
+
+//ScoreModules scores each module like an application of its own, with the scoring model and business value of its
+//application and the details and tag totals of its findings. The modules of all the applications are scored
+//together, scorers like the percentile one ranking them against each other.
+func ScoreModules(apps []Application, modules []AppModule, details map[string]map[string]ApplicationDetails,
+	tagTotals map[string]map[string]TagTotals, scorer Scorer, bins []ScoreBin) ([]ModuleScore, error) {
+
+	byName := make(map[string]*Application)
+	for i := range apps {
+		byName[apps[i].Name] = &apps[i]
+	}
+
+	var scored []*Application
+	var scores []ModuleScore
+	for _, module := range modules {
+		app := byName[module.Application]
+		if app == nil {
+			continue
+		}
+
+		moduleDetails := details[module.Application][module.Module]
+		moduleApp := &Application{Name: fmt.Sprintf("%s/%s", module.Application, module.Module), Model: app.Model,
+			BusinessValue: app.BusinessValue, TagTotals: tagTotals[module.Application][module.Module]}
+		moduleApp.MergeDetails(moduleDetails)
+		moduleApp.SlocCnt = module.SlocCnt
+		moduleApp.FilesCnt = module.FilesCnt
+
+		scored = append(scored, moduleApp)
+		scores = append(scores, ModuleScore{Application: module.Application, Module: module.Module,
+			Findings: moduleDetails.Findings, RawScore: moduleDetails.RawScore, SlocCnt: module.SlocCnt, AppScore: app.Score})
+	}
+
+	err := scorer.Score(scored)
+
+	for i, moduleApp := range scored {
+		scores[i].Score = moduleApp.Score
+		scores[i].Recommendation = moduleApp.Recommendation
+		scores[i].Tier = ScoreBinName(bins, moduleApp.Score)
+	}
+
+	return scores, err
+}
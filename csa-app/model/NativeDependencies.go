@@ -0,0 +1,147 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//Findings of native methods, native libraries bundled with an application and their loading are tagged
+//native-dependency
+const NATIVE_DEPENDENCY_TAG = "native-dependency"
+
+//NativeDependencyKind is the kind of native dependency (jni method, loaded or bundled library...) the findings of a
+//rule name, and how the library is picked out of the line
+type NativeDependencyKind struct {
+	Rule    string
+	Kind    string
+	Library *regexp.Regexp
+}
+
+//NativeDependency is a native method, library load or bundled library of an application, where it is first found
+//and how many times. Library is the name the library is loaded by (libcodec.so and System.loadLibrary("codec") are
+//both codec), empty for native methods.
+type NativeDependency struct {
+	Kind    string `json:"kind"`
+	Library string `json:"library,omitempty"`
+	Value   string `json:"value"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Uses    int    `json:"uses"`
+	Effort  int    `json:"effort"`
+}
+
+//NativeDependencies is the native code an application depends on, each a blocker of running it in a container until
+//the library is built into the image for its platform or replaced
+type NativeDependencies struct {
+	Application  string             `json:"application"`
+	Findings     int                `json:"findings"`
+	Effort       int                `json:"effort"`
+	Libraries    []string           `json:"libraries"`
+	Dependencies []NativeDependency `json:"dependencies"`
+}
+
+var NativeDependencyKinds = []NativeDependencyKind{
+	{Rule: "java-jni", Kind: "jni"},
+	{Rule: "java-systemLoad", Kind: "load", Library: regexp.MustCompile(`\.load(?:Library)?\(\s*"([^"]+)"`)},
+	{Rule: "native-jna", Kind: "jna", Library: regexp.MustCompile(`\(\s*"([^"]+)"`)},
+	{Rule: "native-library-file", Kind: "bundled", Library: regexp.MustCompile(`^(.+)$`)},
+}
+
+//Extensions of native libraries, versioned ones included (libcodec.so.1.2)
+var nativeLibraryExtRegex = regexp.MustCompile(`(?i)\.(so|dylib|jnilib|dll)(\.\d+)*$`)
+
+//NativeLibraryName is the name a library is loaded by: its file name without the lib prefix and extension of native
+//libraries (/opt/codec/libcodec.so.1 is codec)
+func NativeLibraryName(library string) string {
+	name := path.Base(strings.ReplaceAll(library, `\`, "/"))
+	if ext := nativeLibraryExtRegex.FindString(name); ext != "" {
+		name = strings.TrimSuffix(name, ext)
+		if !strings.EqualFold(ext, ".dll") {
+			name = strings.TrimPrefix(name, "lib")
+		}
+	}
+	return name
+}
+
+//NativeDependencyOf is the native dependency found by the finding of a native-dependency rule: its kind (of the rule)
+//and the library picked out of its line
+func NativeDependencyOf(finding *Finding, kinds []NativeDependencyKind) NativeDependency {
+
+	line := strings.TrimSpace(finding.Value)
+	dependency := NativeDependency{Kind: finding.Rule, Value: line, File: finding.Fqn, Line: finding.Line, Uses: 1, Effort: finding.Effort}
+
+	for _, kind := range kinds {
+		if kind.Rule == finding.Rule {
+			dependency.Kind = kind.Kind
+			if kind.Library != nil {
+				if match := kind.Library.FindStringSubmatch(line); match != nil {
+					dependency.Library = NativeLibraryName(match[1])
+				}
+			}
+			break
+		}
+	}
+
+	return dependency
+}
+
+//EvaluateNativeDependencies lists the native dependencies of the application from the findings of its
+//native-dependency rules. A dependency is listed once per kind, library and value, where it is first found, and
+//sorted by kind and library.
+func EvaluateNativeDependencies(app string, findings []Finding, kinds []NativeDependencyKind) NativeDependencies {
+
+	dependencies := NativeDependencies{Application: app, Libraries: []string{}, Dependencies: []NativeDependency{}}
+
+	sorted := make([]Finding, 0, len(findings))
+	for _, finding := range findings {
+		if finding.Application == app {
+			sorted = append(sorted, finding)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Fqn != sorted[j].Fqn {
+			return sorted[i].Fqn < sorted[j].Fqn
+		}
+		return sorted[i].Line < sorted[j].Line
+	})
+
+	byValue := make(map[string]int)
+	libraries := make(map[string]bool)
+	for i := range sorted {
+		dependency := NativeDependencyOf(&sorted[i], kinds)
+		dependencies.Findings++
+		dependencies.Effort += dependency.Effort
+
+		if dependency.Library != "" && !libraries[dependency.Library] {
+			libraries[dependency.Library] = true
+			dependencies.Libraries = append(dependencies.Libraries, dependency.Library)
+		}
+
+		key := dependency.Kind + "|" + dependency.Library + "|" + dependency.Value
+		if index, found := byValue[key]; found {
+			dependencies.Dependencies[index].Uses++
+			dependencies.Dependencies[index].Effort += dependency.Effort
+			continue
+		}
+		byValue[key] = len(dependencies.Dependencies)
+		dependencies.Dependencies = append(dependencies.Dependencies, dependency)
+	}
+
+	sort.Strings(dependencies.Libraries)
+	sort.SliceStable(dependencies.Dependencies, func(i, j int) bool {
+		a, b := dependencies.Dependencies[i], dependencies.Dependencies[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Library < b.Library
+	})
+
+	return dependencies
+}
@@ -8,11 +8,13 @@ package model
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	"csa-app/util"
 	"gopkg.in/yaml.v3"
@@ -31,9 +33,12 @@ type Pattern struct {
 	Pattern       string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Empty if not overriding base rule regex
 	Value         string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
 	Advice        string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Replace       string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
 	Effort        int            `gorm:"type:bigint" json:"effort,omitempty" yaml:"effort,omitempty"`
 	Readiness     int            `gorm:"type:bigint" json:"readiness,omitempty" yaml:"readiness,omitempty"`
 	Criticality   string         `json:"criticality,omitempty" yaml:"criticality,omitempty"`
+	Severity      string         `gorm:"type:text" json:"severity,omitempty" yaml:"severity,omitempty"`
+	Confidence    string         `gorm:"type:text" json:"confidence,omitempty" yaml:"confidence,omitempty"`
 	Tag           string         `gorm:"index;type:text" json:"tag,omitempty" yaml:"tag,omitempty"`
 	Recipe        string         `gorm:"index;type:text" json:"recipe,omitempty" yaml:"recipe,omitempty"`
 	Category      string         `json:"category,omitempty" yaml:"category,omitempty"`
@@ -51,13 +56,57 @@ func (p *Pattern) IsValid(rule *Rule) error {
 	if p.Type == REGEX_MATCH_TYPE || rule.Type == REGEX_MATCH_TYPE {
 		if p.Pattern != "" {
 			patt := strings.Replace(p.Pattern, "%s", "submarker", 1)
-			_, err := regexp.Compile(patt)
+			_, err := util.CompileRegex(patt)
 
 			if err != nil {
 				return fmt.Errorf("regex pattern [%s] for pattern value [%s] is bad. Details: %s", p.Pattern, p.Value, err.Error())
 			}
 		}
 	}
+
+	if rule.Target == MULTILINE_TARGET && p.Type != REGEX_MATCH_TYPE && (p.Type != "" || rule.Type != REGEX_MATCH_TYPE) {
+		return fmt.Errorf("pattern value [%s] of a Rule with Target %s must be of type %s", p.Value, MULTILINE_TARGET, REGEX_MATCH_TYPE)
+	}
+
+	if p.Type == XPATH_MATCH_TYPE || (p.Type == "" && rule.Type == XPATH_MATCH_TYPE) {
+		//The expression is evaluated against the parsed document, not a line of it
+		if rule.Target != FILE_TARGET {
+			return fmt.Errorf("xpath pattern value [%s] requires Rule Target %s but was: %s", p.Value, FILE_TARGET, rule.Target)
+		}
+
+		expr := p.Pattern
+		if expr == "" {
+			expr = rule.DefaultPattern
+		}
+		if strings.Contains(expr, "%s") {
+			expr = strings.Replace(expr, "%s", p.Value, 1)
+		} else {
+			expr = p.Value
+		}
+
+		if _, err := xpath.Compile(expr); err != nil {
+			return fmt.Errorf("xpath expression [%s] for pattern value [%s] is bad. Details: %s", expr, p.Value, err.Error())
+		}
+	}
+
+	if p.Replace != "" || rule.Replace != "" {
+		if p.Type != REGEX_MATCH_TYPE && (p.Type != "" || rule.Type != REGEX_MATCH_TYPE) {
+			return fmt.Errorf("pattern value [%s] with a replacement must be of type %s", p.Value, REGEX_MATCH_TYPE)
+		}
+		if rule.Target != LINE_TARGET && rule.Target != MULTILINE_TARGET && rule.Target != CONTENTS_TARGET {
+			return fmt.Errorf("pattern value [%s] with a replacement requires Rule Target (%s|%s|%s) but was: %s",
+				p.Value, LINE_TARGET, MULTILINE_TARGET, CONTENTS_TARGET, rule.Target)
+		}
+	}
+
+	if err := ValidateSeverity(p.Severity); err != nil {
+		return err
+	}
+
+	if err := ValidateConfidence(p.Confidence); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -68,10 +117,13 @@ func (p *Pattern) ToString() string {
 	b.WriteString(fmt.Sprintf("\tPattern: %s", p.Pattern))
 	b.WriteString(fmt.Sprintf("\tValue: %s", p.Value))
 	b.WriteString(fmt.Sprintf("\tAdvice: %s", p.Advice))
+	b.WriteString(fmt.Sprintf("\tReplace: %s", p.Replace))
 	b.WriteString(fmt.Sprintf("\tEffort: %d", p.Effort))
 	b.WriteString(fmt.Sprintf("\tReadiness: %d", p.Readiness))
 	b.WriteString(fmt.Sprintf("\tTag: %s", p.Tag))
 	b.WriteString(fmt.Sprintf("\tCategory: %s", p.Category))
+	b.WriteString(fmt.Sprintf("\tSeverity: %s", p.Severity))
+	b.WriteString(fmt.Sprintf("\tConfidence: %s", p.Confidence))
 	b.WriteString(fmt.Sprintf("\tRecipe: %s", p.Recipe))
 
 	return b.String()
@@ -103,7 +155,7 @@ func (p *Pattern) compile(rule *Rule) {
 	}
 
 	if p.Type == REGEX_MATCH_TYPE {
-		p.compiledRegex = regexp.MustCompile(p.Pattern)
+		p.compiledRegex = util.MustCompileRegex(p.Pattern)
 	}
 }
 
@@ -147,7 +199,7 @@ func (p *Pattern) Match(target string) (bool, string) {
 	switch p.Type {
 
 	case REGEX_MATCH_TYPE:
-		if p.compiledRegex.MatchString(target) {
+		if util.MatchRegex(p.compiledRegex, target) {
 			return true, ""
 		}
 	case STARTS_WITH_CI_MATCH_TYPE:
@@ -186,3 +238,45 @@ func (p *Pattern) Match(target string) (bool, string) {
 
 	return false, ""
 }
+
+//LineRange is a match of a multiline pattern. Lines are 1 based and EndLine is the line holding the last matched character.
+type LineRange struct {
+	Line    int
+	EndLine int
+	Value   string
+}
+
+//MatchRanges returns every match of a (compiled) regex pattern in the contents along with the lines it spans
+func (p *Pattern) MatchRanges(contents string) (ranges []LineRange) {
+
+	if p.compiledRegex == nil {
+		return nil
+	}
+
+	matches := util.FindAllRegex(p.compiledRegex, contents)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	//Offsets of each line's first character, searched for the line of a match's first and last character
+	lineStarts := []int{0}
+	for i := 0; i < len(contents); i++ {
+		if contents[i] == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	lineOf := func(offset int) int {
+		return sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > offset })
+	}
+
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		last := end - 1
+		if last < start {
+			last = start
+		}
+		ranges = append(ranges, LineRange{Line: lineOf(start), EndLine: lineOf(last), Value: contents[start:end]})
+	}
+
+	return ranges
+}
@@ -0,0 +1,147 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+)
+
+//PortfolioApp is an application of a run ranked by its score, the best scoring application first. The top quarter of
+//the ranking is quartile 1. Deployment is its deployment model (embedded or external server), see ClassifyDeployment.
+type PortfolioApp struct {
+	Rank           int     `json:"rank"`
+	Application    string  `json:"application"`
+	Score          float64 `json:"score"`
+	Tier           string  `json:"tier,omitempty"`
+	Recommendation string  `json:"recommendation"`
+	Deployment     string  `json:"deployment"`
+	Quartile       int     `json:"quartile"`
+	Findings       int     `json:"findings"`
+	Effort         int     `json:"effort"`
+	SlocCnt        int     `json:"slocCnt"`
+}
+
+//PortfolioQuartile is the aggregate of the applications of a quarter of the ranking
+type PortfolioQuartile struct {
+	Quartile     int     `json:"quartile"`
+	Applications int     `json:"applications"`
+	MinScore     float64 `json:"minScore"`
+	MaxScore     float64 `json:"maxScore"`
+	Score        float64 `json:"score"`
+	Findings     int     `json:"findings"`
+	Effort       int     `json:"effort"`
+	SlocCnt      int     `json:"slocCnt"`
+}
+
+//PortfolioTag is the number and effort of the findings carrying a tag across all the applications of a run
+type PortfolioTag struct {
+	Tag          string `json:"tag"`
+	Applications int    `json:"applications"`
+	Findings     int    `json:"findings"`
+	Effort       int    `json:"effort"`
+}
+
+//Portfolio aggregates all the applications of a run: their ranking, totals, quartiles, deployment models and findings
+//by tag
+type Portfolio struct {
+	RunID        uint                `json:"runId"`
+	Applications int                 `json:"applications"`
+	Score        float64             `json:"score"`
+	MedianScore  float64             `json:"medianScore"`
+	Findings     int                 `json:"findings"`
+	Effort       int                 `json:"effort"`
+	SlocCnt      int                 `json:"slocCnt"`
+	Ranking      []PortfolioApp      `json:"ranking"`
+	Quartiles    []PortfolioQuartile `json:"quartiles"`
+	Deployments  map[string]int      `json:"deployments"`
+	Tags         []PortfolioTag      `json:"tags"`
+}
+
+//NewPortfolio ranks the applications of a run by their score, applications with the same score sharing a rank, and
+//aggregates them as a whole, by quartile and by the tags of their first party findings. Tiers come from the score bins.
+func NewPortfolio(runId uint, apps []Application, tagTotals map[string]TagTotals, bins []ScoreBin) *Portfolio {
+
+	portfolio := &Portfolio{RunID: runId, Applications: len(apps), Ranking: []PortfolioApp{}, Quartiles: []PortfolioQuartile{},
+		Deployments: map[string]int{}, Tags: []PortfolioTag{}}
+
+	ranked := make([]Application, len(apps))
+	copy(ranked, apps)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+
+	var totalScore float64
+	for i, app := range ranked {
+		entry := PortfolioApp{Rank: i + 1, Application: app.Name, Score: app.Score, Tier: ScoreBinName(bins, app.Score),
+			Recommendation: app.Recommendation, Deployment: ClassifyDeployment(app.Name, tagTotals[app.Name]).Model,
+			Quartile: i*4/len(ranked) + 1, Findings: app.Findings, Effort: app.RawScore, SlocCnt: app.SlocCnt}
+		if i > 0 && app.Score == ranked[i-1].Score {
+			entry.Rank = portfolio.Ranking[i-1].Rank
+		}
+		portfolio.Ranking = append(portfolio.Ranking, entry)
+		portfolio.Deployments[entry.Deployment]++
+
+		totalScore += app.Score
+		portfolio.Findings += app.Findings
+		portfolio.Effort += app.RawScore
+		portfolio.SlocCnt += app.SlocCnt
+
+		quartile := len(portfolio.Quartiles) - 1
+		if quartile < 0 || portfolio.Quartiles[quartile].Quartile != entry.Quartile {
+			portfolio.Quartiles = append(portfolio.Quartiles, PortfolioQuartile{Quartile: entry.Quartile, MinScore: app.Score, MaxScore: app.Score})
+			quartile++
+		}
+		q := &portfolio.Quartiles[quartile]
+		q.Applications++
+		q.MinScore = app.Score
+		q.Score += app.Score
+		q.Findings += app.Findings
+		q.Effort += app.RawScore
+		q.SlocCnt += app.SlocCnt
+	}
+
+	if len(ranked) == 0 {
+		return portfolio
+	}
+
+	portfolio.Score = totalScore / float64(len(ranked))
+	middle := len(ranked) / 2
+	portfolio.MedianScore = ranked[middle].Score
+	if len(ranked)%2 == 0 {
+		portfolio.MedianScore = (ranked[middle-1].Score + ranked[middle].Score) / 2
+	}
+
+	for i := range portfolio.Quartiles {
+		portfolio.Quartiles[i].Score /= float64(portfolio.Quartiles[i].Applications)
+	}
+
+	byTag := make(map[string]*PortfolioTag)
+	for _, app := range ranked {
+		for tag, total := range tagTotals[app.Name] {
+			if byTag[tag] == nil {
+				byTag[tag] = &PortfolioTag{Tag: tag}
+			}
+			byTag[tag].Applications++
+			byTag[tag].Findings += total.Findings
+			byTag[tag].Effort += total.Effort
+		}
+	}
+
+	for _, tag := range byTag {
+		portfolio.Tags = append(portfolio.Tags, *tag)
+	}
+	sort.Slice(portfolio.Tags, func(i, j int) bool {
+		if portfolio.Tags[i].Findings != portfolio.Tags[j].Findings {
+			return portfolio.Tags[i].Findings > portfolio.Tags[j].Findings
+		}
+		return portfolio.Tags[i].Tag < portfolio.Tags[j].Tag
+	})
+
+	return portfolio
+}
@@ -0,0 +1,123 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"strings"
+)
+
+//Tags of the presentation tier metrics: scriptlets, tag libraries, session state and JSF component libraries
+const JSP_SCRIPTLET_TAG = "jsp-scriptlet"
+const JSP_TAGLIB_TAG = "jsp-taglib"
+const PRESENTATION_SESSION_TAG = "presentation-session"
+const JSF_COMPONENT_LIBRARY_TAG = "jsf-component-library"
+
+//SLOC languages of the pages (views) of the presentation tier
+var presentationLanguages = []string{"JSP", "XHTML"}
+
+//PresentationFramework is a framework of the presentation tier, found by the categories of its rules' findings
+type PresentationFramework struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories"`
+}
+
+//PresentationFrameworkResult is the effort of modernizing a framework of an application's presentation tier
+type PresentationFrameworkResult struct {
+	Framework string `json:"framework"`
+	Effort    int    `json:"effort"`
+}
+
+//PresentationTier is the presentation tier of an application: the volume of its pages (Pages, CodeLines), what makes
+//them hard to modernize (Scriptlets, Taglibs, SessionState, ComponentLibraries) and the effort of modernizing it,
+//apart from the effort of the rest of the application (BackendEffort). Share is the percentage of the application's
+//effort the presentation tier takes.
+type PresentationTier struct {
+	Application        string                        `json:"application"`
+	Pages              int                           `json:"pages"`
+	CodeLines          int                           `json:"codeLines"`
+	Scriptlets         int                           `json:"scriptlets"`
+	Taglibs            int                           `json:"taglibs"`
+	SessionState       int                           `json:"sessionState"`
+	ComponentLibraries int                           `json:"componentLibraries"`
+	Effort             int                           `json:"effort"`
+	BackendEffort      int                           `json:"backendEffort"`
+	Share              int                           `json:"share"`
+	Frameworks         []PresentationFrameworkResult `json:"frameworks"`
+}
+
+var PresentationFrameworks = []PresentationFramework{
+	{Name: "jsp", Description: "JSP pages, scriptlets and tag libraries", Categories: []string{"jsp"}},
+	{Name: "jsf", Description: "JSF, Facelets and JSF component libraries",
+		Categories: []string{"jsf", "jsf-flow", "facelets", "myfaces", "tomahawk", "trinidad"}},
+	{Name: "tiles", Description: "Tiles layouts", Categories: []string{"tiles"}},
+	{Name: "struts", Description: "Struts actions, forms and tags", Categories: []string{"struts"}},
+}
+
+//EvaluatePresentationTier measures the presentation tier of the application from the SLOC of its pages, the totals of
+//its findings by tag and the effort of its findings by category. Findings of the categories of the frameworks are the
+//presentation tier's effort, all others the backend's.
+func EvaluatePresentationTier(app string, slocs []RunSloc, tagTotals TagTotals, categoryEffort map[string]int, frameworks []PresentationFramework) PresentationTier {
+
+	tier := PresentationTier{Application: app, Frameworks: []PresentationFrameworkResult{}}
+
+	for _, sloc := range slocs {
+		if sloc.Application == app && isPresentationLanguage(sloc.Lang) {
+			tier.Pages += sloc.TotalFiles
+			tier.CodeLines += sloc.CodeLines
+		}
+	}
+
+	//Rule tags and categories aren't consistently cased
+	totals := make(TagTotals)
+	for tag, total := range tagTotals {
+		tag = strings.ToLower(tag)
+		totals[tag] = TagTotal{Findings: totals[tag].Findings + total.Findings, Effort: totals[tag].Effort + total.Effort}
+	}
+	tier.Scriptlets = totals[JSP_SCRIPTLET_TAG].Findings
+	tier.Taglibs = totals[JSP_TAGLIB_TAG].Findings
+	tier.SessionState = totals[PRESENTATION_SESSION_TAG].Findings
+	tier.ComponentLibraries = totals[JSF_COMPONENT_LIBRARY_TAG].Findings
+
+	efforts := make(map[string]int)
+	total := 0
+	for category, effort := range categoryEffort {
+		efforts[strings.ToLower(category)] += effort
+		total += effort
+	}
+
+	for _, framework := range frameworks {
+		result := PresentationFrameworkResult{Framework: framework.Name}
+		for _, category := range framework.Categories {
+			result.Effort += efforts[category]
+		}
+		if result.Effort > 0 {
+			tier.Effort += result.Effort
+			tier.Frameworks = append(tier.Frameworks, result)
+		}
+	}
+
+	tier.BackendEffort = total - tier.Effort
+	if total > 0 {
+		tier.Share = tier.Effort * 100 / total
+	}
+
+	return tier
+}
+
+//HasPresentationTier is whether the application has pages or presentation tier findings
+func (t *PresentationTier) HasPresentationTier() bool {
+	return t.Pages > 0 || t.Effort > 0
+}
+
+func isPresentationLanguage(lang string) bool {
+	for _, language := range presentationLanguages {
+		if strings.EqualFold(lang, language) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const FAIL_BELOW_GATE = "fail-below"
+const FAIL_ON_TAG_GATE = "fail-on-tag"
+
+//QualityGate fails a run whose applications score below a threshold or have findings carrying some tags, so csa can
+//gate merges in CI
+type QualityGate struct {
+	FailBelow  float64  `json:"failBelow,omitempty"`
+	FailOnTags []string `json:"failOnTags,omitempty"`
+}
+
+//GateViolation is a gate an application of the run failed
+type GateViolation struct {
+	Gate        string  `json:"gate"`
+	Application string  `json:"application"`
+	Score       float64 `json:"score,omitempty"`
+	Tag         string  `json:"tag,omitempty"`
+	Findings    int     `json:"findings,omitempty"`
+}
+
+//GateResult is the machine readable outcome of a quality gate. The run passed when it has no violations.
+type GateResult struct {
+	RunID      uint            `json:"runId"`
+	Passed     bool            `json:"passed"`
+	Gate       QualityGate     `json:"gate"`
+	Violations []GateViolation `json:"violations"`
+}
+
+func NewQualityGate(failBelow float64, failOnTags []string) *QualityGate {
+
+	gate := &QualityGate{FailBelow: failBelow}
+	for _, tags := range failOnTags {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				gate.FailOnTags = append(gate.FailOnTags, tag)
+			}
+		}
+	}
+
+	return gate
+}
+
+//Enabled tells whether the gate checks anything. A score below zero is impossible, so a zero threshold checks nothing.
+func (g *QualityGate) Enabled() bool {
+	return g.FailBelow > 0 || len(g.FailOnTags) > 0
+}
+
+//Evaluate checks the scores and tag totals of the run's applications against the gate. Tags match regardless of case.
+//Only the first party findings that count against the score (see TagTotals) fail the tag gate.
+func (g *QualityGate) Evaluate(runId uint, apps []*Application) *GateResult {
+
+	result := &GateResult{RunID: runId, Gate: *g, Violations: []GateViolation{}}
+
+	sorted := append([]*Application(nil), apps...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, app := range sorted {
+		if g.FailBelow > 0 && app.Score < g.FailBelow {
+			result.Violations = append(result.Violations, GateViolation{Gate: FAIL_BELOW_GATE, Application: app.Name, Score: app.Score})
+		}
+		for _, tag := range g.FailOnTags {
+			findings := 0
+			for value, total := range app.TagTotals {
+				if strings.EqualFold(value, tag) {
+					findings += total.Findings
+				}
+			}
+			if findings > 0 {
+				result.Violations = append(result.Violations, GateViolation{Gate: FAIL_ON_TAG_GATE, Application: app.Name, Tag: tag,
+					Findings: findings})
+			}
+		}
+	}
+
+	result.Passed = len(result.Violations) == 0
+
+	return result
+}
+
+//Describe tells why the application failed the gate
+func (v GateViolation) Describe(gate QualityGate) string {
+	if v.Gate == FAIL_BELOW_GATE {
+		return fmt.Sprintf("score [%.2f] is below [%.2f]", v.Score, gate.FailBelow)
+	}
+	return fmt.Sprintf("[%d] finding(s) tagged [%s]", v.Findings, v.Tag)
+}
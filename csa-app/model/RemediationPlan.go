@@ -0,0 +1,150 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//RemediationPlan is the backlog of work for one application of a run: its findings grouped by category and rule
+type RemediationPlan struct {
+	RunID       uint
+	Application string
+	Path        string
+	Generated   time.Time
+	Findings    int
+	Effort      int
+	Owners      []string
+	Categories  []PlanCategory
+}
+
+type PlanCategory struct {
+	Name     string
+	Findings int
+	Effort   int
+	Items    []PlanItem
+}
+
+//PlanItem is one backlog entry: every finding of a rule along with the files it was found in and who owns them
+type PlanItem struct {
+	Rule        string
+	Category    string
+	Criticality string
+	Severity    string
+	Advice      string
+	Findings    int
+	Effort      int
+	Files       []string
+	Owners      []string
+	Recipes     []string
+}
+
+//BuildRemediationPlan groups the findings of an application into a plan. Bookkeeping findings (files analyzed, sloc)
+//and findings in third-party code are left out, since neither is work for the application's team.
+func BuildRemediationPlan(runId uint, app *Application, findings []Finding, owners *CodeOwners) *RemediationPlan {
+
+	plan := &RemediationPlan{RunID: runId, Application: app.Name, Path: app.Path, Generated: time.Now()}
+
+	type itemKey struct{ category, rule string }
+	items := make(map[itemKey]*PlanItem)
+	files := make(map[itemKey]map[string]bool)
+	itemOwners := make(map[itemKey]map[string]bool)
+	itemRecipes := make(map[itemKey]map[string]bool)
+	planOwners := make(map[string]bool)
+
+	for i := range findings {
+		finding := &findings[i]
+		if finding.Application != app.Name || finding.ThirdParty != "" ||
+			finding.Category == FILE_ANALYZED_CATEGORY || finding.Category == SLOC_CATEGORY {
+			continue
+		}
+
+		key := itemKey{finding.Category, finding.Rule}
+		item, ok := items[key]
+		if !ok {
+			item = &PlanItem{Rule: finding.Rule, Category: finding.Category, Criticality: finding.Criticality,
+				Severity: finding.Severity, Advice: finding.Advice}
+			items[key] = item
+			files[key] = make(map[string]bool)
+			itemOwners[key] = make(map[string]bool)
+			itemRecipes[key] = make(map[string]bool)
+		}
+		item.Findings++
+		item.Effort += finding.Effort
+
+		relPath := finding.Fqn
+		if rel, err := filepath.Rel(app.Path, finding.Fqn); err == nil && !strings.HasPrefix(rel, "..") {
+			relPath = filepath.ToSlash(rel)
+		}
+		files[key][relPath] = true
+
+		fileOwners := owners.Owners(relPath)
+		if len(fileOwners) == 0 {
+			fileOwners = []string{UNASSIGNED_OWNER}
+		}
+		for _, owner := range fileOwners {
+			itemOwners[key][owner] = true
+			planOwners[owner] = true
+		}
+
+		for _, recipe := range finding.Recipes {
+			itemRecipes[key][recipe.URI] = true
+		}
+	}
+
+	categories := make(map[string]*PlanCategory)
+	for key, item := range items {
+		item.Files = sortedKeys(files[key])
+		item.Owners = sortedKeys(itemOwners[key])
+		item.Recipes = sortedKeys(itemRecipes[key])
+
+		category, ok := categories[key.category]
+		if !ok {
+			category = &PlanCategory{Name: key.category}
+			categories[key.category] = category
+		}
+		category.Items = append(category.Items, *item)
+		category.Findings += item.Findings
+		category.Effort += item.Effort
+	}
+
+	for _, category := range categories {
+		sort.Slice(category.Items, func(i, j int) bool {
+			if category.Items[i].Effort != category.Items[j].Effort {
+				return category.Items[i].Effort > category.Items[j].Effort
+			}
+			return category.Items[i].Rule < category.Items[j].Rule
+		})
+		plan.Categories = append(plan.Categories, *category)
+		plan.Findings += category.Findings
+		plan.Effort += category.Effort
+	}
+
+	//Biggest categories first, so the plan reads in the order the work is most worth doing
+	sort.Slice(plan.Categories, func(i, j int) bool {
+		if plan.Categories[i].Effort != plan.Categories[j].Effort {
+			return plan.Categories[i].Effort > plan.Categories[j].Effort
+		}
+		return plan.Categories[i].Name < plan.Categories[j].Name
+	})
+	plan.Owners = sortedKeys(planOwners)
+
+	return plan
+}
+
+func sortedKeys(set map[string]bool) []string {
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
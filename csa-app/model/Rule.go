@@ -16,29 +16,44 @@ import (
 )
 
 type Rule struct {
-	ID              uint           `gorm:"primary_key" json:"-" yaml:"-"`
-	CreatedAt       time.Time      `json:"-" yaml:"-"`
-	UpdatedAt       time.Time      `json:"-" yaml:"-"`
-	Name            string         `gorm:"type:text;unique_index;not null"`
-	FileType        string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Extension if empty or * then rule applies to all files. Else, rule only applies to files with this extension (sans '.')
-	FileNamePattern string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
-	Target          string         `gorm:"type:text"`                                     //File, Line
-	Type            string         `gorm:"type:text"`                                     //Regex, SimpleText, StartsWith, Contains, EndsWith, SimpleTextCaseInsensitive
-	DefaultPattern  string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Pattern with a placeholder that follows standard GO fmt.Sprintf rules. I.E. "[ .]%s[ (]" uses %s for string Pattern Value substitution before compilation!
-	Advice          string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
-	Effort          int            `gorm:"type:bigint; column:effort" json:",omitempty" yaml:",omitempty"`
-	Impact          string         `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
-	Readiness       int            `gorm:"type:bigint; column:readiness" json:",omitempty" yaml:",omitempty"`
-	Category        string         `json:",omitempty" yaml:",omitempty"`
-	Criticality     string         `json:",omitempty" yaml:",omitempty"`
-	Tags            []Tag          `json:",omitempty" yaml:",omitempty"`
-	Recipes         []Recipe       `gorm:"foreignkey:RuleID" json:",omitempty" yaml:",omitempty"`
-	Patterns        []Pattern      `gorm:"foreignkey:RuleID"`
-	fileNameRegex   *regexp.Regexp `gorm:"-" json:"-" yaml:"-"`
-	regex           *regexp.Regexp `gorm:"-" json:"-" yaml:"-"`
-	Metric          *RuleMetric    `gorm:"-" json:"-" yaml:"-"`
-	overrideApplies bool           `gorm:"-" json:"-" yaml:"-"`
-	Negative        bool           `gorm:"type:integer"`
+	ID              uint            `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt       time.Time       `json:"-" yaml:"-"`
+	UpdatedAt       time.Time       `json:"-" yaml:"-"`
+	Name            string          `gorm:"type:text;unique_index;not null"`
+	FileType        string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Extension if empty or * then rule applies to all files. Else, rule only applies to files with this extension (sans '.')
+	FileNamePattern string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Paths           Globs           `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	ExcludePaths    Globs           `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	MinSize         int64           `gorm:"type:bigint" json:",omitempty" yaml:",omitempty"`
+	MaxSize         int64           `gorm:"type:bigint" json:",omitempty" yaml:",omitempty"`
+	MaxDepth        int             `gorm:"type:bigint" json:",omitempty" yaml:",omitempty"`
+	Target          string          `gorm:"type:text"`                                     //File, Line
+	Type            string          `gorm:"type:text"`                                     //Regex, SimpleText, StartsWith, Contains, EndsWith, SimpleTextCaseInsensitive
+	DefaultPattern  string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Pattern with a placeholder that follows standard GO fmt.Sprintf rules. I.E. "[ .]%s[ (]" uses %s for string Pattern Value substitution before compilation!
+	Advice          string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Replace         string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Effort          int             `gorm:"type:bigint; column:effort" json:",omitempty" yaml:",omitempty"`
+	Impact          string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Readiness       int             `gorm:"type:bigint; column:readiness" json:",omitempty" yaml:",omitempty"`
+	Category        string          `json:",omitempty" yaml:",omitempty"`
+	Criticality     string          `json:",omitempty" yaml:",omitempty"`
+	Severity        string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Risk of findings (info|low|medium|high|critical) independent of effort
+	Confidence      string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //How certain a match is a real finding (low|medium|high), high if empty
+	Tags            []Tag           `json:",omitempty" yaml:",omitempty"`
+	Recipes         []Recipe        `gorm:"foreignkey:RuleID" json:",omitempty" yaml:",omitempty"`
+	Unless          []Exclusion     `gorm:"foreignkey:RuleID" json:",omitempty" yaml:",omitempty"` //Files matching any exclusion are not checked by the rule
+	Patterns        []Pattern       `gorm:"foreignkey:RuleID"`
+	fileNameRegex   *regexp.Regexp  `gorm:"-" json:"-" yaml:"-"`
+	regex           *regexp.Regexp  `gorm:"-" json:"-" yaml:"-"`
+	Metric          *RuleMetric     `gorm:"-" json:"-" yaml:"-"`
+	overrideApplies bool            `gorm:"-" json:"-" yaml:"-"`
+	Negative        bool            `gorm:"type:integer"`
+	Deprecated      bool            `gorm:"type:integer" json:",omitempty" yaml:",omitempty"`
+	ReplacedBy      string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Condition       string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Composite rules only. I.E. "persistence-xml AND NOT datasource-jndi"
+	condition       Condition       `gorm:"-" json:"-" yaml:"-"`
+	Script          string          `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //Script rules only. I.E. "count('synchronized') > 10"
+	script          *compiledScript `gorm:"-" json:"-" yaml:"-"`
 	sync.Mutex      `gorm:"-" json:"-" yaml:"-"`
 }
 
@@ -79,14 +94,22 @@ func (r *Rule) IsValid() (isValid bool, err error) {
 		}
 	}
 
+	if r.IsComposite() {
+		return r.compositeIsValid()
+	}
+
+	if r.IsScript() {
+		return r.scriptIsValid()
+	}
+
 	//Target
 	if r.Target == "" {
 		return false, fmt.Errorf("Rule Target is required!")
 	}
 
-	if r.Target != FILE_TARGET && r.Target != LINE_TARGET && r.Target != CONTENTS_TARGET {
-		return false, fmt.Errorf("Rule Target must be either (%s|%s|%s) but was: %s",
-			FILE_TARGET, LINE_TARGET, CONTENTS_TARGET, r.Target)
+	if r.Target != FILE_TARGET && r.Target != LINE_TARGET && r.Target != CONTENTS_TARGET && r.Target != MULTILINE_TARGET {
+		return false, fmt.Errorf("Rule Target must be either (%s|%s|%s|%s) but was: %s",
+			FILE_TARGET, LINE_TARGET, CONTENTS_TARGET, MULTILINE_TARGET, r.Target)
 	}
 
 	//Type
@@ -100,6 +123,32 @@ func (r *Rule) IsValid() (isValid bool, err error) {
 		return isValid, err
 	}
 
+	if err = ValidateSeverity(r.Severity); err != nil {
+		return false, fmt.Errorf("Rule %s", err.Error())
+	}
+
+	if err = ValidateConfidence(r.Confidence); err != nil {
+		return false, fmt.Errorf("Rule %s", err.Error())
+	}
+
+	if err = r.validateCweTags(); err != nil {
+		return false, fmt.Errorf("Rule %s", err.Error())
+	}
+
+	for _, exclusion := range r.Unless {
+		if err = exclusion.IsValid(); err != nil {
+			return false, err
+		}
+	}
+
+	if err = r.fileConditionsAreValid(); err != nil {
+		return false, err
+	}
+
+	if r.ReplacedBy != "" && (!r.Deprecated || r.ReplacedBy == r.Name) {
+		return false, fmt.Errorf("Rule ReplacedBy [%s] requires the rule to be deprecated and replaced by another rule", r.ReplacedBy)
+	}
+
 	//Patterns
 	if len(r.Patterns) < 1 {
 		return false, fmt.Errorf("Rule must have at least one (1) pattern")
@@ -163,21 +212,72 @@ func (r *Rule) CompilePatterns() {
 	}
 
 	//Compile the rule file type regex!
-	r.regex = regexp.MustCompile(r.FileType)
+	r.regex = util.MustCompileRegex(r.FileType)
 
 	//Compile the rule file name regex!
-	r.fileNameRegex = regexp.MustCompile(r.FileNamePattern)
+	r.fileNameRegex = util.MustCompileRegex(r.FileNamePattern)
 
 	for i, _ := range r.Patterns {
 		r.Patterns[i].compile(r)
 	}
+
+	for i := range r.Unless {
+		r.Unless[i].compile()
+	}
+
+	if r.IsComposite() {
+		r.condition, _ = ParseCondition(r.Condition)
+	}
+
+	if r.IsScript() {
+		r.script, _ = compileScript(r.Script)
+	}
+}
+
+//DeprecationNotice is the warning given when a deprecated rule is loaded. Empty if the rule isn't deprecated.
+func (r *Rule) DeprecationNotice() string {
+	if !r.Deprecated {
+		return ""
+	}
+	if r.ReplacedBy != "" {
+		return fmt.Sprintf("Rule [%s] is deprecated and replaced by rule [%s]", r.Name, r.ReplacedBy)
+	}
+	return fmt.Sprintf("Rule [%s] is deprecated", r.Name)
+}
+
+func (r *Rule) IsComposite() bool {
+	return r.Type == COMPOSITE_MATCH_TYPE
+}
+
+//ConditionMet evaluates a (compiled) composite rule. matched reports whether a rule matched within the file/application.
+func (r *Rule) ConditionMet(matched func(rule string) bool) bool {
+	return r.condition != nil && r.condition.Eval(matched)
+}
+
+//ExcludedFrom checks the rule's exclusions against a file. contents is only called (once) if an exclusion needs it.
+func (r *Rule) ExcludedFrom(fqn string, contents func() []byte) bool {
+	return r.ExcludedBy(fqn, contents) != nil
+}
+
+//ExcludedBy returns the first of the rule's exclusions that applies to the file or nil if none do
+func (r *Rule) ExcludedBy(fqn string, contents func() []byte) *Exclusion {
+	for i := range r.Unless {
+		var data []byte
+		if r.Unless[i].NeedsContents() {
+			data = contents()
+		}
+		if r.Unless[i].Excludes(fqn, data) {
+			return &r.Unless[i]
+		}
+	}
+	return nil
 }
 
 func (r *Rule) GetEscapedPattern() string {
 	return util.EscapeSpecials(r.DefaultPattern)
 }
 
-func (r *Rule) UpdateRule(newRule Rule) (deletedPatterns []Pattern, deletedRecipes []Recipe, deletedTags []Tag) {
+func (r *Rule) UpdateRule(newRule Rule) (deletedPatterns []Pattern, deletedRecipes []Recipe, deletedTags []Tag, deletedExclusions []Exclusion) {
 
 	if newRule.Advice != "" && newRule.Advice != r.Advice {
 		r.Advice = newRule.Advice
@@ -215,9 +315,39 @@ func (r *Rule) UpdateRule(newRule Rule) (deletedPatterns []Pattern, deletedRecip
 		r.Criticality = newRule.Criticality
 	}
 
+	if newRule.Severity != "" && newRule.Severity != r.Severity {
+		r.Severity = newRule.Severity
+	}
+
+	if newRule.Confidence != "" && newRule.Confidence != r.Confidence {
+		r.Confidence = newRule.Confidence
+	}
+
+	if newRule.Condition != "" && newRule.Condition != r.Condition {
+		r.Condition = newRule.Condition
+	}
+
+	if newRule.Script != "" && newRule.Script != r.Script {
+		r.Script = newRule.Script
+	}
+
+	if newRule.Replace != r.Replace {
+		r.Replace = newRule.Replace
+	}
+
+	if newRule.Deprecated != r.Deprecated || newRule.ReplacedBy != r.ReplacedBy {
+		r.Deprecated, r.ReplacedBy = newRule.Deprecated, newRule.ReplacedBy
+	}
+
+	if !r.sameFileConditions(&newRule) {
+		r.Paths, r.ExcludePaths = newRule.Paths, newRule.ExcludePaths
+		r.MinSize, r.MaxSize, r.MaxDepth = newRule.MinSize, newRule.MaxSize, newRule.MaxDepth
+	}
+
 	deletedPatterns = r.updatePatterns(newRule)
 	deletedRecipes = r.updateRecipes(newRule)
 	deletedTags = r.updateTags(newRule)
+	deletedExclusions = r.updateExclusions(newRule)
 
 	return
 }
@@ -255,6 +385,21 @@ func (r *Rule) updatePatterns(newRule Rule) (deleted []Pattern) {
 						patternUpdated = true
 					}
 
+					if pattern.Replace != r.Patterns[i].Replace {
+						r.Patterns[i].Replace = pattern.Replace
+						patternUpdated = true
+					}
+
+					if pattern.Severity != "" && pattern.Severity != r.Patterns[i].Severity {
+						r.Patterns[i].Severity = pattern.Severity
+						patternUpdated = true
+					}
+
+					if pattern.Confidence != "" && pattern.Confidence != r.Patterns[i].Confidence {
+						r.Patterns[i].Confidence = pattern.Confidence
+						patternUpdated = true
+					}
+
 					if pattern.Type != "" && pattern.Type != r.Patterns[i].Type {
 						r.Patterns[i].Type = pattern.Type
 						patternUpdated = true
@@ -350,6 +495,45 @@ func (r *Rule) updateRecipes(newRule Rule) (deleted []Recipe) {
 	return deleted
 }
 
+//updateExclusions replaces the rule's exclusions with those of the new rule
+func (r *Rule) updateExclusions(newRule Rule) (deleted []Exclusion) {
+
+	for _, exclusion := range r.Unless {
+		found := false
+		for _, newExclusion := range newRule.Unless {
+			if exclusion.Target == newExclusion.Target && exclusion.Pattern == newExclusion.Pattern {
+				found = true
+				break
+			}
+		}
+		if !found {
+			deleted = append(deleted, exclusion)
+		}
+	}
+
+	var kept []Exclusion
+	for _, newExclusion := range newRule.Unless {
+		matched := false
+		for _, exclusion := range r.Unless {
+			if exclusion.Target == newExclusion.Target && exclusion.Pattern == newExclusion.Pattern {
+				kept = append(kept, exclusion)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			kept = append(kept, newExclusion)
+		}
+	}
+	r.Unless = kept
+
+	if *util.Verbose && len(newRule.Unless)+len(deleted) > 0 {
+		fmt.Printf(" Exclusions[kept:%d deleted:%d] ", len(r.Unless), len(deleted))
+	}
+
+	return deleted
+}
+
 func (r *Rule) updateTags(newRule Rule) (deleted []Tag) {
 
 	var newTags int
@@ -418,6 +602,47 @@ func GetRulesDir(isImport bool) string {
 
 }
 
+func (r *Rule) compositeIsValid() (isValid bool, err error) {
+
+	if r.Target != FILE_TARGET && r.Target != APPLICATION_TARGET {
+		return false, fmt.Errorf("Composite Rule Target must be either (%s|%s) but was: %s", FILE_TARGET, APPLICATION_TARGET, r.Target)
+	}
+
+	condition, err := ParseCondition(r.Condition)
+	if err != nil {
+		return false, fmt.Errorf("Composite Rule Condition is invalid! Details: %v", err)
+	}
+
+	for _, rule := range condition.Rules() {
+		if rule == r.Name {
+			return false, fmt.Errorf("Composite Rule Condition cannot reference the rule itself")
+		}
+	}
+
+	if len(r.Patterns) > 0 {
+		return false, fmt.Errorf("Composite Rule cannot have patterns. Its condition decides if it matches")
+	}
+
+	isValid, err = r.impactIsValid()
+	if !isValid {
+		return isValid, err
+	}
+
+	if err = ValidateSeverity(r.Severity); err != nil {
+		return false, fmt.Errorf("Rule %s", err.Error())
+	}
+
+	if err = ValidateConfidence(r.Confidence); err != nil {
+		return false, fmt.Errorf("Rule %s", err.Error())
+	}
+
+	if err = r.validateCweTags(); err != nil {
+		return false, fmt.Errorf("Rule %s", err.Error())
+	}
+
+	return true, nil
+}
+
 func (r *Rule) typeIsValid() (isValid bool, err error) {
 	//Type
 	if r.Type == "" {
@@ -0,0 +1,67 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+)
+
+//RuleCoverage is how often a rule loaded by a run matched and which files it matched in
+type RuleCoverage struct {
+	Rule        string
+	Criticality string
+	Deprecation string
+	Checks      int64
+	Matches     int
+	Files       []string
+}
+
+//BuildRuleCoverage lists every rule loaded by a run (its rule metrics) with its findings, rules that never matched first
+func BuildRuleCoverage(metrics []RuleMetric, findings []Finding) []RuleCoverage {
+
+	byRule := make(map[string]*RuleCoverage)
+	files := make(map[string]map[string]bool)
+
+	add := func(rule string, criticality string) *RuleCoverage {
+		coverage, found := byRule[rule]
+		if !found {
+			coverage = &RuleCoverage{Rule: rule, Criticality: criticality}
+			byRule[rule] = coverage
+			files[rule] = make(map[string]bool)
+		}
+		return coverage
+	}
+
+	for i := range metrics {
+		coverage := add(metrics[i].Rule, metrics[i].RuleCriticality)
+		coverage.Checks += metrics[i].Checks
+		coverage.Deprecation = metrics[i].Deprecation()
+	}
+
+	for i := range findings {
+		//File and sloc findings aren't made by rules
+		if findings[i].Rule == "" {
+			continue
+		}
+		add(findings[i].Rule, findings[i].Criticality).Matches++
+		files[findings[i].Rule][findings[i].Fqn] = true
+	}
+
+	coverage := make([]RuleCoverage, 0, len(byRule))
+	for rule, ruleCoverage := range byRule {
+		ruleCoverage.Files = sortedKeys(files[rule])
+		coverage = append(coverage, *ruleCoverage)
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Matches != coverage[j].Matches {
+			return coverage[i].Matches < coverage[j].Matches
+		}
+		return coverage[i].Rule < coverage[j].Rule
+	})
+
+	return coverage
+}
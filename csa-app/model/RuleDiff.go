@@ -0,0 +1,207 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	RULE_ADDED    = "added"
+	RULE_REMOVED  = "removed"
+	RULE_MODIFIED = "modified"
+)
+
+//RuleChange is how the upstream rule pack differs from the local rule set for one rule
+type RuleChange struct {
+	Rule     string
+	Change   string
+	Fields   []string
+	Upstream *Rule
+}
+
+//ReadRulePack reads the rules of an upstream rule pack: a .tgz/.tar.gz release or a directory of rule files.
+//Invalid rules are reported as errors prefixed with the file they are in and skipped.
+func ReadRulePack(path string) (rules []Rule, errs []string) {
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	add := func(name string, reader io.Reader) {
+		if !isRuleFile(name) {
+			return
+		}
+		fileRules, fileErrs := ValidateRuleFile(reader, strings.HasSuffix(strings.ToLower(name), ".json"))
+		for _, fileErr := range fileErrs {
+			errs = append(errs, fmt.Sprintf("%s:%d:%d: %s", name, fileErr.Line, fileErr.Column, fileErr.Message))
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	if info.IsDir() {
+		err = filepath.Walk(path, func(file string, fileInfo os.FileInfo, err error) error {
+			if err != nil || fileInfo.IsDir() {
+				return err
+			}
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			add(file, bytes.NewReader(data))
+			return nil
+		})
+	} else {
+		err = readTgz(path, add)
+	}
+
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	return rules, errs
+}
+
+func readTgz(path string, add func(name string, reader io.Reader)) error {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("[%s] is not a gzipped tar rule pack: %v", path, err)
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			add(header.Name, archive)
+		}
+	}
+}
+
+func isRuleFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return (ext == ".yaml" || ext == ".yml" || ext == ".json") && !IsRuleTestFile(name) && !strings.HasPrefix(filepath.Base(name), ".")
+}
+
+//DiffRules compares the local rules with those of an upstream pack, sorted by rule name. Modified rules list the
+//(exported) fields that differ. The order of tags, recipes, exclusions and patterns is not significant.
+func DiffRules(local []Rule, upstream []Rule) []RuleChange {
+
+	localByName := make(map[string]*Rule)
+	for i := range local {
+		localByName[local[i].Name] = &local[i]
+	}
+
+	upstreamByName := make(map[string]*Rule)
+	for i := range upstream {
+		upstreamByName[upstream[i].Name] = &upstream[i]
+	}
+
+	var changes []RuleChange
+
+	for name, upstreamRule := range upstreamByName {
+		localRule, found := localByName[name]
+		if !found {
+			changes = append(changes, RuleChange{Rule: name, Change: RULE_ADDED, Upstream: upstreamRule})
+			continue
+		}
+
+		if fields := changedFields(localRule, upstreamRule); len(fields) > 0 {
+			changes = append(changes, RuleChange{Rule: name, Change: RULE_MODIFIED, Fields: fields, Upstream: upstreamRule})
+		}
+	}
+
+	for name := range localByName {
+		if _, found := upstreamByName[name]; !found {
+			changes = append(changes, RuleChange{Rule: name, Change: RULE_REMOVED})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Rule < changes[j].Rule })
+
+	return changes
+}
+
+func changedFields(local *Rule, upstream *Rule) []string {
+
+	localFields := ruleFields(local)
+	upstreamFields := ruleFields(upstream)
+
+	names := make(map[string]bool)
+	for name := range localFields {
+		names[name] = true
+	}
+	for name := range upstreamFields {
+		names[name] = true
+	}
+
+	var changed []string
+	for name := range names {
+		if localFields[name] != upstreamFields[name] {
+			changed = append(changed, name)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+//ruleFields returns the json of each exported field of the rule, with list fields in a canonical order
+func ruleFields(rule *Rule) map[string]string {
+
+	fields := make(map[string]string)
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return fields
+	}
+
+	var values map[string]json.RawMessage
+	if err = json.Unmarshal(data, &values); err != nil {
+		return fields
+	}
+
+	for name, value := range values {
+		var items []json.RawMessage
+		if json.Unmarshal(value, &items) == nil {
+			var canonical []string
+			for _, item := range items {
+				canonical = append(canonical, string(item))
+			}
+			sort.Strings(canonical)
+			fields[name] = strings.Join(canonical, ",")
+		} else {
+			fields[name] = string(value)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,299 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	OVERLAP_DUPLICATE  = "duplicate"
+	OVERLAP_EQUIVALENT = "equivalent"
+	OVERLAP_SUBSUMES   = "subsumes"
+)
+
+//Upper bound of sample matches generated per pattern
+const maxLintSamples = 32
+
+//RuleOverlap is a pair of patterns where every line (or file) the second matches is also matched by the first, so both
+//rules record a finding for it. When the rules share a tag the same API is counted twice against that tag.
+type RuleOverlap struct {
+	Rule         string
+	Pattern      string
+	Overlap      string
+	OtherRule    string
+	OtherPattern string
+	SharedTags   []string
+	Sample       string
+}
+
+type lintPattern struct {
+	rule    *Rule
+	pattern *Pattern
+	samples []string
+}
+
+//LintRules finds the overlapping patterns of rules that can see the same files. Subsumption is decided on samples
+//generated from each pattern, so it is evidence rather than proof. Composite, script, plugin and structured
+//(xpath/jsonpath/yamlpath) patterns are not compared. Rules are compiled in place.
+func LintRules(rules []Rule) (overlaps []RuleOverlap) {
+
+	var patterns []*lintPattern
+	fileTypes := make(map[*Rule][]string)
+	for i := range rules {
+		if rules[i].IsComposite() || rules[i].IsScript() {
+			continue
+		}
+		rules[i].CompilePatterns()
+		fileTypes[&rules[i]] = fileTypeSamples(rules[i].FileType)
+		for j := range rules[i].Patterns {
+			if lp := newLintPattern(&rules[i], &rules[i].Patterns[j]); lp != nil {
+				patterns = append(patterns, lp)
+			}
+		}
+	}
+
+	for a := 0; a < len(patterns); a++ {
+		for b := a + 1; b < len(patterns); b++ {
+			first, second := patterns[a], patterns[b]
+			if !sameFiles(first.rule, second.rule, fileTypes) {
+				continue
+			}
+
+			firstCovers, sample := first.covers(second)
+			secondCovers, otherSample := second.covers(first)
+
+			overlap := RuleOverlap{SharedTags: sharedTags(first.rule, second.rule)}
+			switch {
+			case first.pattern.Type == second.pattern.Type && first.pattern.Pattern == second.pattern.Pattern:
+				overlap.Overlap = OVERLAP_DUPLICATE
+			case firstCovers && secondCovers:
+				overlap.Overlap = OVERLAP_EQUIVALENT
+			case firstCovers:
+				overlap.Overlap = OVERLAP_SUBSUMES
+			case secondCovers:
+				overlap.Overlap = OVERLAP_SUBSUMES
+				first, second, sample = second, first, otherSample
+			default:
+				continue
+			}
+
+			overlap.Rule, overlap.Pattern = first.rule.Name, first.pattern.Pattern
+			overlap.OtherRule, overlap.OtherPattern = second.rule.Name, second.pattern.Pattern
+			overlap.Sample = sample
+			overlaps = append(overlaps, overlap)
+		}
+	}
+
+	//Double counting first, as it inflates scores
+	sort.SliceStable(overlaps, func(i, j int) bool {
+		if (len(overlaps[i].SharedTags) > 0) != (len(overlaps[j].SharedTags) > 0) {
+			return len(overlaps[i].SharedTags) > 0
+		}
+		if overlaps[i].Rule != overlaps[j].Rule {
+			return overlaps[i].Rule < overlaps[j].Rule
+		}
+		return overlaps[i].OtherRule < overlaps[j].OtherRule
+	})
+
+	return overlaps
+}
+
+func newLintPattern(rule *Rule, pattern *Pattern) *lintPattern {
+
+	var samples []string
+
+	switch pattern.Type {
+	case XPATH_MATCH_TYPE, JSONPATH_MATCH_TYPE, YAMLPATH_MATCH_TYPE, PLUGIN_MATCH_TYPE:
+		return nil
+	case REGEX_MATCH_TYPE:
+		re, err := syntax.Parse(pattern.Pattern, syntax.Perl)
+		if err != nil {
+			return nil
+		}
+		samples = regexSamples(re.Simplify())
+	default:
+		samples = []string{pattern.Pattern}
+	}
+
+	//Padded samples tell apart patterns anchored to the start or end of the target from those that aren't
+	lp := &lintPattern{rule: rule, pattern: pattern}
+	for _, sample := range samples {
+		for _, padded := range []string{sample, "x " + sample + " x", "x " + sample, sample + " x"} {
+			if matched, _ := pattern.Match(padded); matched {
+				lp.samples = append(lp.samples, padded)
+			}
+		}
+	}
+
+	if len(lp.samples) == 0 {
+		return nil
+	}
+
+	return lp
+}
+
+//covers is true when this pattern matches every sample of the other one. The sample is one they both match.
+func (lp *lintPattern) covers(other *lintPattern) (bool, string) {
+
+	for _, sample := range other.samples {
+		if matched, _ := lp.pattern.Match(sample); !matched {
+			return false, ""
+		}
+	}
+
+	return true, other.samples[0]
+}
+
+//sameFiles is true when both rules have the same target and may apply to the same files
+func sameFiles(first *Rule, second *Rule, fileTypes map[*Rule][]string) bool {
+
+	if first.Target != second.Target {
+		return false
+	}
+
+	if first.overrideApplies || second.overrideApplies {
+		return true
+	}
+
+	for _, ext := range fileTypes[first] {
+		if second.regex.MatchString(ext) {
+			return true
+		}
+	}
+	for _, ext := range fileTypes[second] {
+		if first.regex.MatchString(ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func fileTypeSamples(fileType string) []string {
+
+	re, err := syntax.Parse(fileType, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+
+	return regexSamples(re.Simplify())
+}
+
+func sharedTags(first *Rule, second *Rule) (shared []string) {
+
+	for _, tag := range first.Tags {
+		if second.HasTag(tag.Value) {
+			shared = append(shared, tag.Value)
+		}
+	}
+	sort.Strings(shared)
+
+	return shared
+}
+
+//regexSamples generates strings the regex matches: one per alternative, with optional parts both left out and included
+func regexSamples(re *syntax.Regexp) []string {
+
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return nil
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCharClass:
+		return []string{string(classSample(re.Rune))}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		//'.' reads best when a literal dot was meant, 'x' keeps others from matching by accident
+		return []string{".", "x"}
+	case syntax.OpCapture, syntax.OpPlus:
+		return regexSamples(re.Sub[0])
+	case syntax.OpStar, syntax.OpQuest:
+		return limitSamples(append([]string{""}, regexSamples(re.Sub[0])...))
+	case syntax.OpRepeat:
+		var samples []string
+		if re.Min == 0 {
+			samples = append(samples, "")
+		}
+		for _, sample := range regexSamples(re.Sub[0]) {
+			samples = append(samples, strings.Repeat(sample, maxInt(re.Min, 1)))
+		}
+		return limitSamples(samples)
+	case syntax.OpConcat:
+		samples := []string{""}
+		for _, sub := range re.Sub {
+			var next []string
+			for _, prefix := range samples {
+				for _, suffix := range regexSamples(sub) {
+					next = append(next, prefix+suffix)
+				}
+			}
+			samples = limitSamples(next)
+		}
+		return samples
+	case syntax.OpAlternate:
+		var samples []string
+		for _, sub := range re.Sub {
+			samples = append(samples, regexSamples(sub)...)
+		}
+		return limitSamples(samples)
+	default:
+		//empty matches, anchors and word boundaries consume nothing
+		return []string{""}
+	}
+}
+
+//classSample picks a printable rune of a character class (pairs of lo/hi ranges), preferring letters and digits
+func classSample(ranges []rune) rune {
+
+	for _, preferred := range []rune{'a', 'A', '0', ' ', '_', '.'} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= preferred && preferred <= ranges[i+1] {
+				return preferred
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1] && r-ranges[i] < 256; r++ {
+			if unicode.IsPrint(r) {
+				return r
+			}
+		}
+	}
+
+	if len(ranges) > 0 {
+		return ranges[0]
+	}
+
+	return 'x'
+}
+
+func limitSamples(samples []string) []string {
+
+	seen := make(map[string]bool)
+	var unique []string
+	for _, sample := range samples {
+		if !seen[sample] {
+			seen[sample] = true
+			unique = append(unique, sample)
+		}
+		if len(unique) == maxLintSamples {
+			break
+		}
+	}
+
+	return unique
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -17,6 +17,8 @@ type RuleMetric struct {
 	UpdatedAt       time.Time     `json:"-" yaml:"-"`
 	Rule            string        `gorm:"type:text;index;not null" json:"rule" yaml:"rule"`
 	RuleCriticality string        `gorm:"type:text;index;not null" json:"-" yaml:"-"`
+	RuleDeprecated  bool          `gorm:"type:integer" json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	RuleReplacedBy  string        `gorm:"type:text" json:"replacedBy,omitempty" yaml:"replacedBy,omitempty"`
 	RunID           uint          `gorm:"index;not null"`
 	Checks          int64         `json:"checks" yaml:"checks"`
 	PatternChecks   int64         `json:"patternChecks" yaml:"patternChecks"`
@@ -33,16 +35,52 @@ type RuleMetric struct {
 	AvgRuleStr      string        `json:"avgRule" yaml:"avgRule"`
 	AvgPatStr       string        `json:"avgPat" yaml:"avgPat"`
 	AvgHitStr       string        `json:"avgHit" yaml:"avgHit"`
+	SlowestPattern  string        `gorm:"type:text" json:"slowestPattern,omitempty" yaml:"slowestPattern,omitempty"`
+	SlowestPatTime  time.Duration `json:"-" yaml:"-"`
+	SlowestPatStr   string        `json:"slowestPatternTime,omitempty" yaml:"slowestPatternTime,omitempty"`
 	sync.Mutex      `gorm:"-" json:"-" yaml:"-"`
+
+	//Time spent matching each pattern (by value), only the slowest is persisted
+	patternTimes map[string]time.Duration
+}
+
+//Deprecation labels the findings of a deprecated rule for reports. Empty if the rule wasn't deprecated when the run loaded it.
+func (r *RuleMetric) Deprecation() string {
+	if !r.RuleDeprecated {
+		return ""
+	}
+	if r.RuleReplacedBy != "" {
+		return "replaced by " + r.RuleReplacedBy
+	}
+	return "deprecated"
+}
+
+//Deprecations maps each deprecated rule of the metrics to its Deprecation label
+func Deprecations(metrics []RuleMetric) map[string]string {
+	deprecations := make(map[string]string)
+	for i := range metrics {
+		if label := metrics[i].Deprecation(); label != "" {
+			deprecations[metrics[i].Rule] = label
+		}
+	}
+	return deprecations
 }
 
 func (r *RuleMetric) PrePersist() {
+	for pattern, duration := range r.patternTimes {
+		if duration > r.SlowestPatTime || (duration == r.SlowestPatTime && pattern < r.SlowestPattern) {
+			r.SlowestPattern = pattern
+			r.SlowestPatTime = duration
+		}
+	}
+
 	r.TotalTimeStr = r.TotalTime.String()
 	r.LongestStr = r.Longest.String()
 	r.ShortestStr = r.Shortest.String()
 	r.AvgRuleStr = r.AvgRule.String()
 	r.AvgPatStr = r.AvgPat.String()
 	r.AvgHitStr = r.AvgHit.String()
+	r.SlowestPatStr = r.SlowestPatTime.String()
 }
 
 func (r *RuleMetric) Accumulate(patternCheck int64, hitcnt int64, duration time.Duration) {
@@ -69,8 +107,27 @@ func (r *RuleMetric) Accumulate(patternCheck int64, hitcnt int64, duration time.
 	r.AvgRule = time.Duration(r.TotalTime.Nanoseconds() / r.Checks)
 }
 
+//AccumulatePattern adds the time spent matching a single pattern of the rule, so the pattern slowing a rule down can be
+//told apart from the rest
+func (r *RuleMetric) AccumulatePattern(pattern string, duration time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.patternTimes == nil {
+		r.patternTimes = make(map[string]time.Duration)
+	}
+	r.patternTimes[pattern] += duration
+}
+
 func (r *RuleMetric) Merge(ruleMetric *RuleMetric) {
 
+	for pattern, duration := range ruleMetric.patternTimes {
+		if r.patternTimes == nil {
+			r.patternTimes = make(map[string]time.Duration)
+		}
+		r.patternTimes[pattern] += duration
+	}
+
 	r.Hits += ruleMetric.Hits
 	r.PatternChecks += ruleMetric.PatternChecks
 	r.Checks += ruleMetric.Checks
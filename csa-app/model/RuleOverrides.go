@@ -0,0 +1,126 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//RuleOverrides remaps the effort and advice of specific rules for an engagement without editing the rules themselves
+type RuleOverrides struct {
+	Name   string                  `json:"name" yaml:"name"`
+	Rules  map[string]RuleOverride `json:"rules" yaml:"rules"`
+	Digest string                  `json:"digest" yaml:"-"`
+}
+
+//RuleOverride replaces a rule's effort and/or advice. Patterns carrying their own effort or advice are overridden too,
+//since theirs would otherwise win, unless the pattern (by value) is given its own override.
+type RuleOverride struct {
+	Effort   *int                       `json:"effort,omitempty" yaml:"effort,omitempty"`
+	Advice   string                     `json:"advice,omitempty" yaml:"advice,omitempty"`
+	Patterns map[string]PatternOverride `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+}
+
+type PatternOverride struct {
+	Effort *int   `json:"effort,omitempty" yaml:"effort,omitempty"`
+	Advice string `json:"advice,omitempty" yaml:"advice,omitempty"`
+}
+
+func LoadRuleOverrides(file string) (*RuleOverrides, error) {
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	//yaml is a superset of json
+	overrides := &RuleOverrides{}
+	if err = yaml.UnmarshalStrict(data, overrides); err != nil {
+		return nil, fmt.Errorf("rule overrides file [%s] is invalid! Details: %v", file, err)
+	}
+
+	if overrides.Name == "" {
+		overrides.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	sum := sha256.Sum256(data)
+	overrides.Digest = hex.EncodeToString(sum[:])
+
+	return overrides, overrides.Validate()
+}
+
+func (o *RuleOverrides) Validate() error {
+
+	for name, override := range o.Rules {
+		if override.Effort != nil && *override.Effort < 0 {
+			return fmt.Errorf("rule overrides [%s] effort of rule [%s] cannot be negative", o.Name, name)
+		}
+		for value, pattern := range override.Patterns {
+			if pattern.Effort != nil && *pattern.Effort < 0 {
+				return fmt.Errorf("rule overrides [%s] effort of rule [%s] pattern [%s] cannot be negative", o.Name, name, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+//Apply overrides the rules in place and returns the names of overridden rules that aren't in the rule set, sorted
+func (o *RuleOverrides) Apply(rules []Rule) (unknown []string) {
+
+	found := make(map[string]bool)
+
+	for i := range rules {
+		override, ok := o.Rules[rules[i].Name]
+		if !ok {
+			continue
+		}
+		found[rules[i].Name] = true
+
+		if override.Effort != nil {
+			rules[i].Effort = *override.Effort
+		}
+		if override.Advice != "" {
+			rules[i].Advice = override.Advice
+		}
+
+		for j := range rules[i].Patterns {
+			pattern := &rules[i].Patterns[j]
+			if override.Effort != nil && pattern.Effort != 0 {
+				pattern.Effort = *override.Effort
+			}
+			if override.Advice != "" && pattern.Advice != "" {
+				pattern.Advice = override.Advice
+			}
+
+			if patternOverride, ok := override.Patterns[pattern.Value]; ok {
+				if patternOverride.Effort != nil {
+					pattern.Effort = *patternOverride.Effort
+				}
+				if patternOverride.Advice != "" {
+					pattern.Advice = patternOverride.Advice
+				}
+			}
+		}
+	}
+
+	for name := range o.Rules {
+		if !found[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
+}
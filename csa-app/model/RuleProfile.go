@@ -0,0 +1,229 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"csa-app/util"
+	"gopkg.in/yaml.v2"
+)
+
+//RuleProfile tailors the rule set and scoring to a target platform. Rules are dropped by name or tag and the effort of
+//rules carrying a weighted tag is scaled, I.E. port usage matters less on kubernetes than on TAS.
+type RuleProfile struct {
+	Name         string             `json:"name" yaml:"name"`
+	Description  string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Extends      string             `json:"extends,omitempty" yaml:"extends,omitempty"`
+	ScoringModel string             `json:"scoring-model,omitempty" yaml:"scoring-model,omitempty"`
+	ExcludeRules []string           `json:"exclude-rules,omitempty" yaml:"exclude-rules,omitempty"`
+	ExcludeTags  []string           `json:"exclude-tags,omitempty" yaml:"exclude-tags,omitempty"`
+	Weights      map[string]float64 `json:"weights,omitempty" yaml:"weights,omitempty"`
+}
+
+//BuiltinProfiles are the profiles shipped with csa. A profile file of the same name in the profiles dir replaces one.
+func BuiltinProfiles() map[string]*RuleProfile {
+
+	kubernetes := func(name, description string) *RuleProfile {
+		return &RuleProfile{Name: name, Description: description, Extends: "kubernetes"}
+	}
+
+	profiles := []*RuleProfile{
+		{Name: "tas", Description: "Tanzu Application Service (Cloud Foundry) buildpack deployments",
+			Weights: map[string]float64{"port-usage": 1.5, "native": 1.5}},
+		{Name: "kubernetes", Description: "Generic Kubernetes deployments",
+			ExcludeTags: []string{"cloud-foundry", "load_from_vcap_services"},
+			Weights:     map[string]float64{"port-usage": 0.5, "file": 0.5, "stateful": 0.5}},
+		kubernetes("tkg", "Tanzu Kubernetes Grid"),
+		kubernetes("eks", "Amazon Elastic Kubernetes Service"),
+		kubernetes("aks", "Azure Kubernetes Service"),
+		{Name: "openshift", Description: "Red Hat OpenShift. Containers run as an arbitrary non-root user",
+			Extends: "kubernetes", Weights: map[string]float64{"sudo": 2}},
+	}
+
+	builtin := make(map[string]*RuleProfile)
+	for _, profile := range profiles {
+		builtin[profile.Name] = profile
+	}
+
+	return builtin
+}
+
+//AvailableProfiles returns the builtin profiles with those found in the profiles dir layered on top, sorted by name
+func AvailableProfiles(dir string) ([]*RuleProfile, error) {
+
+	profiles := BuiltinProfiles()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	for _, file := range files {
+		ext := strings.TrimPrefix(filepath.Ext(file), ".")
+		if ext != util.YAML && ext != "yml" && ext != util.JSON {
+			continue
+		}
+
+		profile, err := readProfile(file)
+		if err != nil {
+			return nil, err
+		}
+		profiles[profile.Name] = profile
+	}
+
+	var available []*RuleProfile
+	for _, profile := range profiles {
+		available = append(available, profile)
+	}
+
+	sort.Slice(available, func(i, j int) bool { return available[i].Name < available[j].Name })
+
+	return available, nil
+}
+
+//LoadRuleProfile returns the named profile with the profiles it extends merged in
+func LoadRuleProfile(name string, dir string) (*RuleProfile, error) {
+
+	available, err := AvailableProfiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*RuleProfile)
+	var names []string
+	for _, profile := range available {
+		byName[profile.Name] = profile
+		names = append(names, profile.Name)
+	}
+
+	resolved := &RuleProfile{Name: name, Weights: make(map[string]float64)}
+	seen := make(map[string]bool)
+
+	//Walk up the chain. Settings closest to the requested profile win.
+	for current := name; current != ""; {
+		profile, found := byName[current]
+		if !found {
+			if current == name {
+				return nil, fmt.Errorf("unknown profile [%s]. Available profiles: %s", name, strings.Join(names, ", "))
+			}
+			return nil, fmt.Errorf("profile [%s] extends unknown profile [%s]", name, current)
+		}
+
+		if seen[current] {
+			return nil, fmt.Errorf("profile [%s] extends itself through [%s]", name, current)
+		}
+		seen[current] = true
+
+		if resolved.Description == "" {
+			resolved.Description = profile.Description
+		}
+		if resolved.ScoringModel == "" {
+			resolved.ScoringModel = profile.ScoringModel
+		}
+		resolved.ExcludeRules = append(resolved.ExcludeRules, profile.ExcludeRules...)
+		resolved.ExcludeTags = append(resolved.ExcludeTags, profile.ExcludeTags...)
+		for tag, weight := range profile.Weights {
+			if _, set := resolved.Weights[tag]; !set {
+				resolved.Weights[tag] = weight
+			}
+		}
+
+		current = profile.Extends
+	}
+
+	return resolved, nil
+}
+
+//Row describes the profile (as defined, not resolved) for the `rules profiles` listing
+func (p *RuleProfile) Row() []string {
+
+	var weights []string
+	for tag, weight := range p.Weights {
+		weights = append(weights, fmt.Sprintf("%s=%g", tag, weight))
+	}
+	sort.Strings(weights)
+
+	excluded := append(append([]string{}, p.ExcludeRules...), p.ExcludeTags...)
+
+	return []string{p.Name, p.Extends, p.ScoringModel, strings.Join(excluded, ","), strings.Join(weights, ","), p.Description}
+}
+
+func readProfile(file string) (*RuleProfile, error) {
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	//yaml is a superset of json
+	profile := &RuleProfile{}
+	if err = yaml.UnmarshalStrict(data, profile); err != nil {
+		return nil, fmt.Errorf("profile file [%s] is invalid! Details: %v", file, err)
+	}
+
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	for tag, weight := range profile.Weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("profile [%s] weight of tag [%s] cannot be negative", profile.Name, tag)
+		}
+	}
+
+	return profile, nil
+}
+
+//Apply drops the rules the profile excludes and scales the effort of the remaining rules (and their patterns) by the
+//weights of their tags. A rule carrying several weighted tags is scaled by each of them. Rules are modified in place.
+func (p *RuleProfile) Apply(rules []Rule) []Rule {
+
+	excludedRules := make(map[string]bool)
+	for _, name := range p.ExcludeRules {
+		excludedRules[name] = true
+	}
+
+	var applied []Rule
+
+	for i := range rules {
+		if excludedRules[rules[i].Name] || p.excludesTagOf(&rules[i]) {
+			continue
+		}
+
+		weight := 1.0
+		for tag, w := range p.Weights {
+			if rules[i].HasTag(tag) {
+				weight *= w
+			}
+		}
+
+		if weight != 1.0 {
+			rules[i].Effort = scaleEffort(rules[i].Effort, weight)
+			for j := range rules[i].Patterns {
+				rules[i].Patterns[j].Effort = scaleEffort(rules[i].Patterns[j].Effort, weight)
+			}
+		}
+
+		applied = append(applied, rules[i])
+	}
+
+	return applied
+}
+
+func (p *RuleProfile) excludesTagOf(rule *Rule) bool {
+	for _, tag := range p.ExcludeTags {
+		if rule.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func scaleEffort(effort int, weight float64) int {
+	return int(math.Round(float64(effort) * weight))
+}
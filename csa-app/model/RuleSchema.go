@@ -0,0 +1,584 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//RULE_SCHEMA is the JSON Schema rule files are validated against. It is published as doc/csa-rule.schema.json
+const RULE_SCHEMA = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "csa-rule.schema.json",
+  "title": "CSA Rule",
+  "description": "A cloud suitability analyzer rule. Rule files may hold several rules as separate yaml documents.",
+  "type": "object",
+  "required": [
+    "name",
+    "target",
+    "type"
+  ],
+  "additionalProperties": false,
+  "properties": {
+    "name": {
+      "type": "string",
+      "minLength": 1,
+      "description": "unique name of the rule"
+    },
+    "filetype": {
+      "type": "string",
+      "description": "regex of the file extensions (sans '.') the rule applies to. Empty or * applies to all files"
+    },
+    "filenamepattern": {
+      "type": "string",
+      "description": "regex of the file names the rule applies to"
+    },
+    "paths": {
+      "type": "array",
+      "description": "gitignore style path patterns (relative to the application root) the rule is limited to. I.E. src/main/",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      }
+    },
+    "excludepaths": {
+      "type": "array",
+      "description": "gitignore style path patterns the rule never applies to. I.E. generated/",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      }
+    },
+    "minsize": {
+      "type": "integer",
+      "description": "smallest file (bytes) the rule applies to"
+    },
+    "maxsize": {
+      "type": "integer",
+      "description": "largest file (bytes) the rule applies to. 0 = unlimited"
+    },
+    "maxdepth": {
+      "type": "integer",
+      "description": "deepest directory level below the application root the rule applies to. 0 = unlimited"
+    },
+    "target": {
+      "type": "string",
+      "enum": [
+        "file",
+        "line",
+        "contents",
+        "multiline",
+        "application"
+      ],
+      "description": "what the patterns are matched against. Composite rules target file or application"
+    },
+    "type": {
+      "type": "string",
+      "enum": [
+        "regex",
+        "xpath",
+        "yamlpath",
+        "plugin",
+        "simple-text",
+        "simple-text-ci",
+        "starts-with",
+        "starts-with-ci",
+        "ends-with",
+        "ends-with-ci",
+        "contains",
+        "contains-ci",
+        "composite",
+        "script"
+      ],
+      "description": "how patterns are matched"
+    },
+    "condition": {
+      "type": "string",
+      "description": "composite rules only. Boolean expression of rule names combined with AND, OR, NOT and parentheses"
+    },
+    "script": {
+      "type": "string",
+      "description": "script rules only. CEL expression over the file's content, path, name, ext, lines and findings returning a boolean or an effort"
+    },
+    "defaultpattern": {
+      "type": "string",
+      "description": "pattern used for every pattern value. Must contain a %s substitution marker"
+    },
+    "advice": {
+      "type": "string",
+      "description": "remediation advice attached to findings"
+    },
+    "replace": {
+      "type": "string",
+      "description": "regex replacement template ($1, ${name}) fixing what the patterns match, emitted by report patch"
+    },
+    "effort": {
+      "type": "integer",
+      "description": "effort of a single finding"
+    },
+    "impact": {
+      "type": "string",
+      "enum": [
+        "every",
+        "file",
+        "app"
+      ],
+      "description": "how often a finding counts towards effort"
+    },
+    "readiness": {
+      "type": "integer",
+      "description": "readiness of a single finding"
+    },
+    "category": {
+      "type": "string",
+      "description": "category of findings"
+    },
+    "criticality": {
+      "type": "string",
+      "description": "criticality of findings"
+    },
+    "severity": {
+      "type": "string",
+      "enum": [
+        "info",
+        "low",
+        "medium",
+        "high",
+        "critical"
+      ],
+      "description": "risk of findings, independent of effort"
+    },
+    "confidence": {
+      "type": "string",
+      "enum": [
+        "low",
+        "medium",
+        "high"
+      ],
+      "description": "how certain a match is a real finding. Defaults to high, matches in comments are low"
+    },
+    "negative": {
+      "type": "boolean",
+      "description": "invert matching so a finding is reported when a pattern does not match"
+    },
+    "deprecated": {
+      "type": "boolean",
+      "description": "the rule is superseded. Runs loading it warn and reports flag its findings"
+    },
+    "replacedby": {
+      "type": "string",
+      "description": "name of the rule superseding a deprecated rule"
+    },
+    "tags": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": [
+          "value"
+        ],
+        "additionalProperties": false,
+        "properties": {
+          "value": {
+            "type": "string",
+            "minLength": 1,
+            "description": "tag"
+          }
+        }
+      }
+    },
+    "recipes": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": [
+          "uri"
+        ],
+        "additionalProperties": false,
+        "properties": {
+          "uri": {
+            "type": "string",
+            "minLength": 1,
+            "description": "link to a remediation recipe"
+          }
+        }
+      }
+    },
+    "unless": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": [
+          "pattern"
+        ],
+        "additionalProperties": false,
+        "properties": {
+          "target": {
+            "type": "string",
+            "enum": [
+              "file",
+              "contents"
+            ],
+            "description": "match the pattern against the file's path or its contents (default)"
+          },
+          "pattern": {
+            "type": "string",
+            "minLength": 1,
+            "description": "regex which, when it matches, excludes the whole file from the rule"
+          }
+        }
+      }
+    },
+    "patterns": {
+      "type": "array",
+      "minItems": 1,
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "properties": {
+          "type": {
+            "type": "string",
+            "enum": [
+              "regex",
+              "xpath",
+              "yamlpath",
+              "plugin",
+              "simple-text",
+              "simple-text-ci",
+              "starts-with",
+              "starts-with-ci",
+              "ends-with",
+              "ends-with-ci",
+              "contains",
+              "contains-ci"
+            ],
+            "description": "overrides the rule's match type for this pattern"
+          },
+          "pattern": {
+            "type": "string",
+            "description": "overrides the rule's default pattern. May contain a %s substitution marker for the value"
+          },
+          "value": {
+            "type": "string",
+            "description": "the value to match. Required unless a command is given"
+          },
+          "advice": {
+            "type": "string",
+            "description": "overrides the rule's advice"
+          },
+          "replace": {
+            "type": "string",
+            "description": "overrides the rule's replacement template"
+          },
+          "effort": {
+            "type": "integer",
+            "description": "overrides the rule's effort"
+          },
+          "readiness": {
+            "type": "integer",
+            "description": "overrides the rule's readiness"
+          },
+          "criticality": {
+            "type": "string",
+            "description": "overrides the rule's criticality"
+          },
+          "severity": {
+            "type": "string",
+            "enum": [
+        "info",
+        "low",
+        "medium",
+        "high",
+        "critical"
+      ],
+            "description": "overrides the rule's severity"
+          },
+          "confidence": {
+            "type": "string",
+            "enum": [
+              "low",
+              "medium",
+              "high"
+            ],
+            "description": "overrides the rule's confidence"
+          },
+          "tag": {
+            "type": "string",
+            "description": "additional tag applied to findings of this pattern"
+          },
+          "recipe": {
+            "type": "string",
+            "description": "additional recipe applied to findings of this pattern"
+          },
+          "category": {
+            "type": "string",
+            "description": "overrides the rule's category"
+          },
+          "command": {
+            "type": "string",
+            "description": "plugin command run for plugin rules"
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+//ruleSchema is the subset of JSON Schema used by RULE_SCHEMA
+type ruleSchema struct {
+	Type                 string                 `json:"type"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Properties           map[string]*ruleSchema `json:"properties"`
+	Items                *ruleSchema            `json:"items"`
+	Enum                 []string               `json:"enum"`
+	MinItems             int                    `json:"minItems"`
+	MinLength            int                    `json:"minLength"`
+}
+
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+
+//RuleFileError is a problem found in a rule file along with where it was found
+type RuleFileError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e RuleFileError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+//ValidateRuleFile checks every rule (yaml document) in the file against RULE_SCHEMA and then the rule's own validation.
+//Json field names are matched case-insensitively the same way the json decoder does. Valid rules are returned.
+func ValidateRuleFile(reader io.Reader, isJson bool) (rules []Rule, errs []RuleFileError) {
+
+	schema := &ruleSchema{}
+	if err := json.Unmarshal([]byte(RULE_SCHEMA), schema); err != nil {
+		return nil, []RuleFileError{{Message: fmt.Sprintf("rule schema is invalid: %v", err)}}
+	}
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, []RuleFileError{{Message: err.Error()}}
+	}
+
+	var docs []ruleDocument
+	if isJson {
+		docs, errs = splitJsonRules(content)
+	} else {
+		docs, errs = splitYamlRules(content)
+	}
+
+	for _, doc := range docs {
+		docErrs := schema.validate(doc.root, "rule", isJson)
+		if len(docErrs) == 0 {
+			var rule Rule
+			if err = doc.root.Decode(&rule); err == nil {
+				_, err = rule.IsValid()
+			}
+
+			if err != nil {
+				docErrs = append(docErrs, RuleFileError{Line: doc.root.Line, Column: doc.root.Column, Message: fmt.Sprintf("rule [%s] is invalid: %v", rule.Name, err)})
+			} else {
+				rules = append(rules, rule)
+			}
+		}
+
+		for i := range docErrs {
+			docErrs[i].Line += doc.lineOffset
+		}
+		errs = append(errs, docErrs...)
+	}
+
+	return rules, errs
+}
+
+//ruleDocument is a single rule within a rule file. Json rules are parsed one object at a time so their lines are offset.
+type ruleDocument struct {
+	root       *yaml.Node
+	lineOffset int
+}
+
+func splitYamlRules(content []byte) (docs []ruleDocument, errs []RuleFileError) {
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			line := 0
+			if match := yamlErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+				line, _ = strconv.Atoi(match[1])
+			}
+			errs = append(errs, RuleFileError{Line: line, Message: strings.TrimPrefix(err.Error(), "yaml: ")})
+			break
+		}
+
+		if len(doc.Content) > 0 {
+			docs = append(docs, ruleDocument{root: doc.Content[0]})
+		}
+	}
+
+	return
+}
+
+//splitJsonRules handles files holding several top level json objects (which the yaml decoder would reject)
+func splitJsonRules(content []byte) (docs []ruleDocument, errs []RuleFileError) {
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			offset := decoder.InputOffset()
+			if syntaxErr, ok := err.(*json.SyntaxError); ok {
+				offset = syntaxErr.Offset
+			}
+			errs = append(errs, RuleFileError{Line: bytes.Count(content[:offset], []byte("\n")) + 1, Message: err.Error()})
+			break
+		}
+
+		start := int(decoder.InputOffset()) - len(raw)
+
+		//Tabs are only valid json between tokens but yaml refuses them as indentation
+		var doc yaml.Node
+		if err = yaml.Unmarshal(bytes.ReplaceAll(raw, []byte("\t"), []byte(" ")), &doc); err != nil || len(doc.Content) == 0 {
+			errs = append(errs, RuleFileError{Line: bytes.Count(content[:start], []byte("\n")) + 1, Message: fmt.Sprintf("unable to read rule: %v", err)})
+			continue
+		}
+
+		docs = append(docs, ruleDocument{root: doc.Content[0], lineOffset: bytes.Count(content[:start], []byte("\n"))})
+	}
+
+	return
+}
+
+func (schema *ruleSchema) validate(node *yaml.Node, path string, isJson bool) (errs []RuleFileError) {
+
+	fail := func(format string, args ...interface{}) []RuleFileError {
+		return append(errs, RuleFileError{Line: node.Line, Column: node.Column, Message: path + ": " + fmt.Sprintf(format, args...)})
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			return fail("expected an object")
+		}
+
+		found := make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			name := key.Value
+			if isJson {
+				//Normalize so the yaml decoder finds the fields like the json decoder would
+				name = strings.ToLower(name)
+				key.Value = name
+			}
+
+			property, known := schema.Properties[name]
+			if !known {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					errs = append(errs, RuleFileError{Line: key.Line, Column: key.Column,
+						Message: fmt.Sprintf("%s: unknown field [%s]. Expected one of (%s)", path, key.Value, strings.Join(schema.propertyNames(), "|"))})
+				}
+				continue
+			}
+
+			if value.Tag == "!!null" {
+				continue
+			}
+
+			found[name] = true
+			errs = append(errs, property.validate(value, path+"."+name, isJson)...)
+		}
+
+		for _, required := range schema.Required {
+			if !found[required] {
+				errs = append(errs, RuleFileError{Line: node.Line, Column: node.Column, Message: fmt.Sprintf("%s: missing required field [%s]", path, required)})
+			}
+		}
+
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			return fail("expected a list")
+		}
+
+		if len(node.Content) < schema.MinItems {
+			return fail("expected at least %d item(s) but found %d", schema.MinItems, len(node.Content))
+		}
+
+		if schema.Items != nil {
+			for i, item := range node.Content {
+				errs = append(errs, schema.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), isJson)...)
+			}
+		}
+
+	case "integer":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			return fail("expected an integer but found [%s]", node.Value)
+		}
+
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			return fail("expected true or false but found [%s]", node.Value)
+		}
+
+	case "string":
+		if node.Kind != yaml.ScalarNode {
+			return fail("expected a string")
+		}
+
+		if len(node.Value) < schema.MinLength {
+			return fail("must not be empty")
+		}
+
+		if len(schema.Enum) > 0 && !schema.allows(node.Value) {
+			return fail("[%s] must be one of (%s)", node.Value, strings.Join(schema.Enum, "|"))
+		}
+	}
+
+	return errs
+}
+
+func (schema *ruleSchema) propertyNames() []string {
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (schema *ruleSchema) allows(value string) bool {
+	for _, allowed := range schema.Enum {
+		if value == allowed {
+			return true
+		}
+	}
+	return false
+}
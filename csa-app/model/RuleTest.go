@@ -0,0 +1,124 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//Rule tests live next to the rule file they exercise, i.e. java-file-io.yaml => java-file-io.test.yaml
+const RULE_TEST_SUFFIX = ".test"
+
+type RuleTestSuite struct {
+	Tests []*RuleTestCase `yaml:"tests"`
+}
+
+//RuleTestCase asserts that a rule does (or does not) match a fixture. The fixture is either a file (relative to the
+//test file) or inline content analyzed under the given filename.
+type RuleTestCase struct {
+	Name     string `yaml:"name"`
+	Rule     string `yaml:"rule"`
+	File     string `yaml:"file,omitempty"`
+	Filename string `yaml:"filename,omitempty"`
+	Content  string `yaml:"content,omitempty"`
+	Match    bool   `yaml:"match"`
+	Lines    []int  `yaml:"lines,omitempty"`
+}
+
+//IsRuleTestFile is true for rule test files which must never be imported/validated as rules
+func IsRuleTestFile(filename string) bool {
+	name := filepath.Base(filename)
+	return strings.HasSuffix(strings.TrimSuffix(name, filepath.Ext(name)), RULE_TEST_SUFFIX)
+}
+
+//RuleFileForTest returns the candidate rule files a test file belongs to
+func RuleFileForTest(testFile string) []string {
+	ext := filepath.Ext(testFile)
+	base := strings.TrimSuffix(strings.TrimSuffix(testFile, ext), RULE_TEST_SUFFIX)
+	return []string{base + ".yaml", base + ".yml", base + ".json"}
+}
+
+//LoadRuleTests decodes a rule test file. Tests naming no rule default to the only rule in the rule file.
+func LoadRuleTests(reader io.Reader, rules []Rule) (*RuleTestSuite, error) {
+
+	suite := &RuleTestSuite{}
+	if err := yaml.NewDecoder(reader).Decode(suite); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to decode rule tests: %v", err)
+	}
+
+	for i, test := range suite.Tests {
+		if test.Name == "" {
+			test.Name = fmt.Sprintf("test-%d", i+1)
+		}
+
+		if test.Rule == "" {
+			if len(rules) != 1 {
+				return nil, fmt.Errorf("test [%s] must name the rule it exercises", test.Name)
+			}
+			test.Rule = rules[0].Name
+		}
+
+		if test.File == "" && test.Filename == "" {
+			return nil, fmt.Errorf("test [%s] must have a fixture file or inline content with a filename", test.Name)
+		}
+
+		if test.File != "" && test.Content != "" {
+			return nil, fmt.Errorf("test [%s] cannot have both a fixture file and inline content", test.Name)
+		}
+
+		if !test.Match && len(test.Lines) > 0 {
+			return nil, fmt.Errorf("test [%s] expects no match but lists matching lines", test.Name)
+		}
+	}
+
+	return suite, nil
+}
+
+//Check compares the lines the rule matched against the test's expectations. An empty string means the test passed.
+func (test *RuleTestCase) Check(matchedLines []int) string {
+
+	if !test.Match {
+		if len(matchedLines) > 0 {
+			return fmt.Sprintf("expected no match but rule matched on line(s) %v", matchedLines)
+		}
+		return ""
+	}
+
+	if len(matchedLines) == 0 {
+		return "expected a match but rule did not match"
+	}
+
+	if len(test.Lines) > 0 {
+		expected := append([]int{}, test.Lines...)
+		actual := uniqueLines(matchedLines)
+		sort.Ints(expected)
+
+		if fmt.Sprint(expected) != fmt.Sprint(actual) {
+			return fmt.Sprintf("expected matches on line(s) %v but rule matched on line(s) %v", expected, actual)
+		}
+	}
+
+	return ""
+}
+
+func uniqueLines(lines []int) []int {
+	seen := make(map[int]bool)
+	var unique []int
+	for _, line := range lines {
+		if !seen[line] {
+			seen[line] = true
+			unique = append(unique, line)
+		}
+	}
+	sort.Ints(unique)
+	return unique
+}
@@ -6,6 +6,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/user"
@@ -20,6 +21,7 @@ import (
 	"github.com/kardianos/osext"
 	"github.com/mitchellh/go-homedir"
 	"csa-app/util"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Run struct {
@@ -31,12 +33,19 @@ type Run struct {
 	Command          string                    `gorm:"type:text"`
 	Target           string                    `gorm:"type:text"`
 	ReportsRequested string                    `gorm:"type:text"`
+	RuleOverrides    string                    `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	TagWeights       string                    `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Scorer           string                    `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	ScoringFormula   string                    `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	Normalize        string                    `gorm:"type:text" json:",omitempty" yaml:",omitempty"`
+	TargetJdk        int                       `gorm:"type:bigint" json:",omitempty" yaml:",omitempty"`
 	Files            int                       `json:"Files" yaml:"Files"`
 	Findings         int                       `json:"Findings" yaml:"Findings"`
 	AnalyzedCnt      int                       `gorm:"-" json:"-" yaml:"-"`
 	StartTime        time.Time                 `gorm:"-" json:"-" yaml:"-"`
 	RequestDateTime  string                    `gorm:"-" json:"requestDate" yaml:"requestDate"`
 	Runtime          string                    `gorm:"type:text"`
+	Status           string                    `gorm:"type:text" json:",omitempty" yaml:",omitempty"` //How far the run got. Empty for runs recorded before it was tracked
 	Reports          []int                     `gorm:"-" json:"-" yaml:"-"`
 	Homepath         string                    `gorm:"-"`
 	Exepath          string                    `gorm:"-"`
@@ -44,6 +53,7 @@ type Run struct {
 	RulesDir         string                    `gorm:"-"`
 	DbPath           string                    `gorm:"-"`
 	TmpPath          string                    `gorm:"-"`
+	Workspace        *util.Workspace           `gorm:"-" json:"-" yaml:"-"`
 	RulesImport      bool                      `gorm:"-" json:"-" yaml:"-"`
 	Function         reportFunction            `gorm:"-" json:"-" yaml:"-"`
 	Applications     []*Application            `gorm:"foreignkey:RunID" json:",omitempty" yaml:",omitempty"`
@@ -51,35 +61,39 @@ type Run struct {
 	FileUtil         *util.FileUtil            `gorm:"-" json:"-" yaml:"-"`
 	DB               *gorm.DB                  `gorm:"-" json:"-" yaml:"-"`
 	Rules            []Rule                    `gorm:"-" json:"-" yaml:"-"`
+	Profile          *RuleProfile              `gorm:"-" json:"-" yaml:"-"`
+	Advice           *AdviceCatalog            `gorm:"-" json:"-" yaml:"-"`
+	Overrides        *RuleOverrides            `gorm:"-" json:"-" yaml:"-"`
+	Weights          *TagWeights               `gorm:"-" json:"-" yaml:"-"`
 	UnknownExts      []string                  `gorm:"-" json:"-" yaml:"-"`
 	LineBufferSize   int                       `gorm:"-" json:"-" yaml:"-"`
+	Ctx              context.Context           `gorm:"-" json:"-" yaml:"-"`
+	Listener         RunListener               `gorm:"-" json:"-" yaml:"-"`
 	sync.Mutex       `gorm:"-" json:"-" yaml:"-"`
 }
 
 type reportFunction func(run *Run)
 
+//RunListener is told about the progress of a run. I.E. to publish run lifecycle events
+type RunListener interface {
+	ActivityStopped(run *Run, name string, elapsed time.Duration)
+	FindingsAdded(run *Run, total int)
+}
+
 func NewRun() *Run {
 	var err error
 
 	newRun := &Run{}
 	newRun.Command, err = util.App.Parse(os.Args[1:])
 
-	context, _ := util.App.ParseContext(os.Args[1:])
-
 	if err != nil {
-		util.App.FatalUsageContext(context, "Error parsing command arguments! %s\n", err)
-		os.Exit(1)
+		util.App.FatalCommandUsage(os.Args[1:], "Error parsing command arguments! %s\n", err)
 	}
 
 	newRun.SetupDefaults()
 	newRun.Activities = make(map[string]*util.Activity)
+	newRun.Ctx = context.Background()
 	newRun.FileUtil = util.NewFileUtil()
-	//Establish Temp Directory for Run
-	newRun.TmpPath, err = newRun.FileUtil.EstablishTempDirectory("")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Unable to create temp directory. Details: %v", err)
-		os.Exit(1)
-	}
 
 	//Store line buffer size for file scanning
 	newRun.LineBufferSize = *util.LineBuffer
@@ -90,10 +104,23 @@ func NewRun() *Run {
 	return newRun
 }
 
+//OpenWorkspace establishes a private workspace for the run. Only the commands extracting archives, staging report
+//downloads or writing fixtures open one, so the others leave nothing behind whichever way they exit.
+func (r *Run) OpenWorkspace() {
+	workspace, err := util.NewWorkspace(*util.TmpDirPath, r.Command, *util.TmpDirLimit<<20)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to create temp directory. Details: %v", err)
+		os.Exit(1)
+	}
+	r.Workspace = workspace
+	r.TmpPath = workspace.Root
+	util.RunWorkspace = workspace
+}
+
 func (r *Run) Cleanup() {
 
-	if r.TmpPath != "" && r.FileUtil != nil {
-		r.FileUtil.RemoveDir(r.TmpPath)
+	if r.Workspace != nil {
+		r.Workspace.Close()
 	}
 
 	if r.DB != nil {
@@ -133,11 +160,21 @@ func (r Run) CompletionMessage() {
 func (r *Run) StartActivity(name string) {
 	r.Lock()
 	if _, exists := r.Activities[name]; !exists {
-		r.Activities[name] = util.NewActivity(name)
+		r.Activities[name] = util.NewActivity(r.Ctx, name)
 	}
 	r.Unlock()
 }
 
+//StartTrace opens the root span of the run. All activities started afterwards are traced beneath it.
+func (r *Run) StartTrace(name string) func() {
+	ctx, span := util.StartSpan(r.Ctx, name, attribute.Int64("csa.run.id", int64(r.ID)), attribute.String("csa.run.target", r.Target))
+	r.Ctx = ctx
+	return func() {
+		span.SetAttributes(attribute.Int("csa.run.files", r.Files), attribute.Int("csa.run.findings", r.Findings))
+		span.End()
+	}
+}
+
 func (r *Run) StopActivity(name, msg string, printMsg bool) {
 	r.StopActivityLF(name, msg, true, printMsg)
 }
@@ -149,12 +186,16 @@ func (r *Run) StopActivityLF(name, msg string, prelinefeed bool, printMsg bool)
 
 	r.Lock()
 	if activity, exists = r.Activities[name]; !exists {
-		activity = util.NewActivity(name)
+		activity = util.NewActivity(r.Ctx, name)
 		r.Activities[name] = activity
 	}
 	r.Unlock()
 	activity.Stop()
 
+	if r.Listener != nil {
+		r.Listener.ActivityStopped(r, name, activity.GetElapsed())
+	}
+
 	if printMsg {
 		if prelinefeed {
 			fmt.Printf("\n%s (%s)\n", msg, fmt.Sprintf("%v", activity.GetElapsed()))
@@ -171,7 +212,12 @@ func (r *Run) PrepForMarshal() {
 func (r *Run) AddFindings(count int) {
 	r.Lock()
 	r.Findings += count
+	total := r.Findings
 	r.Unlock()
+
+	if r.Listener != nil {
+		r.Listener.FindingsAdded(r, total)
+	}
 }
 
 func (r *Run) FileAnalyzed() {
@@ -25,6 +25,13 @@ type RunConfig struct {
 	Alias            string               `json:"runName" yaml:"runName"`
 	Applications     []*ApplicationConfig `json:"applications,required" yaml:"applications"`
 	ScoringModel     string               `json:"scoring-model" yaml:"scoring-model"`
+	Scorer           string               `json:"scorer,omitempty" yaml:"scorer,omitempty"`
+	ScoringFormula   string               `json:"scoring-formula,omitempty" yaml:"scoring-formula,omitempty"`
+	Normalize        string               `json:"normalize,omitempty" yaml:"normalize,omitempty"`
+	Profile          string               `json:"profile,omitempty" yaml:"profile,omitempty"`
+	RuleOverrides    string               `json:"rule-overrides,omitempty" yaml:"rule-overrides,omitempty"`
+	TagWeights       string               `json:"tag-weights,omitempty" yaml:"tag-weights,omitempty"`
+	TargetJdk        int                  `json:"target-jdk,omitempty" yaml:"target-jdk,omitempty"`
 	RuleIncludeTags  string               `json:"rule-include-tags" yaml:"rule-include-tags"`
 	RuleExcludeTags  string               `json:"rule-exclude-tags" yaml:"rule-exclude-tags"`
 	DirExcludeRegex  string               `json:"dir-exclude-regex" yaml:"dir-exclude-regex"`
@@ -39,6 +46,13 @@ func NewRunConfig(run *Run, fileUtil *util.FileUtil) *RunConfig {
 		run.Alias,
 		nil,
 		*util.ScoringModel,
+		*util.Scorer,
+		*util.ScoringFormula,
+		*util.Normalize,
+		*util.RuleProfile,
+		*util.RuleOverrides,
+		*util.TagWeights,
+		*util.TargetJdk,
 		*util.RuleIncludeTags,
 		*util.RuleExcludeTags,
 		*util.ExcludedDirsRegEx,
@@ -116,6 +130,34 @@ func (rc *RunConfig) Merge(mergeConfig *RunConfig) {
 		rc.ScoringModel = mergeConfig.ScoringModel
 	}
 
+	if util.IsCmdFlagDefaulted(util.ANALYZE_CMD, util.SCORER_FLAG) && mergeConfig.Scorer != "" {
+		rc.Scorer = mergeConfig.Scorer
+	}
+
+	if *util.ScoringFormula == "" && mergeConfig.ScoringFormula != "" {
+		rc.ScoringFormula = mergeConfig.ScoringFormula
+	}
+
+	if util.IsCmdFlagDefaulted(util.ANALYZE_CMD, util.NORMALIZE_FLAG) && mergeConfig.Normalize != "" {
+		rc.Normalize = mergeConfig.Normalize
+	}
+
+	if *util.RuleProfile == "" && mergeConfig.Profile != "" {
+		rc.Profile = mergeConfig.Profile
+	}
+
+	if *util.RuleOverrides == "" && mergeConfig.RuleOverrides != "" {
+		rc.RuleOverrides = mergeConfig.RuleOverrides
+	}
+
+	if *util.TagWeights == "" && mergeConfig.TagWeights != "" {
+		rc.TagWeights = mergeConfig.TagWeights
+	}
+
+	if *util.TargetJdk == 0 && mergeConfig.TargetJdk != 0 {
+		rc.TargetJdk = mergeConfig.TargetJdk
+	}
+
 	if util.IsCmdFlagDefaulted(util.ANALYZE_CMD, util.RULE_INCLUDE_FLAG) && mergeConfig.RuleIncludeTags != "" {
 		rc.RuleIncludeTags = mergeConfig.RuleIncludeTags
 	}
@@ -187,6 +229,12 @@ func (rc *RunConfig) portfolioDiscovery(searchDir string) (appConfigs []*Applica
 
 		if portfolio.IsDir() {
 
+			//The portfolio's .csaignore can exclude applications as well as the files within them
+			filter, _ := util.NewPathFilter(targetPath, nil, nil)
+			if err = filter.AddIgnoreFile(targetPath); err != nil {
+				util.App.Fatalf("Invalid %s in portfolio dir [%s]! Details: %v", util.CSA_IGNORE_FILENAME, targetPath, err)
+			}
+
 			files, err := ioutil.ReadDir(targetPath)
 
 			if err == nil {
@@ -196,10 +244,11 @@ func (rc *RunConfig) portfolioDiscovery(searchDir string) (appConfigs []*Applica
 							fmt.Printf("Found dir [%s] in porfolio dir [%s]\n", f.Name(), targetPath)
 						}
 						//Check for excluded directory
-						if !rc.FileUtil.DirIsExcluded(f.Name()) {
+						if !rc.FileUtil.DirIsExcluded(f.Name()) && !filter.Excluded(filepath.Join(targetPath, f.Name()), true) {
 							newAppConfig := NewApplicationConfig(rc)
 							newAppConfig.Name = f.Name()
 							newAppConfig.Path = targetPath + util.PathSeparator + f.Name()
+							newAppConfig.portfolio = targetPath
 							newAppConfig.CheckForLocalAppConfig()
 							appConfigs = append(appConfigs, newAppConfig)
 						}
@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+const SHARD_PENDING = "pending"
+const SHARD_CLAIMED = "claimed"
+const SHARD_DONE = "done"
+
+//RunShard is an application of a distributed run. Workers claim pending shards one at a time and mark them done once
+//the findings of the application are saved. A worker keeps touching the shards it holds (its heartbeat) so the
+//coordinator can tell those of a worker that stopped.
+type RunShard struct {
+	ID          uint      `gorm:"primary_key" json:"id" yaml:"id"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	UpdatedAt   time.Time `json:"updatedAt" yaml:"updatedAt"`
+	RunID       uint      `gorm:"index" json:"runId" yaml:"runId"`
+	Application string    `gorm:"type:text" json:"application" yaml:"application"`
+	Path        string    `gorm:"type:text" json:"path" yaml:"path"`
+	Files       int       `json:"files" yaml:"files"`
+	Status      string    `gorm:"type:text" json:"status" yaml:"status"`
+	Worker      string    `gorm:"type:text" json:"worker" yaml:"worker"` //host:pid of the worker that claimed the shard last
+	Claims      int       `json:"claims" yaml:"claims"`
+}
+
+//NewRunShards shards the run by application, the largest first so they don't end up last on a single worker
+func NewRunShards(run *Run) (shards []*RunShard) {
+	for _, app := range run.Applications {
+		shards = append(shards, &RunShard{RunID: run.ID, Application: app.Name, Path: app.Path, Files: len(app.Files), Status: SHARD_PENDING})
+	}
+
+	sort.SliceStable(shards, func(i, j int) bool { return shards[i].Files > shards[j].Files })
+
+	return shards
+}
+
+//ShardWatch tells the claimed shards whose worker stopped: those whose heartbeat didn't change for the timeout. Changes
+//are timed by the coordinator's clock, so the clocks of the workers don't need to agree with it.
+type ShardWatch struct {
+	Timeout   time.Duration
+	heartbeat map[uint]time.Time
+	changed   map[uint]time.Time
+}
+
+func NewShardWatch(timeout time.Duration) *ShardWatch {
+	return &ShardWatch{Timeout: timeout, heartbeat: make(map[uint]time.Time), changed: make(map[uint]time.Time)}
+}
+
+//Abandoned returns the ids of the claimed shards whose heartbeat didn't change for the timeout at now
+func (w *ShardWatch) Abandoned(shards []RunShard, now time.Time) (ids []uint) {
+	for _, shard := range shards {
+		if shard.Status != SHARD_CLAIMED {
+			delete(w.heartbeat, shard.ID)
+			continue
+		}
+
+		if last, seen := w.heartbeat[shard.ID]; !seen || !last.Equal(shard.UpdatedAt) {
+			w.heartbeat[shard.ID] = shard.UpdatedAt
+			w.changed[shard.ID] = now
+			continue
+		}
+
+		if now.Sub(w.changed[shard.ID]) >= w.Timeout {
+			ids = append(ids, shard.ID)
+			delete(w.heartbeat, shard.ID)
+		}
+	}
+
+	return ids
+}
+
+//ShardTotals counts the shards by status and the workers holding or having done them
+func ShardTotals(shards []RunShard) (byStatus map[string]int, workers int) {
+	byStatus = make(map[string]int)
+	seen := make(map[string]bool)
+	for _, shard := range shards {
+		byStatus[shard.Status]++
+		if shard.Worker != "" && !seen[shard.Worker] {
+			seen[shard.Worker] = true
+			workers++
+		}
+	}
+	return
+}
@@ -0,0 +1,88 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const SBOM_FORMAT = "CycloneDX"
+const SBOM_SPEC_VERSION = "1.5"
+
+//Sbom is a CycloneDX software bill of materials of an application, the third-party libraries it declares
+type Sbom struct {
+	BomFormat   string          `json:"bomFormat"`
+	SpecVersion string          `json:"specVersion"`
+	Version     int             `json:"version"`
+	Metadata    SbomMetadata    `json:"metadata"`
+	Components  []SbomComponent `json:"components"`
+}
+
+type SbomMetadata struct {
+	Timestamp string        `json:"timestamp"`
+	Tools     []SbomTool    `json:"tools,omitempty"`
+	Component SbomComponent `json:"component"`
+}
+
+type SbomTool struct {
+	Name string `json:"name"`
+}
+
+type SbomComponent struct {
+	Type     string        `json:"type"`
+	BomRef   string        `json:"bom-ref,omitempty"`
+	Group    string        `json:"group,omitempty"`
+	Name     string        `json:"name"`
+	Version  string        `json:"version,omitempty"`
+	Scope    string        `json:"scope,omitempty"`
+	Purl     string        `json:"purl,omitempty"`
+	Licenses []SbomLicense `json:"licenses,omitempty"`
+}
+
+type SbomLicense struct {
+	License SbomLicenseName `json:"license"`
+}
+
+type SbomLicenseName struct {
+	Name string `json:"name"`
+}
+
+//NewSbom builds the bill of materials of the application from its libraries, licensed by licenses (nil leaves them
+//unlicensed). Dev libraries are optional components. Maven libraries are split into their group and artifact, those
+//of the other ecosystems keep their (scoped) name.
+func NewSbom(app string, libraries []AppLibrary, licenses func(library *AppLibrary) (ResolvedLicense, bool), timestamp time.Time) *Sbom {
+
+	sbom := &Sbom{BomFormat: SBOM_FORMAT, SpecVersion: SBOM_SPEC_VERSION, Version: 1, Components: []SbomComponent{},
+		Metadata: SbomMetadata{Timestamp: timestamp.UTC().Format(time.RFC3339), Tools: []SbomTool{{Name: "csa"}},
+			Component: SbomComponent{Type: "application", Name: app}}}
+
+	for i := range libraries {
+		library := &libraries[i]
+		if library.Application != app {
+			continue
+		}
+
+		purl := library.PackageURL()
+		component := SbomComponent{Type: "library", BomRef: purl, Name: library.Name, Version: library.Version, Scope: "required", Purl: purl}
+		if library.Ecosystem == ECOSYSTEM_MAVEN {
+			if coordinates := strings.SplitN(library.Name, "/", 2); len(coordinates) == 2 {
+				component.Group, component.Name = coordinates[0], coordinates[1]
+			}
+		}
+		if library.Dev {
+			component.Scope = "optional"
+		}
+		if licenses != nil {
+			if license, found := licenses(library); found {
+				component.Licenses = []SbomLicense{{License: SbomLicenseName{Name: license.License}}}
+			}
+		}
+		sbom.Components = append(sbom.Components, component)
+	}
+
+	return sbom
+}
@@ -0,0 +1,73 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+const RUN_ANALYZING = "analyzing"
+const RUN_ANALYZED = "analyzed"
+const RUN_COMPLETE = "complete"
+
+//ScanCheckpoint is how far an interrupted run got analyzing an application. Files are checkpointed by their scan
+//manifest entry, saved with the number of findings sent for the file. A file is complete when all of them were saved
+//before the run was interrupted. The findings and entries of the other files are stale: they are dropped and the files
+//analyzed again when the run is resumed.
+type ScanCheckpoint struct {
+	RunID         uint
+	Findings      []Finding //Findings of the complete files
+	StaleFindings []uint
+	StaleEntries  []uint
+	appPath       string
+	complete      map[string]bool
+	appImpact     map[string]bool
+}
+
+//NewScanCheckpoint sorts the saved findings and manifest entries of the application into complete and stale files
+func NewScanCheckpoint(runId uint, appPath string, manifest []ManifestEntry, findings []Finding, rules []Rule) *ScanCheckpoint {
+
+	checkpoint := &ScanCheckpoint{
+		RunID:     runId,
+		appPath:   appPath,
+		complete:  make(map[string]bool),
+		appImpact: appImpactRules(rules),
+	}
+
+	saved := make(map[string]int)
+	for _, finding := range findings {
+		saved[ManifestPath(appPath, finding.Fqn)]++
+	}
+
+	for _, entry := range manifest {
+		if saved[entry.Path] == entry.Findings && !checkpoint.complete[entry.Path] {
+			checkpoint.complete[entry.Path] = true
+		} else {
+			checkpoint.StaleEntries = append(checkpoint.StaleEntries, entry.ID)
+		}
+	}
+
+	for _, finding := range findings {
+		if checkpoint.complete[ManifestPath(appPath, finding.Fqn)] {
+			checkpoint.Findings = append(checkpoint.Findings, finding)
+		} else {
+			checkpoint.StaleFindings = append(checkpoint.StaleFindings, finding.ID)
+		}
+	}
+
+	return checkpoint
+}
+
+//Complete is true when the file was analyzed and its findings saved before the run was interrupted
+func (checkpoint *ScanCheckpoint) Complete(fqn string) bool {
+	return checkpoint.complete[ManifestPath(checkpoint.appPath, fqn)]
+}
+
+//CompleteFiles is the number of files that don't need to be analyzed again
+func (checkpoint *ScanCheckpoint) CompleteFiles() int {
+	return len(checkpoint.complete)
+}
+
+//ImpactsApp is true for the rules whose effort counts once per application, on their first match
+func (checkpoint *ScanCheckpoint) ImpactsApp(rule string) bool {
+	return checkpoint.appImpact[rule]
+}
@@ -0,0 +1,67 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csa-app/util"
+)
+
+//ManifestEntry records exactly what was scanned for a file so a run can be reproduced (and questioned) later
+type ManifestEntry struct {
+	ID           uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt    time.Time `json:"-" yaml:"-"`
+	RunID        uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application  string    `gorm:"index;not null" json:"application" yaml:"application"`
+	Path         string    `gorm:"type:text" json:"path" yaml:"path"`
+	Size         int64     `json:"size" yaml:"size"`
+	Sha256       string    `gorm:"type:text" json:"sha256" yaml:"sha256"`
+	Language     string    `gorm:"type:text" json:"language,omitempty" yaml:"language,omitempty"`
+	RulesApplied int       `json:"rulesApplied" yaml:"rulesApplied"`
+	ThirdParty   string    `gorm:"type:text" json:"thirdParty,omitempty" yaml:"thirdParty,omitempty"`
+	Findings     int       `gorm:"type:bigint" json:"findings" yaml:"findings"`                           //Findings sent to be saved for the file, file findings included
+	Inaccessible string    `gorm:"type:text" json:"inaccessible,omitempty" yaml:"inaccessible,omitempty"` //Why the file was skipped. I.E. it is encrypted
+}
+
+//NewManifestEntry sizes and hashes the file. The path is recorded relative to the application root.
+func NewManifestEntry(runId uint, app *Application, file *util.FileInfo, language string, rulesApplied int) (*ManifestEntry, error) {
+
+	entry := &ManifestEntry{
+		RunID:        runId,
+		Application:  app.Name,
+		Path:         ManifestPath(app.Path, file.FQN),
+		Language:     language,
+		RulesApplied: rulesApplied,
+		ThirdParty:   file.ThirdParty,
+	}
+
+	in, err := os.Open(file.FQN)
+	if err != nil {
+		return entry, err
+	}
+	defer in.Close()
+
+	hash := sha256.New()
+	entry.Size, err = io.Copy(hash, in)
+	entry.Sha256 = hex.EncodeToString(hash.Sum(nil))
+
+	return entry, err
+}
+
+//ManifestPath is the path of the file in the scan manifest, relative to the application root when it is beneath it
+func ManifestPath(appPath string, fqn string) string {
+	if rel, err := filepath.Rel(appPath, fqn); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return fqn
+}
@@ -0,0 +1,151 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Applications in progress listed on the console's progress line, the others are counted
+const PROGRESS_LINE_APPS = 3
+
+//ScanProgress tracks the files of a run analyzed so far, overall and per application, to report the progress of the
+//analysis, its throughput and when it should be done
+type ScanProgress struct {
+	files     int
+	analyzed  int
+	bytes     int64
+	apps      []*AppProgress
+	appsByKey map[string]*AppProgress
+	start     time.Time
+	last      time.Time
+	lastFiles int
+	lastBytes int64
+	sync.Mutex
+}
+
+//AppProgress is how many of an application's files have been analyzed
+type AppProgress struct {
+	Application string `json:"application"`
+	Files       int    `json:"files"`
+	Analyzed    int    `json:"analyzed"`
+}
+
+//ProgressSnapshot is the progress of the analysis at a point in time. The throughput is that since the previous
+//snapshot, the ETA is from the throughput since the analysis started.
+type ProgressSnapshot struct {
+	Event          string        `json:"event"`
+	Files          int           `json:"files"`
+	Analyzed       int           `json:"analyzed"`
+	Percent        float64       `json:"percent"`
+	MB             float64       `json:"mb"`
+	FilesPerSecond float64       `json:"filesPerSecond"`
+	MBPerSecond    float64       `json:"mbPerSecond"`
+	Elapsed        int           `json:"elapsedSeconds"`
+	ETA            int           `json:"etaSeconds"`
+	Apps           int           `json:"apps"`
+	AppsAnalyzed   int           `json:"appsAnalyzed"`
+	InProgress     []AppProgress `json:"inProgress"`
+}
+
+func NewScanProgress(apps []*Application, start time.Time) *ScanProgress {
+
+	progress := &ScanProgress{appsByKey: make(map[string]*AppProgress), start: start, last: start}
+
+	for _, app := range apps {
+		progress.AddApp(app)
+	}
+
+	return progress
+}
+
+//AddApp tracks the files of another application. I.E. one claimed by a worker of a distributed run
+func (p *ScanProgress) AddApp(app *Application) {
+	p.Lock()
+	defer p.Unlock()
+
+	appProgress := &AppProgress{Application: app.Name, Files: len(app.Files)}
+	p.apps = append(p.apps, appProgress)
+	p.appsByKey[app.Name] = appProgress
+	p.files += appProgress.Files
+}
+
+//FileAnalyzed counts a file of the application, of size bytes, as analyzed
+func (p *ScanProgress) FileAnalyzed(app string, bytes int64) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.analyzed++
+	p.bytes += bytes
+	if appProgress, found := p.appsByKey[app]; found {
+		appProgress.Analyzed++
+	}
+}
+
+//Snapshot returns the progress at now, the start of the throughput of the next snapshot
+func (p *ScanProgress) Snapshot(now time.Time) ProgressSnapshot {
+	p.Lock()
+	defer p.Unlock()
+
+	snapshot := ProgressSnapshot{Event: "progress", Files: p.files, Analyzed: p.analyzed, Percent: 100, Apps: len(p.apps),
+		MB: toMB(p.bytes), Elapsed: int(now.Sub(p.start).Seconds()), InProgress: []AppProgress{}}
+
+	if p.files > 0 {
+		snapshot.Percent = math.Round(float64(p.analyzed)/float64(p.files)*1000) / 10
+	}
+
+	if interval := now.Sub(p.last).Seconds(); interval > 0 {
+		snapshot.FilesPerSecond = math.Round(float64(p.analyzed-p.lastFiles)/interval*10) / 10
+		snapshot.MBPerSecond = math.Round(toMB(p.bytes-p.lastBytes)/interval*10) / 10
+	}
+
+	if elapsed := now.Sub(p.start).Seconds(); elapsed > 0 && p.analyzed > 0 {
+		snapshot.ETA = int(math.Ceil(float64(p.files-p.analyzed) / (float64(p.analyzed) / elapsed)))
+	}
+
+	for _, app := range p.apps {
+		if app.Analyzed >= app.Files {
+			snapshot.AppsAnalyzed++
+		} else if app.Analyzed > 0 {
+			snapshot.InProgress = append(snapshot.InProgress, *app)
+		}
+	}
+
+	p.last, p.lastFiles, p.lastBytes = now, p.analyzed, p.bytes
+
+	return snapshot
+}
+
+//String is the progress line printed to the console
+func (s ProgressSnapshot) String() string {
+
+	var line strings.Builder
+	line.WriteString(fmt.Sprintf("%d/%d files (%.1f%%) | %.1f files/s | %.1f MB/s", s.Analyzed, s.Files, s.Percent, s.FilesPerSecond, s.MBPerSecond))
+
+	if s.Analyzed < s.Files && s.Analyzed > 0 {
+		line.WriteString(fmt.Sprintf(" | ETA %v", time.Duration(s.ETA)*time.Second))
+	}
+
+	line.WriteString(fmt.Sprintf(" | apps %d/%d", s.AppsAnalyzed, s.Apps))
+
+	for i, app := range s.InProgress {
+		if i == PROGRESS_LINE_APPS {
+			line.WriteString(fmt.Sprintf(" +%d", len(s.InProgress)-PROGRESS_LINE_APPS))
+			break
+		}
+		line.WriteString(fmt.Sprintf(" %s %d/%d", app.Application, app.Analyzed, app.Files))
+	}
+
+	return line.String()
+}
+
+func toMB(bytes int64) float64 {
+	return float64(bytes) / (1 << 20)
+}
@@ -0,0 +1,70 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+//Scheduler is a batch framework or scheduler running an application's jobs on its servers (Spring Batch, Quartz, EJB
+//timers, cron...), found by the tags of its findings, with the cloud native scheduler its jobs are re-platformed to
+type Scheduler struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Replacement string   `json:"replacement"`
+	Tags        []string `json:"tags"`
+}
+
+//SchedulerResult is a scheduler found in an application
+type SchedulerResult struct {
+	Scheduler   string   `json:"scheduler"`
+	Replacement string   `json:"replacement"`
+	Findings    int      `json:"findings"`
+	Effort      int      `json:"effort"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+//ScheduledJobs is what re-platforming an application's batch and scheduled jobs takes: the schedulers running them
+//and the findings and effort of each
+type ScheduledJobs struct {
+	Application string            `json:"application"`
+	Findings    int               `json:"findings"`
+	Effort      int               `json:"effort"`
+	Schedulers  []SchedulerResult `json:"schedulers"`
+}
+
+var Schedulers = []Scheduler{
+	{Name: "spring-batch", Description: "Spring Batch jobs", Replacement: "Spring Cloud Task or a Kubernetes Job, launched by Spring Cloud Data Flow or a CronJob",
+		Tags: []string{"spring-batch"}},
+	{Name: "jakarta-batch", Description: "Jakarta Batch (JSR-352) jobs of the application server", Replacement: "Spring Batch on Spring Cloud Task or a Kubernetes Job",
+		Tags: []string{"jakarta-batch"}},
+	{Name: "quartz", Description: "Quartz jobs and triggers", Replacement: "A Kubernetes CronJob, or Quartz clustered on a managed database",
+		Tags: []string{"quartz"}},
+	{Name: "ejb-timer", Description: "EJB timers of the application server", Replacement: "A Kubernetes CronJob calling the application or running a task",
+		Tags: []string{"ejb-timer"}},
+	{Name: "in-process", Description: "Schedules of the application's process (@Scheduled, executors)", Replacement: "A lock so each run happens once (ShedLock), or a Kubernetes CronJob",
+		Tags: []string{"spring-scheduling", "in-process-scheduler"}},
+	{Name: "cron", Description: "Cron entries of the servers", Replacement: "A Kubernetes CronJob or the platform's scheduler",
+		Tags: []string{"cron"}},
+	{Name: "windows-task", Description: "Windows scheduled tasks", Replacement: "A Kubernetes CronJob or the platform's scheduler",
+		Tags: []string{"windows-task"}},
+	{Name: "database", Description: "Jobs scheduled in the database (DBMS_SCHEDULER, SQL Server Agent)", Replacement: "The scheduler of the managed database, or a Kubernetes CronJob",
+		Tags: []string{"db-scheduler"}},
+}
+
+//EvaluateScheduledJobs finds the schedulers running the application's jobs from the totals of its findings by tag
+func EvaluateScheduledJobs(app string, tagTotals TagTotals, schedulers []Scheduler) ScheduledJobs {
+
+	totals := tagTotals.LowerCased()
+	jobs := ScheduledJobs{Application: app, Schedulers: []SchedulerResult{}}
+
+	for _, scheduler := range schedulers {
+		if total, tags := totals.Group(scheduler.Tags); total.Findings > 0 {
+			jobs.Findings += total.Findings
+			jobs.Effort += total.Effort
+			jobs.Schedulers = append(jobs.Schedulers, SchedulerResult{Scheduler: scheduler.Name, Replacement: scheduler.Replacement,
+				Findings: total.Findings, Effort: total.Effort, Tags: tags})
+		}
+	}
+
+	return jobs
+}
@@ -0,0 +1,104 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var scoreBinColorRegex = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[a-zA-Z]+)$`)
+
+//ScoreBin classifies scores into tiers. A bin holds the scores from its minimum up to, but excluding, its maximum. The
+//bin with the highest maximum holds its maximum too, so the best possible score is classified.
+type ScoreBin struct {
+	ID        uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt time.Time `json:"-" yaml:"-"`
+	UpdatedAt time.Time `json:"-" yaml:"-"`
+	Name      string    `gorm:"type:text;unique_index;not null" json:"name" yaml:"name"`
+	Color     string    `gorm:"type:text" json:"color" yaml:"color"`
+	MinScore  float64   `json:"minScore" yaml:"min-score"`
+	MaxScore  float64   `json:"maxScore" yaml:"max-score"`
+}
+
+//DefaultScoreBins are the tiers created with a new database, covering the default scoring model bounds
+func DefaultScoreBins() []ScoreBin {
+	return []ScoreBin{
+		{Name: "low", Color: "#d9534f", MinScore: DEFAULT_MIN_SCORE, MaxScore: 4},
+		{Name: "medium", Color: "#f0ad4e", MinScore: 4, MaxScore: 7},
+		{Name: "high", Color: "#5cb85c", MinScore: 7, MaxScore: DEFAULT_MAX_SCORE},
+	}
+}
+
+func (b *ScoreBin) Validate() error {
+	if strings.TrimSpace(b.Name) == "" {
+		return fmt.Errorf("a score bin must have a name")
+	}
+	if b.MinScore >= b.MaxScore {
+		return fmt.Errorf("score bin [%s] min score [%v] must be below its max score [%v]", b.Name, b.MinScore, b.MaxScore)
+	}
+	if b.Color != "" && !scoreBinColorRegex.MatchString(b.Color) {
+		return fmt.Errorf("score bin [%s] color [%s] must be a #rgb/#rrggbb hex color or a color name", b.Name, b.Color)
+	}
+	return nil
+}
+
+//ValidateScoreBins checks every bin and that no two bins overlap. Gaps are allowed, scores falling in one have no bin.
+func ValidateScoreBins(bins []ScoreBin) error {
+
+	names := make(map[string]bool)
+	sorted := sortedScoreBins(bins)
+	for i := range sorted {
+		if err := sorted[i].Validate(); err != nil {
+			return err
+		}
+		if names[strings.ToLower(sorted[i].Name)] {
+			return fmt.Errorf("more than 1 score bin named [%s]", sorted[i].Name)
+		}
+		names[strings.ToLower(sorted[i].Name)] = true
+		if i > 0 && sorted[i].MinScore < sorted[i-1].MaxScore {
+			return fmt.Errorf("score bin [%s] (%v-%v) overlaps score bin [%s] (%v-%v)", sorted[i].Name, sorted[i].MinScore,
+				sorted[i].MaxScore, sorted[i-1].Name, sorted[i-1].MinScore, sorted[i-1].MaxScore)
+		}
+	}
+	return nil
+}
+
+//ScoreBinFor returns the bin the score falls in, nil if none
+func ScoreBinFor(bins []ScoreBin, score float64) *ScoreBin {
+
+	sorted := sortedScoreBins(bins)
+	for i := range sorted {
+		if score >= sorted[i].MinScore && score < sorted[i].MaxScore {
+			return &sorted[i]
+		}
+	}
+
+	if last := len(sorted) - 1; last >= 0 && score == sorted[last].MaxScore {
+		return &sorted[last]
+	}
+
+	return nil
+}
+
+//ScoreBinName is the name of the bin the score falls in, empty if none
+func ScoreBinName(bins []ScoreBin, score float64) string {
+	if bin := ScoreBinFor(bins, score); bin != nil {
+		return bin.Name
+	}
+	return ""
+}
+
+func sortedScoreBins(bins []ScoreBin) []ScoreBin {
+	sorted := append([]ScoreBin(nil), bins...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinScore < sorted[j].MinScore
+	})
+	return sorted
+}
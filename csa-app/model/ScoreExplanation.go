@@ -0,0 +1,174 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+)
+
+//ScoreContribution is what the scored findings of a rule (or of all the rules of a category when the rule is empty)
+//contribute to an application's score: their share of its raw score and the score points they cost it
+type ScoreContribution struct {
+	Rule     string        `json:"rule,omitempty"`
+	Category string        `json:"category"`
+	Findings int           `json:"findings"`
+	Effort   int           `json:"effort"`
+	Share    float64       `json:"share"`
+	Impact   float64       `json:"impact"`
+	Totals   *WhatIfTotals `json:"-"`
+}
+
+//ScoreExplanation shows the math of an application's score: the raw score the effort of its findings adds up to, the
+//score the run's scorer turns it into and what each rule and category contributes to it, by impact
+type ScoreExplanation struct {
+	Application    string              `json:"application"`
+	Scorer         string              `json:"scorer"`
+	ScoringModel   string              `json:"model"`
+	SlocCnt        int                 `json:"slocCnt"`
+	Findings       int                 `json:"findings"`
+	RawScore       int                 `json:"rawScore"`
+	MaxScore       float64             `json:"maxScore"`
+	Score          float64             `json:"score"`
+	ScoreModified  bool                `json:"scoreModified"`
+	ModifiedScore  float64             `json:"modifiedScore,omitempty"`
+	Tier           string              `json:"tier,omitempty"`
+	Recommendation string              `json:"recommendation"`
+	Rules          []ScoreContribution `json:"rules"`
+	Categories     []ScoreContribution `json:"categories"`
+}
+
+//ExplainScore rescores the application with the scorer as if the findings of each of its rules, then of each of its
+//categories, were resolved. The score points gained are the impact of the rule (category). The other applications of
+//the run are rescored alongside it, scorers like the percentile one ranking them against each other, but none of the
+//applications is modified. Applications need their scoring model and, for the formula scorer, their tag totals.
+func ExplainScore(app *Application, others []*Application, contributions []*ScoreContribution, scorer Scorer) (*ScoreExplanation, error) {
+
+	score, err := rescore(app, others, nil, scorer)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &ScoreExplanation{Application: app.Name, Scorer: scorer.Name(), ScoringModel: app.ScoringModel, SlocCnt: app.SlocCnt,
+		Findings: app.Findings, RawScore: app.RawScore, Score: score.Score, Recommendation: score.Recommendation,
+		ScoreModified: app.ScoreModified}
+	if app.Model != nil {
+		explanation.MaxScore = app.Model.MaxScore
+	}
+	if app.ScoreModified {
+		explanation.ModifiedScore = app.Score
+	}
+
+	byCategory := make(map[string]*ScoreContribution)
+	var categories []*ScoreContribution
+	for _, contribution := range contributions {
+		category, found := byCategory[contribution.Category]
+		if !found {
+			category = &ScoreContribution{Category: contribution.Category, Totals: &WhatIfTotals{Tags: make(TagTotals)}}
+			byCategory[contribution.Category] = category
+			categories = append(categories, category)
+		}
+		category.Findings += contribution.Findings
+		category.Effort += contribution.Effort
+		category.Totals.Add(contribution.Totals)
+	}
+
+	explain := func(contributions []*ScoreContribution) ([]ScoreContribution, error) {
+		explained := []ScoreContribution{}
+		for _, contribution := range contributions {
+			resolved, err := rescore(app, others, contribution.Totals, scorer)
+			if err != nil {
+				return nil, err
+			}
+			contribution.Impact = resolved.Score - score.Score
+			if app.RawScore > 0 {
+				contribution.Share = 100 * float64(contribution.Effort) / float64(app.RawScore)
+			}
+			explained = append(explained, *contribution)
+		}
+		sortContributions(explained)
+		return explained, nil
+	}
+
+	if explanation.Rules, err = explain(contributions); err != nil {
+		return nil, err
+	}
+	if explanation.Categories, err = explain(categories); err != nil {
+		return nil, err
+	}
+
+	return explanation, nil
+}
+
+//rescore scores a copy of the application, with the totals resolved, alongside copies of the others
+func rescore(app *Application, others []*Application, resolved *WhatIfTotals, scorer Scorer) (*Application, error) {
+
+	scored := scoringCopy(app)
+	scored.Resolve(resolved)
+
+	apps := []*Application{scored}
+	for _, other := range others {
+		if other != app {
+			apps = append(apps, scoringCopy(other))
+		}
+	}
+
+	return scored, scorer.Score(apps)
+}
+
+//scoringCopy copies what scorers score an application with
+func scoringCopy(app *Application) *Application {
+
+	tagTotals := make(TagTotals, len(app.TagTotals))
+	for tag, total := range app.TagTotals {
+		tagTotals[tag] = total
+	}
+
+	return &Application{Name: app.Name, ScoringModel: app.ScoringModel, Model: app.Model, BusinessValue: app.BusinessValue,
+		Findings: app.Findings, CIFindings: app.CIFindings, InfoFindings: app.InfoFindings, RawScore: app.RawScore,
+		NumCrits: app.NumCrits, CriticalCnt: app.CriticalCnt, HighCnt: app.HighCnt, SlocCnt: app.SlocCnt, FilesCnt: app.FilesCnt,
+		TagTotals: tagTotals}
+}
+
+//sortContributions orders the contributions by impact, the costliest first, then effort and name
+func sortContributions(contributions []ScoreContribution) {
+	sort.SliceStable(contributions, func(i, j int) bool {
+		if contributions[i].Impact != contributions[j].Impact {
+			return contributions[i].Impact > contributions[j].Impact
+		}
+		if contributions[i].Effort != contributions[j].Effort {
+			return contributions[i].Effort > contributions[j].Effort
+		}
+		if contributions[i].Category != contributions[j].Category {
+			return contributions[i].Category < contributions[j].Category
+		}
+		return contributions[i].Rule < contributions[j].Rule
+	})
+}
+
+//Add accumulates other totals into these
+func (t *WhatIfTotals) Add(other *WhatIfTotals) {
+
+	if other == nil {
+		return
+	}
+
+	t.Findings += other.Findings
+	t.RawScore += other.RawScore
+	t.CIFindings += other.CIFindings
+	t.NumCrits += other.NumCrits
+	t.CriticalCnt += other.CriticalCnt
+	t.HighCnt += other.HighCnt
+
+	if t.Tags == nil {
+		t.Tags = make(TagTotals)
+	}
+	for tag, total := range other.Tags {
+		sum := t.Tags[tag]
+		sum.Findings += total.Findings
+		sum.Effort += total.Effort
+		t.Tags[tag] = sum
+	}
+}
@@ -0,0 +1,97 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const NORMALIZE_NONE = "none"
+const NORMALIZE_SLOC = "sloc"
+const NORMALIZE_FILES = "files"
+
+//Raw scores are normalized to the raw score per this many lines of code or files. Smaller applications count as one unit.
+const NORMALIZE_SLOC_UNIT = 1000
+const NORMALIZE_FILES_UNIT = 100
+
+func ValidateNormalization(by string) error {
+	switch strings.ToLower(by) {
+	case "", NORMALIZE_NONE, NORMALIZE_SLOC, NORMALIZE_FILES:
+		return nil
+	}
+	return fmt.Errorf("unknown normalization [%s]. Valid normalizations are %s|%s|%s", by, NORMALIZE_NONE, NORMALIZE_SLOC, NORMALIZE_FILES)
+}
+
+//NormalizedRawScore is the application's raw score per 1000 lines of code (sloc) or per 100 files (files), so large
+//applications don't score worse only for having more code
+func NormalizedRawScore(app *Application, by string) int {
+
+	var units float64
+	switch strings.ToLower(by) {
+	case NORMALIZE_SLOC:
+		units = float64(app.SlocCnt) / NORMALIZE_SLOC_UNIT
+	case NORMALIZE_FILES:
+		units = float64(app.FilesCnt) / NORMALIZE_FILES_UNIT
+	default:
+		return app.RawScore
+	}
+
+	return int(math.Round(float64(app.RawScore) / math.Max(1, units)))
+}
+
+//normalizedScorer scores the applications as if their raw score was their normalized raw score
+type normalizedScorer struct {
+	Scorer
+	by string
+}
+
+//NormalizeScorer makes the scorer score normalized raw scores. The scorer is returned as is without normalization.
+func NormalizeScorer(scorer Scorer, by string) (Scorer, error) {
+
+	if err := ValidateNormalization(by); err != nil {
+		return nil, err
+	}
+
+	by = strings.ToLower(by)
+	if by == "" || by == NORMALIZE_NONE {
+		return scorer, nil
+	}
+
+	return &normalizedScorer{Scorer: scorer, by: by}, nil
+}
+
+func (s *normalizedScorer) Score(apps []*Application) error {
+
+	rawScores := make([]int, len(apps))
+	for i, app := range apps {
+		rawScores[i] = app.RawScore
+		app.RawScore = NormalizedRawScore(app, s.by)
+		app.normalized = true
+	}
+
+	//The raw score stays the total of the findings' effort
+	defer func() {
+		for i, app := range apps {
+			app.RawScore = rawScores[i]
+			app.normalized = false
+		}
+	}()
+
+	return s.Scorer.Score(apps)
+}
+
+//RunScorer is the scorer the run's applications are scored with: the run's scorer (or formula) and normalization
+func RunScorer(run *Run) (Scorer, error) {
+
+	scorer, err := ScorerFor(run.Scorer, run.ScoringFormula)
+	if err != nil {
+		return nil, err
+	}
+
+	return NormalizeScorer(scorer, run.Normalize)
+}
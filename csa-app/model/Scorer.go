@@ -0,0 +1,186 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const DEFAULT_SCORER = "default"
+const LOG_SCORER = "logarithmic"
+const PERCENTILE_SCORER = "percentile"
+
+//Raw score per 1000 lines of code at which the logarithmic scorer reaches the model's minimum score
+const LOG_SCORER_CEILING = 1000.0
+
+//Scorer turns the raw scores of a run's applications into their scores. Every scorer takes the bounds and the
+//recommendation from each application's scoring model, only the curve the score follows differs.
+type Scorer interface {
+	Name() string
+	//Score scores every application it can, returning an error naming those it couldn't
+	Score(apps []*Application) error
+}
+
+var scorers = map[string]Scorer{
+	DEFAULT_SCORER:    &modelScorer{},
+	LOG_SCORER:        &logScorer{},
+	PERCENTILE_SCORER: &percentileScorer{},
+}
+
+func GetScorer(name string) (Scorer, error) {
+	if name == "" {
+		name = DEFAULT_SCORER
+	}
+	if scorer, found := scorers[strings.ToLower(name)]; found {
+		return scorer, nil
+	}
+	if strings.ToLower(name) == FORMULA_SCORER {
+		return nil, fmt.Errorf("the [%s] scorer needs a scoring formula", FORMULA_SCORER)
+	}
+	return nil, fmt.Errorf("unknown scorer [%s]. Valid scorers are %s", name, strings.Join(ScorerNames(), "|"))
+}
+
+//ScorerFor returns the named scorer, the formula scorer (FORMULA_SCORER) evaluating the given formula
+func ScorerFor(name string, formula string) (Scorer, error) {
+	if strings.ToLower(name) != FORMULA_SCORER {
+		return GetScorer(name)
+	}
+
+	scoringFormula := &ScoringFormula{Name: FORMULA_SCORER, Formula: formula}
+	if err := scoringFormula.Validate(); err != nil {
+		return nil, err
+	}
+	return NewFormulaScorer(scoringFormula), nil
+}
+
+func ScorerNames() []string {
+	names := make([]string, 0, len(scorers))
+	for name := range scorers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//modelScorer scores each application with the calculation of the scoring model's outcome (the built-in curve)
+type modelScorer struct{}
+
+func (s *modelScorer) Name() string { return DEFAULT_SCORER }
+
+func (s *modelScorer) Score(apps []*Application) error {
+	return scoreEach(apps, func(app *Application) error {
+		return app.CalculateScore(nil)
+	})
+}
+
+//logScorer lowers the score by the same amount every time the raw score per 1000 lines of code grows tenfold, so
+//small and large applications are compared by the density of their findings rather than their total
+type logScorer struct{}
+
+func (s *logScorer) Name() string { return LOG_SCORER }
+
+func (s *logScorer) Score(apps []*Application) error {
+	return scoreEach(apps, func(app *Application) error {
+		return app.scoreWithin(1 - math.Log10(1+app.RawScoreDensity())/math.Log10(1+LOG_SCORER_CEILING))
+	})
+}
+
+//percentileScorer scores each application by how it ranks against the other applications of the run. The application
+//with the lowest raw score per 1000 lines of code gets the maximum score, the one with the highest the minimum.
+type percentileScorer struct{}
+
+func (s *percentileScorer) Name() string { return PERCENTILE_SCORER }
+
+func (s *percentileScorer) Score(apps []*Application) error {
+	return scoreEach(apps, func(app *Application) error {
+		if len(apps) < 2 {
+			return app.scoreWithin(1)
+		}
+
+		//Share of the other applications doing better, ties count half
+		better := 0.0
+		for _, other := range apps {
+			if other == app {
+				continue
+			}
+			if other.RawScoreDensity() < app.RawScoreDensity() {
+				better++
+			} else if other.RawScoreDensity() == app.RawScoreDensity() {
+				better += 0.5
+			}
+		}
+
+		return app.scoreWithin(1 - better/float64(len(apps)-1))
+	})
+}
+
+func scoreEach(apps []*Application, score func(app *Application) error) error {
+	var failed []string
+	for _, app := range apps {
+		if err := score(app); err != nil {
+			failed = append(failed, fmt.Sprintf("app [%s]: %v", app.Name, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("scoring failed for %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+//RawScoreDensity is the application's raw score per 1000 lines of code. Applications under 1000 lines count as 1000.
+//A raw score normalized for scoring is used as is.
+func (app *Application) RawScoreDensity() float64 {
+	if app.RawScore <= 0 {
+		return 0
+	}
+	if app.normalized {
+		return float64(app.RawScore)
+	}
+	return float64(app.RawScore) / math.Max(1, float64(app.SlocCnt)/1000)
+}
+
+//scoreWithin sets the score to the fraction (0-1) of the way from the model's minimum to its maximum score
+func (app *Application) scoreWithin(fraction float64) error {
+
+	if app.Model == nil {
+		return fmt.Errorf("unable to calculate score for app [%s] without scoring model", app.Name)
+	}
+
+	fraction = math.Max(0, math.Min(1, fraction))
+	return app.scoreAs(app.Model.MinScore + fraction*(app.Model.MaxScore-app.Model.MinScore))
+}
+
+//scoreAs sets the score, kept within the model's minimum and maximum score, and takes the recommendation from the
+//model's outcome. The outcome is picked by the raw score (with sloc and business value) rather than the score, so a
+//scorer changes how an application scores but not what it is recommended. Score bins classify the score itself.
+func (app *Application) scoreAs(score float64) error {
+
+	if app.Model == nil {
+		return fmt.Errorf("unable to calculate score for app [%s] without scoring model", app.Name)
+	}
+
+	outcome := app.Model.ProcessScore(*app)
+	if outcome == nil {
+		app.Score = math.NaN()
+		app.Recommendation = "Scoring Failed!"
+		return fmt.Errorf("scoring failed! No outcome from scoring model")
+	}
+
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		app.Score = math.NaN()
+		app.Recommendation = "Scoring Failed!"
+		return fmt.Errorf("score [%v] is not a number", score)
+	}
+
+	score = math.Max(app.Model.MinScore, math.Min(app.Model.MaxScore, score))
+	app.Score = math.Round(score*100) / 100
+	app.Recommendation = outcome.Recommendation
+
+	return nil
+}
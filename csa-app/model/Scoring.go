@@ -14,19 +14,22 @@ type PortfolioScore struct {
 	InfoFindings   int           `json:"infoFindings"`
 	RawScore       int           `json:"rawScore"`
 	AppScores      []Application `json:"appScores"`
+	ScoreBins      []ScoreBin    `json:"scoreBins"`
 	Recommendation string        `json:"recommendation"`
 }
 
 type ApplicationDetails struct {
-	Application   string  `json:"application"`
-	Findings      int     `json:"findings"`
-	CIFindings    int     `json:"ciFindings"`
-	InfoFindings  int     `json:"infoFindings"`
-	RawScore      int     `json:"rawScore"`
-	NumCrits      int     `json:"numCrits"`
-	SlocCnt       int     `json:"slocCnt"`
-	FilesCnt      int     `json:"filesCnt"`
-	FindingsRatio float64 `json:"findingsRatio"`
+	Application      string  `json:"application"`
+	Findings         int     `json:"findings"`
+	CIFindings       int     `json:"ciFindings"`
+	InfoFindings     int     `json:"infoFindings"`
+	RawScore         int     `json:"rawScore"`
+	NumCrits         int     `json:"numCrits"`
+	CriticalSeverity int     `json:"criticalSeverity"` //By severity (risk) rather than effort like NumCrits
+	HighSeverity     int     `json:"highSeverity"`
+	SlocCnt          int     `json:"slocCnt"`
+	FilesCnt         int     `json:"filesCnt"`
+	FindingsRatio    float64 `json:"findingsRatio"`
 }
 
 type ApplicationsForRun struct {
@@ -0,0 +1,206 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"csa-app/util"
+
+	"gopkg.in/yaml.v2"
+)
+
+const FORMULA_SCORER = "formula"
+
+//Prefixes of the per tag variables of a scoring formula. I.E. effort_jms is the effort of the findings tagged jms.
+const FORMULA_EFFORT_PREFIX = "effort_"
+const FORMULA_FINDINGS_PREFIX = "findings_"
+
+var formulaVariableRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+//ScoringFormula maps an application's raw numbers to its score with an expression instead of the scorers' fixed
+//curves. The score is kept within the bounds of the application's scoring model, which supplies the recommendation.
+type ScoringFormula struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Formula     string `json:"formula" yaml:"formula"`
+}
+
+//TagTotal is the number and effort of an application's (first party) findings carrying a tag
+type TagTotal struct {
+	Findings int
+	Effort   int
+}
+
+type TagTotals map[string]TagTotal
+
+//LowerCased merges the totals of the tags differing only by case, rule tags aren't consistently cased
+func (totals TagTotals) LowerCased() TagTotals {
+	lowerCased := make(TagTotals)
+	for tag, total := range totals {
+		tag = strings.ToLower(tag)
+		lowerCased[tag] = TagTotal{Findings: lowerCased[tag].Findings + total.Findings, Effort: lowerCased[tag].Effort + total.Effort}
+	}
+	return lowerCased
+}
+
+//Group adds up the totals of the tags of a group (I.E. those of a Windows dependency) and returns the tags found, sorted
+func (totals TagTotals) Group(tags []string) (group TagTotal, found []string) {
+	for _, tag := range tags {
+		if total := totals[tag]; total.Findings > 0 {
+			group.Findings += total.Findings
+			group.Effort += total.Effort
+			found = append(found, tag)
+		}
+	}
+	sort.Strings(found)
+	return
+}
+
+//LoadScoringFormula reads a (yaml|json) formula file
+func LoadScoringFormula(file string) (*ScoringFormula, error) {
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	//yaml is a superset of json
+	formula := &ScoringFormula{}
+	if err = yaml.UnmarshalStrict(data, formula); err != nil {
+		return nil, fmt.Errorf("scoring formula file [%s] is invalid! Details: %v", file, err)
+	}
+
+	if formula.Name == "" {
+		formula.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	return formula, formula.Validate()
+}
+
+func (f *ScoringFormula) Validate() error {
+
+	if strings.TrimSpace(f.Formula) == "" {
+		return fmt.Errorf("scoring formula [%s] has no formula", f.Name)
+	}
+
+	expression, err := util.NewExpression(nil).Compile(f.Formula)
+	if err != nil {
+		return fmt.Errorf("scoring formula [%s] is invalid! Details: %v", f.Name, err)
+	}
+
+	known := FormulaVariables(&Application{}, nil)
+	for _, variable := range expression.Vars() {
+		if _, found := known[variable]; !found && !isTagVariable(variable) {
+			return fmt.Errorf("scoring formula [%s] uses unknown variable [%s]. Valid variables are %s, %s<tag> and %s<tag>",
+				f.Name, variable, strings.Join(sortedVariables(known), ", "), FORMULA_EFFORT_PREFIX, FORMULA_FINDINGS_PREFIX)
+		}
+	}
+
+	return nil
+}
+
+//Calculate evaluates the formula for the application. Tags the application has no findings for count as 0.
+func (f *ScoringFormula) Calculate(app *Application) (float64, error) {
+
+	variables := FormulaVariables(app, app.TagTotals)
+
+	ex := util.NewExpression(variables)
+	expression, err := ex.Compile(f.Formula)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, variable := range expression.Vars() {
+		if _, found := variables[variable]; !found && isTagVariable(variable) {
+			variables[variable] = 0
+		}
+	}
+
+	return ex.Calculate(f.Formula)
+}
+
+//FormulaVariables are the variables a formula can use: those of the scoring model expressions plus the effort and
+//finding count of every tag
+func FormulaVariables(app *Application, tags TagTotals) map[string]interface{} {
+
+	variables := map[string]interface{}{
+		RAW_SCORE_SCORING_TOKEN:         app.RawScore,
+		FILES_SCORING_TOKEN:             app.FilesCnt,
+		FINDINGS_SCORING_TOKEN:          app.Findings,
+		SLOC_CNT_SCORING_TOKEN:          app.SlocCnt,
+		BUSINESS_VALUE_SCORING_TOKEN:    app.BusinessValue,
+		CRITICAL_SEVERITY_SCORING_TOKEN: app.CriticalCnt,
+		HIGH_SEVERITY_SCORING_TOKEN:     app.HighCnt,
+		MAX_SCORE:                       0.0,
+		MIN_SCORE:                       0.0,
+	}
+
+	if app.Model != nil {
+		variables[MAX_SCORE] = app.Model.MaxScore
+		variables[MIN_SCORE] = app.Model.MinScore
+	}
+
+	for tag, total := range tags {
+		name := FormulaTagVariable(tag)
+		//Tags that only differ by punctuation share a variable
+		variables[FORMULA_EFFORT_PREFIX+name] = addInt(variables[FORMULA_EFFORT_PREFIX+name], total.Effort)
+		variables[FORMULA_FINDINGS_PREFIX+name] = addInt(variables[FORMULA_FINDINGS_PREFIX+name], total.Findings)
+	}
+
+	return variables
+}
+
+//FormulaTagVariable is the name a tag has in formula variables, with anything but letters and digits replaced by _
+func FormulaTagVariable(tag string) string {
+	return formulaVariableRegex.ReplaceAllString(strings.ToLower(tag), "_")
+}
+
+func isTagVariable(variable string) bool {
+	return strings.HasPrefix(variable, FORMULA_EFFORT_PREFIX) || strings.HasPrefix(variable, FORMULA_FINDINGS_PREFIX)
+}
+
+func addInt(current interface{}, value int) int {
+	if total, ok := current.(int); ok {
+		return total + value
+	}
+	return value
+}
+
+func sortedVariables(variables map[string]interface{}) []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//formulaScorer scores each application with a scoring formula
+type formulaScorer struct {
+	formula *ScoringFormula
+}
+
+func NewFormulaScorer(formula *ScoringFormula) Scorer {
+	return &formulaScorer{formula: formula}
+}
+
+func (s *formulaScorer) Name() string { return FORMULA_SCORER }
+
+func (s *formulaScorer) Score(apps []*Application) error {
+	return scoreEach(apps, func(app *Application) error {
+		score, err := s.formula.Calculate(app)
+		if err != nil {
+			return err
+		}
+		return app.scoreAs(score)
+	})
+}
@@ -0,0 +1,215 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"csa-app/util"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+)
+
+//ScriptInput is what a script rule sees of the file it is run against
+type ScriptInput struct {
+	Path    string
+	Name    string
+	Ext     string
+	Content string
+	Hits    map[string]int //Findings of the rules that ran before the script rule on this file
+}
+
+//ScriptResult of running a script rule. Effort is the rule's effort unless the script returned a number
+type ScriptResult struct {
+	Matched bool
+	Effort  int
+	Value   string
+}
+
+func (r *Rule) IsScript() bool {
+	return r.Type == SCRIPT_MATCH_TYPE
+}
+
+//compiledScript is a script parsed and type checked once, when the rule's patterns are compiled. Its functions are
+//bound to the file the script is evaluated against.
+type compiledScript struct {
+	source string
+	ast    *cel.Ast
+}
+
+var (
+	scriptEnv     *cel.Env
+	scriptEnvErr  error
+	scriptEnvOnce sync.Once
+)
+
+//scriptEnvironment declares the parameters and functions scripts are checked against
+func scriptEnvironment() (*cel.Env, error) {
+	scriptEnvOnce.Do(func() {
+		scriptEnv, scriptEnvErr = cel.NewEnv(
+			cel.Variable("content", cel.StringType),
+			cel.Variable("path", cel.StringType),
+			cel.Variable("name", cel.StringType),
+			cel.Variable("ext", cel.StringType),
+			cel.Variable("lines", cel.IntType),
+			cel.Variable("findings", cel.IntType),
+			cel.Function("count", cel.Overload("count_string", []*cel.Type{cel.StringType}, cel.IntType)),
+			cel.Function("matched", cel.Overload("matched_string", []*cel.Type{cel.StringType}, cel.IntType)),
+		)
+	})
+	return scriptEnv, scriptEnvErr
+}
+
+//RunScript evaluates a script rule against a file. The script is a CEL expression that can only read its parameters
+//and call the functions below. A boolean result decides whether the rule matched. A numeric result matches when
+//greater than zero and becomes the effort of the finding.
+func (r *Rule) RunScript(input *ScriptInput) (result ScriptResult, err error) {
+
+	script := r.script
+	if script == nil || script.source != r.Script {
+		//Rules that weren't compiled (or whose script changed since) are parsed on demand
+		if script, err = compileScript(r.Script); err != nil {
+			return result, err
+		}
+	}
+
+	program, err := script.bind(input)
+	if err != nil {
+		return result, err
+	}
+
+	total := 0
+	for _, hits := range input.Hits {
+		total += hits
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{
+		"content":  input.Content,
+		"path":     input.Path,
+		"name":     input.Name,
+		"ext":      input.Ext,
+		"lines":    strings.Count(input.Content, "\n"),
+		"findings": total,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	switch v := out.Value().(type) {
+	case bool:
+		result.Matched = v
+		result.Effort = r.Effort
+	case int64:
+		result.Matched = v > 0
+		result.Effort = int(v)
+	case float64:
+		result.Matched = v > 0
+		result.Effort = int(math.Round(v))
+	default:
+		return result, fmt.Errorf("script returned [%v] rather than a boolean or number", out.Value())
+	}
+
+	result.Value = fmt.Sprintf("%v", out.Value())
+
+	return result, nil
+}
+
+func compileScript(script string) (*compiledScript, error) {
+
+	env, err := scriptEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(script)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	switch ast.OutputType() {
+	case cel.BoolType, cel.IntType, cel.DoubleType, cel.DynType:
+	default:
+		return nil, fmt.Errorf("script returns a %s rather than a boolean or number", ast.OutputType())
+	}
+
+	return &compiledScript{source: script, ast: ast}, nil
+}
+
+//bind plans the checked script with its functions reading the given file
+func (s *compiledScript) bind(input *ScriptInput) (cel.Program, error) {
+
+	env, err := scriptEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	return env.Program(s.ast, cel.Functions(scriptFunctions(input)...))
+}
+
+func scriptFunctions(input *ScriptInput) []*functions.Overload {
+	return []*functions.Overload{
+		//count(regex) is the number of times regex occurs in the file, within the --regex-timeout
+		{
+			Operator: "count",
+			Unary: func(arg ref.Val) ref.Val {
+				regex, err := util.CompileRegex(fmt.Sprintf("%v", arg.Value()))
+				if err != nil {
+					return types.NewErr("count: %v", err)
+				}
+				return types.Int(len(util.FindAllRegex(regex, input.Content)))
+			},
+		},
+		//matched(rule) is the number of findings of the rule earlier in the file
+		{
+			Operator: "matched",
+			Unary: func(arg ref.Val) ref.Val {
+				return types.Int(input.Hits[fmt.Sprintf("%v", arg.Value())])
+			},
+		},
+	}
+}
+
+func (r *Rule) scriptIsValid() (isValid bool, err error) {
+
+	if r.Target != CONTENTS_TARGET {
+		return false, fmt.Errorf("Script Rule Target must be %s but was: %s", CONTENTS_TARGET, r.Target)
+	}
+
+	if strings.TrimSpace(r.Script) == "" {
+		return false, fmt.Errorf("Script Rule must have a script")
+	}
+
+	if _, err = compileScript(r.Script); err != nil {
+		return false, fmt.Errorf("Script Rule Script is invalid! Details: %v", err)
+	}
+
+	if len(r.Patterns) > 0 {
+		return false, fmt.Errorf("Script Rule cannot have patterns. Its script decides if it matches")
+	}
+
+	isValid, err = r.impactIsValid()
+	if !isValid {
+		return isValid, err
+	}
+
+	if err = ValidateSeverity(r.Severity); err != nil {
+		return false, fmt.Errorf("Rule %s", err.Error())
+	}
+
+	for _, exclusion := range r.Unless {
+		if err = exclusion.IsValid(); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
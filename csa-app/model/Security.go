@@ -0,0 +1,207 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Findings tagged (or categorized) security are security findings, whether or not they are mapped to a CWE
+const SECURITY_TAG = "security"
+
+//CWE tags form the security tag family, I.E. cwe-89 maps a finding to CWE-89 (SQL injection). Any finding carrying
+//one is a security finding.
+const CWE_TAG_PREFIX = "cwe-"
+
+//Security findings not mapped to any CWE are grouped under
+const CWE_UNMAPPED = "unmapped"
+
+var cweTagRegex = regexp.MustCompile(`^cwe-([1-9][0-9]*)$`)
+
+//Names of the CWEs most likely to be mapped by rules (https://cwe.mitre.org), others are reported by id only
+var CweNames = map[int]string{
+	20:   "Improper Input Validation",
+	22:   "Path Traversal",
+	78:   "OS Command Injection",
+	79:   "Cross-site Scripting",
+	89:   "SQL Injection",
+	90:   "LDAP Injection",
+	94:   "Code Injection",
+	98:   "PHP Remote File Inclusion",
+	200:  "Exposure of Sensitive Information",
+	250:  "Execution with Unnecessary Privileges",
+	256:  "Plaintext Storage of a Password",
+	259:  "Use of Hard-coded Password",
+	287:  "Improper Authentication",
+	295:  "Improper Certificate Validation",
+	311:  "Missing Encryption of Sensitive Data",
+	312:  "Cleartext Storage of Sensitive Information",
+	319:  "Cleartext Transmission of Sensitive Information",
+	326:  "Inadequate Encryption Strength",
+	327:  "Use of a Broken or Risky Cryptographic Algorithm",
+	328:  "Use of Weak Hash",
+	330:  "Use of Insufficiently Random Values",
+	347:  "Improper Verification of Cryptographic Signature",
+	352:  "Cross-Site Request Forgery",
+	377:  "Insecure Temporary File",
+	502:  "Deserialization of Untrusted Data",
+	522:  "Insufficiently Protected Credentials",
+	532:  "Insertion of Sensitive Information into Log File",
+	598:  "Use of GET Request Method With Sensitive Query Strings",
+	611:  "XML External Entity Reference",
+	732:  "Incorrect Permission Assignment for Critical Resource",
+	798:  "Use of Hard-coded Credentials",
+	918:  "Server-Side Request Forgery",
+	1104: "Use of Unmaintained Third Party Components",
+}
+
+//SecurityCwe is the security findings of a run mapped to a CWE (or unmapped), with the highest severity among them
+type SecurityCwe struct {
+	Cwe          string         `json:"cwe"`
+	Name         string         `json:"name,omitempty"`
+	Severity     string         `json:"severity,omitempty"`
+	Findings     int            `json:"findings"`
+	Effort       int            `json:"effort"`
+	Severities   map[string]int `json:"severities"`
+	Rules        []string       `json:"rules"`
+	Applications []string       `json:"applications"`
+}
+
+//IsCweTag is true for the tags of the cwe family, well formed or not
+func IsCweTag(tag string) bool {
+	return strings.HasPrefix(strings.ToLower(tag), CWE_TAG_PREFIX)
+}
+
+//CweId returns the CWE a cwe tag maps to
+func CweId(tag string) (int, bool) {
+	match := cweTagRegex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(tag)))
+	if match == nil {
+		return 0, false
+	}
+	id, err := strconv.Atoi(match[1])
+	return id, err == nil
+}
+
+//ValidateCweTag checks a tag of the cwe family is cwe-<id>. Other tags are left alone.
+func ValidateCweTag(tag string) error {
+	if !IsCweTag(strings.TrimSpace(tag)) {
+		return nil
+	}
+	if _, ok := CweId(tag); !ok {
+		return fmt.Errorf("CWE tag [%s] must be %s<id>, I.E. %s89", tag, CWE_TAG_PREFIX, CWE_TAG_PREFIX)
+	}
+	return nil
+}
+
+//CweLabel formats a CWE id the way it is referred to, I.E. CWE-89
+func CweLabel(id int) string {
+	return fmt.Sprintf("CWE-%d", id)
+}
+
+//IsSecurityFinding is true for the findings categorized or tagged security, or mapped to a CWE
+func IsSecurityFinding(finding *Finding) bool {
+	if strings.EqualFold(finding.Category, SECURITY_TAG) {
+		return true
+	}
+	for _, tag := range finding.Tags {
+		if strings.EqualFold(tag.Value, SECURITY_TAG) || IsCweTag(tag.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+//GroupByCwe groups the security findings by the CWEs they are mapped to, the most severe, then most frequent, CWEs
+//first and the unmapped ones last. A finding mapped to several CWEs counts towards each of them.
+func GroupByCwe(findings []Finding) []SecurityCwe {
+
+	groups := make(map[string]*SecurityCwe)
+	ids := make(map[string]int)
+	rules := make(map[string]map[string]bool)
+	apps := make(map[string]map[string]bool)
+
+	add := func(cwe string, id int, finding *Finding) {
+		group, found := groups[cwe]
+		if !found {
+			group = &SecurityCwe{Cwe: cwe, Name: CweNames[id], Severities: make(map[string]int)}
+			groups[cwe] = group
+			ids[cwe] = id
+			rules[cwe] = make(map[string]bool)
+			apps[cwe] = make(map[string]bool)
+		}
+
+		group.Findings++
+		group.Effort += finding.Effort
+		if finding.Severity != "" {
+			group.Severities[strings.ToLower(finding.Severity)]++
+		}
+		if SeverityRank(finding.Severity) > SeverityRank(group.Severity) {
+			group.Severity = strings.ToLower(finding.Severity)
+		}
+		rules[cwe][finding.Rule] = true
+		apps[cwe][finding.Application] = true
+	}
+
+	for i := range findings {
+		finding := &findings[i]
+		if !IsSecurityFinding(finding) {
+			continue
+		}
+
+		mapped := make(map[int]bool)
+		for _, tag := range finding.Tags {
+			if id, ok := CweId(tag.Value); ok && !mapped[id] {
+				mapped[id] = true
+				add(CweLabel(id), id, finding)
+			}
+		}
+		if len(mapped) == 0 {
+			add(CWE_UNMAPPED, 0, finding)
+		}
+	}
+
+	result := make([]SecurityCwe, 0, len(groups))
+	for cwe, group := range groups {
+		group.Rules = sortedKeys(rules[cwe])
+		group.Applications = sortedKeys(apps[cwe])
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.Cwe == CWE_UNMAPPED) != (b.Cwe == CWE_UNMAPPED) {
+			return b.Cwe == CWE_UNMAPPED
+		}
+		if SeverityRank(a.Severity) != SeverityRank(b.Severity) {
+			return SeverityRank(a.Severity) > SeverityRank(b.Severity)
+		}
+		if a.Findings != b.Findings {
+			return a.Findings > b.Findings
+		}
+		return ids[a.Cwe] < ids[b.Cwe]
+	})
+
+	return result
+}
+
+//validateCweTags checks the cwe tags of the rule and its patterns are well formed
+func (r *Rule) validateCweTags() error {
+	for _, tag := range r.Tags {
+		if err := ValidateCweTag(tag.Value); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range r.Patterns {
+		if err := ValidateCweTag(pattern.Tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Severity communicates the risk of a finding. It is independent of effort which communicates the cost of remediating it.
+const SEVERITY_INFO = "info"
+const SEVERITY_LOW = "low"
+const SEVERITY_MEDIUM = "medium"
+const SEVERITY_HIGH = "high"
+const SEVERITY_CRITICAL = "critical"
+
+//Severities in ascending order of risk
+var Severities = []string{SEVERITY_INFO, SEVERITY_LOW, SEVERITY_MEDIUM, SEVERITY_HIGH, SEVERITY_CRITICAL}
+
+//SeverityRank orders severities by risk. Unknown/unset severities rank below info.
+func SeverityRank(severity string) int {
+	for i, s := range Severities {
+		if strings.EqualFold(s, severity) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+//ValidateSeverity accepts an unset severity or one of the known severities
+func ValidateSeverity(severity string) error {
+	if severity != "" && SeverityRank(severity) == 0 {
+		return fmt.Errorf("Severity must be (%s) but was: %s", strings.Join(Severities, "|"), severity)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var tagNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+//TaxonomyTag is a tag registered as part of the tag taxonomy. Rules may use tags that aren't registered, they are
+//flagged by `rules tags list` since they are likely typos.
+type TaxonomyTag struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	UpdatedAt   time.Time `json:"-" yaml:"-"`
+	Name        string    `gorm:"type:text;unique_index;not null" json:"name" yaml:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+//TagUsage is how much a tag is used by rules (rule and pattern tags) and findings
+type TagUsage struct {
+	Tag        string
+	Rules      int
+	Findings   int
+	Registered bool
+	Similar    []string
+}
+
+//ValidateTagName checks a tag name is lower case (finding tags are stored lower cased) without spaces
+func ValidateTagName(name string) error {
+	if !tagNameRegex.MatchString(name) {
+		return fmt.Errorf("tag [%s] must be lower case letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+//BuildTagUsage merges rule and finding tag counts (case-insensitively, like findings store them) with the taxonomy and
+//finds the tags similar to each other
+func BuildTagUsage(ruleTags map[string]int, findingTags map[string]int, taxonomy []TaxonomyTag) []TagUsage {
+
+	usage := make(map[string]*TagUsage)
+	get := func(tag string) *TagUsage {
+		tag = strings.ToLower(tag)
+		if _, found := usage[tag]; !found {
+			usage[tag] = &TagUsage{Tag: tag}
+		}
+		return usage[tag]
+	}
+
+	for tag, cnt := range ruleTags {
+		get(tag).Rules += cnt
+	}
+	for tag, cnt := range findingTags {
+		get(tag).Findings += cnt
+	}
+	for _, tag := range taxonomy {
+		get(tag.Name).Registered = true
+	}
+	//Tags of the cwe family are registered by being well formed
+	for tag, tagUsage := range usage {
+		if _, ok := CweId(tag); ok {
+			tagUsage.Registered = true
+		}
+	}
+
+	tags := make([]string, 0, len(usage))
+	for tag := range usage {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	result := make([]TagUsage, 0, len(tags))
+	for _, tag := range tags {
+		for _, other := range tags {
+			//cwe-78 and cwe-79 are distinct weaknesses, not typos
+			if other != tag && !(IsCweTag(tag) && IsCweTag(other)) && SimilarTags(tag, other) {
+				usage[tag].Similar = append(usage[tag].Similar, other)
+			}
+		}
+		result = append(result, *usage[tag])
+	}
+
+	return result
+}
+
+//SimilarTags is true when two tags only differ by separators (code-smell, code_smell) or, for longer tags, by a single
+//edit (a typo such as trasaction)
+func SimilarTags(a string, b string) bool {
+
+	separators := strings.NewReplacer("-", "", "_", "", ".", "", " ", "")
+	if separators.Replace(a) == separators.Replace(b) {
+		return true
+	}
+
+	if len(a) < 5 || len(b) < 5 {
+		return false
+	}
+
+	return editDistance(a, b) == 1
+}
+
+func editDistance(a string, b string) int {
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minOf(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minOf(values ...int) int {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
+
+//TagsFor returns the tags a finding of the rule matched by the pattern carries, the same way they are added during
+//analysis
+func (r *Rule) TagsFor(patternValue string) (tags []string) {
+
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		tag = strings.ToLower(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range r.Tags {
+		add(tag.Value)
+	}
+	for _, pattern := range r.Patterns {
+		if pattern.Value == patternValue {
+			add(pattern.Tag)
+		}
+	}
+
+	return tags
+}
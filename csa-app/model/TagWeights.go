@@ -0,0 +1,118 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//TagWeights scale the effort of the findings carrying some tags, so an organization can tune how much categories weigh
+//in the score without editing every rule's effort. I.E.
+//
+//  weights:
+//    jni: 3
+//    logging: 0.5
+type TagWeights struct {
+	Name    string             `json:"name" yaml:"name"`
+	Weights map[string]float64 `json:"weights" yaml:"weights"`
+	Digest  string             `json:"digest" yaml:"-"`
+}
+
+func LoadTagWeights(file string) (*TagWeights, error) {
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	//yaml is a superset of json
+	weights := &TagWeights{}
+	if err = yaml.UnmarshalStrict(data, weights); err != nil {
+		return nil, fmt.Errorf("tag weights file [%s] is invalid! Details: %v", file, err)
+	}
+
+	if weights.Name == "" {
+		weights.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	sum := sha256.Sum256(data)
+	weights.Digest = hex.EncodeToString(sum[:])
+
+	return weights, weights.Validate()
+}
+
+//Validate checks that no weight is negative and no tag is weighted twice. Tags are lower cased like those of findings.
+func (w *TagWeights) Validate() error {
+
+	if len(w.Weights) == 0 {
+		return fmt.Errorf("tag weights [%s] weigh no tags", w.Name)
+	}
+
+	lowered := make(map[string]float64, len(w.Weights))
+	for tag, weight := range w.Weights {
+		if weight < 0 {
+			return fmt.Errorf("tag weights [%s] weight of tag [%s] cannot be negative", w.Name, tag)
+		}
+		key := strings.ToLower(strings.TrimSpace(tag))
+		if _, found := lowered[key]; found {
+			return fmt.Errorf("tag weights [%s] weigh tag [%s] twice", w.Name, key)
+		}
+		lowered[key] = weight
+	}
+	w.Weights = lowered
+
+	return nil
+}
+
+//Weight is the product of the weights of the finding's tags, 1 when none of them is weighted
+func (w *TagWeights) Weight(finding *Finding) float64 {
+
+	weight := 1.0
+	if w == nil {
+		return weight
+	}
+
+	for _, tag := range finding.Tags {
+		if tagWeight, found := w.Weights[tag.Value]; found {
+			weight *= tagWeight
+		}
+	}
+
+	return weight
+}
+
+//Apply scales the finding's effort by the weight of its tags, rounding it, and notes the original effort. It returns
+//whether the effort changed.
+func (w *TagWeights) Apply(finding *Finding) bool {
+
+	weight := w.Weight(finding)
+	if weight == 1 || finding.Effort == 0 {
+		return false
+	}
+
+	effort := int(math.Round(float64(finding.Effort) * weight))
+	if effort == finding.Effort {
+		return false
+	}
+
+	note := fmt.Sprintf("Original effort [%d] of finding was weighted [%g] by tag weights [%s]", finding.Effort, weight, w.Name)
+	if finding.Note != "" {
+		note = finding.Note + ". " + note
+	}
+
+	finding.Note = note
+	finding.Effort = effort
+
+	return true
+}
@@ -0,0 +1,363 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"csa-app/util"
+)
+
+const TECH_FRAMEWORK = "framework"
+const TECH_SERVER = "server"
+const TECH_BUILD_TOOL = "build-tool"
+const TECH_RUNTIME = "runtime"
+
+//Files larger than this aren't read for tech stack detection. Build descriptors and deployment descriptors are small.
+const TECH_DETECTION_MAX_FILE_SIZE = 1024 * 1024
+
+//TechAttribute is a technology (framework, server, build tool or runtime) detected in an application, with the file
+//that gave it away
+type TechAttribute struct {
+	ID          uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	RunID       uint      `gorm:"index;not null" sql:"type:bigint REFERENCES runs(id) ON DELETE CASCADE" json:"runId" yaml:"runId"`
+	Application string    `gorm:"index;not null" json:"application" yaml:"application"`
+	Category    string    `gorm:"type:text;not null" json:"category" yaml:"category"`
+	Name        string    `gorm:"type:text;not null" json:"name" yaml:"name"`
+	Version     string    `gorm:"type:text" json:"version,omitempty" yaml:"version,omitempty"`
+	Evidence    string    `gorm:"type:text" json:"evidence" yaml:"evidence"`
+}
+
+//TechDetector detects a technology by the presence of a file (Files are globs matched against lower cased file names)
+//and, when Content is given, a match of that regex within the file. Version, a regex capturing the version in its
+//first group, is looked for in the files the technology was detected by, FileVersion in their names. Versions maps
+//the captured version to the technology's, when they differ (I.E. the servlet version of a web.xml to Java EE's).
+//When the files don't give the version away (I.E. it's inherited from a parent pom or BOM), that of the first maven
+//library of the application whose name (group/artifact) matches Library is used.
+//Findings tagged with one of Tags are attached the detected version.
+type TechDetector struct {
+	Name        string
+	Category    string
+	Files       []string
+	Content     *regexp.Regexp
+	Version     *regexp.Regexp
+	FileVersion *regexp.Regexp
+	Versions    map[string]string
+	Library     *regexp.Regexp
+	Tags        []string
+}
+
+var mavenDescriptors = []string{"pom.xml"}
+var gradleDescriptors = []string{"build.gradle", "build.gradle.kts"}
+var buildDescriptors = append(append([]string{}, mavenDescriptors...), gradleDescriptors...)
+var manifests = []string{"manifest.mf"}
+
+var javaEETags = []string{"javaee", "ejb", "jsf", "servlet", "mdb", "web-profile", "full-profile"}
+
+//Servlet spec version of a web.xml => Java/Jakarta EE version
+var servletJavaEEVersions = map[string]string{"2.4": "1.4", "2.5": "5", "3.0": "6", "3.1": "7", "4.0": "8", "5.0": "9", "6.0": "10"}
+
+var TechDetectors = []TechDetector{
+	//Build tools
+	{Name: "Maven", Category: TECH_BUILD_TOOL, Files: mavenDescriptors},
+	{Name: "Gradle", Category: TECH_BUILD_TOOL, Files: append([]string{"settings.gradle", "settings.gradle.kts", "gradlew"}, gradleDescriptors...)},
+	{Name: "Ant", Category: TECH_BUILD_TOOL, Files: []string{"build.xml"}, Content: regexp.MustCompile(`<project[\s>]`)},
+	{Name: "sbt", Category: TECH_BUILD_TOOL, Files: []string{"build.sbt"}},
+	{Name: "MSBuild", Category: TECH_BUILD_TOOL, Files: []string{"*.csproj", "*.vbproj", "*.sln"}},
+	{Name: "npm", Category: TECH_BUILD_TOOL, Files: []string{"package.json"}},
+	{Name: "pip", Category: TECH_BUILD_TOOL, Files: []string{"requirements.txt", "setup.py", "pyproject.toml"}},
+	{Name: "Go modules", Category: TECH_BUILD_TOOL, Files: []string{"go.mod"}},
+	{Name: "Composer", Category: TECH_BUILD_TOOL, Files: []string{"composer.json"}},
+	{Name: "Docker", Category: TECH_BUILD_TOOL, Files: []string{"dockerfile", "dockerfile.*", "*.dockerfile", "containerfile"}},
+	{Name: "Helm", Category: TECH_BUILD_TOOL, Files: []string{"chart.yaml"}, Content: regexp.MustCompile(`(?m)^apiVersion:\s*v[12]\s*$`)},
+	{Name: "Terraform", Category: TECH_BUILD_TOOL, Files: []string{"*.tf"}, Version: regexp.MustCompile(`required_version\s*=\s*"([^"]+)"`)},
+	{Name: "CloudFormation", Category: TECH_BUILD_TOOL, Files: []string{"*.yaml", "*.yml", "*.json", "*.template"}, Content: regexp.MustCompile(`AWSTemplateFormatVersion`)},
+
+	//Frameworks
+	{Name: "Spring Boot", Category: TECH_FRAMEWORK, Files: buildDescriptors, Content: regexp.MustCompile(`spring-boot`), Tags: []string{"spring-boot"},
+		Library: regexp.MustCompile(`^org\.springframework\.boot/spring-boot`),
+		Version: regexp.MustCompile(`(?s)<artifactId>spring-boot-starter-parent</artifactId>\s*<version>([^<]+)</version>|org\.springframework\.boot['"]?\)?\s*version\s*['"]([^'"]+)['"]`)},
+	{Name: "Spring", Category: TECH_FRAMEWORK, Files: append([]string{"applicationcontext*.xml", "*-servlet.xml"}, buildDescriptors...), Content: regexp.MustCompile(`org\.springframework|springframework\.org/schema`), Tags: []string{"spring"},
+		Library: regexp.MustCompile(`^org\.springframework/spring-(?:core|context|webmvc)$`),
+		Version: regexp.MustCompile(`(?s)<artifactId>spring-(?:core|context|webmvc)</artifactId>\s*<version>([^<]+)</version>|org\.springframework:spring-(?:core|context|webmvc):([^'":]+)['"]`)},
+	{Name: "Spring", Category: TECH_FRAMEWORK, Files: []string{"spring-core-*.jar", "spring-context-*.jar"}, Tags: []string{"spring"},
+		FileVersion: regexp.MustCompile(`(?i)^spring-(?:core|context)-([0-9].*)\.jar$`)},
+	{Name: "Spring", Category: TECH_FRAMEWORK, Files: manifests, Content: regexp.MustCompile(`(?m)^(?:Implementation-Title|Bundle-SymbolicName):\s*(?:spring-core|org\.springframework\.core)\s*$`), Tags: []string{"spring"},
+		Version: regexp.MustCompile(`(?m)^Implementation-Version:\s*(\S+)`)},
+	{Name: "Java EE", Category: TECH_FRAMEWORK, Files: buildDescriptors, Content: regexp.MustCompile(`(?:javaee|jakartaee)-(?:web-)?api`), Tags: javaEETags,
+		Library: regexp.MustCompile(`^(?:javax/javaee-(?:web-)?api|jakarta\.platform/jakarta\.jakartaee-(?:web-)?api)$`),
+		Version: regexp.MustCompile(`(?s)<artifactId>(?:javaee|jakarta\.jakartaee)-(?:web-)?api</artifactId>\s*<version>([^<]+)</version>|(?:javaee|jakartaee)-(?:web-)?api:([^'":]+)['"]`)},
+	{Name: "Java EE", Category: TECH_FRAMEWORK, Files: []string{"web.xml"}, Content: regexp.MustCompile(`<web-app[\s>]`), Tags: javaEETags,
+		Version: regexp.MustCompile(`(?s)<web-app[^>]*\sversion\s*=\s*["']([0-9.]+)["']`), Versions: servletJavaEEVersions},
+	{Name: "EJB", Category: TECH_FRAMEWORK, Files: []string{"ejb-jar.xml"}},
+	{Name: "EJB", Category: TECH_FRAMEWORK, Files: buildDescriptors, Content: regexp.MustCompile(`(?:javax|jakarta)\.ejb|ejb-api`)},
+	{Name: "Struts", Category: TECH_FRAMEWORK, Files: []string{"struts.xml", "struts-config.xml"}},
+	{Name: "Struts", Category: TECH_FRAMEWORK, Files: buildDescriptors, Content: regexp.MustCompile(`struts2?-core`), Tags: []string{"struts"},
+		Library: regexp.MustCompile(`^org\.apache\.struts/struts2?-core$`),
+		Version: regexp.MustCompile(`(?s)<artifactId>struts2?-core</artifactId>\s*<version>([^<]+)</version>|struts2?-core:([^'":]+)['"]`)},
+	{Name: "Struts", Category: TECH_FRAMEWORK, Files: []string{"struts-core-*.jar", "struts2-core-*.jar", "struts-*.jar"}, Tags: []string{"struts"},
+		FileVersion: regexp.MustCompile(`(?i)^struts2?(?:-core)?-([0-9].*)\.jar$`)},
+	{Name: "JSF", Category: TECH_FRAMEWORK, Files: []string{"faces-config.xml"}},
+	{Name: "Hibernate", Category: TECH_FRAMEWORK, Files: []string{"hibernate.cfg.xml"}},
+	{Name: "Hibernate", Category: TECH_FRAMEWORK, Files: buildDescriptors, Content: regexp.MustCompile(`hibernate-core`), Tags: []string{"hibernate"},
+		Library: regexp.MustCompile(`^org\.hibernate(?:\.orm)?/hibernate-core$`),
+		Version: regexp.MustCompile(`(?s)<artifactId>hibernate-core</artifactId>\s*<version>([^<]+)</version>|hibernate-core:([^'":]+)['"]`)},
+	{Name: "Hibernate", Category: TECH_FRAMEWORK, Files: []string{"hibernate-core-*.jar", "hibernate3.jar"}, Tags: []string{"hibernate"},
+		FileVersion: regexp.MustCompile(`(?i)^hibernate(?:-core-([0-9].*)|([0-9]))\.jar$`)},
+	{Name: "Hibernate", Category: TECH_FRAMEWORK, Files: manifests, Content: regexp.MustCompile(`(?m)^(?:Implementation-Title|Bundle-SymbolicName):\s*(?:hibernate-core|org\.hibernate\.core)\s*$`), Tags: []string{"hibernate"},
+		Version: regexp.MustCompile(`(?m)^Implementation-Version:\s*(\S+)`)},
+	{Name: "Rails", Category: TECH_FRAMEWORK, Files: []string{"gemfile.lock"}, Content: regexp.MustCompile(`(?m)^    rails \(`), Tags: []string{"rails"},
+		Version: regexp.MustCompile(`(?m)^    rails \(([^)]+)\)`)},
+	{Name: "Laravel", Category: TECH_FRAMEWORK, Files: []string{"composer.lock"}, Content: regexp.MustCompile(`"name":\s*"laravel/framework"`), Tags: []string{"laravel"},
+		Version: regexp.MustCompile(`"name":\s*"laravel/framework",\s*"version":\s*"v?([^"]+)"`)},
+	{Name: "Laravel", Category: TECH_FRAMEWORK, Files: []string{"composer.json"}, Content: regexp.MustCompile(`"laravel/framework"\s*:`), Tags: []string{"laravel"},
+		Version: regexp.MustCompile(`"laravel/framework"\s*:\s*"([^"]+)"`)},
+	{Name: "Symfony", Category: TECH_FRAMEWORK, Files: []string{"composer.lock"}, Content: regexp.MustCompile(`"name":\s*"symfony/(?:symfony|framework-bundle)"`), Tags: []string{"symfony"},
+		Version: regexp.MustCompile(`"name":\s*"symfony/(?:symfony|framework-bundle)",\s*"version":\s*"v?([^"]+)"`)},
+	{Name: "Symfony", Category: TECH_FRAMEWORK, Files: []string{"composer.json"}, Content: regexp.MustCompile(`"symfony/(?:symfony|framework-bundle)"\s*:`), Tags: []string{"symfony"},
+		Version: regexp.MustCompile(`"symfony/(?:symfony|framework-bundle)"\s*:\s*"([^"]+)"`)},
+	{Name: "ASP.NET", Category: TECH_FRAMEWORK, Files: []string{"web.config", "*.aspx", "*.csproj"}, Content: regexp.MustCompile(`(?i)system\.web|microsoft\.aspnetcore|<%@\s*page`)},
+
+	//Servers
+	{Name: "Tomcat", Category: TECH_SERVER, Files: []string{"context.xml"}, Content: regexp.MustCompile(`<Context[\s>]`)},
+	{Name: "Tomcat", Category: TECH_SERVER, Files: []string{"server.xml"}, Content: regexp.MustCompile(`Catalina`)},
+	{Name: "WebSphere", Category: TECH_SERVER, Files: []string{"ibm-web-bnd.*", "ibm-web-ext.*", "ibm-application-bnd.*", "ibm-application-ext.*", "ibm-ejb-jar-bnd.*"}, Tags: []string{"websphere"}},
+	{Name: "WebSphere Liberty", Category: TECH_SERVER, Files: []string{"server.xml"}, Content: regexp.MustCompile(`<featureManager>`)},
+	{Name: "WebLogic", Category: TECH_SERVER, Files: []string{"weblogic.xml", "weblogic-application.xml", "weblogic-ejb-jar.xml"}, Tags: []string{"weblogic"}},
+	{Name: "JBoss/WildFly", Category: TECH_SERVER, Files: []string{"jboss-web.xml", "jboss-app.xml", "jboss-deployment-structure.xml", "jboss-ejb3.xml"}},
+	{Name: "Jetty", Category: TECH_SERVER, Files: []string{"jetty.xml", "jetty-web.xml"}},
+	{Name: "CICS", Category: TECH_SERVER, Files: []string{"*.cbl", "*.cob", "*.cpy"}, Content: regexp.MustCompile(`(?i)\bEXEC\s+CICS\b`), Tags: []string{"cics"}},
+	{Name: "IIS", Category: TECH_SERVER, Files: []string{"web.config"}, Content: regexp.MustCompile(`<system\.webServer>`)},
+
+	//Runtimes
+	{Name: "Java", Category: TECH_RUNTIME, Files: mavenDescriptors, Content: regexp.MustCompile(`<(?:java\.version|maven\.compiler\.(?:source|target|release))>`), Tags: []string{"java"},
+		Version: regexp.MustCompile(`<(?:java\.version|maven\.compiler\.(?:release|source|target))>\s*([^<\s]+)\s*<`)},
+	{Name: "Java", Category: TECH_RUNTIME, Files: gradleDescriptors, Content: regexp.MustCompile(`sourceCompatibility|languageVersion`), Tags: []string{"java"},
+		Version: regexp.MustCompile(`(?:sourceCompatibility\s*=\s*['"]?(?:JavaVersion\.VERSION_)?|JavaLanguageVersion\.of\()([0-9._]+)`)},
+	{Name: "Java", Category: TECH_RUNTIME, Files: manifests, Content: regexp.MustCompile(`(?m)^Build-Jdk(?:-Spec)?:`), Tags: []string{"java"},
+		Version: regexp.MustCompile(`(?m)^Build-Jdk(?:-Spec)?:\s*(\S+)`)},
+	{Name: "Java", Category: TECH_RUNTIME, Files: []string{"*.java", "*.jsp", "*.class", "*.jar"}},
+	{Name: "Kotlin", Category: TECH_RUNTIME, Files: gradleDescriptors, Content: regexp.MustCompile(`org\.jetbrains\.kotlin|kotlin\(\s*"jvm"\s*\)`), Tags: []string{"kotlin"},
+		Version: regexp.MustCompile(`(?:org\.jetbrains\.kotlin(?:\.jvm|\.plugin\.spring)?['"]?\)?|kotlin\(\s*"jvm"\s*\))\s*version\s*['"]([^'"]+)['"]`)},
+	{Name: "Kotlin", Category: TECH_RUNTIME, Files: mavenDescriptors, Content: regexp.MustCompile(`<kotlin\.version>`), Tags: []string{"kotlin"},
+		Version: regexp.MustCompile(`<kotlin\.version>\s*([^<\s]+)\s*<`)},
+	{Name: "Kotlin", Category: TECH_RUNTIME, Files: []string{"*.kt"}},
+	{Name: "Scala", Category: TECH_RUNTIME, Files: []string{"build.sbt"}, Content: regexp.MustCompile(`scalaVersion`), Tags: []string{"scala"},
+		Version: regexp.MustCompile(`scalaVersion\s*:=\s*"([^"]+)"`)},
+	{Name: "Scala", Category: TECH_RUNTIME, Files: []string{"*.scala"}},
+	{Name: ".NET", Category: TECH_RUNTIME, Files: []string{"*.csproj", "*.vbproj"},
+		Version: regexp.MustCompile(`<TargetFrameworks?(?:Version)?>([^<]+)<`)},
+	{Name: ".NET", Category: TECH_RUNTIME, Files: []string{"*.cs", "*.vb"}},
+	{Name: "Node.js", Category: TECH_RUNTIME, Files: []string{"package.json"}, Version: regexp.MustCompile(`"node"\s*:\s*"([^"]+)"`)},
+	{Name: "Python", Category: TECH_RUNTIME, Files: []string{".python-version", "runtime.txt"}, Version: regexp.MustCompile(`([0-9]+\.[0-9]+(?:\.[0-9]+)?)`)},
+	{Name: "Python", Category: TECH_RUNTIME, Files: []string{"*.py"}},
+	{Name: "COBOL", Category: TECH_RUNTIME, Files: []string{"*.cbl", "*.cob"}},
+	{Name: "Ruby", Category: TECH_RUNTIME, Files: []string{".ruby-version"}, Version: regexp.MustCompile(`([0-9]+\.[0-9]+(?:\.[0-9]+)?)`)},
+	{Name: "Ruby", Category: TECH_RUNTIME, Files: []string{"*.rb"}},
+	{Name: "PHP", Category: TECH_RUNTIME, Files: []string{"composer.json"}, Content: regexp.MustCompile(`"php"\s*:`), Version: regexp.MustCompile(`"php"\s*:\s*"([^"]+)"`)},
+	{Name: "PHP", Category: TECH_RUNTIME, Files: []string{"*.php"}},
+	{Name: "Go", Category: TECH_RUNTIME, Files: []string{"go.mod"}, Version: regexp.MustCompile(`(?m)^go\s+([0-9.]+)`)},
+}
+
+//DetectTechStack runs the detectors over the application's files. Third-party (vendored) files are skipped, they tell
+//what the vendored code was built with rather than the application.
+func DetectTechStack(runId uint, app *Application, detectors []TechDetector) []*TechAttribute {
+
+	found := make(map[string]*TechAttribute)
+	contents := make(map[string]string)
+
+	for _, detector := range detectors {
+		key := detector.Category + "/" + detector.Name
+		attribute := found[key]
+		//Keep looking while the version is still unknown
+		if attribute != nil && (attribute.Version != "" || !detector.findsVersion()) {
+			continue
+		}
+
+		for _, file := range app.Files {
+			if file.ThirdParty != "" || !detector.matchesFile(file.Name) {
+				continue
+			}
+
+			content := ""
+			if detector.Content != nil || detector.Version != nil {
+				content = readDetectionFile(file.FQN, contents)
+				if detector.Content != nil && !detector.Content.MatchString(content) {
+					continue
+				}
+			}
+
+			if attribute == nil {
+				attribute = &TechAttribute{RunID: runId, Application: app.Name, Category: detector.Category, Name: detector.Name,
+					Evidence: relativeEvidence(app, file)}
+				found[key] = attribute
+			}
+
+			if attribute.Version == "" && detector.findsVersion() {
+				attribute.Version = detector.version(file.Name, content)
+			}
+
+			if attribute.Version != "" || !detector.findsVersion() {
+				break
+			}
+		}
+	}
+
+	for _, detector := range detectors {
+		attribute := found[detector.Category+"/"+detector.Name]
+		if attribute == nil || attribute.Version != "" || detector.Library == nil {
+			continue
+		}
+		for _, library := range app.Libraries {
+			if library.Ecosystem == ECOSYSTEM_MAVEN && library.Version != "" && detector.Library.MatchString(library.Name) {
+				attribute.Version = library.Version
+				break
+			}
+		}
+	}
+
+	attributes := make([]*TechAttribute, 0, len(found))
+	for _, attribute := range found {
+		attributes = append(attributes, attribute)
+	}
+	sort.Sort(TechAttributesByApp(attributes))
+
+	return attributes
+}
+
+func (d *TechDetector) matchesFile(name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range d.Files {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *TechDetector) findsVersion() bool {
+	return d.Version != nil || d.FileVersion != nil
+}
+
+//version returns the first version found in the file's name or content, with property placeholders (${...}) resolved
+//from the content. Versions held by a property defined elsewhere (I.E. a parent pom) remain unknown.
+func (d *TechDetector) version(name string, content string) string {
+	var matches [][]string
+	if d.FileVersion != nil {
+		matches = append(matches, d.FileVersion.FindAllStringSubmatch(name, -1)...)
+	}
+	if d.Version != nil {
+		matches = append(matches, d.Version.FindAllStringSubmatch(content, -1)...)
+	}
+
+	for _, match := range matches {
+		for _, group := range match[1:] {
+			version := resolveVersionProperty(strings.TrimSpace(group), content)
+			if version == "" {
+				continue
+			}
+			version = strings.ReplaceAll(version, "_", ".")
+			if mapped, found := d.Versions[version]; found {
+				return mapped
+			}
+			if d.Versions == nil {
+				return version
+			}
+		}
+	}
+	return ""
+}
+
+var versionPropertyRegex = regexp.MustCompile(`^\$\{([^}]+)\}$`)
+
+//resolveVersionProperty resolves a ${name} version from a maven <name> property or a gradle name = 'value' assignment of
+//the content. Unresolved placeholders resolve to empty.
+func resolveVersionProperty(version string, content string) string {
+	match := versionPropertyRegex.FindStringSubmatch(version)
+	if match == nil {
+		if strings.Contains(version, "$") {
+			return ""
+		}
+		return version
+	}
+
+	property := regexp.QuoteMeta(match[1])
+	for _, definition := range []*regexp.Regexp{
+		regexp.MustCompile(`<` + property + `>\s*([^<$\s]+)\s*</` + property + `>`),
+		regexp.MustCompile(`(?m)^\s*(?:ext\.|def\s+|val\s+)?` + property + `\s*=\s*['"]?([^'"$\s]+)['"]?`),
+	} {
+		if found := definition.FindStringSubmatch(content); found != nil {
+			return found[1]
+		}
+	}
+	return ""
+}
+
+//TechVersionsByTag maps the finding tags of the detectors to the version of the technology they detected, I.E.
+//spring => Spring 4.3.9.RELEASE. The first detector listing a tag wins.
+func TechVersionsByTag(attributes []*TechAttribute, detectors []TechDetector) map[string]string {
+
+	versions := make(map[string]string)
+	for _, detector := range detectors {
+		for _, attribute := range attributes {
+			if attribute.Version == "" || attribute.Category != detector.Category || attribute.Name != detector.Name {
+				continue
+			}
+			for _, tag := range detector.Tags {
+				if _, found := versions[tag]; !found {
+					versions[tag] = attribute.Name + " " + attribute.Version
+				}
+			}
+		}
+	}
+	return versions
+}
+
+//readDetectionFile reads (once) a file a detector looks into. Unreadable and large files read as empty.
+func readDetectionFile(fqn string, contents map[string]string) string {
+	if content, found := contents[fqn]; found {
+		return content
+	}
+
+	content := ""
+	if info, err := os.Stat(fqn); err == nil && info.Size() <= TECH_DETECTION_MAX_FILE_SIZE {
+		if data, err := ioutil.ReadFile(fqn); err == nil {
+			content = string(data)
+		}
+	}
+
+	contents[fqn] = content
+	return content
+}
+
+func relativeEvidence(app *Application, file *util.FileInfo) string {
+	if rel, err := filepath.Rel(app.Path, file.FQN); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return file.FQN
+}
+
+//TechAttributesByApp orders attributes by application, category and name
+type TechAttributesByApp []*TechAttribute
+
+func (a TechAttributesByApp) Len() int      { return len(a) }
+func (a TechAttributesByApp) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a TechAttributesByApp) Less(i, j int) bool {
+	if a[i].Application != a[j].Application {
+		return a[i].Application < a[j].Application
+	}
+	if a[i].Category != a[j].Category {
+		return a[i].Category < a[j].Category
+	}
+	return a[i].Name < a[j].Name
+}
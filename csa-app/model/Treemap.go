@@ -0,0 +1,101 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//FileStats are the per file totals a treemap is built from
+type FileStats struct {
+	Fqn      string
+	Sloc     int
+	Findings int
+	Effort   int
+}
+
+//TreemapNode is a directory (with children) or file (without) of an application. Nodes are sized by sloc and can be
+//colored by density (findings per 1000 lines of code) or effort. Directories hold the totals of everything beneath them.
+type TreemapNode struct {
+	Name     string         `json:"name"`
+	Path     string         `json:"path"`
+	Sloc     int            `json:"sloc"`
+	Findings int            `json:"findings"`
+	Effort   int            `json:"effort"`
+	Density  float64        `json:"density"`
+	Score    *float64       `json:"score,omitempty"`
+	Children []*TreemapNode `json:"children,omitempty"`
+	index    map[string]*TreemapNode
+}
+
+//BuildTreemap arranges the application's files beneath root into their directory hierarchy
+func BuildTreemap(app *Application, files []FileStats) *TreemapNode {
+
+	root := &TreemapNode{Name: app.Name, Path: "", Score: &app.Score}
+
+	for _, file := range files {
+		rel, err := filepath.Rel(app.Path, file.Fqn)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			rel = file.Fqn
+		}
+
+		node := root
+		for _, name := range strings.Split(filepath.ToSlash(rel), "/") {
+			if name != "" && name != "." {
+				node = node.child(name)
+			}
+		}
+
+		node.Sloc += file.Sloc
+		node.Findings += file.Findings
+		node.Effort += file.Effort
+	}
+
+	root.rollup()
+
+	return root
+}
+
+func (node *TreemapNode) child(name string) *TreemapNode {
+
+	if node.index == nil {
+		node.index = make(map[string]*TreemapNode)
+	}
+
+	child, found := node.index[name]
+	if !found {
+		child = &TreemapNode{Name: name, Path: strings.TrimPrefix(node.Path+"/"+name, "/")}
+		node.index[name] = child
+		node.Children = append(node.Children, child)
+	}
+
+	return child
+}
+
+func (node *TreemapNode) rollup() {
+
+	for _, child := range node.Children {
+		child.rollup()
+		node.Sloc += child.Sloc
+		node.Findings += child.Findings
+		node.Effort += child.Effort
+	}
+
+	if node.Sloc > 0 {
+		node.Density = math.Round(float64(node.Findings)*100000/float64(node.Sloc)) / 100
+	}
+
+	//Largest first, which is how treemaps are laid out
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		if node.Children[i].Sloc != node.Children[j].Sloc {
+			return node.Children[i].Sloc > node.Children[j].Sloc
+		}
+		return node.Children[i].Name < node.Children[j].Name
+	})
+}
@@ -0,0 +1,86 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const TRIAGE_SUPPRESSED = "suppressed"
+const TRIAGE_FALSE_POSITIVE = "false-positive"
+const TRIAGE_ACCEPTED = "accepted"
+
+//Clears the triage state of a finding
+const TRIAGE_NONE = "none"
+
+var TriageStates = []string{TRIAGE_SUPPRESSED, TRIAGE_FALSE_POSITIVE, TRIAGE_ACCEPTED}
+
+//Triage states whose findings are left out of scores and reports. Accepted findings are acknowledged, but still count.
+var ExcludedTriageStates = []string{TRIAGE_SUPPRESSED, TRIAGE_FALSE_POSITIVE}
+
+//FindingTriage is the triage decision taken on a finding
+type FindingTriage struct {
+	State     string     `json:"state"`
+	Reason    string     `json:"reason,omitempty"`
+	TriagedAt *time.Time `json:"triagedAt,omitempty"`
+}
+
+//ValidateTriage checks the state is one of the triage states, or none to clear it
+func ValidateTriage(state string) error {
+	if state == TRIAGE_NONE {
+		return nil
+	}
+	for _, triage := range TriageStates {
+		if state == triage {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown triage state [%s]! Must be one of (%s|%s)", state, strings.Join(TriageStates, "|"), TRIAGE_NONE)
+}
+
+//IsTriageExcluded returns whether findings in the triage state are left out of scores and reports
+func IsTriageExcluded(state string) bool {
+	for _, excluded := range ExcludedTriageStates {
+		if state == excluded {
+			return true
+		}
+	}
+	return false
+}
+
+//ExcludeTriaged drops the findings left out of scores and reports by their triage state
+func ExcludeTriaged(findings []Finding) []Finding {
+	kept := findings[:0]
+	for _, finding := range findings {
+		if !IsTriageExcluded(finding.Triage) {
+			kept = append(kept, finding)
+		}
+	}
+	return kept
+}
+
+//CarryForwardTriage returns the triage of the previous findings matched by current ones (current finding id =>
+//triage), so rescans keep the decisions taken on findings that are still there
+func CarryForwardTriage(previous []Finding, matched map[uint]uint) map[uint]FindingTriage {
+
+	triaged := make(map[uint]FindingTriage)
+	for _, finding := range previous {
+		if finding.Triage != "" {
+			triaged[finding.ID] = FindingTriage{State: finding.Triage, Reason: finding.TriageNote, TriagedAt: finding.TriagedAt}
+		}
+	}
+
+	carried := make(map[uint]FindingTriage)
+	for id, previousId := range matched {
+		if triage, found := triaged[previousId]; found {
+			carried[id] = triage
+		}
+	}
+
+	return carried
+}
@@ -0,0 +1,147 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+const FACTOR_PASS = "pass"
+const FACTOR_FAIL = "fail"
+const FACTOR_UNKNOWN = "unknown"
+
+//TwelveFactor is one of the twelve factors (https://12factor.net) an application is evaluated against. Findings
+//tagged with one of Violations fail the factor, findings tagged with one of Evidence (positive findings) pass it.
+//Without either the factor is unknown, unless the rules cover its violations well enough for their absence to
+//pass it (PassWhenClean).
+type TwelveFactor struct {
+	Number        int      `json:"number"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Violations    []string `json:"violations"`
+	Evidence      []string `json:"evidence"`
+	PassWhenClean bool     `json:"passWhenClean"`
+}
+
+//FactorResult is the evaluation of a factor for an application. Its score is the share of the factor's findings that
+//are evidence, scaled to 0-10, and is only known when the status is.
+type FactorResult struct {
+	Number     int      `json:"number"`
+	Factor     string   `json:"factor"`
+	Status     string   `json:"status"`
+	Score      float64  `json:"score"`
+	Violations int      `json:"violations"`
+	Evidence   int      `json:"evidence"`
+	Effort     int      `json:"effort"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+//TwelveFactorReport is an application's twelve factor checklist. Its score averages the scores of the known factors.
+type TwelveFactorReport struct {
+	Application string         `json:"application"`
+	Passed      int            `json:"passed"`
+	Failed      int            `json:"failed"`
+	Unknown     int            `json:"unknown"`
+	Score       float64        `json:"score"`
+	Factors     []FactorResult `json:"factors"`
+}
+
+var TwelveFactors = []TwelveFactor{
+	{Number: 1, Name: "Codebase", Description: "One codebase tracked in revision control, many deploys"},
+	{Number: 2, Name: "Dependencies", Description: "Explicitly declare and isolate dependencies",
+		Violations: []string{"jni", "native", "loadlibrary", "dl"},
+		Evidence:   []string{"maven", "gradle", "pom", "build-system"}},
+	{Number: 3, Name: "Config", Description: "Store config in the environment",
+		Violations: []string{"env-config", "hard-ip", "hardcoded-uri", "sonnection-string", "web-config", "windows-registry"},
+		Evidence:   []string{"externalized-config"}, PassWhenClean: true},
+	{Number: 4, Name: "Backing services", Description: "Treat backing services as attached resources",
+		Violations: []string{"jndi", "jca", "resource-adapter", "msmq", "sqlite", "corba"},
+		Evidence:   []string{"attached-resource"}, PassWhenClean: true},
+	{Number: 5, Name: "Build, release, run", Description: "Strictly separate build and run stages",
+		Violations: []string{"sudo"},
+		Evidence:   []string{"docker"}},
+	{Number: 6, Name: "Processes", Description: "Execute the app as one or more stateless processes",
+		Violations: []string{"stateful", "session", "non-dist-cache", "filesystem", "file", "writefile"},
+		Evidence:   []string{"stateless", "dist-cache", "redis"}, PassWhenClean: true},
+	{Number: 7, Name: "Port binding", Description: "Export services via port binding",
+		Violations: []string{"app-server", "ear", "weblogic", "websphere", "jboss", "glassfish", "isapi-filter"},
+		Evidence:   []string{"port-binding", "spring-boot", "springbootapplication"}},
+	{Number: 8, Name: "Concurrency", Description: "Scale out via the process model",
+		Violations: []string{"threading", "process-launch", "windows-service"}},
+	{Number: 9, Name: "Disposability", Description: "Maximize robustness with fast startup and graceful shutdown",
+		Violations: []string{"process-exit", "distributed-transaction", "jta"},
+		Evidence:   []string{"term", "graceful-shutdown"}},
+	{Number: 10, Name: "Dev/prod parity", Description: "Keep development, staging, and production as similar as possible",
+		Violations: []string{"sqlite", "windows-desktop", "windows-forms"},
+		Evidence:   []string{"docker"}},
+	{Number: 11, Name: "Logs", Description: "Treat logs as event streams",
+		Violations: []string{"log2file", "fileappender", "eventlog"},
+		Evidence:   []string{"log2stdout"}, PassWhenClean: true},
+	{Number: 12, Name: "Admin processes", Description: "Run admin/management tasks as one-off processes",
+		Violations: []string{"batch", "scheduler", "alarm-d"}},
+}
+
+//EvaluateTwelveFactors evaluates an application against the factors from the totals of its findings by tag
+func EvaluateTwelveFactors(app string, tagTotals TagTotals, factors []TwelveFactor) TwelveFactorReport {
+
+	//Rule tags aren't consistently cased
+	totals := make(TagTotals)
+	for tag, total := range tagTotals {
+		tag = strings.ToLower(tag)
+		totals[tag] = TagTotal{Findings: totals[tag].Findings + total.Findings, Effort: totals[tag].Effort + total.Effort}
+	}
+
+	report := TwelveFactorReport{Application: app}
+	scored := 0
+
+	for _, factor := range factors {
+		result := FactorResult{Number: factor.Number, Factor: factor.Name, Status: FACTOR_UNKNOWN}
+
+		for _, tag := range factor.Violations {
+			if total, found := totals[tag]; found && total.Findings > 0 {
+				result.Violations += total.Findings
+				result.Effort += total.Effort
+				result.Tags = append(result.Tags, tag)
+			}
+		}
+		for _, tag := range factor.Evidence {
+			if total, found := totals[tag]; found && total.Findings > 0 {
+				result.Evidence += total.Findings
+				result.Tags = append(result.Tags, tag)
+			}
+		}
+		sort.Strings(result.Tags)
+
+		switch {
+		case result.Violations > 0:
+			result.Status = FACTOR_FAIL
+			report.Failed++
+		case result.Evidence > 0 || factor.PassWhenClean:
+			result.Status = FACTOR_PASS
+			report.Passed++
+		default:
+			report.Unknown++
+		}
+
+		if result.Status != FACTOR_UNKNOWN {
+			result.Score = DEFAULT_MAX_SCORE
+			if findings := result.Violations + result.Evidence; findings > 0 {
+				result.Score = DEFAULT_MAX_SCORE * float64(result.Evidence) / float64(findings)
+			}
+			report.Score += result.Score
+			scored++
+		}
+
+		report.Factors = append(report.Factors, result)
+	}
+
+	if scored > 0 {
+		report.Score = report.Score / float64(scored)
+	}
+
+	return report
+}
@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+//WhatIfTotals are the totals of an application's scored findings a what-if resolves
+type WhatIfTotals struct {
+	Findings    int
+	RawScore    int
+	CIFindings  int
+	NumCrits    int
+	CriticalCnt int
+	HighCnt     int
+	Tags        TagTotals
+}
+
+//WhatIfScore is an application's score before and after resolving the findings of a what-if
+type WhatIfScore struct {
+	Application       string  `json:"application"`
+	Resolved          int     `json:"resolvedFindings"`
+	ResolvedEffort    int     `json:"resolvedEffort"`
+	RawScore          int     `json:"rawScore"`
+	NewRawScore       int     `json:"newRawScore"`
+	Score             float64 `json:"score"`
+	NewScore          float64 `json:"newScore"`
+	Tier              string  `json:"tier,omitempty"`
+	NewTier           string  `json:"newTier,omitempty"`
+	Recommendation    string  `json:"recommendation"`
+	NewRecommendation string  `json:"newRecommendation"`
+}
+
+//ParseWhatIf parses the finding queries of a what-if (see ParseCriteria). The findings matching any of them are
+//resolved.
+func ParseWhatIf(queries []string) ([]*Criteria, error) {
+
+	var whatIf []*Criteria
+	for _, query := range queries {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		criteria, err := ParseCriteria(query)
+		if err != nil {
+			return nil, err
+		}
+		whatIf = append(whatIf, criteria)
+	}
+
+	if len(whatIf) == 0 {
+		return nil, fmt.Errorf("a what-if needs at least one query of the findings to resolve. I.E. tag=jms")
+	}
+
+	return whatIf, nil
+}
+
+//Resolve removes the resolved findings from the application's totals, as if they were fixed. Scoring the application
+//afterwards gives its what-if score.
+func (app *Application) Resolve(totals *WhatIfTotals) {
+
+	if totals == nil {
+		return
+	}
+
+	app.Findings = nonNegative(app.Findings - totals.Findings)
+	app.RawScore = nonNegative(app.RawScore - totals.RawScore)
+	app.CIFindings = nonNegative(app.CIFindings - totals.CIFindings)
+	app.NumCrits = nonNegative(app.NumCrits - totals.NumCrits)
+	app.CriticalCnt = nonNegative(app.CriticalCnt - totals.CriticalCnt)
+	app.HighCnt = nonNegative(app.HighCnt - totals.HighCnt)
+
+	for tag, resolved := range totals.Tags {
+		if total, found := app.TagTotals[tag]; found {
+			total.Findings = nonNegative(total.Findings - resolved.Findings)
+			total.Effort = nonNegative(total.Effort - resolved.Effort)
+			app.TagTotals[tag] = total
+		}
+	}
+}
+
+func nonNegative(value int) int {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
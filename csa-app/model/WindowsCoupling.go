@@ -0,0 +1,75 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model
+
+//WindowsDependency is a dependency of an application on Windows (registry, services, DCOM, drive letters, MSMQ...),
+//found by the tags of its findings in Java and .NET code, configuration and scripts, with what it is usually replaced
+//by in a Linux container. Blockers keep the application from running in a Linux container at all.
+type WindowsDependency struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Replacement string   `json:"replacement"`
+	Tags        []string `json:"tags"`
+	Blocker     bool     `json:"blocker"`
+}
+
+//WindowsDependencyResult is a dependency found in an application
+type WindowsDependencyResult struct {
+	Dependency  string   `json:"dependency"`
+	Replacement string   `json:"replacement"`
+	Findings    int      `json:"findings"`
+	Effort      int      `json:"effort"`
+	Blocker     bool     `json:"blocker"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+//WindowsCoupling is what moving an application from Windows to Linux containers takes: its dependencies on Windows
+//and the findings and effort of each. Blocked is true when one of them is a blocker.
+type WindowsCoupling struct {
+	Application  string                    `json:"application"`
+	Blocked      bool                      `json:"blocked"`
+	Findings     int                       `json:"findings"`
+	Effort       int                       `json:"effort"`
+	Dependencies []WindowsDependencyResult `json:"dependencies"`
+}
+
+var WindowsDependencies = []WindowsDependency{
+	{Name: "registry", Description: "Windows registry", Replacement: "Configuration from the environment or a configuration server",
+		Tags: []string{"windows-registry"}, Blocker: true},
+	{Name: "windows-services", Description: "Windows services, service wrappers and service control", Replacement: "A console process the platform starts and restarts",
+		Tags: []string{"windows-service"}},
+	{Name: "dcom", Description: "COM, DCOM and COM+ components", Replacement: "An HTTP or gRPC API in front of the component, or a rewrite",
+		Tags: []string{"dcom", "com+"}, Blocker: true},
+	{Name: "msmq", Description: "MSMQ queues", Replacement: "A message broker (RabbitMQ, a managed queue service)",
+		Tags: []string{"msmq"}, Blocker: true},
+	{Name: "paths", Description: "Drive letter and UNC paths, Windows folders and environment variables", Replacement: "Paths from configuration and mounted volumes",
+		Tags: []string{"windows-path"}},
+	{Name: "native", Description: "Win32 APIs (P/Invoke, JNA), WMI and Windows programs", Replacement: "Portable APIs and libraries",
+		Tags: []string{"windows-interop", "wmi", "windows-process"}, Blocker: true},
+	{Name: "event-log", Description: "Windows event log", Replacement: "Logging to the console",
+		Tags: []string{"eventlog"}},
+	{Name: "windows-auth", Description: "Windows authentication and principals", Replacement: "OpenID Connect, or Kerberos through Negotiate authentication",
+		Tags: []string{"windows-auth", "windows-principal"}},
+}
+
+//EvaluateWindowsCoupling finds the dependencies of the application on Windows from the totals of its findings by tag
+func EvaluateWindowsCoupling(app string, tagTotals TagTotals, dependencies []WindowsDependency) WindowsCoupling {
+
+	totals := tagTotals.LowerCased()
+	coupling := WindowsCoupling{Application: app, Dependencies: []WindowsDependencyResult{}}
+
+	for _, dependency := range dependencies {
+		if total, tags := totals.Group(dependency.Tags); total.Findings > 0 {
+			coupling.Findings += total.Findings
+			coupling.Effort += total.Effort
+			coupling.Dependencies = append(coupling.Dependencies, WindowsDependencyResult{Dependency: dependency.Name, Replacement: dependency.Replacement,
+				Findings: total.Findings, Effort: total.Effort, Blocker: dependency.Blocker, Tags: tags})
+			coupling.Blocked = coupling.Blocked || dependency.Blocker
+		}
+	}
+
+	return coupling
+}
@@ -0,0 +1,80 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func adviceRules() []model.Rule {
+	return []model.Rule{
+		{Name: "java-jni", Advice: "Bundle native libraries"},
+		{Name: "java-system-config", Patterns: []model.Pattern{
+			{Value: "System.getProperty", Advice: "Use environment variables"},
+			{Value: "System.getenv"},
+		}},
+		{Name: "java-rmi"},
+	}
+}
+
+func TestAdviceCatalogLocalize(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "csa-i18n")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	source := model.ExtractAdvice(adviceRules())
+	assert.Equal(t, 2, len(source.Rules))
+
+	catalog := model.NewAdviceCatalog("pt")
+	added, err := catalog.Contribute(source, &model.AdviceCatalog{Rules: map[string]*model.RuleAdvice{
+		"java-system-config": {Patterns: map[string]string{"System.getProperty": "Use variaveis de ambiente"}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.NoError(t, catalog.Save(dir))
+
+	//Regions fall back to their language
+	loaded, err := model.LoadAdviceCatalog(dir, "pt-BR")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pt"}, model.AvailableLocales(dir))
+
+	rules := adviceRules()
+	assert.Equal(t, 1, loaded.Localize(rules))
+	assert.Equal(t, "Bundle native libraries", rules[0].Advice)
+	assert.Equal(t, "Use variaveis de ambiente", rules[1].Patterns[0].Advice)
+	assert.Equal(t, "", rules[1].Patterns[1].Advice)
+
+	coverage := loaded.Coverage(source)
+	assert.Equal(t, 1, coverage.Translated)
+	assert.Equal(t, 2, coverage.Total)
+	assert.Equal(t, 50.0, coverage.Percent)
+
+	_, err = model.LoadAdviceCatalog(dir, "de")
+	assert.Error(t, err)
+	_, err = model.LoadAdviceCatalog(dir, "../secrets")
+	assert.Error(t, err)
+}
+
+func TestAdviceCatalogContributeRejectsUnknownAdvice(t *testing.T) {
+
+	source := model.ExtractAdvice(adviceRules())
+	catalog := model.NewAdviceCatalog("de")
+
+	_, err := catalog.Contribute(source, &model.AdviceCatalog{Rules: map[string]*model.RuleAdvice{"java-rmi": {Advice: "RMI"}}})
+	assert.Error(t, err)
+
+	_, err = catalog.Contribute(source, &model.AdviceCatalog{Rules: map[string]*model.RuleAdvice{
+		"java-system-config": {Patterns: map[string]string{"System.getenv": "Umgebung"}}}})
+	assert.Error(t, err)
+	assert.Empty(t, catalog.Rules)
+}
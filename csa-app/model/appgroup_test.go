@@ -0,0 +1,58 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppGroupValidation(t *testing.T) {
+	assert.NoError(t, (&model.AppGroup{Name: "retail", Type: model.BUSINESS_UNIT_GROUP}).Validate())
+	assert.NoError(t, (&model.AppGroup{Name: "payments", Type: model.PORTFOLIO_GROUP, Parent: "retail"}).Validate())
+	assert.Error(t, (&model.AppGroup{Name: "retail", Type: model.BUSINESS_UNIT_GROUP, Parent: "corp"}).Validate())
+	assert.Error(t, (&model.AppGroup{Name: "retail", Type: "division"}).Validate())
+	assert.Error(t, (&model.AppGroup{Name: "retail", Type: model.BUSINESS_UNIT_GROUP,
+		Applications: []*model.AppGroupMember{{Application: "app1"}}}).Validate())
+}
+
+func TestRollupGroups(t *testing.T) {
+	groups := []model.AppGroup{
+		{Name: "retail", Type: model.BUSINESS_UNIT_GROUP},
+		{Name: "payments", Type: model.PORTFOLIO_GROUP, Parent: "retail",
+			Applications: []*model.AppGroupMember{{Application: "app1"}, {Application: "app2"}}},
+		{Name: "stores", Type: model.PORTFOLIO_GROUP, Parent: "retail",
+			Applications: []*model.AppGroupMember{{Application: "app3"}, {Application: "missing"}}},
+	}
+
+	apps := []model.Application{
+		{Name: "app1", Score: 8, Findings: 10, RawScore: 20, SlocCnt: 1000},
+		{Name: "app2", Score: 6, Findings: 5, RawScore: 10, SlocCnt: 500},
+		{Name: "app3", Score: 4, Findings: 1, RawScore: 3, SlocCnt: 100},
+	}
+
+	rollups := model.RollupGroups(groups, apps)
+
+	assert.Len(t, rollups, 3)
+
+	assert.Equal(t, "retail", rollups[0].Name, "business units should be listed first")
+	assert.Equal(t, 3, rollups[0].Applications)
+	assert.Equal(t, 16, rollups[0].Findings)
+	assert.Equal(t, 33, rollups[0].Effort)
+	assert.Equal(t, 1600, rollups[0].SlocCnt)
+	assert.Equal(t, 6.0, rollups[0].Score)
+
+	assert.Equal(t, "payments", rollups[1].Name)
+	assert.Equal(t, 2, rollups[1].Applications)
+	assert.Equal(t, 7.0, rollups[1].Score)
+
+	assert.Equal(t, "stores", rollups[2].Name)
+	assert.Equal(t, 1, rollups[2].Applications, "applications not in the run should be ignored")
+	assert.Equal(t, 4.0, rollups[2].Score)
+}
@@ -21,6 +21,8 @@ const FILES_SCORING_TOKEN = "files_cnt"
 const FINDINGS_SCORING_TOKEN = "findings_cnt"
 const SLOC_CNT_SCORING_TOKEN = "sloc_cnt"
 const BUSINESS_VALUE_SCORING_TOKEN = "bv"
+const CRITICAL_SEVERITY_SCORING_TOKEN = "critical_severity_cnt"
+const HIGH_SEVERITY_SCORING_TOKEN = "high_severity_cnt"
 
 type Application struct {
 	ID             uint              `gorm:"primary_key" json:"appId" yaml:"appId"`
@@ -38,14 +40,23 @@ type Application struct {
 	InfoFindings   int               `json:"infoFindings"`
 	RawScore       int               `json:"rawScore"`
 	NumCrits       int               `json:"numCrits"`
+	CriticalCnt    int               `json:"criticalSeverityFindings"`
+	HighCnt        int               `json:"highSeverityFindings"`
 	ScoringModel   string            `json:"model" yaml:"model"`
 	Score          float64           `json:"score"`
 	OriginalScore  float64           `gorm:"default:'-1.0'" json:"originalScore"`
 	ScoreModified  bool              `json:"scoreModified"`
 	Recommendation string            `json:"recommendation"`
+	ContainerScore float64           `json:"containerScore"`
+	ScoreBin       *ScoreBin         `gorm:"-" json:"scoreBin,omitempty" yaml:"-"`
 	SlocCnt        int               `json:"slocCnt"`
 	FilesCnt       int               `json:"filesCnt"`
 	FindingsRatio  float64           `json:"findingsRatio"`
+	BaselineRunID  uint              `json:"baselineRunId,omitempty" yaml:"baselineRunId,omitempty"`
+	Fingerprint    string            `gorm:"type:text" json:"-" yaml:"-"` //Of the rules and options the app was analyzed with, see RulesFingerprint
+	NewCnt         int               `json:"newFindings"`
+	RecurringCnt   int               `json:"recurringFindings"`
+	ResolvedCnt    int               `json:"resolvedFindings"`
 	Tags           []*ApplicationTag `gorm:"foreignkey:ApplicationID" json:"tags" yaml:"tags"`
 	Files          []*util.FileInfo  `gorm:"-" json:"-" yaml:"-"`
 	IgnoredFiles   []*util.FileInfo  `gorm:"-" json:"-" yaml:"-"`
@@ -54,7 +65,18 @@ type Application struct {
 	MatchedRules   map[string]int    `gorm:"-" json:"-" yaml:"-"`
 	Bins           []Bin             `gorm:"-" json:"bins" yaml:"bins"`
 	Model          *ScoringModel     `gorm:"-" json:"-" yaml:"-"`
+	TagTotals      TagTotals         `gorm:"-" json:"-" yaml:"-"`
+	TechStack      []*TechAttribute  `gorm:"-" json:"techStack,omitempty" yaml:"-"`
+	Modules        []*AppModule      `gorm:"-" json:"-" yaml:"-"`
+	Libraries      []*AppLibrary     `gorm:"-" json:"-" yaml:"-"`
+	DotnetProjects []*DotnetProject  `gorm:"-" json:"-" yaml:"-"`
 	sync.Mutex     `gorm:"-" json:"-" yaml:"-"`
+
+	//The copybooks its COBOL programs copy, resolved before they are analyzed
+	Copybooks []CopybookReference `gorm:"-" json:"-" yaml:"-"`
+
+	//Set while the raw score is normalized for scoring
+	normalized bool
 }
 
 type ApplicationTag struct {
@@ -84,6 +106,8 @@ func (app *Application) MergeDetails(details ApplicationDetails) {
 	app.FilesCnt = details.FilesCnt
 	app.CIFindings = details.CIFindings
 	app.NumCrits = details.NumCrits
+	app.CriticalCnt = details.CriticalSeverity
+	app.HighCnt = details.HighSeverity
 	app.RawScore = details.RawScore
 	app.SlocCnt = details.SlocCnt
 	app.InfoFindings = details.InfoFindings
@@ -188,6 +212,8 @@ func (app *Application) calculate(target string, scoreModel *ScoringModel) (floa
 	parameters[FINDINGS_SCORING_TOKEN] = app.Findings
 	parameters[SLOC_CNT_SCORING_TOKEN] = app.SlocCnt
 	parameters[BUSINESS_VALUE_SCORING_TOKEN] = app.BusinessValue
+	parameters[CRITICAL_SEVERITY_SCORING_TOKEN] = app.CriticalCnt
+	parameters[HIGH_SEVERITY_SCORING_TOKEN] = app.HighCnt
 	parameters[MAX_SCORE] = scoreModel.MaxScore
 	parameters[MIN_SCORE] = scoreModel.MinScore
 
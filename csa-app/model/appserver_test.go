@@ -0,0 +1,161 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectAppServerResources(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "appserver")
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"liberty/server.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<server description="orders">
+  <featureManager><feature>jdbc-4.2</feature></featureManager>
+  <dataSource id="ordersDS" jndiName="jdbc/orders">
+    <properties.oracle URL="jdbc:oracle:thin:@db:1521/ORDERS"/>
+  </dataSource>
+  <jndiEntry jndiName="config/region" value="emea"/>
+  <managedExecutorService jndiName="concurrent/batch"/>
+  <basicRegistry id="basic" realm="OrdersRealm"/>
+</server>`,
+		"tomcat/conf/server.xml": `<Server port="8005"><Service name="Catalina"/></Server>`,
+		"src/main/webapp/WEB-INF/ibm-web-bnd.xml": `<web-bnd xmlns="http://websphere.ibm.com/xml/ns/javaee" version="1.0">
+  <resource-ref name="jdbc/orders" binding-name="jdbc/ordersDS"/>
+</web-bnd>`,
+		"weblogic/WEB-INF/weblogic.xml": `<wls:weblogic-web-app xmlns:wls="http://xmlns.oracle.com/weblogic/weblogic-web-app">
+  <wls:resource-description>
+    <wls:res-ref-name>jdbc/orders</wls:res-ref-name>
+    <wls:jndi-name>OrdersDS</wls:jndi-name>
+  </wls:resource-description>
+  <wls:work-manager>
+    <wls:name>OrdersWM</wls:name>
+    <wls:max-threads-constraint><wls:name>max</wls:name><wls:count>10</wls:count></wls:max-threads-constraint>
+  </wls:work-manager>
+</wls:weblogic-web-app>`,
+		"domain/config/config.xml": `<domain>
+  <security-configuration><realm><name>myrealm</name></realm></security-configuration>
+  <self-tuning><work-manager><name>BatchWM</name><target>cluster1</target></work-manager></self-tuning>
+  <jdbc-system-resource><name>OrdersDS</name><descriptor-file-name>jdbc/OrdersDS-jdbc.xml</descriptor-file-name></jdbc-system-resource>
+</domain>`,
+		"domain/config/jdbc/OrdersDS-jdbc.xml": `<jdbc-data-source>
+  <name>OrdersDS</name>
+  <jdbc-driver-params><url>jdbc:oracle:thin:@db:1521/ORDERS</url></jdbc-driver-params>
+  <jdbc-data-source-params><jndi-name>jdbc/OrdersDS</jndi-name></jdbc-data-source-params>
+</jdbc-data-source>`,
+		"jboss/orders-ds.xml": `<datasources>
+  <datasource jndi-name="java:jboss/datasources/OrdersDS" pool-name="OrdersDS">
+    <connection-url>jdbc:postgresql://db:5432/orders</connection-url>
+  </datasource>
+</datasources>`,
+		"jboss/standalone.xml": `<server xmlns="urn:jboss:domain:19.0">
+  <subsystem xmlns="urn:jboss:domain:naming:2.0">
+    <bindings><simple name="java:global/region" value="emea"/></bindings>
+  </subsystem>
+  <subsystem xmlns="urn:jboss:domain:security:2.0">
+    <security-domains><security-domain name="orders-domain"/></security-domains>
+  </subsystem>
+</server>`,
+		"src/main/webapp/WEB-INF/jboss-web.xml": `<jboss-web><security-domain>orders-domain</security-domain></jboss-web>`,
+		"vendor/weblogic.xml":                   `<weblogic-web-app><work-manager><name>VendorWM</name></work-manager></weblogic-web-app>`,
+		"broken/weblogic.xml":                   `<weblogic-web-app><work-manager>`,
+	}
+
+	app := &model.Application{Name: "orders", Path: dir}
+	for name, content := range files {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		_ = ioutil.WriteFile(fqn, []byte(content), 0644)
+		file := &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn}
+		if name == "vendor/weblogic.xml" {
+			file.ThirdParty = "vendor"
+		}
+		app.Files = append(app.Files, file)
+	}
+
+	resources := model.DetectAppServerResources(5, app, model.AppServerExtractors)
+	assert.Len(t, resources, 15)
+
+	//By descriptor and name
+	found := make(map[string]*model.AppServerResource)
+	for _, resource := range resources {
+		assert.Equal(t, uint(5), resource.RunID)
+		assert.Equal(t, "orders", resource.Application)
+		found[resource.Descriptor+"#"+resource.Name] = resource
+	}
+
+	assert.Equal(t, &model.AppServerResource{RunID: 5, Application: "orders", Server: model.APP_SERVER_WEBSPHERE, Kind: model.APP_SERVER_DATASOURCE,
+		Name: "ordersDS", Jndi: "jdbc/orders", Detail: "jdbc:oracle:thin:@db:1521/ORDERS", Descriptor: "liberty/server.xml", Line: 4,
+		Fqn: filepath.Join(dir, "liberty", "server.xml")}, found["liberty/server.xml#ordersDS"])
+	assert.Equal(t, "emea", found["liberty/server.xml#config/region"].Detail)
+	assert.Equal(t, model.APP_SERVER_WORK_MANAGER, found["liberty/server.xml#concurrent/batch"].Kind)
+	assert.Equal(t, model.APP_SERVER_SECURITY_REALM, found["liberty/server.xml#OrdersRealm"].Kind)
+	assert.Equal(t, "jdbc/ordersDS", found["src/main/webapp/WEB-INF/ibm-web-bnd.xml#jdbc/orders"].Jndi)
+
+	assert.Equal(t, "OrdersDS", found["weblogic/WEB-INF/weblogic.xml#jdbc/orders"].Jndi, "namespace prefixes are ignored")
+	assert.Equal(t, "10", found["weblogic/WEB-INF/weblogic.xml#OrdersWM"].Detail)
+	assert.Equal(t, 6, found["weblogic/WEB-INF/weblogic.xml#OrdersWM"].Line)
+	assert.Equal(t, "cluster1", found["domain/config/config.xml#BatchWM"].Detail)
+	assert.Equal(t, model.APP_SERVER_SECURITY_REALM, found["domain/config/config.xml#myrealm"].Kind)
+	assert.Equal(t, "jdbc/OrdersDS-jdbc.xml", found["domain/config/config.xml#OrdersDS"].Detail)
+	assert.Equal(t, "jdbc/OrdersDS", found["domain/config/jdbc/OrdersDS-jdbc.xml#OrdersDS"].Jndi)
+
+	assert.Equal(t, "jdbc:postgresql://db:5432/orders", found["jboss/orders-ds.xml#OrdersDS"].Detail)
+	assert.Equal(t, "java:jboss/datasources/OrdersDS", found["jboss/orders-ds.xml#OrdersDS"].Jndi)
+	assert.Equal(t, "emea", found["jboss/standalone.xml#java:global/region"].Detail)
+	assert.Equal(t, model.APP_SERVER_SECURITY_REALM, found["jboss/standalone.xml#orders-domain"].Kind)
+	assert.Equal(t, model.APP_SERVER_SECURITY_REALM, found["src/main/webapp/WEB-INF/jboss-web.xml#orders-domain"].Kind)
+
+	assert.NotContains(t, found, "vendor/weblogic.xml#VendorWM", "vendored descriptors are skipped")
+	assert.NotContains(t, found, "tomcat/conf/server.xml#Catalina")
+}
+
+func TestAppServerResourceFinding(t *testing.T) {
+
+	resource := &model.AppServerResource{RunID: 5, Application: "orders", Server: model.APP_SERVER_WEBLOGIC, Kind: model.APP_SERVER_JNDI,
+		Name: "jdbc/orders", Jndi: "OrdersDS", Descriptor: "WEB-INF/weblogic.xml", Line: 3, Fqn: "/src/orders/WEB-INF/weblogic.xml"}
+
+	finding := resource.Finding()
+
+	assert.Equal(t, "app-server-jndi", finding.Rule)
+	assert.Equal(t, "jdbc/orders => OrdersDS", finding.Value)
+	assert.Equal(t, "weblogic.xml", finding.Filename)
+	assert.Equal(t, ".xml", finding.Ext)
+	assert.Equal(t, 3, finding.Line)
+	assert.Equal(t, 3, finding.Effort)
+	assert.Equal(t, []model.FindingTag{{Value: "app-server"}, {Value: "weblogic"}, {Value: "app-server-jndi"}}, finding.Tags)
+}
+
+func TestAppServerInventories(t *testing.T) {
+
+	resources := []model.AppServerResource{
+		{Application: "orders", Server: model.APP_SERVER_WEBLOGIC, Kind: model.APP_SERVER_DATASOURCE, Name: "OrdersDS"},
+		{Application: "billing", Server: model.APP_SERVER_JBOSS, Kind: model.APP_SERVER_JNDI, Name: "java:global/region"},
+		{Application: "orders", Server: model.APP_SERVER_WEBSPHERE, Kind: model.APP_SERVER_DATASOURCE, Name: "ordersDS"},
+		{Application: "orders", Server: model.APP_SERVER_WEBLOGIC, Kind: model.APP_SERVER_SECURITY_REALM, Name: "myrealm"},
+	}
+
+	inventories := model.AppServerInventories(resources)
+
+	assert.Len(t, inventories, 2)
+	assert.Equal(t, "billing", inventories[0].Application)
+	assert.Equal(t, "orders", inventories[1].Application)
+	assert.Equal(t, []string{"weblogic", "websphere"}, inventories[1].Servers)
+	assert.Equal(t, map[string]int{"datasource": 2, "security-realm": 1}, inventories[1].Kinds)
+	assert.Equal(t, 24, inventories[1].Effort)
+	assert.Len(t, inventories[1].Resources, 3)
+}
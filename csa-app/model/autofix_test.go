@@ -0,0 +1,82 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixFile(t *testing.T) {
+
+	imports := model.Rule{Name: "java-javax-imports", Target: model.LINE_TARGET, Type: model.REGEX_MATCH_TYPE,
+		DefaultPattern: `^import\s+javax\.(%s\..*);`, Replace: "import jakarta.$1;",
+		Patterns: []model.Pattern{{Value: "servlet"}, {Value: "persistence"}}}
+	log4j := model.Rule{Name: "java-log4j1", Target: model.MULTILINE_TARGET, Type: model.REGEX_MATCH_TYPE,
+		Patterns: []model.Pattern{{Value: `Logger\.getLogger\(\s*(\w+)\.class\s*\)`, Replace: "LogManager.getLogger($1.class)"}}}
+	advice := model.Rule{Name: "java-jndi", Target: model.LINE_TARGET, Type: model.REGEX_MATCH_TYPE,
+		Patterns: []model.Pattern{{Value: "InitialContext"}}}
+
+	rules := map[string]*model.Rule{}
+	for _, rule := range []*model.Rule{&imports, &log4j, &advice} {
+		rule.CompilePatterns()
+		rules[rule.Name] = rule
+	}
+	assert.False(t, advice.IsFixable())
+
+	contents := "import javax.servlet.http.HttpServlet;\r\n" +
+		"import javax.naming.InitialContext;\r\n" +
+		"// import javax.persistence.Entity; is not an import\r\n" +
+		"Logger log = Logger.getLogger(\r\n    Orders.class);\r\n"
+	findings := []model.Finding{
+		{Rule: "java-javax-imports", Pattern: "servlet", Line: 1},
+		{Rule: "java-jndi", Pattern: "InitialContext", Line: 2},
+		{Rule: "java-log4j1", Line: 4, EndLine: 5},
+	}
+
+	fix := model.FixFile("src/Orders.java", contents, findings, rules)
+	assert.Equal(t, 2, fix.Fixes)
+	assert.Equal(t, "import jakarta.servlet.http.HttpServlet;\r\n"+
+		"import javax.naming.InitialContext;\r\n"+
+		"// import javax.persistence.Entity; is not an import\r\n"+
+		"Logger log = LogManager.getLogger(Orders.class);\r\n", fix.Fixed)
+
+	diff, err := fix.UnifiedDiff()
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "--- a/src/Orders.java")
+	assert.Contains(t, diff, "+++ b/src/Orders.java")
+	assert.Contains(t, diff, "-import javax.servlet.http.HttpServlet;")
+	assert.Contains(t, diff, "+import jakarta.servlet.http.HttpServlet;")
+
+	unchanged := model.FixFile("src/Orders.java", contents, findings[1:2], rules)
+	diff, err = unchanged.UnifiedDiff()
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestReplacementValidation(t *testing.T) {
+
+	r := getValidRule()
+	r.Target = model.LINE_TARGET
+	r.Replace = "Forrest $0"
+	if valid, err := r.IsValid(); !valid {
+		t.Errorf("Regex line rule with a replacement should be valid! Details: %v", err)
+	}
+
+	r.Target = model.FILE_TARGET
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("File rule with a replacement should fail validation but passed!")
+	}
+
+	r.Target = model.LINE_TARGET
+	r.Type = model.CONTAINS_MATCH_TYPE
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Contains rule with a replacement should fail validation but passed!")
+	}
+}
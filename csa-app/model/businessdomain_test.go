@@ -0,0 +1,78 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollupDomains(t *testing.T) {
+
+	apps := []model.Application{
+		{Name: "billing-api", BusinessDomain: "payments", Score: 8, RawScore: 40, Findings: 10, SlocCnt: 1000},
+		{Name: "ledger", BusinessDomain: "payments", Score: 4, RawScore: 200, Findings: 30, SlocCnt: 3000},
+		{Name: "tracking", BusinessDomain: "logistics", Score: 9, RawScore: 20, Findings: 5, SlocCnt: 500},
+		{Name: "legacy-batch", Score: 6, RawScore: 90, Findings: 12, SlocCnt: 2000},
+	}
+
+	rollups := model.RollupDomains(apps)
+	assert.Len(t, rollups, 3)
+
+	assert.Equal(t, "logistics", rollups[0].Domain)
+	assert.Equal(t, 1, rollups[0].Rank)
+
+	payments := rollups[1]
+	assert.Equal(t, "payments", payments.Domain)
+	assert.Equal(t, 2, payments.Rank)
+	assert.Equal(t, 2, payments.Applications)
+	assert.Equal(t, 6.0, payments.Score)
+	assert.Equal(t, 5.0, payments.WeightedScore, "ledger carries 3/4 of the sloc")
+	assert.Equal(t, 4.0, payments.MinScore)
+	assert.Equal(t, 8.0, payments.MaxScore)
+	assert.Equal(t, "ledger", payments.LowestApp)
+	assert.Equal(t, 240, payments.Effort)
+	assert.Equal(t, 40, payments.Findings)
+	assert.Equal(t, 4000, payments.SlocCnt)
+
+	//Applications without a domain are rolled up too, tying with payments
+	assert.Equal(t, model.UNASSIGNED_DOMAIN, rollups[2].Domain)
+	assert.Equal(t, 2, rollups[2].Rank)
+}
+
+func TestLoadDomainMapping(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "domains")
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "domains.yaml")
+	_ = ioutil.WriteFile(file, []byte("domains:\n  payments: [billing-api, ledger]\n  logistics: [tracking]\n"), 0644)
+
+	mapping, err := model.LoadDomainMapping(file)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"logistics", "payments"}, mapping.DomainNames())
+
+	domain, found := mapping.DomainOf("ledger")
+	assert.True(t, found)
+	assert.Equal(t, "payments", domain)
+
+	_, found = mapping.DomainOf("legacy-batch")
+	assert.False(t, found)
+
+	_ = ioutil.WriteFile(file, []byte("domains:\n  payments: [ledger]\n  finance: [ledger]\n"), 0644)
+	_, err = model.LoadDomainMapping(file)
+	assert.Error(t, err, "an application belongs to a single domain")
+
+	_ = ioutil.WriteFile(file, []byte("domain:\n  payments: [ledger]\n"), 0644)
+	_, err = model.LoadDomainMapping(file)
+	assert.Error(t, err)
+}
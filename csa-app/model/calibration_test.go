@@ -0,0 +1,66 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibrate(t *testing.T) {
+
+	base := &model.EstimationModel{Name: "engagement", DayRate: 800,
+		Default:    model.ProductivityFactor{MinDays: 0.1, MaxDays: 0.3},
+		Categories: map[string]model.ProductivityFactor{"EJB": {MinDays: 0.5, MaxDays: 1.5}, "logging": {MinDays: 0.01, MaxDays: 0.03}}}
+
+	//orders is estimated 10 days of ejb (10 * 1) and 10 days of jni (50 * 0.2) but took 30, billing 10 days of ejb
+	samples := []model.CalibrationSample{
+		{Application: "orders", ActualDays: 30, Effort: map[string]int{"ejb": 10, "jni": 50}},
+		{Application: "billing", ActualDays: 10, Effort: map[string]int{"ejb": 10}},
+		{Application: "docs", ActualDays: 5, Effort: map[string]int{}},
+	}
+
+	calibrated, calibrations, err := model.Calibrate(base, samples)
+	assert.NoError(t, err)
+	assert.NoError(t, calibrated.Validate())
+	assert.Equal(t, "engagement-calibrated", calibrated.Name)
+	assert.Equal(t, 800.0, calibrated.DayRate)
+
+	assert.Len(t, calibrations, 3)
+	ejb, jni, overall := calibrations[0], calibrations[1], calibrations[2]
+
+	assert.Equal(t, "EJB", ejb.Category, "categories are named like in the model")
+	assert.Equal(t, 2, ejb.Applications)
+	assert.Equal(t, 20, ejb.Effort)
+	assert.InDelta(t, 20, ejb.EstimatedDays, 0.0001)
+	assert.InDelta(t, 25, ejb.ActualDays, 0.0001)
+	assert.InDelta(t, 1.25, ejb.Ratio, 0.0001)
+	assert.InDelta(t, 0.625, calibrated.Factor("ejb").MinDays, 0.0001)
+	assert.InDelta(t, 1.875, calibrated.Factor("ejb").MaxDays, 0.0001)
+
+	assert.Equal(t, "jni", jni.Category)
+	assert.InDelta(t, 1.5, jni.Ratio, 0.0001)
+	assert.InDelta(t, 0.15, calibrated.Factor("jni").MinDays, 0.0001)
+
+	assert.Equal(t, model.DEFAULT_FACTOR, overall.Category)
+	assert.Equal(t, 2, overall.Applications, "applications without effort are not calibrated with")
+	assert.InDelta(t, 1.3333, overall.Ratio, 0.0001)
+	assert.InDelta(t, 0.1333, calibrated.Default.MinDays, 0.0001)
+	assert.InDelta(t, 0.04, calibrated.Factor("logging").MaxDays, 0.0001, "categories without outcomes take the overall ratio")
+
+	_, _, err = model.Calibrate(base, samples[2:])
+	assert.Error(t, err)
+}
+
+func TestMigrationOutcomeValidate(t *testing.T) {
+	assert.NoError(t, (&model.MigrationOutcome{Application: "orders", ActualDays: 12.5}).Validate())
+	assert.Error(t, (&model.MigrationOutcome{ActualDays: 12.5}).Validate())
+	assert.Error(t, (&model.MigrationOutcome{Application: "orders"}).Validate())
+	assert.Error(t, (&model.MigrationOutcome{Application: "orders", ActualDays: -1}).Validate())
+}
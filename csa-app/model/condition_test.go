@@ -0,0 +1,80 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCondition(t *testing.T) {
+
+	matched := func(rules ...string) func(string) bool {
+		return func(rule string) bool {
+			for _, r := range rules {
+				if r == rule {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	condition, err := model.ParseCondition("persistence-xml and not (datasource-jndi OR datasource-env)")
+	assert.NoError(t, err)
+	assert.Equal(t, "(persistence-xml AND NOT (datasource-jndi OR datasource-env))", condition.String())
+	assert.Equal(t, []string{"persistence-xml", "datasource-jndi", "datasource-env"}, condition.Rules())
+
+	assert.True(t, condition.Eval(matched("persistence-xml")))
+	assert.False(t, condition.Eval(matched("persistence-xml", "datasource-env")))
+	assert.False(t, condition.Eval(matched()))
+
+	//AND binds tighter than OR
+	condition, err = model.ParseCondition("a OR b AND c")
+	assert.NoError(t, err)
+	assert.True(t, condition.Eval(matched("a")))
+	assert.False(t, condition.Eval(matched("b")))
+
+	for _, invalid := range []string{"", "a AND", "(a OR b", "a b", "AND a", "a OR )"} {
+		_, err = model.ParseCondition(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCompositeRuleValidation(t *testing.T) {
+
+	r := model.Rule{Name: "jpa-without-datasource", Type: model.COMPOSITE_MATCH_TYPE, Target: model.APPLICATION_TARGET,
+		Condition: "persistence-xml AND NOT datasource-jndi", Effort: 5}
+
+	valid, err := r.IsValid()
+	assert.True(t, valid, err)
+
+	r.CompilePatterns()
+	assert.True(t, r.ConditionMet(func(rule string) bool { return rule == "persistence-xml" }))
+
+	r.Target = model.LINE_TARGET
+	valid, _ = r.IsValid()
+	assert.False(t, valid)
+
+	r.Target = model.FILE_TARGET
+	r.Condition = "jpa-without-datasource OR persistence-xml"
+	valid, _ = r.IsValid()
+	assert.False(t, valid)
+
+	r.Condition = "persistence-xml"
+	r.Patterns = []model.Pattern{{Value: "persistence"}}
+	valid, _ = r.IsValid()
+	assert.False(t, valid)
+
+	//Only composite rules can target the application
+	regular := getValidRule()
+	regular.Target = model.APPLICATION_TARGET
+	valid, _ = regular.IsValid()
+	assert.False(t, valid)
+}
@@ -0,0 +1,35 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfidenceRank(t *testing.T) {
+	assert.True(t, model.ConfidenceRank(model.CONFIDENCE_LOW) < model.ConfidenceRank(model.CONFIDENCE_MEDIUM))
+	assert.Equal(t, model.ConfidenceRank(model.CONFIDENCE_HIGH), model.ConfidenceRank(""), "unset confidence is high")
+	assert.Equal(t, model.ConfidenceRank(model.CONFIDENCE_MEDIUM), model.ConfidenceRank("Medium"))
+
+	assert.NoError(t, model.ValidateConfidence(""))
+	assert.NoError(t, model.ValidateConfidence("LOW"))
+	assert.Error(t, model.ValidateConfidence("certain"))
+}
+
+func TestFindingConfidence(t *testing.T) {
+	assert.Equal(t, model.CONFIDENCE_HIGH, model.FindingConfidence("", "ctx.lookup(\"java:comp/env\");", true))
+	assert.Equal(t, model.CONFIDENCE_MEDIUM, model.FindingConfidence("Medium", "new InitialContext();", true))
+
+	for _, comment := range []string{"  // new InitialContext()", " * uses InitialContext", "# InitialContext", "<!-- jndi -->", "-- select * from dual"} {
+		assert.Equal(t, model.CONFIDENCE_LOW, model.FindingConfidence(model.CONFIDENCE_HIGH, comment, true), comment)
+	}
+
+	assert.Equal(t, model.CONFIDENCE_HIGH, model.FindingConfidence("", "# not a line target", false), "only line matches are checked for comments")
+}
@@ -0,0 +1,73 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupConfigItems(t *testing.T) {
+
+	assert.Empty(t, model.GroupConfigItems(nil, model.ConfigKinds))
+
+	findings := []model.Finding{
+		{Application: "orders", Fqn: "/src/orders/application.properties", Line: 3, Rule: "config-hardcoded-url", Effort: 3,
+			Value: "spring.datasource.url=jdbc:oracle:thin:@orders-db.prod.corp:1521/ORDERS"},
+		{Application: "orders", Fqn: "/src/orders/application.properties", Line: 1, Rule: "config-credential", Effort: 5,
+			Value: "spring.datasource.password=s3cr3t"},
+		{Application: "orders", Fqn: "/src/orders/application.yml", Line: 4, Rule: "config-hardcoded-host", Effort: 3,
+			Value: "    host: redis.prod.corp"},
+		{Application: "orders", Fqn: "/src/orders/application.yml", Line: 7, Rule: "hardcode-uri", Effort: 3,
+			Value: "  defaultZone: http://eureka.prod.corp:8761/eureka/"},
+		{Application: "orders", Fqn: "/src/orders/application.yml", Line: 7, Rule: "config-hardcoded-url", Effort: 3,
+			Value: "  defaultZone: http://eureka.prod.corp:8761/eureka/"},
+		{Application: "orders", Fqn: "/src/orders/src/Reports.java", Line: 12, Rule: "config-hardcoded-path-code", Effort: 3,
+			Value: `    File reports = new File("/opt/orders/reports");`},
+		{Application: "billing", Fqn: "/src/billing/Billing.java", Line: 9, Rule: "java-hardIP", Effort: 1,
+			Value: `    Socket socket = new Socket("10.20.0.5", 9000);`},
+		{Application: "billing", Fqn: "/src/billing/billing.conf", Line: 2, Rule: "custom-config-rule", Effort: 2,
+			Value: "  region = emea-1 "},
+	}
+
+	apps := model.GroupConfigItems(findings, model.ConfigKinds)
+	assert.Len(t, apps, 2)
+
+	billing := apps[0]
+	assert.Equal(t, "billing", billing.Application)
+	assert.Equal(t, model.ConfigItem{Kind: "host", File: "/src/billing/Billing.java", Line: 9, Value: "10.20.0.5", Rule: "java-hardIP", Effort: 1},
+		billing.Items[0])
+	assert.Equal(t, model.ConfigItem{Kind: model.CONFIG_OTHER_KIND, File: "/src/billing/billing.conf", Line: 2, Value: "region = emea-1",
+		Rule: "custom-config-rule", Effort: 2}, billing.Items[1], "rules of no kind keep the line")
+
+	orders := apps[1]
+	assert.Len(t, orders.Items, 5, "values flagged by several rules are listed once")
+	assert.Equal(t, 17, orders.Effort)
+	assert.Equal(t, map[string]int{"url": 2, "credential": 1, "host": 1, "path": 1}, orders.Kinds)
+
+	assert.Equal(t, model.ConfigItem{Kind: "credential", File: "/src/orders/application.properties", Line: 1, Key: "spring.datasource.password",
+		Value: model.CONFIG_MASKED_VALUE, Variable: "SPRING_DATASOURCE_PASSWORD", Rule: "config-credential", Effort: 5}, orders.Items[0], "sorted by file and line")
+	assert.Equal(t, "jdbc:oracle:thin:@orders-db.prod.corp:1521/ORDERS", orders.Items[1].Value)
+	assert.Equal(t, "SPRING_DATASOURCE_URL", orders.Items[1].Variable)
+
+	assert.Equal(t, "host", orders.Items[2].Key)
+	assert.Equal(t, "redis.prod.corp", orders.Items[2].Value)
+	assert.Empty(t, orders.Items[2].Variable, "unqualified keys don't name the setting")
+	assert.Equal(t, "http://eureka.prod.corp:8761/eureka/", orders.Items[3].Value)
+	assert.Equal(t, "hardcode-uri", orders.Items[3].Rule)
+	assert.Equal(t, "/opt/orders/reports", orders.Items[4].Value)
+}
+
+func TestConfigVariable(t *testing.T) {
+
+	assert.Equal(t, "SPRING_DATASOURCE_URL", model.ConfigVariable("spring.datasource.url"))
+	assert.Equal(t, "SPRING_KAFKA_BOOTSTRAP_SERVERS", model.ConfigVariable("spring.kafka.bootstrap-servers"))
+	assert.Equal(t, "ORDERS_NODES_0", model.ConfigVariable("orders.nodes[0]"))
+	assert.Equal(t, "DB_URL", model.ConfigVariable("DB_URL"))
+}
@@ -29,6 +29,17 @@ const ENDS_WITH_MATCH_TYPE string = "ends-with"
 const ENDS_WITH_CI_MATCH_TYPE string = "ends-with-ci"
 const CONTAINS_MATCH_TYPE string = "contains"
 const CONTAINS_CI_MATCH_TYPE string = "contains-ci"
+
+//Composite rules match when their condition over other rules' matches holds, per file or once for the whole application
+const COMPOSITE_MATCH_TYPE string = "composite"
+const APPLICATION_TARGET string = "application"
+
+//Script rules match when their expression over the file contents, path and earlier findings holds
+const SCRIPT_MATCH_TYPE string = "script"
+
+//Multiline rules match regex patterns spanning lines against the file contents, recording a finding per match
+const MULTILINE_TARGET string = "multiline"
+
 const DEFAULT_CRITICALITY string = "medium"
 const DEFAULT_LINE_REGEX_PATTERN string = "[ .]%s[ (]"
 const THIRD_PARTY_TAG string = "third-party"
@@ -42,6 +53,9 @@ const MIN_RULE_SCORE int = 1
 
 const THIRD_PARTY_REPORT_ID int = 1
 const THIRD_PARTY_HEADER string = "ThirdParty"
+const THIRD_PARTY_LICENSE_HEADER string = "License"
+const THIRD_PARTY_LICENSE_TYPE_HEADER string = "LicenseType"
+const THIRD_PARTY_LICENSE_SOURCE_HEADER string = "LicenseSource"
 
 const API_SUMMARY_REPORT_ID int = 2
 const API_SUMMARY_API_HEADER string = "API"
@@ -0,0 +1,54 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateContainerReadiness(t *testing.T) {
+
+	clean := model.EvaluateContainerReadiness("clean", nil, model.ContainerConcerns)
+	assert.Equal(t, 10.0, clean.Score)
+	assert.False(t, clean.Containerized)
+	assert.False(t, clean.Blocked)
+	assert.Len(t, clean.Concerns, len(model.ContainerConcerns))
+
+	totals := model.TagTotals{
+		"File":                   {Findings: 2, Effort: 200},
+		"writeFile":              {Findings: 1, Effort: 5},
+		"jni":                    {Findings: 1000, Effort: 1000},
+		"Dockerfile":             {Findings: 1, Effort: 5},
+		"dockerfile-healthcheck": {Findings: 3, Effort: 9},
+	}
+
+	readiness := model.EvaluateContainerReadiness("orders", totals, model.ContainerConcerns)
+	assert.True(t, readiness.Containerized)
+	assert.True(t, readiness.Blocked, "native libraries are blockers")
+
+	concerns := make(map[string]model.ConcernResult)
+	for _, concern := range readiness.Concerns {
+		concerns[concern.Concern] = concern
+	}
+
+	filesystem := concerns["filesystem"]
+	assert.Equal(t, 3, filesystem.Findings, "tags are matched regardless of case")
+	assert.Equal(t, 205, filesystem.Effort)
+	assert.Equal(t, []string{"file", "writefile"}, filesystem.Tags)
+	assert.InDelta(t, 1.5, filesystem.Penalty, 0.001, "half the weight at 3 findings")
+
+	assert.InDelta(t, 2.0, concerns["native"].Penalty, 0.01, "the penalty approaches the weight")
+	assert.True(t, concerns["native"].Penalty < 2.0)
+	assert.True(t, concerns["native"].Blocker)
+	assert.Equal(t, 0.0, concerns["ports"].Penalty)
+	assert.InDelta(t, 0.5, concerns["image"].Penalty, 0.001, "Dockerfile anti-patterns cost readiness")
+
+	assert.InDelta(t, 10-filesystem.Penalty-concerns["native"].Penalty-concerns["image"].Penalty, readiness.Score, 0.001)
+}
@@ -0,0 +1,59 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveCopybooks(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "copybooks")
+	defer os.RemoveAll(dir)
+
+	app := &model.Application{Name: "orders", Path: dir}
+	files := make(map[string]*util.FileInfo)
+	for name, content := range map[string]string{
+		"src/ORDINQ.cbl": `000100 IDENTIFICATION DIVISION.
+000200 DATA DIVISION.
+000300 WORKING-STORAGE SECTION.
+000400     COPY CUSTREC.
+000500*    COPY OLDREC.
+000600     COPY 'ORDREC'.
+000700     EXEC SQL INCLUDE SQLCA END-EXEC.
+000800     EXEC SQL INCLUDE DCLORD END-EXEC.
+`,
+		"copylib/CUSTREC":    "       01 CUSTOMER-RECORD.\n           COPY ADDRREC.\n",
+		"copylib/ORDREC.cpy": "       01 ORDER-RECORD.\n",
+		"copylib/ADDRREC":    "       05 ADDRESS-LINE PIC X(40).\n",
+	} {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		assert.NoError(t, ioutil.WriteFile(fqn, []byte(content), 0644))
+		files[name] = &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn, Ext: filepath.Ext(fqn)}
+		app.Files = append(app.Files, files[name])
+	}
+
+	references := model.ResolveCopybooks(app)
+	assert.Equal(t, []model.CopybookReference{
+		{Program: "copylib/CUSTREC", Copybook: "ADDRREC", File: "copylib/ADDRREC"},
+		{Program: "src/ORDINQ.cbl", Copybook: "CUSTREC", File: "copylib/CUSTREC"},
+		{Program: "src/ORDINQ.cbl", Copybook: "DCLORD"},
+		{Program: "src/ORDINQ.cbl", Copybook: "ORDREC", File: "copylib/ORDREC.cpy"},
+	}, references, "commented and precompiler copybooks are skipped")
+
+	assert.Equal(t, "cpy", files["copylib/CUSTREC"].GetCleanedExt(), "copybooks are analyzed as cobol")
+	assert.Equal(t, "cpy", files["copylib/ADDRREC"].GetCleanedExt(), "copybooks of copybooks too")
+	assert.Equal(t, "cbl", files["src/ORDINQ.cbl"].GetCleanedExt())
+}
@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCriteria(t *testing.T) {
+	criteria, err := model.ParseCriteria("tag=filesystem AND effort>3")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(criteria.Criterion()))
+	assert.Equal(t, model.CRITERION_TAG, criteria.Criterion()[0].Key())
+	assert.Equal(t, "filesystem", criteria.Criterion()[0].Value())
+	assert.Equal(t, model.EXACT_MATCH, criteria.Criterion()[0].MatchType())
+	assert.Equal(t, model.CRITERION_EFFORT, criteria.Criterion()[1].Key())
+	assert.Equal(t, model.GREATER_MATCH, criteria.Criterion()[1].MatchType())
+}
+
+func TestParseCriteriaQuotedValue(t *testing.T) {
+	criteria, err := model.ParseCriteria(`category ~ "java io" and Application!=legacy`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "java io", criteria.Criterion()[0].Value())
+	assert.Equal(t, model.CRITERION_APPLICATION, criteria.Criterion()[1].Key())
+	assert.Equal(t, model.NOT_EQUAL_MATCH, criteria.Criterion()[1].MatchType())
+}
+
+func TestParseCriteriaEmpty(t *testing.T) {
+	criteria, err := model.ParseCriteria("  ")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(criteria.Criterion()))
+}
+
+func TestParseCriteriaRejectsBadTerms(t *testing.T) {
+	_, err := model.ParseCriteria("bogus=1")
+	assert.NotNil(t, err)
+
+	_, err = model.ParseCriteria("tag>3")
+	assert.NotNil(t, err)
+
+	_, err = model.ParseCriteria("effort>high")
+	assert.NotNil(t, err)
+
+	_, err = model.ParseCriteria("effort")
+	assert.NotNil(t, err)
+}
@@ -76,3 +76,21 @@ func NewLevelDetail(app string, cat string, file string, line int, val string, p
 func (a *ApiByApp) AddAPIUsage(api string, cnt int) {
 	a.ApiDetails = append(a.ApiDetails, ApiUsage{Api: api, UsageCount: cnt})
 }
+
+//ThirdPartySummary totals the findings of one vendored/third-party tree within an application
+type ThirdPartySummary struct {
+	Application string `json:"application"`
+	Path        string `json:"path"`
+	Files       int    `json:"files"`
+	Findings    int    `json:"findings"`
+	Effort      int    `json:"effort"`
+}
+
+type FindingAggregate struct {
+	Group        string `json:"group"`
+	Findings     int    `json:"findings"`
+	Effort       int    `json:"effort"`
+	Applications int    `json:"applications"`
+	Files        int    `json:"files"`
+	Estimate     string `json:"estimate,omitempty"`
+}
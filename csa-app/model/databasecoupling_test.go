@@ -0,0 +1,57 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateDatabaseCoupling(t *testing.T) {
+
+	slocs := []model.RunSloc{
+		{Application: "billing", Lang: "PLSQL", TotalFiles: 12, CodeLines: 4200},
+		{Application: "billing", Lang: "SQL", TotalFiles: 3, CodeLines: 300},
+		{Application: "billing", Lang: "Java", TotalFiles: 80, CodeLines: 9000},
+		{Application: "orders", Lang: "SQL", TotalFiles: 2, CodeLines: 150},
+		{Application: "reports", Lang: "SQL", TotalFiles: 40, CodeLines: 12000},
+	}
+
+	none := model.EvaluateDatabaseCoupling("catalog", slocs, nil, model.DatabaseConstructs)
+	assert.Equal(t, model.DB_COUPLING_NONE, none.Coupling)
+	assert.Empty(t, none.Constructs)
+
+	orders := model.EvaluateDatabaseCoupling("orders", slocs, nil, model.DatabaseConstructs)
+	assert.Equal(t, model.DB_COUPLING_LOW, orders.Coupling, "plain SQL scripts")
+
+	reports := model.EvaluateDatabaseCoupling("reports", slocs, nil, model.DatabaseConstructs)
+	assert.Equal(t, model.DB_COUPLING_HIGH, reports.Coupling, "a large database tier")
+
+	totals := model.TagTotals{
+		"stored-procedure": {Findings: 30, Effort: 150},
+		"Trigger":          {Findings: 4, Effort: 20},
+		"oracle-sql":       {Findings: 60, Effort: 180},
+		"java":             {Findings: 9, Effort: 90},
+	}
+
+	billing := model.EvaluateDatabaseCoupling("billing", slocs, totals, model.DatabaseConstructs)
+	assert.Equal(t, 15, billing.Files)
+	assert.Equal(t, 4500, billing.CodeLines, "only the code of the database tier")
+	assert.Equal(t, 34, billing.Routines, "tags are matched regardless of case")
+	assert.Equal(t, 94, billing.Findings)
+	assert.Equal(t, 350, billing.Effort)
+	assert.Equal(t, model.DB_COUPLING_MEDIUM, billing.Coupling)
+	assert.Len(t, billing.Constructs, 2)
+
+	totals["utl-file"] = model.TagTotal{Findings: 1, Effort: 8}
+	billing = model.EvaluateDatabaseCoupling("billing", slocs, totals, model.DatabaseConstructs)
+	assert.Equal(t, model.DB_COUPLING_HIGH, billing.Coupling, "blockers make the coupling high")
+	assert.True(t, billing.Constructs[2].Blocker)
+	assert.Equal(t, "file-access", billing.Constructs[2].Construct)
+}
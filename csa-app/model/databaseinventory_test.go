@@ -0,0 +1,85 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseConnectionOf(t *testing.T) {
+
+	connection := func(rule string, line string) model.DatabaseConnection {
+		return model.DatabaseConnectionOf(&model.Finding{Rule: rule, Value: line, Fqn: "/src/orders/jdbc.properties", Line: 3},
+			model.DatabaseVendors, model.DatabaseConnectionKinds)
+	}
+
+	assert.Equal(t, model.DatabaseConnection{Vendor: "oracle", Kind: "driver", Value: "oracle.jdbc.OracleDriver",
+		File: "/src/orders/jdbc.properties", Line: 3, Uses: 1}, connection("db-jdbc-driver", "jdbc.driverClassName=oracle.jdbc.OracleDriver"))
+
+	xa := connection("db-jdbc-driver", "<xa-datasource-class>com.ibm.db2.jcc.DB2XADataSource</xa-datasource-class>")
+	assert.Equal(t, "com.ibm.db2.jcc.DB2XADataSource", xa.Value)
+	assert.Equal(t, "db2", xa.Vendor)
+	assert.True(t, xa.XA)
+
+	url := connection("db-jdbc-url", "spring.datasource.url=jdbc:oracle:thin:scott/tiger@db.example.com:1521/ORDERS")
+	assert.Equal(t, "jdbc:oracle:thin:***/***@db.example.com:1521/ORDERS", url.Value, "credentials are masked")
+	assert.False(t, url.XA)
+
+	jtds := connection("db-jdbc-url", `<Resource name="jdbc/orders" url="jdbc:jtds:sybase://db:5000/orders;password=secret"/>`)
+	assert.Equal(t, "jdbc:jtds:sybase://db:5000/orders;password=***", jtds.Value)
+	assert.Equal(t, "sybase", jtds.Vendor, "jTDS urls are of the vendor they name")
+
+	assert.Equal(t, "org.hibernate.dialect.SQLServer2012Dialect",
+		connection("db-dialect", `<property name="hibernate.dialect" value="org.hibernate.dialect.SQLServer2012Dialect"/>`).Value)
+	target := connection("db-dialect", `<property name="eclipselink.target-database" value="DB2"/>`)
+	assert.Equal(t, "DB2", target.Value)
+	assert.Equal(t, "db2", target.Vendor)
+
+	web := connection("db-connection-string", `<add name="Orders" connectionString="Data Source=db;Initial Catalog=Orders;Password=secret" />`)
+	assert.Equal(t, "connection-string", web.Kind)
+	assert.Equal(t, "Data Source=db;Initial Catalog=Orders;Password=***", web.Value)
+	assert.Equal(t, "sqlserver", web.Vendor)
+
+	assert.Equal(t, model.DB_VENDOR_UNKNOWN, connection("db-connection-string", `"Orders": "Host=db;Database=orders;"`).Vendor)
+}
+
+func TestEvaluateDatabaseInventory(t *testing.T) {
+
+	findings := []model.Finding{
+		{Application: "orders", Fqn: "/src/orders/jdbc.properties", Line: 2, Rule: "db-jdbc-url",
+			Value: "orders.url=jdbc:postgresql://db:5432/orders"},
+		{Application: "orders", Fqn: "/src/orders/standalone.xml", Line: 12, Rule: "db-jdbc-driver",
+			Value: "<xa-datasource-class>oracle.jdbc.xa.client.OracleXADataSource</xa-datasource-class>"},
+		{Application: "orders", Fqn: "/src/orders/jdbc.properties", Line: 1, Rule: "db-jdbc-driver",
+			Value: "orders.driver=org.postgresql.Driver"},
+		{Application: "orders", Fqn: "/src/orders/Test.java", Line: 9, Rule: "db-jdbc-url",
+			Value: `String url = "jdbc:postgresql://db:5432/orders";`},
+		{Application: "billing", Fqn: "/src/billing/jdbc.properties", Line: 1, Rule: "db-jdbc-url",
+			Value: "billing.url=jdbc:db2://db:50000/BILLING"},
+	}
+
+	inventory := model.EvaluateDatabaseInventory("orders", findings, model.DatabaseVendors, model.DatabaseConnectionKinds)
+	assert.True(t, inventory.ConnectsToDatabase())
+	assert.Equal(t, "oracle", inventory.Primary, "commercial vendors come first")
+	assert.True(t, inventory.XA)
+
+	assert.Equal(t, []model.DatabaseVendorUsage{
+		{Vendor: "oracle", License: model.DB_LICENSE_COMMERCIAL, Connections: 1, Uses: 1, XA: true},
+		{Vendor: "postgresql", License: model.DB_LICENSE_OPEN_SOURCE, Connections: 2, Uses: 3},
+	}, inventory.Vendors)
+
+	assert.Len(t, inventory.Connections, 3)
+	assert.Equal(t, model.DatabaseConnection{Vendor: "postgresql", Kind: "url", Value: "jdbc:postgresql://db:5432/orders",
+		File: "/src/orders/Test.java", Line: 9, Uses: 2}, inventory.Connections[2], "listed once, where first named")
+
+	none := model.EvaluateDatabaseInventory("inventory", findings, model.DatabaseVendors, model.DatabaseConnectionKinds)
+	assert.False(t, none.ConnectsToDatabase())
+	assert.Equal(t, "", none.Primary)
+}
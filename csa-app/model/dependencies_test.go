@@ -0,0 +1,151 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dependenciesPom = `<project>
+  <parent>
+    <artifactId>company-parent</artifactId>
+  </parent>
+  <artifactId>orders-service</artifactId>
+  <dependencies>
+    <dependency>
+      <groupId>com.company</groupId>
+      <artifactId>billing-client</artifactId>
+      <version>1.4.0</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+func TestInterfaceNames(t *testing.T) {
+	assert.Equal(t, []string{"billing-client"}, model.InterfaceNames(model.DEPENDENCY_JAR, "Billing-Client-1.4.0-SNAPSHOT.jar"))
+	assert.Equal(t, []string{"inventory-svc", "stock"}, model.InterfaceNames(model.DEPENDENCY_HTTP, "http://inventory-svc.prod:8080/api/v1/stock/42"))
+	assert.Equal(t, []string{"stock"}, model.InterfaceNames(model.DEPENDENCY_HTTP, "http://localhost:8080/stock"))
+	assert.Equal(t, []string{"orders"}, model.InterfaceNames(model.DEPENDENCY_HTTP, "/orders/{id}"))
+	assert.Empty(t, model.InterfaceNames(model.DEPENDENCY_HTTP, "${billing.url}"), "placeholders can't be matched")
+	assert.Equal(t, []string{"sales"}, model.InterfaceNames(model.DEPENDENCY_DATABASE, "jdbc:postgresql://db:5432/sales?ssl=true"))
+	assert.Equal(t, []string{"orcl"}, model.InterfaceNames(model.DEPENDENCY_DATABASE, "jdbc:oracle:thin:@dbhost:1521:ORCL"))
+	assert.Equal(t, []string{"crm"}, model.InterfaceNames(model.DEPENDENCY_DATABASE, "jdbc:sqlserver://db:1433;databaseName=CRM"))
+	assert.Empty(t, model.InterfaceNames(model.DEPENDENCY_DATABASE, "jdbc:h2:mem:test"), "in memory databases aren't shared")
+	assert.Empty(t, model.InterfaceNames(model.DEPENDENCY_QUEUE, "true"))
+}
+
+func TestDetectInterfaces(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "dependencies")
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"pom.xml": dependenciesPom,
+		"src/main/java/OrderController.java": `@RequestMapping("/orders")
+class OrderController {
+  void placed() { jmsTemplate.convertAndSend("order.placed", order); }
+  String stock = "http://inventory-svc/stock";
+}`,
+		"src/main/kotlin/PaymentListener.kt": `@KafkaListener(topics = ["payments"])
+fun onPayment(event: PaymentEvent) {}`,
+		"src/main/resources/application.properties": "spring.datasource.url=jdbc:mysql://db:3306/sales\norders.queue=order.placed",
+		"lib/shipping-api-2.0.jar":                  "",
+	}
+
+	app := &model.Application{Name: "orders", Path: dir}
+	for name, content := range files {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		_ = ioutil.WriteFile(fqn, []byte(content), 0644)
+		file := &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn}
+		if filepath.Ext(name) == ".jar" {
+			file.ThirdParty = "lib"
+		}
+		app.Files = append(app.Files, file)
+	}
+
+	detected := make(map[string]string)
+	for _, appInterface := range model.DetectInterfaces(3, app, model.InterfaceExtractors) {
+		assert.Equal(t, uint(3), appInterface.RunID)
+		detected[appInterface.Kind+"/"+appInterface.Direction+"/"+appInterface.Name] = appInterface.Evidence
+	}
+
+	assert.Contains(t, detected, "jar/provides/orders-service")
+	assert.NotContains(t, detected, "jar/provides/company-parent", "the parent isn't the project")
+	assert.Contains(t, detected, "jar/consumes/billing-client")
+	assert.Contains(t, detected, "jar/consumes/shipping-api")
+	assert.Equal(t, "src/main/java/OrderController.java", detected["http/provides/orders"])
+	assert.Contains(t, detected, "http/consumes/inventory-svc")
+	assert.Contains(t, detected, "queue/provides/order.placed")
+	assert.Contains(t, detected, "queue/shares/order.placed")
+	assert.Equal(t, "src/main/kotlin/PaymentListener.kt", detected["queue/consumes/payments"], "kotlin sources are read like java's")
+	assert.Contains(t, detected, "database/shares/sales")
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+
+	interfaces := []model.AppInterface{
+		{Application: "billing", Kind: model.DEPENDENCY_JAR, Direction: model.INTERFACE_PROVIDES, Name: "billing-client", Evidence: "pom.xml"},
+		{Application: "orders", Kind: model.DEPENDENCY_JAR, Direction: model.INTERFACE_CONSUMES, Name: "billing-client", Evidence: "pom.xml"},
+		{Application: "inventory", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_PROVIDES, Name: "stock", Evidence: "Stock.java"},
+		{Application: "orders", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_CONSUMES, Name: "stock", Evidence: "Order.java"},
+		{Application: "billing", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_CONSUMES, Name: "stock", Evidence: "Bill.java"},
+		{Application: "orders", Kind: model.DEPENDENCY_DATABASE, Direction: model.INTERFACE_SHARES, Name: "sales", Evidence: "app.properties"},
+		{Application: "reports", Kind: model.DEPENDENCY_DATABASE, Direction: model.INTERFACE_SHARES, Name: "sales", Evidence: "app.yml"},
+		{Application: "reports", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_CONSUMES, Name: "reports", Evidence: "self.js"},
+		{Application: "reports", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_PROVIDES, Name: "reports", Evidence: "Report.java"},
+	}
+
+	graph := model.BuildDependencyGraph([]string{"billing", "inventory", "orders", "reports"}, interfaces)
+
+	assert.Equal(t, []model.DependencyEdge{
+		{From: "billing", To: "inventory", Kind: model.DEPENDENCY_HTTP, Names: []string{"stock"}, Evidence: []string{"billing: Bill.java", "inventory: Stock.java"}},
+		{From: "orders", To: "billing", Kind: model.DEPENDENCY_JAR, Names: []string{"billing-client"}, Evidence: []string{"billing: pom.xml", "orders: pom.xml"}},
+		{From: "orders", To: "inventory", Kind: model.DEPENDENCY_HTTP, Names: []string{"stock"}, Evidence: []string{"inventory: Stock.java", "orders: Order.java"}},
+		{From: "orders", To: "reports", Kind: model.DEPENDENCY_DATABASE, Shared: true, Names: []string{"sales"}, Evidence: []string{"orders: app.properties", "reports: app.yml"}},
+	}, graph.Edges, "no self edges")
+
+	waves := make(map[string]model.DependencyNode)
+	for _, node := range graph.Applications {
+		waves[node.Application] = node
+	}
+	assert.Equal(t, 1, waves["inventory"].Wave)
+	assert.Equal(t, 2, waves["billing"].Wave)
+	assert.Equal(t, 3, waves["orders"].Wave)
+	assert.Equal(t, 1, waves["reports"].Wave, "shared databases don't order waves")
+	assert.Equal(t, 2, waves["orders"].DependsOn)
+	assert.Equal(t, 2, waves["inventory"].Dependents)
+	assert.Equal(t, 1, waves["reports"].Shared)
+
+	var dot bytes.Buffer
+	assert.NoError(t, graph.WriteDot(&dot))
+	assert.Contains(t, dot.String(), `"orders" -> "reports" [label="database: sales", dir=none, style=dashed];`)
+}
+
+func TestDependencyWavesOfCycles(t *testing.T) {
+
+	interfaces := []model.AppInterface{
+		{Application: "a", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_CONSUMES, Name: "b"},
+		{Application: "b", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_PROVIDES, Name: "b"},
+		{Application: "b", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_CONSUMES, Name: "a"},
+		{Application: "a", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_PROVIDES, Name: "a"},
+		{Application: "c", Kind: model.DEPENDENCY_HTTP, Direction: model.INTERFACE_CONSUMES, Name: "a"},
+	}
+
+	graph := model.BuildDependencyGraph([]string{"a", "b", "c"}, interfaces)
+
+	assert.Equal(t, 1, graph.Applications[0].Wave)
+	assert.Equal(t, 1, graph.Applications[1].Wave, "circular dependencies share a wave")
+	assert.Equal(t, 2, graph.Applications[2].Wave)
+}
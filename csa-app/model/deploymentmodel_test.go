@@ -0,0 +1,31 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyDeployment(t *testing.T) {
+
+	boot := model.ClassifyDeployment("orders", model.TagTotals{"embedded-server": {Findings: 2}, "spring-boot": {Findings: 5}})
+	assert.Equal(t, model.DeploymentModel{Application: "orders", Model: model.DEPLOYMENT_EMBEDDED, Embedded: 2}, boot)
+
+	war := model.ClassifyDeployment("billing", model.TagTotals{"External-Server": {Findings: 1}, "app-server": {Findings: 3}})
+	assert.Equal(t, model.DEPLOYMENT_EXTERNAL, war.Model, "server descriptors count as external")
+	assert.Equal(t, 4, war.External)
+
+	bootWar := model.ClassifyDeployment("portal", model.TagTotals{"embedded-server": {Findings: 1}, "external-server": {Findings: 1}})
+	assert.Equal(t, model.DEPLOYMENT_HYBRID, bootWar.Model)
+
+	assert.Equal(t, model.DEPLOYMENT_UNKNOWN, model.ClassifyDeployment("lib", model.TagTotals{"spring-boot": {Findings: 1}}).Model,
+		"spring-boot tags alone aren't an embedded server")
+	assert.Equal(t, model.DEPLOYMENT_UNKNOWN, model.ClassifyDeployment("none", nil).Model)
+}
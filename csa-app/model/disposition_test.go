@@ -0,0 +1,114 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecommendDisposition(t *testing.T) {
+
+	rules := model.DefaultDispositionRules()
+	assert.NoError(t, rules.Validate())
+
+	recommend := func(facts *model.DispositionFacts) model.Disposition {
+		disposition, err := rules.Recommend(facts)
+		assert.NoError(t, err)
+		return disposition
+	}
+
+	ready := recommend(&model.DispositionFacts{Application: "api", Score: 9.2, BusinessValue: -1, Tech: []string{"Spring Boot"}})
+	assert.Equal(t, model.DISPOSITION_REHOST, ready.Disposition)
+	assert.Empty(t, ready.Factors)
+
+	server := recommend(&model.DispositionFacts{Application: "orders", Score: 8.5, BusinessValue: -1, Tech: []string{"Java EE", "WebLogic"}})
+	assert.Equal(t, model.DISPOSITION_REPLATFORM, server.Disposition)
+	assert.Contains(t, server.Factors, "tech=WebLogic")
+	assert.Contains(t, server.Factors, "score=8.50")
+
+	stateful := recommend(&model.DispositionFacts{Application: "cart", Score: 6, Stateful: true, Blockers: 2, BusinessValue: -1})
+	assert.Equal(t, model.DISPOSITION_REFACTOR, stateful.Disposition)
+	assert.Contains(t, stateful.Factors, "stateful=true")
+	assert.Contains(t, stateful.Factors, "blockers=2")
+
+	blocked := recommend(&model.DispositionFacts{Application: "mainframe-gw", Score: 1.5, Critical: 3, BusinessValue: -1})
+	assert.Equal(t, model.DISPOSITION_RETAIN, blocked.Disposition)
+
+	unused := recommend(&model.DispositionFacts{Application: "reports", Score: 4, BusinessValue: 1})
+	assert.Equal(t, model.DISPOSITION_RETIRE, unused.Disposition)
+	assert.Contains(t, unused.Factors, "business_value=1.00")
+}
+
+func TestDispositionFacts(t *testing.T) {
+
+	app := &model.Application{Name: "cart", Score: 6.5, NumCrits: 4, BusinessValue: -1}
+	tech := []model.TechAttribute{
+		{Application: "cart", Category: model.TECH_SERVER, Name: "Tomcat"},
+		{Application: "cart", Category: model.TECH_FRAMEWORK, Name: "Spring"},
+		{Application: "other", Category: model.TECH_SERVER, Name: "WebLogic"},
+	}
+	tags := model.TagTotals{"Session": {Findings: 3, Effort: 30}, "ejb": {Findings: 1, Effort: 10}}
+
+	facts := model.NewDispositionFacts(app, tech, tags)
+
+	assert.Equal(t, []string{"Spring", "Tomcat"}, facts.Tech)
+	assert.True(t, facts.Stateful, "session state fails the processes factor")
+	assert.Equal(t, 4, facts.Blockers)
+	assert.True(t, facts.ContainerScore < 10, "the ejb findings hint at a slow startup")
+
+	rules := &model.DispositionRules{Name: "tags", Rules: []model.DispositionRule{
+		{Disposition: model.DISPOSITION_REFACTOR, When: "tag('ejb') > 0"},
+		{Disposition: model.DISPOSITION_REHOST, When: "true"},
+	}}
+	assert.NoError(t, rules.Validate())
+
+	disposition, err := rules.Recommend(facts)
+	assert.NoError(t, err)
+	assert.Equal(t, model.DISPOSITION_REFACTOR, disposition.Disposition)
+	assert.Equal(t, []string{"tag ejb=1"}, disposition.Factors)
+}
+
+func TestLoadDispositionRules(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "dispositions")
+	defer os.RemoveAll(dir)
+
+	write := func(name string, content string) string {
+		file := filepath.Join(dir, name)
+		_ = ioutil.WriteFile(file, []byte(content), 0644)
+		return file
+	}
+
+	rules, err := model.LoadDispositionRules("")
+	assert.NoError(t, err)
+	assert.Equal(t, model.DEFAULT_DISPOSITION_RULES, rules.Name)
+
+	rules, err = model.LoadDispositionRules(write("cloud-first.yaml", `name: cloud-first
+rules:
+  - disposition: refactor
+    when: score < 6
+  - disposition: rehost
+    when: "true"
+`))
+	assert.NoError(t, err)
+	assert.Len(t, rules.Rules, 2)
+
+	_, err = model.LoadDispositionRules(write("typo.yaml", "name: typo\nrules:\n  - disposition: rebuild\n    when: \"true\"\n"))
+	assert.Error(t, err, "rebuild is not one of the 6Rs")
+
+	_, err = model.LoadDispositionRules(write("unknown.yaml", "name: unknown\nrules:\n  - disposition: rehost\n    when: scroe > 7\n"))
+	assert.Error(t, err, "unknown variable")
+
+	_, err = model.LoadDispositionRules(write("empty.yaml", "name: empty\n"))
+	assert.Error(t, err)
+}
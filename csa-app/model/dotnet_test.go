@@ -0,0 +1,101 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDotnetProjects(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "dotnet")
+	defer os.RemoveAll(dir)
+
+	app := &model.Application{Name: "orders", Path: dir}
+	for name, content := range map[string]string{
+		"Orders.Web/Orders.Web.csproj": `<?xml version="1.0" encoding="utf-8"?>
+<Project ToolsVersion="15.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <TargetFrameworkVersion>v4.7.2</TargetFrameworkVersion>
+  </PropertyGroup>
+</Project>`,
+		"Orders.Core/Orders.Core.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFrameworks>net48;netstandard2.0</TargetFrameworks>
+  </PropertyGroup>
+  <PropertyGroup Condition="'$(Modern)' == 'true'">
+    <TargetFrameworks>net8.0;netstandard2.0</TargetFrameworks>
+  </PropertyGroup>
+</Project>`,
+		"Orders.Core/Orders.cs": "namespace Orders {}",
+	} {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		assert.NoError(t, ioutil.WriteFile(fqn, []byte(content), 0644))
+		app.Files = append(app.Files, &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn})
+	}
+
+	projects := model.DetectDotnetProjects(3, app)
+	assert.Len(t, projects, 2)
+
+	assert.Equal(t, "Orders.Core/Orders.Core.csproj", projects[0].Project)
+	assert.Equal(t, "Microsoft.NET.Sdk", projects[0].Sdk)
+	assert.Equal(t, []string{"net48", "netstandard2.0", "net8.0"}, projects[0].Frameworks(), "each framework once")
+
+	assert.Equal(t, "Orders.Web/Orders.Web.csproj", projects[1].Project)
+	assert.Equal(t, "", projects[1].Sdk, "legacy projects have no sdk")
+	assert.Equal(t, "net472", projects[1].TargetFrameworks)
+}
+
+func TestIsDotnetFramework(t *testing.T) {
+	for framework, expected := range map[string]bool{"net48": true, "net472": true, "net35": true, "NET40": true,
+		"net8.0": false, "net6.0-windows": false, "netcoreapp3.1": false, "netstandard2.0": false} {
+		assert.Equal(t, expected, model.IsDotnetFramework(framework), framework)
+	}
+}
+
+func TestEvaluateDotnetMigration(t *testing.T) {
+
+	projects := []model.DotnetProject{
+		{Application: "orders", Project: "Orders.Web/Orders.Web.csproj", TargetFrameworks: "net472"},
+		{Application: "orders", Project: "Orders.Core/Orders.Core.csproj", Sdk: "Microsoft.NET.Sdk", TargetFrameworks: "net8.0;netstandard2.0"},
+		{Application: "billing", Project: "Billing.csproj", Sdk: "Microsoft.NET.Sdk", TargetFrameworks: "net8.0"},
+	}
+	totals := model.TagTotals{
+		"asp-web-form":   {Findings: 4, Effort: 40},
+		"Windows-WCF":    {Findings: 2, Effort: 0},
+		"system-drawing": {Findings: 1, Effort: 100},
+		"java":           {Findings: 9, Effort: 9},
+	}
+
+	migration := model.EvaluateDotnetMigration("orders", projects, totals, model.DotnetBlockers)
+	assert.Equal(t, 2, migration.Projects)
+	assert.Equal(t, 1, migration.LegacyProjects)
+	assert.Equal(t, []string{"net472", "net8.0", "netstandard2.0"}, migration.TargetFrameworks)
+	assert.True(t, migration.Framework)
+	assert.Equal(t, 7, migration.Findings)
+	assert.Equal(t, 140, migration.Effort)
+
+	blockers := make(map[string]model.DotnetBlockerResult)
+	for _, blocker := range migration.Blockers {
+		blockers[blocker.Blocker] = blocker
+	}
+	assert.Len(t, blockers, 3, "only blockers with findings")
+	assert.Equal(t, []string{"windows-wcf"}, blockers["wcf"].Tags, "tags are matched regardless of case")
+	assert.Equal(t, "ImageSharp or SkiaSharp", blockers["system-drawing"].Replacement)
+
+	billing := model.EvaluateDotnetMigration("billing", projects, nil, model.DotnetBlockers)
+	assert.False(t, billing.Framework)
+	assert.Empty(t, billing.Blockers)
+}
@@ -0,0 +1,52 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	findings := []model.Finding{
+		{Application: "billing", Rule: "java-file-io", Filename: "FileUtil.java", Value: "new FileWriter(path)", Effort: 5},
+		{Application: "billing", Rule: "java-file-io", Filename: "FileUtil.java", Value: "new FileWriter(path)", Effort: 5},
+		{Application: "orders", Rule: "java-file-io", Filename: "FileUtil.java", Value: "  new FileWriter(path)", Effort: 5},
+		{Application: "orders", Rule: "java-file-io", Filename: "FileUtil.java", Value: "new FileWriter(path)", Effort: 5},
+		{Application: "claims", Rule: "java-file-io", Filename: "FileUtil.java", Value: "new FileWriter(path)", Effort: 5},
+		{Application: "claims", Rule: "java-jndi", Filename: "Locator.java", Value: "ctx.lookup(name)", Effort: 7},
+		{Application: "orders", Rule: "java-jndi", Filename: "Locator.java", Value: "ctx.lookup(name)", Effort: 7},
+		{Application: "orders", Rule: "java-jndi", Filename: "Dao.java", Value: "ctx.lookup(name)", Effort: 7},
+	}
+
+	duplicates, summary := model.FindDuplicates(findings, 2)
+
+	assert.Len(t, duplicates, 2)
+
+	assert.Equal(t, "java-file-io", duplicates[0].Rule, "most effort saved first")
+	assert.Equal(t, []string{"billing", "claims", "orders"}, duplicates[0].Applications)
+	assert.Equal(t, 5, duplicates[0].Findings, "surrounding whitespace is ignored")
+	assert.Equal(t, 25, duplicates[0].Effort)
+	assert.Equal(t, 10, duplicates[0].DedupedEffort, "fixed once, it takes the effort of the app with the most copies")
+
+	assert.Equal(t, "Locator.java", duplicates[1].Filename)
+	assert.Equal(t, 7, duplicates[1].DedupedEffort)
+
+	assert.Equal(t, 8, summary.Findings)
+	assert.Equal(t, 46, summary.Effort)
+	assert.Equal(t, 2, summary.Duplicates)
+	assert.Equal(t, 7, summary.DuplicateFindings)
+	assert.Equal(t, 39, summary.DuplicateEffort)
+	assert.Equal(t, 24, summary.DedupedEffort)
+	assert.Equal(t, 22, summary.Saved())
+
+	duplicates, summary = model.FindDuplicates(findings, 3)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, 31, summary.DedupedEffort)
+}
@@ -0,0 +1,51 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffortScalePresets(t *testing.T) {
+	for name, scale := range model.PresetEffortScales() {
+		assert.NoError(t, scale.Validate(), name)
+	}
+
+	fibonacci, err := model.LoadEffortScale("Fibonacci")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", fibonacci.Convert(1))
+	assert.Equal(t, "5", fibonacci.Convert(4))
+	assert.Equal(t, "21", fibonacci.Convert(12))
+	assert.Equal(t, "34", fibonacci.Convert(100))
+	assert.Equal(t, "story points", fibonacci.Label())
+	assert.Equal(t, "29", fibonacci.Total(map[int]int{1: 3, 5: 1, 12: 1}))
+
+	tshirt, err := model.LoadEffortScale(model.TSHIRT_EFFORT_SCALE)
+	assert.NoError(t, err)
+	assert.Equal(t, "M", tshirt.Convert(5))
+	assert.Equal(t, "XS:2 M:1 XL:1", tshirt.Total(map[int]int{1: 2, 5: 1, 12: 1}))
+
+	_, err = model.LoadEffortScale("no-such-scale")
+	assert.Error(t, err)
+}
+
+func TestEffortScaleValidation(t *testing.T) {
+	overlapping := &model.EffortScale{Name: "custom", Bands: []*model.EffortBand{
+		{Start: 1, End: 5, Value: "small"},
+		{Start: 5, End: 10, Value: "large"}}}
+	assert.Error(t, overlapping.Validate())
+
+	backwards := &model.EffortScale{Name: "custom", Bands: []*model.EffortBand{{Start: 5, End: 1, Value: "small"}}}
+	assert.Error(t, backwards.Validate())
+
+	gaps := &model.EffortScale{Name: "custom", Bands: []*model.EffortBand{{Start: 1, End: 3, Value: "small"}}}
+	assert.NoError(t, gaps.Validate())
+	assert.Equal(t, "7", gaps.Convert(7))
+}
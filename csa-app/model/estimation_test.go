@@ -0,0 +1,66 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate(t *testing.T) {
+
+	estimationModel := &model.EstimationModel{Name: "engagement", Currency: "EUR", DayRate: 800,
+		Default:    model.ProductivityFactor{MinDays: 0.1, MaxDays: 0.2},
+		Categories: map[string]model.ProductivityFactor{"Logging": {MinDays: 0.01, MaxDays: 0.05}}}
+	assert.NoError(t, estimationModel.Validate())
+
+	estimates := estimationModel.Estimate("orders", map[string]int{"logging": 20, "ejb": 10})
+	assert.Len(t, estimates, 3)
+
+	ejb, logging, total := estimates[0], estimates[1], estimates[2]
+	assert.Equal(t, "ejb", ejb.Category)
+	assert.InDelta(t, 1.0, ejb.MinDays, 0.0001)
+	assert.InDelta(t, 2.0, ejb.MaxDays, 0.0001)
+	assert.InDelta(t, 1600, ejb.MaxCost, 0.0001)
+
+	assert.InDelta(t, 0.2, logging.MinDays, 0.0001, "categories are matched regardless of case")
+	assert.InDelta(t, 1.0, logging.MaxDays, 0.0001)
+
+	assert.Equal(t, "orders", total.Application)
+	assert.Equal(t, "", total.Category)
+	assert.Equal(t, 30, total.Effort)
+	assert.InDelta(t, 1.2, total.MinDays, 0.0001)
+	assert.InDelta(t, 2400, total.MaxCost, 0.0001)
+
+	none := model.DefaultEstimationModel().Estimate("empty", nil)
+	assert.Equal(t, []model.Estimate{{Application: "empty"}}, none)
+}
+
+func TestLoadEstimationModel(t *testing.T) {
+
+	defaultModel, err := model.LoadEstimationModel("")
+	assert.NoError(t, err)
+	assert.Equal(t, model.DEFAULT_ESTIMATION_MODEL, defaultModel.Name)
+
+	dir, _ := ioutil.TempDir("", "estimation")
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "model.json")
+	_ = ioutil.WriteFile(file, []byte(`{"name": "bad", "default": {"minDays": 0.5, "maxDays": 0.25}}`), 0644)
+	_, err = model.LoadEstimationModel(file)
+	assert.Error(t, err, "min days above max days")
+
+	_ = ioutil.WriteFile(file, []byte(`{"name": "good", "dayRate": 1000, "default": {"minDays": 0.25, "maxDays": 0.5}}`), 0644)
+	loaded, err := model.LoadEstimationModel(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, loaded.DayRate)
+}
@@ -0,0 +1,93 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleAppliesToPath(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "conditions")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(relPath string, size int) string {
+		fqn := filepath.Join(dir, filepath.FromSlash(relPath))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(fqn), 0755))
+		assert.NoError(t, ioutil.WriteFile(fqn, make([]byte, size), 0644))
+		return fqn
+	}
+
+	main := write("src/main/java/com/acme/Orders.java", 100)
+	generated := write("src/main/java/generated/OrdersStub.java", 100)
+	test := write("src/test/java/OrdersTest.java", 100)
+	build := write("target/classes/Orders.java", 100)
+	large := write("src/main/java/Large.java", 5000)
+
+	rule := model.Rule{Paths: model.Globs{"src/main/"}, ExcludePaths: model.Globs{"generated/", "target/"}}
+	assert.True(t, rule.AppliesToPath(dir, main))
+	assert.False(t, rule.AppliesToPath(dir, generated), "excluded at any depth")
+	assert.False(t, rule.AppliesToPath(dir, test), "outside the included paths")
+	assert.False(t, rule.AppliesToPath(dir, build))
+
+	rule = model.Rule{Paths: model.Globs{"**/*.java"}, MaxSize: 1000}
+	assert.True(t, rule.AppliesToPath(dir, main))
+	assert.False(t, rule.AppliesToPath(dir, large), "larger than the max size")
+
+	rule = model.Rule{MinSize: 1000}
+	assert.False(t, rule.AppliesToPath(dir, main), "smaller than the min size")
+	assert.True(t, rule.AppliesToPath(dir, large))
+
+	rule = model.Rule{MaxDepth: 3}
+	assert.True(t, rule.AppliesToPath(dir, large), "src/main/java is 3 directories deep")
+	assert.False(t, rule.AppliesToPath(dir, main))
+
+	rule = model.Rule{}
+	assert.True(t, rule.AppliesToPath(dir, filepath.Join(dir, "missing.java")), "no conditions, no stat")
+}
+
+func TestRuleFileConditionsValidation(t *testing.T) {
+
+	r := getValidRule()
+	r.Paths = model.Globs{"src/main/", "**/*.java"}
+	r.ExcludePaths = model.Globs{"generated/"}
+	r.MinSize, r.MaxSize, r.MaxDepth = 10, 1000, 4
+	if valid, err := r.IsValid(); !valid {
+		t.Errorf("Rule with file conditions should be valid! Details: %v", err)
+	}
+
+	r.Paths = model.Globs{"src/[main/"}
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Path pattern %v should fail validation but passed!", r.Paths)
+	}
+
+	r.Paths = nil
+	r.MinSize = 2000
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("MinSize larger than MaxSize should fail validation but passed!")
+	}
+}
+
+func TestGlobsColumn(t *testing.T) {
+
+	value, err := model.Globs{"src/main/", "**/*.java"}.Value()
+	assert.NoError(t, err)
+
+	var globs model.Globs
+	assert.NoError(t, globs.Scan(value))
+	assert.Equal(t, model.Globs{"src/main/", "**/*.java"}, globs)
+
+	assert.NoError(t, globs.Scan(nil))
+	assert.Empty(t, globs)
+}
@@ -0,0 +1,35 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingShaHashesTheFullLine(t *testing.T) {
+
+	prefix := strings.Repeat("x", model.FINDING_VAL_LEN)
+
+	first := &model.Finding{LineSha: model.HashLine(prefix + "first")}
+	first.SetValue(prefix + "first")
+	second := &model.Finding{LineSha: model.HashLine(prefix + "second")}
+	second.SetValue(prefix + "second")
+
+	assert.Equal(t, first.Value, second.Value, "values are truncated alike")
+	assert.NotEqual(t, first.Sha(), second.Sha())
+	assert.Equal(t, model.HashLine(prefix+"first"), first.CreateDTO().Sha)
+}
+
+func TestFindingShaFallsBackToTheValue(t *testing.T) {
+
+	finding := &model.Finding{Value: "new InitialContext()"}
+	assert.Equal(t, model.HashLine("new InitialContext()"), finding.Sha())
+}
@@ -0,0 +1,50 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFindings(t *testing.T) {
+	previous := []model.Finding{
+		{ID: 1, Rule: "java-file-io", Fqn: "/scans/old/app/src/Main.java", Line: 10, Value: "new File(path)"},
+		{ID: 2, Rule: "java-file-io", Fqn: "/scans/old/app/src/Main.java", Line: 80, Value: "new FileReader(path)"},
+		{ID: 3, Rule: "java-jni", Fqn: "/scans/old/app/src/Native.java", Line: 5, Value: "native void load()"},
+	}
+
+	current := []model.Finding{
+		//drifted a few lines
+		{ID: 11, Rule: "java-file-io", Fqn: "/scans/new/app/src/Main.java", Line: 14, Value: "new File(path)"},
+		//same value moved far away
+		{ID: 12, Rule: "java-file-io", Fqn: "/scans/new/app/src/Main.java", Line: 200, Value: "new FileReader(path)"},
+		//new usage in a new file
+		{ID: 13, Rule: "java-file-io", Fqn: "/scans/new/app/src/Other.java", Line: 10, Value: "new File(path)"},
+	}
+
+	match := model.MatchFindings(previous, "/scans/old/app", current, "/scans/new/app", 10)
+
+	assert.Equal(t, map[uint]uint{11: 1, 12: 2}, match.Previous)
+	assert.Equal(t, 1, len(match.Resolved))
+	assert.Equal(t, uint(3), match.Resolved[0].ID)
+}
+
+func TestMatchFindingsLineTolerance(t *testing.T) {
+	previous := []model.Finding{{ID: 1, Rule: "rule", Fqn: "/app/Main.java", Line: 10, Value: "old"}}
+	current := []model.Finding{{ID: 2, Rule: "rule", Fqn: "/app/Main.java", Line: 30, Value: "new"}}
+
+	match := model.MatchFindings(previous, "/app", current, "/app", 10)
+	assert.Empty(t, match.Previous)
+	assert.Equal(t, 1, len(match.Resolved))
+
+	match = model.MatchFindings(previous, "/app", current, "/app", 20)
+	assert.Equal(t, map[uint]uint{2: 1}, match.Previous)
+	assert.Empty(t, match.Resolved)
+}
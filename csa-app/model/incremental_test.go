@@ -0,0 +1,77 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncrementalBaseline(t *testing.T) {
+
+	manifest := []model.ManifestEntry{
+		{RunID: 4, Path: "src/Orders.java", Size: 120, Sha256: "aaa", RulesApplied: 12},
+		{RunID: 4, Path: "src/Billing.java", Size: 80, Sha256: "bbb"},
+		{RunID: 4, Path: "secrets.jks", Size: 10, Sha256: "ccc", Inaccessible: "encrypted"},
+	}
+
+	findings := []model.Finding{
+		{ID: 7, RunID: 4, Fqn: "/apps/orders/src/Orders.java", Rule: "java-jni", Effort: 100, Lifecycle: model.FINDING_RECURRING, PreviousID: 3,
+			Tags: []model.FindingTag{{ID: 9, FindingID: 7, Value: "jni"}}, Recipes: []model.FindingRecipe{{ID: 2, FindingID: 7, URI: "recipe:jni"}}},
+		{ID: 8, RunID: 4, Fqn: "/apps/orders/src/Orders.java", Rule: "jni-and-threads", Effort: 10},
+		{ID: 10, RunID: 4, Fqn: "/apps/orders/src/Billing.java", Rule: "java-file-io", Effort: 5},
+	}
+
+	rules := []model.Rule{
+		{Name: "java-jni", Impact: model.APP_IMPACT},
+		{Name: "jni-and-threads", Type: model.COMPOSITE_MATCH_TYPE},
+		{Name: "java-file-io"},
+	}
+
+	baseline := model.NewIncrementalBaseline(4, manifest, findings, rules)
+
+	previous, unchanged := baseline.Unchanged(&model.ManifestEntry{Path: "src/Orders.java", Size: 120, Sha256: "aaa"})
+	assert.True(t, unchanged)
+	assert.Equal(t, 12, previous.RulesApplied)
+
+	_, unchanged = baseline.Unchanged(&model.ManifestEntry{Path: "src/Billing.java", Size: 80, Sha256: "bbd"})
+	assert.False(t, unchanged, "content changed")
+	_, unchanged = baseline.Unchanged(&model.ManifestEntry{Path: "src/Shipping.java", Size: 80, Sha256: "bbb"})
+	assert.False(t, unchanged, "new file")
+	_, unchanged = baseline.Unchanged(&model.ManifestEntry{Path: "secrets.jks", Size: 10, Sha256: "ccc"})
+	assert.False(t, unchanged, "files that couldn't be read are analyzed again")
+
+	carried := baseline.CarryForward(5, "/apps/orders/src/Orders.java")
+	assert.Equal(t, []model.Finding{
+		{RunID: 5, Fqn: "/apps/orders/src/Orders.java", Rule: "java-jni", Effort: 100,
+			Tags: []model.FindingTag{{Value: "jni"}}, Recipes: []model.FindingRecipe{{URI: "recipe:jni"}}},
+	}, carried, "new findings of the run without the composite rule's")
+	assert.Equal(t, uint(7), findings[0].ID, "the baseline's findings aren't modified")
+
+	assert.Empty(t, baseline.CarryForward(5, "/apps/orders/src/Shipping.java"))
+	assert.True(t, baseline.ImpactsApp("java-jni"))
+	assert.False(t, baseline.ImpactsApp("java-file-io"))
+}
+
+func TestRulesFingerprint(t *testing.T) {
+
+	run := &model.Run{}
+	rules := []model.Rule{{Name: "java-jni", Effort: 100}, {Name: "java-file-io", Effort: 5}}
+
+	fingerprint := model.RulesFingerprint(run, rules, "low")
+	assert.Equal(t, fingerprint, model.RulesFingerprint(run, []model.Rule{rules[1], rules[0]}, "low"), "rules are fingerprinted by name")
+	assert.Empty(t, model.FingerprintChanges(fingerprint, fingerprint))
+
+	rules[0].Effort = 50
+	run.TagWeights = `{"name":"jni","weights":{"jni":3}}`
+	assert.Equal(t, []string{"rules", "tag-weights", "min-confidence"}, model.FingerprintChanges(fingerprint, model.RulesFingerprint(run, rules, "high")))
+
+	run.Profile = &model.RuleProfile{Name: "tas"}
+	assert.Equal(t, []string{"profile"}, model.FingerprintChanges(model.RulesFingerprint(&model.Run{TagWeights: run.TagWeights}, rules, "low"), model.RulesFingerprint(run, rules, "low")))
+}
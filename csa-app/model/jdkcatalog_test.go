@@ -0,0 +1,90 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"regexp"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJdkRules(t *testing.T) {
+
+	rules := make(map[string]*model.Rule)
+	generated := model.JdkRules(17)
+	for i := range generated {
+		rules[generated[i].Name] = &generated[i]
+	}
+
+	assert.Equal(t, model.JDK_REMOVED_CATEGORY, rules["jdk-jaxb"].Category)
+	assert.Equal(t, "high", rules["jdk-jaxb"].Severity)
+	assert.Equal(t, model.JDK_REMOVED_CATEGORY, rules["jdk-nashorn"].Category)
+	assert.Equal(t, model.JDK_REMOVED_CATEGORY, rules["jdk-internals"].Category)
+	assert.Equal(t, model.JDK_DEPRECATED_CATEGORY, rules["jdk-security-manager"].Category, "removed after the target")
+	assert.Equal(t, "low", rules["jdk-security-manager"].Severity)
+	assert.Contains(t, rules["jdk-security-manager"].Advice, "deprecated since JDK 17 and removed in JDK 24")
+	assert.Contains(t, rules["jdk-thread-stop"].Advice, "deprecated since JDK 1.2")
+
+	var names []string
+	generated = model.JdkRules(11)
+	for i := range generated {
+		names = append(names, generated[i].Name)
+	}
+	assert.Contains(t, names, "jdk-nashorn", "deprecated by 11")
+	assert.NotContains(t, names, "jdk-rmi-activation", "not deprecated yet")
+
+	assert.Empty(t, model.JdkRules(0))
+	assert.Error(t, model.ValidateTargetJdk(7))
+	assert.NoError(t, model.ValidateTargetJdk(21))
+}
+
+func TestJdkApiPatterns(t *testing.T) {
+
+	matches := func(api string, line string) bool {
+		return regexp.MustCompile(model.JdkApiByRule("jdk-" + api).Pattern).MatchString(line)
+	}
+
+	assert.True(t, matches("jaxb", "import javax.xml.bind.JAXBContext;"))
+	assert.True(t, matches("nashorn", `ScriptEngine engine = manager.getEngineByName("nashorn");`))
+	assert.True(t, matches("internals", "import sun.security.x509.X500Name;"))
+	assert.False(t, matches("internals", "import sun.misc.Unsafe;"))
+	assert.True(t, matches("sun-misc-base64", "new sun.misc.BASE64Encoder().encode(bytes)"))
+	assert.True(t, matches("common-annotations", "import javax.annotation.PostConstruct;"))
+	assert.False(t, matches("common-annotations", "import javax.annotation.Nullable;"))
+	assert.True(t, matches("wrapper-constructors", "Integer count = new Integer(5);"))
+	assert.Nil(t, model.JdkApiByRule("java-jakarta-namespace"))
+}
+
+func TestSummarizeJdkUsage(t *testing.T) {
+
+	findings := []model.Finding{
+		{Application: "orders", Rule: "jdk-jaxb", Fqn: "A.java", Effort: 20},
+		{Application: "orders", Rule: "jdk-jaxb", Fqn: "A.java", Effort: 20},
+		{Application: "orders", Rule: "jdk-jaxb", Fqn: "B.java", Effort: 20},
+		{Application: "orders", Rule: "jdk-finalize", Fqn: "C.java", Effort: 1},
+		{Application: "billing", Rule: "jdk-nashorn", Fqn: "D.java", Effort: 20},
+		{Application: "billing", Rule: "java-jakarta-namespace", Fqn: "E.java", Effort: 1},
+	}
+
+	usages := model.SummarizeJdkUsage(findings, 17)
+
+	assert.Len(t, usages, 3)
+	assert.Equal(t, "billing", usages[0].Application)
+	assert.Equal(t, "nashorn", usages[0].Api)
+	assert.Equal(t, 15, usages[0].Release)
+
+	assert.Equal(t, "jaxb", usages[1].Api)
+	assert.Equal(t, "removed", usages[1].Status)
+	assert.Equal(t, 3, usages[1].Findings)
+	assert.Equal(t, 2, usages[1].Files)
+	assert.Equal(t, 60, usages[1].Effort)
+
+	assert.Equal(t, "finalize", usages[2].Api)
+	assert.Equal(t, "deprecated", usages[2].Status)
+}
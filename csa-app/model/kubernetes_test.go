@@ -0,0 +1,49 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupKubernetesFindings(t *testing.T) {
+
+	assert.Empty(t, model.GroupKubernetesFindings(nil, model.KubernetesIssues))
+
+	findings := []model.Finding{
+		{Application: "orders", Fqn: "/src/orders/k8s/deployment.yaml", Rule: "k8s-image-registry", Line: 21, Effort: 3},
+		{Application: "orders", Fqn: "/src/orders/k8s/deployment.yaml", Rule: "k8s-privileged", Line: 30, Effort: 8},
+		{Application: "orders", Fqn: "/src/orders/k8s/deployment.yaml", Rule: "k8s-privileged", Line: 12, Effort: 3},
+		{Application: "orders", Fqn: "/src/orders/k8s/deployment.yaml", Rule: "k8s-resource-limits", Effort: 3},
+		{Application: "orders", Fqn: "/src/orders/chart/values.yaml", Rule: "k8s-image-registry", Line: 4, Effort: 3},
+		{Application: "billing", Fqn: "/src/billing/pod.yaml", Rule: "custom-k8s-rule", Line: 7, Effort: 20},
+	}
+
+	manifests := model.GroupKubernetesFindings(findings, model.KubernetesIssues)
+	assert.Len(t, manifests, 3)
+
+	assert.Equal(t, "billing", manifests[0].Application, "the most effort first")
+	assert.Equal(t, model.KUBERNETES_OTHER_ISSUE, manifests[0].Issues[0].Issue, "rules of no issue are grouped apart")
+
+	deployment := manifests[1]
+	assert.Equal(t, "/src/orders/k8s/deployment.yaml", deployment.Manifest)
+	assert.Equal(t, 4, deployment.Findings)
+	assert.Equal(t, 17, deployment.Effort)
+
+	var issues []string
+	for _, issue := range deployment.Issues {
+		issues = append(issues, issue.Issue)
+	}
+	assert.Equal(t, []string{"resource-limits", "privileged", "image-registry"}, issues, "issues in catalog order")
+	assert.Equal(t, []int{12, 30}, deployment.Issues[1].Lines)
+	assert.Empty(t, deployment.Issues[0].Lines, "contents findings have no line")
+
+	assert.Equal(t, "/src/orders/chart/values.yaml", manifests[2].Manifest)
+}
@@ -0,0 +1,403 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//detectTestLibraries detects the libraries of an application made of the files
+func detectTestLibraries(t *testing.T, files map[string]string) map[string]*model.AppLibrary {
+
+	dir, _ := ioutil.TempDir("", "libraries")
+	defer os.RemoveAll(dir)
+
+	app := &model.Application{Name: "orders", Path: dir}
+	for name, content := range files {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		assert.NoError(t, ioutil.WriteFile(fqn, []byte(content), 0644))
+		app.Files = append(app.Files, &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn})
+	}
+
+	libraries := make(map[string]*model.AppLibrary)
+	for _, library := range model.DetectLibraries(7, app, model.LibraryParsers) {
+		assert.Equal(t, uint(7), library.RunID)
+		assert.Equal(t, "orders", library.Application)
+		libraries[library.Ecosystem+"/"+library.Name] = library
+	}
+	return libraries
+}
+
+func TestDetectPythonRequirements(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"requirements.txt": `# web
+Django==4.2.7
+requests[socks] >= 2.31, < 3  # http
+psycopg2-binary==2.9.9 ; python_version >= "3.8"
+-r requirements-dev.txt
+-e git+https://github.com/acme/billing.git#egg=billing
+./vendor/legacy
+Flask_Login
+`,
+		"requirements-dev.txt": "pytest==7.4.3\n",
+	})
+
+	assert.Len(t, libraries, 5)
+	assert.Equal(t, "4.2.7", libraries["pypi/django"].Version, "names are normalized")
+	assert.Equal(t, "requirements.txt", libraries["pypi/django"].Evidence)
+	assert.Equal(t, ">=2.31,<3", libraries["pypi/requests"].Version)
+	assert.Equal(t, "2.9.9", libraries["pypi/psycopg2-binary"].Version, "markers are dropped")
+	assert.Equal(t, "", libraries["pypi/flask-login"].Version)
+	assert.Equal(t, "7.4.3", libraries["pypi/pytest"].Version)
+
+	assert.Equal(t, "pkg:pypi/django@4.2.7", libraries["pypi/django"].PackageURL())
+	assert.Equal(t, "pkg:pypi/requests", libraries["pypi/requests"].PackageURL(), "ranges aren't versions")
+}
+
+func TestDetectPoetryLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"pyproject.toml": `[tool.poetry]
+name = "orders"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+flask = "^3.0"
+SQLAlchemy = { version = "^2.0", extras = ["asyncio"] }
+
+[tool.poetry.group.test.dependencies]
+pytest = "^7.4"
+`,
+		"poetry.lock": `[[package]]
+name = "flask"
+version = "3.0.0"
+description = "A simple framework for building complex web applications."
+optional = false
+
+[package.dependencies]
+Werkzeug = ">=3.0.0"
+
+[[package]]
+name = "pytest"
+version = "7.4.3"
+category = "dev"
+`,
+	})
+
+	assert.Len(t, libraries, 3, "python isn't a library")
+	assert.Equal(t, "3.0.0", libraries["pypi/flask"].Version, "lock files pin versions")
+	assert.Equal(t, "poetry.lock", libraries["pypi/flask"].Evidence)
+	assert.False(t, libraries["pypi/flask"].Dev)
+	assert.Equal(t, "^2.0", libraries["pypi/sqlalchemy"].Version)
+	assert.True(t, libraries["pypi/pytest"].Dev)
+}
+
+func TestDetectPep621AndPipenvLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"pyproject.toml": `[project]
+name = "billing"
+dependencies = [
+    "celery[redis]>=5.3",
+    'boto3',
+]
+
+[project.optional-dependencies]
+docs = ["sphinx==7.2.6"]
+`,
+		"Pipfile": `[packages]
+gunicorn = "*"
+redis = {version = ">=5.0"}
+
+[dev-packages]
+black = "*"
+`,
+		"Pipfile.lock": `{"_meta": {"pipfile-spec": 6, "sources": [{"name": "pypi"}]}, "default": {"gunicorn": {"version": "==21.2.0"}}, "develop": {"black": {"version": "==23.11.0"}}}`,
+	})
+
+	assert.Len(t, libraries, 6)
+	assert.Equal(t, ">=5.3", libraries["pypi/celery"].Version)
+	assert.Equal(t, "", libraries["pypi/boto3"].Version)
+	assert.Equal(t, "7.2.6", libraries["pypi/sphinx"].Version)
+	assert.Equal(t, "21.2.0", libraries["pypi/gunicorn"].Version)
+	assert.Equal(t, ">=5.0", libraries["pypi/redis"].Version)
+	assert.True(t, libraries["pypi/black"].Dev)
+}
+
+func TestDetectNpmLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"package.json": `{
+  "name": "orders",
+  "dependencies": {"express": "^4.18.2", "@angular/core": "~16.2.0", "shared": "file:../shared", "lodash": "*"},
+  "devDependencies": {"jest": "^29.7.0"}
+}`,
+		"package-lock.json": `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "orders"},
+    "node_modules/express": {"version": "4.18.2"},
+    "node_modules/jest": {"version": "29.7.0", "dev": true},
+    "node_modules/express/node_modules/debug": {"version": "2.6.9"},
+    "node_modules/shared": {"resolved": "../shared", "link": true}
+  }
+}`,
+	})
+
+	assert.Len(t, libraries, 4, "local and nested packages aren't libraries")
+	assert.Equal(t, "4.18.2", libraries["npm/express"].Version)
+	assert.Equal(t, "package-lock.json", libraries["npm/express"].Evidence)
+	assert.Equal(t, "~16.2.0", libraries["npm/@angular/core"].Version)
+	assert.Equal(t, "", libraries["npm/lodash"].Version)
+	assert.True(t, libraries["npm/jest"].Dev)
+
+	assert.Equal(t, "pkg:npm/express@4.18.2", libraries["npm/express"].PackageURL())
+	assert.Equal(t, "pkg:npm/%40angular/core", libraries["npm/@angular/core"].PackageURL())
+}
+
+func TestDetectYarnLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"package.json": `{"dependencies": {"@babel/core": "^7.23.0"}, "devDependencies": {"eslint": "^8.0.0"}}`,
+		"yarn.lock": `# yarn lockfile v1
+
+"@babel/core@^7.22.0", "@babel/core@^7.23.0":
+  version "7.23.2"
+  dependencies:
+    "@babel/code-frame" "^7.22.13"
+
+eslint@^8.0.0:
+  version "8.52.0"
+`,
+		"web/yarn.lock": `__metadata:
+  version: 6
+
+"axios@npm:^1.5.0":
+  version: 1.5.1
+
+"web@workspace:.":
+  version: 0.0.0-use.local
+`,
+	})
+
+	assert.Len(t, libraries, 3)
+	assert.Equal(t, "7.23.2", libraries["npm/@babel/core"].Version)
+	assert.Equal(t, "1.5.1", libraries["npm/axios"].Version, "berry lock files are read too")
+	assert.True(t, libraries["npm/eslint"].Dev, "package.json tells dev libraries apart")
+}
+
+func TestDetectNugetLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"src/Orders/Orders.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.3" />
+    <PackageReference Include="Serilog.AspNetCore">
+      <Version>8.0.0</Version>
+    </PackageReference>
+    <PackageReference Include="StyleCop.Analyzers" Version="1.1.118" PrivateAssets="all" />
+    <PackageReference Include="Dapper" Version="$(DapperVersion)" />
+    <ProjectReference Include="..\Shared\Shared.csproj" />
+  </ItemGroup>
+</Project>`,
+		"src/Orders/packages.lock.json": `{
+  "version": 1,
+  "dependencies": {
+    "net8.0": {
+      "Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.3, )", "resolved": "13.0.3"},
+      "Serilog": {"type": "Transitive", "resolved": "3.1.1"},
+      "Shared": {"type": "Project"}
+    }
+  }
+}`,
+		"legacy/Billing/packages.config": `<?xml version="1.0" encoding="utf-8"?>
+<packages>
+  <package id="EntityFramework" version="6.4.4" targetFramework="net472" />
+  <package id="Microsoft.Net.Compilers" version="2.4.0" targetFramework="net472" developmentDependency="true" />
+</packages>`,
+	})
+
+	assert.Len(t, libraries, 7, "project references aren't libraries")
+	assert.Equal(t, "13.0.3", libraries["nuget/Newtonsoft.Json"].Version)
+	assert.Equal(t, "src/Orders/packages.lock.json", libraries["nuget/Newtonsoft.Json"].Evidence)
+	assert.Equal(t, "3.1.1", libraries["nuget/Serilog"].Version, "lock files include transitive packages")
+	assert.Equal(t, "8.0.0", libraries["nuget/Serilog.AspNetCore"].Version)
+	assert.True(t, libraries["nuget/StyleCop.Analyzers"].Dev)
+	assert.Equal(t, "", libraries["nuget/Dapper"].Version, "properties aren't resolved")
+	assert.Equal(t, "6.4.4", libraries["nuget/EntityFramework"].Version)
+	assert.True(t, libraries["nuget/Microsoft.Net.Compilers"].Dev)
+
+	assert.Equal(t, "pkg:nuget/EntityFramework@6.4.4", libraries["nuget/EntityFramework"].PackageURL())
+}
+
+func TestDetectGoLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"go.mod": `module example.com/orders
+
+go 1.21
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	github.com/lib/pq v1.10.9 // indirect
+	example.com/shared v0.0.0-00010101000000-000000000000
+)
+
+require golang.org/x/sync v0.5.0
+
+replace example.com/shared => ../shared
+
+replace (
+	github.com/lib/pq v1.10.9 => github.com/acme/pq v1.10.10
+)
+`,
+	})
+
+	assert.Len(t, libraries, 3, "modules replaced by local directories aren't libraries")
+	assert.Equal(t, "v1.9.1", libraries["golang/github.com/gin-gonic/gin"].Version)
+	assert.Equal(t, "go.mod", libraries["golang/github.com/gin-gonic/gin"].Evidence)
+	assert.Equal(t, "v1.10.9", libraries["golang/github.com/lib/pq"].Version, "indirect modules are libraries too")
+	assert.Equal(t, "v0.5.0", libraries["golang/golang.org/x/sync"].Version)
+
+	assert.Equal(t, "pkg:golang/github.com/gin-gonic/gin@v1.9.1", libraries["golang/github.com/gin-gonic/gin"].PackageURL())
+}
+
+func TestDetectGemLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"Gemfile": `source "https://rubygems.org"
+
+gem "rails", "~> 7.1.2"
+gem 'pg', '>= 1.1', '< 2.0'
+gem "sidekiq"
+gem "billing", path: "../billing"
+gem "rubocop", require: false, group: :development
+
+group :development, :test do
+  gem "rspec-rails" # specs
+  platforms :mri do
+    gem "byebug"
+  end
+end
+
+group :production do
+  gem "lograge"
+end
+`,
+		"Gemfile.lock": `PATH
+  remote: ../billing
+  specs:
+    billing (0.1.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    nokogiri (1.15.4-x86_64-linux)
+      racc (~> 1.4)
+    rails (7.1.2)
+      actionpack (= 7.1.2)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  rails (~> 7.1.2)
+`,
+	})
+
+	assert.Len(t, libraries, 8, "local gems aren't libraries")
+	assert.Equal(t, "7.1.2", libraries["gem/rails"].Version, "lock files pin versions")
+	assert.Equal(t, "Gemfile.lock", libraries["gem/rails"].Evidence)
+	assert.Equal(t, "1.15.4", libraries["gem/nokogiri"].Version, "platforms aren't part of versions")
+	assert.Equal(t, ">=1.1,<2.0", libraries["gem/pg"].Version)
+	assert.Equal(t, "", libraries["gem/sidekiq"].Version)
+	assert.True(t, libraries["gem/rubocop"].Dev)
+	assert.True(t, libraries["gem/rspec-rails"].Dev)
+	assert.True(t, libraries["gem/byebug"].Dev, "blocks nested in groups are part of them")
+	assert.False(t, libraries["gem/lograge"].Dev)
+
+	assert.Equal(t, "pkg:gem/rails@7.1.2", libraries["gem/rails"].PackageURL())
+}
+
+func TestDetectComposerLibraries(t *testing.T) {
+
+	libraries := detectTestLibraries(t, map[string]string{
+		"composer.json": `{
+  "require": {
+    "php": "^8.1",
+    "ext-pdo": "*",
+    "laravel/framework": "^10.10",
+    "guzzlehttp/guzzle": "^7.2",
+    "Acme/Billing": "*"
+  },
+  "require-dev": {
+    "phpunit/phpunit": "^10.1"
+  }
+}`,
+		"composer.lock": `{
+  "packages": [
+    {"name": "laravel/framework", "version": "v10.48.4", "dist": {"type": "zip"}},
+    {"name": "symfony/console", "version": "v6.4.4", "dist": {"type": "zip"}},
+    {"name": "acme/shared", "version": "dev-main", "dist": {"type": "path", "url": "../shared"}}
+  ],
+  "packages-dev": [
+    {"name": "phpunit/phpunit", "version": "10.5.13", "dist": {"type": "zip"}}
+  ]
+}`,
+	})
+
+	assert.Len(t, libraries, 5, "platform and path packages aren't libraries")
+	assert.Equal(t, "v10.48.4", libraries["composer/laravel/framework"].Version, "lock files pin versions")
+	assert.Equal(t, "composer.lock", libraries["composer/laravel/framework"].Evidence)
+	assert.Equal(t, "^7.2", libraries["composer/guzzlehttp/guzzle"].Version)
+	assert.Equal(t, "", libraries["composer/acme/billing"].Version)
+	assert.True(t, libraries["composer/phpunit/phpunit"].Dev)
+	assert.False(t, libraries["composer/symfony/console"].Dev)
+
+	assert.Equal(t, "pkg:composer/laravel/framework@v10.48.4", libraries["composer/laravel/framework"].PackageURL())
+}
+
+func TestResolveLibraryLicense(t *testing.T) {
+
+	resolver := model.NewLicenseResolver(&model.DefaultLicenseDB)
+
+	license, found := resolver.ResolveLibrary(&model.AppLibrary{Ecosystem: model.ECOSYSTEM_PYPI, Name: "psycopg2", Version: "2.9.9"})
+	assert.True(t, found)
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, license.Type)
+
+	_, found = resolver.ResolveLibrary(&model.AppLibrary{Ecosystem: model.ECOSYSTEM_PYPI, Name: "psycopg2-pool"})
+	assert.False(t, found, "libraries are resolved by name, not prefix")
+
+	license, found = resolver.ResolveLibrary(&model.AppLibrary{Ecosystem: model.ECOSYSTEM_NPM, Name: "@angular/core", Version: "16.2.0"})
+	assert.True(t, found)
+	assert.Equal(t, "MIT", license.License)
+
+	license, found = resolver.ResolveLibrary(&model.AppLibrary{Ecosystem: model.ECOSYSTEM_NUGET, Name: "Serilog.Sinks.File"})
+	assert.True(t, found, "nuget packages are resolved by their id prefix")
+	assert.Equal(t, "Apache-2.0", license.License)
+
+	license, found = resolver.ResolveLibrary(&model.AppLibrary{Ecosystem: model.ECOSYSTEM_GOLANG, Name: "github.com/go-sql-driver/mysql"})
+	assert.True(t, found)
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, license.Type)
+
+	license, found = resolver.ResolveLibrary(&model.AppLibrary{Ecosystem: model.ECOSYSTEM_GEM, Name: "sidekiq", Version: "7.2.0"})
+	assert.True(t, found)
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, license.Type)
+
+	license, found = resolver.ResolveLibrary(&model.AppLibrary{Ecosystem: model.ECOSYSTEM_COMPOSER, Name: "phpmailer/phpmailer"})
+	assert.True(t, found)
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, license.Type)
+}
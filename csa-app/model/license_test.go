@@ -0,0 +1,110 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLicenseType(t *testing.T) {
+	assert.Equal(t, model.LICENSE_PERMISSIVE, model.LicenseType("The Apache Software License, Version 2.0"))
+	assert.Equal(t, model.LICENSE_PERMISSIVE, model.LicenseType("MIT"))
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, model.LicenseType("GNU Lesser General Public License v2.1"))
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, model.LicenseType("EPL-2.0"))
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, model.LicenseType("GPL-2.0 WITH Classpath-exception-2.0"))
+	assert.Equal(t, model.LICENSE_STRONG_COPYLEFT, model.LicenseType("GNU General Public License, version 2"))
+	assert.Equal(t, model.LICENSE_STRONG_COPYLEFT, model.LicenseType("AGPL-3.0"))
+	assert.Equal(t, model.LICENSE_PROPRIETARY, model.LicenseType("Proprietary"))
+	assert.Equal(t, model.LICENSE_UNKNOWN, model.LicenseType("Custom"))
+	assert.Equal(t, model.LICENSE_PERMISSIVE, model.LicenseType("EPL-2.0 OR Apache-2.0"), "the least obligations of a choice apply")
+	assert.Equal(t, model.LICENSE_WEAK_COPYLEFT, model.LicenseType("CDDL/GPLv2+CE"))
+
+	assert.True(t, model.IsLicenseRisk(model.LICENSE_STRONG_COPYLEFT))
+	assert.False(t, model.IsLicenseRisk(model.LICENSE_PERMISSIVE))
+	assert.False(t, model.IsLicenseRisk(model.LICENSE_UNKNOWN))
+}
+
+func TestImportedPackage(t *testing.T) {
+	assert.Equal(t, "org.apache.commons.lang3.StringUtils", model.ImportedPackage("import org.apache.commons.lang3.StringUtils;"))
+	assert.Equal(t, "org.junit.Assert.*", model.ImportedPackage("import static org.junit.Assert.*;"))
+	assert.Equal(t, "com.itextpdf.text.Document", model.ImportedPackage(`<%@ page import="com.itextpdf.text.Document" %>`))
+	assert.Equal(t, "", model.ImportedPackage("import"))
+}
+
+func TestResolveLicenses(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "licenses")
+	defer os.RemoveAll(dir)
+
+	write := func(name string, content string) string {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		_ = ioutil.WriteFile(fqn, []byte(content), 0644)
+		return fqn
+	}
+
+	pom := write("app/pom.xml", `<project>
+  <properties><itext.version>5.5.13</itext.version></properties>
+  <dependencies>
+    <dependency><groupId>com.itextpdf</groupId><artifactId>itextpdf</artifactId><version>${itext.version}</version></dependency>
+    <dependency><groupId>com.acme</groupId><artifactId>acme-core</artifactId><version>1.0</version></dependency>
+  </dependencies>
+</project>`)
+	gradle := write("app/build.gradle", `implementation 'org.acme.tools:tools:${toolsVersion}'`)
+
+	repo := filepath.Join(dir, "repository")
+	write("repository/com/acme/acme-core/1.0/acme-core-1.0.pom", `<project>
+  <parent><groupId>com.acme</groupId><artifactId>acme-parent</artifactId><version>3</version></parent>
+</project>`)
+	write("repository/com/acme/acme-parent/3/acme-parent-3.pom", `<project>
+  <licenses><license><name>GNU General Public License v3</name></license></licenses>
+</project>`)
+	gradleHome := filepath.Join(dir, "gradle")
+	write("gradle/caches/modules-2/files-2.1/org.acme.tools/tools/2.1/5f3a/tools-2.1.pom", `<project>
+  <licenses><license><name>MIT License</name></license></licenses>
+</project>`)
+
+	app := &model.Application{Name: "orders", Path: filepath.Join(dir, "app"), Files: []*util.FileInfo{
+		{Name: "pom.xml", FQN: pom}, {Name: "build.gradle", FQN: gradle}}}
+
+	assert.Equal(t, []model.Dependency{
+		{Group: "com.itextpdf", Artifact: "itextpdf", Version: "5.5.13"},
+		{Group: "com.acme", Artifact: "acme-core", Version: "1.0"},
+		{Group: "org.acme.tools", Artifact: "tools"},
+	}, model.DeclaredDependencies(app, make(map[string]string)))
+
+	licenseDB := &model.LicenseDB{Licenses: append([]model.LicenseEntry{{Package: "org.apache.commons", License: "Custom"}},
+		model.DefaultLicenseDB.Licenses...)}
+	resolver := model.NewLicenseResolver(licenseDB)
+	resolver.AddDependencies(app, repo, gradleHome)
+
+	license, found := resolver.Resolve("import com.acme.core.Widget;")
+	assert.True(t, found)
+	assert.Equal(t, model.ResolvedLicense{Package: "com.acme", License: "GNU General Public License v3", Type: model.LICENSE_STRONG_COPYLEFT,
+		Source: model.LICENSE_SOURCE_MAVEN}, license, "licenses are inherited from parent poms")
+
+	license, _ = resolver.Resolve("import org.acme.tools.Tool;")
+	assert.Equal(t, model.LICENSE_SOURCE_GRADLE, license.Source, "the last version cached is used without one")
+	assert.Equal(t, model.LICENSE_PERMISSIVE, license.Type)
+
+	license, _ = resolver.Resolve("import com.itextpdf.text.Document;")
+	assert.Equal(t, model.LICENSE_SOURCE_DB, license.Source, "no local pom")
+	assert.Equal(t, model.LICENSE_STRONG_COPYLEFT, license.Type)
+
+	license, _ = resolver.Resolve("import org.apache.commons.lang3.StringUtils;")
+	assert.Equal(t, "Custom", license.License, "the longest package wins")
+
+	_, found = resolver.Resolve("import com.unknown.Thing;")
+	assert.False(t, found)
+}
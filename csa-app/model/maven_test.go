@@ -0,0 +1,129 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveMavenLibraries(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "maven")
+	defer os.RemoveAll(dir)
+
+	app := &model.Application{Name: "orders", Path: filepath.Join(dir, "app")}
+	write := func(name string, content string) {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		_ = ioutil.WriteFile(fqn, []byte(content), 0644)
+		if strings.HasPrefix(name, "app/") {
+			app.Files = append(app.Files, &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn})
+		}
+	}
+
+	//Multi-module build: the parent manages versions itself and through a BOM of the local repository
+	write("app/pom.xml", `<project>
+  <groupId>com.shop</groupId>
+  <artifactId>orders-parent</artifactId>
+  <version>1.0.0</version>
+  <properties>
+    <commons.version>3.12.0</commons.version>
+    <acme.version>2.0</acme.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency><groupId>org.apache.commons</groupId><artifactId>commons-lang3</artifactId><version>${commons.version}</version></dependency>
+      <dependency><groupId>com.acme</groupId><artifactId>acme-bom</artifactId><version>${acme.version}</version><type>pom</type><scope>import</scope></dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency><groupId>junit</groupId><artifactId>junit</artifactId><version>4.13.2</version><scope>test</scope></dependency>
+  </dependencies>
+</project>`)
+	write("app/orders-service/pom.xml", `<project>
+  <parent><groupId>com.shop</groupId><artifactId>orders-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>orders-service</artifactId>
+  <dependencies>
+    <dependency><groupId>org.apache.commons</groupId><artifactId>commons-lang3</artifactId></dependency>
+    <dependency><groupId>com.acme</groupId><artifactId>acme-client</artifactId></dependency>
+    <dependency><groupId>com.shop</groupId><artifactId>orders-api</artifactId><version>${project.version}</version></dependency>
+    <dependency><groupId>com.unknown</groupId><artifactId>unknown</artifactId><version>${unknown.version}</version></dependency>
+  </dependencies>
+</project>`)
+	write("app/orders-api/pom.xml", `<project>
+  <parent><groupId>com.shop</groupId><artifactId>orders-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>orders-api</artifactId>
+</project>`)
+	write("repository/com/acme/acme-bom/2.0/acme-bom-2.0.pom", `<project>
+  <dependencyManagement>
+    <dependencies>
+      <dependency><groupId>com.acme</groupId><artifactId>acme-client</artifactId><version>2.0.3</version></dependency>
+      <dependency><groupId>org.apache.commons</groupId><artifactId>commons-lang3</artifactId><version>3.0</version></dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`)
+
+	//Gradle: versions from variables, gradle.properties, platforms and the Spring Boot plugin
+	write("app/orders-web/build.gradle", `plugins {
+    id 'org.springframework.boot' version '2.7.4'
+}
+ext.guavaVersion = '31.1-jre'
+dependencies {
+    implementation platform("com.acme:acme-bom:2.0")
+    implementation 'com.google.guava:guava:${guavaVersion}'
+    implementation "com.fasterxml.jackson.core:jackson-databind:$jacksonVersion"
+    implementation 'com.acme:acme-client'
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+    testImplementation 'org.mockito:mockito-core:4.8.0'
+}`)
+	write("app/gradle.properties", "jacksonVersion=2.13.4\n")
+	write("repository/org/springframework/boot/spring-boot-dependencies/2.7.4/spring-boot-dependencies-2.7.4.pom", `<project>
+  <properties><spring-boot.version>2.7.4</spring-boot.version></properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency><groupId>org.springframework.boot</groupId><artifactId>spring-boot-starter-web</artifactId><version>${spring-boot.version}</version></dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`)
+
+	libraries := model.ResolveMavenLibraries(3, app, filepath.Join(dir, "repository"), "")
+
+	versions := make(map[string]string)
+	for _, library := range libraries {
+		assert.Equal(t, uint(3), library.RunID)
+		assert.Equal(t, model.ECOSYSTEM_MAVEN, library.Ecosystem)
+		versions[library.Name] = library.Version
+	}
+
+	assert.Equal(t, "3.12.0", versions["org.apache.commons/commons-lang3"], "versions managed by the parent win over the BOM's")
+	assert.Equal(t, "2.0.3", versions["com.acme/acme-client"], "versions are imported from BOMs")
+	assert.Equal(t, "", versions["com.unknown/unknown"], "undefined properties are not versions")
+	assert.NotContains(t, versions, "com.shop/orders-api", "modules of the application are not libraries")
+	assert.Equal(t, "31.1-jre", versions["com.google.guava/guava"])
+	assert.Equal(t, "2.13.4", versions["com.fasterxml.jackson.core/jackson-databind"], "variables are read from gradle.properties")
+	assert.Equal(t, "2.7.4", versions["org.springframework.boot/spring-boot-starter-web"], "the Spring Boot plugin imports its BOM")
+	assert.NotContains(t, versions, "com.acme/acme-bom", "platforms are not libraries")
+
+	for _, library := range libraries {
+		switch library.Name {
+		case "junit/junit", "org.mockito/mockito-core":
+			assert.True(t, library.Dev, library.Name)
+		case "org.apache.commons/commons-lang3":
+			assert.False(t, library.Dev)
+			assert.Equal(t, "orders-service/pom.xml", library.Evidence)
+			assert.Equal(t, "pkg:maven/org.apache.commons/commons-lang3@3.12.0", library.PackageURL())
+		}
+	}
+}
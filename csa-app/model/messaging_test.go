@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessagingDestinationOf(t *testing.T) {
+
+	destination := func(rule string, line string) model.MessagingDestination {
+		return model.MessagingDestinationOf(&model.Finding{Rule: rule, Value: line, Fqn: "/src/orders/Orders.java", Line: 7}, model.MessagingTechnologies)
+	}
+
+	assert.Equal(t, model.MessagingDestination{Name: "ORDERS.IN", Kind: "queue", Technology: "jms", File: "/src/orders/Orders.java", Line: 7,
+		Rule: "messaging-jms-destination", Uses: 1}, destination("messaging-jms-destination", `    Queue queue = session.createQueue("ORDERS.IN");`))
+
+	mdb := destination("messaging-jms-destination", `@ActivationConfigProperty(propertyName = "destinationLookup", propertyValue = "jms/orders"),`)
+	assert.Equal(t, "jms/orders", mdb.Name)
+	assert.Equal(t, model.MESSAGING_DESTINATION_KIND, mdb.Kind)
+
+	listener := destination("messaging-kafka-destination", `@KafkaListener(id = "orders", topics = "orders.created")`)
+	assert.Equal(t, "orders.created", listener.Name, "the argument naming the destination")
+	assert.Equal(t, "topic", listener.Kind)
+	assert.Equal(t, "kafka", listener.Technology)
+
+	assert.Equal(t, "ORDERS.IN", destination("messaging-jms-destination-xml", `<property name="baseQueueName" value="ORDERS.IN"/>`).Name)
+	assert.Equal(t, "jms/OrdersQueue", destination("messaging-jms-destination-xml", `<mapped-name>jms/OrdersQueue</mapped-name>`).Name)
+	assert.Equal(t, "ORDERS.IN", destination("messaging-ibm-mq-definition", `DEFINE QLOCAL(ORDERS.IN) MAXDEPTH(5000)`).Name)
+	assert.Equal(t, "orders", destination("messaging-rabbitmq-destination", `channel.queue_declare(queue='orders', durable=True)`).Name)
+	assert.Equal(t, "exchange", destination("messaging-rabbitmq-destination", `return new TopicExchange("orders.exchange");`).Kind)
+
+	binding := destination("messaging-destination-config", "spring.cloud.stream.bindings.input.destination=orders")
+	assert.Equal(t, "orders", binding.Name)
+	assert.Equal(t, model.MESSAGING_UNKNOWN_TECHNOLOGY, binding.Technology, "the binder isn't named")
+
+	rabbit := destination("messaging-destination-config", "spring.rabbitmq.template.default-receive-queue: orders.in")
+	assert.Equal(t, "orders.in", rabbit.Name)
+	assert.Equal(t, "rabbitmq", rabbit.Technology, "rules of no technology take the technology of the line")
+	assert.Equal(t, "queue", rabbit.Kind)
+}
+
+func TestEvaluateMessaging(t *testing.T) {
+
+	tagTotals := model.TagTotals{
+		"jms":       {Findings: 6, Effort: 60},
+		"MDB":       {Findings: 1, Effort: 5},
+		"ibm-mq":    {Findings: 3, Effort: 21},
+		"messaging": {Findings: 5, Effort: 5},
+	}
+	findings := []model.Finding{
+		{Application: "orders", Fqn: "/src/orders/Sender.java", Line: 12, Rule: "messaging-jms-destination",
+			Value: `session.createQueue("queue:///ORDERS.IN");`},
+		{Application: "orders", Fqn: "/src/orders/Sender.java", Line: 12, Rule: "messaging-ibm-mq-destination",
+			Value: `session.createQueue("queue:///ORDERS.IN");`},
+		{Application: "orders", Fqn: "/src/orders/Reader.java", Line: 30, Rule: "messaging-ibm-mq-destination",
+			Value: `qmgr.accessQueue("queue:///ORDERS.IN", options);`},
+		{Application: "orders", Fqn: "/src/orders/Listener.java", Line: 4, Rule: "messaging-jms-destination",
+			Value: `@JmsListener(destination = "orders.out")`},
+		{Application: "orders", Fqn: "/src/orders/application.properties", Line: 2, Rule: "messaging-destination-config",
+			Value: "spring.cloud.stream.bindings.audit.destination=audit"},
+		{Application: "billing", Fqn: "/src/billing/Billing.java", Line: 9, Rule: "messaging-kafka-destination",
+			Value: `kafkaTemplate.send("invoices", invoice);`},
+	}
+
+	inventory := model.EvaluateMessaging("orders", tagTotals, findings, model.MessagingTechnologies)
+	assert.True(t, inventory.UsesMessaging())
+	assert.Equal(t, "orders", inventory.Application)
+
+	assert.Equal(t, []model.MessagingUsage{
+		{Technology: "jms", Findings: 7, Effort: 65, Destinations: 1},
+		{Technology: "ibm-mq", Findings: 3, Effort: 21, Destinations: 1},
+		{Technology: model.MESSAGING_UNKNOWN_TECHNOLOGY, Destinations: 1},
+	}, inventory.Technologies, "sorted by findings, tags of any case")
+
+	assert.Len(t, inventory.Destinations, 3)
+	assert.Equal(t, model.MessagingDestination{Name: "queue:///ORDERS.IN", Kind: "queue", Technology: "ibm-mq", File: "/src/orders/Reader.java",
+		Line: 30, Rule: "messaging-ibm-mq-destination", Uses: 2}, inventory.Destinations[0], "a line named by several rules is the most specific technology's")
+	assert.Equal(t, "orders.out", inventory.Destinations[1].Name)
+	assert.Equal(t, "audit", inventory.Destinations[2].Name)
+
+	none := model.EvaluateMessaging("inventory", nil, findings, model.MessagingTechnologies)
+	assert.False(t, none.UsesMessaging())
+}
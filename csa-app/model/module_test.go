@@ -0,0 +1,102 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func monorepo() *model.Application {
+	return &model.Application{Name: "shop", Path: "/apps/shop",
+		Files: []*util.FileInfo{
+			{FQN: "/apps/shop/billing/src/Invoice.java"},
+			{FQN: "/apps/shop/billing/core/build.gradle"},
+			{FQN: "/apps/shop/billing/core/src/Ledger.java"},
+			{FQN: "/apps/shop/tools/Build.java"},
+			{FQN: "/apps/shop/vendor/lib/pom.xml", ThirdParty: "vendor"},
+		},
+		IgnoredFiles: []*util.FileInfo{
+			{FQN: "/apps/shop/pom.xml"},
+			{FQN: "/apps/shop/billing/pom.xml"},
+		},
+	}
+}
+
+func TestDetectModules(t *testing.T) {
+
+	app := monorepo()
+	modules := model.DetectModules(7, app)
+
+	var names, buildFiles []string
+	for _, module := range modules {
+		names = append(names, module.Module)
+		buildFiles = append(buildFiles, module.BuildFile)
+		assert.Equal(t, uint(7), module.RunID)
+		assert.Equal(t, "shop", module.Application)
+	}
+	assert.Equal(t, []string{model.ROOT_MODULE, "billing", "billing/core"}, names, "third party build files are no modules")
+	assert.Equal(t, []string{"pom.xml", "pom.xml", "build.gradle"}, buildFiles)
+
+	//A single build file makes no modules
+	single := &model.Application{Name: "api", Path: "/apps/api", Files: []*util.FileInfo{{FQN: "/apps/api/pom.xml"}}}
+	assert.Empty(t, model.DetectModules(7, single))
+}
+
+func TestModuleOf(t *testing.T) {
+
+	app := monorepo()
+	assert.Equal(t, "", app.ModuleOf("/apps/shop/billing/src/Invoice.java"), "no modules detected yet")
+
+	app.Modules = model.DetectModules(7, app)
+	assert.Equal(t, "billing", app.ModuleOf("/apps/shop/billing/src/Invoice.java"))
+	assert.Equal(t, "billing/core", app.ModuleOf("/apps/shop/billing/core/src/Ledger.java"), "innermost module")
+	assert.Equal(t, model.ROOT_MODULE, app.ModuleOf("/apps/shop/tools/Build.java"))
+	assert.Equal(t, model.ROOT_MODULE, app.ModuleOf("/apps/shop/billing-ui/app.js"), "not part of billing")
+
+	app.CountModuleSloc(map[string]int{"/apps/shop/billing/src/Invoice.java": 100, "/apps/shop/billing/core/src/Ledger.java": 40,
+		"/apps/shop/tools/Build.java": 10})
+	assert.Equal(t, 10, app.Modules[0].SlocCnt)
+	assert.Equal(t, 100, app.Modules[1].SlocCnt)
+	assert.Equal(t, 1, app.Modules[1].FilesCnt)
+	assert.Equal(t, 40, app.Modules[2].SlocCnt)
+}
+
+func TestScoreModules(t *testing.T) {
+
+	scoringModel := &model.ScoringModel{Name: "test", MaxScore: 10, MinScore: 0}
+	scoringModel.AddRangeWithOutcome(model.SLOC_BKT_TYPE, 0, int(^uint(0)>>1), 0, "Refactor")
+
+	apps := []model.Application{{Name: "shop", Score: 4, Model: scoringModel}}
+	modules := []model.AppModule{
+		{Application: "shop", Module: model.ROOT_MODULE, SlocCnt: 1000},
+		{Application: "shop", Module: "billing", SlocCnt: 1000},
+		{Application: "legacy", Module: "batch", SlocCnt: 1000},
+	}
+	details := map[string]map[string]model.ApplicationDetails{
+		"shop": {"billing": {Application: "shop", Findings: 3, RawScore: 999}},
+	}
+
+	scorer, _ := model.GetScorer(model.LOG_SCORER)
+	scores, err := model.ScoreModules(apps, modules, details, nil, scorer, nil)
+	assert.NoError(t, err)
+	assert.Len(t, scores, 2, "modules of applications not in the run are left out")
+
+	assert.Equal(t, model.ROOT_MODULE, scores[0].Module)
+	assert.Equal(t, 0, scores[0].Findings)
+	assert.Equal(t, 10.0, scores[0].Score, "no findings")
+
+	assert.Equal(t, "billing", scores[1].Module)
+	assert.Equal(t, 3, scores[1].Findings)
+	assert.Equal(t, 999, scores[1].RawScore)
+	assert.Equal(t, 0.0, scores[1].Score)
+	assert.Equal(t, "Refactor", scores[1].Recommendation)
+	assert.Equal(t, 4.0, scores[1].AppScore)
+}
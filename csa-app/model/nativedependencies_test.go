@@ -0,0 +1,51 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNativeLibraryName(t *testing.T) {
+	assert.Equal(t, "codec", model.NativeLibraryName("codec"))
+	assert.Equal(t, "codec", model.NativeLibraryName("/opt/codec/lib/libcodec.so.1.2"))
+	assert.Equal(t, "codec", model.NativeLibraryName("libcodec.jnilib"))
+	assert.Equal(t, "libxml", model.NativeLibraryName(`C:\codec\libxml.DLL`), "dlls keep their lib prefix")
+}
+
+func TestEvaluateNativeDependencies(t *testing.T) {
+
+	findings := []model.Finding{
+		{Application: "orders", Rule: "java-systemLoad", Fqn: "src/Codec.java", Line: 12, Effort: 1000, Value: `    static { System.loadLibrary("codec"); }`},
+		{Application: "orders", Rule: "java-jni", Fqn: "src/Codec.java", Line: 14, Effort: 1000, Value: "    private native byte[] encode(byte[] frame);"},
+		{Application: "orders", Rule: "native-library-file", Fqn: "lib/x86_64/libcodec.so", Effort: 100, Value: "libcodec.so"},
+		{Application: "orders", Rule: "native-library-file", Fqn: "lib/aarch64/libcodec.so", Effort: 100, Value: "libcodec.so"},
+		{Application: "orders", Rule: "native-jna", Fqn: "src/Zip.java", Line: 3, Effort: 100, Value: `Zip lib = Native.load("z", Zip.class);`},
+		{Application: "billing", Rule: "java-jni", Fqn: "src/Other.java", Line: 1, Effort: 1000, Value: "native void other();"},
+	}
+
+	dependencies := model.EvaluateNativeDependencies("orders", findings, model.NativeDependencyKinds)
+	assert.Equal(t, "orders", dependencies.Application)
+	assert.Equal(t, 5, dependencies.Findings)
+	assert.Equal(t, 2300, dependencies.Effort)
+	assert.Equal(t, []string{"codec", "z"}, dependencies.Libraries, "bundled and loaded libraries by the name they are loaded by")
+
+	assert.Equal(t, []model.NativeDependency{
+		{Kind: "bundled", Library: "codec", Value: "libcodec.so", File: "lib/aarch64/libcodec.so", Uses: 2, Effort: 200},
+		{Kind: "jna", Library: "z", Value: `Zip lib = Native.load("z", Zip.class);`, File: "src/Zip.java", Line: 3, Uses: 1, Effort: 100},
+		{Kind: "jni", Value: "private native byte[] encode(byte[] frame);", File: "src/Codec.java", Line: 14, Uses: 1, Effort: 1000},
+		{Kind: "load", Library: "codec", Value: `static { System.loadLibrary("codec"); }`, File: "src/Codec.java", Line: 12, Uses: 1, Effort: 1000},
+	}, dependencies.Dependencies)
+
+	none := model.EvaluateNativeDependencies("inventory", findings, model.NativeDependencyKinds)
+	assert.Equal(t, 0, none.Findings)
+	assert.Empty(t, none.Dependencies)
+	assert.Empty(t, none.Libraries)
+}
@@ -56,3 +56,35 @@ func getValidRulePatternInvalidRegex() model.Rule {
 
 	return r
 }
+
+func TestPatternMatchRanges(t *testing.T) {
+
+	r := model.Rule{Name: "spring-requires-new", Target: model.MULTILINE_TARGET, Type: model.REGEX_MATCH_TYPE}
+	r.AddPattern(model.Pattern{Value: `@Transactional\(\s*propagation\s*=\s*Propagation\.REQUIRES_NEW`})
+
+	if valid, err := r.IsValid(); !valid {
+		t.Fatalf("Multiline rule should be valid! Details: %v", err)
+	}
+
+	r.CompilePatterns()
+
+	contents := "class Orders {\n  @Transactional(\n      propagation = Propagation.REQUIRES_NEW)\n  void save() {}\n" +
+		"  @Transactional(propagation = Propagation.REQUIRES_NEW)\n  void audit() {}\n}\n"
+
+	ranges := r.Patterns[0].MatchRanges(contents)
+
+	if len(ranges) != 2 {
+		t.Fatalf("Expected 2 matches but found %d: %v", len(ranges), ranges)
+	}
+	if ranges[0].Line != 2 || ranges[0].EndLine != 3 {
+		t.Errorf("Match spanning lines should record lines 2-3 but was %d-%d", ranges[0].Line, ranges[0].EndLine)
+	}
+	if ranges[1].Line != 5 || ranges[1].EndLine != 5 {
+		t.Errorf("Match on a single line should record lines 5-5 but was %d-%d", ranges[1].Line, ranges[1].EndLine)
+	}
+
+	r.Patterns[0].Type = model.CONTAINS_MATCH_TYPE
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Multiline rule patterns should be required to be regex!")
+	}
+}
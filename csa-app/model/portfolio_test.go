@@ -0,0 +1,89 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPortfolio(t *testing.T) {
+
+	apps := []model.Application{
+		{Name: "ledger", Score: 4, RawScore: 200, Findings: 30, SlocCnt: 3000, Recommendation: "Refactor"},
+		{Name: "billing-api", Score: 8, RawScore: 40, Findings: 10, SlocCnt: 1000},
+		{Name: "tracking", Score: 9, RawScore: 20, Findings: 5, SlocCnt: 500},
+		{Name: "legacy-batch", Score: 4, RawScore: 90, Findings: 12, SlocCnt: 2000},
+		{Name: "portal", Score: 6, RawScore: 60, Findings: 8, SlocCnt: 1500},
+	}
+	tagTotals := map[string]model.TagTotals{
+		"ledger":      {"jms": {Findings: 4, Effort: 40}, "ejb": {Findings: 2, Effort: 20}, "external-server": {Findings: 1}},
+		"billing-api": {"jms": {Findings: 1, Effort: 10}, "Embedded-Server": {Findings: 2}},
+		"portal":      {"ejb": {Findings: 6, Effort: 30}, "embedded-server": {Findings: 1}, "external-server": {Findings: 1}},
+	}
+
+	portfolio := model.NewPortfolio(3, apps, tagTotals, nil)
+
+	assert.Equal(t, uint(3), portfolio.RunID)
+	assert.Equal(t, 5, portfolio.Applications)
+	assert.Equal(t, 6.2, portfolio.Score)
+	assert.Equal(t, 6.0, portfolio.MedianScore)
+	assert.Equal(t, 410, portfolio.Effort)
+	assert.Equal(t, 65, portfolio.Findings)
+	assert.Equal(t, 8000, portfolio.SlocCnt)
+
+	var names []string
+	var ranks, quartiles []int
+	for _, app := range portfolio.Ranking {
+		names = append(names, app.Application)
+		ranks = append(ranks, app.Rank)
+		quartiles = append(quartiles, app.Quartile)
+	}
+	assert.Equal(t, []string{"tracking", "billing-api", "portal", "ledger", "legacy-batch"}, names, "best score first, ties by name")
+	assert.Equal(t, []int{1, 2, 3, 4, 4}, ranks, "same score, same rank")
+	assert.Equal(t, []int{1, 1, 2, 3, 4}, quartiles)
+	assert.Equal(t, "Refactor", portfolio.Ranking[3].Recommendation)
+
+	var deployments []string
+	for _, app := range portfolio.Ranking {
+		deployments = append(deployments, app.Deployment)
+	}
+	assert.Equal(t, []string{model.DEPLOYMENT_UNKNOWN, model.DEPLOYMENT_EMBEDDED, model.DEPLOYMENT_HYBRID, model.DEPLOYMENT_EXTERNAL,
+		model.DEPLOYMENT_UNKNOWN}, deployments)
+	assert.Equal(t, map[string]int{model.DEPLOYMENT_UNKNOWN: 2, model.DEPLOYMENT_EMBEDDED: 1, model.DEPLOYMENT_HYBRID: 1,
+		model.DEPLOYMENT_EXTERNAL: 1}, portfolio.Deployments)
+
+	assert.Len(t, portfolio.Quartiles, 4)
+	top := portfolio.Quartiles[0]
+	assert.Equal(t, 1, top.Quartile)
+	assert.Equal(t, 2, top.Applications)
+	assert.Equal(t, 8.0, top.MinScore)
+	assert.Equal(t, 9.0, top.MaxScore)
+	assert.Equal(t, 8.5, top.Score)
+	assert.Equal(t, 60, top.Effort)
+
+	assert.Equal(t, []model.PortfolioTag{
+		{Tag: "ejb", Applications: 2, Findings: 8, Effort: 50},
+		{Tag: "jms", Applications: 2, Findings: 5, Effort: 50},
+		{Tag: "Embedded-Server", Applications: 1, Findings: 2},
+		{Tag: "external-server", Applications: 2, Findings: 2},
+		{Tag: "embedded-server", Applications: 1, Findings: 1},
+	}, portfolio.Tags)
+}
+
+func TestNewPortfolioWithoutApplications(t *testing.T) {
+
+	portfolio := model.NewPortfolio(1, nil, nil, nil)
+
+	assert.Equal(t, 0, portfolio.Applications)
+	assert.Empty(t, portfolio.Ranking)
+	assert.Empty(t, portfolio.Quartiles)
+	assert.Empty(t, portfolio.Deployments)
+	assert.Empty(t, portfolio.Tags)
+}
@@ -0,0 +1,52 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluatePresentationTier(t *testing.T) {
+
+	slocs := []model.RunSloc{
+		{Application: "orders", Lang: "JSP", TotalFiles: 40, CodeLines: 6000},
+		{Application: "orders", Lang: "XHTML", TotalFiles: 5, CodeLines: 400},
+		{Application: "orders", Lang: "Java", TotalFiles: 120, CodeLines: 15000},
+		{Application: "billing", Lang: "Java", TotalFiles: 60, CodeLines: 8000},
+	}
+
+	billing := model.EvaluatePresentationTier("billing", slocs, nil, map[string]int{"jni": 30}, model.PresentationFrameworks)
+	assert.False(t, billing.HasPresentationTier())
+	assert.Equal(t, 30, billing.BackendEffort)
+	assert.Empty(t, billing.Frameworks)
+
+	totals := model.TagTotals{
+		"jsp-scriptlet":         {Findings: 120, Effort: 240},
+		"JSP-Taglib":            {Findings: 35, Effort: 45},
+		"presentation-session":  {Findings: 4, Effort: 20},
+		"jsf-component-library": {Findings: 5, Effort: 25},
+	}
+	efforts := map[string]int{"jsp": 290, "Facelets": 10, "jsf": 35, "tiles": 12, "ejb": 100, "jni": 53}
+
+	orders := model.EvaluatePresentationTier("orders", slocs, totals, efforts, model.PresentationFrameworks)
+	assert.True(t, orders.HasPresentationTier())
+	assert.Equal(t, 45, orders.Pages)
+	assert.Equal(t, 6400, orders.CodeLines, "only the code of the pages")
+	assert.Equal(t, 120, orders.Scriptlets)
+	assert.Equal(t, 35, orders.Taglibs, "tags are matched regardless of case")
+	assert.Equal(t, 4, orders.SessionState)
+	assert.Equal(t, 5, orders.ComponentLibraries)
+
+	assert.Equal(t, 347, orders.Effort)
+	assert.Equal(t, 153, orders.BackendEffort)
+	assert.Equal(t, 69, orders.Share)
+	assert.Equal(t, []model.PresentationFrameworkResult{{Framework: "jsp", Effort: 290}, {Framework: "jsf", Effort: 45}, {Framework: "tiles", Effort: 12}},
+		orders.Frameworks, "frameworks in catalog order, categories matched regardless of case")
+}
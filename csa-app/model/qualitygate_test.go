@@ -0,0 +1,43 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQualityGate(t *testing.T) {
+
+	assert.False(t, model.NewQualityGate(0, nil).Enabled())
+
+	gate := model.NewQualityGate(6, []string{"jni, ejb", "", "jms"})
+	assert.True(t, gate.Enabled())
+	assert.Equal(t, []string{"jni", "ejb", "jms"}, gate.FailOnTags)
+
+	apps := []*model.Application{
+		{Name: "tracking", Score: 9},
+		{Name: "ledger", Score: 4, TagTotals: model.TagTotals{"EJB": {Findings: 3, Effort: 30}}},
+		{Name: "billing", Score: 7, TagTotals: model.TagTotals{"jni": {Findings: 1, Effort: 10}, "logging": {Findings: 5}}},
+	}
+
+	result := gate.Evaluate(12, apps)
+	assert.False(t, result.Passed)
+	assert.Equal(t, uint(12), result.RunID)
+	assert.Equal(t, []model.GateViolation{
+		{Gate: model.FAIL_ON_TAG_GATE, Application: "billing", Tag: "jni", Findings: 1},
+		{Gate: model.FAIL_BELOW_GATE, Application: "ledger", Score: 4},
+		{Gate: model.FAIL_ON_TAG_GATE, Application: "ledger", Tag: "ejb", Findings: 3},
+	}, result.Violations)
+	assert.Equal(t, "score [4.00] is below [6.00]", result.Violations[1].Describe(*gate))
+
+	result = model.NewQualityGate(3, []string{"cobol"}).Evaluate(12, apps)
+	assert.True(t, result.Passed)
+	assert.Empty(t, result.Violations)
+}
@@ -0,0 +1,80 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOwners(t *testing.T) {
+
+	owners, err := model.ParseCodeOwners(strings.NewReader(`
+# default owners
+*                @platform
+*.sql            @dba
+/src/web/        @web-team
+docs/            @writers
+/src/web/legacy/
+`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"@platform"}, owners.Owners("src/main/App.java"))
+	assert.Equal(t, []string{"@dba"}, owners.Owners("db/schema/init.sql"))
+	assert.Equal(t, []string{"@web-team"}, owners.Owners("src/web/pages/index.jsp"))
+	assert.Equal(t, []string{"@writers"}, owners.Owners("module/docs/readme.md"), "unanchored directories match at any depth")
+	assert.Empty(t, owners.Owners("src/web/legacy/old.jsp"), "an entry without owners leaves the path unowned")
+
+	var none *model.CodeOwners
+	assert.Empty(t, none.Owners("src/main/App.java"))
+}
+
+func TestBuildRemediationPlan(t *testing.T) {
+
+	app := &model.Application{Name: "orders", Path: "/apps/orders"}
+	owners, _ := model.ParseCodeOwners(strings.NewReader("/src/dao/ @data-team\n"))
+
+	findings := []model.Finding{
+		{Application: "orders", Rule: "java-jni", Category: "native", Criticality: "high", Effort: 10, Fqn: "/apps/orders/src/Native.java"},
+		{Application: "orders", Rule: "java-jni", Category: "native", Criticality: "high", Effort: 10, Fqn: "/apps/orders/src/Native.java"},
+		{Application: "orders", Rule: "java-jdbc", Category: "database", Effort: 3, Fqn: "/apps/orders/src/dao/OrderDao.java",
+			Recipes: []model.FindingRecipe{{URI: "https://example.com/jdbc"}}},
+		{Application: "orders", Rule: "java-jndi", Category: "database", Effort: 5, Fqn: "/apps/orders/src/dao/Lookup.java"},
+		{Application: "orders", Rule: "sloc", Category: model.SLOC_CATEGORY, Effort: 0, Fqn: "/apps/orders/src/Native.java"},
+		{Application: "orders", Rule: "java-jni", Category: "native", Effort: 10, Fqn: "/apps/orders/vendor/lib.java", ThirdParty: "vendor"},
+		{Application: "billing", Rule: "java-jni", Category: "native", Effort: 10, Fqn: "/apps/billing/Native.java"},
+	}
+
+	plan := model.BuildRemediationPlan(7, app, findings, owners)
+
+	assert.Equal(t, uint(7), plan.RunID)
+	assert.Equal(t, 4, plan.Findings)
+	assert.Equal(t, 28, plan.Effort)
+	assert.Equal(t, []string{"@data-team", model.UNASSIGNED_OWNER}, plan.Owners)
+
+	if assert.Len(t, plan.Categories, 2) {
+		native := plan.Categories[0]
+		assert.Equal(t, "native", native.Name, "categories are ordered by effort")
+		assert.Equal(t, 20, native.Effort)
+		if assert.Len(t, native.Items, 1) {
+			assert.Equal(t, 2, native.Items[0].Findings)
+			assert.Equal(t, []string{"src/Native.java"}, native.Items[0].Files)
+			assert.Equal(t, []string{model.UNASSIGNED_OWNER}, native.Items[0].Owners)
+		}
+
+		database := plan.Categories[1]
+		if assert.Len(t, database.Items, 2) {
+			assert.Equal(t, "java-jndi", database.Items[0].Rule, "items are ordered by effort")
+			assert.Equal(t, "java-jdbc", database.Items[1].Rule)
+			assert.Equal(t, []string{"@data-team"}, database.Items[1].Owners)
+			assert.Equal(t, []string{"https://example.com/jdbc"}, database.Items[1].Recipes)
+		}
+	}
+}
@@ -183,6 +183,79 @@ func TestRuleTypeValuesRestricted(t *testing.T) {
 
 }
 
+func TestRuleSeverityValuesRestricted(t *testing.T) {
+
+	r := getValidRule()
+
+	for _, severity := range append(model.Severities, "") {
+		r.Severity = severity
+		if valid, err := r.IsValid(); !valid {
+			t.Errorf("Severity [%s] should be valid but failed validation! Details: %v", severity, err)
+		}
+	}
+
+	r.Severity = "blocker"
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Severity [%s] should be invalid but passed validation!", r.Severity)
+	}
+
+	r.Severity = ""
+	r.Patterns[0].Severity = "blocker"
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Pattern Severity [%s] should be invalid but passed validation!", r.Patterns[0].Severity)
+	}
+
+	if model.SeverityRank(model.SEVERITY_CRITICAL) <= model.SeverityRank(model.SEVERITY_HIGH) || model.SeverityRank("") != 0 {
+		t.Errorf("Severities must rank in ascending order of risk!")
+	}
+}
+
+func TestRuleExclusions(t *testing.T) {
+
+	r := getValidRule()
+	r.Unless = []model.Exclusion{{Target: model.FILE_TARGET, Pattern: `/src/test/`}, {Pattern: `@Autowired`}}
+
+	if valid, err := r.IsValid(); !valid {
+		t.Errorf("Rule with exclusions should be valid! Details: %v", err)
+	}
+
+	r.CompilePatterns()
+
+	loads := 0
+	contents := func(content string) func() []byte {
+		return func() []byte {
+			loads++
+			return []byte(content)
+		}
+	}
+
+	if !r.ExcludedFrom("app/src/test/java/LookupTest.java", contents("")) || loads != 0 {
+		t.Errorf("Test classes should be excluded by path without reading the file!")
+	}
+
+	if !r.ExcludedFrom("app/src/main/java/Lookup.java", contents("@Autowired DataSource ds;")) {
+		t.Errorf("Files with a spring managed alternative should be excluded!")
+	}
+
+	if r.ExcludedFrom("app/src/main/java/Lookup.java", contents("ctx.lookup(\"java:comp/env/jdbc\");")) {
+		t.Errorf("Files matching no exclusion should not be excluded!")
+	}
+
+	if by := r.ExcludedBy("app/src/main/java/Lookup.java", contents("@Autowired DataSource ds;")); by == nil || by.Pattern != `@Autowired` {
+		t.Errorf("The exclusion that applied should be returned! Got: %v", by)
+	}
+
+	r.Unless = []model.Exclusion{{Target: model.LINE_TARGET, Pattern: "x"}}
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Exclusion target [%s] should be invalid!", model.LINE_TARGET)
+	}
+
+	r.Unless = []model.Exclusion{{Pattern: "[x"}}
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Exclusion pattern must be a valid regex!")
+	}
+}
+
 func TestDefaultPatternValidation(t *testing.T) {
 
 	r := getValidRule()
@@ -208,6 +281,55 @@ func TestDefaultPatternValidation(t *testing.T) {
 
 }
 
+func TestXpathPatternValidation(t *testing.T) {
+
+	r := getValidRule()
+	r.Type = model.XPATH_MATCH_TYPE
+	r.DefaultPattern = ""
+	r.Patterns = []model.Pattern{{Value: `//web-app/resource-ref[res-type="javax.sql.DataSource"]`}}
+
+	if valid, err := r.IsValid(); !valid {
+		t.Errorf("XPath rule should be valid! Details: %v", err)
+	}
+
+	r.Patterns = []model.Pattern{{Value: `//web-app/resource-ref[`}}
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("XPath expression: %s should fail validation but passed!", r.Patterns[0].Value)
+	}
+
+	r.Patterns = []model.Pattern{{Value: `//web-app/distributable`}}
+	r.Target = model.LINE_TARGET
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("XPath rule with target [%s] should fail validation but passed!", r.Target)
+	}
+}
+
+func TestRuleDeprecation(t *testing.T) {
+
+	r := getValidRule()
+	if notice := r.DeprecationNotice(); notice != "" {
+		t.Errorf("Rule that isn't deprecated should have no deprecation notice! Got: %s", notice)
+	}
+
+	r.ReplacedBy = "java-jndi-lookup"
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Rule replaced by another should be required to be deprecated!")
+	}
+
+	r.Deprecated = true
+	if valid, err := r.IsValid(); !valid {
+		t.Errorf("Deprecated rule should be valid! Details: %v", err)
+	}
+	if notice := r.DeprecationNotice(); notice != "Rule [Test Rule] is deprecated and replaced by rule [java-jndi-lookup]" {
+		t.Errorf("Unexpected deprecation notice: %s", notice)
+	}
+
+	r.ReplacedBy = r.Name
+	if valid, _ := r.IsValid(); valid {
+		t.Errorf("Rule cannot be replaced by itself!")
+	}
+}
+
 func getValidRule() model.Rule {
 	r := model.Rule{}
 	r.Name = "Test Rule"
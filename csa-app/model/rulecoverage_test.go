@@ -0,0 +1,48 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRuleCoverage(t *testing.T) {
+
+	metrics := []model.RuleMetric{
+		{Rule: "java-jni", RuleCriticality: "high", Checks: 40},
+		{Rule: "java-corba", RuleCriticality: "high", Checks: 40, RuleDeprecated: true, RuleReplacedBy: "java-rmi-iiop"},
+		{Rule: "dotnet-wcf", RuleCriticality: "medium"},
+	}
+
+	findings := []model.Finding{
+		{Rule: "java-jni", Fqn: "/app/b/Native.java"},
+		{Rule: "java-jni", Fqn: "/app/a/Native.java"},
+		{Rule: "java-jni", Fqn: "/app/b/Native.java"},
+		{Category: model.SLOC_CATEGORY, Fqn: "/app/b/Native.java"},
+	}
+
+	coverage := model.BuildRuleCoverage(metrics, findings)
+
+	assert.Equal(t, map[string]string{"java-corba": "replaced by java-rmi-iiop"}, model.Deprecations(metrics))
+
+	if assert.Len(t, coverage, 3) {
+		assert.Equal(t, "dotnet-wcf", coverage[0].Rule, "rules that never matched are listed first")
+		assert.Equal(t, int64(0), coverage[0].Checks)
+		assert.Equal(t, "java-corba", coverage[1].Rule)
+		assert.Equal(t, 0, coverage[1].Matches)
+		assert.Equal(t, "replaced by java-rmi-iiop", coverage[1].Deprecation)
+		assert.Empty(t, coverage[1].Files)
+
+		assert.Equal(t, "java-jni", coverage[2].Rule)
+		assert.Equal(t, 3, coverage[2].Matches)
+		assert.Empty(t, coverage[2].Deprecation)
+		assert.Equal(t, []string{"/app/a/Native.java", "/app/b/Native.java"}, coverage[2].Files)
+	}
+}
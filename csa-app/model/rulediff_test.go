@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffRules(t *testing.T) {
+
+	local := []model.Rule{
+		{Name: "java-jni", Effort: 10, Tags: []model.Tag{{Value: "jni"}, {Value: "native"}}},
+		{Name: "java-fileIO", Effort: 5},
+		{Name: "java-corba", Effort: 100},
+	}
+
+	upstream := []model.Rule{
+		//Tag order is not significant
+		{Name: "java-jni", Effort: 10, Tags: []model.Tag{{Value: "native"}, {Value: "jni"}}},
+		{Name: "java-fileIO", Effort: 7, Advice: "use a volume service"},
+		{Name: "java-rmi", Effort: 50},
+	}
+
+	changes := model.DiffRules(local, upstream)
+
+	assert.Equal(t, 3, len(changes))
+	assert.Equal(t, "java-corba", changes[0].Rule)
+	assert.Equal(t, model.RULE_REMOVED, changes[0].Change)
+	assert.Nil(t, changes[0].Upstream)
+	assert.Equal(t, "java-fileIO", changes[1].Rule)
+	assert.Equal(t, model.RULE_MODIFIED, changes[1].Change)
+	assert.Equal(t, []string{"Advice", "Effort"}, changes[1].Fields)
+	assert.Equal(t, "java-rmi", changes[2].Rule)
+	assert.Equal(t, model.RULE_ADDED, changes[2].Change)
+	assert.Equal(t, 50, changes[2].Upstream.Effort)
+}
+
+func TestReadRulePackDir(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "csa-rule-pack")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "java-rmi.yaml"), []byte(`name: java-rmi
+filetype: java
+target: line
+type: regex
+effort: 50
+patterns:
+  - value: java.rmi
+`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "java-rmi.test.yaml"), []byte("rule: java-rmi\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# rules\n"), 0644))
+
+	rules, errs := model.ReadRulePack(dir)
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, len(rules))
+	assert.Equal(t, "java-rmi", rules[0].Name)
+
+	_, errs = model.ReadRulePack(filepath.Join(dir, "missing.tgz"))
+	assert.NotEmpty(t, errs)
+}
@@ -0,0 +1,64 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintRules(t *testing.T) {
+
+	rules := []model.Rule{
+		{Name: "java-jdbc", FileType: "java", Target: model.LINE_TARGET, Type: model.REGEX_MATCH_TYPE, DefaultPattern: `[ .]%s[ (]`,
+			Tags: []model.Tag{{Value: "jdbc"}}, Patterns: []model.Pattern{{Value: "DriverManager"}, {Value: "CallableStatement"}}},
+		{Name: "java-sql", FileType: "(java|jsp)", Target: model.LINE_TARGET, Type: model.REGEX_MATCH_TYPE, DefaultPattern: `[ .]%s[ (]`,
+			Tags: []model.Tag{{Value: "JDBC"}, {Value: "sql"}}, Patterns: []model.Pattern{{Value: "CallableStatement"}}},
+		{Name: "java-driver", FileType: "java", Target: model.LINE_TARGET, Type: model.CONTAINS_MATCH_TYPE,
+			Patterns: []model.Pattern{{Value: "Driver"}}},
+		{Name: "java-driver-start", FileType: "java", Target: model.LINE_TARGET, Type: model.STARTS_WITH_MATCH_TYPE,
+			Patterns: []model.Pattern{{Value: "Driver"}}},
+		//different files, so never double counted
+		{Name: "cs-sql", FileType: "cs", Target: model.LINE_TARGET, Type: model.REGEX_MATCH_TYPE, DefaultPattern: `[ .]%s[ (]`,
+			Tags: []model.Tag{{Value: "jdbc"}}, Patterns: []model.Pattern{{Value: "CallableStatement"}}},
+	}
+
+	overlaps := model.LintRules(rules)
+
+	find := func(rule, other string) *model.RuleOverlap {
+		for i := range overlaps {
+			if overlaps[i].Rule == rule && overlaps[i].OtherRule == other {
+				return &overlaps[i]
+			}
+		}
+		return nil
+	}
+
+	duplicate := find("java-jdbc", "java-sql")
+	if assert.NotNil(t, duplicate) {
+		assert.Equal(t, model.OVERLAP_DUPLICATE, duplicate.Overlap)
+		assert.Equal(t, []string{"jdbc"}, duplicate.SharedTags, "tags are compared case insensitively")
+		assert.Equal(t, " CallableStatement ", duplicate.Sample)
+	}
+	assert.Equal(t, duplicate, &overlaps[0], "double counting is listed first")
+
+	subsumes := find("java-driver", "java-driver-start")
+	if assert.NotNil(t, subsumes) {
+		assert.Equal(t, model.OVERLAP_SUBSUMES, subsumes.Overlap)
+		assert.Empty(t, subsumes.SharedTags)
+	}
+	assert.Nil(t, find("java-driver-start", "java-driver"), "starts-with doesn't match everything contains does")
+
+	assert.NotNil(t, find("java-driver", "java-jdbc"), "every line the regex matches contains Driver")
+
+	for _, overlap := range overlaps {
+		assert.NotEqual(t, "cs-sql", overlap.Rule)
+		assert.NotEqual(t, "cs-sql", overlap.OtherRule)
+	}
+}
@@ -0,0 +1,40 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleMetricSlowestPattern(t *testing.T) {
+
+	app1 := &model.RuleMetric{Rule: "java-jndi"}
+	app1.AccumulatePattern("InitialContext", 3*time.Millisecond)
+	app1.AccumulatePattern(`(\w+)*Context$`, 2*time.Millisecond)
+	app1.Accumulate(2, 1, 6*time.Millisecond)
+
+	app2 := &model.RuleMetric{Rule: "java-jndi"}
+	app2.AccumulatePattern(`(\w+)*Context$`, 4*time.Millisecond)
+	app2.Accumulate(2, 0, 5*time.Millisecond)
+
+	app1.Merge(app2)
+	app1.PrePersist()
+
+	assert.Equal(t, `(\w+)*Context$`, app1.SlowestPattern)
+	assert.Equal(t, 6*time.Millisecond, app1.SlowestPatTime)
+	assert.Equal(t, "6ms", app1.SlowestPatStr)
+	assert.Equal(t, 11*time.Millisecond, app1.TotalTime)
+	assert.Equal(t, int64(4), app1.PatternChecks)
+
+	idle := &model.RuleMetric{Rule: "java-rmi"}
+	idle.PrePersist()
+	assert.Empty(t, idle.SlowestPattern)
+}
@@ -0,0 +1,84 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadRuleOverrides(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "overrides")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "acme.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("rules:\n  java-jni:\n    effort: 20\n"), 0644))
+
+	overrides, err := model.LoadRuleOverrides(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", overrides.Name, "the name defaults to the file name")
+	assert.Len(t, overrides.Digest, 64)
+	assert.Equal(t, 20, *overrides.Rules["java-jni"].Effort)
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("rules:\n  java-jni:\n    effort: -1\n"), 0644))
+	_, err = model.LoadRuleOverrides(file)
+	assert.Error(t, err, "negative effort")
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("rules:\n  java-jni:\n    efort: 1\n"), 0644))
+	_, err = model.LoadRuleOverrides(file)
+	assert.Error(t, err, "unknown keys are rejected")
+}
+
+func TestRuleOverridesApply(t *testing.T) {
+
+	effort := func(e int) *int { return &e }
+
+	overrides := &model.RuleOverrides{Rules: map[string]model.RuleOverride{
+		"java-jni": {Effort: effort(20), Advice: "use the bridge service"},
+		"java-fileIO": {Patterns: map[string]model.PatternOverride{
+			"FileOutputStream": {Effort: effort(8), Advice: "write to a volume"},
+		}},
+		"java-missing": {Effort: effort(1)},
+	}}
+
+	rules := []model.Rule{
+		{Name: "java-jni", Effort: 10, Advice: "avoid jni", Patterns: []model.Pattern{
+			{Value: "loadLibrary", Effort: 50, Advice: "avoid native libraries"},
+			{Value: "native"},
+		}},
+		{Name: "java-fileIO", Effort: 5, Patterns: []model.Pattern{
+			{Value: "FileOutputStream"},
+			{Value: "FileInputStream"},
+		}},
+		{Name: "java-corba", Effort: 100},
+	}
+
+	unknown := overrides.Apply(rules)
+
+	assert.Equal(t, []string{"java-missing"}, unknown)
+
+	assert.Equal(t, 20, rules[0].Effort)
+	assert.Equal(t, "use the bridge service", rules[0].Advice)
+	assert.Equal(t, 20, rules[0].Patterns[0].Effort, "pattern effort would otherwise win over the overridden rule effort")
+	assert.Equal(t, "use the bridge service", rules[0].Patterns[0].Advice)
+	assert.Equal(t, 0, rules[0].Patterns[1].Effort, "patterns without their own effort keep using the rule's")
+	assert.Equal(t, "", rules[0].Patterns[1].Advice)
+
+	assert.Equal(t, 5, rules[1].Effort)
+	assert.Equal(t, 8, rules[1].Patterns[0].Effort)
+	assert.Equal(t, "write to a volume", rules[1].Patterns[0].Advice)
+	assert.Equal(t, 0, rules[1].Patterns[1].Effort)
+
+	assert.Equal(t, 100, rules[2].Effort)
+}
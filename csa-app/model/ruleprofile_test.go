@@ -0,0 +1,81 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBuiltinRuleProfile(t *testing.T) {
+
+	profile, err := model.LoadRuleProfile("openshift", "does-not-exist")
+	assert.NoError(t, err)
+
+	//Inherited from kubernetes
+	assert.Contains(t, profile.ExcludeTags, "cloud-foundry")
+	assert.Equal(t, 0.5, profile.Weights["port-usage"])
+	assert.Equal(t, 2.0, profile.Weights["sudo"])
+
+	_, err = model.LoadRuleProfile("mainframe", "does-not-exist")
+	assert.Error(t, err)
+}
+
+func TestRuleProfileFilesOverrideBuiltins(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "csa-profiles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "tkg.yaml"), []byte(`name: tkg
+extends: kubernetes
+scoring-model: tkg-model
+exclude-rules:
+  - java-jni
+weights:
+  port-usage: 1
+`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "loop.yaml"), []byte("name: loop\nextends: loop\n"), 0644))
+
+	profile, err := model.LoadRuleProfile("tkg", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "tkg-model", profile.ScoringModel)
+	assert.Equal(t, []string{"java-jni"}, profile.ExcludeRules)
+	//Closest profile wins
+	assert.Equal(t, 1.0, profile.Weights["port-usage"])
+	assert.Equal(t, 0.5, profile.Weights["file"])
+
+	_, err = model.LoadRuleProfile("loop", dir)
+	assert.Error(t, err)
+}
+
+func TestRuleProfileApply(t *testing.T) {
+
+	profile := &model.RuleProfile{Name: "test", ExcludeRules: []string{"java-jni"}, ExcludeTags: []string{"cloud-foundry"},
+		Weights: map[string]float64{"port-usage": 0.5, "https": 0.5}}
+
+	rules := []model.Rule{
+		{Name: "java-jni", Effort: 10},
+		{Name: "python-cf", Effort: 10, Tags: []model.Tag{{Value: "Cloud-Foundry"}}},
+		{Name: "java-portUsage", Effort: 10, Tags: []model.Tag{{Value: "port-usage"}, {Value: "https"}},
+			Patterns: []model.Pattern{{Value: "ServerSocket", Effort: 7}}},
+		{Name: "java-fileIO", Effort: 5},
+	}
+
+	applied := profile.Apply(rules)
+
+	assert.Equal(t, 2, len(applied))
+	assert.Equal(t, "java-portUsage", applied[0].Name)
+	assert.Equal(t, 3, applied[0].Effort)
+	assert.Equal(t, 2, applied[0].Patterns[0].Effort)
+	assert.Equal(t, 5, applied[1].Effort)
+}
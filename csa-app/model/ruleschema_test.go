@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishedRuleSchemaIsCurrent(t *testing.T) {
+	published, err := ioutil.ReadFile("../../doc/csa-rule.schema.json")
+	assert.NoError(t, err)
+
+	var expected, actual interface{}
+	assert.NoError(t, json.Unmarshal([]byte(model.RULE_SCHEMA), &expected))
+	assert.NoError(t, json.Unmarshal(published, &actual))
+	assert.Equal(t, expected, actual)
+}
+
+func TestValidateRuleFile(t *testing.T) {
+	rules := `name: java-file-io
+filetype: java$
+target: line
+type: regex
+effort: 5
+tags:
+  - value: io
+patterns:
+  - value: FileReader
+---
+name: bad-rule
+target: lines
+type: regex
+effort: lots
+recipe: http://example.com
+patterns:
+  - value: FileWriter
+`
+	valid, errs := model.ValidateRuleFile(strings.NewReader(rules), false)
+
+	assert.Equal(t, 1, len(valid))
+	assert.Equal(t, "java-file-io", valid[0].Name)
+
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, 12, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "rule.target")
+	assert.Equal(t, 14, errs[1].Line)
+	assert.Contains(t, errs[1].Message, "rule.effort")
+	assert.Equal(t, 15, errs[2].Line)
+	assert.Contains(t, errs[2].Message, "unknown field [recipe]")
+}
+
+func TestValidateRuleFileMissingFieldsAndSyntax(t *testing.T) {
+	_, errs := model.ValidateRuleFile(strings.NewReader("name: no-patterns\ntarget: line\ntype: regex\npatterns: []\n"), false)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, 4, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "at least 1")
+
+	_, errs = model.ValidateRuleFile(strings.NewReader("name: broken\ntarget: [line\n"), false)
+	assert.Equal(t, 1, len(errs))
+	assert.True(t, errs[0].Line > 0)
+}
+
+func TestValidateJsonRuleFile(t *testing.T) {
+	rule := `{"Name": "exported", "Target": "line", "Type": "contains", "Patterns": [{"Value": "System.exit"}]}`
+
+	valid, errs := model.ValidateRuleFile(strings.NewReader(rule), true)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, len(valid))
+	assert.Equal(t, "System.exit", valid[0].Patterns[0].Value)
+
+	//Several top level objects in one file
+	rules := rule + "\n{\n  \"Name\": \"second\",\n  \"Target\": \"line\",\n  \"Type\": \"contains\",\n  \"Effort\": \"high\",\n  \"Patterns\": [{\"Value\": \"exit\"}]\n}\n"
+
+	valid, errs = model.ValidateRuleFile(strings.NewReader(rules), true)
+
+	assert.Equal(t, 1, len(valid))
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, 6, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "rule.effort")
+}
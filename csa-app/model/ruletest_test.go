@@ -0,0 +1,58 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRuleTestFile(t *testing.T) {
+	assert.True(t, model.IsRuleTestFile("rules/java-file-io.test.yaml"))
+	assert.True(t, model.IsRuleTestFile("java-file-io.test.yml"))
+	assert.False(t, model.IsRuleTestFile("rules/java-file-io.yaml"))
+	assert.False(t, model.IsRuleTestFile("rules/test.yaml"))
+	assert.Equal(t, "rules/java-file-io.yaml", model.RuleFileForTest("rules/java-file-io.test.yaml")[0])
+}
+
+func TestLoadRuleTests(t *testing.T) {
+	tests := `tests:
+  - filename: Reader.java
+    content: import java.io.FileReader;
+    match: true
+    lines: [1]
+  - name: no-fixture
+    match: false
+`
+	rules := []model.Rule{{Name: "java-file-io"}}
+
+	_, err := model.LoadRuleTests(strings.NewReader(tests), rules)
+	assert.EqualError(t, err, "test [no-fixture] must have a fixture file or inline content with a filename")
+
+	suite, err := model.LoadRuleTests(strings.NewReader(strings.SplitN(tests, "  - name", 2)[0]), rules)
+	assert.NoError(t, err)
+	assert.Len(t, suite.Tests, 1)
+	assert.Equal(t, "java-file-io", suite.Tests[0].Rule)
+	assert.Equal(t, "test-1", suite.Tests[0].Name)
+
+	_, err = model.LoadRuleTests(strings.NewReader(tests), append(rules, model.Rule{Name: "other"}))
+	assert.EqualError(t, err, "test [test-1] must name the rule it exercises")
+}
+
+func TestRuleTestCaseCheck(t *testing.T) {
+	match := &model.RuleTestCase{Match: true, Lines: []int{3, 1}}
+	assert.Equal(t, "", match.Check([]int{1, 3, 3}))
+	assert.Equal(t, "expected matches on line(s) [1 3] but rule matched on line(s) [1]", match.Check([]int{1}))
+	assert.Equal(t, "expected a match but rule did not match", match.Check(nil))
+
+	noMatch := &model.RuleTestCase{Match: false}
+	assert.Equal(t, "", noMatch.Check(nil))
+	assert.Equal(t, "expected no match but rule matched on line(s) [2]", noMatch.Check([]int{2}))
+}
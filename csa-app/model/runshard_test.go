@@ -0,0 +1,60 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRunShards(t *testing.T) {
+
+	run := &model.Run{ID: 12, Applications: []*model.Application{
+		{Name: "billing", Path: "/apps/billing", Files: make([]*util.FileInfo, 2)},
+		{Name: "orders", Path: "/apps/orders", Files: make([]*util.FileInfo, 5)},
+	}}
+
+	shards := model.NewRunShards(run)
+	assert.Len(t, shards, 2)
+	assert.Equal(t, model.RunShard{RunID: 12, Application: "orders", Path: "/apps/orders", Files: 5, Status: model.SHARD_PENDING}, *shards[0],
+		"the largest application first")
+	assert.Equal(t, "billing", shards[1].Application)
+}
+
+func TestShardWatch(t *testing.T) {
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	watch := model.NewShardWatch(time.Minute)
+
+	//The heartbeats are of the workers' clocks, behind the coordinator's
+	shards := []model.RunShard{
+		{ID: 1, Status: model.SHARD_CLAIMED, UpdatedAt: start.Add(-time.Hour)},
+		{ID: 2, Status: model.SHARD_CLAIMED, UpdatedAt: start.Add(-time.Hour)},
+		{ID: 3, Status: model.SHARD_DONE, UpdatedAt: start.Add(-time.Hour)},
+		{ID: 4, Status: model.SHARD_PENDING},
+	}
+	assert.Empty(t, watch.Abandoned(shards, start), "heartbeats are timed from when they are first seen")
+
+	shards[1].UpdatedAt = start.Add(-30 * time.Minute)
+	assert.Empty(t, watch.Abandoned(shards, start.Add(30*time.Second)))
+
+	assert.Equal(t, []uint{1}, watch.Abandoned(shards, start.Add(time.Minute)), "shard 2's heartbeat changed 30s ago")
+	assert.Equal(t, []uint{2}, watch.Abandoned(shards, start.Add(90*time.Second)))
+
+	byStatus, workers := model.ShardTotals([]model.RunShard{
+		{Status: model.SHARD_CLAIMED, Worker: "host-a:12"},
+		{Status: model.SHARD_DONE, Worker: "host-a:12"},
+		{Status: model.SHARD_DONE, Worker: "host-b:7"},
+		{Status: model.SHARD_PENDING},
+	})
+	assert.Equal(t, map[string]int{model.SHARD_CLAIMED: 1, model.SHARD_DONE: 2, model.SHARD_PENDING: 1}, byStatus)
+	assert.Equal(t, 2, workers)
+}
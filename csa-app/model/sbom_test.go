@@ -0,0 +1,50 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSbom(t *testing.T) {
+
+	libraries := []model.AppLibrary{
+		{Application: "orders", Ecosystem: model.ECOSYSTEM_MAVEN, Name: "org.apache.commons/commons-lang3", Version: "3.12.0"},
+		{Application: "orders", Ecosystem: model.ECOSYSTEM_MAVEN, Name: "junit/junit", Version: "4.13.2", Dev: true},
+		{Application: "orders", Ecosystem: "npm", Name: "@angular/core", Version: "14.2.0"},
+		{Application: "billing", Ecosystem: "pypi", Name: "requests", Version: "==2.28.1"},
+	}
+
+	licenses := func(library *model.AppLibrary) (model.ResolvedLicense, bool) {
+		if library.Name == "org.apache.commons/commons-lang3" {
+			return model.ResolvedLicense{License: "Apache-2.0"}, true
+		}
+		return model.ResolvedLicense{}, false
+	}
+
+	sbom := model.NewSbom("orders", libraries, licenses, time.Date(2022, 10, 3, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, "CycloneDX", sbom.BomFormat)
+	assert.Equal(t, "1.5", sbom.SpecVersion)
+	assert.Equal(t, "2022-10-03T12:00:00Z", sbom.Metadata.Timestamp)
+	assert.Equal(t, "orders", sbom.Metadata.Component.Name)
+	assert.Len(t, sbom.Components, 3, "only the application's libraries are components")
+
+	assert.Equal(t, model.SbomComponent{Type: "library", BomRef: "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+		Group: "org.apache.commons", Name: "commons-lang3", Version: "3.12.0", Scope: "required",
+		Purl: "pkg:maven/org.apache.commons/commons-lang3@3.12.0", Licenses: []model.SbomLicense{{License: model.SbomLicenseName{Name: "Apache-2.0"}}}}, sbom.Components[0])
+	assert.Equal(t, "optional", sbom.Components[1].Scope, "dev libraries are optional")
+	assert.Equal(t, "@angular/core", sbom.Components[2].Name)
+	assert.Equal(t, "pkg:npm/%40angular/core@14.2.0", sbom.Components[2].Purl)
+	assert.Empty(t, sbom.Components[2].Licenses)
+
+	assert.Len(t, model.NewSbom("billing", libraries, nil, time.Now()).Components, 1)
+}
@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanCheckpoint(t *testing.T) {
+
+	manifest := []model.ManifestEntry{
+		{ID: 1, Path: "src/Orders.java", Findings: 2},
+		{ID: 2, Path: "src/Billing.java", Findings: 3},
+		{ID: 3, Path: "README.md"},
+		{ID: 4, Path: "src/Orders.java", Findings: 2},
+	}
+
+	findings := []model.Finding{
+		{ID: 10, Fqn: "/apps/orders/src/Orders.java", Rule: "java-jni", Effort: 100},
+		{ID: 11, Fqn: "/apps/orders/src/Orders.java", Category: model.FILE_ANALYZED_CATEGORY},
+		{ID: 12, Fqn: "/apps/orders/src/Billing.java", Rule: "java-file-io", Effort: 5},
+		{ID: 13, Fqn: "/apps/orders/src/Shipping.java", Rule: "java-file-io", Effort: 5},
+	}
+
+	rules := []model.Rule{{Name: "java-jni", Impact: model.APP_IMPACT}, {Name: "java-file-io"}}
+
+	checkpoint := model.NewScanCheckpoint(6, "/apps/orders", manifest, findings, rules)
+
+	assert.True(t, checkpoint.Complete("/apps/orders/src/Orders.java"))
+	assert.True(t, checkpoint.Complete("/apps/orders/README.md"), "files without findings")
+	assert.False(t, checkpoint.Complete("/apps/orders/src/Billing.java"), "not all of its findings were saved")
+	assert.False(t, checkpoint.Complete("/apps/orders/src/Shipping.java"), "its entry wasn't saved")
+	assert.Equal(t, 2, checkpoint.CompleteFiles())
+
+	assert.Equal(t, []uint{10, 11}, findingIds(checkpoint.Findings))
+	assert.Equal(t, []uint{12, 13}, checkpoint.StaleFindings)
+	assert.Equal(t, []uint{2, 4}, checkpoint.StaleEntries, "duplicate entries are stale")
+
+	assert.True(t, checkpoint.ImpactsApp("java-jni"))
+	assert.False(t, checkpoint.ImpactsApp("java-file-io"))
+}
+
+func findingIds(findings []model.Finding) (ids []uint) {
+	for _, finding := range findings {
+		ids = append(ids, finding.ID)
+	}
+	return
+}
@@ -0,0 +1,41 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewManifestEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fqn := filepath.Join(dir, "src", "Main.java")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(fqn), 0755))
+	assert.NoError(t, ioutil.WriteFile(fqn, []byte("hello"), 0644))
+
+	app := &model.Application{Name: "app", Path: dir}
+	entry, err := model.NewManifestEntry(7, app, &util.FileInfo{FQN: fqn}, "Java", 12)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), entry.RunID)
+	assert.Equal(t, "src/Main.java", entry.Path)
+	assert.Equal(t, int64(5), entry.Size)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", entry.Sha256)
+	assert.Equal(t, 12, entry.RulesApplied)
+
+	_, err = model.NewManifestEntry(7, app, &util.FileInfo{FQN: filepath.Join(dir, "missing.java")}, "Java", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanProgress(t *testing.T) {
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	apps := []*model.Application{
+		{Name: "orders", Files: make([]*util.FileInfo, 4)},
+		{Name: "billing", Files: make([]*util.FileInfo, 2)},
+		{Name: "inventory", Files: make([]*util.FileInfo, 4)},
+	}
+	progress := model.NewScanProgress(apps, start)
+
+	progress.FileAnalyzed("orders", 1<<20)
+	progress.FileAnalyzed("orders", 1<<20)
+	progress.FileAnalyzed("billing", 1<<20)
+	progress.FileAnalyzed("billing", 1<<20)
+
+	snapshot := progress.Snapshot(start.Add(2 * time.Second))
+	assert.Equal(t, 10, snapshot.Files)
+	assert.Equal(t, 4, snapshot.Analyzed)
+	assert.Equal(t, 40.0, snapshot.Percent)
+	assert.Equal(t, 2.0, snapshot.FilesPerSecond)
+	assert.Equal(t, 2.0, snapshot.MBPerSecond)
+	assert.Equal(t, 3, snapshot.ETA, "6 files left at 2 files/s")
+	assert.Equal(t, 1, snapshot.AppsAnalyzed)
+	assert.Equal(t, []model.AppProgress{{Application: "orders", Files: 4, Analyzed: 2}}, snapshot.InProgress)
+	assert.Equal(t, "4/10 files (40.0%) | 2.0 files/s | 2.0 MB/s | ETA 3s | apps 1/3 orders 2/4", snapshot.String())
+
+	progress.FileAnalyzed("inventory", 0)
+
+	snapshot = progress.Snapshot(start.Add(4 * time.Second))
+	assert.Equal(t, 0.5, snapshot.FilesPerSecond, "throughput since the previous snapshot")
+	assert.Equal(t, 0.0, snapshot.MBPerSecond, "files carried over aren't read")
+	assert.Equal(t, 4.0, snapshot.MB)
+	assert.Equal(t, 4, snapshot.ETA, "from the throughput since the start")
+	assert.Len(t, snapshot.InProgress, 2)
+}
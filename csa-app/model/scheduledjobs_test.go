@@ -0,0 +1,37 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateScheduledJobs(t *testing.T) {
+
+	tagTotals := model.TagTotals{
+		"cron":                 {Findings: 2, Effort: 10},
+		"quartz":               {Findings: 4, Effort: 40},
+		"in-process-scheduler": {Findings: 1, Effort: 5},
+		"spring-scheduling":    {Findings: 2, Effort: 10},
+		"spring":               {Findings: 9, Effort: 9},
+	}
+
+	jobs := model.EvaluateScheduledJobs("orders", tagTotals, model.Schedulers)
+	assert.Equal(t, 9, jobs.Findings)
+	assert.Equal(t, 65, jobs.Effort)
+	assert.Equal(t, []string{"quartz", "in-process", "cron"}, []string{jobs.Schedulers[0].Scheduler, jobs.Schedulers[1].Scheduler,
+		jobs.Schedulers[2].Scheduler}, "in the order of the schedulers")
+	assert.Equal(t, model.SchedulerResult{Scheduler: "in-process", Replacement: "A lock so each run happens once (ShedLock), or a Kubernetes CronJob",
+		Findings: 3, Effort: 15, Tags: []string{"in-process-scheduler", "spring-scheduling"}}, jobs.Schedulers[1], "the tags of a scheduler add up")
+
+	none := model.EvaluateScheduledJobs("inventory", nil, model.Schedulers)
+	assert.Equal(t, 0, none.Findings)
+	assert.Empty(t, none.Schedulers)
+}
@@ -0,0 +1,58 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fiveTierBins() []model.ScoreBin {
+	return []model.ScoreBin{
+		{Name: "tier-5", Color: "red", MinScore: 0, MaxScore: 2},
+		{Name: "tier-3", Color: "#ffcc00", MinScore: 4, MaxScore: 6},
+		{Name: "tier-4", Color: "#f80", MinScore: 2, MaxScore: 4},
+		{Name: "tier-2", Color: "#99cc00", MinScore: 6, MaxScore: 8},
+		{Name: "tier-1", Color: "green", MinScore: 8, MaxScore: 10},
+	}
+}
+
+func TestScoreBinFor(t *testing.T) {
+
+	bins := fiveTierBins()
+	assert.NoError(t, model.ValidateScoreBins(bins))
+
+	assert.Equal(t, "tier-5", model.ScoreBinName(bins, 0))
+	assert.Equal(t, "tier-4", model.ScoreBinName(bins, 2), "a bin starts at its min score")
+	assert.Equal(t, "tier-3", model.ScoreBinName(bins, 5.99))
+	assert.Equal(t, "tier-1", model.ScoreBinName(bins, 10), "the highest bin holds its max score")
+	assert.Equal(t, "", model.ScoreBinName(bins, 10.5))
+	assert.Equal(t, "", model.ScoreBinName(nil, 5))
+
+	assert.Equal(t, "#ffcc00", model.ScoreBinFor(bins, 4.5).Color)
+	assert.Nil(t, model.ScoreBinFor(bins, -1))
+
+	assert.NoError(t, model.ValidateScoreBins(model.DefaultScoreBins()))
+}
+
+func TestValidateScoreBins(t *testing.T) {
+
+	overlapping := append(fiveTierBins(), model.ScoreBin{Name: "tier-0", MinScore: 9.5, MaxScore: 10})
+	assert.Error(t, model.ValidateScoreBins(overlapping))
+
+	duplicate := append(fiveTierBins(), model.ScoreBin{Name: "Tier-5", MinScore: 10, MaxScore: 11})
+	assert.Error(t, model.ValidateScoreBins(duplicate))
+
+	//Gaps are allowed
+	assert.NoError(t, model.ValidateScoreBins([]model.ScoreBin{{Name: "low", MinScore: 0, MaxScore: 3}, {Name: "high", MinScore: 7, MaxScore: 10}}))
+
+	assert.Error(t, (&model.ScoreBin{Name: "empty", MinScore: 5, MaxScore: 5}).Validate())
+	assert.Error(t, (&model.ScoreBin{Name: " ", MinScore: 0, MaxScore: 5}).Validate())
+	assert.Error(t, (&model.ScoreBin{Name: "bad-color", Color: "#12345", MinScore: 0, MaxScore: 5}).Validate())
+}
@@ -0,0 +1,68 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scoreExplanationContributions() []*model.ScoreContribution {
+	return []*model.ScoreContribution{
+		{Rule: "log4j", Category: "logging", Findings: 9, Effort: 18, Totals: &model.WhatIfTotals{Findings: 9, RawScore: 18}},
+		{Rule: "ejb-remote", Category: "ejb", Findings: 99, Effort: 1900, Totals: &model.WhatIfTotals{Findings: 99, RawScore: 1900}},
+		{Rule: "ejb-home", Category: "ejb", Findings: 8, Effort: 80, Totals: &model.WhatIfTotals{Findings: 8, RawScore: 80}},
+	}
+}
+
+func TestExplainScore(t *testing.T) {
+
+	apps := scorerTestApps()
+	legacy := apps[3]
+	scorer, _ := model.GetScorer(model.LOG_SCORER)
+
+	explanation, err := model.ExplainScore(legacy, apps, scoreExplanationContributions(), scorer)
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", explanation.Application)
+	assert.Equal(t, model.LOG_SCORER, explanation.Scorer)
+	assert.Equal(t, 1998, explanation.RawScore)
+	assert.Equal(t, 0.0, explanation.Score)
+	assert.Equal(t, 10.0, explanation.MaxScore)
+
+	//Resolving ejb leaves 18 points, 9 per kloc
+	assert.Len(t, explanation.Categories, 2)
+	assert.Equal(t, "ejb", explanation.Categories[0].Category)
+	assert.Equal(t, 1980, explanation.Categories[0].Effort)
+	assert.InDelta(t, 6.67, explanation.Categories[0].Impact, 0.0001)
+	assert.InDelta(t, 99.1, explanation.Categories[0].Share, 0.01)
+	assert.Equal(t, "logging", explanation.Categories[1].Category)
+
+	assert.Len(t, explanation.Rules, 3)
+	assert.Equal(t, "ejb-remote", explanation.Rules[0].Rule, "the costliest rule comes first")
+	assert.InDelta(t, 4.34, explanation.Rules[0].Impact, 0.0001)
+	assert.Equal(t, "log4j", explanation.Rules[2].Rule)
+	assert.InDelta(t, 0.01, explanation.Rules[2].Impact, 0.0001, "points barely moving the score have little impact")
+
+	assert.Equal(t, 1998, legacy.RawScore, "the app is left as is")
+	assert.Equal(t, 0.0, legacy.Score)
+}
+
+func TestExplainScoreAgainstOtherApps(t *testing.T) {
+
+	apps := scorerTestApps()
+	scorer, _ := model.GetScorer(model.PERCENTILE_SCORER)
+
+	explanation, err := model.ExplainScore(apps[3], apps, scoreExplanationContributions(), scorer)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, explanation.Score, "legacy ranks last")
+
+	//Resolving ejb ties legacy with small and large, only clean does better
+	assert.InDelta(t, 3.33, explanation.Categories[0].Impact, 0.0001)
+	assert.Equal(t, 0.0, apps[0].Score, "the other apps are left as is")
+}
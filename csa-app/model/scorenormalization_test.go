@@ -0,0 +1,53 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizedRawScore(t *testing.T) {
+
+	app := &model.Application{RawScore: 900, SlocCnt: 100000, FilesCnt: 450}
+	assert.Equal(t, 900, model.NormalizedRawScore(app, model.NORMALIZE_NONE))
+	assert.Equal(t, 9, model.NormalizedRawScore(app, model.NORMALIZE_SLOC))
+	assert.Equal(t, 200, model.NormalizedRawScore(app, model.NORMALIZE_FILES))
+
+	//Small apps aren't inflated
+	small := &model.Application{RawScore: 9, SlocCnt: 500, FilesCnt: 12}
+	assert.Equal(t, 9, model.NormalizedRawScore(small, model.NORMALIZE_SLOC))
+	assert.Equal(t, 9, model.NormalizedRawScore(small, model.NORMALIZE_FILES))
+
+	assert.Error(t, model.ValidateNormalization("kloc"))
+}
+
+func TestNormalizedScorer(t *testing.T) {
+
+	apps := scorerTestApps()
+	scorer, _ := model.GetScorer(model.PERCENTILE_SCORER)
+	scorer, err := model.NormalizeScorer(scorer, model.NORMALIZE_SLOC)
+	assert.NoError(t, err)
+	assert.Equal(t, model.PERCENTILE_SCORER, scorer.Name())
+
+	assert.NoError(t, scorer.Score(apps))
+
+	//Normalized once, small and large are both at 9 per 1000 lines
+	assert.Equal(t, apps[1].Score, apps[2].Score)
+	assert.Equal(t, 900, apps[2].RawScore, "the raw score is restored")
+
+	run := &model.Run{Scorer: model.LOG_SCORER, Normalize: model.NORMALIZE_FILES}
+	scorer, err = model.RunScorer(run)
+	assert.NoError(t, err)
+	assert.Equal(t, model.LOG_SCORER, scorer.Name())
+
+	run.Normalize = "pages"
+	_, err = model.RunScorer(run)
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scorerTestApps() []*model.Application {
+	scoringModel := &model.ScoringModel{Name: "test", MaxScore: 10, MinScore: 0}
+	scoringModel.AddRangeWithOutcome(model.SLOC_BKT_TYPE, 0, int(^uint(0)>>1), 0, "Refactor")
+
+	return []*model.Application{
+		{Name: "clean", SlocCnt: 20000, RawScore: 0, Model: scoringModel},
+		{Name: "small", SlocCnt: 500, RawScore: 9, Model: scoringModel},
+		{Name: "large", SlocCnt: 100000, RawScore: 900, Model: scoringModel},
+		{Name: "legacy", SlocCnt: 2000, RawScore: 1998, Model: scoringModel},
+	}
+}
+
+func TestGetScorer(t *testing.T) {
+
+	scorer, err := model.GetScorer("")
+	assert.NoError(t, err)
+	assert.Equal(t, model.DEFAULT_SCORER, scorer.Name())
+
+	scorer, err = model.GetScorer("Percentile")
+	assert.NoError(t, err)
+	assert.Equal(t, model.PERCENTILE_SCORER, scorer.Name())
+
+	_, err = model.GetScorer("linear")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"default", "logarithmic", "percentile"}, model.ScorerNames())
+}
+
+func TestLogarithmicScorer(t *testing.T) {
+
+	apps := scorerTestApps()
+	scorer, _ := model.GetScorer(model.LOG_SCORER)
+	assert.NoError(t, scorer.Score(apps))
+
+	//9 per kloc for both small and large, the ceiling (999 per kloc) for legacy
+	assert.Equal(t, 10.0, apps[0].Score)
+	assert.Equal(t, 6.67, apps[1].Score)
+	assert.Equal(t, apps[1].Score, apps[2].Score)
+	assert.Equal(t, 0.0, apps[3].Score)
+	assert.Equal(t, "Refactor", apps[3].Recommendation)
+}
+
+func TestPercentileScorer(t *testing.T) {
+
+	apps := scorerTestApps()
+	scorer, _ := model.GetScorer(model.PERCENTILE_SCORER)
+	assert.NoError(t, scorer.Score(apps))
+
+	assert.Equal(t, 10.0, apps[0].Score)
+	assert.Equal(t, 5.0, apps[1].Score)
+	assert.Equal(t, 5.0, apps[2].Score)
+	assert.Equal(t, 0.0, apps[3].Score)
+
+	alone := apps[3:]
+	assert.NoError(t, scorer.Score(alone))
+	assert.Equal(t, 10.0, alone[0].Score)
+
+	alone[0].Model = nil
+	assert.Error(t, scorer.Score(alone))
+}
+
+//The recommendation comes from the scoring model's buckets the raw score (with sloc and business value) falls in. The
+//scorer only moves the score, so the recommendation doesn't follow it.
+func TestScorersKeepTheRecommendationOfTheRawScore(t *testing.T) {
+
+	scoringModel := &model.ScoringModel{Name: "raw-buckets", MaxScore: 10, MinScore: 0}
+	sloc := scoringModel.AddRange(model.SLOC_BKT_TYPE, 0, int(^uint(0)>>1))
+	sloc.AddRangeWithOutcome(model.RAW_BKT_TYPE, 0, 100, 0, "Rehost")
+	sloc.AddRangeWithOutcome(model.RAW_BKT_TYPE, 101, int(^uint(0)>>1), 0, "Refactor")
+
+	for _, name := range model.ScorerNames() {
+		apps := []*model.Application{
+			{Name: "dense", SlocCnt: 1000, RawScore: 90, Model: scoringModel},
+			{Name: "sparse", SlocCnt: 100000, RawScore: 900, Model: scoringModel},
+		}
+
+		scorer, _ := model.GetScorer(name)
+		assert.NoError(t, scorer.Score(apps))
+		assert.Equal(t, "Rehost", apps[0].Recommendation, name)
+		assert.Equal(t, "Refactor", apps[1].Recommendation, name)
+
+		if name == model.LOG_SCORER {
+			assert.True(t, apps[1].Score > apps[0].Score, "sparse scores better yet is still recommended a refactor")
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoringFormulaValidation(t *testing.T) {
+
+	formula := &model.ScoringFormula{Name: "jms", Formula: "max_score - log(10, 1 + effort_jms + findings_spring_boot) - high_severity_cnt"}
+	assert.NoError(t, formula.Validate())
+
+	formula.Formula = "max_score - lines"
+	assert.Error(t, formula.Validate(), "unknown variable")
+
+	formula.Formula = "max_score - ("
+	assert.Error(t, formula.Validate(), "syntax error")
+
+	formula.Formula = " "
+	assert.Error(t, formula.Validate(), "no formula")
+
+	assert.Equal(t, "spring_boot", model.FormulaTagVariable("Spring-Boot"))
+}
+
+func TestFormulaScorer(t *testing.T) {
+
+	apps := scorerTestApps()
+	apps[2].TagTotals = model.TagTotals{"jms": {Findings: 3, Effort: 90}, "ejb-2": {Findings: 1, Effort: 9}}
+
+	formula := &model.ScoringFormula{Name: "jms", Formula: "max_score - (effort_jms + effort_ejb_2) / 10 - findings_jms"}
+	scorer := model.NewFormulaScorer(formula)
+	assert.Equal(t, model.FORMULA_SCORER, scorer.Name())
+	assert.NoError(t, scorer.Score(apps))
+
+	//Apps without jms findings count them as 0
+	assert.Equal(t, 10.0, apps[0].Score)
+	assert.Equal(t, 0.0, apps[2].Score, "kept within the model's bounds")
+	assert.Equal(t, "Refactor", apps[2].Recommendation)
+
+	formula.Formula = "max_score - findings_jms / 2"
+	assert.NoError(t, scorer.Score(apps))
+	assert.Equal(t, 8.5, apps[2].Score)
+
+	formula.Formula = "log(10, raw_score)"
+	assert.Error(t, scorer.Score(apps), "log of a zero raw score")
+}
+
+func TestLoadScoringFormula(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "csa-formula")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "density.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("formula: max_score - log(10, 1 + raw_score / max(1, sloc_cnt / 1000))\n"), 0644))
+
+	formula, err := model.LoadScoringFormula(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "density", formula.Name)
+
+	scorer, err := model.ScorerFor(model.FORMULA_SCORER, formula.Formula)
+	assert.NoError(t, err)
+	assert.Equal(t, model.FORMULA_SCORER, scorer.Name())
+
+	_, err = model.ScorerFor(model.FORMULA_SCORER, "")
+	assert.Error(t, err)
+	_, err = model.GetScorer(model.FORMULA_SCORER)
+	assert.Error(t, err)
+}
@@ -221,17 +221,16 @@ func TestScoreModelReturnsCorrectOutcome(t *testing.T) {
 
 func TestExport(t *testing.T) {
 
-	fu := util.NewFileUtil()
 	//Establish Temp Directory for Run
-	tmpPath, err := fu.EstablishTempDirectory("")
+	workspace, err := util.NewWorkspace("", "test-export", 0)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Unable to create temp directory. Details: %v", err)
-		assert.Fail(t, "Failed to create temp directory!")
-	} else {
-		fmt.Printf("Created Tmp Dir => %s\n", tmpPath)
+		assert.FailNow(t, "Failed to create temp directory!")
 	}
+	tmpPath := workspace.Root
+	fmt.Printf("Created Tmp Dir => %s\n", tmpPath)
 
-	defer fu.RemoveDir(tmpPath)
+	defer workspace.Close()
 
 	*util.ModelsDir = util.DEFAULT_MODELS_DIR
 	*util.OutputDir = tmpPath
@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunScript(t *testing.T) {
+
+	input := &model.ScriptInput{
+		Path:    "/src/Cache.java",
+		Name:    "Cache.java",
+		Ext:     "java",
+		Content: "synchronized(a) {}\nsynchronized(b) {}\nsynchronized(c) {}\n",
+		Hits:    map[string]int{"java-static": 2},
+	}
+
+	rule := model.Rule{Name: "heavy-sync", Target: model.CONTENTS_TARGET, Type: model.SCRIPT_MATCH_TYPE, Effort: 4,
+		Script: "count('synchronized') > 2 && matched('java-static') > 0"}
+	_, err := rule.IsValid()
+	assert.NoError(t, err)
+
+	result, err := rule.RunScript(input)
+	assert.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Equal(t, 4, result.Effort)
+
+	//Numeric results are the effort
+	rule.Script = "count('synchronized') * 2"
+	result, err = rule.RunScript(input)
+	assert.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Equal(t, 6, result.Effort)
+
+	rule.Script = "lines > 100"
+	result, err = rule.RunScript(input)
+	assert.NoError(t, err)
+	assert.False(t, result.Matched)
+
+	rule.Script = "ext == 'java' && content.matches('synchronized[(]c[)]')"
+	result, err = rule.RunScript(input)
+	assert.NoError(t, err)
+	assert.True(t, result.Matched)
+
+	rule.Script = "path"
+	_, err = rule.RunScript(input)
+	assert.Error(t, err)
+}
+
+func TestScriptRuleIsValid(t *testing.T) {
+
+	rule := model.Rule{Name: "bad", Target: model.LINE_TARGET, Type: model.SCRIPT_MATCH_TYPE, Script: "true"}
+	_, err := rule.IsValid()
+	assert.Error(t, err)
+
+	rule.Target = model.CONTENTS_TARGET
+	rule.Script = "count('a' >"
+	_, err = rule.IsValid()
+	assert.Error(t, err)
+
+	//Scripts are type checked
+	rule.Script = "count('a') > 'b'"
+	_, err = rule.IsValid()
+	assert.Error(t, err)
+
+	rule.Script = "name"
+	_, err = rule.IsValid()
+	assert.Error(t, err)
+}
+
+func TestCompiledScriptIsBoundToEachFile(t *testing.T) {
+
+	rule := model.Rule{Name: "many-threads", Target: model.CONTENTS_TARGET, Type: model.SCRIPT_MATCH_TYPE, Effort: 1,
+		Script: "count('new Thread') + matched('java-thread')"}
+	rule.CompilePatterns()
+
+	few := &model.ScriptInput{Content: "new Thread()", Hits: map[string]int{}}
+	many := &model.ScriptInput{Content: "new Thread()\nnew Thread()\n", Hits: map[string]int{"java-thread": 3}}
+
+	result, err := rule.RunScript(many)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.Effort)
+
+	result, err = rule.RunScript(few)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Effort)
+}
@@ -0,0 +1,75 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCweTags(t *testing.T) {
+	id, ok := model.CweId("CWE-89")
+	assert.True(t, ok)
+	assert.Equal(t, 89, id)
+	assert.Equal(t, "CWE-89", model.CweLabel(id))
+
+	_, ok = model.CweId("cwe-sql")
+	assert.False(t, ok)
+
+	assert.NoError(t, model.ValidateCweTag("cwe-798"))
+	assert.NoError(t, model.ValidateCweTag("security"), "other tags are left alone")
+	assert.Error(t, model.ValidateCweTag("cwe-"))
+	assert.Error(t, model.ValidateCweTag("cwe-089"))
+}
+
+func TestGroupByCwe(t *testing.T) {
+
+	tags := func(values ...string) (tags []model.FindingTag) {
+		for _, value := range values {
+			tags = append(tags, model.FindingTag{Value: value})
+		}
+		return tags
+	}
+
+	findings := []model.Finding{
+		{Rule: "sql-concat", Application: "orders", Severity: "high", Effort: 5, Tags: tags("security", "cwe-89")},
+		{Rule: "sql-concat", Application: "billing", Severity: "medium", Effort: 5, Tags: tags("cwe-89")},
+		{Rule: "hardcoded-password", Application: "orders", Severity: "critical", Effort: 3, Tags: tags("cwe-798", "cwe-259")},
+		{Rule: "md5", Application: "orders", Effort: 1, Category: "security"},
+		{Rule: "jndi", Application: "orders", Severity: "critical", Effort: 8, Tags: tags("jndi")},
+	}
+
+	cwes := model.GroupByCwe(findings)
+
+	var order []string
+	for _, cwe := range cwes {
+		order = append(order, cwe.Cwe)
+	}
+	assert.Equal(t, []string{"CWE-259", "CWE-798", "CWE-89", model.CWE_UNMAPPED}, order, "most severe first, unmapped last")
+
+	sql := cwes[2]
+	assert.Equal(t, "SQL Injection", sql.Name)
+	assert.Equal(t, "high", sql.Severity)
+	assert.Equal(t, 2, sql.Findings)
+	assert.Equal(t, 10, sql.Effort)
+	assert.Equal(t, map[string]int{"high": 1, "medium": 1}, sql.Severities)
+	assert.Equal(t, []string{"billing", "orders"}, sql.Applications)
+
+	assert.Equal(t, []string{"md5"}, cwes[3].Rules, "categorized security without a cwe")
+	assert.Equal(t, "", cwes[3].Severity)
+}
+
+func TestCweTagsAreRegistered(t *testing.T) {
+	usage := model.BuildTagUsage(map[string]int{"cwe-78": 1, "cwe-79": 1, "cwe-x": 1}, nil, nil)
+
+	assert.Equal(t, "cwe-78", usage[0].Tag)
+	assert.True(t, usage[0].Registered)
+	assert.Empty(t, usage[0].Similar, "distinct weaknesses aren't typos of each other")
+	assert.False(t, usage[2].Registered, "malformed cwe tags aren't")
+}
@@ -0,0 +1,44 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarTags(t *testing.T) {
+	assert.True(t, model.SimilarTags("code-smell", "code_smell"))
+	assert.True(t, model.SimilarTags("transaction", "trasaction"))
+	assert.True(t, model.SimilarTags("websocket", "websockets"))
+	assert.False(t, model.SimilarTags("jms", "jmx"), "short tags need more than one edit")
+	assert.False(t, model.SimilarTags("logging", "tagging"))
+}
+
+func TestBuildTagUsage(t *testing.T) {
+
+	usage := model.BuildTagUsage(map[string]int{"JMS": 2, "cache": 1}, map[string]int{"jms": 5, "cahce": 1},
+		[]model.TaxonomyTag{{Name: "jms"}, {Name: "security"}})
+
+	assert.Equal(t, []model.TagUsage{
+		{Tag: "cache", Rules: 1},
+		{Tag: "cahce", Findings: 1},
+		{Tag: "jms", Rules: 2, Findings: 5, Registered: true},
+		{Tag: "security", Registered: true},
+	}, usage)
+}
+
+func TestRuleTagsFor(t *testing.T) {
+
+	rule := model.Rule{Tags: []model.Tag{{Value: "Messaging"}, {Value: "jms"}},
+		Patterns: []model.Pattern{{Value: "javax.jms", Tag: "jms"}, {Value: "org.apache.activemq", Tag: "activemq"}}}
+
+	assert.Equal(t, []string{"messaging", "jms"}, rule.TagsFor("javax.jms"))
+	assert.Equal(t, []string{"messaging", "jms", "activemq"}, rule.TagsFor("org.apache.activemq"))
+}
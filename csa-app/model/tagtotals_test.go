@@ -0,0 +1,35 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagTotalsGroup(t *testing.T) {
+
+	totals := model.TagTotals{
+		"Quartz": {Findings: 1, Effort: 10},
+		"quartz": {Findings: 3, Effort: 30},
+		"cron":   {Findings: 2, Effort: 10},
+		"ejb":    {Findings: 0, Effort: 0},
+	}.LowerCased()
+
+	assert.Equal(t, model.TagTotals{"quartz": {Findings: 4, Effort: 40}, "cron": {Findings: 2, Effort: 10}, "ejb": {}}, totals,
+		"tags of any case are merged")
+
+	group, found := totals.Group([]string{"quartz", "ejb", "cron", "at"})
+	assert.Equal(t, model.TagTotal{Findings: 6, Effort: 50}, group)
+	assert.Equal(t, []string{"cron", "quartz"}, found, "the tags with findings, sorted")
+
+	group, found = totals.Group([]string{"at"})
+	assert.Equal(t, model.TagTotal{}, group)
+	assert.Empty(t, found)
+}
@@ -0,0 +1,78 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTagWeights(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "weights")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "acme.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("weights:\n  JNI: 3\n  logging: 0.5\n"), 0644))
+
+	weights, err := model.LoadTagWeights(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", weights.Name, "the name defaults to the file name")
+	assert.Len(t, weights.Digest, 64)
+	assert.Equal(t, map[string]float64{"jni": 3, "logging": 0.5}, weights.Weights, "tags are lower cased")
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("weights:\n  jni: -1\n"), 0644))
+	_, err = model.LoadTagWeights(file)
+	assert.Error(t, err, "negative weight")
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("weights:\n  jni: 2\n  Jni: 3\n"), 0644))
+	_, err = model.LoadTagWeights(file)
+	assert.Error(t, err, "tag weighted twice")
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("weight:\n  jni: 2\n"), 0644))
+	_, err = model.LoadTagWeights(file)
+	assert.Error(t, err, "unknown keys are rejected")
+}
+
+func TestApplyTagWeights(t *testing.T) {
+
+	weights := &model.TagWeights{Name: "acme", Weights: map[string]float64{"jni": 3, "logging": 0.5, "native": 2}}
+
+	finding := &model.Finding{Effort: 5}
+	finding.AddTag("JNI")
+	assert.True(t, weights.Apply(finding))
+	assert.Equal(t, 15, finding.Effort)
+	assert.Equal(t, "Original effort [5] of finding was weighted [3] by tag weights [acme]", finding.Note)
+
+	//Weights of several tags multiply
+	finding = &model.Finding{Effort: 5}
+	finding.AddTag("jni")
+	finding.AddTag("native")
+	finding.AddTag("logging")
+	assert.True(t, weights.Apply(finding))
+	assert.Equal(t, 15, finding.Effort)
+
+	finding = &model.Finding{Effort: 3}
+	finding.AddTag("logging")
+	assert.True(t, weights.Apply(finding))
+	assert.Equal(t, 2, finding.Effort, "rounded")
+
+	finding = &model.Finding{Effort: 5}
+	finding.AddTag("api")
+	assert.False(t, weights.Apply(finding), "no weighted tag")
+	assert.Equal(t, 5, finding.Effort)
+	assert.Empty(t, finding.Note)
+
+	var none *model.TagWeights
+	assert.False(t, none.Apply(finding), "runs without tag weights")
+}
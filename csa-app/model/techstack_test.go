@@ -0,0 +1,197 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/model"
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const techStackPom = `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>2.7.4</version>
+  </parent>
+  <properties>
+    <java.version>11</java.version>
+    <struts.version>2.5.26</struts.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>org.apache.struts</groupId>
+      <artifactId>struts2-core</artifactId>
+      <version>${struts.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.hibernate</groupId>
+      <artifactId>hibernate-core</artifactId>
+      <version>${hibernate.version}</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+func TestDetectTechStack(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "techstack")
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"pom.xml": techStackPom,
+		"src/main/webapp/WEB-INF/ibm-web-bnd.xml": "<web-bnd/>",
+		"src/main/webapp/WEB-INF/web.xml":         `<web-app xmlns="http://xmlns.jcp.org/xml/ns/javaee" version="3.1">`,
+		"lib/spring-core-4.3.9.RELEASE.jar":       "",
+		"src/main/java/App.java":                  "class App {}",
+		"lib/vendor/build.gradle":                 "apply plugin: 'war'",
+	}
+
+	app := &model.Application{Name: "orders", Path: dir}
+	for name, content := range files {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		_ = ioutil.WriteFile(fqn, []byte(content), 0644)
+		file := &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn}
+		if name == "lib/vendor/build.gradle" {
+			file.ThirdParty = "lib/vendor"
+		}
+		app.Files = append(app.Files, file)
+	}
+
+	detected := make(map[string]*model.TechAttribute)
+	for _, attribute := range model.DetectTechStack(7, app, model.TechDetectors) {
+		assert.Equal(t, uint(7), attribute.RunID)
+		assert.Equal(t, "orders", attribute.Application)
+		detected[attribute.Category+"/"+attribute.Name] = attribute
+	}
+
+	assert.Contains(t, detected, "build-tool/Maven")
+	assert.NotContains(t, detected, "build-tool/Gradle", "vendored files are skipped")
+
+	assert.Equal(t, "2.7.4", detected["framework/Spring Boot"].Version)
+	assert.Equal(t, "pom.xml", detected["framework/Spring Boot"].Evidence)
+	assert.Equal(t, "2.5.26", detected["framework/Struts"].Version, "property placeholders are resolved")
+	assert.Equal(t, "", detected["framework/Hibernate"].Version, "undefined properties are not versions")
+	assert.Equal(t, "4.3.9.RELEASE", detected["framework/Spring"].Version)
+	assert.Equal(t, "7", detected["framework/Java EE"].Version, "servlet 3.1 is Java EE 7")
+
+	assert.Equal(t, "src/main/webapp/WEB-INF/ibm-web-bnd.xml", detected["server/WebSphere"].Evidence)
+	assert.NotContains(t, detected, "server/Tomcat")
+
+	assert.Equal(t, "11", detected["runtime/Java"].Version)
+}
+
+func TestDetectTechStackLibraryVersions(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "techstack")
+	defer os.RemoveAll(dir)
+
+	pom := filepath.Join(dir, "pom.xml")
+	_ = ioutil.WriteFile(pom, []byte(techStackPom), 0644)
+
+	app := &model.Application{Name: "orders", Path: dir, Files: []*util.FileInfo{{Name: "pom.xml", FQN: pom}},
+		Libraries: []*model.AppLibrary{
+			{Ecosystem: "npm", Name: "org.hibernate/hibernate-core", Version: "1.0.0"},
+			{Ecosystem: model.ECOSYSTEM_MAVEN, Name: "org.hibernate/hibernate-core", Version: "5.6.10.Final"},
+			{Ecosystem: model.ECOSYSTEM_MAVEN, Name: "org.apache.struts/struts2-core", Version: "2.5.30"},
+		}}
+
+	detected := make(map[string]*model.TechAttribute)
+	for _, attribute := range model.DetectTechStack(7, app, model.TechDetectors) {
+		detected[attribute.Category+"/"+attribute.Name] = attribute
+	}
+
+	assert.Equal(t, "5.6.10.Final", detected["framework/Hibernate"].Version, "the version managed by the parent pom")
+	assert.Equal(t, "2.5.26", detected["framework/Struts"].Version, "versions of the build file win")
+}
+
+func TestTechVersionsByTag(t *testing.T) {
+
+	attributes := []*model.TechAttribute{
+		{Category: model.TECH_FRAMEWORK, Name: "Spring", Version: "4.3.9.RELEASE"},
+		{Category: model.TECH_FRAMEWORK, Name: "Java EE", Version: "7"},
+		{Category: model.TECH_FRAMEWORK, Name: "Hibernate"},
+		{Category: model.TECH_SERVER, Name: "WebLogic"},
+	}
+
+	versions := model.TechVersionsByTag(attributes, model.TechDetectors)
+
+	assert.Equal(t, "Spring 4.3.9.RELEASE", versions["spring"])
+	assert.Equal(t, "Java EE 7", versions["ejb"])
+	assert.Equal(t, "Java EE 7", versions["javaee"])
+	assert.NotContains(t, versions, "hibernate", "technologies without a version are not attached")
+	assert.NotContains(t, versions, "weblogic")
+}
+
+func TestDetectPhpTechStack(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "techstack")
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"composer.json":       `{"require": {"php": "^8.1", "laravel/framework": "^10.10"}}`,
+		"composer.lock":       `{"packages": [{"name": "laravel/framework", "version": "v10.48.4"}]}`,
+		"admin/composer.json": `{"require": {"symfony/framework-bundle": "6.4.*"}}`,
+		"public/index.php":    "<?php",
+	}
+
+	app := &model.Application{Name: "shop", Path: dir}
+	for name, content := range files {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		_ = ioutil.WriteFile(fqn, []byte(content), 0644)
+		app.Files = append(app.Files, &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn})
+	}
+
+	detected := make(map[string]*model.TechAttribute)
+	for _, attribute := range model.DetectTechStack(7, app, model.TechDetectors) {
+		detected[attribute.Category+"/"+attribute.Name] = attribute
+	}
+
+	assert.Contains(t, detected, "build-tool/Composer")
+	assert.Equal(t, "10.48.4", detected["framework/Laravel"].Version, "locked versions win over constraints")
+	assert.Equal(t, "composer.lock", detected["framework/Laravel"].Evidence)
+	assert.Equal(t, "6.4.*", detected["framework/Symfony"].Version)
+	assert.Equal(t, "^8.1", detected["runtime/PHP"].Version)
+}
+
+func TestDetectDeploymentTechStack(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "techstack")
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"Dockerfile":             "FROM eclipse-temurin:17-jre",
+		"chart/Chart.yaml":       "apiVersion: v2\nname: orders",
+		"infra/versions.tf":      "terraform {\n  required_version = \">= 1.5.0\"\n}",
+		"infra/stack.yaml":       "AWSTemplateFormatVersion: \"2010-09-09\"",
+		"config/application.yml": "server:\n  port: 8080",
+	}
+
+	app := &model.Application{Name: "orders", Path: dir}
+	for name, content := range files {
+		fqn := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(fqn), 0755)
+		_ = ioutil.WriteFile(fqn, []byte(content), 0644)
+		app.Files = append(app.Files, &util.FileInfo{Name: filepath.Base(fqn), FQN: fqn})
+	}
+
+	detected := make(map[string]*model.TechAttribute)
+	for _, attribute := range model.DetectTechStack(7, app, model.TechDetectors) {
+		detected[attribute.Category+"/"+attribute.Name] = attribute
+	}
+
+	assert.Contains(t, detected, "build-tool/Docker")
+	assert.Contains(t, detected, "build-tool/Helm")
+	assert.Equal(t, ">= 1.5.0", detected["build-tool/Terraform"].Version)
+	assert.Equal(t, "infra/stack.yaml", detected["build-tool/CloudFormation"].Evidence)
+}
@@ -0,0 +1,50 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTreemap(t *testing.T) {
+
+	app := &model.Application{Name: "petclinic", Path: "/src/petclinic", Score: 7.5}
+	files := []model.FileStats{
+		{Fqn: "/src/petclinic/pom.xml", Sloc: 100},
+		{Fqn: "/src/petclinic/src/main/Owner.java", Sloc: 300, Findings: 3, Effort: 15},
+		{Fqn: "/src/petclinic/src/main/Vet.java", Sloc: 200, Findings: 1, Effort: 2},
+	}
+
+	treemap := model.BuildTreemap(app, files)
+
+	assert.Equal(t, "petclinic", treemap.Name)
+	assert.Equal(t, 7.5, *treemap.Score)
+	assert.Equal(t, 600, treemap.Sloc)
+	assert.Equal(t, 4, treemap.Findings)
+	assert.Equal(t, 17, treemap.Effort)
+	assert.Equal(t, 6.67, treemap.Density)
+
+	//Largest first
+	assert.Equal(t, 2, len(treemap.Children))
+	src := treemap.Children[0]
+	assert.Equal(t, "src", src.Name)
+	assert.Equal(t, 500, src.Sloc)
+	assert.Nil(t, src.Score)
+
+	main := src.Children[0]
+	assert.Equal(t, "src/main", main.Path)
+	assert.Equal(t, "Owner.java", main.Children[0].Name)
+	assert.Equal(t, "src/main/Owner.java", main.Children[0].Path)
+	assert.Equal(t, 10.0, main.Children[0].Density)
+	assert.Empty(t, main.Children[0].Children)
+
+	assert.Equal(t, "pom.xml", treemap.Children[1].Name)
+	assert.Equal(t, 0.0, treemap.Children[1].Density)
+}
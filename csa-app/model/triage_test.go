@@ -0,0 +1,59 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTriage(t *testing.T) {
+	for _, state := range append(model.TriageStates, model.TRIAGE_NONE) {
+		assert.NoError(t, model.ValidateTriage(state), state)
+	}
+	assert.Error(t, model.ValidateTriage("ignored"))
+	assert.Error(t, model.ValidateTriage(""))
+}
+
+func TestExcludeTriaged(t *testing.T) {
+	findings := []model.Finding{
+		{ID: 1},
+		{ID: 2, Triage: model.TRIAGE_SUPPRESSED},
+		{ID: 3, Triage: model.TRIAGE_ACCEPTED},
+		{ID: 4, Triage: model.TRIAGE_FALSE_POSITIVE},
+	}
+
+	kept := model.ExcludeTriaged(findings)
+
+	assert.Len(t, kept, 2)
+	assert.Equal(t, uint(1), kept[0].ID)
+	assert.Equal(t, uint(3), kept[1].ID, "accepted findings still count")
+}
+
+func TestCarryForwardTriage(t *testing.T) {
+	triagedAt := time.Now()
+	previous := []model.Finding{
+		{ID: 10, Rule: "java-jndi", Fqn: "/v1/app/src/Dao.java", Line: 12, Triage: model.TRIAGE_FALSE_POSITIVE, TriageNote: "only a constant", TriagedAt: &triagedAt},
+		{ID: 11, Rule: "java-jndi", Fqn: "/v1/app/src/Service.java", Line: 40},
+		{ID: 12, Rule: "java-file-io", Fqn: "/v1/app/src/Export.java", Line: 7, Triage: model.TRIAGE_SUPPRESSED},
+	}
+	current := []model.Finding{
+		{ID: 20, Rule: "java-jndi", Fqn: "/v2/app/src/Dao.java", Line: 14},
+		{ID: 21, Rule: "java-jndi", Fqn: "/v2/app/src/Service.java", Line: 40},
+	}
+
+	match := model.MatchFindings(previous, "/v1/app", current, "/v2/app", 3)
+	carried := model.CarryForwardTriage(previous, match.Previous)
+
+	assert.Len(t, carried, 1, "untriaged and resolved findings carry nothing")
+	assert.Equal(t, model.TRIAGE_FALSE_POSITIVE, carried[20].State)
+	assert.Equal(t, "only a constant", carried[20].Reason)
+	assert.Equal(t, &triagedAt, carried[20].TriagedAt)
+}
@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateTwelveFactors(t *testing.T) {
+
+	totals := model.TagTotals{
+		"Log2File":            {Findings: 2, Effort: 6},
+		"externalized-config": {Findings: 3},
+		"hard-ip":             {Findings: 1, Effort: 10},
+		"term":                {Findings: 1, Effort: 1},
+	}
+
+	report := model.EvaluateTwelveFactors("orders", totals, model.TwelveFactors)
+
+	assert.Equal(t, "orders", report.Application)
+	assert.Len(t, report.Factors, 12)
+
+	factors := make(map[string]model.FactorResult)
+	for _, factor := range report.Factors {
+		factors[factor.Factor] = factor
+	}
+
+	logs := factors["Logs"]
+	assert.Equal(t, model.FACTOR_FAIL, logs.Status, "tags are matched regardless of case")
+	assert.Equal(t, 6, logs.Effort)
+	assert.Equal(t, 0.0, logs.Score)
+
+	config := factors["Config"]
+	assert.Equal(t, model.FACTOR_FAIL, config.Status, "a violation fails the factor despite evidence")
+	assert.Equal(t, 7.5, config.Score)
+	assert.Equal(t, []string{"externalized-config", "hard-ip"}, config.Tags)
+
+	assert.Equal(t, model.FACTOR_PASS, factors["Disposability"].Status)
+	assert.Equal(t, model.FACTOR_PASS, factors["Processes"].Status, "clean factors pass when the rules cover them")
+	assert.Equal(t, 10.0, factors["Processes"].Score)
+	assert.Equal(t, model.FACTOR_UNKNOWN, factors["Codebase"].Status)
+	assert.Equal(t, 0.0, factors["Codebase"].Score)
+
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, 3, report.Passed, "disposability, backing services and processes")
+	assert.Equal(t, 7, report.Unknown)
+	assert.InDelta(t, (0+7.5+10+10+10)/5.0, report.Score, 0.001)
+}
@@ -0,0 +1,50 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWhatIf(t *testing.T) {
+
+	whatIf, err := model.ParseWhatIf([]string{"tag=jms", "", "category=ejb AND severity=high"})
+	assert.NoError(t, err)
+	assert.Len(t, whatIf, 2, "empty queries are skipped")
+	assert.Len(t, whatIf[1].Criterion(), 2)
+
+	_, err = model.ParseWhatIf(nil)
+	assert.Error(t, err, "nothing to resolve")
+
+	_, err = model.ParseWhatIf([]string{"jms"})
+	assert.Error(t, err)
+}
+
+func TestResolveWhatIf(t *testing.T) {
+
+	app := &model.Application{Name: "orders", Findings: 20, RawScore: 150, CIFindings: 12, NumCrits: 3, CriticalCnt: 1, HighCnt: 4,
+		TagTotals: model.TagTotals{"jms": {Findings: 5, Effort: 50}, "file": {Findings: 2, Effort: 6}}}
+
+	app.Resolve(&model.WhatIfTotals{Findings: 5, RawScore: 50, CIFindings: 5, NumCrits: 2, HighCnt: 6,
+		Tags: model.TagTotals{"jms": {Findings: 5, Effort: 50}, "mq": {Findings: 5, Effort: 50}}})
+
+	assert.Equal(t, 15, app.Findings)
+	assert.Equal(t, 100, app.RawScore)
+	assert.Equal(t, 7, app.CIFindings)
+	assert.Equal(t, 1, app.NumCrits)
+	assert.Equal(t, 1, app.CriticalCnt)
+	assert.Equal(t, 0, app.HighCnt, "totals don't go negative")
+	assert.Equal(t, model.TagTotal{}, app.TagTotals["jms"])
+	assert.Equal(t, model.TagTotal{Findings: 2, Effort: 6}, app.TagTotals["file"])
+	assert.NotContains(t, app.TagTotals, "mq", "tags the app has no findings for are left alone")
+
+	app.Resolve(nil)
+	assert.Equal(t, 100, app.RawScore)
+}
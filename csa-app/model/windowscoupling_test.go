@@ -0,0 +1,41 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package model_test
+
+import (
+	"testing"
+
+	"csa-app/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateWindowsCoupling(t *testing.T) {
+
+	tagTotals := model.TagTotals{
+		"windows-path":    {Findings: 4, Effort: 12},
+		"windows-service": {Findings: 3, Effort: 30},
+		"msmq":            {Findings: 1, Effort: 50},
+		"spring":          {Findings: 9, Effort: 9},
+	}
+
+	coupling := model.EvaluateWindowsCoupling("orders", tagTotals, model.WindowsDependencies)
+	assert.True(t, coupling.Blocked, "msmq is a blocker")
+	assert.Equal(t, 8, coupling.Findings)
+	assert.Equal(t, 92, coupling.Effort)
+	assert.Equal(t, []string{"windows-services", "msmq", "paths"}, []string{coupling.Dependencies[0].Dependency, coupling.Dependencies[1].Dependency,
+		coupling.Dependencies[2].Dependency}, "in the order of the dependencies")
+	assert.Equal(t, model.WindowsDependencyResult{Dependency: "msmq", Replacement: "A message broker (RabbitMQ, a managed queue service)",
+		Findings: 1, Effort: 50, Blocker: true, Tags: []string{"msmq"}}, coupling.Dependencies[1])
+
+	paths := model.EvaluateWindowsCoupling("billing", model.TagTotals{"windows-path": {Findings: 1, Effort: 3}}, model.WindowsDependencies)
+	assert.False(t, paths.Blocked)
+	assert.Len(t, paths.Dependencies, 1)
+
+	none := model.EvaluateWindowsCoupling("inventory", nil, model.WindowsDependencies)
+	assert.Equal(t, 0, none.Findings)
+	assert.Empty(t, none.Dependencies)
+}
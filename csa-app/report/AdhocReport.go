@@ -0,0 +1,115 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+type AdhocReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewAdhocReportService(mgr *db.Repositories) *AdhocReportService {
+	return &AdhocReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (adhocService *AdhocReportService) RunAdhocReport(query string, groupBy string, format string, runId uint) {
+
+	if runId == 0 {
+		runId = latestRunId(adhocService.runRepository, "csa")
+	}
+
+	if format == util.CSV || format == util.JSON {
+		fileName, err := adhocService.ExportAdhocReport(runId, query, groupBy, format, AdhocReportName(runId, groupBy))
+		if err != nil {
+			util.App.Fatalf("Unable to build adhoc report for run [%d]! Details: %v", runId, err)
+		}
+		fmt.Printf("Adhoc report written to [%s]\n", fileName)
+		return
+	}
+
+	_, headers, data, err := adhocService.buildAdhocReport(runId, query, groupBy)
+	if err != nil {
+		util.App.Fatalf("Unable to build adhoc report for run [%d]! Details: %v", runId, err)
+	}
+
+	adhocService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Findings by %s", runId, groupBy), false)
+}
+
+//AdhocReportName is the name the adhoc report of a run is exported under
+func AdhocReportName(runId uint, groupBy string) string {
+	return fmt.Sprintf("%d-adhoc-by-%s", runId, groupBy)
+}
+
+//ExportAdhocReport writes the adhoc report to a csv or json file named name in the output dir and returns the file name
+func (adhocService *AdhocReportService) ExportAdhocReport(runId uint, query string, groupBy string, format string, name string) (string, error) {
+
+	aggregates, headers, data, err := adhocService.buildAdhocReport(runId, query, groupBy)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case util.CSV:
+		return exportCsvReport(name, headers, data)
+	case util.JSON:
+		return exportJsonReport(name, aggregates)
+	}
+
+	return "", fmt.Errorf("adhoc reports can not be exported as [%s]", format)
+}
+
+func (adhocService *AdhocReportService) buildAdhocReport(runId uint, query string, groupBy string) (aggregates []model.FindingAggregate, headers []string, data [][]string, err error) {
+
+	criteria, err := model.ParseCriteria(query)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid query [%s]: %v", query, err)
+	}
+
+	aggregates, err = adhocService.findingRepository.GetFindingAggregates(runId, criteria, groupBy)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	scale, err := effortScale()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var effortCounts map[string]map[int]int
+	headers = []string{groupBy, "findings", "effort", "applications", "files"}
+
+	if scale != nil {
+		effortCounts, err = adhocService.findingRepository.GetFindingEffortCounts(runId, criteria, groupBy)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		headers = append(headers, scale.Label())
+	}
+
+	for i, aggregate := range aggregates {
+		row := []string{aggregate.Group, fmt.Sprint(aggregate.Findings), fmt.Sprint(aggregate.Effort),
+			fmt.Sprint(aggregate.Applications), fmt.Sprint(aggregate.Files)}
+		if scale != nil {
+			aggregates[i].Estimate = scale.Total(effortCounts[aggregate.Group])
+			row = append(row, aggregates[i].Estimate)
+		}
+		data = append(data, row)
+	}
+
+	return
+}
@@ -0,0 +1,118 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//AppReport is a report of the run's applications having findings of a kind (I.E. their dependencies on Windows). The
+//report evaluates each application, orders them and tells the rows of an application's table.
+type AppReport struct {
+	name      string //of the report's files, I.E. <run id>-windows.csv
+	title     string
+	headers   []string
+	evaluator func(findingRepository db.FindingRepository, runId uint) (appEvaluator, error)
+	less      func(a interface{}, b interface{}) bool
+	rows      func(report interface{}) [][]string
+}
+
+//appEvaluator evaluates the report of an application and returns it with its findings
+type appEvaluator func(app string) (report interface{}, findings int)
+
+//tagTotalsEvaluator evaluates the applications from the totals of their findings by tag
+func tagTotalsEvaluator(evaluate func(app string, tagTotals model.TagTotals) (interface{}, int)) func(db.FindingRepository, uint) (appEvaluator, error) {
+	return func(findingRepository db.FindingRepository, runId uint) (appEvaluator, error) {
+		tagTotals, err := findingRepository.GetAppTagTotals(runId)
+		if err != nil {
+			return nil, err
+		}
+		return func(app string) (interface{}, int) {
+			return evaluate(app, tagTotals[app])
+		}, nil
+	}
+}
+
+type AppReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewAppReportService(mgr *db.Repositories) *AppReportService {
+	return &AppReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//AppReports evaluates the report of the run's applications, app narrowing them down to one, and returns those with
+//findings in the report's order
+func (appService *AppReportService) AppReports(report AppReport, runId uint, app string) ([]interface{}, error) {
+
+	apps, err := appService.runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluate, err := report.evaluator(appService.findingRepository, runId)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []interface{}{}
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+		if appReport, findings := evaluate(application.Name); findings > 0 {
+			reports = append(reports, appReport)
+		}
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return report.less(reports[i], reports[j])
+	})
+
+	return reports, nil
+}
+
+func (appService *AppReportService) RunAppReport(report AppReport, runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(appService.runRepository, "csa")
+	}
+
+	reports, err := appService.AppReports(report, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the %s of run [%d]", strings.ToLower(report.title), runId), err)
+
+	name := fmt.Sprintf("%d-%s", runId, report.name)
+
+	if format == util.JSON {
+		util.WriteStructToFile(reports, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("%s written to [%s%s%s.%s]\n", report.title, *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	var data [][]string
+	for _, appReport := range reports {
+		data = append(data, report.rows(appReport)...)
+	}
+
+	if format == util.CSV {
+		fmt.Printf("%s written to [%s]\n", report.title, writeCsvReport(name, report.headers, data))
+		return
+	}
+
+	appService.reportService.DisplayReport(report.headers, data, fmt.Sprintf("Run [%d] %s", runId, report.title), false)
+}
@@ -0,0 +1,76 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//AppServerReportService reports the migration inventory of the run's applications: the datasources, JNDI bindings,
+//work managers and security realms their application server descriptors configure or bind
+type AppServerReportService struct {
+	runRepository db.RunRepository
+	reportService *ReportService
+}
+
+func NewAppServerReportService(mgr *db.Repositories) *AppServerReportService {
+	return &AppServerReportService{
+		runRepository: mgr.Run,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//AppServerInventoryReports returns the migration inventory of each of the run's applications with server resources.
+//app narrows them down to one application.
+func AppServerInventoryReports(runRepository db.RunRepository, runId uint, app string) ([]model.AppServerInventory, error) {
+
+	resources, err := runRepository.GetAppServerResources(runId, app)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.AppServerInventories(resources), nil
+}
+
+func (appServerService *AppServerReportService) RunAppServerReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(appServerService.runRepository, "csa")
+	}
+
+	inventories, err := AppServerInventoryReports(appServerService.runRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the app server resources of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-app-server", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(inventories, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("App server inventory written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A row per resource, with what it becomes off the server
+	headers := []string{"application", "server", "kind", "name", "jndi", "detail", "descriptor", "line", "replacement"}
+
+	var data [][]string
+	for _, inventory := range inventories {
+		for _, resource := range inventory.Resources {
+			data = append(data, []string{resource.Application, resource.Server, resource.Kind, resource.Name, resource.Jndi,
+				resource.Detail, resource.Descriptor, fmt.Sprint(resource.Line), model.AppServerKindOf(resource.Kind).Replacement})
+		}
+	}
+
+	if format == util.CSV {
+		fmt.Printf("App server inventory written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	appServerService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] App Server Migration Inventory", runId), false)
+}
@@ -0,0 +1,37 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/model"
+)
+
+//BatchReport reports the batch frameworks and schedulers running the jobs of the run's applications, which are
+//re-platformed to cloud native schedulers. The most effort comes first.
+var BatchReport = AppReport{
+	name:  "batch",
+	title: "Batch & Scheduled Jobs",
+	//A row per scheduler of each application
+	headers: []string{"application", "scheduler", "findings", "effort", "tags", "replacement"},
+	evaluator: tagTotalsEvaluator(func(app string, tagTotals model.TagTotals) (interface{}, int) {
+		jobs := model.EvaluateScheduledJobs(app, tagTotals, model.Schedulers)
+		return jobs, jobs.Findings
+	}),
+	less: func(a interface{}, b interface{}) bool {
+		return a.(model.ScheduledJobs).Effort > b.(model.ScheduledJobs).Effort
+	},
+	rows: func(report interface{}) (rows [][]string) {
+		jobs := report.(model.ScheduledJobs)
+		for _, scheduler := range jobs.Schedulers {
+			rows = append(rows, []string{jobs.Application, scheduler.Scheduler, fmt.Sprint(scheduler.Findings), fmt.Sprint(scheduler.Effort),
+				strings.Join(scheduler.Tags, ","), scheduler.Replacement})
+		}
+		return
+	},
+}
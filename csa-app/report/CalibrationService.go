@@ -0,0 +1,154 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//CalibrationService records the actual effort of migrated applications and calibrates estimation models with it
+type CalibrationService struct {
+	repositories  *db.Repositories
+	reportService *ReportService
+}
+
+//outcomeEstimate is a recorded outcome next to the estimate of its application
+type outcomeEstimate struct {
+	model.MigrationOutcome
+	Effort  int     `json:"effort"`
+	MinDays float64 `json:"minDays"`
+	MaxDays float64 `json:"maxDays"`
+}
+
+func NewCalibrationService(mgr *db.Repositories) *CalibrationService {
+	return &CalibrationService{
+		repositories:  mgr,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//CalibrationSamples pairs the recorded outcomes with the effort by category of their applications' findings.
+//Applications no longer part of their run are skipped.
+func CalibrationSamples(mgr *db.Repositories) ([]model.MigrationOutcome, []model.CalibrationSample, error) {
+
+	outcomes, err := mgr.Run.GetOutcomes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	efforts := make(map[uint]map[string]map[string]int)
+	var recorded []model.MigrationOutcome
+	var samples []model.CalibrationSample
+	for _, outcome := range outcomes {
+		if _, found := efforts[outcome.RunID]; !found {
+			if efforts[outcome.RunID], err = mgr.Findings.GetAppCategoryEffort(outcome.RunID); err != nil {
+				return nil, nil, err
+			}
+		}
+		if _, err = mgr.Run.GetApp(outcome.RunID, outcome.Application); err != nil {
+			continue
+		}
+		recorded = append(recorded, outcome)
+		samples = append(samples, model.CalibrationSample{Application: outcome.Application, ActualDays: outcome.ActualDays,
+			Effort: efforts[outcome.RunID][outcome.Application]})
+	}
+
+	return recorded, samples, nil
+}
+
+func (calibrationService *CalibrationService) RecordOutcome(runId uint, app string, days float64, note string) {
+
+	if runId == 0 {
+		runId = latestRunId(calibrationService.repositories.Run, "csa")
+	}
+
+	_, err := calibrationService.repositories.Run.GetApp(runId, app)
+	exitOnError(fmt.Sprintf("Unable to record the outcome of application [%s]", app), err)
+
+	outcome := &model.MigrationOutcome{RunID: runId, Application: app, ActualDays: days, Note: note}
+	exitOnError("Unable to record the outcome", outcome.Validate())
+
+	err = calibrationService.repositories.Run.SaveOutcome(outcome)
+	exitOnError(fmt.Sprintf("Unable to record the outcome of application [%s]", app), err)
+
+	fmt.Printf("Recorded [%g] person-days for application [%s] of run [%d]\n", days, app, runId)
+}
+
+//ListOutcomes lists the recorded outcomes next to the estimates of the estimation model of the file (the default model
+//when empty)
+func (calibrationService *CalibrationService) ListOutcomes(modelFile string, format string) {
+
+	estimationModel, err := model.LoadEstimationModel(modelFile)
+	exitOnError("Unable to load the estimation model", err)
+
+	outcomes, samples, err := CalibrationSamples(calibrationService.repositories)
+	exitOnError("Unable to retrieve the recorded outcomes", err)
+
+	var data []outcomeEstimate
+	for i, outcome := range outcomes {
+		estimates := estimationModel.Estimate(outcome.Application, samples[i].Effort)
+		total := estimates[len(estimates)-1]
+		data = append(data, outcomeEstimate{MigrationOutcome: outcome, Effort: total.Effort, MinDays: total.MinDays, MaxDays: total.MaxDays})
+	}
+
+	name := "outcomes"
+
+	if format == util.JSON {
+		util.WriteStructToFile(data, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("[%d] outcomes written to [%s%s%s.%s]\n", len(data), *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"run", "application", "effort", "estimated days", "actual days", "note", "recorded"}
+	var rows [][]string
+	for _, outcome := range data {
+		rows = append(rows, []string{fmt.Sprint(outcome.RunID), outcome.Application, fmt.Sprint(outcome.Effort),
+			fmt.Sprintf("%.1f - %.1f", outcome.MinDays, outcome.MaxDays), fmt.Sprintf("%.1f", outcome.ActualDays), outcome.Note,
+			outcome.CreatedAt.Format("2006-01-02 15:04")})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("[%d] outcomes written to [%s]\n", len(rows), writeCsvReport(name, headers, rows))
+		return
+	}
+
+	calibrationService.reportService.DisplayReport(headers, rows, fmt.Sprintf("Migration Outcomes (%s model)", estimationModel.Name), false)
+}
+
+//Calibrate calibrates the estimation model of the file (the default model when empty) with the recorded outcomes, see
+//model.Calibrate, and writes the calibrated model to the output dir to estimate the remaining applications with
+func (calibrationService *CalibrationService) Calibrate(modelFile string, format string) {
+
+	estimationModel, err := model.LoadEstimationModel(modelFile)
+	exitOnError("Unable to load the estimation model", err)
+
+	_, samples, err := CalibrationSamples(calibrationService.repositories)
+	exitOnError("Unable to retrieve the recorded outcomes", err)
+
+	calibrated, calibrations, err := model.Calibrate(estimationModel, samples)
+	exitOnError(fmt.Sprintf("Unable to calibrate estimation model [%s]", estimationModel.Name), err)
+
+	headers := []string{"category", "applications", "effort", "estimated days", "actual days", "ratio", "min days", "max days"}
+	var rows [][]string
+	for _, calibration := range calibrations {
+		factor := calibrated.Factor(calibration.Category)
+		if calibration.Category == model.DEFAULT_FACTOR {
+			factor = calibrated.Default
+		}
+		rows = append(rows, []string{calibration.Category, fmt.Sprint(calibration.Applications), fmt.Sprint(calibration.Effort),
+			fmt.Sprintf("%.1f", calibration.EstimatedDays), fmt.Sprintf("%.1f", calibration.ActualDays), fmt.Sprintf("%.2f", calibration.Ratio),
+			fmt.Sprint(factor.MinDays), fmt.Sprint(factor.MaxDays)})
+	}
+
+	calibrationService.reportService.DisplayReport(headers, rows, fmt.Sprintf("Calibration of Estimation Model [%s] (%d outcomes)", estimationModel.Name, len(samples)), false)
+
+	util.WriteStructToFile(calibrated, calibrated.Name, *util.OutputDir, format, true)
+	fmt.Printf("Calibrated model written to [%s%s%s.%s]. Use it with `csa report estimate --model`\n", *util.OutputDir, util.PathSeparator, calibrated.Name, format)
+}
@@ -0,0 +1,194 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Compares a run in the current database against a run stored in a different (baseline) database
+type CompareReportService struct {
+	current       *db.Repositories
+	baseline      *db.Repositories
+	reportService *ReportService
+}
+
+func NewCompareReportService(current *db.Repositories, baseline *db.Repositories) *CompareReportService {
+	return &CompareReportService{
+		current:       current,
+		baseline:      baseline,
+		reportService: NewReportSvc(current),
+	}
+}
+
+func (compareService *CompareReportService) RunComparison(runId uint, baselineRunId uint, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(compareService.current.Run, "current")
+	}
+
+	if baselineRunId == 0 {
+		baselineRunId = latestRunId(compareService.baseline.Run, "baseline")
+	}
+
+	appHeaders, appData := compareService.compareApplications(runId, baselineRunId)
+	ruleHeaders, ruleData := compareService.compareRules(runId, baselineRunId)
+
+	switch format {
+	case util.CSV:
+		fmt.Printf("Application comparison written to [%s]\n", writeCsvReport(fmt.Sprintf("%d-vs-baseline-%d-apps", runId, baselineRunId), appHeaders, appData))
+		fmt.Printf("Rule comparison written to [%s]\n", writeCsvReport(fmt.Sprintf("%d-vs-baseline-%d-rules", runId, baselineRunId), ruleHeaders, ruleData))
+	default:
+		compareService.reportService.DisplayReport(appHeaders, appData, fmt.Sprintf("Run [%d] vs Baseline Run [%d] - Applications", runId, baselineRunId), false)
+		compareService.reportService.DisplayReport(ruleHeaders, ruleData, fmt.Sprintf("Run [%d] vs Baseline Run [%d] - Rules", runId, baselineRunId), false)
+	}
+}
+
+func (compareService *CompareReportService) compareApplications(runId uint, baselineRunId uint) (headers []string, data [][]string) {
+
+	headers = []string{"application", "status", "baseline score", "score", "score delta", "baseline tier", "tier", "baseline findings", "findings",
+		"baseline effort", "effort", "effort delta", "baseline sloc", "sloc"}
+
+	//Both runs are classified with the current bins, so a tier change is a score change
+	bins := scoreBins(compareService.current.Scoring)
+
+	currentApps, err := compareService.current.Run.GetRunApps(runId)
+	checkReportError("compare-apps", err)
+	baselineApps, err := compareService.baseline.Run.GetRunApps(baselineRunId)
+	checkReportError("compare-apps", err)
+
+	baselineByName := make(map[string]model.Application)
+	for _, app := range baselineApps {
+		baselineByName[app.Name] = app
+	}
+
+	currentByName := make(map[string]model.Application)
+	for _, app := range currentApps {
+		currentByName[app.Name] = app
+	}
+
+	for _, name := range sortedAppNames(currentByName, baselineByName) {
+		current, inCurrent := currentByName[name]
+		baseline, inBaseline := baselineByName[name]
+
+		status := COMPARE_CHANGED
+		if !inBaseline {
+			status = COMPARE_ADDED
+		} else if !inCurrent {
+			status = COMPARE_REMOVED
+		} else if current.Score == baseline.Score && current.RawScore == baseline.RawScore {
+			status = COMPARE_UNCHANGED
+		}
+
+		data = append(data, []string{name, status,
+			fmt.Sprintf("%2.2f", baseline.Score), fmt.Sprintf("%2.2f", current.Score), fmt.Sprintf("%+2.2f", current.Score-baseline.Score),
+			compareTier(bins, baseline, inBaseline), compareTier(bins, current, inCurrent),
+			fmt.Sprint(baseline.Findings), fmt.Sprint(current.Findings),
+			fmt.Sprint(baseline.RawScore), fmt.Sprint(current.RawScore), fmt.Sprintf("%+d", current.RawScore-baseline.RawScore),
+			fmt.Sprint(baseline.SlocCnt), fmt.Sprint(current.SlocCnt)})
+	}
+
+	return
+}
+
+func (compareService *CompareReportService) compareRules(runId uint, baselineRunId uint) (headers []string, data [][]string) {
+
+	headers = []string{"rule", "status", "baseline findings", "findings", "findings delta", "baseline effort", "effort", "effort delta"}
+
+	criteria := &model.Criteria{}
+
+	currentRules, err := compareService.current.Findings.GetFindingAggregates(runId, criteria, model.CRITERION_RULE)
+	checkReportError("compare-rules", err)
+	baselineRules, err := compareService.baseline.Findings.GetFindingAggregates(baselineRunId, criteria, model.CRITERION_RULE)
+	checkReportError("compare-rules", err)
+
+	current := make(map[string]model.FindingAggregate)
+	for _, aggregate := range currentRules {
+		current[aggregate.Group] = aggregate
+	}
+
+	baseline := make(map[string]model.FindingAggregate)
+	for _, aggregate := range baselineRules {
+		baseline[aggregate.Group] = aggregate
+	}
+
+	var rules []string
+	for rule := range current {
+		rules = append(rules, rule)
+	}
+	for rule := range baseline {
+		if _, found := current[rule]; !found {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Strings(rules)
+
+	for _, rule := range rules {
+		cur, inCurrent := current[rule]
+		base, inBaseline := baseline[rule]
+
+		status := COMPARE_CHANGED
+		if !inBaseline {
+			status = COMPARE_ADDED
+		} else if !inCurrent {
+			status = COMPARE_REMOVED
+		} else if cur.Findings == base.Findings && cur.Effort == base.Effort {
+			status = COMPARE_UNCHANGED
+		}
+
+		data = append(data, []string{rule, status,
+			fmt.Sprint(base.Findings), fmt.Sprint(cur.Findings), fmt.Sprintf("%+d", cur.Findings-base.Findings),
+			fmt.Sprint(base.Effort), fmt.Sprint(cur.Effort), fmt.Sprintf("%+d", cur.Effort-base.Effort)})
+	}
+
+	return
+}
+
+func latestRunId(runRepository db.RunRepository, label string) uint {
+
+	runs, err := runRepository.GetRunsByCommand(util.ANALYZE_CMD)
+
+	if err != nil || len(runs) == 0 {
+		fmt.Fprintf(os.Stderr, "No analyze runs found in %s database!\n", label)
+		os.Exit(1)
+	}
+
+	return runs[len(runs)-1].ID
+}
+
+func sortedAppNames(current map[string]model.Application, baseline map[string]model.Application) (names []string) {
+
+	for name := range current {
+		names = append(names, name)
+	}
+
+	for name := range baseline {
+		if _, found := current[name]; !found {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return
+}
+
+const COMPARE_ADDED = "added"
+const COMPARE_REMOVED = "removed"
+const COMPARE_CHANGED = "changed"
+const COMPARE_UNCHANGED = "unchanged"
+
+func compareTier(bins []model.ScoreBin, app model.Application, inRun bool) string {
+	if !inRun {
+		return ""
+	}
+	return model.ScoreBinName(bins, app.Score)
+}
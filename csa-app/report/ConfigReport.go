@@ -0,0 +1,85 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//ConfigReportService reports the hard-coded, environment specific values (urls, hosts, paths, credentials) the run's
+//applications must externalize, the findings tagged externalize-config
+type ConfigReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewConfigReportService(mgr *db.Repositories) *ConfigReportService {
+	return &ConfigReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//ConfigExternalizationReports groups the run's (first party) findings tagged externalize-config by application, an
+//item per hard-coded value. app narrows them down to one application.
+func ConfigExternalizationReports(findingRepository db.FindingRepository, runId uint, app string) ([]model.ConfigExternalization, error) {
+
+	findings, err := findingRepository.GetFindingsByTag(runId, model.CONFIG_EXTERNALIZE_TAG)
+	if err != nil {
+		return nil, err
+	}
+
+	var appFindings []model.Finding
+	for _, finding := range model.ExcludeTriaged(findings) {
+		if finding.ThirdParty == "" && (app == "" || finding.Application == app) {
+			appFindings = append(appFindings, finding)
+		}
+	}
+
+	return model.GroupConfigItems(appFindings, model.ConfigKinds), nil
+}
+
+func (configService *ConfigReportService) RunConfigReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(configService.runRepository, "csa")
+	}
+
+	apps, err := ConfigExternalizationReports(configService.findingRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the configuration to externalize of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-config-externalization", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(apps, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Configuration to externalize written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A row per hard-coded value
+	headers := []string{"application", "kind", "file", "line", "key", "value", "variable", "rule", "effort"}
+
+	var data [][]string
+	for _, application := range apps {
+		for _, item := range application.Items {
+			data = append(data, []string{application.Application, item.Kind, item.File, fmt.Sprint(item.Line), item.Key,
+				item.Value, item.Variable, item.Rule, fmt.Sprint(item.Effort)})
+		}
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Configuration to externalize written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	configService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Configuration To Externalize", runId), false)
+}
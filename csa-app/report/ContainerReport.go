@@ -0,0 +1,106 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//ContainerReportService reports how container ready each application of a run is, apart from its cloud score
+type ContainerReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewContainerReportService(mgr *db.Repositories) *ContainerReportService {
+	return &ContainerReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//ContainerReadinessReports scores the applications of the run, the least container ready first. app narrows them
+//down to one.
+func ContainerReadinessReports(findingRepository db.FindingRepository, runRepository db.RunRepository, runId uint, app string) ([]model.ContainerReadiness, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := findingRepository.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	readiness := []model.ContainerReadiness{}
+	for _, application := range apps {
+		if app == "" || application.Name == app {
+			readiness = append(readiness, model.EvaluateContainerReadiness(application.Name, tagTotals[application.Name], model.ContainerConcerns))
+		}
+	}
+
+	sort.SliceStable(readiness, func(i, j int) bool {
+		return readiness[i].Score < readiness[j].Score
+	})
+
+	return readiness, nil
+}
+
+func (containerService *ContainerReportService) RunContainerReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(containerService.runRepository, "csa")
+	}
+
+	readiness, err := ContainerReadinessReports(containerService.findingRepository, containerService.runRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to score the container readiness of run [%d]", runId), err)
+
+	if app != "" && len(readiness) == 0 {
+		exitOnError("Unable to score the container readiness", fmt.Errorf("application [%s] is not part of run [%d]", app, runId))
+	}
+
+	name := fmt.Sprintf("%d-container-readiness", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(readiness, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Container readiness written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A findings/penalty column per concern
+	headers := []string{"application", "container score", "containerized", "blocked"}
+	for _, concern := range model.ContainerConcerns {
+		headers = append(headers, concern.Name)
+	}
+	headers = append(headers, "tags")
+
+	var data [][]string
+	for _, application := range readiness {
+		row := []string{application.Application, fmt.Sprintf("%2.2f", application.Score), fmt.Sprint(application.Containerized), fmt.Sprint(application.Blocked)}
+		var tags []string
+		for _, concern := range application.Concerns {
+			row = append(row, fmt.Sprintf("%d (-%2.2f)", concern.Findings, concern.Penalty))
+			tags = append(tags, concern.Tags...)
+		}
+		data = append(data, append(row, strings.Join(tags, ",")))
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Container readiness written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	containerService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Container Readiness", runId), false)
+}
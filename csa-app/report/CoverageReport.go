@@ -0,0 +1,92 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Files listed per rule by the table format. The csv lists them all.
+const maxCoverageFiles = 3
+
+//Lists every rule a run loaded with how often it matched and where, so rules that never fire can be fixed or retired
+type CoverageReportService struct {
+	ruleRepository    db.RuleRepository
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewCoverageReportService(mgr *db.Repositories) *CoverageReportService {
+	return &CoverageReportService{
+		ruleRepository:    mgr.Rules,
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (coverageService *CoverageReportService) RunCoverageReport(runId uint, format string, unmatchedOnly bool) {
+
+	if runId == 0 {
+		runId = latestRunId(coverageService.runRepository, "csa")
+	}
+
+	metrics, err := coverageService.ruleRepository.GetRuleMetrics(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve the rules of run [%d]! Details: %v", runId, err)
+	}
+	if len(metrics) == 0 {
+		util.App.Fatalf("Run [%d] recorded no rule metrics, so the rules it loaded are unknown", runId)
+	}
+
+	findings, err := coverageService.findingRepository.GetFindingsWithoutPreload(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve findings for run [%d]! Details: %v", runId, err)
+	}
+
+	headers := []string{"rule", "criticality", "deprecated", "checks", "matches", "files", "matched files"}
+	var data [][]string
+	unmatched, deprecated := 0, 0
+
+	coverage := model.BuildRuleCoverage(metrics, findings)
+	for _, rule := range coverage {
+		if rule.Matches == 0 {
+			unmatched++
+		} else if unmatchedOnly {
+			continue
+		}
+
+		files := rule.Files
+		if format != util.CSV && len(files) > maxCoverageFiles {
+			files = append(files[:maxCoverageFiles:maxCoverageFiles], fmt.Sprintf("(%d more)", len(rule.Files)-maxCoverageFiles))
+		}
+
+		if rule.Deprecation != "" {
+			deprecated++
+		}
+
+		data = append(data, []string{rule.Rule, rule.Criticality, rule.Deprecation, fmt.Sprint(rule.Checks), fmt.Sprint(rule.Matches),
+			fmt.Sprint(len(rule.Files)), strings.Join(files, ";")})
+	}
+
+	switch format {
+	case util.CSV:
+		fmt.Printf("Rule coverage written to [%s]\n", writeCsvReport(fmt.Sprintf("%d-rule-coverage", runId), headers, data))
+	default:
+		coverageService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Rule Coverage", runId), false)
+	}
+
+	fmt.Printf("[%d] of the [%d] rules loaded by run [%d] never matched\n", unmatched, len(coverage), runId)
+	if deprecated > 0 {
+		fmt.Printf("[%d] of the rules listed were deprecated when the run loaded them\n", deprecated)
+	}
+}
@@ -0,0 +1,214 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Order of the coupling levels, the tightest first
+var dbCouplingOrder = map[string]int{model.DB_COUPLING_HIGH: 0, model.DB_COUPLING_MEDIUM: 1, model.DB_COUPLING_LOW: 2, model.DB_COUPLING_NONE: 3}
+
+//DatabaseReportService reports how tightly the run's applications are coupled to their databases: the business
+//logic in their database tier and the vendor specific constructs it uses
+type DatabaseReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	slocRepository    db.SlocRepository
+	reportService     *ReportService
+}
+
+func NewDatabaseReportService(mgr *db.Repositories) *DatabaseReportService {
+	return &DatabaseReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		slocRepository:    mgr.Sloc,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//DatabaseCouplingReports rates the database coupling of the run's applications with database code or constructs,
+//the tightest first. app narrows them down to one.
+func DatabaseCouplingReports(findingRepository db.FindingRepository, runRepository db.RunRepository, slocRepository db.SlocRepository, runId uint, app string) ([]model.DatabaseCoupling, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	slocs, err := slocRepository.GetSlocForRun(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := findingRepository.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	couplings := []model.DatabaseCoupling{}
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+		coupling := model.EvaluateDatabaseCoupling(application.Name, slocs, tagTotals[application.Name], model.DatabaseConstructs)
+		if coupling.Coupling != model.DB_COUPLING_NONE {
+			couplings = append(couplings, coupling)
+		}
+	}
+
+	sort.SliceStable(couplings, func(i, j int) bool {
+		if couplings[i].Coupling != couplings[j].Coupling {
+			return dbCouplingOrder[couplings[i].Coupling] < dbCouplingOrder[couplings[j].Coupling]
+		}
+		return couplings[i].CodeLines > couplings[j].CodeLines
+	})
+
+	return couplings, nil
+}
+
+func (databaseService *DatabaseReportService) RunDatabaseReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(databaseService.runRepository, "csa")
+	}
+
+	couplings, err := DatabaseCouplingReports(databaseService.findingRepository, databaseService.runRepository, databaseService.slocRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the database coupling of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-database-coupling", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(couplings, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Database coupling written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A findings column per construct
+	headers := []string{"application", "coupling", "database files", "database sloc", "routines"}
+	for _, construct := range model.DatabaseConstructs {
+		headers = append(headers, construct.Name)
+	}
+	headers = append(headers, "effort", "tags")
+
+	var data [][]string
+	for _, coupling := range couplings {
+		row := []string{coupling.Application, coupling.Coupling, fmt.Sprint(coupling.Files), fmt.Sprint(coupling.CodeLines), fmt.Sprint(coupling.Routines)}
+		found := make(map[string]model.DatabaseConstructResult)
+		var tags []string
+		for _, construct := range coupling.Constructs {
+			found[construct.Construct] = construct
+			tags = append(tags, construct.Tags...)
+		}
+		for _, construct := range model.DatabaseConstructs {
+			row = append(row, fmt.Sprint(found[construct.Name].Findings))
+		}
+		data = append(data, append(row, fmt.Sprint(coupling.Effort), strings.Join(tags, ",")))
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Database coupling written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	databaseService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Database Coupling", runId), false)
+}
+
+//DatabaseInventoryReports inventories the database drivers, urls, dialects and connection strings of the run's
+//applications connecting to a database, from their (first party) findings tagged database-connection. Applications
+//are grouped by their primary vendor, in the vendors' order, to plan migration waves. app narrows them down to one.
+func DatabaseInventoryReports(findingRepository db.FindingRepository, runRepository db.RunRepository, runId uint, app string) ([]model.DatabaseInventory, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := findingRepository.GetFindingsByTag(runId, model.DB_CONNECTION_TAG)
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []model.Finding
+	for _, finding := range model.ExcludeTriaged(findings) {
+		if finding.ThirdParty == "" {
+			connections = append(connections, finding)
+		}
+	}
+
+	inventories := []model.DatabaseInventory{}
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+		inventory := model.EvaluateDatabaseInventory(application.Name, connections, model.DatabaseVendors, model.DatabaseConnectionKinds)
+		if inventory.ConnectsToDatabase() {
+			inventories = append(inventories, inventory)
+		}
+	}
+
+	vendorOrder := make(map[string]int)
+	for i, vendor := range model.DatabaseVendors {
+		vendorOrder[vendor.Name] = i
+	}
+	vendorOrder[model.DB_VENDOR_UNKNOWN] = len(model.DatabaseVendors)
+
+	sort.SliceStable(inventories, func(i, j int) bool {
+		if inventories[i].Primary != inventories[j].Primary {
+			return vendorOrder[inventories[i].Primary] < vendorOrder[inventories[j].Primary]
+		}
+		return inventories[i].Application < inventories[j].Application
+	})
+
+	return inventories, nil
+}
+
+func (databaseService *DatabaseReportService) RunDatabaseInventoryReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(databaseService.runRepository, "csa")
+	}
+
+	inventories, err := DatabaseInventoryReports(databaseService.findingRepository, databaseService.runRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the database inventory of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-database-inventory", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(inventories, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Database inventory written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A row per connection
+	headers := []string{"application", "primary", "vendor", "license", "xa", "kind", "value", "uses", "file", "line"}
+
+	licenses := make(map[string]string)
+	for _, vendor := range model.DatabaseVendors {
+		licenses[vendor.Name] = vendor.License
+	}
+
+	var data [][]string
+	for _, inventory := range inventories {
+		for _, connection := range inventory.Connections {
+			data = append(data, []string{inventory.Application, inventory.Primary, connection.Vendor, licenses[connection.Vendor],
+				fmt.Sprint(connection.XA), connection.Kind, connection.Value, fmt.Sprint(connection.Uses), connection.File, fmt.Sprint(connection.Line)})
+		}
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Database inventory written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	databaseService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Database Inventory", runId), false)
+}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//DependencyReportService reports which applications of a run depend on each other, and in which wave each of them can
+//be migrated given those dependencies
+type DependencyReportService struct {
+	dependencyRepository db.DependencyRepository
+	runRepository        db.RunRepository
+	reportService        *ReportService
+}
+
+func NewDependencyReportService(mgr *db.Repositories) *DependencyReportService {
+	return &DependencyReportService{
+		dependencyRepository: mgr.Dependencies,
+		runRepository:        mgr.Run,
+		reportService:        NewReportSvc(mgr),
+	}
+}
+
+//DependencyGraphFor builds the dependency graph of the run's applications from the interfaces detected in them
+func DependencyGraphFor(runRepository db.RunRepository, dependencyRepository db.DependencyRepository, runId uint) (*model.DependencyGraph, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces, err := dependencyRepository.GetAppInterfaces(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, app := range apps {
+		names = append(names, app.Name)
+	}
+
+	return model.BuildDependencyGraph(names, interfaces), nil
+}
+
+func (dependencyService *DependencyReportService) RunDependencyReport(runId uint, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(dependencyService.runRepository, "csa")
+	}
+
+	graph, err := DependencyGraphFor(dependencyService.runRepository, dependencyService.dependencyRepository, runId)
+	checkReportError("dependencies", err)
+
+	name := fmt.Sprintf("%d-dependencies", runId)
+
+	switch format {
+	case util.JSON:
+		util.WriteStructToFile(graph, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Dependency graph written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	case util.DOT:
+		fileName, err := writeDot(graph, name)
+		checkReportError("dependencies", err)
+		fmt.Printf("Dependency graph written to [%s]\n", fileName)
+		return
+	}
+
+	headers := []string{"application", "depends on", "kind", "shared", "names", "evidence"}
+	var data [][]string
+	for _, edge := range graph.Edges {
+		data = append(data, []string{edge.From, edge.To, edge.Kind, fmt.Sprint(edge.Shared), strings.Join(edge.Names, ","),
+			strings.Join(edge.Evidence, ",")})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Dependency graph written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	dependencyService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Application Dependencies", runId), false)
+
+	var summary [][]string
+	for _, node := range graph.Applications {
+		summary = append(summary, []string{node.Application, fmt.Sprint(node.Wave), fmt.Sprint(node.DependsOn),
+			fmt.Sprint(node.Dependents), fmt.Sprint(node.Shared)})
+	}
+
+	dependencyService.reportService.DisplayReport([]string{"application", "wave", "depends on", "dependents", "shares with"}, summary,
+		fmt.Sprintf("Run [%d] Migration Waves", runId), false)
+}
+
+//writeDot writes the graph for graphviz, I.E. dot -Tsvg 1-dependencies.dot > dependencies.svg
+func writeDot(graph *model.DependencyGraph, name string) (string, error) {
+
+	util.CheckAndCreateDir(*util.OutputDir)
+	fileName := fmt.Sprintf("%s%s%s.%s", *util.OutputDir, util.PathSeparator, name, util.DOT)
+
+	write := func(out io.Writer) error {
+		return graph.WriteDot(out)
+	}
+
+	if util.RunWorkspace != nil {
+		return fileName, util.RunWorkspace.Stage(fileName, write)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fileName, err
+	}
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return fileName, err
+}
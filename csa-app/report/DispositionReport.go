@@ -0,0 +1,111 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//DispositionReportService recommends a 6R disposition for each application of a run
+type DispositionReportService struct {
+	repositories  *db.Repositories
+	reportService *ReportService
+}
+
+func NewDispositionReportService(mgr *db.Repositories) *DispositionReportService {
+	return &DispositionReportService{
+		repositories:  mgr,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//Dispositions recommends a disposition for the applications of the run with the rules. app narrows them down to one.
+func Dispositions(mgr *db.Repositories, runId uint, app string, rules *model.DispositionRules) ([]model.Disposition, error) {
+
+	apps, err := mgr.Run.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tech, err := mgr.TechStack.GetTechStack(runId, app)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := mgr.Findings.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	dispositions := []model.Disposition{}
+	for i := range apps {
+		if app != "" && apps[i].Name != app {
+			continue
+		}
+		disposition, err := rules.Recommend(model.NewDispositionFacts(&apps[i], tech, tagTotals[apps[i].Name]))
+		if err != nil {
+			return nil, err
+		}
+		dispositions = append(dispositions, disposition)
+	}
+
+	return dispositions, nil
+}
+
+func (dispositionService *DispositionReportService) RunDispositionReport(runId uint, app string, rulesFile string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(dispositionService.repositories.Run, "csa")
+	}
+
+	rules, err := model.LoadDispositionRules(rulesFile)
+	exitOnError("Unable to load the disposition rules", err)
+
+	dispositions, err := Dispositions(dispositionService.repositories, runId, app, rules)
+	exitOnError(fmt.Sprintf("Unable to recommend the dispositions of run [%d]", runId), err)
+
+	if app != "" && len(dispositions) == 0 {
+		exitOnError("Unable to recommend a disposition", fmt.Errorf("application [%s] is not part of run [%d]", app, runId))
+	}
+
+	name := fmt.Sprintf("%d-dispositions", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(dispositions, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Dispositions written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"application", "disposition", "score", "stateful", "blockers", "tech stack", "reason", "factors"}
+	var data [][]string
+	counts := make(map[string]int)
+	for _, disposition := range dispositions {
+		counts[disposition.Disposition]++
+		data = append(data, []string{disposition.Application, disposition.Disposition, fmt.Sprintf("%2.2f", disposition.Score),
+			fmt.Sprint(disposition.Stateful), fmt.Sprint(disposition.Blockers), strings.Join(disposition.Tech, ","),
+			disposition.Reason, strings.Join(disposition.Factors, " ")})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Dispositions written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	dispositionService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Dispositions (%s rules)", runId, rules.Name), false)
+
+	var totals []string
+	for _, disposition := range append(model.Dispositions, model.DISPOSITION_NONE) {
+		if counts[disposition] > 0 {
+			totals = append(totals, fmt.Sprintf("%s:%d", disposition, counts[disposition]))
+		}
+	}
+	fmt.Printf("Dispositions: %s\n", strings.Join(totals, " "))
+}
@@ -0,0 +1,82 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//DomainReportService rolls the applications of a run up by business domain, ranking the domains by score
+type DomainReportService struct {
+	groupRepository   db.AppGroupRepository
+	runRepository     db.RunRepository
+	scoringRepository db.ScoringRepository
+	reportService     *ReportService
+}
+
+func NewDomainReportService(mgr *db.Repositories) *DomainReportService {
+	return &DomainReportService{
+		groupRepository:   mgr.Groups,
+		runRepository:     mgr.Run,
+		scoringRepository: mgr.Scoring,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//RunDomainReport reports the run's domain roll-ups. A mapping file first (re)assigns the domains of the applications it names.
+func (domainService *DomainReportService) RunDomainReport(runId uint, mappingFile string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(domainService.runRepository, "csa")
+	}
+
+	if mappingFile != "" {
+		mapping, err := model.LoadDomainMapping(mappingFile)
+		exitOnError("Unable to load the domain mapping", err)
+
+		assigned, err := domainService.groupRepository.AssignDomains(runId, mapping)
+		exitOnError(fmt.Sprintf("Unable to assign the domains of run [%d]", runId), err)
+		fmt.Printf("Assigned the business domain of [%d] applications of run [%d]\n", assigned, runId)
+	}
+
+	rollups, err := domainService.groupRepository.GetDomainRollups(runId)
+	exitOnError(fmt.Sprintf("Unable to roll-up the domains of run [%d]", runId), err)
+
+	bins := scoreBins(domainService.scoringRepository)
+	for i := range rollups {
+		rollups[i].Tier = model.ScoreBinName(bins, rollups[i].Score)
+	}
+
+	name := fmt.Sprintf("%d-domains", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(rollups, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Domain roll-up written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"rank", "domain", "applications", "score", "sloc weighted score", "tier", "min score", "max score",
+		"lowest scoring app", "findings", "effort", "sloc"}
+	var data [][]string
+
+	for _, rollup := range rollups {
+		data = append(data, []string{fmt.Sprint(rollup.Rank), rollup.Domain, fmt.Sprint(rollup.Applications),
+			fmt.Sprintf("%2.2f", rollup.Score), fmt.Sprintf("%2.2f", rollup.WeightedScore), rollup.Tier,
+			fmt.Sprintf("%2.2f", rollup.MinScore), fmt.Sprintf("%2.2f", rollup.MaxScore), rollup.LowestApp,
+			fmt.Sprint(rollup.Findings), fmt.Sprint(rollup.Effort), fmt.Sprint(rollup.SlocCnt)})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Domain roll-up written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	domainService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Business Domains", runId), false)
+}
@@ -0,0 +1,110 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//DotnetReportService reports the target frameworks of the run's .NET applications and what keeps them from moving to
+//.NET 8
+type DotnetReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewDotnetReportService(mgr *db.Repositories) *DotnetReportService {
+	return &DotnetReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//DotnetMigrationReports evaluates the migration of the run's applications with .NET projects or blockers, the most
+//effort first. app narrows them down to one.
+func DotnetMigrationReports(findingRepository db.FindingRepository, runRepository db.RunRepository, runId uint, app string) ([]model.DotnetMigration, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := runRepository.GetDotnetProjects(runId, app)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := findingRepository.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []model.DotnetMigration{}
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+		migration := model.EvaluateDotnetMigration(application.Name, projects, tagTotals[application.Name], model.DotnetBlockers)
+		if migration.Projects > 0 || migration.Findings > 0 {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].Effort > migrations[j].Effort
+	})
+
+	return migrations, nil
+}
+
+func (dotnetService *DotnetReportService) RunDotnetReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(dotnetService.runRepository, "csa")
+	}
+
+	migrations, err := DotnetMigrationReports(dotnetService.findingRepository, dotnetService.runRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the .NET migration of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-dotnet", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(migrations, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf(".NET migration written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A row per blocker of each application, one without blocker for those that have none
+	headers := []string{"application", "projects", "legacy projects", "target frameworks", ".net framework", "blocker", "findings", "effort", "tags", "replacement"}
+
+	var data [][]string
+	for _, migration := range migrations {
+		application := []string{migration.Application, fmt.Sprint(migration.Projects), fmt.Sprint(migration.LegacyProjects),
+			strings.Join(migration.TargetFrameworks, ","), fmt.Sprint(migration.Framework)}
+		if len(migration.Blockers) == 0 {
+			data = append(data, append(application, "", "0", "0", "", ""))
+		}
+		for _, blocker := range migration.Blockers {
+			data = append(data, append(append([]string{}, application...), blocker.Blocker, fmt.Sprint(blocker.Findings),
+				fmt.Sprint(blocker.Effort), strings.Join(blocker.Tags, ","), blocker.Replacement))
+		}
+	}
+
+	if format == util.CSV {
+		fmt.Printf(".NET migration written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	dotnetService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] .NET Framework => %s Migration", runId, model.DOTNET_MIGRATION_TARGET), false)
+}
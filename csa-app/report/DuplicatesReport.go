@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Matched values are cut down to this many characters in the table
+const duplicateValueWidth = 60
+
+//DuplicatesReportService reports the findings a run's applications share, I.E. copied libraries/utility classes, as
+//single remediation items
+type DuplicatesReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+type duplicatesReport struct {
+	Duplicates []model.DuplicateFinding `json:"duplicates"`
+	Summary    model.DuplicateSummary   `json:"summary"`
+}
+
+func NewDuplicatesReportService(mgr *db.Repositories) *DuplicatesReportService {
+	return &DuplicatesReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//RunDuplicatesReport lists the findings occurring in at least minApps of the run's applications, with the effort
+//rollup of the portfolio counting each of them once
+func (duplicatesService *DuplicatesReportService) RunDuplicatesReport(runId uint, minApps int, format string) {
+
+	if minApps < 2 {
+		exitOnError("Unable to find duplicates", fmt.Errorf("min apps [%d] must be at least 2", minApps))
+	}
+
+	if runId == 0 {
+		runId = latestRunId(duplicatesService.runRepository, "csa")
+	}
+
+	findings, err := duplicatesService.findingRepository.GetScoredFindings(runId)
+	exitOnError(fmt.Sprintf("Unable to retrieve the findings of run [%d]", runId), err)
+
+	data := duplicatesReport{}
+	data.Duplicates, data.Summary = model.FindDuplicates(findings, minApps)
+
+	name := fmt.Sprintf("%d-duplicates", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(data, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Duplicate findings written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"rule", "file", "value", "apps", "findings", "effort", "deduped effort", "applications"}
+	var rows [][]string
+	for _, duplicate := range data.Duplicates {
+		value := duplicate.Value
+		if format != util.CSV {
+			value = util.Truncate(value, duplicateValueWidth)
+		}
+		rows = append(rows, []string{duplicate.Rule, duplicate.Filename, value, fmt.Sprint(len(duplicate.Applications)),
+			fmt.Sprint(duplicate.Findings), fmt.Sprint(duplicate.Effort), fmt.Sprint(duplicate.DedupedEffort), strings.Join(duplicate.Applications, ",")})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Duplicate findings written to [%s]\n", writeCsvReport(name, headers, rows))
+		return
+	}
+
+	duplicatesService.reportService.DisplayReport(headers, rows, fmt.Sprintf("Run [%d] Findings Shared by %d+ Applications", runId, minApps), false)
+
+	summary := data.Summary
+	saved := 0.0
+	if summary.Effort > 0 {
+		saved = 100 * float64(summary.Saved()) / float64(summary.Effort)
+	}
+
+	duplicatesService.reportService.DisplayReport([]string{"findings", "effort", "duplicates", "duplicate findings", "duplicate effort", "deduped effort", "saved"},
+		[][]string{{fmt.Sprint(summary.Findings), fmt.Sprint(summary.Effort), fmt.Sprint(summary.Duplicates), fmt.Sprint(summary.DuplicateFindings),
+			fmt.Sprint(summary.DuplicateEffort), fmt.Sprint(summary.DedupedEffort), fmt.Sprintf("%d (%.1f%%)", summary.Saved(), saved)}},
+		fmt.Sprintf("Run [%d] Portfolio Effort counting Duplicates once", runId), false)
+}
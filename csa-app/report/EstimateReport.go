@@ -0,0 +1,122 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+const portfolioEstimate = "portfolio"
+
+//EstimateReportService converts the effort of a run's findings into person-days and cost per application and portfolio
+type EstimateReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+type estimateReport struct {
+	Model        *model.EstimationModel `json:"model"`
+	Applications []model.Estimate       `json:"applications"`
+	Categories   []model.Estimate       `json:"categories"`
+	Portfolio    model.Estimate         `json:"portfolio"`
+}
+
+func NewEstimateReportService(mgr *db.Repositories) *EstimateReportService {
+	return &EstimateReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//RunEstimateReport estimates the run's applications, only the app when one is given, with the estimation model of the
+//file (the default model when empty)
+func (estimateService *EstimateReportService) RunEstimateReport(runId uint, app string, modelFile string, format string) {
+
+	estimationModel, err := model.LoadEstimationModel(modelFile)
+	exitOnError("Unable to load the estimation model", err)
+
+	if runId == 0 {
+		runId = latestRunId(estimateService.runRepository, "csa")
+	}
+
+	apps, err := estimateService.runRepository.GetRunApps(runId)
+	exitOnError(fmt.Sprintf("Unable to retrieve the applications of run [%d]", runId), err)
+
+	efforts, err := estimateService.findingRepository.GetAppCategoryEffort(runId)
+	exitOnError(fmt.Sprintf("Unable to retrieve the effort of run [%d]", runId), err)
+
+	data := estimateReport{Model: estimationModel}
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+		estimates := estimationModel.Estimate(application.Name, efforts[application.Name])
+		total := estimates[len(estimates)-1]
+		data.Categories = append(data.Categories, estimates[:len(estimates)-1]...)
+		data.Applications = append(data.Applications, total)
+		data.Portfolio.Add(total)
+	}
+
+	if app != "" && len(data.Applications) == 0 {
+		exitOnError("Unable to estimate", fmt.Errorf("application [%s] is not part of run [%d]", app, runId))
+	}
+
+	name := fmt.Sprintf("%d-estimate", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(data, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Estimate written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	withCost := estimationModel.DayRate > 0
+	costHeader := strings.TrimSpace("cost " + estimationModel.Currency)
+
+	headers := []string{"application", "category", "effort", "person-days"}
+	if withCost {
+		headers = append(headers, costHeader)
+	}
+
+	var rows [][]string
+	for _, estimate := range data.Categories {
+		rows = append(rows, estimateRow([]string{estimate.Application, estimate.Category}, estimate, withCost))
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Estimate written to [%s]\n", writeCsvReport(name, headers, rows))
+		return
+	}
+
+	estimateService.reportService.DisplayReport(headers, rows, fmt.Sprintf("Run [%d] Estimate (%s model)", runId, estimationModel.Name), false)
+
+	summaryHeaders := []string{"application", "effort", "person-days"}
+	if withCost {
+		summaryHeaders = append(summaryHeaders, costHeader)
+	}
+
+	var summary [][]string
+	for _, estimate := range data.Applications {
+		summary = append(summary, estimateRow([]string{estimate.Application}, estimate, withCost))
+	}
+	summary = append(summary, estimateRow([]string{portfolioEstimate}, data.Portfolio, withCost))
+
+	estimateService.reportService.DisplayReport(summaryHeaders, summary, fmt.Sprintf("Run [%d] Cost Summary", runId), false)
+}
+
+func estimateRow(row []string, estimate model.Estimate, withCost bool) []string {
+	row = append(row, fmt.Sprint(estimate.Effort), fmt.Sprintf("%.1f - %.1f", estimate.MinDays, estimate.MaxDays))
+	if withCost {
+		row = append(row, fmt.Sprintf("%.0f - %.0f", estimate.MinCost, estimate.MaxCost))
+	}
+	return row
+}
@@ -0,0 +1,120 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Exports every raw finding of a run with all of its fields, rather than the condensed report data projections
+type FindingsReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	ruleRepository    db.RuleRepository
+}
+
+func NewFindingsReportService(mgr *db.Repositories) *FindingsReportService {
+	return &FindingsReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		ruleRepository:    mgr.Rules,
+	}
+}
+
+func (findingsService *FindingsReportService) RunFindingsReport(runId uint, app string) {
+
+	if runId == 0 {
+		runId = latestRunId(findingsService.runRepository, "csa")
+	}
+
+	fileName, rows, err := findingsService.ExportFindings(runId, app, FindingsReportName(runId, app))
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve findings for run [%d]! Details: %v", runId, err)
+	}
+
+	fmt.Printf("[%d] findings written to [%s]\n", rows, fileName)
+}
+
+//FindingsReportName is the name the findings of a run, or of one of its apps, are exported under
+func FindingsReportName(runId uint, app string) string {
+	if app != "" {
+		return fmt.Sprintf("%d-%s-findings", runId, strings.NewReplacer(util.PathSeparator, "_", " ", "_").Replace(app))
+	}
+	return fmt.Sprintf("%d-findings", runId)
+}
+
+//ExportFindings writes the findings csv named name to the output dir and returns the file name and number of findings
+//written
+func (findingsService *FindingsReportService) ExportFindings(runId uint, app string, name string) (string, int, error) {
+
+	scale, err := effortScale()
+	if err != nil {
+		return "", 0, err
+	}
+
+	findings, err := findingsService.findingRepository.GetFindings(runId)
+	if err != nil {
+		return "", 0, err
+	}
+	//Suppressed/false positive findings are audited with `triage list` instead
+	findings = model.ExcludeTriaged(findings)
+
+	//Runs without rule metrics simply have no deprecations to flag
+	metrics, _ := findingsService.ruleRepository.GetRuleMetrics(runId)
+	deprecations := model.Deprecations(metrics)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Application != findings[j].Application {
+			return findings[i].Application < findings[j].Application
+		}
+		if findings[i].Fqn != findings[j].Fqn {
+			return findings[i].Fqn < findings[j].Fqn
+		}
+		return findings[i].Line < findings[j].Line
+	})
+
+	headers := []string{"id", "application", "rule", "pattern", "tags", "category", "criticality", "severity", "confidence", "triage", "effort", "readiness",
+		"filename", "fqn", "ext", "line", "end line", "value", "advice", "note", "recipes", "sha", "context", "deprecated rule"}
+	if scale != nil {
+		headers = append(headers, scale.Label())
+	}
+	var data [][]string
+
+	for _, finding := range findings {
+		if app != "" && finding.Application != app {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range finding.Tags {
+			tags = append(tags, tag.Value)
+		}
+
+		var recipes []string
+		for _, recipe := range finding.Recipes {
+			recipes = append(recipes, recipe.URI)
+		}
+
+		row := []string{fmt.Sprint(finding.ID), finding.Application, finding.Rule, finding.Pattern,
+			strings.Join(tags, ";"), finding.Category, finding.Criticality, finding.Severity, finding.Confidence, finding.Triage, fmt.Sprint(finding.Effort), fmt.Sprint(finding.Readiness),
+			finding.Filename, finding.Fqn, finding.Ext, fmt.Sprint(finding.Line), fmt.Sprint(finding.EndLine), finding.Value, finding.Advice, finding.Note,
+			strings.Join(recipes, ";"), finding.Sha(), finding.Context, deprecations[finding.Rule]}
+		if scale != nil {
+			row = append(row, scale.Convert(finding.Effort))
+		}
+
+		data = append(data, row)
+	}
+
+	fileName, err := exportCsvReport(name, headers, data)
+	return fileName, len(data), err
+}
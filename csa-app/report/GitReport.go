@@ -34,7 +34,7 @@ func (gitReportService GitReportService) RunGitReports(run *model.Run) {
 	if _, err := os.Stat(fmt.Sprintf("%s/.git", run.Target)); err != nil {
 		//Repository Path does not contain a .git dir
 		util.App.Errorf("%s\n", fmt.Sprintf("Path [%s] does not contain a GIT Repo!", run.Target))
-		util.App.Usage(os.Args)
+		util.App.Usage(os.Args[1:])
 		os.Exit(1)
 	}
 
@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+)
+
+type GroupService struct {
+	groupRepository   db.AppGroupRepository
+	runRepository     db.RunRepository
+	scoringRepository db.ScoringRepository
+	reportService     *ReportService
+}
+
+func NewGroupService(mgr *db.Repositories) *GroupService {
+	return &GroupService{
+		groupRepository:   mgr.Groups,
+		runRepository:     mgr.Run,
+		scoringRepository: mgr.Scoring,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (groupService *GroupService) ListGroups() {
+	groups, err := groupService.groupRepository.GetGroups()
+	exitOnError("Unable to retrieve groups", err)
+
+	headers := []string{"name", "type", "parent", "applications"}
+	var data [][]string
+
+	for _, group := range groups {
+		var apps []string
+		for _, member := range group.Applications {
+			apps = append(apps, member.Application)
+		}
+		data = append(data, []string{group.Name, group.Type, group.Parent, strings.Join(apps, ",")})
+	}
+
+	groupService.reportService.DisplayReport(headers, data, "Application Groups", false)
+}
+
+func (groupService *GroupService) CreateGroup(name string, groupType string, parent string) {
+	group := &model.AppGroup{Name: name, Type: groupType, Parent: parent}
+	exitOnError(fmt.Sprintf("Unable to create group [%s]", name), groupService.groupRepository.SaveGroup(group))
+	fmt.Printf("Created %s [%s]\n", groupType, name)
+}
+
+func (groupService *GroupService) DeleteGroup(name string) {
+	exitOnError(fmt.Sprintf("Unable to delete group [%s]", name), groupService.groupRepository.DeleteGroup(name))
+	fmt.Printf("Deleted group [%s]\n", name)
+}
+
+func (groupService *GroupService) AddApplication(groupName string, appName string) {
+	exitOnError(fmt.Sprintf("Unable to add application [%s] to group [%s]", appName, groupName), groupService.groupRepository.AddApplication(groupName, appName))
+	fmt.Printf("Added application [%s] to group [%s]\n", appName, groupName)
+}
+
+func (groupService *GroupService) RemoveApplication(groupName string, appName string) {
+	exitOnError(fmt.Sprintf("Unable to remove application [%s] from group [%s]", appName, groupName), groupService.groupRepository.RemoveApplication(groupName, appName))
+	fmt.Printf("Removed application [%s] from group [%s]\n", appName, groupName)
+}
+
+func (groupService *GroupService) RollupReport(runId uint) {
+
+	if runId == 0 {
+		runId = latestRunId(groupService.runRepository, "csa")
+	}
+
+	rollups, err := groupService.groupRepository.GetGroupRollups(runId)
+	exitOnError(fmt.Sprintf("Unable to roll-up groups for run [%d]", runId), err)
+
+	bins := scoreBins(groupService.scoringRepository)
+
+	headers := []string{"group", "type", "parent", "applications", "score", "tier", "findings", "effort", "sloc"}
+	var data [][]string
+
+	for _, rollup := range rollups {
+		data = append(data, []string{rollup.Name, rollup.Type, rollup.Parent, fmt.Sprint(rollup.Applications),
+			fmt.Sprintf("%2.2f", rollup.Score), model.ScoreBinName(bins, rollup.Score), fmt.Sprint(rollup.Findings), fmt.Sprint(rollup.Effort), fmt.Sprint(rollup.SlocCnt)})
+	}
+
+	groupService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Group Roll-up", runId), false)
+}
@@ -0,0 +1,75 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//JdkReportService reports the JDK APIs the applications of a run use that its target JDK removed or deprecated
+type JdkReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewJdkReportService(mgr *db.Repositories) *JdkReportService {
+	return &JdkReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (jdkService *JdkReportService) RunJdkReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(jdkService.runRepository, "csa")
+	}
+
+	run, err := jdkService.runRepository.GetRun(runId)
+	exitOnError(fmt.Sprintf("Unable to retrieve run [%d]", runId), err)
+
+	if run.TargetJdk == 0 {
+		exitOnError(fmt.Sprintf("Unable to report on run [%d]", runId), fmt.Errorf("the run didn't target a JDK. Analyze with --target-jdk"))
+	}
+
+	findings, err := jdkService.findingRepository.GetJdkFindings(runId, app)
+	exitOnError(fmt.Sprintf("Unable to retrieve the jdk findings of run [%d]", runId), err)
+
+	usages := model.SummarizeJdkUsage(findings, run.TargetJdk)
+
+	name := fmt.Sprintf("%d-jdk-%d", runId, run.TargetJdk)
+
+	if format == util.JSON {
+		util.WriteStructToFile(usages, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("JDK report written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"application", "api", "status", "since", "findings", "files", "effort", "replacement"}
+	var data [][]string
+	for _, usage := range usages {
+		data = append(data, []string{usage.Application, usage.Description, usage.Status, model.JdkRelease(usage.Release),
+			fmt.Sprint(usage.Findings), fmt.Sprint(usage.Files), fmt.Sprint(usage.Effort), usage.Replacement})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("JDK report written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	title := fmt.Sprintf("Run [%d] JDK %d Deprecated/Removed API Usage", runId, run.TargetJdk)
+	if app != "" {
+		title = fmt.Sprintf("Run [%d] App [%s] JDK %d Deprecated/Removed API Usage", runId, app, run.TargetJdk)
+	}
+
+	jdkService.reportService.DisplayReport(headers, data, title, false)
+}
@@ -0,0 +1,90 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//KubernetesReportService reports the issues of the Kubernetes manifests and Helm charts of the run's applications,
+//the findings tagged kubernetes
+type KubernetesReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewKubernetesReportService(mgr *db.Repositories) *KubernetesReportService {
+	return &KubernetesReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//KubernetesManifestReports groups the run's findings tagged kubernetes by manifest and issue, the manifests with the
+//most effort first. app narrows them down to one application.
+func KubernetesManifestReports(findingRepository db.FindingRepository, runId uint, app string) ([]model.KubernetesManifest, error) {
+
+	findings, err := findingRepository.GetFindingsByTag(runId, model.KUBERNETES_TAG)
+	if err != nil {
+		return nil, err
+	}
+
+	var appFindings []model.Finding
+	for _, finding := range findings {
+		if app == "" || finding.Application == app {
+			appFindings = append(appFindings, finding)
+		}
+	}
+
+	return model.GroupKubernetesFindings(appFindings, model.KubernetesIssues), nil
+}
+
+func (kubernetesService *KubernetesReportService) RunKubernetesReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(kubernetesService.runRepository, "csa")
+	}
+
+	manifests, err := KubernetesManifestReports(kubernetesService.findingRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the Kubernetes manifests of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-kubernetes", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(manifests, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Kubernetes manifests written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A row per issue of each manifest
+	headers := []string{"application", "manifest", "issue", "findings", "effort", "lines"}
+
+	var data [][]string
+	for _, manifest := range manifests {
+		for _, issue := range manifest.Issues {
+			var lines []string
+			for _, line := range issue.Lines {
+				lines = append(lines, fmt.Sprint(line))
+			}
+			data = append(data, []string{manifest.Application, manifest.Manifest, issue.Issue, fmt.Sprint(issue.Findings),
+				fmt.Sprint(issue.Effort), strings.Join(lines, ",")})
+		}
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Kubernetes manifests written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	kubernetesService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Kubernetes Manifest Issues", runId), false)
+}
@@ -0,0 +1,115 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//MessagingReportService reports the messaging (JMS, IBM MQ, TIBCO, ActiveMQ, Kafka, RabbitMQ) the run's applications
+//use and the queues and topics they name
+type MessagingReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewMessagingReportService(mgr *db.Repositories) *MessagingReportService {
+	return &MessagingReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//MessagingReports inventories the messaging of the run's applications using messaging, from the totals of their
+//findings by tag and their (first party) findings tagged messaging-destination. app narrows them down to one.
+func MessagingReports(findingRepository db.FindingRepository, runRepository db.RunRepository, runId uint, app string) ([]model.MessagingInventory, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := findingRepository.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := findingRepository.GetFindingsByTag(runId, model.MESSAGING_DESTINATION_TAG)
+	if err != nil {
+		return nil, err
+	}
+
+	var destinations []model.Finding
+	for _, finding := range model.ExcludeTriaged(findings) {
+		if finding.ThirdParty == "" {
+			destinations = append(destinations, finding)
+		}
+	}
+
+	inventories := []model.MessagingInventory{}
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+		inventory := model.EvaluateMessaging(application.Name, tagTotals[application.Name], destinations, model.MessagingTechnologies)
+		if inventory.UsesMessaging() {
+			inventories = append(inventories, inventory)
+		}
+	}
+
+	return inventories, nil
+}
+
+func (messagingService *MessagingReportService) RunMessagingReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(messagingService.runRepository, "csa")
+	}
+
+	inventories, err := MessagingReports(messagingService.findingRepository, messagingService.runRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the messaging of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-messaging", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(inventories, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Messaging inventory written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//A row per destination, and one per technology naming none
+	headers := []string{"application", "technology", "findings", "effort", "kind", "destination", "uses", "file", "line"}
+
+	var data [][]string
+	for _, inventory := range inventories {
+		for _, usage := range inventory.Technologies {
+			row := []string{inventory.Application, usage.Technology, fmt.Sprint(usage.Findings), fmt.Sprint(usage.Effort)}
+			if usage.Destinations == 0 {
+				data = append(data, append(row, "", "", "", "", ""))
+				continue
+			}
+			for _, destination := range inventory.Destinations {
+				if destination.Technology == usage.Technology {
+					data = append(data, append(append([]string{}, row...), destination.Kind, destination.Name, fmt.Sprint(destination.Uses),
+						destination.File, fmt.Sprint(destination.Line)))
+				}
+			}
+		}
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Messaging inventory written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	messagingService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Messaging Inventory", runId), false)
+}
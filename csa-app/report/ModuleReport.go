@@ -0,0 +1,118 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//ModuleReportService scores the modules of a run's multi-module applications apart from their application
+type ModuleReportService struct {
+	repositories  *db.Repositories
+	reportService *ReportService
+}
+
+func NewModuleReportService(mgr *db.Repositories) *ModuleReportService {
+	return &ModuleReportService{
+		repositories:  mgr,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//ScoreRunModules scores the modules of the run's applications with the run's scorer, only those of app when one is
+//given. Nothing is saved, the scores follow the findings as they are triaged.
+func ScoreRunModules(mgr *db.Repositories, runId uint, app string) ([]model.ModuleScore, error) {
+
+	run, err := mgr.Run.GetRun(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	scorer, err := model.RunScorer(&run)
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := mgr.Run.GetRunModules(runId, app)
+	if err != nil || len(modules) == 0 {
+		return []model.ModuleScore{}, err
+	}
+
+	apps, err := mgr.Run.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make(map[string]*model.ScoringModel)
+	for i := range apps {
+		if _, found := models[apps[i].ScoringModel]; !found {
+			if models[apps[i].ScoringModel], err = mgr.Scoring.GetModelByName(apps[i].ScoringModel); err != nil {
+				return nil, fmt.Errorf("unable to retrieve scoring model [%s] of app [%s]: %v", apps[i].ScoringModel, apps[i].Name, err)
+			}
+		}
+		apps[i].Model = models[apps[i].ScoringModel]
+	}
+
+	details, err := mgr.Findings.GetModuleDetails(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals := make(map[string]map[string]model.TagTotals)
+	if run.Scorer == model.FORMULA_SCORER {
+		if tagTotals, err = mgr.Findings.GetModuleTagTotals(runId); err != nil {
+			return nil, err
+		}
+	}
+
+	bins, err := mgr.Scoring.GetScoreBins()
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ScoreModules(apps, modules, details, tagTotals, scorer, bins)
+}
+
+func (moduleService *ModuleReportService) RunModuleReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(moduleService.repositories.Run, "csa")
+	}
+
+	scores, err := ScoreRunModules(moduleService.repositories, runId, app)
+	exitOnError(fmt.Sprintf("Unable to score the modules of run [%d]", runId), err)
+
+	if len(scores) == 0 {
+		fmt.Printf("Run [%d] has no multi-module applications\n", runId)
+		return
+	}
+
+	name := fmt.Sprintf("%d-modules", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(scores, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Module scores written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"application", "module", "findings", "effort", "sloc", "score", "tier", "recommendation", "app score"}
+	var data [][]string
+	for _, score := range scores {
+		data = append(data, []string{score.Application, score.Module, fmt.Sprint(score.Findings), fmt.Sprint(score.RawScore),
+			fmt.Sprint(score.SlocCnt), fmt.Sprintf("%.2f", score.Score), score.Tier, score.Recommendation, fmt.Sprintf("%.2f", score.AppScore)})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Module scores written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	moduleService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Module Scores", runId), false)
+}
@@ -0,0 +1,56 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+)
+
+//NativeReport reports the native code (JNI, loaded and bundled libraries, JNA) the run's applications depend on, which
+//blocks running them in containers. The dependencies are those of the (first party) findings tagged native-dependency,
+//the most effort first.
+var NativeReport = AppReport{
+	name:  "native",
+	title: "Native Dependencies",
+	//A row per dependency
+	headers:   []string{"application", "libraries", "kind", "library", "value", "uses", "effort", "file", "line"},
+	evaluator: nativeEvaluator,
+	less: func(a interface{}, b interface{}) bool {
+		return a.(model.NativeDependencies).Effort > b.(model.NativeDependencies).Effort
+	},
+	rows: func(report interface{}) (rows [][]string) {
+		dependencies := report.(model.NativeDependencies)
+		for _, dependency := range dependencies.Dependencies {
+			rows = append(rows, []string{dependencies.Application, strings.Join(dependencies.Libraries, ","), dependency.Kind, dependency.Library,
+				dependency.Value, fmt.Sprint(dependency.Uses), fmt.Sprint(dependency.Effort), dependency.File, fmt.Sprint(dependency.Line)})
+		}
+		return
+	},
+}
+
+func nativeEvaluator(findingRepository db.FindingRepository, runId uint) (appEvaluator, error) {
+
+	findings, err := findingRepository.GetFindingsByTag(runId, model.NATIVE_DEPENDENCY_TAG)
+	if err != nil {
+		return nil, err
+	}
+
+	var natives []model.Finding
+	for _, finding := range model.ExcludeTriaged(findings) {
+		if finding.ThirdParty == "" {
+			natives = append(natives, finding)
+		}
+	}
+
+	return func(app string) (interface{}, int) {
+		dependencies := model.EvaluateNativeDependencies(app, natives, model.NativeDependencyKinds)
+		return dependencies, dependencies.Findings
+	}, nil
+}
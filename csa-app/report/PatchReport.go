@@ -0,0 +1,168 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Turns the findings of rules with a replacement template into a unified diff patch per application
+type PatchReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	ruleRepository    db.RuleRepository
+}
+
+func NewPatchReportService(mgr *db.Repositories) *PatchReportService {
+	return &PatchReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		ruleRepository:    mgr.Rules,
+	}
+}
+
+func (patchService *PatchReportService) RunPatchReport(runId uint, app string) {
+
+	if runId == 0 {
+		runId = latestRunId(patchService.runRepository, "csa")
+	}
+
+	rules, err := patchService.ruleRepository.GetRules()
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve rules! Details: %v", err)
+	}
+
+	fixable := make(map[string]*model.Rule)
+	for i := range rules {
+		if rules[i].IsFixable() {
+			rules[i].CompilePatterns()
+			fixable[rules[i].Name] = &rules[i]
+		}
+	}
+
+	if len(fixable) == 0 {
+		fmt.Println("No rule has a replacement (replace) to generate patches from")
+		return
+	}
+
+	apps, err := patchService.runRepository.GetRunApps(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve applications for run [%d]! Details: %v", runId, err)
+	}
+
+	findings, err := patchService.findingRepository.GetFindings(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve findings for run [%d]! Details: %v", runId, err)
+	}
+
+	found := false
+	for i := range apps {
+		if app != "" && apps[i].Name != app {
+			continue
+		}
+		found = true
+
+		diffs, fixes := patchService.appDiffs(&apps[i], findings, fixable)
+		if len(diffs) == 0 {
+			fmt.Printf("No fixes for app [%s]\n", apps[i].Name)
+			continue
+		}
+
+		fileName, err := writePatch(runId, apps[i].Name, diffs)
+		if err != nil {
+			util.App.Fatalf("Unable to write patch for app [%s]! Details: %v", apps[i].Name, err)
+		}
+		fmt.Printf("Patch for app [%s] (%d fixes in %d files) written to [%s]\n", apps[i].Name, fixes, len(diffs), fileName)
+	}
+
+	if !found {
+		util.App.Fatalf("Run [%d] has no application named [%s]", runId, app)
+	}
+}
+
+//appDiffs fixes the application's files as they are now. Third-party code and files that are no longer under the
+//application's path (I.E. decompiled from an archive) are left alone.
+func (patchService *PatchReportService) appDiffs(app *model.Application, findings []model.Finding, rules map[string]*model.Rule) (diffs []string, fixes int) {
+
+	byFile := make(map[string][]model.Finding)
+	for _, finding := range findings {
+		if finding.Application == app.Name && finding.ThirdParty == "" && rules[finding.Rule] != nil {
+			byFile[finding.Fqn] = append(byFile[finding.Fqn], finding)
+		}
+	}
+
+	files := make([]string, 0, len(byFile))
+	for fqn := range byFile {
+		files = append(files, fqn)
+	}
+	sort.Strings(files)
+
+	for _, fqn := range files {
+
+		relPath, err := filepath.Rel(app.Path, fqn)
+		if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+			util.TrackError("patch report", fmt.Errorf("file [%s] is not under the path [%s] of app [%s]", fqn, app.Path, app.Name))
+			continue
+		}
+
+		contents, err := ioutil.ReadFile(fqn)
+		if err != nil {
+			util.TrackError("patch report", fmt.Errorf("unable to read file [%s] of app [%s]: %v", fqn, app.Name, err))
+			continue
+		}
+
+		fix := model.FixFile(filepath.ToSlash(relPath), string(contents), byFile[fqn], rules)
+		diff, err := fix.UnifiedDiff()
+		if err != nil {
+			util.TrackError("patch report", fmt.Errorf("unable to diff file [%s] of app [%s]: %v", fqn, app.Name, err))
+			continue
+		}
+
+		if diff != "" {
+			diffs = append(diffs, diff)
+			fixes += fix.Fixes
+		}
+	}
+
+	return diffs, fixes
+}
+
+func writePatch(runId uint, app string, diffs []string) (string, error) {
+
+	util.CheckAndCreateDir(*util.OutputDir)
+	name := fmt.Sprintf("%d-%s", runId, strings.NewReplacer(util.PathSeparator, "_", " ", "_").Replace(app))
+	fileName := fmt.Sprintf("%s%s%s.patch", *util.OutputDir, util.PathSeparator, name)
+
+	write := func(out io.Writer) error {
+		_, err := io.WriteString(out, strings.Join(diffs, ""))
+		return err
+	}
+
+	if util.RunWorkspace != nil {
+		return fileName, util.RunWorkspace.Stage(fileName, write)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fileName, err
+	}
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return fileName, err
+}
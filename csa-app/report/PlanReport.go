@@ -0,0 +1,191 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+const (
+	PLAN_MARKDOWN = "md"
+	PLAN_DOCX     = "docx"
+)
+
+//DefaultPlanTemplate is used when no --template is given. Templates are go text/templates executed against a
+//model.RemediationPlan that produce markdown; docx plans are converted from that markdown.
+const DefaultPlanTemplate = `# Remediation Plan: {{ .Application }}
+
+Generated {{ .Generated.Format "2006-01-02" }} from run {{ .RunID }}.
+
+| Findings | Effort | Owners |
+| --- | --- | --- |
+| {{ .Findings }} | {{ effort .Effort }} | {{ join .Owners ", " }} |
+{{ range .Categories }}
+## {{ .Name }} ({{ .Findings }} findings, effort {{ effort .Effort }})
+{{ range .Items }}
+### {{ .Rule }}
+
+- Criticality: {{ .Criticality }}{{ if .Severity }}, severity: {{ .Severity }}{{ end }}
+- Findings: {{ .Findings }} in {{ len .Files }} file(s), effort {{ effort .Effort }}
+- Owners: {{ join .Owners ", " }}
+{{- if .Advice }}
+- Advice: {{ .Advice }}
+{{- end }}
+{{- if .Recipes }}
+- Recipes: {{ join .Recipes ", " }}
+{{- end }}
+- Files:
+{{- range .Files }}
+  - {{ . }}
+{{- end }}
+{{ end }}{{ end }}`
+
+//Turns a run's findings into a remediation plan document per application, following a configurable template
+type PlanReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+}
+
+func NewPlanReportService(mgr *db.Repositories) *PlanReportService {
+	return &PlanReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+	}
+}
+
+func (planService *PlanReportService) RunPlanReport(runId uint, app string, templateFile string, format string, ownersFile string) {
+
+	if runId == 0 {
+		runId = latestRunId(planService.runRepository, "csa")
+	}
+
+	tmpl, err := planTemplate(templateFile)
+	if err != nil {
+		util.App.Fatalf("Unable to load plan template [%s]! Details: %v", templateFile, err)
+	}
+
+	var owners *model.CodeOwners
+	if ownersFile != "" {
+		owners, err = model.LoadCodeOwners(ownersFile)
+		if err != nil {
+			util.App.Fatalf("Unable to load owners file [%s]! Details: %v", ownersFile, err)
+		}
+	}
+
+	apps, err := planService.runRepository.GetRunApps(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve applications for run [%d]! Details: %v", runId, err)
+	}
+
+	findings, err := planService.findingRepository.GetFindings(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve findings for run [%d]! Details: %v", runId, err)
+	}
+	findings = model.ExcludeTriaged(findings)
+
+	written := 0
+	for i := range apps {
+		if app != "" && apps[i].Name != app {
+			continue
+		}
+
+		appOwners := owners
+		if appOwners == nil {
+			if appOwners, err = model.FindCodeOwners(apps[i].Path); err != nil {
+				util.TrackError("plan report", fmt.Errorf("unable to read CODEOWNERS of app [%s]: %v", apps[i].Name, err))
+			}
+		}
+
+		plan := model.BuildRemediationPlan(runId, &apps[i], findings, appOwners)
+
+		fileName, err := writePlan(plan, tmpl, format)
+		if err != nil {
+			util.App.Fatalf("Unable to write remediation plan for app [%s]! Details: %v", apps[i].Name, err)
+		}
+		fmt.Printf("Remediation plan for app [%s] (%d findings) written to [%s]\n", apps[i].Name, plan.Findings, fileName)
+		written++
+	}
+
+	if written == 0 {
+		util.App.Fatalf("Run [%d] has no application named [%s]", runId, app)
+	}
+}
+
+func planTemplate(templateFile string) (*template.Template, error) {
+
+	text := DefaultPlanTemplate
+	if templateFile != "" {
+		contents, err := ioutil.ReadFile(templateFile)
+		if err != nil {
+			return nil, err
+		}
+		text = string(contents)
+	}
+
+	funcs := template.FuncMap{
+		"join": strings.Join,
+		"effort": func(effort int) string {
+			if scale, err := effortScale(); err == nil && scale != nil {
+				return scale.Convert(effort)
+			}
+			return fmt.Sprint(effort)
+		},
+	}
+
+	return template.New("plan").Funcs(funcs).Parse(text)
+}
+
+//RenderPlan executes the template against the plan and writes it in the requested format (md|docx)
+func RenderPlan(out io.Writer, plan *model.RemediationPlan, tmpl *template.Template, format string) error {
+
+	var markdown bytes.Buffer
+	if err := tmpl.Execute(&markdown, plan); err != nil {
+		return err
+	}
+
+	if format == PLAN_DOCX {
+		return writeDocx(out, markdown.String())
+	}
+
+	_, err := out.Write(markdown.Bytes())
+	return err
+}
+
+func writePlan(plan *model.RemediationPlan, tmpl *template.Template, format string) (string, error) {
+
+	util.CheckAndCreateDir(*util.OutputDir)
+	name := fmt.Sprintf("%d-%s-plan", plan.RunID, strings.NewReplacer(util.PathSeparator, "_", " ", "_").Replace(plan.Application))
+	fileName := fmt.Sprintf("%s%s%s.%s", *util.OutputDir, util.PathSeparator, name, format)
+
+	write := func(out io.Writer) error {
+		return RenderPlan(out, plan, tmpl, format)
+	}
+
+	if util.RunWorkspace != nil {
+		return fileName, util.RunWorkspace.Stage(fileName, write)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fileName, err
+	}
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return fileName, err
+}
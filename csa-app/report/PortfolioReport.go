@@ -0,0 +1,116 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//PortfolioReportService aggregates all the applications of a run into a single portfolio report
+type PortfolioReportService struct {
+	repositories  *db.Repositories
+	reportService *ReportService
+}
+
+func NewPortfolioReportService(mgr *db.Repositories) *PortfolioReportService {
+	return &PortfolioReportService{
+		repositories:  mgr,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//BuildPortfolio ranks and aggregates the applications of the run, see model.NewPortfolio
+func BuildPortfolio(mgr *db.Repositories, runId uint) (*model.Portfolio, error) {
+
+	apps, err := mgr.Run.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := mgr.Findings.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	bins, err := mgr.Scoring.GetScoreBins()
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPortfolio(runId, apps, tagTotals, bins), nil
+}
+
+//RunPortfolioReport reports the ranking, totals, quartiles, deployment models and top tags of the run's applications.
+//The csv holds the ranking, each application with its deployment model and quartile, while the json holds the whole
+//portfolio.
+func (portfolioService *PortfolioReportService) RunPortfolioReport(runId uint, top int, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(portfolioService.repositories.Run, "csa")
+	}
+
+	portfolio, err := BuildPortfolio(portfolioService.repositories, runId)
+	exitOnError(fmt.Sprintf("Unable to build the portfolio of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-portfolio", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(portfolio, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Portfolio written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"rank", "application", "score", "tier", "recommendation", "deployment", "quartile", "findings", "effort", "sloc"}
+	var data [][]string
+	for _, app := range portfolio.Ranking {
+		data = append(data, []string{fmt.Sprint(app.Rank), app.Application, fmt.Sprintf("%2.2f", app.Score), app.Tier,
+			app.Recommendation, app.Deployment, fmt.Sprint(app.Quartile), fmt.Sprint(app.Findings), fmt.Sprint(app.Effort), fmt.Sprint(app.SlocCnt)})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Portfolio written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	portfolioService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Portfolio Ranking", runId), false)
+
+	headers = []string{"quartile", "applications", "min score", "max score", "avg score", "findings", "effort", "sloc"}
+	data = nil
+	for _, quartile := range portfolio.Quartiles {
+		data = append(data, []string{fmt.Sprintf("Q%d", quartile.Quartile), fmt.Sprint(quartile.Applications),
+			fmt.Sprintf("%2.2f", quartile.MinScore), fmt.Sprintf("%2.2f", quartile.MaxScore), fmt.Sprintf("%2.2f", quartile.Score),
+			fmt.Sprint(quartile.Findings), fmt.Sprint(quartile.Effort), fmt.Sprint(quartile.SlocCnt)})
+	}
+	data = append(data, []string{"portfolio", fmt.Sprint(portfolio.Applications), "", "", fmt.Sprintf("%2.2f", portfolio.Score),
+		fmt.Sprint(portfolio.Findings), fmt.Sprint(portfolio.Effort), fmt.Sprint(portfolio.SlocCnt)})
+
+	portfolioService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Portfolio Quartiles (median score %2.2f)", runId, portfolio.MedianScore), false)
+
+	headers = []string{"deployment", "applications"}
+	data = nil
+	for _, deployment := range []string{model.DEPLOYMENT_EMBEDDED, model.DEPLOYMENT_HYBRID, model.DEPLOYMENT_EXTERNAL, model.DEPLOYMENT_UNKNOWN} {
+		if portfolio.Deployments[deployment] > 0 {
+			data = append(data, []string{deployment, fmt.Sprint(portfolio.Deployments[deployment])})
+		}
+	}
+
+	portfolioService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Portfolio Deployment Models", runId), false)
+
+	headers = []string{"tag", "applications", "findings", "effort"}
+	data = nil
+	for i, tag := range portfolio.Tags {
+		if top > 0 && i == top {
+			break
+		}
+		data = append(data, []string{tag.Tag, fmt.Sprint(tag.Applications), fmt.Sprint(tag.Findings), fmt.Sprint(tag.Effort)})
+	}
+
+	portfolioService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Portfolio Findings By Tag", runId), false)
+}
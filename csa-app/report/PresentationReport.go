@@ -0,0 +1,121 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//PresentationReportService reports the presentation tier (JSP, JSF, Tiles, Struts) of the run's applications and
+//the effort of modernizing it, apart from the effort of their backend
+type PresentationReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	slocRepository    db.SlocRepository
+	reportService     *ReportService
+}
+
+func NewPresentationReportService(mgr *db.Repositories) *PresentationReportService {
+	return &PresentationReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		slocRepository:    mgr.Sloc,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//PresentationTierReports measures the presentation tier of the run's applications with pages or presentation
+//findings, the most presentation effort first. app narrows them down to one.
+func PresentationTierReports(findingRepository db.FindingRepository, runRepository db.RunRepository, slocRepository db.SlocRepository, runId uint, app string) ([]model.PresentationTier, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	slocs, err := slocRepository.GetSlocForRun(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := findingRepository.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryEffort, err := findingRepository.GetAppCategoryEffort(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers := []model.PresentationTier{}
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+		tier := model.EvaluatePresentationTier(application.Name, slocs, tagTotals[application.Name], categoryEffort[application.Name], model.PresentationFrameworks)
+		if tier.HasPresentationTier() {
+			tiers = append(tiers, tier)
+		}
+	}
+
+	sort.SliceStable(tiers, func(i, j int) bool {
+		return tiers[i].Effort > tiers[j].Effort
+	})
+
+	return tiers, nil
+}
+
+func (presentationService *PresentationReportService) RunPresentationReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(presentationService.runRepository, "csa")
+	}
+
+	tiers, err := PresentationTierReports(presentationService.findingRepository, presentationService.runRepository, presentationService.slocRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to report the presentation tier of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-presentation-tier", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(tiers, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Presentation tier written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	//An effort column per framework
+	headers := []string{"application", "pages", "page sloc", "scriptlets", "taglibs", "session state", "component libraries"}
+	for _, framework := range model.PresentationFrameworks {
+		headers = append(headers, framework.Name)
+	}
+	headers = append(headers, "presentation effort", "backend effort", "share %")
+
+	var data [][]string
+	for _, tier := range tiers {
+		row := []string{tier.Application, fmt.Sprint(tier.Pages), fmt.Sprint(tier.CodeLines), fmt.Sprint(tier.Scriptlets), fmt.Sprint(tier.Taglibs),
+			fmt.Sprint(tier.SessionState), fmt.Sprint(tier.ComponentLibraries)}
+		found := make(map[string]int)
+		for _, framework := range tier.Frameworks {
+			found[framework.Framework] = framework.Effort
+		}
+		for _, framework := range model.PresentationFrameworks {
+			row = append(row, fmt.Sprint(found[framework.Name]))
+		}
+		data = append(data, append(row, fmt.Sprint(tier.Effort), fmt.Sprint(tier.BackendEffort), fmt.Sprint(tier.Share)))
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Presentation tier written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	presentationService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Presentation Tier", runId), false)
+}
@@ -0,0 +1,75 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/util"
+)
+
+//Reports how findings evolved since each application's previous run (new/recurring/resolved)
+type ResolvedReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewResolvedReportService(mgr *db.Repositories) *ResolvedReportService {
+	return &ResolvedReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (resolvedService *ResolvedReportService) RunResolvedReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(resolvedService.runRepository, "csa")
+	}
+
+	apps, err := resolvedService.runRepository.GetRunApps(runId)
+	checkReportError("resolved-summary", err)
+
+	summaryHeaders := []string{"application", "baseline run", "new", "recurring", "resolved"}
+	var summaryData [][]string
+
+	for _, application := range apps {
+		if app != "" && application.Name != app {
+			continue
+		}
+
+		baseline := "-"
+		if application.BaselineRunID > 0 {
+			baseline = fmt.Sprint(application.BaselineRunID)
+		}
+
+		summaryData = append(summaryData, []string{application.Name, baseline, fmt.Sprint(application.NewCnt),
+			fmt.Sprint(application.RecurringCnt), fmt.Sprint(application.ResolvedCnt)})
+	}
+
+	resolved, err := resolvedService.findingRepository.GetResolvedFindings(runId, app)
+	checkReportError("resolved-findings", err)
+
+	headers := []string{"application", "baseline run", "rule", "category", "criticality", "severity", "effort", "fqn", "line", "value"}
+	var data [][]string
+
+	for _, finding := range resolved {
+		data = append(data, []string{finding.Application, fmt.Sprint(finding.RunID), finding.Rule, finding.Category,
+			finding.Criticality, finding.Severity, fmt.Sprint(finding.Effort), finding.Fqn, fmt.Sprint(finding.Line), finding.Value})
+	}
+
+	switch format {
+	case util.CSV:
+		fmt.Printf("Lifecycle summary written to [%s]\n", writeCsvReport(fmt.Sprintf("%d-lifecycle", runId), summaryHeaders, summaryData))
+		fmt.Printf("Resolved findings written to [%s]\n", writeCsvReport(fmt.Sprintf("%d-resolved-findings", runId), headers, data))
+	default:
+		resolvedService.reportService.DisplayReport(summaryHeaders, summaryData, fmt.Sprintf("Run [%d] Finding Lifecycle", runId), false)
+		resolvedService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Resolved Findings", runId), false)
+	}
+}
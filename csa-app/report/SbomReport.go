@@ -0,0 +1,91 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//SbomReportService writes a CycloneDX bill of materials of each application of a run, the libraries it declares
+type SbomReportService struct {
+	dependencyRepository db.DependencyRepository
+	runRepository        db.RunRepository
+}
+
+func NewSbomReportService(mgr *db.Repositories) *SbomReportService {
+	return &SbomReportService{
+		dependencyRepository: mgr.Dependencies,
+		runRepository:        mgr.Run,
+	}
+}
+
+//Sboms builds the bill of materials of the run's applications, or only app's. The licenses of the libraries are
+//resolved from their poms in the local maven repository (mavenRepo) and gradle cache, then licenseDB (when usable).
+func Sboms(runRepository db.RunRepository, dependencyRepository db.DependencyRepository, runId uint, app string, licenseDB string, mavenRepo string) ([]*model.Sbom, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	libraries, err := dependencyRepository.GetAppLibraries(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	var licenses *model.LicenseResolver
+	if entries, err := model.LoadLicenseDB(licenseDB); err == nil {
+		licenses = model.NewLicenseResolver(entries)
+		mavenRepo, gradleHome := model.LocalRepositories(mavenRepo)
+		appLibraries := make(map[string][]*model.AppLibrary)
+		for i := range libraries {
+			appLibraries[libraries[i].Application] = append(appLibraries[libraries[i].Application], &libraries[i])
+		}
+		for name, declared := range appLibraries {
+			licenses.AddDependencies(&model.Application{Name: name, Libraries: declared}, mavenRepo, gradleHome)
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Licenses of the libraries will not be resolved! Details: %v\n", err)
+	}
+
+	var resolve func(library *model.AppLibrary) (model.ResolvedLicense, bool)
+	if licenses != nil {
+		resolve = licenses.ResolveLibrary
+	}
+
+	now := time.Now()
+	var sboms []*model.Sbom
+	for _, application := range apps {
+		if app == "" || application.Name == app {
+			sboms = append(sboms, model.NewSbom(application.Name, libraries, resolve, now))
+		}
+	}
+
+	return sboms, nil
+}
+
+func (sbomService *SbomReportService) RunSbomReport(runId uint, app string, licenseDB string, mavenRepo string) {
+
+	if runId == 0 {
+		runId = latestRunId(sbomService.runRepository, "csa")
+	}
+
+	sboms, err := Sboms(sbomService.runRepository, sbomService.dependencyRepository, runId, app, licenseDB, mavenRepo)
+	exitOnError(fmt.Sprintf("Unable to build the bills of materials of run [%d]", runId), err)
+
+	for _, sbom := range sboms {
+		name := fmt.Sprintf("%d-%s-sbom", runId, sbom.Metadata.Component.Name)
+		util.WriteStructToFile(sbom, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Bill of materials of [%s] (%d components) written to [%s%s%s.%s]\n", sbom.Metadata.Component.Name,
+			len(sbom.Components), *util.OutputDir, util.PathSeparator, name, util.JSON)
+	}
+}
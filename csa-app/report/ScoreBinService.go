@@ -0,0 +1,107 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+)
+
+//ScoreBinService manages the score bins (tiers) application scores are classified into in reports and the UI
+type ScoreBinService struct {
+	scoringRepository db.ScoringRepository
+	reportService     *ReportService
+}
+
+func NewScoreBinService(mgr *db.Repositories) *ScoreBinService {
+	return &ScoreBinService{
+		scoringRepository: mgr.Scoring,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (binService *ScoreBinService) ListScoreBins() {
+	bins, err := binService.scoringRepository.GetScoreBins()
+	exitOnError("Unable to retrieve score bins", err)
+
+	headers := []string{"name", "min score", "max score", "color"}
+	var data [][]string
+
+	for _, bin := range bins {
+		data = append(data, []string{bin.Name, fmt.Sprint(bin.MinScore), fmt.Sprint(bin.MaxScore), bin.Color})
+	}
+
+	binService.reportService.DisplayReport(headers, data, "Score Bins", false)
+}
+
+func (binService *ScoreBinService) CreateScoreBin(name string, minScore float64, maxScore float64, color string) {
+
+	bins, err := binService.scoringRepository.GetScoreBins()
+	exitOnError("Unable to retrieve score bins", err)
+
+	if existing := findScoreBin(bins, name); existing != nil {
+		exitOnError(fmt.Sprintf("Unable to create score bin [%s]", name), fmt.Errorf("score bin [%s] already exists, update it instead", existing.Name))
+	}
+
+	bin := &model.ScoreBin{Name: name, Color: color, MinScore: minScore, MaxScore: maxScore}
+	exitOnError(fmt.Sprintf("Unable to create score bin [%s]", name), binService.scoringRepository.SaveScoreBin(bin))
+	fmt.Printf("Created score bin [%s] (%v-%v)\n", name, minScore, maxScore)
+}
+
+//UpdateScoreBin changes the given values of the bin, values left empty are kept
+func (binService *ScoreBinService) UpdateScoreBin(name string, minScore string, maxScore string, color string) {
+
+	bins, err := binService.scoringRepository.GetScoreBins()
+	exitOnError("Unable to retrieve score bins", err)
+
+	bin := findScoreBin(bins, name)
+	if bin == nil {
+		exitOnError(fmt.Sprintf("Unable to update score bin [%s]", name), fmt.Errorf("score bin [%s] does not exist", name))
+	}
+
+	if minScore != "" {
+		bin.MinScore, err = strconv.ParseFloat(minScore, 64)
+		exitOnError(fmt.Sprintf("Invalid min score [%s]", minScore), err)
+	}
+	if maxScore != "" {
+		bin.MaxScore, err = strconv.ParseFloat(maxScore, 64)
+		exitOnError(fmt.Sprintf("Invalid max score [%s]", maxScore), err)
+	}
+	if color != "" {
+		bin.Color = color
+	}
+
+	exitOnError(fmt.Sprintf("Unable to update score bin [%s]", name), binService.scoringRepository.SaveScoreBin(bin))
+	fmt.Printf("Updated score bin [%s] (%v-%v)\n", bin.Name, bin.MinScore, bin.MaxScore)
+}
+
+func (binService *ScoreBinService) DeleteScoreBin(name string) {
+	exitOnError(fmt.Sprintf("Unable to delete score bin [%s]", name), binService.scoringRepository.DeleteScoreBin(name))
+	fmt.Printf("Deleted score bin [%s]\n", name)
+}
+
+//scoreBins are the bins reports classify scores into. Reports are still produced, without tiers, when they can't be read.
+func scoreBins(scoringRepository db.ScoringRepository) []model.ScoreBin {
+	bins, err := scoringRepository.GetScoreBins()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to retrieve score bins! Details: %v\n", err)
+	}
+	return bins
+}
+
+func findScoreBin(bins []model.ScoreBin, name string) *model.ScoreBin {
+	for i := range bins {
+		if strings.EqualFold(bins[i].Name, name) {
+			return &bins[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//ScoreExplanationReportService shows the math of the scores of a run's applications
+type ScoreExplanationReportService struct {
+	repositories  *db.Repositories
+	reportService *ReportService
+}
+
+func NewScoreExplanationReportService(mgr *db.Repositories) *ScoreExplanationReportService {
+	return &ScoreExplanationReportService{
+		repositories:  mgr,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//ExplainScores explains the scores of the run's applications, only app's when one is given, with the run's scorer.
+//See model.ExplainScore.
+func ExplainScores(mgr *db.Repositories, runId uint, app string) ([]*model.ScoreExplanation, error) {
+
+	run, err := mgr.Run.GetRun(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	scorer, err := model.RunScorer(&run)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := mgr.Run.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := mgr.Findings.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := mgr.Findings.GetScoreContributions(runId, app)
+	if err != nil {
+		return nil, err
+	}
+
+	bins, err := mgr.Scoring.GetScoreBins()
+	if err != nil {
+		return nil, err
+	}
+
+	models := make(map[string]*model.ScoringModel)
+	var scored []*model.Application
+	for i := range apps {
+		application := &apps[i]
+		if _, found := models[application.ScoringModel]; !found {
+			if models[application.ScoringModel], err = mgr.Scoring.GetModelByName(application.ScoringModel); err != nil {
+				return nil, fmt.Errorf("unable to retrieve scoring model [%s] of app [%s]: %v", application.ScoringModel, application.Name, err)
+			}
+		}
+		application.Model = models[application.ScoringModel]
+		application.TagTotals = tagTotals[application.Name]
+		scored = append(scored, application)
+	}
+
+	var explanations []*model.ScoreExplanation
+	for _, application := range scored {
+		if app != "" && application.Name != app {
+			continue
+		}
+		explanation, err := model.ExplainScore(application, scored, contributions[application.Name], scorer)
+		if err != nil {
+			return nil, fmt.Errorf("unable to explain the score of app [%s]: %v", application.Name, err)
+		}
+		explanation.Tier = model.ScoreBinName(bins, explanation.Score)
+		explanations = append(explanations, explanation)
+	}
+
+	if app != "" && len(explanations) == 0 {
+		return nil, fmt.Errorf("application [%s] is not part of run [%d]", app, runId)
+	}
+
+	return explanations, nil
+}
+
+//RunScoreExplanationReport lists, per application, the rules and categories contributing to its score with the score
+//points each costs it, the costliest first. Only the top rules are shown, all of them when top isn't positive.
+func (explanationService *ScoreExplanationReportService) RunScoreExplanationReport(runId uint, app string, top int, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(explanationService.repositories.Run, "csa")
+	}
+
+	explanations, err := ExplainScores(explanationService.repositories, runId, app)
+	exitOnError(fmt.Sprintf("Unable to explain the scores of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-score-explanation", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(explanations, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Score explanation written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"application", "score", "category", "rule", "findings", "effort", "share %", "impact"}
+	if format == util.CSV {
+		var data [][]string
+		for _, explanation := range explanations {
+			for _, rule := range explanation.Rules {
+				data = append(data, contributionRow(explanation, rule))
+			}
+		}
+		fmt.Printf("Score explanation written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	for _, explanation := range explanations {
+		title := fmt.Sprintf("Run [%d] App [%s] Score [%.2f/%.2f] %s: raw score [%d] from [%d] findings, [%d] sloc, %s scorer",
+			runId, explanation.Application, explanation.Score, explanation.MaxScore, explanation.Tier, explanation.RawScore,
+			explanation.Findings, explanation.SlocCnt, explanation.Scorer)
+		if explanation.ScoreModified {
+			title += fmt.Sprintf(" (modified in the UI to [%.2f])", explanation.ModifiedScore)
+		}
+
+		var data [][]string
+		for _, category := range explanation.Categories {
+			data = append(data, contributionRow(explanation, category))
+		}
+		for i, rule := range explanation.Rules {
+			if top > 0 && i == top {
+				break
+			}
+			data = append(data, contributionRow(explanation, rule))
+		}
+
+		explanationService.reportService.DisplayReport(headers, data, title, false)
+	}
+
+	fmt.Println("Impact is the score points an application would gain were the findings resolved")
+}
+
+func contributionRow(explanation *model.ScoreExplanation, contribution model.ScoreContribution) []string {
+	return []string{explanation.Application, fmt.Sprintf("%.2f", explanation.Score), contribution.Category, contribution.Rule,
+		fmt.Sprint(contribution.Findings), fmt.Sprint(contribution.Effort), fmt.Sprintf("%.1f", contribution.Share),
+		fmt.Sprintf("%+.2f", contribution.Impact)}
+}
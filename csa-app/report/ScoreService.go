@@ -0,0 +1,137 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/model"
+)
+
+//ScoreService rescores the applications of a run with another scorer or scoring formula, or previews (simulates) it
+type ScoreService struct {
+	runRepository     db.RunRepository
+	scoringRepository db.ScoringRepository
+	findingRepository db.FindingRepository
+	reportService     *ReportService
+}
+
+func NewScoreService(mgr *db.Repositories) *ScoreService {
+	return &ScoreService{
+		runRepository:     mgr.Run,
+		scoringRepository: mgr.Scoring,
+		findingRepository: mgr.Findings,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (scoreService *ScoreService) Rescore(runId uint, scorerName string, formulaFile string, expression string, normalize string, simulate bool) {
+
+	if runId == 0 {
+		runId = latestRunId(scoreService.runRepository, "csa")
+	}
+
+	run, err := scoreService.runRepository.GetRun(runId)
+	exitOnError(fmt.Sprintf("Unable to retrieve run [%d]", runId), err)
+
+	formula, err := scoringFormula(formulaFile, expression)
+	exitOnError("Unable to load the scoring formula", err)
+
+	switch {
+	case formula != nil:
+		run.Scorer = model.FORMULA_SCORER
+		run.ScoringFormula = formula.Formula
+	case scorerName != "":
+		run.Scorer = scorerName
+		run.ScoringFormula = ""
+	}
+
+	if normalize != "" {
+		run.Normalize = normalize
+	}
+
+	scorer, err := model.RunScorer(&run)
+	exitOnError("Unable to rescore", err)
+	run.Scorer = scorer.Name()
+
+	apps, err := scoreService.runRepository.GetRunApps(runId)
+	exitOnError(fmt.Sprintf("Unable to retrieve the applications of run [%d]", runId), err)
+
+	tagTotals := make(map[string]model.TagTotals)
+	if run.Scorer == model.FORMULA_SCORER {
+		tagTotals, err = scoreService.findingRepository.GetAppTagTotals(runId)
+		exitOnError(fmt.Sprintf("Unable to retrieve the tag totals of run [%d]", runId), err)
+	}
+
+	//Scores modified in the UI are kept
+	models := make(map[string]*model.ScoringModel)
+	currentScores := make(map[string]float64)
+	currentRecommendations := make(map[string]string)
+	var rescored []*model.Application
+	for i := range apps {
+		app := &apps[i]
+		if app.ScoreModified {
+			continue
+		}
+
+		if _, found := models[app.ScoringModel]; !found {
+			models[app.ScoringModel], err = scoreService.scoringRepository.GetModelByName(app.ScoringModel)
+			exitOnError(fmt.Sprintf("Unable to retrieve scoring model [%s] of app [%s]", app.ScoringModel, app.Name), err)
+		}
+
+		app.Model = models[app.ScoringModel]
+		app.TagTotals = tagTotals[app.Name]
+		currentScores[app.Name] = app.Score
+		currentRecommendations[app.Name] = app.Recommendation
+		rescored = append(rescored, app)
+	}
+
+	exitOnError(fmt.Sprintf("Unable to rescore run [%d] with the [%s] scorer", runId, scorer.Name()), scorer.Score(rescored))
+
+	bins := scoreBins(scoreService.scoringRepository)
+
+	headers := []string{"application", "sloc", "raw score", "score", "new score", "change", "tier", "new tier", "recommendation", "new recommendation"}
+	var data [][]string
+	for _, app := range rescored {
+		data = append(data, []string{app.Name, fmt.Sprint(app.SlocCnt), fmt.Sprint(app.RawScore), fmt.Sprintf("%.2f", currentScores[app.Name]),
+			fmt.Sprintf("%.2f", app.Score), fmt.Sprintf("%+.2f", app.Score-currentScores[app.Name]),
+			model.ScoreBinName(bins, currentScores[app.Name]), model.ScoreBinName(bins, app.Score), currentRecommendations[app.Name], app.Recommendation})
+	}
+
+	title := fmt.Sprintf("Run [%d] Scores with the [%s] Scorer", runId, scorer.Name())
+	if run.Normalize != "" && run.Normalize != model.NORMALIZE_NONE {
+		title += fmt.Sprintf(" normalized by [%s]", run.Normalize)
+	}
+	scoreService.reportService.DisplayReport(headers, data, title, false)
+
+	if kept := len(apps) - len(rescored); kept > 0 {
+		fmt.Printf("[%d] applications whose score was modified in the UI kept their score\n", kept)
+	}
+
+	if simulate {
+		fmt.Println("Simulation only, no score was saved")
+		return
+	}
+
+	exitOnError(fmt.Sprintf("Unable to save the scores of run [%d]", runId), scoreService.runRepository.SaveScores(&run, rescored))
+	fmt.Printf("Saved the scores of [%d] applications of run [%d]\n", len(rescored), runId)
+}
+
+func scoringFormula(formulaFile string, expression string) (*model.ScoringFormula, error) {
+
+	switch {
+	case formulaFile != "" && expression != "":
+		return nil, fmt.Errorf("use either a formula file or an expression, not both")
+	case formulaFile != "":
+		return model.LoadScoringFormula(formulaFile)
+	case expression != "":
+		formula := &model.ScoringFormula{Name: "expression", Formula: expression}
+		return formula, formula.Validate()
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//SecurityReportService reports the security findings of a run grouped by the CWEs their rules map them to
+type SecurityReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewSecurityReportService(mgr *db.Repositories) *SecurityReportService {
+	return &SecurityReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (securityService *SecurityReportService) RunSecurityReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(securityService.runRepository, "csa")
+	}
+
+	findings, err := securityService.findingRepository.GetSecurityFindings(runId, app)
+	exitOnError(fmt.Sprintf("Unable to retrieve the security findings of run [%d]", runId), err)
+
+	cwes := model.GroupByCwe(findings)
+
+	name := fmt.Sprintf("%d-security", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(cwes, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Security report written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"cwe", "name", "severity", "findings", "by severity", "effort", "rules", "applications"}
+	var data [][]string
+	for _, cwe := range cwes {
+		data = append(data, []string{cwe.Cwe, cwe.Name, cwe.Severity, fmt.Sprint(cwe.Findings), severityCounts(cwe.Severities),
+			fmt.Sprint(cwe.Effort), strings.Join(cwe.Rules, ","), strings.Join(cwe.Applications, ",")})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Security report written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	title := fmt.Sprintf("Run [%d] Security Findings by CWE", runId)
+	if app != "" {
+		title = fmt.Sprintf("Run [%d] App [%s] Security Findings by CWE", runId, app)
+	}
+
+	securityService.reportService.DisplayReport(headers, data, title, false)
+}
+
+//severityCounts formats the findings per severity, the most severe first. I.E. critical:1 high:3
+func severityCounts(counts map[string]int) string {
+	var parts []string
+	for i := len(model.Severities) - 1; i >= 0; i-- {
+		if cnt := counts[model.Severities[i]]; cnt > 0 {
+			parts = append(parts, fmt.Sprintf("%s:%d", model.Severities[i], cnt))
+		}
+	}
+	return strings.Join(parts, " ")
+}
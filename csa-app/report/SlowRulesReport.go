@@ -0,0 +1,96 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Patterns longer than this are cut in the table format. The csv lists them in full.
+const maxSlowPatternLen = 60
+
+//Ranks the rules of a run by the time spent evaluating them, so the rule (and pattern) slowing a scan down can be found
+type SlowRulesReportService struct {
+	ruleRepository db.RuleRepository
+	runRepository  db.RunRepository
+	reportService  *ReportService
+}
+
+func NewSlowRulesReportService(mgr *db.Repositories) *SlowRulesReportService {
+	return &SlowRulesReportService{
+		ruleRepository: mgr.Rules,
+		runRepository:  mgr.Run,
+		reportService:  NewReportSvc(mgr),
+	}
+}
+
+func (slowRulesService *SlowRulesReportService) RunSlowRulesReport(runId uint, top int, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(slowRulesService.runRepository, "csa")
+	}
+
+	metrics, err := slowRulesService.ruleRepository.GetRuleMetrics(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve the rule metrics of run [%d]! Details: %v", runId, err)
+	}
+	if len(metrics) == 0 {
+		util.App.Fatalf("Run [%d] recorded no rule metrics to profile", runId)
+	}
+
+	var total time.Duration
+	ranked := make([]model.KV, len(metrics))
+	for i := range metrics {
+		total += metrics[i].TotalTime
+		ranked[i] = model.KV{Key: metrics[i].Rule, Value: &metrics[i]}
+	}
+	sort.Sort(model.MetricByTimeAndHits(ranked))
+
+	if top > 0 && len(ranked) > top {
+		ranked = ranked[:top]
+	}
+
+	headers := []string{"rule", "criticality", "total-time", "share", "checks", "match-attempts", "hits", "attempt-avg", "longest", "slowest pattern", "pattern-time"}
+	var data [][]string
+	var listed time.Duration
+
+	for _, kv := range ranked {
+		metric := kv.Value
+		listed += metric.TotalTime
+
+		pattern := metric.SlowestPattern
+		if format != util.CSV {
+			pattern = util.Truncate(pattern, maxSlowPatternLen)
+		}
+
+		data = append(data, []string{metric.Rule, metric.RuleCriticality, metric.TotalTime.String(), timeShare(metric.TotalTime, total),
+			fmt.Sprint(metric.Checks), fmt.Sprint(metric.PatternChecks), fmt.Sprint(metric.Hits), metric.AvgPat.String(),
+			metric.Longest.String(), pattern, metric.SlowestPatTime.String()})
+	}
+
+	switch format {
+	case util.CSV:
+		fmt.Printf("Slowest rules written to [%s]\n", writeCsvReport(fmt.Sprintf("%d-slow-rules", runId), headers, data))
+	default:
+		slowRulesService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Slowest Rules", runId), false)
+	}
+
+	fmt.Printf("The [%d] rules of run [%d] took [%v] to evaluate, the [%d] listed [%v] (%s)\n", len(metrics), runId, total,
+		len(ranked), listed, timeShare(listed, total))
+}
+
+func timeShare(part time.Duration, total time.Duration) string {
+	if total <= 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
@@ -0,0 +1,115 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+)
+
+//TagService manages the tag taxonomy. Reports grouping findings by tag (I.E. GetFindingsByTag) silently miss the
+//findings of a rule whose tag is misspelled, so tags are listed with their usage and likely typos flagged.
+type TagService struct {
+	tagRepository  db.TagRepository
+	ruleRepository db.RuleRepository
+	runRepository  db.RunRepository
+	reportService  *ReportService
+}
+
+func NewTagService(mgr *db.Repositories) *TagService {
+	return &TagService{
+		tagRepository:  mgr.Tags,
+		ruleRepository: mgr.Rules,
+		runRepository:  mgr.Run,
+		reportService:  NewReportSvc(mgr),
+	}
+}
+
+func (tagService *TagService) ListTags(problemsOnly bool) {
+	usage, err := tagService.tagRepository.GetTagUsage()
+	exitOnError("Unable to retrieve tags", err)
+
+	taxonomy, err := tagService.tagRepository.GetTaxonomy()
+	exitOnError("Unable to retrieve the tag taxonomy", err)
+
+	descriptions := make(map[string]string)
+	for _, tag := range taxonomy {
+		descriptions[tag.Name] = tag.Description
+	}
+
+	headers := []string{"tag", "rules", "findings", "registered", "similar to", "description"}
+	var data [][]string
+	problems := 0
+
+	for _, tag := range usage {
+		//Without a taxonomy every tag would be unregistered
+		unregistered := len(taxonomy) > 0 && !tag.Registered
+		if unregistered || len(tag.Similar) > 0 {
+			problems++
+		} else if problemsOnly {
+			continue
+		}
+
+		registered := "yes"
+		if !tag.Registered {
+			registered = "no"
+		}
+		data = append(data, []string{tag.Tag, fmt.Sprint(tag.Rules), fmt.Sprint(tag.Findings), registered,
+			strings.Join(tag.Similar, ","), descriptions[tag.Tag]})
+	}
+
+	tagService.reportService.DisplayReport(headers, data, "Tags", false)
+
+	fmt.Printf("[%d] tags, [%d] registered, [%d] unregistered or similar to another tag\n", len(usage), len(taxonomy), problems)
+}
+
+func (tagService *TagService) CreateTag(name string, description string) {
+	tag := &model.TaxonomyTag{Name: name, Description: description}
+	exitOnError(fmt.Sprintf("Unable to create tag [%s]", name), tagService.tagRepository.SaveTaxonomyTag(tag))
+	fmt.Printf("Registered tag [%s]\n", name)
+}
+
+func (tagService *TagService) RenameTag(from string, to string) {
+	usage, err := tagService.tagRepository.GetTagUsage()
+	exitOnError("Unable to retrieve tags", err)
+
+	if strings.ToLower(from) != to {
+		for _, tag := range usage {
+			if tag.Tag == to {
+				exitOnError(fmt.Sprintf("Unable to rename tag [%s]", from),
+					fmt.Errorf("tag [%s] is already in use. Use `rules tags merge %s %s` to merge them", to, to, from))
+			}
+		}
+	}
+
+	exitOnError(fmt.Sprintf("Unable to rename tag [%s]", from), tagService.tagRepository.RenameTag(from, to))
+	fmt.Printf("Renamed tag [%s] to [%s]\n", from, to)
+}
+
+func (tagService *TagService) MergeTags(from []string, into string) {
+	for _, tag := range from {
+		exitOnError(fmt.Sprintf("Unable to merge tag [%s] into [%s]", tag, into), tagService.tagRepository.RenameTag(tag, into))
+		fmt.Printf("Merged tag [%s] into [%s]\n", tag, into)
+	}
+}
+
+func (tagService *TagService) RetagFindings(runId uint) {
+
+	if runId == 0 {
+		runId = latestRunId(tagService.runRepository, "csa")
+	}
+
+	rules, err := tagService.ruleRepository.GetRules()
+	exitOnError("Unable to retrieve rules", err)
+
+	retagged, err := tagService.tagRepository.RetagFindings(runId, rules)
+	exitOnError(fmt.Sprintf("Unable to retag the findings of run [%d]", runId), err)
+
+	fmt.Printf("Retagged [%d] findings of run [%d]\n", retagged, runId)
+}
@@ -0,0 +1,84 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"csa-app/db"
+	"csa-app/util"
+)
+
+//TechStackReportService reports the frameworks, servers, build tools and runtimes detected in each application of a run
+type TechStackReportService struct {
+	techStackRepository db.TechStackRepository
+	runRepository       db.RunRepository
+	reportService       *ReportService
+}
+
+func NewTechStackReportService(mgr *db.Repositories) *TechStackReportService {
+	return &TechStackReportService{
+		techStackRepository: mgr.TechStack,
+		runRepository:       mgr.Run,
+		reportService:       NewReportSvc(mgr),
+	}
+}
+
+func (techStackService *TechStackReportService) RunTechStackReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(techStackService.runRepository, "csa")
+	}
+
+	attributes, err := techStackService.techStackRepository.GetTechStack(runId, app)
+	checkReportError("tech-stack", err)
+
+	name := fmt.Sprintf("%d-tech-stack", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(attributes, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Tech stack written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"application", "category", "technology", "version", "evidence"}
+	var data [][]string
+
+	//How many applications use each technology
+	usage := make(map[[2]string]int)
+
+	for _, attribute := range attributes {
+		data = append(data, []string{attribute.Application, attribute.Category, attribute.Name, attribute.Version, attribute.Evidence})
+		usage[[2]string{attribute.Category, attribute.Name}]++
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Tech stack written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	techStackService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Tech Stack", runId), false)
+
+	var technologies [][2]string
+	for technology := range usage {
+		technologies = append(technologies, technology)
+	}
+	sort.Slice(technologies, func(i, j int) bool {
+		if usage[technologies[i]] != usage[technologies[j]] {
+			return usage[technologies[i]] > usage[technologies[j]]
+		}
+		return technologies[i][0]+technologies[i][1] < technologies[j][0]+technologies[j][1]
+	})
+
+	var summary [][]string
+	for _, technology := range technologies {
+		summary = append(summary, []string{technology[0], technology[1], fmt.Sprint(usage[technology])})
+	}
+
+	techStackService.reportService.DisplayReport([]string{"category", "technology", "applications"}, summary,
+		fmt.Sprintf("Run [%d] Tech Stack Summary", runId), false)
+}
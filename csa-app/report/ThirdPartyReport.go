@@ -0,0 +1,50 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+
+	"csa-app/db"
+	"csa-app/util"
+)
+
+//Reports the findings attributed to vendored/third-party code separately from the applications own findings
+type ThirdPartyReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewThirdPartyReportService(mgr *db.Repositories) *ThirdPartyReportService {
+	return &ThirdPartyReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+func (thirdPartyService *ThirdPartyReportService) RunThirdPartyReport(runId uint) {
+
+	if runId == 0 {
+		runId = latestRunId(thirdPartyService.runRepository, "csa")
+	}
+
+	summaries, err := thirdPartyService.findingRepository.GetThirdPartySummary(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to summarize third-party code for run [%d]! Details: %v", runId, err)
+	}
+
+	headers := []string{"application", "third-party path", "files", "findings", "effort"}
+	var data [][]string
+
+	for _, summary := range summaries {
+		data = append(data, []string{summary.Application, summary.Path, fmt.Sprint(summary.Files),
+			fmt.Sprint(summary.Findings), fmt.Sprint(summary.Effort)})
+	}
+
+	thirdPartyService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Third-Party Code (excluded from scores)", runId), false)
+}
@@ -0,0 +1,82 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//Exports each application's directory hierarchy sized by sloc with finding totals, ready to be rendered as a treemap
+type TreemapReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+}
+
+func NewTreemapReportService(mgr *db.Repositories) *TreemapReportService {
+	return &TreemapReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+	}
+}
+
+func (treemapService *TreemapReportService) RunTreemapReport(runId uint, app string) {
+
+	if runId == 0 {
+		runId = latestRunId(treemapService.runRepository, "csa")
+	}
+
+	apps, err := treemapService.runRepository.GetRunApps(runId)
+	if err != nil {
+		util.App.Fatalf("Unable to retrieve applications for run [%d]! Details: %v", runId, err)
+	}
+
+	exported := 0
+	for i := range apps {
+		if app != "" && apps[i].Name != app {
+			continue
+		}
+
+		treemap, err := treemapService.buildTreemap(runId, &apps[i])
+		if err != nil {
+			util.App.Fatalf("Unable to build treemap for app [%s]! Details: %v", apps[i].Name, err)
+		}
+
+		name := fmt.Sprintf("%d-%s-treemap", runId, strings.NewReplacer(util.PathSeparator, "_", " ", "_").Replace(apps[i].Name))
+		util.WriteStructToFile(treemap, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Treemap for app [%s] written to [%s%s%s.%s]\n", apps[i].Name, *util.OutputDir, util.PathSeparator, name, util.JSON)
+		exported++
+	}
+
+	if exported == 0 {
+		util.App.Fatalf("Run [%d] has no application named [%s]", runId, app)
+	}
+}
+
+//BuildTreemap returns the treemap of one application of the run
+func (treemapService *TreemapReportService) BuildTreemap(runId uint, appName string) (*model.TreemapNode, error) {
+
+	app, err := treemapService.runRepository.GetApp(runId, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	return treemapService.buildTreemap(runId, app)
+}
+
+func (treemapService *TreemapReportService) buildTreemap(runId uint, app *model.Application) (*model.TreemapNode, error) {
+
+	files, err := treemapService.findingRepository.GetFileStats(runId, app.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.BuildTreemap(app, files), nil
+}
@@ -0,0 +1,192 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//TriageService triages the findings of a run, rescoring its applications without the suppressed/false positive ones
+type TriageService struct {
+	repositories  *db.Repositories
+	reportService *ReportService
+}
+
+func NewTriageService(mgr *db.Repositories) *TriageService {
+	return &TriageService{
+		repositories:  mgr,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//TriageFindings sets the triage of the run's findings with the ids and rescores the run's applications, returning how
+//many findings were triaged
+func TriageFindings(mgr *db.Repositories, runId uint, ids []uint, state string, reason string) (int64, error) {
+
+	if err := model.ValidateTriage(state); err != nil {
+		return 0, err
+	}
+
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("no finding to triage")
+	}
+
+	now := time.Now()
+	triaged, err := mgr.Findings.SetFindingTriage(runId, ids, model.FindingTriage{State: state, Reason: reason, TriagedAt: &now})
+	if err != nil || triaged == 0 {
+		return triaged, err
+	}
+
+	return triaged, RescoreTriagedRun(mgr, runId)
+}
+
+//RescoreTriagedRun recounts the findings of the run's applications and rescores them with the run's scorer, so
+//findings suppressed (or no longer suppressed) by triage count (or not) against them. Scores modified in the UI are kept.
+func RescoreTriagedRun(mgr *db.Repositories, runId uint) error {
+
+	run, err := mgr.Run.GetRun(runId)
+	if err != nil {
+		return err
+	}
+
+	scorer, err := model.RunScorer(&run)
+	if err != nil {
+		return err
+	}
+
+	apps, err := mgr.Run.GetRunApps(runId)
+	if err != nil {
+		return err
+	}
+
+	appDetails, err := mgr.Findings.GetApplicationDetailsForRun(runId, 10, 1, false)
+	if err != nil {
+		return err
+	}
+
+	tagTotals, err := mgr.Findings.GetAppTagTotals(runId)
+	if err != nil {
+		return err
+	}
+
+	models := make(map[string]*model.ScoringModel)
+	var recounted, rescored []*model.Application
+	for i := range apps {
+		app := &apps[i]
+
+		//Apps whose every finding was suppressed have no details left
+		details := model.ApplicationDetails{Application: app.Name, SlocCnt: app.SlocCnt, FilesCnt: app.FilesCnt}
+		for _, appDetail := range appDetails {
+			if appDetail.Application == app.Name {
+				details = appDetail
+				break
+			}
+		}
+		app.MergeDetails(details)
+		app.TagTotals = tagTotals[app.Name]
+		app.ContainerScore = model.EvaluateContainerReadiness(app.Name, app.TagTotals, model.ContainerConcerns).Score
+		recounted = append(recounted, app)
+
+		if app.ScoreModified {
+			continue
+		}
+
+		if _, found := models[app.ScoringModel]; !found {
+			if models[app.ScoringModel], err = mgr.Scoring.GetModelByName(app.ScoringModel); err != nil {
+				return fmt.Errorf("unable to retrieve scoring model [%s] of app [%s]: %v", app.ScoringModel, app.Name, err)
+			}
+		}
+		app.Model = models[app.ScoringModel]
+		rescored = append(rescored, app)
+	}
+
+	if err = scorer.Score(rescored); err != nil {
+		return err
+	}
+
+	if err = mgr.Run.SaveAppDetails(recounted); err != nil {
+		return err
+	}
+
+	return mgr.Run.SaveScores(&run, rescored)
+}
+
+func (triageService *TriageService) SetTriage(runId uint, findingIds []string, state string, reason string) {
+
+	if runId == 0 {
+		runId = latestRunId(triageService.repositories.Run, "csa")
+	}
+
+	var ids []uint
+	for _, findingId := range findingIds {
+		id, err := strconv.ParseUint(findingId, 10, 64)
+		exitOnError("Unable to triage findings", err)
+		ids = append(ids, uint(id))
+	}
+
+	triaged, err := TriageFindings(triageService.repositories, runId, ids, state, reason)
+	exitOnError(fmt.Sprintf("Unable to triage the findings of run [%d]", runId), err)
+
+	if triaged < int64(len(ids)) {
+		fmt.Printf("[%d] of the finding(s) are not part of run [%d]\n", int64(len(ids))-triaged, runId)
+	}
+
+	if state == model.TRIAGE_NONE {
+		fmt.Printf("Cleared the triage of [%d] finding(s) of run [%d]\n", triaged, runId)
+	} else {
+		fmt.Printf("Triaged [%d] finding(s) of run [%d] as [%s]\n", triaged, runId, state)
+	}
+	if triaged > 0 {
+		fmt.Printf("Rescored the applications of run [%d]\n", runId)
+	}
+}
+
+//ListTriage lists the run's triaged findings, suppressed ones included, for auditing
+func (triageService *TriageService) ListTriage(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(triageService.repositories.Run, "csa")
+	}
+
+	findings, err := triageService.repositories.Findings.GetTriagedFindings(runId, app)
+	exitOnError(fmt.Sprintf("Unable to retrieve the triaged findings of run [%d]", runId), err)
+
+	name := fmt.Sprintf("%d-triage", runId)
+
+	if format == util.JSON {
+		var dtos []*model.FindingDTO
+		for i := range findings {
+			dtos = append(dtos, findings[i].CreateDTO())
+		}
+		util.WriteStructToFile(dtos, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("[%d] triaged findings written to [%s%s%s.%s]\n", len(dtos), *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"id", "application", "rule", "file", "line", "effort", "triage", "note", "triaged", "lifecycle"}
+	var data [][]string
+	for _, finding := range findings {
+		triagedAt := ""
+		if finding.TriagedAt != nil {
+			triagedAt = finding.TriagedAt.Format("2006-01-02 15:04")
+		}
+		data = append(data, []string{fmt.Sprint(finding.ID), finding.Application, finding.Rule, finding.Fqn, fmt.Sprint(finding.Line),
+			fmt.Sprint(finding.Effort), finding.Triage, finding.TriageNote, triagedAt, finding.Lifecycle})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("[%d] triaged findings written to [%s]\n", len(data), writeCsvReport(name, headers, data))
+		return
+	}
+
+	triageService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Triaged Findings", runId), false)
+}
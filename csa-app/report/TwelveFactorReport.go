@@ -0,0 +1,133 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//TwelveFactorReportService evaluates each application of a run against the twelve factors
+type TwelveFactorReportService struct {
+	findingRepository db.FindingRepository
+	runRepository     db.RunRepository
+	reportService     *ReportService
+}
+
+func NewTwelveFactorReportService(mgr *db.Repositories) *TwelveFactorReportService {
+	return &TwelveFactorReportService{
+		findingRepository: mgr.Findings,
+		runRepository:     mgr.Run,
+		reportService:     NewReportSvc(mgr),
+	}
+}
+
+//TwelveFactorReports evaluates the applications of the run, app narrows them down to one
+func TwelveFactorReports(findingRepository db.FindingRepository, runRepository db.RunRepository, runId uint, app string) ([]model.TwelveFactorReport, error) {
+
+	apps, err := runRepository.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals, err := findingRepository.GetAppTagTotals(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []model.TwelveFactorReport{}
+	for _, application := range apps {
+		if app == "" || application.Name == app {
+			reports = append(reports, model.EvaluateTwelveFactors(application.Name, tagTotals[application.Name], model.TwelveFactors))
+		}
+	}
+
+	return reports, nil
+}
+
+func (twelveFactorService *TwelveFactorReportService) RunTwelveFactorReport(runId uint, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(twelveFactorService.runRepository, "csa")
+	}
+
+	reports, err := TwelveFactorReports(twelveFactorService.findingRepository, twelveFactorService.runRepository, runId, app)
+	exitOnError(fmt.Sprintf("Unable to evaluate the twelve factors of run [%d]", runId), err)
+
+	if app != "" && len(reports) == 0 {
+		exitOnError("Unable to evaluate the twelve factors", fmt.Errorf("application [%s] is not part of run [%d]", app, runId))
+	}
+
+	name := fmt.Sprintf("%d-twelve-factor", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(reports, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("Twelve factor checklist written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"application", "#", "factor", "status", "score", "violations", "evidence", "effort", "tags"}
+	var data [][]string
+
+	//Per factor: applications passing, failing and unknown, and the total of the known scores
+	passed := make(map[int]int)
+	failed := make(map[int]int)
+	unknown := make(map[int]int)
+	scores := make(map[int]float64)
+
+	for _, report := range reports {
+		for _, factor := range report.Factors {
+			score := ""
+			switch factor.Status {
+			case model.FACTOR_PASS:
+				passed[factor.Number]++
+			case model.FACTOR_FAIL:
+				failed[factor.Number]++
+			default:
+				unknown[factor.Number]++
+			}
+			if factor.Status != model.FACTOR_UNKNOWN {
+				score = fmt.Sprintf("%2.2f", factor.Score)
+				scores[factor.Number] += factor.Score
+			}
+			data = append(data, []string{report.Application, fmt.Sprint(factor.Number), factor.Factor, factor.Status, score,
+				fmt.Sprint(factor.Violations), fmt.Sprint(factor.Evidence), fmt.Sprint(factor.Effort), strings.Join(factor.Tags, ",")})
+		}
+	}
+
+	if format == util.CSV {
+		fmt.Printf("Twelve factor checklist written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	twelveFactorService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] Twelve Factor Checklist", runId), false)
+
+	var summary [][]string
+	for _, factor := range model.TwelveFactors {
+		score := ""
+		if known := passed[factor.Number] + failed[factor.Number]; known > 0 {
+			score = fmt.Sprintf("%2.2f", scores[factor.Number]/float64(known))
+		}
+		summary = append(summary, []string{fmt.Sprint(factor.Number), factor.Name, fmt.Sprint(passed[factor.Number]),
+			fmt.Sprint(failed[factor.Number]), fmt.Sprint(unknown[factor.Number]), score})
+	}
+
+	twelveFactorService.reportService.DisplayReport([]string{"#", "factor", "pass", "fail", "unknown", "score"}, summary,
+		fmt.Sprintf("Run [%d] Twelve Factor Summary", runId), false)
+
+	var apps [][]string
+	for _, report := range reports {
+		apps = append(apps, []string{report.Application, fmt.Sprint(report.Passed), fmt.Sprint(report.Failed),
+			fmt.Sprint(report.Unknown), fmt.Sprintf("%2.2f", report.Score)})
+	}
+
+	twelveFactorService.reportService.DisplayReport([]string{"application", "pass", "fail", "unknown", "score"}, apps,
+		fmt.Sprintf("Run [%d] Twelve Factor Readiness", runId), false)
+}
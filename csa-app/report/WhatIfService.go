@@ -0,0 +1,160 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/db"
+	"csa-app/model"
+	"csa-app/util"
+)
+
+//WhatIfService simulates the scores of a run's applications once the findings matching some queries are resolved,
+//without saving anything
+type WhatIfService struct {
+	repositories  *db.Repositories
+	reportService *ReportService
+}
+
+func NewWhatIfService(mgr *db.Repositories) *WhatIfService {
+	return &WhatIfService{
+		repositories:  mgr,
+		reportService: NewReportSvc(mgr),
+	}
+}
+
+//SimulateWhatIf rescores the applications of the run with the run's scorer as if the findings matching any of the
+//queries were resolved. Every application is rescored, scorers like the percentile one rank them against each
+//other, but only app's score is returned when one is given. Scores modified in the UI are left out.
+func SimulateWhatIf(mgr *db.Repositories, runId uint, queries []string, app string) ([]model.WhatIfScore, error) {
+
+	whatIf, err := model.ParseWhatIf(queries)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := mgr.Run.GetRun(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	scorer, err := model.RunScorer(&run)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := mgr.Run.GetRunApps(runId)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := mgr.Findings.GetWhatIfTotals(runId, whatIf)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTotals := make(map[string]model.TagTotals)
+	if run.Scorer == model.FORMULA_SCORER {
+		if tagTotals, err = mgr.Findings.GetAppTagTotals(runId); err != nil {
+			return nil, err
+		}
+	}
+
+	models := make(map[string]*model.ScoringModel)
+	var rescored []*model.Application
+	var scores []*model.WhatIfScore
+	for i := range apps {
+		application := &apps[i]
+		if application.ScoreModified {
+			continue
+		}
+
+		if _, found := models[application.ScoringModel]; !found {
+			if models[application.ScoringModel], err = mgr.Scoring.GetModelByName(application.ScoringModel); err != nil {
+				return nil, fmt.Errorf("unable to retrieve scoring model [%s] of app [%s]: %v", application.ScoringModel, application.Name, err)
+			}
+		}
+
+		score := &model.WhatIfScore{Application: application.Name, RawScore: application.RawScore, Score: application.Score,
+			Recommendation: application.Recommendation}
+		if totals := resolved[application.Name]; totals != nil {
+			score.Resolved = totals.Findings
+			score.ResolvedEffort = totals.RawScore
+		}
+
+		application.Model = models[application.ScoringModel]
+		application.TagTotals = tagTotals[application.Name]
+		application.Resolve(resolved[application.Name])
+
+		rescored = append(rescored, application)
+		scores = append(scores, score)
+	}
+
+	if err = scorer.Score(rescored); err != nil {
+		return nil, err
+	}
+
+	bins, err := mgr.Scoring.GetScoreBins()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []model.WhatIfScore{}
+	for i, application := range rescored {
+		if app != "" && application.Name != app {
+			continue
+		}
+		score := scores[i]
+		score.NewRawScore = application.RawScore
+		score.NewScore = application.Score
+		score.NewRecommendation = application.Recommendation
+		score.Tier = model.ScoreBinName(bins, score.Score)
+		score.NewTier = model.ScoreBinName(bins, score.NewScore)
+		result = append(result, *score)
+	}
+
+	return result, nil
+}
+
+func (whatIfService *WhatIfService) RunWhatIf(runId uint, queries []string, app string, format string) {
+
+	if runId == 0 {
+		runId = latestRunId(whatIfService.repositories.Run, "csa")
+	}
+
+	scores, err := SimulateWhatIf(whatIfService.repositories, runId, queries, app)
+	exitOnError(fmt.Sprintf("Unable to simulate the what-if on run [%d]", runId), err)
+
+	if app != "" && len(scores) == 0 {
+		exitOnError("Unable to simulate the what-if", fmt.Errorf("application [%s] is not part of run [%d] or its score was modified in the UI", app, runId))
+	}
+
+	name := fmt.Sprintf("%d-what-if", runId)
+
+	if format == util.JSON {
+		util.WriteStructToFile(scores, name, *util.OutputDir, util.JSON, true)
+		fmt.Printf("What-if written to [%s%s%s.%s]\n", *util.OutputDir, util.PathSeparator, name, util.JSON)
+		return
+	}
+
+	headers := []string{"application", "resolved findings", "resolved effort", "raw score", "new raw score", "score", "new score", "change", "tier", "new tier", "recommendation", "new recommendation"}
+	var data [][]string
+	for _, score := range scores {
+		data = append(data, []string{score.Application, fmt.Sprint(score.Resolved), fmt.Sprint(score.ResolvedEffort),
+			fmt.Sprint(score.RawScore), fmt.Sprint(score.NewRawScore), fmt.Sprintf("%.2f", score.Score), fmt.Sprintf("%.2f", score.NewScore),
+			fmt.Sprintf("%+.2f", score.NewScore-score.Score), score.Tier, score.NewTier, score.Recommendation, score.NewRecommendation})
+	}
+
+	if format == util.CSV {
+		fmt.Printf("What-if written to [%s]\n", writeCsvReport(name, headers, data))
+		return
+	}
+
+	whatIfService.reportService.DisplayReport(headers, data, fmt.Sprintf("Run [%d] What If [%s] Were Resolved", runId, strings.Join(queries, "] or [")), false)
+	fmt.Println("Simulation only, nothing was saved")
+}
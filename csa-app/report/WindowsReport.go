@@ -0,0 +1,41 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"csa-app/model"
+)
+
+//WindowsReport reports what ties the run's Java and .NET applications to Windows, which they have to shed to move to
+//Linux containers. The blocked applications come first, then the most effort.
+var WindowsReport = AppReport{
+	name:  "windows",
+	title: "Windows Coupling",
+	//A row per dependency of each application
+	headers: []string{"application", "blocked", "dependency", "blocker", "findings", "effort", "tags", "replacement"},
+	evaluator: tagTotalsEvaluator(func(app string, tagTotals model.TagTotals) (interface{}, int) {
+		coupling := model.EvaluateWindowsCoupling(app, tagTotals, model.WindowsDependencies)
+		return coupling, coupling.Findings
+	}),
+	less: func(a interface{}, b interface{}) bool {
+		i, j := a.(model.WindowsCoupling), b.(model.WindowsCoupling)
+		if i.Blocked != j.Blocked {
+			return i.Blocked
+		}
+		return i.Effort > j.Effort
+	},
+	rows: func(report interface{}) (rows [][]string) {
+		coupling := report.(model.WindowsCoupling)
+		for _, dependency := range coupling.Dependencies {
+			rows = append(rows, []string{coupling.Application, fmt.Sprint(coupling.Blocked), dependency.Dependency, fmt.Sprint(dependency.Blocker),
+				fmt.Sprint(dependency.Findings), fmt.Sprint(dependency.Effort), strings.Join(dependency.Tags, ","), dependency.Replacement})
+		}
+		return
+	},
+}
@@ -0,0 +1,51 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"csa-app/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var displayHeaders = []string{"rule", "severity", "effort"}
+var displayData = [][]string{{"java-file-io", "critical", "7"}, {"java-logging", "low", "1"}}
+
+func TestRenderReportPlain(t *testing.T) {
+	report := renderReport(displayHeaders, displayData, "Findings", false, false)
+
+	assert.NotContains(t, report, "\033[")
+	assert.NotContains(t, report, "✖")
+	assert.Contains(t, report, "| critical|")
+}
+
+func TestRenderReportDecorated(t *testing.T) {
+	report := renderReport(displayHeaders, displayData, "Findings", true, false)
+
+	assert.NotContains(t, report, "\033[")
+	assert.Contains(t, report, "| ✖ critical|")
+	assert.Contains(t, report, "|      ▼ low|")
+
+	//Glyphs are accounted for so every line of the table is the same width
+	lines := strings.Split(strings.TrimSpace(report), "\n")[1:]
+	for _, line := range lines {
+		assert.Equal(t, displayLen(lines[0]), displayLen(line))
+	}
+}
+
+func TestRenderReportColored(t *testing.T) {
+	report := renderReport(displayHeaders, displayData, "Findings", true, true)
+
+	//Critical rows are highlighted throughout, other rows only color the severity
+	assert.Contains(t, report, util.Colorize("java-file-io", util.ANSI_BOLD_RED))
+	assert.Contains(t, report, util.Colorize("✖ critical", util.ANSI_BOLD_RED))
+	assert.Contains(t, report, "| java-logging|")
+	assert.Contains(t, report, util.Colorize("▼ low", util.ANSI_CYAN))
+	assert.Contains(t, report, util.Colorize("rule", util.ANSI_BOLD))
+}
@@ -0,0 +1,176 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+const docxDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:pPr><w:spacing w:after="80"/></w:pPr><w:rPr><w:sz w:val="22"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="240"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="36"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="200"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="30"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="160"/><w:outlineLvl w:val="2"/></w:pPr><w:rPr><w:b/><w:sz w:val="26"/></w:rPr></w:style>
+</w:styles>`
+
+var (
+	docxHeading   = regexp.MustCompile(`^(#{1,3})\s+(.*)$`)
+	docxBullet    = regexp.MustCompile(`^(\s*)[-*]\s+(.*)$`)
+	docxSeparator = regexp.MustCompile(`^\|?[\s:|-]+\|?$`)
+)
+
+//writeDocx converts the markdown a plan template produced into a minimal WordprocessingML document. Only the
+//markdown plan templates need is supported: headings (#, ##, ###), bullets (nested by indentation), tables and paragraphs.
+func writeDocx(out io.Writer, markdown string) error {
+
+	var body bytes.Buffer
+	var table [][]string
+
+	flushTable := func() {
+		if len(table) > 0 {
+			writeDocxTable(&body, table)
+			table = nil
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "|") {
+			if !docxSeparator.MatchString(trimmed) {
+				table = append(table, splitTableRow(trimmed))
+			}
+			continue
+		}
+		flushTable()
+
+		if trimmed == "" {
+			continue
+		}
+
+		if match := docxHeading.FindStringSubmatch(trimmed); match != nil {
+			writeDocxParagraph(&body, match[2], fmt.Sprintf("Heading%d", len(match[1])), 0)
+		} else if match := docxBullet.FindStringSubmatch(line); match != nil {
+			level := len(match[1])/2 + 1
+			writeDocxParagraph(&body, "• "+match[2], "", level*360)
+		} else {
+			writeDocxParagraph(&body, trimmed, "", 0)
+		}
+	}
+	flushTable()
+
+	zipWriter := zip.NewWriter(out)
+	parts := []struct{ name, contents string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRels},
+		{"word/_rels/document.xml.rels", docxDocumentRels},
+		{"word/styles.xml", docxStyles},
+		{"word/document.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+			`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			body.String() + `</w:body></w:document>`},
+	}
+
+	for _, part := range parts {
+		writer, err := zipWriter.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(writer, part.contents); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func writeDocxParagraph(body *bytes.Buffer, text string, style string, indent int) {
+
+	body.WriteString("<w:p>")
+	if style != "" || indent > 0 {
+		body.WriteString("<w:pPr>")
+		if style != "" {
+			body.WriteString(`<w:pStyle w:val="` + style + `"/>`)
+		}
+		if indent > 0 {
+			body.WriteString(`<w:ind w:left="` + strconv.Itoa(indent) + `"/>`)
+		}
+		body.WriteString("</w:pPr>")
+	}
+	writeDocxRun(body, text)
+	body.WriteString("</w:p>")
+}
+
+func writeDocxRun(body *bytes.Buffer, text string) {
+	body.WriteString(`<w:r><w:t xml:space="preserve">`)
+	_ = xml.EscapeText(body, []byte(text))
+	body.WriteString("</w:t></w:r>")
+}
+
+func writeDocxTable(body *bytes.Buffer, rows [][]string) {
+
+	body.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="0" w:type="auto"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		body.WriteString(`<w:` + side + ` w:val="single" w:sz="4" w:space="0" w:color="auto"/>`)
+	}
+	body.WriteString(`</w:tblBorders></w:tblPr>`)
+
+	for r, row := range rows {
+		body.WriteString("<w:tr>")
+		for _, cell := range row {
+			body.WriteString("<w:tc><w:p>")
+			if r == 0 {
+				//the first row is the markdown header row
+				body.WriteString(`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">`)
+				_ = xml.EscapeText(body, []byte(cell))
+				body.WriteString("</w:t></w:r>")
+			} else {
+				writeDocxRun(body, cell)
+			}
+			body.WriteString("</w:p></w:tc>")
+		}
+		body.WriteString("</w:tr>")
+	}
+	body.WriteString("</w:tbl>")
+	//word requires a paragraph between adjacent tables and after a table ending the body
+	body.WriteString("<w:p/>")
+}
+
+func splitTableRow(row string) []string {
+
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+
+	return cells
+}
@@ -0,0 +1,29 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"sync"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+var (
+	scaleOnce    sync.Once
+	scale        *model.EffortScale
+	scaleLoadErr error
+)
+
+//effortScale loads the scale requested with --effort-scale once. A nil scale means effort is reported as is.
+func effortScale() (*model.EffortScale, error) {
+	scaleOnce.Do(func() {
+		if *util.EffortScale != "" {
+			scale, scaleLoadErr = model.LoadEffortScale(*util.EffortScale)
+		}
+	})
+	return scale, scaleLoadErr
+}
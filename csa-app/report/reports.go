@@ -7,7 +7,10 @@ package report
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -15,6 +18,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"csa-app/db"
 	"csa-app/model"
@@ -70,6 +74,13 @@ func (reportService *ReportService) listReportsForType(reportType string, report
 	}
 }
 
+//saveReportData saves the rows of a report in batches
+func (reportService *ReportService) saveReportData(rows []*model.ReportData) {
+	if err := reportService.reportDataRepository.SaveReportDataBatch(rows); err != nil {
+		util.TrackError("Reports", fmt.Errorf("error saving report data: %v", err))
+	}
+}
+
 func (reportService *ReportService) ExportReport(runId uint, reportId int, title string, displayOnStdOut bool, writeFile bool) {
 
 	//Get Report Metadata
@@ -135,7 +146,7 @@ func (reportService *ReportService) GenerateReports(run *model.Run) {
 		case 1:
 			run.StartActivity("3rd")
 			util.WriteLog("3rd Party Import Report...", "3rd Party Import Report...\n")
-			reportService.generateThirdPartyImportReport(run.ID)
+			reportService.generateThirdPartyImportReport(run)
 			run.StopActivity("3rd", "3rd Party Import Report...done!", true)
 		case 2:
 			run.StartActivity("api-sum")
@@ -158,33 +169,97 @@ func (reportService *ReportService) GenerateReports(run *model.Run) {
 	}
 }
 
-func (reportService *ReportService) generateThirdPartyImportReport(runId uint) {
+func (reportService *ReportService) generateThirdPartyImportReport(run *model.Run) {
 
+	runId := run.ID
 	findings := db.GetFindingsByRunAndTag(runId, model.THIRD_PARTY_TAG)
 
 	thirdPartyUniq := db.UniqueFinding(findings)
 	sort.Strings(thirdPartyUniq)
 
+	licenses := newLicenseResolver(run)
+	risks := 0
+	var rows []*model.ReportData
+
+	save := func(value string, resolve func() (model.ResolvedLicense, bool)) {
+		data := &model.ReportData{RunID: runId, ReportID: model.THIRD_PARTY_REPORT_ID, Data1: value, Data3: model.LICENSE_UNKNOWN}
+		if licenses != nil {
+			if license, found := resolve(); found {
+				data.Data2, data.Data3, data.Data4 = license.License, license.Type, license.Source
+				if model.IsLicenseRisk(license.Type) {
+					risks++
+				}
+			}
+		}
+		rows = append(rows, data)
+	}
+
 	//Store Report Data
 	for _, res2 := range thirdPartyUniq {
 		util.WriteLog("3rd Party Import Report...", "3rd Party Import Report...Found Import: %s\n", res2)
-		reportService.reportDataRepository.SaveReportData(&model.ReportData{RunID: runId, ReportID: model.THIRD_PARTY_REPORT_ID, Data1: res2})
+		save(res2, func() (model.ResolvedLicense, bool) { return licenses.Resolve(res2) })
 	}
 
+	//Libraries declared by package manager files (I.E. requirements.txt) are listed by package url
+	libraries := make(map[string]*model.AppLibrary)
+	for _, app := range run.Applications {
+		for _, library := range app.Libraries {
+			libraries[library.PackageURL()] = library
+		}
+	}
+	purls := make([]string, 0, len(libraries))
+	for purl := range libraries {
+		purls = append(purls, purl)
+	}
+	sort.Strings(purls)
+
+	for _, purl := range purls {
+		util.WriteLog("3rd Party Import Report...", "3rd Party Import Report...Found Library: %s\n", purl)
+		library := libraries[purl]
+		save(purl, func() (model.ResolvedLicense, bool) { return licenses.ResolveLibrary(library) })
+	}
+
+	reportService.saveReportData(rows)
 	reportService.ExportReport(runId, model.THIRD_PARTY_REPORT_ID, "Third-Party", false, true)
+
+	if risks > 0 {
+		fmt.Printf("[%d] third-party import(s) are under copyleft or proprietary licenses, a migration/compliance risk. See the LicenseType column of the Third-Party report\n", risks)
+	}
+}
+
+//newLicenseResolver resolves licenses from the poms of the dependencies the run's applications declare, then the
+//license db. Without a usable license db, licenses aren't resolved.
+func newLicenseResolver(run *model.Run) *model.LicenseResolver {
+
+	licenseDB, err := model.LoadLicenseDB(*util.LicenseDB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Licenses of third-party imports will not be resolved! Details: %v\n", err)
+		return nil
+	}
+
+	mavenRepo, gradleHome := model.LocalRepositories(*util.MavenRepo)
+
+	resolver := model.NewLicenseResolver(licenseDB)
+	for _, app := range run.Applications {
+		resolver.AddDependencies(app, mavenRepo, gradleHome)
+	}
+
+	return resolver
 }
 
 func (reportService *ReportService) generateJavaApiSummaryReport(runId uint, findings []model.Finding) {
 
 	apiCalls := make(map[string]int)
+	var rows []*model.ReportData
 
 	for _, entry := range findings {
 		apiCalls[entry.Category] += 1
 	}
 	for _, res1 := range util.SortedKeys(apiCalls) {
 		util.WriteLog("Jave API Usage Report (Summary)...", "Jave API Usage Report (Summary)...API: %s Count: %d\n", res1, apiCalls[res1])
-		reportService.reportDataRepository.SaveReportData(&model.ReportData{RunID: runId, ReportID: model.API_SUMMARY_REPORT_ID, Data1: res1, Data2: strconv.Itoa(apiCalls[res1])})
+		rows = append(rows, &model.ReportData{RunID: runId, ReportID: model.API_SUMMARY_REPORT_ID, Data1: res1, Data2: strconv.Itoa(apiCalls[res1])})
 	}
+	reportService.saveReportData(rows)
 
 	reportService.ExportReport(runId, model.API_SUMMARY_REPORT_ID, "API-SUMMARY", false, true)
 
@@ -192,18 +267,20 @@ func (reportService *ReportService) generateJavaApiSummaryReport(runId uint, fin
 
 func (reportService *ReportService) generateJavaApiDetailReport(runId uint, findings []model.Finding, includeDomainDir *bool) {
 
+	var rows []*model.ReportData
 	for _, entry := range findings {
 		util.WriteLog("Java API Usage Report (Detailed)...", "Java API Usage Report (Detailed)...API: %s\n", entry.Category)
 		if *includeDomainDir {
-			reportService.reportDataRepository.SaveReportData(&model.ReportData{RunID: runId, ReportID: model.API_DETAILED_REPORT_ID, Data1: entry.Application,
+			rows = append(rows, &model.ReportData{RunID: runId, ReportID: model.API_DETAILED_REPORT_ID, Data1: entry.Application,
 				Data2: entry.Category, Data3: entry.Pattern, Data4: entry.Filename, Data5: fmt.Sprint(entry.Line), Data6: entry.Value, Data7: strconv.Itoa(entry.Effort),
 				Data8: entry.Advice})
 		} else {
-			reportService.reportDataRepository.SaveReportData(&model.ReportData{RunID: runId, ReportID: model.API_DETAILED_REPORT_ID, Data1: "",
+			rows = append(rows, &model.ReportData{RunID: runId, ReportID: model.API_DETAILED_REPORT_ID, Data1: "",
 				Data2: entry.Category, Data3: entry.Pattern, Data4: entry.Filename, Data5: fmt.Sprint(entry.Line), Data6: entry.Value, Data7: strconv.Itoa(entry.Effort),
 				Data8: entry.Advice})
 		}
 	}
+	reportService.saveReportData(rows)
 	reportService.ExportReport(runId, model.API_DETAILED_REPORT_ID, "API-DETAIL", false, true)
 
 }
@@ -214,16 +291,21 @@ func (reportService *ReportService) generateAnnotationReport(runId uint) {
 
 	annotationsUniq := db.UniqueFinding(findings)
 	sort.Strings(annotationsUniq)
+	var rows []*model.ReportData
 	for _, res3 := range annotationsUniq {
 		util.WriteLog("Annotations Report...", "Annotations Report...%s\n", res3)
-		reportService.reportDataRepository.SaveReportData(&model.ReportData{RunID: runId, ReportID: model.ANNOTATIONS_REPORT_ID, Data1: res3})
+		rows = append(rows, &model.ReportData{RunID: runId, ReportID: model.ANNOTATIONS_REPORT_ID, Data1: res3})
 	}
+	reportService.saveReportData(rows)
 
 	reportService.ExportReport(runId, model.ANNOTATIONS_REPORT_ID, "ANNOTATIONS", false, true)
 }
 
 func (reportService *ReportService) GenerateClocReport(run *model.Run, displayOnly bool) {
 
+	_, span := util.StartSpan(run.Ctx, "cloc-report")
+	defer span.End()
+
 	slocData, _ := reportService.slocRepository.GetSlocForRun(run.ID)
 
 	totalFiles := 0
@@ -251,13 +333,15 @@ func (reportService *ReportService) GenerateClocReport(run *model.Run, displayOn
 	}
 
 	//Write Results to DB!
+	var rows []*model.ReportData
 	for _, item := range langTotals {
-		reportService.reportDataRepository.SaveReportData(item)
+		rows = append(rows, item)
 	}
 
-	reportService.reportDataRepository.SaveReportData(&model.ReportData{RunID: run.ID, ReportID: model.CLOC_REPORT_ID, Data1: model.TOTAL_FIELD,
+	rows = append(rows, &model.ReportData{RunID: run.ID, ReportID: model.CLOC_REPORT_ID, Data1: model.TOTAL_FIELD,
 		Data2: fmt.Sprint(totalFiles), Data3: fmt.Sprint(totalBlank),
 		Data4: fmt.Sprint(totalComment), Data5: fmt.Sprint(totalCode)})
+	reportService.saveReportData(rows)
 
 	reportService.ExportReport(run.ID, model.CLOC_REPORT_ID, "SLOC SUMMARY", true, !displayOnly)
 
@@ -301,6 +385,67 @@ func createReportFile(runId uint, reportName string, extension string, path stri
 	return file
 }
 
+//writeCsvReport writes headers and data as a properly quoted csv file in the output dir and returns the file name
+func writeCsvReport(name string, headers []string, data [][]string) string {
+	fileName, err := exportCsvReport(name, headers, data)
+	checkReportError(name, err)
+	return fileName
+}
+
+//exportCsvReport writes headers and data as a properly quoted csv file in the output dir and returns the file name, or
+//the error the file could not be written with
+func exportCsvReport(name string, headers []string, data [][]string) (string, error) {
+
+	return stageReport(fmt.Sprintf("%s/%s.%s", *util.OutputDir, name, util.CSV), func(out io.Writer) error {
+		writer := csv.NewWriter(out)
+		_ = writer.Write(headers)
+		_ = writer.WriteAll(data)
+		return writer.Error()
+	})
+}
+
+//exportJsonReport writes target as indented json in the output dir and returns the file name, or the error the file
+//could not be written with
+func exportJsonReport(name string, target interface{}) (string, error) {
+
+	out, err := json.MarshalIndent(target, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return stageReport(fmt.Sprintf("%s/%s.%s", *util.OutputDir, name, util.JSON), func(file io.Writer) error {
+		_, err := file.Write(out)
+		return err
+	})
+}
+
+func stageReport(fileName string, write func(out io.Writer) error) (string, error) {
+
+	util.CheckAndCreateDir(*util.OutputDir)
+
+	//Stage in the workspace so concurrent readers (i.e. report job downloads) never see a partial file
+	if util.RunWorkspace != nil {
+		if err := util.RunWorkspace.Stage(fileName, write); err != nil {
+			return "", err
+		}
+		return fileName, nil
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return "", err
+	}
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
 func checkReportError(reportName string, err error) {
 	if err != nil {
 		log.Printf("Failed generating report [%s]! Details: %v\n", reportName, err)
@@ -309,20 +454,43 @@ func checkReportError(reportName string, err error) {
 
 func (reportService *ReportService) DisplayReport(headers []string, data [][]string, title string, sortByColumn bool) {
 
+	if sortByColumn {
+		sort.Sort(ByColumn(data))
+	}
+
+	//Glyphs only make sense on a terminal. Color can additionally be turned off there.
+	report := renderReport(headers, data, title, util.StdoutIsTerminal(), util.ColorEnabled())
+
+	if *util.Pager {
+		util.Page(report)
+	} else {
+		fmt.Print(report)
+	}
+}
+
+//renderReport lays the report out as a table. Decorated reports mark severities with glyphs, colored ones
+//additionally color severities and highlight rows with critical findings.
+func renderReport(headers []string, data [][]string, title string, decorate bool, color bool) string {
+
+	var buffer bytes.Buffer
+
+	cells := make([][]string, len(data))
 	fieldLens := make(map[string]int)
 
 	//get longest header
 	for _, hdr := range headers {
-		fieldLens[hdr] = len(hdr) + 1
+		fieldLens[hdr] = displayLen(util.Truncate(hdr, *util.MaxColumnWidth)) + 1
 	}
 
-	if sortByColumn {
-		sort.Sort(ByColumn(data))
-	}
-
-	for _, line := range data {
+	for l, line := range data {
+		cells[l] = make([]string, len(line))
 		for i := 0; i < len(line); i++ {
-			fieldLen := len(line[i]) + 1
+			cells[l][i] = util.Truncate(line[i], *util.MaxColumnWidth)
+			if decorate {
+				cells[l][i] = severityGlyph(headers[i], line[i]) + cells[l][i]
+			}
+
+			fieldLen := displayLen(cells[l][i]) + 1
 			if fieldLen > fieldLens[headers[i]] {
 				fieldLens[headers[i]] = fieldLen
 			}
@@ -341,46 +509,73 @@ func (reportService *ReportService) DisplayReport(headers []string, data [][]str
 	leftPad := fmt.Sprint(" " + util.Padd(" ", divLength))
 	rightPad := fmt.Sprint(util.Padd(" ", divLength) + "")
 
-	fmt.Printf("\n%s%s%s\n", leftPad, title, rightPad)
-	fmt.Print(util.Padd("-", paddlen+2) + "\n")
+	buffer.WriteString(fmt.Sprintf("\n%s%s%s\n", leftPad, title, rightPad))
+	buffer.WriteString(util.Padd("-", paddlen+2) + "\n")
+
+	headerColor := ""
+	if color {
+		headerColor = util.ANSI_BOLD
+	}
 
 	//Write the headers
 	cnt := 0
 	for _, hdr := range headers {
 		if cnt == 0 {
-			fmt.Print("|")
+			buffer.WriteString("|")
 		}
-		fmt.Printf("%"+strconv.Itoa(fieldLens[hdr])+"v|", hdr)
+		buffer.WriteString(alignCell(util.Truncate(hdr, *util.MaxColumnWidth), fieldLens[hdr], headerColor) + "|")
 		cnt++
 	}
 
-	fmt.Print("\n")
+	buffer.WriteString("\n")
 
 	cnt = 0
 	for _, hdr := range headers {
 		if cnt == 0 {
-			fmt.Print("|")
+			buffer.WriteString("|")
 		}
-		fmt.Printf("%s%s", util.Padd("-", fieldLens[hdr]), "|")
+		buffer.WriteString(fmt.Sprintf("%s%s", util.Padd("-", fieldLens[hdr]), "|"))
 		cnt++
 	}
-	fmt.Print("\n")
+	buffer.WriteString("\n")
 
 	//Write the body
-	for _, line := range data {
+	for l, line := range data {
+		rowColor := ""
+		if color {
+			rowColor = severityRowColor(headers, line)
+		}
+
 		for i := 0; i < len(line); i++ {
 			if i == 0 {
-				fmt.Print("|")
+				buffer.WriteString("|")
+			}
+
+			cellColor := rowColor
+			if color {
+				if severityColor := severityCellColor(headers[i], line[i]); severityColor != "" {
+					cellColor = severityColor
+				}
 			}
-			fmt.Printf("%"+strconv.Itoa(fieldLens[headers[i]])+"v|", line[i])
+			buffer.WriteString(alignCell(cells[l][i], fieldLens[headers[i]], cellColor) + "|")
 
 		}
-		fmt.Print("\n")
+		buffer.WriteString("\n")
 	}
 
 	//Write Footer
-	fmt.Print(util.Padd("-", paddlen+2) + "\n")
+	buffer.WriteString(util.Padd("-", paddlen+2) + "\n")
+
+	return buffer.String()
+}
+
+//alignCell right aligns the value within width. Only the value is colored so escape codes never affect the layout.
+func alignCell(value string, width int, color string) string {
+	return util.Padd(" ", width-displayLen(value)) + util.Colorize(value, color)
+}
 
+func displayLen(value string) int {
+	return utf8.RuneCountInString(value)
 }
 
 func getReportHeaders(reportId int) (headers []string, longestHeader int) {
@@ -444,3 +639,11 @@ func (line ByColumn) Less(i, j int) bool {
 	}
 	return false
 }
+
+//exitOnError reports the error on std err and exits. For the CLI report and service commands only.
+func exitOnError(msg string, err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s! Details: %v\n", msg, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,62 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package report
+
+import (
+	"strings"
+
+	"csa-app/model"
+	"csa-app/util"
+)
+
+type severityStyle struct {
+	glyph string
+	color string
+}
+
+var severityStyles = map[string]severityStyle{
+	model.SEVERITY_CRITICAL: {glyph: "✖", color: util.ANSI_BOLD_RED},
+	model.SEVERITY_HIGH:     {glyph: "▲", color: util.ANSI_RED},
+	model.SEVERITY_MEDIUM:   {glyph: "◆", color: util.ANSI_YELLOW},
+	model.SEVERITY_LOW:      {glyph: "▼", color: util.ANSI_CYAN},
+	model.SEVERITY_INFO:     {glyph: "•", color: util.ANSI_DIM},
+}
+
+//Only cells of these columns are treated as severities. Criticality shares the low/medium/high vocabulary.
+func isSeverityColumn(header string) bool {
+	header = strings.ToLower(header)
+	return header == model.CRITERION_SEVERITY || header == model.CRITERION_CRITICALITY
+}
+
+func lookupSeverityStyle(header string, value string) (severityStyle, bool) {
+	if !isSeverityColumn(header) {
+		return severityStyle{}, false
+	}
+	style, found := severityStyles[strings.ToLower(strings.TrimSpace(value))]
+	return style, found
+}
+
+func severityGlyph(header string, value string) string {
+	if style, found := lookupSeverityStyle(header, value); found {
+		return style.glyph + " "
+	}
+	return ""
+}
+
+func severityCellColor(header string, value string) string {
+	style, _ := lookupSeverityStyle(header, value)
+	return style.color
+}
+
+//severityRowColor highlights the entire row when it holds a critical severity
+func severityRowColor(headers []string, line []string) string {
+	for i := range line {
+		if i < len(headers) && isSeverityColumn(headers[i]) && strings.EqualFold(strings.TrimSpace(line[i]), model.SEVERITY_CRITICAL) {
+			return util.ANSI_BOLD_RED
+		}
+	}
+	return ""
+}
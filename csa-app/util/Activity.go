@@ -6,22 +6,28 @@
 package util
 
 import (
+	"context"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Activity struct {
 	name  string
 	start time.Time
 	stop  time.Time
+	span  trace.Span
 	sync.Mutex
 }
 
-func NewActivity(name string) *Activity {
+//NewActivity starts timing the activity and opens a trace span for it beneath ctx
+func NewActivity(ctx context.Context, name string) *Activity {
 
 	activity := &Activity{}
 	activity.name = name
 	activity.start = time.Now()
+	_, activity.span = StartSpan(ctx, name)
 
 	return activity
 }
@@ -34,6 +40,9 @@ func (activity *Activity) Start() {
 
 func (activity *Activity) Stop() {
 	activity.Lock()
+	if activity.stop.IsZero() {
+		activity.span.End()
+	}
 	activity.stop = time.Now()
 	activity.Unlock()
 }
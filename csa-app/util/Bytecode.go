@@ -0,0 +1,632 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Archives nested deeper than this (a jar in a war in an ear is 2) aren't unpacked
+const BYTECODE_MAX_DEPTH = 5
+
+const CLASS_FILE_MAGIC = 0xCAFEBABE
+
+//Access flags of a class file
+const (
+	ACC_PUBLIC     = 0x0001
+	ACC_FINAL      = 0x0010
+	ACC_INTERFACE  = 0x0200
+	ACC_ABSTRACT   = 0x0400
+	ACC_ANNOTATION = 0x2000
+	ACC_ENUM       = 0x4000
+	ACC_MODULE     = 0x8000
+)
+
+//Tags of the constant pool entries
+const (
+	cpUtf8               = 1
+	cpInteger            = 3
+	cpFloat              = 4
+	cpLong               = 5
+	cpDouble             = 6
+	cpClass              = 7
+	cpString             = 8
+	cpFieldref           = 9
+	cpMethodref          = 10
+	cpInterfaceMethodref = 11
+	cpNameAndType        = 12
+	cpMethodHandle       = 15
+	cpMethodType         = 16
+	cpDynamic            = 17
+	cpInvokeDynamic      = 18
+	cpModule             = 19
+	cpPackage            = 20
+)
+
+var archiveEntryRegex = regexp.MustCompile(`(?i)[.](ear|war|jar|rar|sar)$`)
+var descriptorClassRegex = regexp.MustCompile(`L([^;<>]+);`)
+
+//ClassFile is what csa analyzes of a compiled class when its source isn't available: its (internal) name, super class
+//and interfaces, its annotations and those of its fields and methods (rendered as in source), and every class it
+//references through its constant pool, descriptors and annotations.
+type ClassFile struct {
+	Name              string
+	Super             string
+	Interfaces        []string
+	Access            uint16
+	Annotations       []string
+	MemberAnnotations []string
+	References        []string
+}
+
+type cpEntry struct {
+	tag   byte
+	str   string
+	value string
+	index uint16
+	other uint16
+}
+
+type classReader struct {
+	data []byte
+	pos  int
+	err  error
+	pool []cpEntry
+	refs map[string]bool
+}
+
+//ParseClassFile parses the class file (I.E. an entry of a jar) without loading or decompiling it
+func ParseClassFile(data []byte) (class *ClassFile, err error) {
+
+	r := &classReader{data: data, refs: make(map[string]bool)}
+
+	if r.u4() != CLASS_FILE_MAGIC {
+		return nil, fmt.Errorf("not a class file")
+	}
+	r.skip(4)
+
+	r.readConstantPool()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	class = &ClassFile{Access: r.u2()}
+	class.Name = r.className(r.u2())
+	class.Super = r.className(r.u2())
+	for i, count := 0, int(r.u2()); i < count && r.err == nil; i++ {
+		class.Interfaces = append(class.Interfaces, r.className(r.u2()))
+	}
+
+	//Fields then methods
+	for members := 0; members < 2; members++ {
+		for i, count := 0, int(r.u2()); i < count && r.err == nil; i++ {
+			r.skip(4)
+			r.addDescriptor(r.utf8(r.u2()))
+			class.MemberAnnotations = append(class.MemberAnnotations, r.readAttributes()...)
+		}
+	}
+	class.Annotations = r.readAttributes()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	delete(r.refs, class.Name)
+	delete(r.refs, "")
+	for ref := range r.refs {
+		class.References = append(class.References, ref)
+	}
+	sort.Strings(class.References)
+
+	return class, nil
+}
+
+//IsPackageInfo is whether the class holds the annotations of its package
+func (c *ClassFile) IsPackageInfo() bool {
+	return path.Base(c.Name) == "package-info"
+}
+
+//BytecodeSource is the java source csa analyzes in place of the classes (a top level class and its inner classes): its
+//package, an import of every class it references and its annotations. The rules of java sources find the APIs,
+//frameworks and annotations the classes use in it, like they would in the missing source.
+func BytecodeSource(classes []*ClassFile) string {
+
+	if len(classes) == 0 {
+		return ""
+	}
+
+	top := classes[0]
+	for _, class := range classes {
+		if !strings.Contains(path.Base(class.Name), "$") {
+			top = class
+			break
+		}
+	}
+
+	pkg := path.Dir(top.Name)
+	if pkg == "." {
+		pkg = ""
+	}
+	own := strings.SplitN(top.Name, "$", 2)[0]
+
+	imports := make(map[string]bool)
+	for _, class := range classes {
+		for _, ref := range class.References {
+			ref = strings.SplitN(ref, "$", 2)[0]
+			if ref == own || path.Dir(ref) == pkg || path.Dir(ref) == "java/lang" || !strings.Contains(ref, "/") {
+				continue
+			}
+			imports[strings.Replace(ref, "/", ".", -1)] = true
+		}
+	}
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+
+	var source strings.Builder
+	fmt.Fprintf(&source, "//Generated by csa from the bytecode of %s.class, its source isn't available\n", top.Name)
+
+	if top.IsPackageInfo() {
+		for _, annotation := range top.Annotations {
+			source.WriteString(annotation + "\n")
+		}
+	}
+	if pkg != "" {
+		fmt.Fprintf(&source, "package %s;\n", strings.Replace(pkg, "/", ".", -1))
+	}
+	if len(sorted) > 0 {
+		source.WriteString("\n")
+	}
+	for _, imp := range sorted {
+		fmt.Fprintf(&source, "import %s;\n", imp)
+	}
+	if top.IsPackageInfo() {
+		return source.String()
+	}
+
+	source.WriteString("\n")
+	for _, annotation := range top.Annotations {
+		source.WriteString(annotation + "\n")
+	}
+	source.WriteString(classDeclaration(top) + " {\n")
+	for _, annotation := range top.MemberAnnotations {
+		source.WriteString("    " + annotation + "\n")
+	}
+	for _, class := range classes {
+		if class == top {
+			continue
+		}
+		for _, annotation := range append(class.Annotations, class.MemberAnnotations...) {
+			source.WriteString("    " + annotation + "\n")
+		}
+	}
+	source.WriteString("}\n")
+
+	return source.String()
+}
+
+//UnpackArchive unpacks the archive (jar|war|ear) into basePath, and the archives nested in it (I.E. the jars of a
+//war's WEB-INF/lib) into a directory named after them. In place of its classes, it writes the java source of their
+//bytecode (see BytecodeSource), so that archives are analyzed without their source and without a decompiler.
+func (fu *FileUtil) UnpackArchive(target FileInfo, basePath string) {
+
+	filePath := strings.Replace(filepath.Base(target.Name), filepath.Ext(target.Name), "", -1)
+
+	Lock.Lock()
+	defer Lock.Unlock()
+
+	unpackDir := basePath + "/" + filePath
+	exists, err := CreateDirIfNotExist(unpackDir)
+	if exists {
+		//Already or in process of unpacking this archive...
+		return
+	}
+
+	fmt.Printf("Unpacking [%s]...\n", target.Name)
+
+	archive, err := zip.OpenReader(target.FQN)
+	if err == nil {
+		defer archive.Close()
+		err = unpackArchive(&archive.Reader, unpackDir, 0)
+	}
+
+	if err != nil {
+		App.Fatalf("Unpacking of %s failed!\nError-Details: %s\n", target.Name, err)
+	}
+
+	fu.useDecompileRegex = true
+}
+
+/***********************************************************************************************************************
+														PRIVATE API
+***********************************************************************************************************************/
+
+func unpackArchive(archive *zip.Reader, dir string, depth int) error {
+
+	classes := make(map[string][]*ClassFile)
+	sources := make(map[string]bool)
+
+	for _, entry := range archive.File {
+
+		name := path.Clean(strings.Replace(entry.Name, "\\", "/", -1))
+		if strings.HasSuffix(entry.Name, "/") || name == "." {
+			continue
+		}
+		if name == ".." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+			WriteLog("Unpacking", "Skipping entry [%s] outside of the archive\n", entry.Name)
+			continue
+		}
+
+		data, err := readArchiveEntry(entry)
+		if err != nil {
+			return fmt.Errorf("reading [%s]: %v", entry.Name, err)
+		}
+
+		switch {
+		case strings.HasSuffix(name, ".class"):
+			class, err := ParseClassFile(data)
+			if err != nil {
+				WriteLog("Unpacking", "Skipping class [%s]: %v\n", entry.Name, err)
+				continue
+			}
+			if class.Access&ACC_MODULE != 0 {
+				continue
+			}
+			//Inner classes are analyzed with their top level class
+			source := strings.SplitN(strings.TrimSuffix(name, ".class"), "$", 2)[0]
+			classes[source] = append(classes[source], class)
+
+		case archiveEntryRegex.MatchString(name) && depth < BYTECODE_MAX_DEPTH:
+			nested, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			if err == nil {
+				if err = unpackArchive(nested, filepath.Join(dir, strings.TrimSuffix(name, path.Ext(name))), depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			WriteLog("Unpacking", "Archive [%s] can't be unpacked: %v\n", entry.Name, err)
+			fallthrough
+
+		default:
+			if strings.HasSuffix(name, ".java") {
+				sources[strings.TrimSuffix(name, ".java")] = true
+			}
+			if err = writeUnpackedFile(filepath.Join(dir, name), data); err != nil {
+				return err
+			}
+		}
+	}
+
+	for source, group := range classes {
+		//Archives shipping their source are analyzed with it
+		if sources[source] {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
+		if err := writeUnpackedFile(filepath.Join(dir, source+".java"), []byte(BytecodeSource(group))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readArchiveEntry(entry *zip.File) ([]byte, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+func writeUnpackedFile(target string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(target, data, 0644)
+}
+
+func classDeclaration(class *ClassFile) string {
+
+	var declaration strings.Builder
+	if class.Access&ACC_PUBLIC != 0 {
+		declaration.WriteString("public ")
+	}
+
+	name := simpleClassName(class.Name)
+	interfaces := class.Interfaces
+
+	switch {
+	case class.Access&ACC_ANNOTATION != 0:
+		declaration.WriteString("@interface " + name)
+		return declaration.String()
+	case class.Access&ACC_INTERFACE != 0:
+		declaration.WriteString("interface " + name)
+		if len(interfaces) > 0 {
+			declaration.WriteString(" extends " + simpleClassNames(interfaces))
+		}
+		return declaration.String()
+	case class.Access&ACC_ENUM != 0:
+		declaration.WriteString("enum " + name)
+	default:
+		if class.Access&ACC_ABSTRACT != 0 {
+			declaration.WriteString("abstract ")
+		} else if class.Access&ACC_FINAL != 0 {
+			declaration.WriteString("final ")
+		}
+		declaration.WriteString("class " + name)
+		if class.Super != "" && class.Super != "java/lang/Object" {
+			declaration.WriteString(" extends " + simpleClassName(class.Super))
+		}
+	}
+	if len(interfaces) > 0 {
+		declaration.WriteString(" implements " + simpleClassNames(interfaces))
+	}
+
+	return declaration.String()
+}
+
+//simpleClassName is the name of the (internal) class in source, I.E. Map.Entry for java/util/Map$Entry
+func simpleClassName(name string) string {
+	return strings.Replace(path.Base(name), "$", ".", -1)
+}
+
+func simpleClassNames(names []string) string {
+	simple := make([]string, len(names))
+	for i, name := range names {
+		simple[i] = simpleClassName(name)
+	}
+	return strings.Join(simple, ", ")
+}
+
+func (r *classReader) fail(format string, v ...interface{}) {
+	if r.err == nil {
+		r.err = fmt.Errorf(format, v...)
+	}
+}
+
+func (r *classReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos+n > len(r.data) {
+		r.fail("truncated class file")
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *classReader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *classReader) u1() byte {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *classReader) u2() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *classReader) u4() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *classReader) readConstantPool() {
+
+	count := int(r.u2())
+	r.pool = make([]cpEntry, count)
+
+	for i := 1; i < count && r.err == nil; i++ {
+		entry := cpEntry{tag: r.u1()}
+		switch entry.tag {
+		case cpUtf8:
+			entry.str = string(r.bytes(int(r.u2())))
+		case cpInteger:
+			entry.value = strconv.Itoa(int(int32(r.u4())))
+		case cpFloat:
+			entry.value = strconv.FormatFloat(float64(math.Float32frombits(r.u4())), 'g', -1, 32)
+		case cpLong, cpDouble:
+			bits := uint64(r.u4())<<32 | uint64(r.u4())
+			if entry.tag == cpLong {
+				entry.value = strconv.FormatInt(int64(bits), 10)
+			} else {
+				entry.value = strconv.FormatFloat(math.Float64frombits(bits), 'g', -1, 64)
+			}
+			//Longs and doubles take two entries
+			r.pool[i] = entry
+			i++
+			continue
+		case cpClass, cpString, cpMethodType, cpModule, cpPackage:
+			entry.index = r.u2()
+		case cpFieldref, cpMethodref, cpInterfaceMethodref, cpNameAndType, cpDynamic, cpInvokeDynamic:
+			entry.index, entry.other = r.u2(), r.u2()
+		case cpMethodHandle:
+			r.skip(1)
+			entry.index = r.u2()
+		default:
+			r.fail("unknown constant pool tag %d", entry.tag)
+		}
+		r.pool[i] = entry
+	}
+
+	//Every class and every type of the descriptors of the fields and methods used is a reference
+	for _, entry := range r.pool {
+		switch entry.tag {
+		case cpClass:
+			r.addClass(r.utf8(entry.index))
+		case cpNameAndType:
+			r.addDescriptor(r.utf8(entry.other))
+		case cpMethodType:
+			r.addDescriptor(r.utf8(entry.index))
+		}
+	}
+}
+
+func (r *classReader) entry(index uint16) *cpEntry {
+	if int(index) >= len(r.pool) || index == 0 {
+		return nil
+	}
+	return &r.pool[index]
+}
+
+func (r *classReader) utf8(index uint16) string {
+	if entry := r.entry(index); entry != nil && entry.tag == cpUtf8 {
+		return entry.str
+	}
+	return ""
+}
+
+func (r *classReader) className(index uint16) string {
+	if entry := r.entry(index); entry != nil && entry.tag == cpClass {
+		return r.utf8(entry.index)
+	}
+	return ""
+}
+
+//addClass adds the class of the constant pool, which is the descriptor of arrays
+func (r *classReader) addClass(name string) {
+	if strings.HasPrefix(name, "[") {
+		r.addDescriptor(name)
+	} else {
+		r.refs[name] = true
+	}
+}
+
+func (r *classReader) addDescriptor(descriptor string) {
+	for _, match := range descriptorClassRegex.FindAllStringSubmatch(descriptor, -1) {
+		r.refs[match[1]] = true
+	}
+}
+
+//readAttributes reads the attributes of a class, field or method and returns its annotations (and those of a
+//method's parameters)
+func (r *classReader) readAttributes() (annotations []string) {
+
+	for i, count := 0, int(r.u2()); i < count && r.err == nil; i++ {
+		name := r.utf8(r.u2())
+		length := int(r.u4())
+		end := r.pos + length
+
+		switch name {
+		case "RuntimeVisibleAnnotations", "RuntimeInvisibleAnnotations":
+			annotations = append(annotations, r.readAnnotations()...)
+		case "RuntimeVisibleParameterAnnotations", "RuntimeInvisibleParameterAnnotations":
+			for p, parameters := 0, int(r.u1()); p < parameters && r.err == nil; p++ {
+				annotations = append(annotations, r.readAnnotations()...)
+			}
+		}
+
+		if r.err == nil && r.pos > end {
+			r.fail("malformed attribute %s", name)
+		}
+		r.skip(end - r.pos)
+	}
+
+	return annotations
+}
+
+func (r *classReader) readAnnotations() (annotations []string) {
+	for i, count := 0, int(r.u2()); i < count && r.err == nil; i++ {
+		annotations = append(annotations, r.readAnnotation())
+	}
+	return annotations
+}
+
+//readAnnotation renders the annotation as in source, I.E. @Table(name = "ORDERS")
+func (r *classReader) readAnnotation() string {
+
+	annotation := "@" + r.typeName(r.utf8(r.u2()))
+
+	var elements []string
+	for i, count := 0, int(r.u2()); i < count && r.err == nil; i++ {
+		name := r.utf8(r.u2())
+		value := r.readElementValue()
+		if count == 1 && name == "value" {
+			elements = append(elements, value)
+		} else {
+			elements = append(elements, name+" = "+value)
+		}
+	}
+
+	if len(elements) > 0 {
+		annotation += "(" + strings.Join(elements, ", ") + ")"
+	}
+	return annotation
+}
+
+func (r *classReader) readElementValue() string {
+
+	switch tag := r.u1(); tag {
+	case 's':
+		return strconv.Quote(r.utf8(r.u2()))
+	case 'B', 'C', 'D', 'F', 'I', 'J', 'S', 'Z':
+		value := ""
+		if entry := r.entry(r.u2()); entry != nil {
+			value = entry.value
+		}
+		switch tag {
+		case 'Z':
+			return strconv.FormatBool(value != "0")
+		case 'C':
+			if code, err := strconv.Atoi(value); err == nil {
+				return strconv.QuoteRune(rune(code))
+			}
+		}
+		return value
+	case 'e':
+		enum := r.typeName(r.utf8(r.u2()))
+		return enum + "." + r.utf8(r.u2())
+	case 'c':
+		return r.typeName(r.utf8(r.u2())) + ".class"
+	case '@':
+		return r.readAnnotation()
+	case '[':
+		var values []string
+		for i, count := 0, int(r.u2()); i < count && r.err == nil; i++ {
+			values = append(values, r.readElementValue())
+		}
+		return "{" + strings.Join(values, ", ") + "}"
+	default:
+		r.fail("unknown annotation element tag %q", tag)
+		return ""
+	}
+}
+
+//typeName is the name in source of the type of the descriptor (I.E. Ljavax/ejb/Stateless;), which is a reference
+func (r *classReader) typeName(descriptor string) string {
+	r.addDescriptor(descriptor)
+	if match := descriptorClassRegex.FindStringSubmatch(descriptor); match != nil {
+		return simpleClassName(match[1])
+	}
+	return descriptor
+}
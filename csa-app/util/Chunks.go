@@ -0,0 +1,141 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+//LineChunk is a run of whole lines of a file. Chunks overlap: the lines before Owned[0] were owned by the previous
+//chunk and the lines from Owned[1] on are owned by the next one, so that a match (or finding context) near the edge of
+//a chunk sees the lines around it. Each line is owned by exactly one chunk.
+type LineChunk struct {
+	Lines     []string
+	FirstLine int    //Line number (1 based) of Lines[0]
+	Owned     [2]int //Indexes of Lines owned by this chunk, [start, end)
+	Final     bool
+	text      string
+}
+
+//Whole is true when the chunk holds the whole file
+func (c *LineChunk) Whole() bool {
+	return c.FirstLine == 1 && c.Final
+}
+
+//Text is the lines of the chunk, each ended by a new line
+func (c *LineChunk) Text() string {
+	if c.text == "" && len(c.Lines) > 0 {
+		c.text = strings.Join(c.Lines, "\n") + "\n"
+	}
+	return c.text
+}
+
+//Owns is true when the line (1 based) is owned by the chunk
+func (c *LineChunk) Owns(line int) bool {
+	return line >= c.FirstLine+c.Owned[0] && line < c.FirstLine+c.Owned[1]
+}
+
+//ChunkReader reads a file in chunks of lines of about size bytes, overlapping by about overlap bytes either side.
+//A file smaller than size is read as a single (whole) chunk.
+type ChunkReader struct {
+	scanner   *bufio.Scanner
+	size      int
+	overlap   int
+	lines     []string
+	firstLine int
+	owned     int //Lines already owned by the previous chunk
+	bytes     int
+	eof       bool
+	done      bool
+}
+
+func NewChunkReader(reader io.Reader, lineBuffer int, size int, overlap int) *ChunkReader {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, lineBuffer), MAX_LINE_BUFFER_SIZE)
+	return &ChunkReader{scanner: scanner, size: size, overlap: overlap, firstLine: 1}
+}
+
+//Next returns the next chunk or nil once the file has been read
+func (r *ChunkReader) Next() *LineChunk {
+	if r.done {
+		return nil
+	}
+
+	for r.bytes < r.size+r.overlap {
+		if !r.scanner.Scan() {
+			r.eof = true
+			break
+		}
+		line := r.scanner.Text()
+		r.lines = append(r.lines, line)
+		r.bytes += len(line) + 1
+	}
+
+	chunk := &LineChunk{Lines: r.lines, FirstLine: r.firstLine, Owned: [2]int{r.owned, len(r.lines)}}
+
+	if r.eof {
+		chunk.Final = true
+		r.done = true
+		return chunk
+	}
+
+	//The lines of the last overlap bytes are left to the next chunk, which keeps as many lines before them
+	ahead := r.tail(len(r.lines), r.owned+1)
+	behind := r.tail(ahead, 0)
+	chunk.Owned[1] = ahead
+
+	//Lines longer than the chunks would have the next chunk read nothing new: it does without the lines before
+	if r.bytesFrom(behind) >= r.size+r.overlap {
+		behind = ahead
+	}
+
+	r.lines = append([]string(nil), r.lines[behind:]...)
+	r.firstLine += behind
+	r.owned = ahead - behind
+	r.bytes = r.bytesFrom(0)
+
+	return chunk
+}
+
+//bytesFrom returns the size of the lines from start on
+func (r *ChunkReader) bytesFrom(start int) (bytes int) {
+	for _, line := range r.lines[start:] {
+		bytes += len(line) + 1
+	}
+	return
+}
+
+//Err is the error that stopped reading the file, if any (I.E. a line longer than MAX_LINE_BUFFER_SIZE)
+func (r *ChunkReader) Err() error {
+	return r.scanner.Err()
+}
+
+//tail returns the index of the first of the lines before end that add up to the overlap, no lower than min
+func (r *ChunkReader) tail(end int, min int) int {
+	start := end
+	for bytes := 0; start > min && bytes < r.overlap; {
+		start--
+		bytes += len(r.lines[start]) + 1
+	}
+	return start
+}
+
+//ChunkSizes returns the size of the chunks large files are read in and their overlap, in bytes
+func ChunkSizes() (size int, overlap int) {
+	size = 32 << 20
+	if LargeFileSize != nil && *LargeFileSize > 0 {
+		size = *LargeFileSize << 20
+	}
+	if ChunkOverlap != nil && *ChunkOverlap > 0 {
+		overlap = *ChunkOverlap << 10
+	}
+	if overlap > size/4 {
+		overlap = size / 4
+	}
+	return
+}
@@ -0,0 +1,522 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+//CliApp is the csa command line: a tree of cobra commands declared flag by flag (see Shared.go). Flags of the app are
+//global, accepted by every command. Besides cobra's syntax, the command line keeps that of the csa releases before it:
+//a default command run when none is given (csa <path> analyzes the path), --no-<flag> to turn off a boolean flag and
+//flags defaulted from environment variables.
+type CliApp struct {
+	*CliCommand
+	defaultCmd *CliCommand
+	selected   *CliCommand
+	version    string
+}
+
+//CliCommand is a command of the command line, with its flags and positional args
+type CliCommand struct {
+	cmd  *cobra.Command
+	app  *CliApp
+	args []*ArgClause
+}
+
+//FlagClause declares a flag, its type being given last. I.E. Flag("port", "port").Default("3001").Int()
+type FlagClause struct {
+	flags    *pflag.FlagSet
+	command  *CliCommand
+	name     string
+	help     string
+	short    string
+	defaults []string
+	envar    string
+	hidden   bool
+	required bool
+}
+
+//ArgClause declares a positional arg of a command, its type being given last
+type ArgClause struct {
+	name     string
+	help     string
+	defaults []string
+	required bool
+	multiple bool
+	set      func(value string) error
+}
+
+func NewCliApp(name string, help string) *CliApp {
+
+	root := &cobra.Command{Use: name, Short: help, SilenceErrors: true, SilenceUsage: true}
+	//csa has its own completion command
+	root.CompletionOptions.DisableDefaultCmd = true
+
+	app := &CliApp{}
+	app.CliCommand = &CliCommand{cmd: root, app: app}
+	return app
+}
+
+func (app *CliApp) SetVersion(version string) {
+	app.version = version
+	app.cmd.Version = version
+}
+
+func (app *CliApp) Version() string {
+	return app.version
+}
+
+//Flag declares a global flag
+func (app *CliApp) Flag(name string, help string) *FlagClause {
+	return &FlagClause{flags: app.cmd.PersistentFlags(), command: app.CliCommand, name: name, help: help}
+}
+
+//Parse parses the command line and returns the full name of the command selected (I.E. "report windows"). Help,
+//version and completion requests are answered and exit.
+func (app *CliApp) Parse(args []string) (string, error) {
+
+	app.cmd.InitDefaultHelpCmd()
+
+	if app.defaultCmd != nil && (len(args) == 0 || !strings.HasPrefix(args[0], "__complete")) {
+		if found, _, _ := app.cmd.Find(args); found == app.cmd && !helpRequested(args) {
+			args = append(strings.Fields(app.defaultCmd.FullCommand()), args...)
+		}
+	}
+
+	app.selected = nil
+	app.cmd.SetArgs(args)
+	if _, err := app.cmd.ExecuteC(); err != nil {
+		return "", err
+	}
+
+	if app.selected == nil {
+		//Help, version or completions were printed
+		os.Exit(0)
+	}
+
+	return app.selected.FullCommand(), nil
+}
+
+func helpRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" || arg == "--version" {
+			return true
+		}
+	}
+	return len(args) > 0 && args[0] == "help"
+}
+
+//CompletionScript writes the (bash|zsh) completion script of the command line. The script completes by calling back
+//into cobra's hidden __complete command.
+func (app *CliApp) CompletionScript(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return app.cmd.GenBashCompletionV2(w, true)
+	case "zsh":
+		return app.cmd.GenZshCompletion(w)
+	}
+	return fmt.Errorf("no completion script for shell [%s]", shell)
+}
+
+//Usage prints the help of the command args select
+func (app *CliApp) Usage(args []string) {
+	command, _, err := app.cmd.Find(args)
+	if err != nil {
+		command = app.cmd
+	}
+	_ = command.Help()
+}
+
+//Errorf prints an error to std err
+func (app *CliApp) Errorf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, app.cmd.Name()+": error: "+format+"\n", args...)
+}
+
+//Fatalf prints an error to std err and exits
+func (app *CliApp) Fatalf(format string, args ...interface{}) {
+	app.Errorf(format, args...)
+	os.Exit(1)
+}
+
+//FatalUsage prints an error and the help of the app, then exits
+func (app *CliApp) FatalUsage(format string, args ...interface{}) {
+	app.FatalCommandUsage(nil, format, args...)
+}
+
+//FatalCommandUsage prints an error and the help of the command args select, then exits
+func (app *CliApp) FatalCommandUsage(args []string, format string, a ...interface{}) {
+	app.Errorf(format, a...)
+	app.Usage(args)
+	os.Exit(1)
+}
+
+//FatalIfError prints the error, prefixed by the message, and exits when err isn't nil
+func (app *CliApp) FatalIfError(err error, format string, args ...interface{}) {
+	if err != nil {
+		app.Fatalf(format+": %v", append(args, err)...)
+	}
+}
+
+//FlagDefaulted tells whether the flag of the command (the app's when empty) is left to its default
+func (app *CliApp) FlagDefaulted(command string, name string) bool {
+
+	cmd := app.cmd
+	if command != "" {
+		cmd, _, _ = app.cmd.Find(strings.Fields(command))
+	}
+
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		flag = cmd.InheritedFlags().Lookup(name)
+	}
+
+	return flag == nil || flag.Value.String() == flag.DefValue
+}
+
+//Command declares a sub-command
+func (c *CliCommand) Command(name string, help string) *CliCommand {
+
+	command := &CliCommand{cmd: &cobra.Command{Use: name, Short: help, Args: cobra.ArbitraryArgs}, app: c.app}
+	command.cmd.RunE = command.run
+	c.cmd.AddCommand(command.cmd)
+
+	return command
+}
+
+//Default makes the command the one run when none is given
+func (c *CliCommand) Default() *CliCommand {
+	c.app.defaultCmd = c
+	return c
+}
+
+func (c *CliCommand) Hidden() *CliCommand {
+	c.cmd.Hidden = true
+	return c
+}
+
+//Alias adds another name of the command, I.E. the name of a command it replaced
+func (c *CliCommand) Alias(name string) *CliCommand {
+	c.cmd.Aliases = append(c.cmd.Aliases, name)
+	return c
+}
+
+//Deprecated has the command print the message whenever it is used
+func (c *CliCommand) Deprecated(message string) *CliCommand {
+	c.cmd.Deprecated = message
+	return c
+}
+
+//FullCommand is the name of the command preceded by those of its parents, I.E. "report windows"
+func (c *CliCommand) FullCommand() string {
+	return strings.TrimPrefix(strings.TrimPrefix(c.cmd.CommandPath(), c.app.cmd.Name()), " ")
+}
+
+func (c *CliCommand) Flag(name string, help string) *FlagClause {
+	return &FlagClause{flags: c.cmd.Flags(), command: c, name: name, help: help}
+}
+
+func (c *CliCommand) Arg(name string, help string) *ArgClause {
+
+	arg := &ArgClause{name: name, help: help}
+	c.args = append(c.args, arg)
+
+	var usage []string
+	for _, arg := range c.args {
+		if arg.required {
+			usage = append(usage, "<"+arg.name+">")
+		} else {
+			usage = append(usage, "[<"+arg.name+">]")
+		}
+	}
+	c.cmd.Use = c.cmd.Name() + " " + strings.Join(usage, " ")
+
+	return arg
+}
+
+//run assigns the positional args of the command selected
+func (c *CliCommand) run(_ *cobra.Command, args []string) error {
+
+	if c.cmd.HasAvailableSubCommands() {
+		if len(args) > 0 {
+			return fmt.Errorf("unknown command [%s] of [%s], see `%s %s --help`", args[0], c.FullCommand(), c.app.cmd.Name(), c.FullCommand())
+		}
+		return fmt.Errorf("[%s] needs a command, see `%s %s --help`", c.FullCommand(), c.app.cmd.Name(), c.FullCommand())
+	}
+
+	for i, arg := range c.args {
+		values := args
+		if i < len(args) && !arg.multiple {
+			values = args[i : i+1]
+		} else if i >= len(args) {
+			values = nil
+		} else {
+			values = args[i:]
+		}
+
+		if len(values) == 0 && arg.required {
+			return fmt.Errorf("required argument '%s' not provided", arg.name)
+		}
+		for _, value := range values {
+			if err := arg.set(value); err != nil {
+				return fmt.Errorf("invalid argument '%s': %v", arg.name, err)
+			}
+		}
+	}
+
+	if len(args) > len(c.args) && (len(c.args) == 0 || !c.args[len(c.args)-1].multiple) {
+		return fmt.Errorf("unexpected %s", args[len(c.args)])
+	}
+
+	c.app.selected = c
+	return nil
+}
+
+func (f *FlagClause) Default(values ...string) *FlagClause {
+	f.defaults = values
+	return f
+}
+
+func (f *FlagClause) Short(short rune) *FlagClause {
+	f.short = string(short)
+	return f
+}
+
+func (f *FlagClause) Hidden() *FlagClause {
+	f.hidden = true
+	return f
+}
+
+func (f *FlagClause) Required() *FlagClause {
+	f.required = true
+	return f
+}
+
+//Envar defaults the flag to the environment variable, when set
+func (f *FlagClause) Envar(name string) *FlagClause {
+	f.envar = name
+	return f
+}
+
+func (f *FlagClause) String() *string {
+	defer f.declared()
+	return f.flags.StringP(f.name, f.short, "", f.help)
+}
+
+//Strings is a flag given any number of times
+func (f *FlagClause) Strings() *[]string {
+	values := f.flags.StringArrayP(f.name, f.short, f.defaults, f.help)
+	//The values given replace the defaults rather than adding to them
+	f.defaults = nil
+	f.declared()
+	return values
+}
+
+func (f *FlagClause) Bool() *bool {
+	value := f.flags.BoolP(f.name, f.short, false, f.help)
+	f.declared()
+
+	//--no-<flag> turns it off
+	f.flags.VarPF(negatedBool{value}, "no-"+f.name, "", "").NoOptDefVal = "true"
+	_ = f.flags.MarkHidden("no-" + f.name)
+
+	return value
+}
+
+func (f *FlagClause) Int() *int {
+	defer f.declared()
+	return f.flags.IntP(f.name, f.short, 0, f.help)
+}
+
+func (f *FlagClause) Int64() *int64 {
+	defer f.declared()
+	return f.flags.Int64P(f.name, f.short, 0, f.help)
+}
+
+func (f *FlagClause) Uint() *uint {
+	defer f.declared()
+	return f.flags.UintP(f.name, f.short, 0, f.help)
+}
+
+func (f *FlagClause) Float64() *float64 {
+	defer f.declared()
+	return f.flags.Float64P(f.name, f.short, 0, f.help)
+}
+
+func (f *FlagClause) Duration() *time.Duration {
+	defer f.declared()
+	return f.flags.DurationP(f.name, f.short, 0, f.help)
+}
+
+//Enum is a string flag given one of the options
+func (f *FlagClause) Enum(options ...string) *string {
+	value := &enumValue{options: options, value: new(string)}
+	f.flags.VarP(value, f.name, f.short, f.help)
+	f.declared()
+
+	_ = f.command.cmd.RegisterFlagCompletionFunc(f.name, func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return options, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return value.value
+}
+
+//declared sets the defaults and the attributes of the flag declared
+func (f *FlagClause) declared() {
+
+	flag := f.flags.Lookup(f.name)
+
+	defaults := f.defaults
+	if value, found := os.LookupEnv(f.envar); found && f.envar != "" {
+		defaults = []string{value}
+	}
+	for _, value := range defaults {
+		if err := flag.Value.Set(value); err != nil {
+			panic(fmt.Sprintf("invalid default [%s] of flag [%s]: %v", value, f.name, err))
+		}
+	}
+	flag.DefValue = flag.Value.String()
+
+	//pflag would take a `quoted` word of the help for the name of the flag's value
+	flag.Usage = strings.ReplaceAll(flag.Usage, "`", "'")
+	if f.envar != "" {
+		flag.Usage += fmt.Sprintf(" ($%s)", f.envar)
+	}
+
+	flag.Hidden = f.hidden
+	if f.required {
+		_ = cobra.MarkFlagRequired(f.flags, f.name)
+	}
+}
+
+func (a *ArgClause) Default(values ...string) *ArgClause {
+	a.defaults = values
+	return a
+}
+
+func (a *ArgClause) Required() *ArgClause {
+	a.required = true
+	return a
+}
+
+func (a *ArgClause) String() *string {
+	value := new(string)
+	a.declare(func(arg string) error {
+		*value = arg
+		return nil
+	})
+	return value
+}
+
+func (a *ArgClause) Strings() *[]string {
+	values := new([]string)
+	*values = append(*values, a.defaults...)
+	a.multiple = true
+
+	//The values given replace the defaults rather than adding to them
+	given := false
+	a.set = func(arg string) error {
+		if !given {
+			*values, given = nil, true
+		}
+		*values = append(*values, arg)
+		return nil
+	}
+	return values
+}
+
+func (a *ArgClause) Float64() *float64 {
+	value := new(float64)
+	a.declare(func(arg string) (err error) {
+		*value, err = strconv.ParseFloat(arg, 64)
+		return
+	})
+	return value
+}
+
+func (a *ArgClause) Enum(options ...string) *string {
+	value := &enumValue{options: options, value: new(string)}
+	a.declare(value.Set)
+	return value.value
+}
+
+//ExistingFile is the path of a file that has to exist
+func (a *ArgClause) ExistingFile() *string {
+	value := new(string)
+	a.declare(func(arg string) error {
+		if info, err := os.Stat(arg); err != nil {
+			return err
+		} else if info.IsDir() {
+			return fmt.Errorf("'%s' is a directory", arg)
+		}
+		*value = arg
+		return nil
+	})
+	return value
+}
+
+//declare sets the default of the arg, which the value given replaces
+func (a *ArgClause) declare(set func(value string) error) {
+	for _, value := range a.defaults {
+		if err := set(value); err != nil {
+			panic(fmt.Sprintf("invalid default [%s] of argument [%s]: %v", value, a.name, err))
+		}
+	}
+	a.set = set
+}
+
+//enumValue is a flag or arg given one of the options
+type enumValue struct {
+	options []string
+	value   *string
+}
+
+func (e *enumValue) Set(value string) error {
+	for _, option := range e.options {
+		if value == option {
+			*e.value = value
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s, got '%s'", strings.Join(e.options, "|"), value)
+}
+
+func (e *enumValue) String() string {
+	return *e.value
+}
+
+func (e *enumValue) Type() string {
+	return "string"
+}
+
+//negatedBool is the --no-<flag> of a boolean flag
+type negatedBool struct {
+	value *bool
+}
+
+func (n negatedBool) Set(value string) error {
+	negated, err := strconv.ParseBool(value)
+	if err == nil && negated {
+		*n.value = false
+	}
+	return err
+}
+
+func (n negatedBool) String() string {
+	return "false"
+}
+
+func (n negatedBool) Type() string {
+	return "bool"
+}
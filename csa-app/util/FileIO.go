@@ -19,6 +19,8 @@ import (
 const JSON string = "json"
 const YAML string = "yaml"
 const YML string = "yml"
+const CSV string = "csv"
+const DOT string = "dot"
 
 type FileEncoder interface {
 	Encode(v interface{}) (err error)
@@ -28,9 +28,17 @@ type FileInfo struct {
 	Comment    string
 	Exists     bool
 	MatchedRules   map[string]int
+	ThirdParty     string
+	context        *contextLines
 	sync.Mutex
 }
 
+//contextLines are the lines of a file Context reads from, starting at line firstLine
+type contextLines struct {
+	firstLine int
+	lines     []string
+}
+
 type ApiInfo struct {
 	Dir     string
 	API     string
@@ -56,6 +64,7 @@ type FileUtil struct {
 	ExcludedDirsRegExp            *regexp.Regexp
 	ExcludedFilesRegEx            *regexp.Regexp
 	DecompilingExcludedDirsRegExp *regexp.Regexp
+	ThirdPartyDirsRegExp          *regexp.Regexp
 	Langs                         *DefinedLanguages
 	archiveRegex                  *regexp.Regexp
 	useDecompileRegex             bool
@@ -105,6 +114,11 @@ func NewFileUtil() *FileUtil {
 	}
 
 	util.DecompilingExcludedDirsRegExp, _ = regexp.Compile(strings.Replace(*ExcludedDirsRegEx, "classes|", "", -1))
+
+	util.ThirdPartyDirsRegExp, err = regexp.Compile(*ThirdPartyDirsRegEx)
+	if err != nil {
+		App.Fatalf("Invalid 'third-party-dirs' regex! Details: %v", err)
+	}
 	util.archiveRegex, _ = regexp.Compile(".*([.]ear|[.]war|[.]jar)$")
 
 	return util
@@ -119,6 +133,7 @@ func (fu *FileUtil) CloneFileUtil() *FileUtil {
 	util.ExcludedFilesRegEx = fu.ExcludedFilesRegEx
 	util.ExcludedDirsRegExp = fu.ExcludedDirsRegExp
 	util.DecompilingExcludedDirsRegExp = fu.DecompilingExcludedDirsRegExp
+	util.ThirdPartyDirsRegExp = fu.ThirdPartyDirsRegExp
 	util.archiveRegex = fu.archiveRegex
 
 	return util
@@ -168,6 +183,9 @@ func (fu *FileUtil) CheckForArchive(path string) (finalTargetPath string, alias
 
 		//Get DecompilePath
 		decompilePath := *TmpDirPath + "/decompile"
+		if RunWorkspace != nil {
+			decompilePath, _ = RunWorkspace.Dir("decompile")
+		}
 		if *DecompileDir != "" {
 			if !Exists(*DecompileDir) {
 				CreateDirIfNotExist(*DecompileDir)
@@ -182,6 +200,12 @@ func (fu *FileUtil) CheckForArchive(path string) (finalTargetPath string, alias
 			var alias string
 
 			for _, file := range files {
+				if reason := InaccessibleReason(file.FQN); reason != "" {
+					fmt.Printf("Skipping [%s]: %s\n", file.Name, reason)
+					TrackInaccessible(file.FQN, reason)
+					continue
+				}
+
 				if len(alias) > 0 {
 					alias += "|" + file.Name
 				} else {
@@ -189,13 +213,24 @@ func (fu *FileUtil) CheckForArchive(path string) (finalTargetPath string, alias
 				}
 
 				WriteLog("Decompiling", "...   Filename: %s\n", file.Name)
-				fu.Decompile(file, decompilePath)
+				fu.decompileOrUnpack(file, decompilePath)
 			}
 
 		} else {
 			file := GetFile(path)
+			if reason := InaccessibleReason(file.FQN); reason != "" {
+				fmt.Printf("Skipping [%s]: %s\n", file.Name, reason)
+				TrackInaccessible(file.FQN, reason)
+				return path, alias, false
+			}
 			WriteLog("Decompiling", "...   Filename: %s\n", file.Name)
-			fu.Decompile(file, decompilePath)
+			fu.decompileOrUnpack(file, decompilePath)
+		}
+
+		if RunWorkspace != nil {
+			if err := RunWorkspace.CheckLimit(); err != nil {
+				App.Fatalf("Decompiling [%s] exhausted the workspace! Details: %v\n", path, err)
+			}
 		}
 
 		return decompilePath, alias, true
@@ -251,7 +286,7 @@ func (fu *FileUtil) GetFilesWithExtension(searchDir string, extension string) (m
 
 	if err != nil {
 		App.Errorf("%s\n", fmt.Sprintf("Error getting files in directory [%s]! Details: %s", searchDir, err))
-		App.Usage(os.Args)
+		App.Usage(os.Args[1:])
 		os.Exit(1)
 	}
 
@@ -311,6 +346,15 @@ func (fu *FileUtil) Decompile(target FileInfo, basePath string) {
 	fu.useDecompileRegex = true
 }
 
+//decompileOrUnpack decompiles the archive with fernflower or, with --bytecode, unpacks it and analyzes its bytecode
+func (fu *FileUtil) decompileOrUnpack(target FileInfo, basePath string) {
+	if *Bytecode {
+		fu.UnpackArchive(target, basePath)
+	} else {
+		fu.Decompile(target, basePath)
+	}
+}
+
 func (fu *FileUtil) GetLangForFileExt(fileExt string) (language *Language, ok bool) {
 
 	ext, ok := Exts[strings.ToLower(fileExt)]
@@ -323,36 +367,6 @@ func (fu *FileUtil) GetLangForFileExt(fileExt string) (language *Language, ok bo
 	return lang, ok
 }
 
-func (fu *FileUtil) EstablishTempDirectory(uniqueId string) (path string, err error) {
-
-	//Will default to the OS specific default temp path if one is not specified via cmd line switch
-	path, err = ioutil.TempDir(*TmpDirPath, uniqueId)
-
-	if err == nil {
-		_, err = CreateDirIfNotExist(path)
-	}
-
-	if err != nil {
-		exePath, err := os.Executable()
-		if err == nil {
-			path, err = ioutil.TempDir(filepath.Dir(exePath), uniqueId)
-			_, err = CreateDirIfNotExist(path)
-		} else {
-			homepath, err := homedir.Dir()
-			if err == nil {
-				path, err = ioutil.TempDir(homepath, uniqueId)
-				_, err = CreateDirIfNotExist(path)
-			}
-		}
-	}
-
-	//Update to the final tmp path!
-	*TmpDirPath = path
-
-	return path, err
-
-}
-
 func (fu *FileUtil) RemoveDir(path string) {
 	os.RemoveAll(path)
 }
@@ -379,6 +393,65 @@ func (f *FileInfo) GetCleanedExt() string {
 	return f.Ext
 }
 
+//Context returns up to window lines either side of line (1 based), each prefixed by its line number and the
+//line itself marked with '>'. The file's lines are read once and kept until ReleaseContext, unless the lines of the
+//chunk being analyzed were set by SetContextLines.
+func (f *FileInfo) Context(line int, window int) string {
+	if line <= 0 || window <= 0 {
+		return ""
+	}
+
+	f.Lock()
+	if f.context == nil {
+		contents, err := ioutil.ReadFile(f.FQN)
+		if err != nil {
+			f.Unlock()
+			TrackError("Context", err)
+			return ""
+		}
+		f.context = &contextLines{firstLine: 1, lines: strings.Split(strings.TrimRight(string(contents), "\n"), "\n")}
+	}
+	lines, firstLine := f.context.lines, f.context.firstLine
+	f.Unlock()
+
+	first := line - window
+	if first < firstLine {
+		first = firstLine
+	}
+	last := line + window
+	if last > firstLine+len(lines)-1 {
+		last = firstLine + len(lines) - 1
+	}
+
+	var context []string
+	for n := first; n <= last; n++ {
+		//Lines are cut after MAX_CONTEXT_LINE_LEN characters, never within one
+		text := Truncate(strings.TrimRight(lines[n-firstLine], "\r"), MAX_CONTEXT_LINE_LEN+len(ELLIPSIS))
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+		context = append(context, fmt.Sprintf("%s%d: %s", marker, n, text))
+	}
+
+	return strings.Join(context, "\n")
+}
+
+//SetContextLines has Context take the lines around a finding from the chunk of the file being analyzed rather than
+//reading the file. Lines outside of the chunk are left out of the context.
+func (f *FileInfo) SetContextLines(firstLine int, lines []string) {
+	f.Lock()
+	f.context = &contextLines{firstLine: firstLine, lines: lines}
+	f.Unlock()
+}
+
+//ReleaseContext drops the lines cached by Context once the file has been processed
+func (f *FileInfo) ReleaseContext() {
+	f.Lock()
+	f.context = nil
+	f.Unlock()
+}
+
 /***********************************************************************************************************************
 														PUBLIC API
 ***********************************************************************************************************************/
@@ -446,7 +519,7 @@ func getDecompilerPath() string {
 
 	if !Exists(fernFlowerPath) {
 		App.Errorf("%s\n", fmt.Sprintf("Fernflower not installed or found at [%s]", fernFlowerPath))
-		App.Usage(os.Args)
+		App.Usage(os.Args[1:])
 		os.Exit(1)
 	}
 
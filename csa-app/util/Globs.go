@@ -0,0 +1,204 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+//Name of the files listing globs of the files and directories not to analyze, like a .gitignore
+const CSA_IGNORE_FILENAME = ".csaignore"
+
+//PathFilter tells the files and directories the file walker skips. The globs of the --exclude flags and of the
+//.csaignore files found while walking exclude paths, the last glob matching a path deciding (a glob starting with !
+//includes it again). The flags are relative to the application's root and take precedence over the .csaignore files,
+//relative to the directory they are in. When there are --include globs only the files matching one of them are kept.
+type PathFilter struct {
+	includes []*glob
+	excludes []*glob
+	ignores  []*glob
+}
+
+//glob is a pattern of a path relative to base. Patterns without a / match the name of a file or directory at any depth,
+//those ending with a / match directories only. A directory matched matches everything beneath it.
+type glob struct {
+	base    string
+	negate  bool
+	dirOnly bool
+	full    *regexp.Regexp
+	beneath *regexp.Regexp
+}
+
+func NewPathFilter(root string, includes []string, excludes []string) (*PathFilter, error) {
+
+	filter := &PathFilter{}
+
+	for _, pattern := range includes {
+		include, err := compileGlob(root, pattern)
+		if err != nil {
+			return nil, err
+		}
+		filter.includes = append(filter.includes, include)
+	}
+
+	for _, pattern := range excludes {
+		exclude, err := compileGlob(root, pattern)
+		if err != nil {
+			return nil, err
+		}
+		filter.excludes = append(filter.excludes, exclude)
+	}
+
+	return filter, nil
+}
+
+//AddIgnoreFile adds the globs of the .csaignore file in dir, if there is one. Blank lines and those starting with #
+//are skipped.
+func (pf *PathFilter) AddIgnoreFile(dir string) error {
+
+	file, err := os.Open(filepath.Join(dir, CSA_IGNORE_FILENAME))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		ignore, err := compileGlob(dir, pattern)
+		if err != nil {
+			return fmt.Errorf("%s: %v", file.Name(), err)
+		}
+		pf.ignores = append(pf.ignores, ignore)
+	}
+
+	return scanner.Err()
+}
+
+//Excluded tells whether the file or directory at path is skipped
+func (pf *PathFilter) Excluded(path string, isDir bool) bool {
+
+	if excluded, matched := lastMatch(pf.excludes, path, isDir); matched {
+		return excluded
+	}
+
+	if excluded, _ := lastMatch(pf.ignores, path, isDir); excluded {
+		return true
+	}
+
+	if isDir || len(pf.includes) == 0 {
+		return false
+	}
+
+	for _, include := range pf.includes {
+		if include.matches(path, isDir) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lastMatch(globs []*glob, path string, isDir bool) (excluded bool, matched bool) {
+	for _, g := range globs {
+		if g.matches(path, isDir) {
+			excluded, matched = !g.negate, true
+		}
+	}
+	return
+}
+
+func (g *glob) matches(path string, isDir bool) bool {
+
+	rel, err := filepath.Rel(g.base, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	if g.dirOnly && !isDir {
+		return g.beneath.MatchString(rel)
+	}
+	return g.full.MatchString(rel)
+}
+
+//compileGlob translates the pattern to regular expressions: * and ? match within a directory, ** across directories
+//and [...] a character class
+func compileGlob(base string, pattern string) (*glob, error) {
+
+	g := &glob{base: base}
+
+	if strings.HasPrefix(pattern, "!") {
+		g.negate = true
+		pattern = pattern[1:]
+	}
+
+	pattern = filepath.ToSlash(pattern)
+	if strings.HasSuffix(pattern, "/") {
+		g.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty glob")
+	}
+
+	var expr strings.Builder
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 3
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+			i++
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+			i++
+		case pattern[i] == '[' && strings.Contains(pattern[i+1:], "]"):
+			class := pattern[i+1 : i+1+strings.Index(pattern[i+1:], "]")]
+			i += len(class) + 2
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			i++
+		}
+	}
+
+	prefix := "^"
+	if !anchored {
+		prefix += "(.*/)?"
+	}
+
+	var err error
+	if g.full, err = regexp.Compile(prefix + expr.String() + "(/.*)?$"); err == nil {
+		g.beneath, err = regexp.Compile(prefix + expr.String() + "/.*$")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob [%s]: %v", pattern, err)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,150 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	ENCRYPTED_ARCHIVE  = "password-protected archive"
+	ENCRYPTED_OFFICE   = "encrypted office document"
+	ENCRYPTED_PDF      = "encrypted pdf"
+	ENCRYPTED_PGP      = "pgp encrypted"
+	ENCRYPTED_VAULT    = "ansible vault encrypted"
+	ENCRYPTED_OPENSSL  = "openssl encrypted"
+	ENCRYPTED_GITCRYPT = "git-crypt encrypted"
+)
+
+// Bytes read from the start of a file to recognize it and from the end of a pdf to find its trailer
+const inaccessibleHeadSize = 1024
+const pdfTailSize = 64 * 1024
+
+var (
+	zipMagic      = []byte("PK\x03\x04")
+	oleMagic      = []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")
+	pdfMagic      = []byte("%PDF-")
+	pgpMagic      = []byte("-----BEGIN PGP MESSAGE-----")
+	vaultMagic    = []byte("$ANSIBLE_VAULT;")
+	opensslMagic  = []byte("Salted__")
+	gitCryptMagic = []byte("\x00GITCRYPT\x00")
+	ooxmlExts     = map[string]bool{".docx": true, ".docm": true, ".xlsx": true, ".xlsm": true, ".pptx": true, ".pptm": true}
+)
+
+// InaccessibleInput is a file that could not be analyzed because its contents are encrypted
+type InaccessibleInput struct {
+	Path   string
+	Reason string
+}
+
+var inaccessible = make(map[string]string)
+var inaccessibleLock sync.Mutex
+
+// InaccessibleReason tells why a file's contents can't be analyzed (I.E. it is a password-protected archive) or returns
+// an empty string when they can. Files that can't be read at all are left to the usual error handling.
+func InaccessibleReason(path string) string {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	head := make([]byte, inaccessibleHeadSize)
+	n, _ := io.ReadFull(file, head)
+	head = head[:n]
+
+	switch {
+	case bytes.HasPrefix(head, zipMagic):
+		return encryptedZip(path)
+	case bytes.HasPrefix(head, oleMagic):
+		//Office Open XML documents are zips unless they are encrypted, which wraps them in an OLE container
+		if ooxmlExts[strings.ToLower(filepath.Ext(path))] {
+			return ENCRYPTED_OFFICE
+		}
+	case bytes.HasPrefix(head, pdfMagic):
+		return encryptedPdf(file)
+	case bytes.HasPrefix(head, pgpMagic):
+		return ENCRYPTED_PGP
+	case bytes.HasPrefix(head, vaultMagic):
+		return ENCRYPTED_VAULT
+	case bytes.HasPrefix(head, opensslMagic):
+		return ENCRYPTED_OPENSSL
+	case bytes.HasPrefix(head, gitCryptMagic):
+		return ENCRYPTED_GITCRYPT
+	}
+
+	return ""
+}
+
+func encryptedZip(path string) string {
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return ""
+	}
+	defer archive.Close()
+
+	for _, entry := range archive.File {
+		//Bit 0 of the general purpose flags marks an encrypted entry
+		if entry.Flags&0x1 != 0 {
+			return ENCRYPTED_ARCHIVE
+		}
+	}
+
+	return ""
+}
+
+// encryptedPdf looks for the /Encrypt entry of the pdf's trailer
+func encryptedPdf(file *os.File) string {
+
+	info, err := file.Stat()
+	if err != nil {
+		return ""
+	}
+
+	offset := info.Size() - pdfTailSize
+	if offset < 0 {
+		offset = 0
+	}
+
+	tail := make([]byte, info.Size()-offset)
+	n, _ := file.ReadAt(tail, offset)
+
+	if bytes.Contains(tail[:n], []byte("/Encrypt")) {
+		return ENCRYPTED_PDF
+	}
+
+	return ""
+}
+
+func TrackInaccessible(path string, reason string) {
+	inaccessibleLock.Lock()
+	defer inaccessibleLock.Unlock()
+
+	inaccessible[path] = reason
+}
+
+// InaccessibleInputs returns the inputs skipped because they are encrypted, sorted by path
+func InaccessibleInputs() (inputs []InaccessibleInput) {
+	inaccessibleLock.Lock()
+	defer inaccessibleLock.Unlock()
+
+	for path, reason := range inaccessible {
+		inputs = append(inputs, InaccessibleInput{Path: path, Reason: reason})
+	}
+
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].Path < inputs[j].Path })
+
+	return inputs
+}
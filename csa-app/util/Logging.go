@@ -73,7 +73,7 @@ func WriteLogLineWithToken(line int, action string, token string, format string,
 
 	if *Verbose {
 		log.Printf(format, v...)
-	} else {
+	} else if !*Quiet {
 		symbol := token
 		if token == "" {
 			sp.Lock()
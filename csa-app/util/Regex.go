@@ -0,0 +1,122 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//Compiled regexes are safe for concurrent use so every rule copy and worker shares one per pattern
+var regexCache sync.Map
+
+//Patterns that exceeded the regex timeout. They no longer match for the rest of the run
+var stalledRegexes sync.Map
+
+//Number of targets cut to --regex-max-input before being matched
+var truncatedTargets int64
+
+//CompileRegex compiles a pattern once per process
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+
+	if cached, found := regexCache.Load(pattern); found {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, _ := regexCache.LoadOrStore(pattern, regex)
+
+	return cached.(*regexp.Regexp), nil
+}
+
+//ResetRegexCache drops every compiled and stalled pattern. A long running server calls it when rules are reloaded so
+//the patterns of edited or deleted rules are released and patterns disabled by a timeout get another chance.
+func ResetRegexCache() {
+	regexCache.Range(func(pattern, _ interface{}) bool {
+		regexCache.Delete(pattern)
+		return true
+	})
+	stalledRegexes.Range(func(pattern, _ interface{}) bool {
+		stalledRegexes.Delete(pattern)
+		return true
+	})
+}
+
+//MustCompileRegex is CompileRegex for patterns already validated. It panics if the pattern is not a valid regex.
+func MustCompileRegex(pattern string) *regexp.Regexp {
+
+	regex, err := CompileRegex(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("regexp: Compile(%q): %v", pattern, err))
+	}
+
+	return regex
+}
+
+//MatchRegex matches the target within the --regex-timeout. Go's regex engine is RE2 (no backtracking, linear in the size
+//of the target) yet a pattern run against the contents of a huge file can still hold a worker up. A pattern exceeding
+//the timeout is reported once and then disabled for the rest of the run.
+func MatchRegex(regex *regexp.Regexp, target string) bool {
+	matched := runRegex(regex, target, func(target string) interface{} { return regex.MatchString(target) })
+	return matched == true
+}
+
+//FindAllRegex returns the start/end index pairs of every match in the target, within the --regex-timeout like MatchRegex
+func FindAllRegex(regex *regexp.Regexp, target string) [][]int {
+	matches, _ := runRegex(regex, target, func(target string) interface{} { return regex.FindAllStringIndex(target, -1) }).([][]int)
+	return matches
+}
+
+//runRegex runs match unless the regex has stalled, returning nil when it has. The target is first cut to the input
+//limit (--regex-max-input) so that, RE2 being linear, every match finishes in bounded time. A match is never interrupted (an
+//abandoned match would keep burning a cpu): one that took longer than the timeout completes, then disables its pattern.
+func runRegex(regex *regexp.Regexp, target string, match func(target string) interface{}) interface{} {
+
+	if RegexMaxInput != nil && *RegexMaxInput > 0 && len(target) > *RegexMaxInput<<10 {
+		target = target[:*RegexMaxInput<<10]
+		atomic.AddInt64(&truncatedTargets, 1)
+	}
+
+	if RegexTimeout == nil || *RegexTimeout <= 0 {
+		return match(target)
+	}
+
+	if _, stalled := stalledRegexes.Load(regex.String()); stalled {
+		return nil
+	}
+
+	start := time.Now()
+	result := match(target)
+
+	if elapsed := time.Since(start); elapsed > *RegexTimeout {
+		if _, reported := stalledRegexes.LoadOrStore(regex.String(), true); !reported {
+			TrackError("Regex", fmt.Errorf("pattern [%s] took %v matching [%d] characters, exceeding the regex timeout of %v, and was disabled for the rest of the run", regex.String(), elapsed, len(target), *RegexTimeout))
+		}
+	}
+
+	return result
+}
+
+//TruncatedRegexTargets is the number of targets only the first --regex-max-input KB of were matched
+func TruncatedRegexTargets() int64 {
+	return atomic.LoadInt64(&truncatedTargets)
+}
+
+//StalledRegexes returns the patterns disabled for exceeding the regex timeout
+func StalledRegexes() (patterns []string) {
+	stalledRegexes.Range(func(pattern, _ interface{}) bool {
+		patterns = append(patterns, pattern.(string))
+		return true
+	})
+	return patterns
+}
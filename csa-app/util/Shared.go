@@ -8,16 +8,17 @@ package util
 import (
 	"strconv"
 	"sync"
-
-	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 var (
-	App               = kingpin.New(APP_NAME, "CSA is used to analyze & collect data related to the cloud readiness of an application based on it's source-code.")
+	App               = NewCliApp(APP_NAME, "CSA is used to analyze & collect data related to the cloud readiness of an application based on it's source-code.")
 	Verbose           = App.Flag("verbose", "enable verbose mode.").Short('v').Bool()
-	Profile           = App.Flag("profile", "enables profiling (cpu|mem)").Enum("cpu", "mem")
+	PerfProfile       = App.Flag("perf-profile", "enables profiling (cpu|mem)").Enum("cpu", "mem")
 	RulesDir          = App.Flag("rules-dir", "directory where csa rules are. Rules found in this directory will be automatically imported on tool startup. This will also be the default directory for `rules` import").Default(DEFAULT_RULES_DIR).String()
 	ModelsDir         = App.Flag("models-dir", "directory where csa scoring models are. Scoring Models found in this directory will be automatically imported on tool startup. This will also be the default directory for `scoring-models` import").Default(DEFAULT_MODELS_DIR).String()
+	I18nDir           = App.Flag("i18n-dir", "directory of the per-locale rule advice catalogs (advice.<locale>.yaml)").Default(DEFAULT_I18N_DIR).String()
+	Locale            = App.Flag("locale", "locale of the rule advice recorded with findings. I.E. de or pt-BR. Untranslated advice is left in english").Envar("CSA_LOCALE").String()
+	ProfilesDir       = App.Flag("profiles-dir", "directory of rule profile (yaml|json) files. A profile file replaces the builtin profile of the same name").Default(DEFAULT_PROFILES_DIR).String()
 	OutputDir         = App.Flag("output-dir", "directory path where csa results will be output").Default(DEFUALT_OUTPUT_DIR).String()
 	ExcludedDirsRegEx = App.Flag(EXCLUDED_DIRS_FLAG, "regex pattern of directories not to be included in analysis").Default("^([.].*|target|bin|test|node_modules|eclipse|out|vendors|obj)$").String()
 	DB                = App.Flag("db", "which database engine to use (sqlite|postgres)").Default(SQLITE).Enum(SQLITE, POSTGRES)
@@ -25,24 +26,43 @@ var (
 	DbDir             = App.Flag("database-dir", "directory path where database can be found or created. (defaults to csa executable directory)").String()
 	DBDriverFlags     = App.Flag("db-driver-flags", "flags to configure the database driver (Default: sqlite: "+Sqlite_driverFlags+" postgres: "+Postgres_driverFlags).String()
 	ReportsFlag       = App.Flag("report", "comma delimited list of report(s) to run. (for example \"-r1,3,4\". 0=All)").Default("0").Short('r').String()
-	TmpDirPath        = App.Flag("temp-dir", "The root path where files created by csa will be placed. Defaults to OS specific temp path/run-id").Short('t').String()
+	TmpDirPath        = App.Flag("temp-dir", "The root path where files created by csa will be placed. Each run gets its own workspace beneath it. Defaults to OS specific temp path").Short('t').String()
+	TmpDirLimit       = App.Flag("temp-dir-limit", "maximum size (in MB) a run's temporary workspace may grow to. 0=unlimited").Default("10240").Int64()
+	Pager             = App.Flag("pager", "page reports displayed on std out through $PAGER (defaults to 'less -RS')").Bool()
+	NoColor           = App.Flag("no-color", "do not color reports displayed on std out. Color is also dropped when std out is not a terminal or NO_COLOR is set").Bool()
+	MaxColumnWidth    = App.Flag("max-column-width", "truncate report columns displayed on std out to this width (with ellipsis). 0=unlimited").Default("0").Int()
+	OtelEndpoint      = App.Flag("otel-endpoint", "host:port of an OTLP/HTTP collector to export run trace spans to. Tracing is disabled when not set").String()
+	EffortScale       = App.Flag("effort-scale", "express effort in reports using a preset (fibonacci|t-shirt) or a yaml/json effort scale file").String()
+	OtelInsecure      = App.Flag("otel-insecure", "export trace spans over plain http rather than https").Bool()
 
 	//Get Build Info
 	BuildInfoCmd = App.Command("info", "Get full build details of this csa executable")
 
 	//csa ui
-	CsaCmd  = App.Command("ui", "Launch the CSA UI")
-	CsaPort = CsaCmd.Flag("port", "port to start ui on").Default("3001").Int()
+	CsaCmd             = App.Command("ui", "Launch the CSA UI")
+	CsaPort            = CsaCmd.Flag("port", "port to start ui on").Default("3001").Int()
+	ReportWorkers      = CsaCmd.Flag("report-workers", "number of background workers generating report exports").Default("2").Int()
+	WatchRules         = CsaCmd.Flag("watch-rules", "reload the rules of the --rules-dir when a rule file is added or modified. Runs started afterwards use them").Bool()
+	WatchRulesInterval = CsaCmd.Flag("watch-rules-interval", "how often the --rules-dir is checked for rule changes").Default("5s").Duration()
 
-	//List reports Command
-	ShowReports = App.Command("list", "list available reports to run")
+	//List reports Command (superseded by `report list` but kept for existing scripts)
+	ShowReports = App.Command("list", "list available reports to run").Hidden().Deprecated("use `csa report list`")
 	ReportType  = ShowReports.Flag("report-type", "show reports available for a particular type (all|csa|git)").Default(APP_NAME).String()
 
+	//Database Cmd(s)
+	DbCmd     = App.Command("db", "inspect the csa database")
+	DbInfoCmd = DbCmd.Command("info", "show the database engine, its location and how many runs, findings and rules it holds")
+
+	//Shell completion
+	CompletionCmd   = App.Command("completion", "print a shell completion script. I.E. `source <(csa completion bash)`")
+	CompletionShell = CompletionCmd.Arg("shell", "shell to complete for (bash|zsh)").Default("bash").Enum("bash", "zsh")
+
 	//Analyze Command
 	AnalyzeCmd            = App.Command(ANALYZE_CMD, "analyze the code on the provided path").Default()
 	Path                  = AnalyzeCmd.Arg("path", "Path to source or jar/war/ear file").Default(".").String()
 	Alias                 = AnalyzeCmd.Flag("alias", "the name or alias for this run. (defaults to target dir or archive name if not provided)").String()
 	DecompileDir          = AnalyzeCmd.Flag("decompile-dir", "destination of fernflower decompile (defaults to <jar dir>/decompile)").String()
+	Bytecode              = AnalyzeCmd.Flag("bytecode", "analyze the bytecode of jar/war/ear archives (the classes they reference and their annotations) instead of decompiling them with fernflower. Nested archives are unpacked as well").Bool()
 	AnalyzeArchives       = AnalyzeCmd.Flag("analyze-archives", "this tells csa to decompile archives (jar|ear|war) that is finds under the target path (including within other archives)").Short('a').Default("false").Hidden().Bool()
 	OutputReports         = AnalyzeCmd.Flag("output-reports", "create the original csv reports").Bool()
 	TxtIndexingEnabled    = AnalyzeCmd.Flag("enable-txt-index", "index the run for free form text searching").Bool()
@@ -50,7 +70,17 @@ var (
 	DomainFlag            = AnalyzeCmd.Flag("domain-dir", "include domain/1st sub-directory in target path in report(s)").Short('d').Default("false").Hidden().Bool()
 	IncludedFilesRegEx    = AnalyzeCmd.Flag(INCLUDED_FILES, "regex pattern of file(s) to include in analysis. Note: if this is set/modified it will override the excluded-files switch").Default(".*").String()
 	ExcludedFilesRegEx    = AnalyzeCmd.Flag(EXCLUDED_FILES, "regex pattern of file(s) to exclude from analysis").Default("^(.*[.](exe|png|tiff|tif|gif|jpg|jpeg|bmp|dmg|mpeg|class)|[.].*|csa-config[.](yaml|yml|json))$").String()
-	MaxBuffer             = AnalyzeCmd.Flag("max-buffer", "size of buffer for channel(s). Note: this will affect memory utilization and speed").Default("100000").Int()
+	IncludeGlobs          = AnalyzeCmd.Flag("include", "glob of the files to analyze, relative to the application's root (I.E. src/main/**). Repeatable, files matching none of them aren't analyzed").Strings()
+	ExcludeGlobs          = AnalyzeCmd.Flag("exclude", "glob of the files and directories not to analyze, relative to the application's root (I.E. **/generated/ or *.min.js). Repeatable, taking precedence over the globs of "+CSA_IGNORE_FILENAME+" files").Strings()
+	Workers               = AnalyzeCmd.Flag("workers", "number of files analyzed at once. Defaults to the processor count").Int()
+	Incremental           = AnalyzeCmd.Flag("incremental", "only analyze the files that changed since the last run of the same path. The findings of unchanged files (same sha256) are copied forward from that run").Bool()
+	Resume                = AnalyzeCmd.Flag("resume", "resume the analysis run with this id, interrupted before it was done analyzing. The files analyzed before it was interrupted aren't analyzed again").Uint()
+	CheckpointInterval    = AnalyzeCmd.Flag("checkpoint-interval", "seconds between checkpoints, when the findings found so far and the files they were found in are committed to the database").Default("30").Int()
+	Distribute            = AnalyzeCmd.Flag("distribute", "coordinate the run's analysis across workers: its applications are shards claimed by workers joining the run, then merged into the run. Requires a postgres database shared by the workers").Bool()
+	JoinRun               = AnalyzeCmd.Flag("join", "analyze applications of the distributed run with this id as one of its workers, until none are left to claim. Give the same options as the coordinator").Uint()
+	ShardTimeout          = AnalyzeCmd.Flag("shard-timeout", "seconds without a heartbeat after which the applications held by a worker of a distributed run are given to another worker").Default("300").Int()
+	MaxBuffer             = AnalyzeCmd.Flag("max-buffer", "number of findings waiting to be saved before analysis workers wait on the database. Defaults to "+strconv.Itoa(DEFAULT_FINDINGS_PER_WORKER)+" per worker. Note: this will affect memory utilization and speed").Int()
+	BatchSize             = AnalyzeCmd.Flag("batch-size", "rows saved per transaction when saving findings and report data. Finding tags, recipes and report data are inserted with multi-row statements of up to as many rows").Default(strconv.Itoa(DEFAULT_BATCH_SIZE)).Int()
 	MaxSaveWorkers        = AnalyzeCmd.Flag("max-save-workers", "maximum number of workers to utilize for finding save channel. Note: this will affect memory utilization and speed ("+SQLITE+"=1 "+POSTGRES+"=10").Int()
 	MaxIndexWorkers       = AnalyzeCmd.Flag("max-idx-workers", "maximum number of workers to utilize for finding index channel. Note: this will affect memory utilization and speed").Default("1").Hidden().Int()
 	DumpRuleMetrics       = AnalyzeCmd.Flag("display-rule-metrics", "show rule metrics on std out").Short('m').Bool()
@@ -64,13 +94,46 @@ var (
 	DisableIgnoreComments = AnalyzeCmd.Flag("disable-ignore-comments", "tell csa to read analyze comments for findings").Short('i').Bool()
 	WriteAppConfig        = AnalyzeCmd.Flag("write-app-config", "csa will write an application configuration file to the apps root directory").Short('w').Bool()
 	LineBuffer            = AnalyzeCmd.Flag("line-buffer", "size of line buffer used when reading files. This will affect memory utilization and speed").Default(strconv.Itoa(DEFAULT_LINE_BUFFER_SIZE)).Int()
+	LargeFileSize         = AnalyzeCmd.Flag("large-file-size", "size (in MB) above which files are read and matched in chunks of that size rather than whole. Limits the memory a file's multiline and contents rules need").Default("32").Int()
+	ChunkOverlap          = AnalyzeCmd.Flag("chunk-overlap", "size (in KB) by which the chunks of large files overlap, so that multiline patterns spanning chunks are matched. At most a quarter of --large-file-size").Default("64").Int()
 	FailFast              = AnalyzeCmd.Flag("fail-fast", "tell csa to immediately stop the run on any failure. Note: there is a risk of corrupting the csa datastore.").Short('f').Bool()
 	DisplayErrors         = AnalyzeCmd.Flag("display-errors", "show errors at end of run").Short('e').Bool()
 	WriteConfigsOnly      = AnalyzeCmd.Flag("write-configs-only", "tell csa to only generate config files instead of performing a full run").Short('o').Bool()
 	OutputFormatJson      = AnalyzeCmd.Flag("json", "write config files in json format. Default is yaml").Short('j').Bool()
 	ScoringModel          = AnalyzeCmd.Flag(SCORING_MODEL_FLAG, "the name of the scoring model to use for scoring applications").Short('s').Default("default").String()
+	Scorer                = AnalyzeCmd.Flag(SCORER_FLAG, "how raw scores are turned into scores for the run (default|logarithmic|percentile). Bounds and recommendations still come from each app's scoring model").Default("default").String()
+	ScoringFormula        = AnalyzeCmd.Flag(SCORING_FORMULA_FLAG, "(yaml|json) file with a formula turning raw scores into scores. Selects the formula scorer").String()
+	Normalize             = AnalyzeCmd.Flag(NORMALIZE_FLAG, "normalize raw scores by size before scoring so large and small apps are comparable (none|sloc|files). sloc scores the raw score per 1000 lines of code, files per 100 files").Default("none").Enum("none", "sloc", "files")
+	MinConfidence         = AnalyzeCmd.Flag("min-confidence", "findings below this confidence (low|medium|high) are reported but don't count towards scores. Matches in comments are low confidence").Default("low").Enum("low", "medium", "high")
+	RuleProfile           = AnalyzeCmd.Flag("profile", "target platform rule profile (tas|kubernetes|tkg|eks|aks|openshift or one from the profiles dir). Drops rules that don't apply and weights effort for the platform").String()
+	RuleOverrides         = AnalyzeCmd.Flag("rule-overrides", "yaml/json file remapping the effort and advice of specific rules for this engagement. Applied when rules are loaded and recorded with the run").String()
+	TagWeights            = AnalyzeCmd.Flag("tag-weights", "yaml/json file weighting the effort of findings by their tags. I.E. jni: 3, logging: 0.5. Recorded with the run").String()
+	ThirdPartyDirsRegEx   = AnalyzeCmd.Flag("third-party-dirs", "regex pattern of directories holding vendored/third-party code. Findings beneath them are reported separately and excluded from the app score").Default("^(vendor|third[_-]?party|3rd[_-]?party|external|bower_components|Pods|site-packages)$").String()
+	NoThirdPartyDetection = AnalyzeCmd.Flag("disable-third-party-detection", "treat all code as the application's own. Configured third-party-paths still apply").Bool()
+	LicenseDB             = AnalyzeCmd.Flag("license-db", "(yaml|json) offline license db mapping third-party packages (or group ids) to licenses. Consulted by the third-party import report for imports the local poms don't resolve").String()
+	MavenRepo             = AnalyzeCmd.Flag("maven-repo", "local maven repository the poms (licenses) of declared dependencies are read from. Defaults to ~/.m2/repository").String()
+	TargetJdk             = AnalyzeCmd.Flag("target-jdk", "JDK release the applications are upgraded to (I.E. 17). Adds rules from the built-in catalog of JDK APIs deprecated or removed by that release. See 'report jdk'").Int()
+	LifecycleTolerance    = AnalyzeCmd.Flag("lifecycle-line-tolerance", "how many lines a finding may move between runs and still be considered the same (recurring) finding").Default("10").Int()
 	MaxProcs              = AnalyzeCmd.Flag("max-procs", "Set the max concurrency from a processor perspective. Defaults to system processor count.").Int()
 	MaxThreads            = AnalyzeCmd.Flag("max-threads", "Set the max OS threads that csa can utilize. Default is '20000'").Default(strconv.Itoa(20000)).Int()
+	Quiet                 = AnalyzeCmd.Flag("quiet", "replace the console progress of the analysis with a machine-readable progress stream: a json object per line on std err, every second and when the analysis is done").Short('q').Bool()
+	NdjsonOutput          = AnalyzeCmd.Flag("ndjson", "stream every finding as newline delimited json to this file as it is discovered. Use '-' for std out, where finding lines start with '{' and are interleaved with the console output").String()
+	NotifyOn              = AnalyzeCmd.Flag("notify-on", "comma delimited run events sent to webhook/slack/email subscribers (all|run-started|phase-completed|finding-threshold|run-finished)").Default("run-finished,finding-threshold").String()
+	NotifyWebhooks        = AnalyzeCmd.Flag("notify-webhook", "url run events are POSTed to as json. Repeat for several webhooks").Strings()
+	NotifySlack           = AnalyzeCmd.Flag("notify-slack", "Slack incoming webhook url run events are posted to").Envar("CSA_SLACK_WEBHOOK").String()
+	NotifyEmails          = AnalyzeCmd.Flag("notify-email", "address run events are mailed to. Repeat for several recipients. Requires --smtp-server").Strings()
+	NotifySmtpServer      = AnalyzeCmd.Flag("smtp-server", "host:port of the smtp server used for email notifications").String()
+	NotifySmtpFrom        = AnalyzeCmd.Flag("smtp-from", "sender address of email notifications").Default("csa@localhost").String()
+	NotifySmtpUser        = AnalyzeCmd.Flag("smtp-user", "smtp user. Auth is only used when set").Envar("CSA_SMTP_USER").String()
+	NotifySmtpPassword    = AnalyzeCmd.Flag("smtp-password", "smtp password").Envar("CSA_SMTP_PASSWORD").Hidden().String()
+	NotifyMetricsFile     = AnalyzeCmd.Flag("metrics-file", "file run metrics are written to in Prometheus text format as the run progresses (I.E. for the node_exporter textfile collector)").String()
+	RegexTimeout          = AnalyzeCmd.Flag("regex-timeout", "maximum time a single regex match may take. I.E. 500ms. A pattern exceeding it is reported and disabled for the rest of the run. 0=unlimited").Default("0s").Duration()
+	RegexMaxInput         = AnalyzeCmd.Flag("regex-max-input", "maximum size (in KB) of the line or file contents a regex is matched against. Only the first KB of larger targets are matched. 0=unlimited").Default("0").Int()
+	ContextLines          = AnalyzeCmd.Flag("context-lines", "number of lines before and after the matched line stored with each finding (and exported by 'report findings'). 0=disabled").Default("0").Int()
+	ExplainRule           = AnalyzeCmd.Flag("explain-rule", "dry run of the named rule against the path. Shows the lines it would match, the exclusions that applied and the resulting effort without writing anything to the database").String()
+	FindingThreshold      = AnalyzeCmd.Flag("finding-threshold", "publish a finding-threshold event once the run records this many findings. 0=disabled").Default("0").Int()
+	FailBelow             = AnalyzeCmd.Flag("fail-below", "exit with 3 when an application scores below this score. Writes <run>-gate.json to the output dir. 0=disabled").Default("0").Float64()
+	FailOnTags            = AnalyzeCmd.Flag("fail-on-tag", "exit with 3 when an application has findings carrying this tag. Repeat (or comma delimit) for several tags. Writes <run>-gate.json to the output dir").Strings()
 
 	//Search Command
 	SearchCmd = App.Command("search", "search full text index for findings based on query")
@@ -92,8 +155,40 @@ var (
 	DeleteRulesCmd    = RulesCmd.Command("delete", "delete a rule in the database")
 	DeleteRulesName   = DeleteRulesCmd.Arg("name", "name of rule to be deleted").Required().String()
 	DeleteAllRulesCmd = RulesCmd.Command("delete-all", "delete all rules in the database")
-	ValidateRuleCmd   = RulesCmd.Command("validate", "validate a rule")
-	ValidateRuleName  = ValidateRuleCmd.Arg("file", "rule file to validate").Required().String()
+	ValidateRuleCmd   = RulesCmd.Command("validate", "validate rule file(s) against the rule schema reporting errors by line")
+	ValidateRuleName  = ValidateRuleCmd.Arg("file", "rule file (yaml|json) or directory of rule files to validate").Required().String()
+	RuleSchemaCmd     = RulesCmd.Command("schema", "print the json schema rule files are validated against")
+	RuleProfilesCmd   = RulesCmd.Command("profiles", "list the target platform rule profiles available to `analyze --profile`")
+	TestRulesCmd      = RulesCmd.Command("test", "run rule tests (<rule-file>.test.yaml) asserting rules match/don't match their fixtures")
+	TestRulesPath     = TestRulesCmd.Arg("path", "rule test file or directory of rule tests. Default is the rules directory").String()
+	I18nCmd           = RulesCmd.Command("i18n", "manage the translations of rule advice")
+	I18nExtractCmd    = I18nCmd.Command("extract", "write the rules' own advice to the source (en) catalog translations are made from")
+	I18nStatusCmd     = I18nCmd.Command("status", "show how much of the rule advice each locale translates")
+	I18nContributeCmd = I18nCmd.Command("contribute", "merge a (partial) catalog of translated advice into the catalog of its locale")
+	I18nContribution  = I18nContributeCmd.Arg("catalog", "catalog (yaml|json) file holding the locale and the translated advice").Required().ExistingFile()
+	DiffUpstreamCmd   = RulesCmd.Command("diff-upstream", "compare the local rules against an upstream rule pack release listing added/removed/modified rules")
+	DiffUpstreamPack  = DiffUpstreamCmd.Arg("pack", "upstream rule pack (.tgz) or directory of rule files").Required().String()
+	AdoptRules        = DiffUpstreamCmd.Flag("adopt", "name of a changed rule to adopt from the upstream pack (removed rules are deleted). Repeatable").Strings()
+	AdoptAdded        = DiffUpstreamCmd.Flag("adopt-added", "adopt all rules added upstream").Bool()
+	AdoptModified     = DiffUpstreamCmd.Flag("adopt-modified", "adopt all rules modified upstream").Bool()
+	AdoptRemoved      = DiffUpstreamCmd.Flag("adopt-removed", "delete all local rules not in the upstream pack").Bool()
+	LintRulesCmd      = RulesCmd.Command("lint", "detect rule patterns that subsume/duplicate each other, double counting matches (against the same tag)")
+	LintRulesPath     = LintRulesCmd.Arg("path", "rule pack (.tgz) or directory of rule files to lint. Default is the rules in the database").String()
+	LintDoubleCounts  = LintRulesCmd.Flag("double-counting-only", "only report overlapping patterns of rules sharing a tag").Bool()
+	TagsCmd           = RulesCmd.Command("tags", "manage the tag taxonomy rules and findings are grouped by")
+	ListTagsCmd       = TagsCmd.Command("list", "list tags with the rules/findings using them, flagging unregistered tags and tags similar to others (typos)")
+	TagProblemsOnly   = ListTagsCmd.Flag("problems-only", "only list unregistered and similar tags").Bool()
+	CreateTagCmd      = TagsCmd.Command("create", "register a tag in the taxonomy")
+	CreateTagName     = CreateTagCmd.Arg("name", "name of the tag").Required().String()
+	TagDescription    = CreateTagCmd.Flag("description", "what the tag stands for").String()
+	RenameTagCmd      = TagsCmd.Command("rename", "rename a tag in rules, findings, applications, bins and the taxonomy")
+	RenameTagFrom     = RenameTagCmd.Arg("tag", "tag to rename").Required().String()
+	RenameTagTo       = RenameTagCmd.Arg("new-name", "new name of the tag. Must not be in use, merge into it instead").Required().String()
+	MergeTagsCmd      = TagsCmd.Command("merge", "merge tags into another tag everywhere they are used")
+	MergeTagsInto     = MergeTagsCmd.Arg("into", "tag to merge into").Required().String()
+	MergeTagsFrom     = MergeTagsCmd.Arg("tags", "tags merged into it").Required().Strings()
+	RetagCmd          = TagsCmd.Command("retag", "replace the tags of a run's findings (and applications) with those their rules carry now")
+	RetagRunId        = RetagCmd.Flag("run", "id of the run to retag. Defaults to the latest analyze run").Uint()
 
 	//Bins Cmd(s)
 	BinsCmd             = App.Command("bins", "modify (import/export) Bin definition(s)")
@@ -108,6 +203,24 @@ var (
 	ValidateBinsCmd     = BinsCmd.Command("validate", "validate a bin(s) file")
 	ValidateBinName     = ValidateBinsCmd.Arg("file", "file containing bin definitions to validate").Required().String()
 
+	//Groups Cmd(s)
+	GroupsCmd           = App.Command("groups", "manage the business unit -> portfolio -> application hierarchy")
+	ListGroupsCmd       = GroupsCmd.Command("list", "list all groups and their applications")
+	CreateGroupCmd      = GroupsCmd.Command("create", "create a business unit or portfolio group")
+	CreateGroupName     = CreateGroupCmd.Arg("name", "name of the group").Required().String()
+	CreateGroupType     = CreateGroupCmd.Flag("type", "type of group (business-unit|portfolio)").Default("portfolio").Enum("business-unit", "portfolio")
+	CreateGroupParent   = CreateGroupCmd.Flag("parent", "business unit the portfolio belongs to").String()
+	DeleteGroupCmd      = GroupsCmd.Command("delete", "delete a group")
+	DeleteGroupName     = DeleteGroupCmd.Arg("name", "name of group to be deleted").Required().String()
+	AddGroupAppCmd      = GroupsCmd.Command("add-app", "add an application to a portfolio")
+	AddGroupAppGroup    = AddGroupAppCmd.Arg("group", "name of the portfolio").Required().String()
+	AddGroupAppName     = AddGroupAppCmd.Arg("app", "name of the application").Required().String()
+	RemoveGroupAppCmd   = GroupsCmd.Command("remove-app", "remove an application from a portfolio")
+	RemoveGroupAppGroup = RemoveGroupAppCmd.Arg("group", "name of the portfolio").Required().String()
+	RemoveGroupAppName  = RemoveGroupAppCmd.Arg("app", "name of the application").Required().String()
+	GroupReportCmd      = GroupsCmd.Command("report", "roll-up scores, sloc and effort for each business unit and portfolio")
+	GroupReportRunId    = GroupReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+
 	//Rules Cmd(s)
 	ModelsCmd              = App.Command("score-models", "modify (import/export) csa scoring models")
 	ExportModelsCmd        = ModelsCmd.Command("export", "export scoring model(s) from the database")
@@ -123,6 +236,246 @@ var (
 	ValidateModelCmd       = ModelsCmd.Command("validate", "validate a scoring model")
 	ValidateModelName      = ValidateModelCmd.Arg("file", "scoring model file to validate").Required().String()
 
+	//Score Cmd
+	ScoreCmd        = App.Command("score", "rescore the applications of a run with another scorer or scoring formula")
+	ScoreRunId      = ScoreCmd.Flag("run", "id of the run to rescore. Defaults to the latest analyze run").Uint()
+	ScoreScorer     = ScoreCmd.Flag("scorer", "scorer to rescore with (default|logarithmic|percentile). Defaults to the run's scorer").String()
+	ScoreFormula    = ScoreCmd.Flag("formula", "(yaml|json) file with the scoring formula to rescore with").String()
+	ScoreExpression = ScoreCmd.Flag("expression", "scoring formula to rescore with. I.E. \"max_score - log(10, 1 + effort_jms)\"").String()
+	ScoreNormalize  = ScoreCmd.Flag("normalize", "normalization to rescore with (none|sloc|files). Defaults to the run's normalization").Enum("none", "sloc", "files")
+	ScoreSimulate   = ScoreCmd.Flag("simulate", "preview the scores side by side with the current ones without saving them").Bool()
+
+	//What-if Cmd
+	WhatIfCmd     = App.Command("what-if", "simulate the scores of a run's applications if the findings matching the queries were resolved, without saving anything")
+	WhatIfRunId   = WhatIfCmd.Flag("run", "id of the run to simulate on. Defaults to the latest analyze run").Uint()
+	WhatIfResolve = WhatIfCmd.Flag("resolve", "query of the findings to resolve. I.E. \"tag=jms\" or \"category=ejb AND severity=high\". Repeat to resolve the findings matching any of them").Required().Strings()
+	WhatIfApp     = WhatIfCmd.Flag("app", "only list the scores of this application").String()
+	WhatIfFormat  = WhatIfCmd.Flag("format", "output format of the simulation (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	//Score Bins Cmd(s)
+	ScoreBinsCmd        = App.Command("score-bins", "manage the tiers (name, color and score range) scores are classified into")
+	ListScoreBinsCmd    = ScoreBinsCmd.Command("list", "list the score bins")
+	CreateScoreBinCmd   = ScoreBinsCmd.Command("create", "create a score bin")
+	CreateScoreBinName  = CreateScoreBinCmd.Arg("name", "name of the bin").Required().String()
+	CreateScoreBinMin   = CreateScoreBinCmd.Flag("min", "lowest score of the bin").Required().Float64()
+	CreateScoreBinMax   = CreateScoreBinCmd.Flag("max", "score the bin ends at. Only the highest bin includes it").Required().Float64()
+	CreateScoreBinColor = CreateScoreBinCmd.Flag("color", "color of the bin (#rrggbb or a color name)").String()
+	UpdateScoreBinCmd   = ScoreBinsCmd.Command("update", "update a score bin. Only the given values change")
+	UpdateScoreBinName  = UpdateScoreBinCmd.Arg("name", "name of the bin").Required().String()
+	UpdateScoreBinMin   = UpdateScoreBinCmd.Flag("min", "lowest score of the bin").String()
+	UpdateScoreBinMax   = UpdateScoreBinCmd.Flag("max", "score the bin ends at. Only the highest bin includes it").String()
+	UpdateScoreBinColor = UpdateScoreBinCmd.Flag("color", "color of the bin (#rrggbb or a color name)").String()
+	DeleteScoreBinCmd   = ScoreBinsCmd.Command("delete", "delete a score bin. Deleting every bin restores the default bins")
+	DeleteScoreBinName  = DeleteScoreBinCmd.Arg("name", "name of the bin to be deleted").Required().String()
+
+	//Triage Cmd(s)
+	TriageCmd         = App.Command("triage", "triage findings as suppressed, false positives or accepted. Suppressed and false positive findings no longer count against scores nor show up in reports")
+	SetTriageCmd      = TriageCmd.Command("set", "set the triage state of findings and rescore their run")
+	SetTriageState    = SetTriageCmd.Arg("state", "triage state (suppressed|false-positive|accepted). none clears it").Required().Enum("suppressed", "false-positive", "accepted", "none")
+	SetTriageFindings = SetTriageCmd.Arg("finding", "id(s) of the finding(s) to triage").Required().Strings()
+	SetTriageRunId    = SetTriageCmd.Flag("run", "id of the run the findings belong to. Defaults to the latest analyze run").Uint()
+	SetTriageReason   = SetTriageCmd.Flag("reason", "why the findings were triaged, kept for auditing").String()
+	ListTriageCmd     = TriageCmd.Command("list", "list the triaged findings of a run, suppressed ones included, for auditing")
+	ListTriageRunId   = ListTriageCmd.Flag("run", "id of the run to list. Defaults to the latest analyze run").Uint()
+	ListTriageApp     = ListTriageCmd.Flag("app", "only list the triaged findings of this application").String()
+	ListTriageFormat  = ListTriageCmd.Flag("format", "output format (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	//Outcome Cmd(s)
+	OutcomesCmd             = App.Command("outcomes", "record the actual effort migrating applications took and calibrate the estimation model with it")
+	RecordOutcomeCmd        = OutcomesCmd.Command("record", "record the person-days migrating an application took, replacing the one recorded before")
+	RecordOutcomeApp        = RecordOutcomeCmd.Arg("app", "name of the migrated application").Required().String()
+	RecordOutcomeDays       = RecordOutcomeCmd.Arg("days", "person-days the migration took").Required().Float64()
+	RecordOutcomeRunId      = RecordOutcomeCmd.Flag("run", "id of the run the application was analyzed by. Defaults to the latest analyze run").Uint()
+	RecordOutcomeNote       = RecordOutcomeCmd.Flag("note", "note on the migration, kept with the outcome").String()
+	ListOutcomesCmd         = OutcomesCmd.Command("list", "list the recorded outcomes next to their estimates")
+	ListOutcomesModel       = ListOutcomesCmd.Flag("model", "(yaml|json) estimation model file to estimate with. Defaults to the default model").String()
+	ListOutcomesFormat      = ListOutcomesCmd.Flag("format", "output format (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+	CalibrateOutcomesCmd    = OutcomesCmd.Command("calibrate", "calibrate the category factors of an estimation model with the recorded outcomes, writing the calibrated model to the output dir")
+	CalibrateOutcomesModel  = CalibrateOutcomesCmd.Flag("model", "(yaml|json) estimation model file to calibrate. Defaults to the default model").String()
+	CalibrateOutcomesFormat = CalibrateOutcomesCmd.Flag("format", "format of the calibrated model (yaml|json)").Default(YAML).Enum(YAML, JSON)
+
+	//Report Cmd(s)
+	ReportCmd      = App.Command("report", "generate reports directly from the findings store")
+	AdhocReportCmd = ReportCmd.Command("adhoc", "build a one-off aggregated report from a findings query")
+	AdhocQuery     = AdhocReportCmd.Flag("query", "findings query. Terms are ANDed together. (for example \"tag=filesystem AND effort>3\")").String()
+	AdhocGroupBy   = AdhocReportCmd.Flag("group-by", "finding field to aggregate by (tag|category|rule|pattern|effort|readiness|level|criticality|severity|application|filename|ext|module)").Default("category").String()
+	AdhocFormat    = AdhocReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+	AdhocRunId     = AdhocReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+
+	ListReportsCmd = ReportCmd.Command("list", "list available reports to run")
+	ListReportType = ListReportsCmd.Flag("report-type", "show reports available for a particular type (all|csa|git)").Default(APP_NAME).String()
+
+	CompareReportCmd = ReportCmd.Command("compare", "compare a run in this database with a run in another csa database (i.e. a scan of the same portfolio taken months ago)")
+	CompareDbPath    = CompareReportCmd.Arg("baseline-db", "path to the baseline csa (sqlite) database file").Required().String()
+	CompareRunId     = CompareReportCmd.Flag("run", "id of the run in this database. Defaults to the latest analyze run").Uint()
+	CompareBaseRunId = CompareReportCmd.Flag("baseline-run", "id of the run in the baseline database. Defaults to its latest analyze run").Uint()
+	CompareFormat    = CompareReportCmd.Flag("format", "output format of the comparison (table|csv)").Default("table").Enum("table", CSV)
+
+	FindingsReportCmd   = ReportCmd.Command("findings", "export every raw finding of a run, with all of its fields, to csv")
+	FindingsReportRunId = FindingsReportCmd.Flag("run", "id of the run to export. Defaults to the latest analyze run").Uint()
+	FindingsReportApp   = FindingsReportCmd.Flag("app", "only export findings for this application").String()
+
+	ThirdPartyReportCmd   = ReportCmd.Command("third-party", "summarize findings in vendored/third-party code, which are excluded from application scores")
+	ThirdPartyReportRunId = ThirdPartyReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+
+	ResolvedReportCmd    = ReportCmd.Command("resolved", "show new/recurring/resolved finding counts and the findings resolved since each application's previous run")
+	ResolvedReportRunId  = ResolvedReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	ResolvedReportApp    = ResolvedReportCmd.Flag("app", "only report on this application").String()
+	ResolvedReportFormat = ResolvedReportCmd.Flag("format", "output format of the report (table|csv)").Default("table").Enum("table", CSV)
+
+	TreemapReportCmd   = ReportCmd.Command("treemap", "export each application's directory hierarchy sized by sloc with finding totals/density as treemap-ready json")
+	TreemapReportRunId = TreemapReportCmd.Flag("run", "id of the run to export. Defaults to the latest analyze run").Uint()
+	TreemapReportApp   = TreemapReportCmd.Flag("app", "only export the treemap of this application").String()
+
+	PlanReportCmd      = ReportCmd.Command("plan", "generate a remediation plan (markdown|docx) per application from a run's findings, grouped by category and rule with effort totals and owners")
+	PlanReportRunId    = PlanReportCmd.Flag("run", "id of the run to plan. Defaults to the latest analyze run").Uint()
+	PlanReportApp      = PlanReportCmd.Flag("app", "only generate the plan of this application").String()
+	PlanReportTemplate = PlanReportCmd.Flag("template", "go text/template (producing markdown) to render each plan with. Defaults to the builtin plan template").String()
+	PlanReportFormat   = PlanReportCmd.Flag("format", "output format of the plan (md|docx)").Default("md").Enum("md", "docx")
+	PlanReportOwners   = PlanReportCmd.Flag("owners", "CODEOWNERS formatted file assigning owners to application paths. Defaults to each application's own CODEOWNERS file").String()
+
+	CoverageReportCmd       = ReportCmd.Command("coverage", "list every rule loaded by a run with its match count (including zero) and the files it matched")
+	CoverageReportRunId     = CoverageReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	CoverageReportFormat    = CoverageReportCmd.Flag("format", "output format of the report (table|csv)").Default("table").Enum("table", CSV)
+	CoverageReportUnmatched = CoverageReportCmd.Flag("unmatched-only", "only list rules that never matched").Bool()
+
+	PatchReportCmd   = ReportCmd.Command("patch", "write a unified diff patch per application applying the replacements of the rules its findings came from")
+	PatchReportRunId = PatchReportCmd.Flag("run", "id of the run to patch. Defaults to the latest analyze run").Uint()
+	PatchReportApp   = PatchReportCmd.Flag("app", "only write the patch of this application").String()
+
+	SlowRulesReportCmd    = ReportCmd.Command("slow-rules", "rank the rules of a run by the time spent evaluating them, with their match attempts and slowest pattern")
+	SlowRulesReportRunId  = SlowRulesReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	SlowRulesReportTop    = SlowRulesReportCmd.Flag("top", "number of rules to list, 0 lists them all").Default("20").Int()
+	SlowRulesReportFormat = SlowRulesReportCmd.Flag("format", "output format of the report (table|csv)").Default("table").Enum("table", CSV)
+
+	DomainReportCmd     = ReportCmd.Command("domains", "roll-up scores, effort and sloc per business domain, ranking the domains by score")
+	DomainReportRunId   = DomainReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	DomainReportMapping = DomainReportCmd.Flag("mapping", "(yaml|json) file mapping business domains to their applications. Assigns the domains of the run's applications before reporting").String()
+	DomainReportFormat  = DomainReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	TechStackReportCmd    = ReportCmd.Command("tech-stack", "list the frameworks, servers, build tools and runtimes detected in each application")
+	TechStackReportRunId  = TechStackReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	TechStackReportApp    = TechStackReportCmd.Flag("app", "only report on this application").String()
+	TechStackReportFormat = TechStackReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	TwelveFactorReportCmd    = ReportCmd.Command("twelve-factor", "evaluate each application against the twelve factors, marking each factor pass/fail/unknown with a score")
+	TwelveFactorReportRunId  = TwelveFactorReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	TwelveFactorReportApp    = TwelveFactorReportCmd.Flag("app", "only report on this application").String()
+	TwelveFactorReportFormat = TwelveFactorReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	ContainerReportCmd    = ReportCmd.Command("container", "score how container ready each application is (filesystem writes, hard-coded ports, os specific calls, native libraries, startup), apart from its cloud score")
+	ContainerReportRunId  = ContainerReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	ContainerReportApp    = ContainerReportCmd.Flag("app", "only report on this application").String()
+	ContainerReportFormat = ContainerReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	DotnetReportCmd    = ReportCmd.Command("dotnet", "list the target frameworks of each .NET application and the blockers (Web Forms, WCF, System.Web, System.Drawing, registry...) of migrating it from the .NET Framework to .NET 8")
+	DotnetReportRunId  = DotnetReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	DotnetReportApp    = DotnetReportCmd.Flag("app", "only report on this application").String()
+	DotnetReportFormat = DotnetReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	WindowsReportCmd    = ReportCmd.Command("windows", "list what ties each Java and .NET application to Windows (registry, Windows services, DCOM, MSMQ, drive letter paths, Win32 APIs...) and has to go to move it to Linux containers")
+	WindowsReportRunId  = WindowsReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	WindowsReportApp    = WindowsReportCmd.Flag("app", "only report on this application").String()
+	WindowsReportFormat = WindowsReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	DatabaseReportCmd    = ReportCmd.Command("database", "rate how tightly each application is coupled to its database (Database Coupling): the sloc and routines of its SQL/PL-SQL, vendor specific SQL, database links, UTL_FILE/xp_cmdshell usage...")
+	DatabaseReportRunId  = DatabaseReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	DatabaseReportApp    = DatabaseReportCmd.Flag("app", "only report on this application").String()
+	DatabaseReportFormat = DatabaseReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	DatabaseInventoryReportCmd    = ReportCmd.Command("database-inventory", "inventory the JDBC drivers, urls, dialects and connection strings of each application: its database vendors (Oracle, DB2, SQL Server...) and whether it uses XA, grouped by primary vendor for migration wave planning")
+	DatabaseInventoryReportRunId  = DatabaseInventoryReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	DatabaseInventoryReportApp    = DatabaseInventoryReportCmd.Flag("app", "only report on this application").String()
+	DatabaseInventoryReportFormat = DatabaseInventoryReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	KubernetesReportCmd    = ReportCmd.Command("kubernetes", "list the issues of the Kubernetes manifests and Helm charts of each application (findings tagged kubernetes): missing resource limits, hostPath volumes, privileged containers, hard-coded image registries")
+	KubernetesReportRunId  = KubernetesReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	KubernetesReportApp    = KubernetesReportCmd.Flag("app", "only report on this application").String()
+	KubernetesReportFormat = KubernetesReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	AppServerReportCmd    = ReportCmd.Command("app-server", "list the migration inventory of each application: the datasources, JNDI bindings, work managers and security realms its WebSphere, WebLogic and JBoss descriptors configure or bind, with what replaces them")
+	AppServerReportRunId  = AppServerReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	AppServerReportApp    = AppServerReportCmd.Flag("app", "only report on this application").String()
+	AppServerReportFormat = AppServerReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	ConfigReportCmd    = ReportCmd.Command("config", "list the hard-coded, environment specific values (urls, hosts, file paths, credentials) of each application's properties, yaml, descriptors and code that must be externalized before it is deployed to the cloud")
+	ConfigReportRunId  = ConfigReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	ConfigReportApp    = ConfigReportCmd.Flag("app", "only report on this application").String()
+	ConfigReportFormat = ConfigReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	PresentationReportCmd    = ReportCmd.Command("presentation", "measure the presentation tier (JSP, JSF, Tiles, Struts) of each application: its pages, scriptlets, tag libraries, session state and component libraries, and the effort of modernizing it apart from the backend's")
+	PresentationReportRunId  = PresentationReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	PresentationReportApp    = PresentationReportCmd.Flag("app", "only report on this application").String()
+	PresentationReportFormat = PresentationReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	MessagingReportCmd    = ReportCmd.Command("messaging", "inventory the messaging (JMS, IBM MQ, TIBCO, ActiveMQ, Kafka, RabbitMQ) each application uses and the queues, topics and exchanges its code and configuration name")
+	MessagingReportRunId  = MessagingReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	MessagingReportApp    = MessagingReportCmd.Flag("app", "only report on this application").String()
+	MessagingReportFormat = MessagingReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	BatchReportCmd    = ReportCmd.Command("batch", "list the batch frameworks and schedulers (Spring Batch, Quartz, EJB timers, @Scheduled, cron entries...) running each application's jobs, to re-platform on cloud native schedulers")
+	BatchReportRunId  = BatchReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	BatchReportApp    = BatchReportCmd.Flag("app", "only report on this application").String()
+	BatchReportFormat = BatchReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	NativeReportCmd    = ReportCmd.Command("native", "list the native dependencies (JNI methods, System.loadLibrary calls, bundled .so/.dll files, JNA) of each application, which block running it in a container")
+	NativeReportRunId  = NativeReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	NativeReportApp    = NativeReportCmd.Flag("app", "only report on this application").String()
+	NativeReportFormat = NativeReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	SbomReportCmd       = ReportCmd.Command("sbom", "write a CycloneDX (json) bill of materials of each application: the libraries its package manager files declare, maven ones with the versions their parent poms and BOMs resolve, and their licenses")
+	SbomReportRunId     = SbomReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	SbomReportApp       = SbomReportCmd.Flag("app", "only write the bill of materials of this application").String()
+	SbomReportLicenseDB = SbomReportCmd.Flag("license-db", "(yaml|json) offline license db mapping packages (or group ids) to licenses, for the libraries the local poms don't resolve").String()
+	SbomReportMavenRepo = SbomReportCmd.Flag("maven-repo", "local maven repository the poms (licenses) of the libraries are read from. Defaults to ~/.m2/repository").String()
+
+	EstimateReportCmd    = ReportCmd.Command("estimate", "convert the effort of each application and the portfolio into person-day (and cost) ranges")
+	EstimateReportRunId  = EstimateReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	EstimateReportApp    = EstimateReportCmd.Flag("app", "only report on this application").String()
+	EstimateReportModel  = EstimateReportCmd.Flag("model", "(yaml|json) estimation model file with the person-days an effort point takes per category and the day rate. Defaults to half an hour to an hour per point").String()
+	EstimateReportFormat = EstimateReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	DuplicatesReportCmd     = ReportCmd.Command("duplicates", "report findings occurring identically in several applications (copied libraries/utility classes) as single remediation items, with the effort rollup counting them once")
+	DuplicatesReportRunId   = DuplicatesReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	DuplicatesReportMinApps = DuplicatesReportCmd.Flag("min-apps", "number of applications a finding must occur in to be a duplicate").Default("2").Int()
+	DuplicatesReportFormat  = DuplicatesReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	DependencyReportCmd    = ReportCmd.Command("dependencies", "report the applications depending on each other through shared jars, http endpoints, queues and databases, with the migration wave of each")
+	DependencyReportRunId  = DependencyReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	DependencyReportFormat = DependencyReportCmd.Flag("format", "output format of the report (table|csv|json|dot)").Default("table").Enum("table", CSV, JSON, DOT)
+
+	SecurityReportCmd    = ReportCmd.Command("security", "report the security findings (tagged security or with cwe-<id> tags) grouped by CWE with their severity")
+	SecurityReportRunId  = SecurityReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	SecurityReportApp    = SecurityReportCmd.Flag("app", "only report on this application").String()
+	SecurityReportFormat = SecurityReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	JdkReportCmd    = ReportCmd.Command("jdk", "list the JDK APIs each application uses that the run's target JDK (--target-jdk) removed or deprecated")
+	JdkReportRunId  = JdkReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	JdkReportApp    = JdkReportCmd.Flag("app", "only report on this application").String()
+	JdkReportFormat = JdkReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	DispositionReportCmd    = ReportCmd.Command("disposition", "recommend a 6R disposition (rehost|replatform|refactor|repurchase|retire|retain) for each application from its score, tech stack, statefulness and blockers")
+	DispositionReportRunId  = DispositionReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	DispositionReportApp    = DispositionReportCmd.Flag("app", "only report on this application").String()
+	DispositionReportRules  = DispositionReportCmd.Flag("rules", "(yaml|json) file of disposition rules evaluated in order, the first holding for an application decides its disposition. Defaults to the built-in rules").String()
+	DispositionReportFormat = DispositionReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	ModuleReportCmd    = ReportCmd.Command("modules", "score each Maven/Gradle module of the multi-module applications like an application of its own")
+	ModuleReportRunId  = ModuleReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	ModuleReportApp    = ModuleReportCmd.Flag("app", "only report on the modules of this application").String()
+	ModuleReportFormat = ModuleReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	PortfolioReportCmd    = ReportCmd.Command("portfolio", "aggregate all the applications of a run: ranking by score, effort, findings and sloc totals, quartiles and findings by tag")
+	PortfolioReportRunId  = PortfolioReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	PortfolioReportTop    = PortfolioReportCmd.Flag("top", "number of tags to list, 0 lists them all").Default("20").Int()
+	PortfolioReportFormat = PortfolioReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
+	ExplainReportCmd    = ReportCmd.Command("explain", "explain the score of each application: the rules and categories contributing to it and the score points each costs, costliest first")
+	ExplainReportRunId  = ExplainReportCmd.Flag("run", "id of the run to report on. Defaults to the latest analyze run").Uint()
+	ExplainReportApp    = ExplainReportCmd.Flag("app", "only explain the score of this application").String()
+	ExplainReportTop    = ExplainReportCmd.Flag("top", "number of rules to list per application, 0 lists them all").Default("20").Int()
+	ExplainReportFormat = ExplainReportCmd.Flag("format", "output format of the report (table|csv|json)").Default("table").Enum("table", CSV, JSON)
+
 	//Naturalize Command
 	NatLangCmd      = App.Command("naturalize", "process files and create naturual lang files for each code file found")
 	NatLangPath     = NatLangCmd.Arg("path", "Path to source or jar/war/ear file").Default(".").String()
@@ -137,27 +490,7 @@ func IsAppFlagDefaulted(flag string) bool {
 }
 
 func IsCmdFlagDefaulted(cmd string, flag string) bool {
-
-	var defValues []string
-	var value string
-	var f *kingpin.FlagClause
-
-	if cmd == "" {
-		f = App.GetFlag(flag)
-	} else {
-		f = App.GetCommand(cmd).GetFlag(flag)
-	}
-
-	defValues = f.Model().Default
-	value = f.Model().Value.String()
-
-	for _, defVal := range defValues {
-		if defVal == value {
-			return true
-		}
-	}
-
-	return false
+	return App.FlagDefaulted(cmd, flag)
 }
 
 const APP_NAME string = "csa"
@@ -172,13 +505,21 @@ const POSTGRES string = "postgres"
 const DEFAULT_RULES_DIR = "./rules"
 const DEFUALT_OUTPUT_DIR = "csa-reports"
 const DEFAULT_MODELS_DIR = "./scoring-models"
+const DEFAULT_PROFILES_DIR = "./profiles"
+const DEFAULT_I18N_DIR = "./i18n"
 const RULE_BOOTSTRAP_TEMPLATE = "BootstrapRulesTemplate.txt"
 const BIN_BOOTSTRAP_TEMPLATE = "BootstrapBinsTemplate.txt"
 const SCORING_MODEL_BOOTSTRAP_TEMPLATE = "BootstrapScoringModelsTemplate.txt"
 const DEFAULT_BIN_FILE = "bins.yaml"
 const DEFAULT_LINE_BUFFER_SIZE int = 128 * 1024
 const MAX_LINE_BUFFER_SIZE int = 4096 * 1024
+const MAX_CONTEXT_LINE_LEN int = 256
 const DEFAULT_MAX_POSTGRES_WORKERS = 10
+const DEFAULT_FINDINGS_PER_WORKER = 1000
+const DEFAULT_BATCH_SIZE = 500
+const DEFAULT_PAGER = "less -RS"
+const GATE_FAILED_EXIT_CODE = 3
+const ELLIPSIS = "..."
 
 //CMDS
 const ANALYZE_CMD string = "analyze"
@@ -188,3 +529,6 @@ const EXCLUDED_DIRS_FLAG string = "excluded-dirs"
 const INCLUDED_FILES string = "included-files"
 const EXCLUDED_FILES string = "excluded-files"
 const SCORING_MODEL_FLAG string = "scoring-model"
+const SCORER_FLAG string = "scorer"
+const SCORING_FORMULA_FLAG string = "scoring-formula"
+const NORMALIZE_FLAG string = "normalize"
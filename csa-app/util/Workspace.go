@@ -0,0 +1,229 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+const WORKSPACE_PREFIX = "csa-ws-"
+const WORKSPACE_OWNER_FILE = ".csa-owner"
+
+var workspaceNameRegex = regexp.MustCompile(`[^A-Za-z0-9_.]+`)
+
+//Workspaces touch their owner file this often. One that hasn't been touched for WORKSPACE_STALE_AFTER belongs to a
+//csa process that crashed or was killed and is reclaimed by the next csa to start.
+const WORKSPACE_HEARTBEAT = time.Minute
+const WORKSPACE_STALE_AFTER = 10 * WORKSPACE_HEARTBEAT
+
+//RunWorkspace is the workspace of the current run. Archive extraction and report staging happen beneath it.
+var RunWorkspace *Workspace
+
+//Workspace is a private, size-limited scratch directory. Every csa process gets its own so concurrent runs on a shared
+//server never step on each other's files.
+type Workspace struct {
+	Root     string
+	maxBytes int64
+	done     chan struct{}
+	once     sync.Once
+}
+
+//NewWorkspace creates a uniquely named workspace beneath base (the OS temp dir when empty), falling back to the csa
+//executable's directory and then the user's home. Stale workspaces left behind by crashed runs are reclaimed first.
+func NewWorkspace(base string, name string, maxBytes int64) (ws *Workspace, err error) {
+
+	var candidates []string
+	if base != "" {
+		candidates = append(candidates, base)
+	} else {
+		candidates = append(candidates, os.TempDir())
+	}
+
+	if exePath, exeErr := os.Executable(); exeErr == nil {
+		candidates = append(candidates, filepath.Dir(exePath))
+	}
+
+	if homepath, homeErr := homedir.Dir(); homeErr == nil {
+		candidates = append(candidates, homepath)
+	}
+
+	for _, candidate := range candidates {
+		if _, err = CreateDirIfNotExist(candidate); err != nil {
+			continue
+		}
+
+		ReclaimStaleWorkspaces(candidate)
+
+		var root string
+		root, err = ioutil.TempDir(candidate, WORKSPACE_PREFIX+workspaceNameRegex.ReplaceAllString(name, "-")+"-")
+		if err != nil {
+			continue
+		}
+
+		ws = &Workspace{Root: root, maxBytes: maxBytes, done: make(chan struct{})}
+		if err = ws.touch(); err != nil {
+			_ = os.RemoveAll(root)
+			continue
+		}
+
+		go ws.heartbeat()
+		return ws, nil
+	}
+
+	return nil, fmt.Errorf("unable to create a workspace in any of [%s]: %v", strings.Join(candidates, ", "), err)
+}
+
+//Dir returns (creating if necessary) a named sub-directory of the workspace
+func (ws *Workspace) Dir(name string) (string, error) {
+	path := filepath.Join(ws.Root, name)
+	return path, os.MkdirAll(path, 0755)
+}
+
+//Usage is the number of bytes currently held in the workspace
+func (ws *Workspace) Usage() (size int64) {
+	_ = filepath.Walk(ws.Root, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return
+}
+
+//CheckLimit fails once the workspace holds more than its limit. Callers check after each step that writes to it.
+func (ws *Workspace) CheckLimit() error {
+	if ws.maxBytes <= 0 {
+		return nil
+	}
+
+	if usage := ws.Usage(); usage > ws.maxBytes {
+		return fmt.Errorf("workspace [%s] holds %d MB which exceeds its %d MB limit (see --temp-dir-limit)", ws.Root, usage>>20, ws.maxBytes>>20)
+	}
+
+	return nil
+}
+
+//Stage writes a file inside the workspace and only moves it to dest once complete, so readers never see partial files
+func (ws *Workspace) Stage(dest string, write func(out io.Writer) error) error {
+
+	dir, err := ws.Dir("staging")
+	if err != nil {
+		return err
+	}
+
+	staged, err := ioutil.TempFile(dir, filepath.Base(dest)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(staged.Name())
+
+	err = write(staged)
+	if closeErr := staged.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = ws.CheckLimit()
+	}
+	if err != nil {
+		return err
+	}
+
+	//Rename fails across file systems so fall back to copying
+	if os.Rename(staged.Name(), dest) == nil {
+		return nil
+	}
+
+	return copyFile(staged.Name(), dest)
+}
+
+//Close stops the heartbeat and removes the workspace along with everything in it
+func (ws *Workspace) Close() (err error) {
+	ws.once.Do(func() {
+		close(ws.done)
+		err = os.RemoveAll(ws.Root)
+	})
+	return
+}
+
+//ReclaimStaleWorkspaces removes workspaces beneath base whose owner stopped updating its heartbeat
+func ReclaimStaleWorkspaces(base string) (reclaimed []string) {
+
+	entries, err := ioutil.ReadDir(base)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), WORKSPACE_PREFIX) {
+			continue
+		}
+
+		root := filepath.Join(base, entry.Name())
+		owner, err := os.Stat(filepath.Join(root, WORKSPACE_OWNER_FILE))
+		if err != nil || time.Since(owner.ModTime()) < WORKSPACE_STALE_AFTER {
+			continue
+		}
+
+		if os.RemoveAll(root) == nil {
+			reclaimed = append(reclaimed, root)
+			if *Verbose {
+				fmt.Printf("Reclaimed stale workspace [%s]\n", root)
+			}
+		}
+	}
+
+	return
+}
+
+func (ws *Workspace) touch() error {
+	host, _ := os.Hostname()
+	return ioutil.WriteFile(filepath.Join(ws.Root, WORKSPACE_OWNER_FILE),
+		[]byte(fmt.Sprintf("pid: %d\nhost: %s\nheartbeat: %s\n", os.Getpid(), host, time.Now().Format(time.RFC3339))), 0644)
+}
+
+func (ws *Workspace) heartbeat() {
+	ticker := time.NewTicker(WORKSPACE_HEARTBEAT)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ws.done:
+			return
+		case <-ticker.C:
+			_ = ws.touch()
+		}
+	}
+}
+
+func copyFile(src string, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
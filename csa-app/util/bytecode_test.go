@@ -0,0 +1,215 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+//classBuilder writes class files the way javac would, for the parts csa reads
+type classBuilder struct {
+	pool  bytes.Buffer
+	count uint16
+	utf8s map[string]uint16
+}
+
+func newClassBuilder() *classBuilder {
+	return &classBuilder{count: 1, utf8s: make(map[string]uint16)}
+}
+
+func (b *classBuilder) entry(tag byte, values ...uint16) uint16 {
+	b.pool.WriteByte(tag)
+	for _, value := range values {
+		_ = binary.Write(&b.pool, binary.BigEndian, value)
+	}
+	b.count++
+	return b.count - 1
+}
+
+func (b *classBuilder) utf8(s string) uint16 {
+	if index, found := b.utf8s[s]; found {
+		return index
+	}
+	b.pool.WriteByte(1)
+	_ = binary.Write(&b.pool, binary.BigEndian, uint16(len(s)))
+	b.pool.WriteString(s)
+	b.count++
+	b.utf8s[s] = b.count - 1
+	return b.count - 1
+}
+
+func (b *classBuilder) class(name string) uint16 {
+	return b.entry(7, b.utf8(name))
+}
+
+func (b *classBuilder) methodref(class string, name string, descriptor string) uint16 {
+	return b.entry(10, b.class(class), b.entry(12, b.utf8(name), b.utf8(descriptor)))
+}
+
+//annotations is a RuntimeVisibleAnnotations attribute of annotations of string elements
+func (b *classBuilder) annotations(annotations ...[]string) []byte {
+	var info bytes.Buffer
+	_ = binary.Write(&info, binary.BigEndian, uint16(len(annotations)))
+	for _, annotation := range annotations {
+		_ = binary.Write(&info, binary.BigEndian, []uint16{b.utf8(annotation[0]), uint16(len(annotation) / 2)})
+		for i := 1; i+1 < len(annotation); i += 2 {
+			_ = binary.Write(&info, binary.BigEndian, b.utf8(annotation[i]))
+			info.WriteByte('s')
+			_ = binary.Write(&info, binary.BigEndian, b.utf8(annotation[i+1]))
+		}
+	}
+	return b.attribute("RuntimeVisibleAnnotations", info.Bytes())
+}
+
+func (b *classBuilder) attribute(name string, info []byte) []byte {
+	var attribute bytes.Buffer
+	_ = binary.Write(&attribute, binary.BigEndian, b.utf8(name))
+	_ = binary.Write(&attribute, binary.BigEndian, uint32(len(info)))
+	attribute.Write(info)
+	return attribute.Bytes()
+}
+
+//orderBean is com/shop/OrderBean: a @Stateless session bean with a @Resource DataSource, looking up the jndi
+func orderBean() []byte {
+
+	b := newClassBuilder()
+	this := b.class("com/shop/OrderBean")
+	super := b.class("java/lang/Object")
+	session := b.class("javax/ejb/SessionBean")
+	b.methodref("javax/naming/InitialContext", "lookup", "(Ljava/lang/String;)Ljava/lang/Object;")
+	b.methodref("com/shop/Order", "<init>", "()V")
+	b.class("[Lorg/apache/log4j/Logger;")
+
+	field := b.annotations([]string{"Ljavax/annotation/Resource;", "lookup", "jdbc/orders"})
+	bean := b.annotations([]string{"Ljavax/ejb/Stateless;", "name", "orders"}, []string{"Ljavax/ejb/Remote;"})
+	var sourceFile bytes.Buffer
+	_ = binary.Write(&sourceFile, binary.BigEndian, b.utf8("OrderBean.java"))
+	source := b.attribute("SourceFile", sourceFile.Bytes())
+	fieldName, fieldType := b.utf8("dataSource"), b.utf8("Ljavax/sql/DataSource;")
+
+	var class bytes.Buffer
+	_ = binary.Write(&class, binary.BigEndian, []uint32{0xCAFEBABE, 52})
+	_ = binary.Write(&class, binary.BigEndian, b.count)
+	class.Write(b.pool.Bytes())
+	_ = binary.Write(&class, binary.BigEndian, []uint16{0x0021, this, super, 1, session})
+
+	//a field and no methods
+	_ = binary.Write(&class, binary.BigEndian, []uint16{1, 0x0002, fieldName, fieldType, 1})
+	class.Write(field)
+	_ = binary.Write(&class, binary.BigEndian, uint16(0))
+
+	_ = binary.Write(&class, binary.BigEndian, uint16(2))
+	class.Write(bean)
+	class.Write(source)
+
+	return class.Bytes()
+}
+
+func TestParseClassFile(t *testing.T) {
+
+	class, err := util.ParseClassFile(orderBean())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "com/shop/OrderBean", class.Name)
+	assert.Equal(t, "java/lang/Object", class.Super)
+	assert.Equal(t, []string{"javax/ejb/SessionBean"}, class.Interfaces)
+	assert.Equal(t, []string{`@Stateless(name = "orders")`, "@Remote"}, class.Annotations)
+	assert.Equal(t, []string{`@Resource(lookup = "jdbc/orders")`}, class.MemberAnnotations)
+	assert.Equal(t, []string{"com/shop/Order", "java/lang/Object", "java/lang/String", "javax/annotation/Resource", "javax/ejb/Remote",
+		"javax/ejb/SessionBean", "javax/ejb/Stateless", "javax/naming/InitialContext", "javax/sql/DataSource", "org/apache/log4j/Logger"},
+		class.References, "classes of the constant pool, descriptors and annotations")
+
+	_, err = util.ParseClassFile([]byte("PK\x03\x04"))
+	assert.Error(t, err)
+	_, err = util.ParseClassFile(orderBean()[:40])
+	assert.Error(t, err, "truncated class files aren't parsed")
+}
+
+func TestBytecodeSource(t *testing.T) {
+
+	class, err := util.ParseClassFile(orderBean())
+	assert.NoError(t, err)
+	inner := &util.ClassFile{Name: "com/shop/OrderBean$Cache", Super: "java/lang/Object", References: []string{"net/sf/ehcache/Cache"},
+		MemberAnnotations: []string{"@PostConstruct"}}
+
+	assert.Equal(t, `//Generated by csa from the bytecode of com/shop/OrderBean.class, its source isn't available
+package com.shop;
+
+import javax.annotation.Resource;
+import javax.ejb.Remote;
+import javax.ejb.SessionBean;
+import javax.ejb.Stateless;
+import javax.naming.InitialContext;
+import javax.sql.DataSource;
+import net.sf.ehcache.Cache;
+import org.apache.log4j.Logger;
+
+@Stateless(name = "orders")
+@Remote
+public class OrderBean implements SessionBean {
+    @Resource(lookup = "jdbc/orders")
+    @PostConstruct
+}
+`, util.BytecodeSource([]*util.ClassFile{inner, class}))
+
+	assert.Equal(t, `//Generated by csa from the bytecode of com/shop/package-info.class, its source isn't available
+@XmlSchema(namespace = "urn:orders")
+package com.shop;
+
+import javax.xml.bind.annotation.XmlSchema;
+`, util.BytecodeSource([]*util.ClassFile{{Name: "com/shop/package-info", Annotations: []string{`@XmlSchema(namespace = "urn:orders")`},
+		References: []string{"javax/xml/bind/annotation/XmlSchema"}}}))
+}
+
+func zipOf(t *testing.T, entries map[string][]byte) []byte {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, data := range entries {
+		entry, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = entry.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+	return archive.Bytes()
+}
+
+func TestUnpackArchive(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "bytecode")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	jar := zipOf(t, map[string][]byte{"com/shop/OrderBean.class": orderBean(), "META-INF/ejb-jar.xml": []byte("<ejb-jar/>")})
+	war := zipOf(t, map[string][]byte{"WEB-INF/web.xml": []byte("<web-app/>"), "WEB-INF/lib/orders-ejb.jar": jar,
+		"WEB-INF/classes/com/shop/Broken.class": []byte("not a class")})
+	ear := filepath.Join(dir, "shop.ear")
+	assert.NoError(t, ioutil.WriteFile(ear, zipOf(t, map[string][]byte{"shop-web.war": war, "META-INF/application.xml": []byte("<application/>")}), 0644))
+
+	out := filepath.Join(dir, "out")
+	fileUtil := &util.FileUtil{}
+	fileUtil.UnpackArchive(util.FileInfo{FQN: ear, Name: "shop.ear"}, out)
+
+	assert.FileExists(t, filepath.Join(out, "shop", "META-INF", "application.xml"))
+	assert.FileExists(t, filepath.Join(out, "shop", "shop-web", "WEB-INF", "web.xml"), "nested archives are unpacked")
+	assert.FileExists(t, filepath.Join(out, "shop", "shop-web", "WEB-INF", "lib", "orders-ejb", "META-INF", "ejb-jar.xml"))
+
+	source, err := ioutil.ReadFile(filepath.Join(out, "shop", "shop-web", "WEB-INF", "lib", "orders-ejb", "com", "shop", "OrderBean.java"))
+	assert.NoError(t, err, "classes are analyzed as the java source of their bytecode")
+	assert.Contains(t, string(source), "import javax.ejb.Stateless;")
+
+	assert.NoFileExists(t, filepath.Join(out, "shop", "shop-web", "WEB-INF", "lib", "orders-ejb", "com", "shop", "OrderBean.class"))
+	assert.NoFileExists(t, filepath.Join(out, "shop", "shop-web", "WEB-INF", "classes", "com", "shop", "Broken.java"), "classes that can't be parsed are skipped")
+}
@@ -0,0 +1,87 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkReaderReadsSmallFilesWhole(t *testing.T) {
+	reader := util.NewChunkReader(strings.NewReader("one\ntwo\r\nthree"), 16, 1024, 64)
+
+	chunk := reader.Next()
+	assert.True(t, chunk.Whole())
+	assert.Equal(t, []string{"one", "two", "three"}, chunk.Lines)
+	assert.Equal(t, "one\ntwo\nthree\n", chunk.Text())
+	assert.Equal(t, [2]int{0, 3}, chunk.Owned)
+	assert.Nil(t, reader.Next())
+	assert.NoError(t, reader.Err())
+}
+
+func TestChunkReaderOverlapsChunks(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %03d", i)) //9 bytes with its new line
+	}
+
+	reader := util.NewChunkReader(strings.NewReader(strings.Join(lines, "\n")), 16, 180, 27)
+
+	owners := make(map[int]int)
+	var chunks []*util.LineChunk
+	for chunk := reader.Next(); chunk != nil; chunk = reader.Next() {
+		chunks = append(chunks, chunk)
+		for i, line := range chunk.Lines {
+			assert.Equal(t, lines[chunk.FirstLine+i-1], line, "lines keep their numbers")
+		}
+		for line := chunk.FirstLine; line < chunk.FirstLine+len(chunk.Lines); line++ {
+			if chunk.Owns(line) {
+				owners[line]++
+			}
+		}
+	}
+
+	assert.Equal(t, 100, len(owners))
+	for line, owned := range owners {
+		assert.Equal(t, 1, owned, "line %d is owned by a single chunk", line)
+	}
+
+	assert.True(t, len(chunks) > 1)
+	assert.False(t, chunks[0].Whole())
+	assert.True(t, chunks[len(chunks)-1].Final)
+	for _, chunk := range chunks[1:] {
+		assert.Equal(t, 3, chunk.Owned[0], "chunks start with the overlap of the lines before")
+	}
+	for _, chunk := range chunks[:len(chunks)-1] {
+		assert.Equal(t, 3, len(chunk.Lines)-chunk.Owned[1], "chunks end with the overlap of the lines after")
+		assert.True(t, len(chunk.Text()) <= 180+27)
+	}
+}
+
+func TestChunkReaderMovesPastLinesLongerThanChunks(t *testing.T) {
+	long := strings.Repeat("x", 150)
+	reader := util.NewChunkReader(strings.NewReader("first\n"+long+"\nlast"), 16, 100, 20)
+
+	var owned []string
+	var chunk *util.LineChunk
+	for i := 0; i < 10; i++ {
+		if chunk = reader.Next(); chunk == nil || chunk.Final {
+			break
+		}
+		owned = append(owned, chunk.Lines[chunk.Owned[0]:chunk.Owned[1]]...)
+	}
+
+	if assert.NotNil(t, chunk) && assert.True(t, chunk.Final, "every chunk moves forward") {
+		owned = append(owned, chunk.Lines[chunk.Owned[0]:chunk.Owned[1]]...)
+	}
+	assert.Equal(t, []string{"first", long, "last"}, owned)
+	assert.Nil(t, reader.Next())
+	assert.NoError(t, reader.Err())
+}
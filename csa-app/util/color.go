@@ -0,0 +1,44 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"os"
+)
+
+const ANSI_RESET = "\033[0m"
+const ANSI_BOLD = "\033[1m"
+const ANSI_DIM = "\033[2m"
+const ANSI_RED = "\033[31m"
+const ANSI_BOLD_RED = "\033[1;31m"
+const ANSI_YELLOW = "\033[33m"
+const ANSI_CYAN = "\033[36m"
+
+//StdoutIsTerminal is true when std out is a terminal rather than a file or pipe
+func StdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+//ColorEnabled decides if output written to std out should be colored. Color is dropped for --no-color,
+//the NO_COLOR convention, dumb terminals and whenever std out is redirected.
+func ColorEnabled() bool {
+	if *NoColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return StdoutIsTerminal()
+}
+
+//Colorize wraps value in the ansi color. An empty color leaves value untouched.
+func Colorize(value string, color string) string {
+	if color == "" || value == "" {
+		return value
+	}
+	return color + value + ANSI_RESET
+}
@@ -0,0 +1,17 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"os"
+)
+
+//PrintCompletionScript writes the (bash|zsh) completion script for every csa command and flag to std out and exits.
+//Completions themselves are served by the hidden __complete command the script calls back into.
+func PrintCompletionScript(shell string) {
+	App.FatalIfError(App.CompletionScript(shell, os.Stdout), "unable to generate %s completion script", shell)
+	os.Exit(0)
+}
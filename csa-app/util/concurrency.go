@@ -8,6 +8,7 @@ package util
 import (
 	"fmt"
 	"runtime"
+	"sync"
 )
 
 type Worker func(id string, in <-chan interface{}, out chan<- interface{}, jointWorker chan<- interface{}, args []interface{})
@@ -48,3 +49,71 @@ func StartWorkers(w Worker, workername string, amountOfWork int, workLimitFactor
 func StartSingleTonWorker(w Worker, workername string, amountOfWork int, in <-chan interface{}, out chan<- interface{}, jointWorker chan<- interface{}, args ...interface{}) int {
 	return StartWorkers(w, workername, amountOfWork, amountOfWork, 1, in, out, jointWorker, args...)
 }
+
+//WorkerPool runs submitted tasks on a fixed number of workers. Tasks queue up to the pool's queue size, after which
+//Submit blocks until a worker is free, holding back whoever is producing the work.
+type WorkerPool struct {
+	tasks     chan func()
+	waitGroup sync.WaitGroup
+}
+
+//NewWorkerPool starts a pool of workers (the processor count when workers <= 0) queueing up to queue tasks
+func NewWorkerPool(workers int, queue int) *WorkerPool {
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	if queue < 0 {
+		queue = 0
+	}
+
+	pool := &WorkerPool{tasks: make(chan func(), queue)}
+	pool.waitGroup.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer pool.waitGroup.Done()
+			for task := range pool.tasks {
+				task()
+			}
+		}()
+	}
+
+	return pool
+}
+
+//Submit queues the task, blocking while the queue is full
+func (pool *WorkerPool) Submit(task func()) {
+	pool.tasks <- task
+}
+
+//Close stops accepting tasks and waits for the queued ones to complete
+func (pool *WorkerPool) Close() {
+	close(pool.tasks)
+	pool.waitGroup.Wait()
+}
+
+//AnalysisWorkers is the number of files analyzed at once, --workers or the processor count
+func AnalysisWorkers() int {
+	if *Workers > 0 {
+		return *Workers
+	}
+	return runtime.NumCPU()
+}
+
+//FindingQueueSize is how many findings may wait to be saved, --max-buffer or DEFAULT_FINDINGS_PER_WORKER per
+//analysis worker. Workers block once it is full so findings can't pile up in memory faster than they are saved
+func FindingQueueSize() int {
+	if *MaxBuffer > 0 {
+		return *MaxBuffer
+	}
+	return AnalysisWorkers() * DEFAULT_FINDINGS_PER_WORKER
+}
+
+//SaveBatchSize is how many rows are saved at once, --batch-size or DEFAULT_BATCH_SIZE
+func SaveBatchSize() int {
+	if *BatchSize > 0 {
+		return *BatchSize
+	}
+	return DEFAULT_BATCH_SIZE
+}
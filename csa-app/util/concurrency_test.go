@@ -0,0 +1,56 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolBoundsConcurrency(t *testing.T) {
+	var running, maxRunning, done int32
+	release := make(chan struct{})
+
+	pool := util.NewWorkerPool(2, 1)
+	go func() {
+		for i := 0; i < 3; i++ {
+			<-release
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		pool.Submit(func() {
+			now := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if now <= max || atomic.CompareAndSwapInt32(&maxRunning, max, now) {
+					break
+				}
+			}
+			release <- struct{}{}
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&done, 1)
+		})
+	}
+	pool.Close()
+
+	assert.Equal(t, int32(3), done, "close waits for the queued tasks")
+	assert.LessOrEqual(t, maxRunning, int32(2))
+}
+
+func TestWorkerPoolDefaultsToProcessorCount(t *testing.T) {
+	var done int32
+	pool := util.NewWorkerPool(0, 0)
+	for i := 0; i < 10; i++ {
+		pool.Submit(func() { atomic.AddInt32(&done, 1) })
+	}
+	pool.Close()
+
+	assert.Equal(t, int32(10), done)
+}
@@ -0,0 +1,67 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileInfoContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "context")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "Orders.java")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("one\r\ntwo\nthree\nfour\nfive\n"), 0644))
+
+	file := &util.FileInfo{FQN: path, Name: "Orders.java"}
+	defer file.ReleaseContext()
+
+	assert.Equal(t, " 2: two\n>3: three\n 4: four", file.Context(3, 1))
+	assert.Equal(t, ">1: one\n 2: two", file.Context(1, 1), "context is clipped at the start of the file")
+	assert.Equal(t, " 4: four\n>5: five", file.Context(5, 1), "context is clipped at the end of the file")
+	assert.Empty(t, file.Context(3, 0), "a window of 0 disables context")
+	assert.Empty(t, file.Context(0, 2), "findings without a line have no context")
+
+	//lines are cached until released
+	assert.NoError(t, ioutil.WriteFile(path, []byte("changed\n"), 0644))
+	assert.Equal(t, ">1: one\n 2: two", file.Context(1, 1))
+	file.ReleaseContext()
+	assert.Equal(t, ">1: changed", file.Context(1, 1))
+}
+
+func TestFileInfoContextFromChunk(t *testing.T) {
+	file := &util.FileInfo{FQN: filepath.Join(os.TempDir(), "missing", "Orders.java"), Name: "Orders.java"}
+	defer file.ReleaseContext()
+
+	file.SetContextLines(10, []string{"ten", "eleven", "twelve"})
+
+	assert.Equal(t, " 10: ten\n>11: eleven\n 12: twelve", file.Context(11, 1))
+	assert.Equal(t, ">10: ten\n 11: eleven", file.Context(10, 1), "context is clipped at the start of the chunk")
+	assert.Equal(t, " 11: eleven\n>12: twelve", file.Context(12, 1), "context is clipped at the end of the chunk")
+}
+
+func TestFileInfoContextTruncatesLongLinesByCharacters(t *testing.T) {
+	file := &util.FileInfo{FQN: filepath.Join(os.TempDir(), "missing", "messages_de.properties"), Name: "messages_de.properties"}
+	defer file.ReleaseContext()
+
+	short := strings.Repeat("ä", 200)
+	long := strings.Repeat("ä", 300)
+	file.SetContextLines(1, []string{short, long})
+
+	context := file.Context(2, 1)
+	assert.True(t, utf8.ValidString(context), "no character is split")
+	assert.Equal(t, " 1: "+short+"\n>2: "+strings.Repeat("ä", util.MAX_CONTEXT_LINE_LEN)+util.ELLIPSIS, context,
+		"lines are cut by characters, so 200 of them (400 bytes) are kept whole")
+}
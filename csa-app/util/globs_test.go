@@ -0,0 +1,69 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathFilterExcludes(t *testing.T) {
+	filter, err := util.NewPathFilter("/apps/shop", nil, []string{"node_modules", "target/", "/src/test/**", "**/generated/*.java", "*.min.js"})
+	assert.NoError(t, err)
+
+	assert.True(t, filter.Excluded("/apps/shop/web/node_modules", true), "names match at any depth")
+	assert.True(t, filter.Excluded("/apps/shop/target", true))
+	assert.False(t, filter.Excluded("/apps/shop/src/target", false), "target/ matches directories only")
+	assert.True(t, filter.Excluded("/apps/shop/src/test/java/ShopTest.java", false))
+	assert.False(t, filter.Excluded("/apps/shop/lib/src/test/Test.java", false), "globs with a / are relative to the root")
+	assert.True(t, filter.Excluded("/apps/shop/src/main/generated/Client.java", false))
+	assert.False(t, filter.Excluded("/apps/shop/src/main/generated/sub/Client.java", false), "* doesn't match a /")
+	assert.True(t, filter.Excluded("/apps/shop/web/static/app.min.js", false))
+	assert.False(t, filter.Excluded("/apps/shop/web/static/app.js", false))
+	assert.False(t, filter.Excluded("/apps/other/target", true), "outside the root")
+
+	_, err = util.NewPathFilter("/apps/shop", nil, []string{"/"})
+	assert.Error(t, err)
+}
+
+func TestPathFilterIncludes(t *testing.T) {
+	filter, err := util.NewPathFilter("/apps/shop", []string{"src/main/**", "*.xml"}, []string{"**/*Mock*"})
+	assert.NoError(t, err)
+
+	assert.False(t, filter.Excluded("/apps/shop/src/main/java/Shop.java", false))
+	assert.False(t, filter.Excluded("/apps/shop/pom.xml", false))
+	assert.True(t, filter.Excluded("/apps/shop/README.md", false))
+	assert.False(t, filter.Excluded("/apps/shop/docs", true), "includes don't skip directories")
+	assert.True(t, filter.Excluded("/apps/shop/src/main/java/ShopMock.java", false), "excludes take precedence")
+}
+
+func TestPathFilterIgnoreFiles(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "csa-ignore")
+	defer os.RemoveAll(dir)
+
+	_ = os.MkdirAll(filepath.Join(dir, "web"), 0755)
+	_ = ioutil.WriteFile(filepath.Join(dir, util.CSA_IGNORE_FILENAME), []byte("# test data\ntestdata/\n*.sql\n"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(dir, "web", util.CSA_IGNORE_FILENAME), []byte("!schema.sql\n/dist\n"), 0644)
+
+	filter, err := util.NewPathFilter(dir, nil, []string{"!seed.sql"})
+	assert.NoError(t, err)
+	assert.NoError(t, filter.AddIgnoreFile(dir))
+	assert.NoError(t, filter.AddIgnoreFile(filepath.Join(dir, "web")))
+	assert.NoError(t, filter.AddIgnoreFile(filepath.Join(dir, "missing")))
+
+	assert.True(t, filter.Excluded(filepath.Join(dir, "api", "testdata"), true))
+	assert.True(t, filter.Excluded(filepath.Join(dir, "db", "drop.sql"), false))
+	assert.False(t, filter.Excluded(filepath.Join(dir, "web", "schema.sql"), false), "the last glob matching decides")
+	assert.True(t, filter.Excluded(filepath.Join(dir, "db", "schema.sql"), false), "a .csaignore applies beneath its directory")
+	assert.True(t, filter.Excluded(filepath.Join(dir, "web", "dist"), true))
+	assert.False(t, filter.Excluded(filepath.Join(dir, "dist"), true))
+	assert.False(t, filter.Excluded(filepath.Join(dir, "db", "seed.sql"), false), "the flags take precedence over .csaignore files")
+}
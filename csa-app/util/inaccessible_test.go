@@ -0,0 +1,53 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInaccessibleReason(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inaccessible")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	writeZip := func(name string, flags uint16) string {
+		path := filepath.Join(dir, name)
+		out, err := os.Create(path)
+		assert.NoError(t, err)
+		archive := zip.NewWriter(out)
+		entry, err := archive.CreateHeader(&zip.FileHeader{Name: "Main.class", Method: zip.Store, Flags: flags})
+		assert.NoError(t, err)
+		_, _ = entry.Write([]byte("class"))
+		assert.NoError(t, archive.Close())
+		assert.NoError(t, out.Close())
+		return path
+	}
+
+	assert.Equal(t, "", util.InaccessibleReason(write("Main.java", "public class Main {}")))
+	assert.Equal(t, "", util.InaccessibleReason(writeZip("plain.jar", 0)))
+	assert.Equal(t, util.ENCRYPTED_ARCHIVE, util.InaccessibleReason(writeZip("secret.jar", 0x1)))
+	assert.Equal(t, util.ENCRYPTED_VAULT, util.InaccessibleReason(write("secrets.yml", "$ANSIBLE_VAULT;1.1;AES256\n6231")))
+	assert.Equal(t, util.ENCRYPTED_PGP, util.InaccessibleReason(write("db.properties.asc", "-----BEGIN PGP MESSAGE-----\n\nhQEMA")))
+	assert.Equal(t, util.ENCRYPTED_PDF, util.InaccessibleReason(write("design.pdf", "%PDF-1.7\n...\ntrailer << /Root 1 0 R /Encrypt 5 0 R >>\n%%EOF")))
+	assert.Equal(t, "", util.InaccessibleReason(write("open.pdf", "%PDF-1.7\n...\ntrailer << /Root 1 0 R >>\n%%EOF")))
+	assert.Equal(t, util.ENCRYPTED_OFFICE, util.InaccessibleReason(write("budget.xlsx", "\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1rest")))
+	assert.Equal(t, "", util.InaccessibleReason(write("legacy.xls", "\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1rest")))
+	assert.Equal(t, "", util.InaccessibleReason(filepath.Join(dir, "missing.txt")))
+}
@@ -58,6 +58,8 @@ func (ls Languages) Less(i, j int) bool {
 
 var reShebangEnv = regexp.MustCompile("^#! *(\\S+/env) ([a-zA-Z]+)")
 var reShebangLang = regexp.MustCompile("^#! *[.a-zA-Z/]+/([a-zA-Z]+)")
+var cobolSequenceNumber = regexp.MustCompile(`^[0-9]{6}`)
+var generatedScript = regexp.MustCompile(`[.-](min|bundle|chunk)\.[cm]?js$`)
 
 var Exts = map[string]string{
 	"as":          "ActionScript",
@@ -75,9 +77,15 @@ var Exts = map[string]string{
 	"btm":         "Batch",
 	"bb":          "BitBake",
 	"cbl":         "COBOL",
+	"CBL":         "COBOL",
+	"cob":         "COBOL",
+	"COB":         "COBOL",
+	"cpy":         "COBOL",
+	"CPY":         "COBOL",
 	"cmd":         "Batch",
 	"bash":        "BASH",
 	"sh":          "Bourne Shell",
+	"ksh":         "Bourne Shell",
 	"c":           "C",
 	"carp":        "Carp",
 	"csh":         "C Shell",
@@ -145,6 +153,7 @@ var Exts = map[string]string{
 	"gradle":      "Groovy",
 	"h":           "C Header",
 	"hs":          "Haskell",
+	"hcl":         "HCL",
 	"hpp":         "C++ Header",
 	"hh":          "C++ Header",
 	"html":        "HTML",
@@ -155,12 +164,22 @@ var Exts = map[string]string{
 	"io":          "Io",
 	"ipynb":       "Jupyter Notebook",
 	"jai":         "JAI",
+	"jcl":         "JCL",
+	"JCL":         "JCL",
 	"java":        "Java",
 	"js":          "JavaScript",
+	"mjs":         "JavaScript",
+	"cjs":         "JavaScript",
 	"jl":          "Julia",
 	"json":        "JSON",
+	"jsp":         "JSP",
+	"jspf":        "JSP",
+	"jspx":        "JSP",
+	"tag":         "JSP",
+	"tagx":        "JSP",
 	"jsx":         "JSX",
 	"kt":          "Kotlin",
+	"kts":         "Kotlin",
 	"lds":         "LD Script",
 	"less":        "LESS",
 	"Objective-C": "Objective-C", // deplicated Obj-C/Matlab/Mercury
@@ -185,6 +204,9 @@ var Exts = map[string]string{
 	"l":           "lex",
 	"nim":         "Nim",
 	"php":         "PHP",
+	"phtml":       "PHP",
+	"php5":        "PHP",
+	"php7":        "PHP",
 	"pas":         "Pascal",
 	"PL":          "Perl",
 	"pl":          "Perl",
@@ -193,11 +215,14 @@ var Exts = map[string]string{
 	"pony":        "Pony",
 	"properties":  "Properties File",
 	"ps1":         "PowerShell",
+	"psm1":        "PowerShell",
 	"text":        "Plain Text",
 	"txt":         "Plain Text",
 	"polly":       "Polly",
 	"proto":       "Protocol Buffers",
 	"py":          "Python",
+	"pyw":         "Python",
+	"pyi":         "Python",
 	"pxd":         "Cython",
 	"pyx":         "Cython",
 	"r":           "R",
@@ -208,8 +233,10 @@ var Exts = map[string]string{
 	"Rmd":         "RMarkdown",
 	"rake":        "Ruby",
 	"rb":          "Ruby",
+	"gemspec":     "Ruby",
 	"rkt":         "Racket",
 	"rhtml":       "Ruby HTML",
+	"erb":         "Ruby HTML",
 	"rs":          "Rust",
 	"rst":         "ReStructuredText",
 	"sass":        "Sass",
@@ -221,6 +248,14 @@ var Exts = map[string]string{
 	"sml":         "Standard ML",
 	"sol":         "Solidity",
 	"sql":         "SQL",
+	"pks":         "PLSQL",
+	"pkb":         "PLSQL",
+	"pck":         "PLSQL",
+	"prc":         "PLSQL",
+	"fnc":         "PLSQL",
+	"trg":         "PLSQL",
+	"pls":         "PLSQL",
+	"plb":         "PLSQL",
 	"swift":       "Swift",
 	"t":           "Terra",
 	"tex":         "TeX",
@@ -228,8 +263,13 @@ var Exts = map[string]string{
 	"tla":         "TLA",
 	"sty":         "TeX",
 	"tcl":         "Tcl/Tk",
+	"tf":          "HCL",
+	"tfvars":      "HCL",
 	"toml":        "TOML",
 	"ts":          "TypeScript",
+	"tsx":         "TypeScript",
+	"mts":         "TypeScript",
+	"cts":         "TypeScript",
 	"mat":         "Unity-Prefab",
 	"prefab":      "Unity-Prefab",
 	"Coq":         "Coq",
@@ -240,6 +280,7 @@ var Exts = map[string]string{
 	"vim":         "VimL",
 	"xml":         "XML",
 	"XML":         "XML",
+	"xhtml":       "XHTML",
 	"xsd":         "XSD",
 	"xsl":         "XSLT",
 	"xslt":        "XSLT",
@@ -331,6 +372,8 @@ func GetFileType(path string, opts *ClocOptions) (ext string, ok bool) {
 		return "Ant", true
 	case "pom.xml":
 		return "maven", true
+	case "Gemfile", "Rakefile":
+		return "rb", true
 	}
 
 	switch strings.ToLower(base) {
@@ -342,6 +385,11 @@ func GetFileType(path string, opts *ClocOptions) (ext string, ok bool) {
 		return "", false
 	}
 
+	//Minified and bundled javascript is generated, not written
+	if generatedScript.MatchString(strings.ToLower(base)) {
+		return "", false
+	}
+
 	shebangLang, ok := getFileTypeByShebang(path)
 	if ok {
 		return shebangLang, true
@@ -353,6 +401,15 @@ func GetFileType(path string, opts *ClocOptions) (ext string, ok bool) {
 	return ext, ok
 }
 
+//CobolSourceArea drops the sequence number (columns 1-6) of a fixed format COBOL line, so the indicator of column 7
+//(* and / for comments) starts the line once trimmed
+func CobolSourceArea(line string) string {
+	if cobolSequenceNumber.MatchString(line) {
+		return line[6:]
+	}
+	return line
+}
+
 func NewLanguage(name string, lineComments []string, multiLine, multiLineEnd string) *Language {
 	return &Language{
 		Name:         name,
@@ -457,6 +514,7 @@ func NewDefinedLanguages() *DefinedLanguages {
 			"Go":                  NewLanguage("Go", []string{"//"}, "/*", "*/"),
 			"Groovy":              NewLanguage("Groovy", []string{"//"}, "/*", "*/"),
 			"Haskell":             NewLanguage("Haskell", []string{"--"}, "{-", "-}"),
+			"HCL":                 NewLanguage("HCL", []string{"#", "//"}, "/*", "*/"),
 			"Haxe":                NewLanguage("Haxe", []string{"//"}, "/*", "*/"),
 			"HLSL":                NewLanguage("HLSL", []string{"//"}, "/*", "*/"),
 			"HTML":                NewLanguage("HTML", []string{"//", "<!--"}, "<!--", "-->"),
@@ -464,12 +522,14 @@ func NewDefinedLanguages() *DefinedLanguages {
 			"Io":                  NewLanguage("Io", []string{"//", "#"}, "/*", "*/"),
 			"SKILL":               NewLanguage("SKILL", []string{";"}, "/*", "*/"),
 			"JAI":                 NewLanguage("JAI", []string{"//"}, "/*", "*/"),
+			"JCL":                 NewLanguage("JCL", []string{"//*"}, "", ""),
 			"Java":                NewLanguage("Java", []string{"//"}, "/*", "*/"),
 			"???":                 NewLanguage("???", []string{"//"}, "/*", "*/"),
 			"JavaScript":          NewLanguage("JavaScript", []string{"//"}, "/*", "*/"),
 			"Julia":               NewLanguage("Julia", []string{"#"}, "#:=", ":=#"),
 			"Jupyter Notebook":    NewLanguage("Jupyter Notebook", []string{"#"}, "", ""),
 			"JSON":                NewLanguage("JSON", []string{}, "", ""),
+			"JSP":                 NewLanguage("JSP", []string{"<%--"}, "<%--", "--%>"),
 			"JSX":                 NewLanguage("JSX", []string{"//"}, "/*", "*/"),
 			"Kotlin":              NewLanguage("Kotlin", []string{"//"}, "/*", "*/"),
 			"LD Script":           NewLanguage("LD Script", []string{"//"}, "/*", "*/"),
@@ -507,7 +567,7 @@ func NewDefinedLanguages() *DefinedLanguages {
 			"RAML":                NewLanguage("RAML", []string{"#"}, "", ""),
 			"Racket":              NewLanguage("Racket", []string{";"}, "#|", "|#"),
 			"ReStructuredText":    NewLanguage("ReStructuredText", []string{}, "", ""),
-			"Ruby":                NewLanguage("Ruby", []string{"#"}, ":=begin", ":=end"),
+			"Ruby":                NewLanguage("Ruby", []string{"#"}, "=begin", "=end"),
 			"Ruby HTML":           NewLanguage("Ruby HTML", []string{"<!--"}, "<!--", "-->"),
 			"Rust":                NewLanguage("Rust", []string{"//", "///", "//!"}, "/*", "*/"),
 			"Scala":               NewLanguage("Scala", []string{"//"}, "/*", "*/"),
@@ -519,6 +579,7 @@ func NewDefinedLanguages() *DefinedLanguages {
 			"Bourne Shell":        NewLanguage("Bourne Shell", []string{"#"}, "", ""),
 			"Standard ML":         NewLanguage("Standard ML", []string{}, "(*", "*)"),
 			"SQL":                 NewLanguage("SQL", []string{"--"}, "/*", "*/"),
+			"PLSQL":               NewLanguage("PLSQL", []string{"--"}, "/*", "*/"),
 			"Swift":               NewLanguage("Swift", []string{"//"}, "/*", "*/"),
 			"Terra":               NewLanguage("Terra", []string{"--"}, "--[[", "]]"),
 			"TeX":                 NewLanguage("TeX", []string{"%"}, "", ""),
@@ -534,6 +595,7 @@ func NewDefinedLanguages() *DefinedLanguages {
 			"VimL":                NewLanguage("VimL", []string{`"`}, "", ""),
 			"WiX":                 NewLanguage("WiX", []string{"<!--"}, "<!--", "-->"),
 			"XML":                 NewLanguage("XML", []string{"<!--"}, "<!--", "-->"),
+			"XHTML":               NewLanguage("XHTML", []string{"<!--"}, "<!--", "-->"),
 			"XSLT":                NewLanguage("XSLT", []string{"<!--"}, "<!--", "-->"),
 			"XSD":                 NewLanguage("XSD", []string{"<!--"}, "<!--", "-->"),
 			"YAML":                NewLanguage("YAML", []string{"#"}, "", ""),
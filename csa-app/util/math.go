@@ -45,13 +45,30 @@ func NewExpression(parameters map[string]interface{}) *Expression {
 		}
 	}
 
+	functions["min"] = func(args ...interface{}) (interface{}, error) {
+		return minMax("min", args, math.Min)
+	}
+
+	functions["max"] = func(args ...interface{}) (interface{}, error) {
+		return minMax("max", args, math.Max)
+	}
+
 	//If needed more functions can be added here...
 
 	return &Expression{Functions: functions, Params: parameters}
 }
 
+//Compile parses the expression without calculating it, I.E. to validate it or list the variables it uses
+func (ex *Expression) Compile(target string) (*govaluate.EvaluableExpression, error) {
+	return govaluate.NewEvaluableExpressionWithFunctions(target, ex.Functions)
+}
+
 func (ex *Expression) Calculate(target string) (float64, error) {
-	expression, err := govaluate.NewEvaluableExpressionWithFunctions(target, ex.Functions)
+	expression, err := ex.Compile(target)
+	if err != nil {
+		return math.NaN(), err
+	}
+
 	result, err := expression.Evaluate(ex.Params)
 
 	if err == nil {
@@ -61,6 +78,24 @@ func (ex *Expression) Calculate(target string) (float64, error) {
 	return math.NaN(), err
 }
 
+func minMax(name string, args []interface{}, pick func(float64, float64) float64) (interface{}, error) {
+	if len(args) == 0 {
+		return math.NaN(), fmt.Errorf("%s function needs at least one value", name)
+	}
+
+	result, err := getFloat(args[0])
+	for i := 1; err == nil && i < len(args); i++ {
+		var val float64
+		val, err = getFloat(args[i])
+		result = pick(result, val)
+	}
+	if err != nil {
+		return math.NaN(), fmt.Errorf("invalid value for %s function! details: %s", name, err.Error())
+	}
+
+	return result, nil
+}
+
 func getFloat(unk interface{}) (float64, error) {
 	switch i := unk.(type) {
 	case float64:
@@ -77,3 +77,26 @@ func TestSimpleLogOtherBaseExpressionWithParams(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 4.0, result)
 }
+
+func TestMinMaxExpression(t *testing.T) {
+
+	params := make(map[string]interface{})
+	params["sloc"] = 500
+
+	ex := util.NewExpression(params)
+	result, err := ex.Calculate("max(1, sloc / 1000)")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, result)
+
+	result, err = ex.Calculate("min(10, sloc, 42)")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10.0, result)
+}
+
+func TestInvalidExpression(t *testing.T) {
+	_, err := util.NewExpression(nil).Calculate("10 - (")
+
+	assert.NotNil(t, err)
+}
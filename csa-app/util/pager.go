@@ -0,0 +1,40 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+//Page sends content through the user's pager ($PAGER or less -RS). If no pager can be started the content is written to std out.
+func Page(content string) {
+
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = DEFAULT_PAGER
+	}
+
+	tokens := strings.Fields(pager)
+	if len(tokens) == 0 {
+		fmt.Print(content)
+		return
+	}
+
+	cmd := exec.Command(tokens[0], tokens[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if *Verbose {
+			fmt.Fprintf(os.Stderr, "Unable to page output with [%s]. Details: %v\n", pager, err)
+		}
+		fmt.Print(content)
+	}
+}
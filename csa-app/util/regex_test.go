@@ -0,0 +1,78 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileRegexIsCached(t *testing.T) {
+	first, err := util.CompileRegex(`[ .]javax\.ejb[ (]`)
+	assert.NoError(t, err)
+
+	second := util.MustCompileRegex(`[ .]javax\.ejb[ (]`)
+	assert.True(t, first == second)
+
+	_, err = util.CompileRegex(`(?<=lookbehind)`)
+	assert.Error(t, err)
+}
+
+func TestMatchRegexTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { *util.RegexTimeout = timeout }(*util.RegexTimeout)
+
+	regex := util.MustCompileRegex(`(a|b)*c(d|e)*f$`)
+	huge := strings.Repeat("ab", 5000000)
+
+	*util.RegexTimeout = 0
+	assert.True(t, util.MatchRegex(regex, "ababcdef"))
+
+	*util.RegexTimeout = time.Nanosecond
+	assert.False(t, util.MatchRegex(regex, huge))
+	assert.Contains(t, util.StalledRegexes(), regex.String())
+
+	//Disabled for the rest of the run
+	*util.RegexTimeout = time.Minute
+	assert.False(t, util.MatchRegex(regex, "ababcdef"))
+}
+
+func TestResetRegexCache(t *testing.T) {
+	defer func(timeout time.Duration) { *util.RegexTimeout = timeout }(*util.RegexTimeout)
+
+	regex := util.MustCompileRegex(`(x|y)*z(v|w)*u$`)
+
+	*util.RegexTimeout = time.Nanosecond
+	assert.False(t, util.MatchRegex(regex, strings.Repeat("xy", 5000000)))
+	assert.Contains(t, util.StalledRegexes(), regex.String())
+
+	util.ResetRegexCache()
+	assert.NotContains(t, util.StalledRegexes(), regex.String())
+	assert.False(t, regex == util.MustCompileRegex(`(x|y)*z(v|w)*u$`), "recompiled after the reset")
+
+	*util.RegexTimeout = time.Minute
+	assert.True(t, util.MatchRegex(regex, "xyzvwu"))
+}
+
+func TestMatchRegexMaxInput(t *testing.T) {
+	defer func(limit int) { *util.RegexMaxInput = limit }(*util.RegexMaxInput)
+
+	regex := util.MustCompileRegex(`needle`)
+	haystack := strings.Repeat("x", 2048) + "needle"
+
+	*util.RegexMaxInput = 0
+	assert.True(t, util.MatchRegex(regex, haystack))
+
+	truncated := util.TruncatedRegexTargets()
+	*util.RegexMaxInput = 1
+	assert.False(t, util.MatchRegex(regex, haystack))
+	assert.Equal(t, 0, len(util.FindAllRegex(regex, haystack)))
+	assert.Equal(t, truncated+2, util.TruncatedRegexTargets())
+	assert.True(t, util.MatchRegex(regex, "needle"))
+}
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 var specials = map[string]string{
@@ -42,6 +43,22 @@ func Padd(character string, cnt int) (result string) {
 	return result
 }
 
+//Truncate shortens value to at most maxLen characters, marking the cut with an ellipsis. A maxLen <= 0 means no limit.
+//Characters are runes, so multi-byte characters are never split.
+func Truncate(value string, maxLen int) string {
+
+	if maxLen <= 0 || utf8.RuneCountInString(value) <= maxLen {
+		return value
+	}
+
+	runes := []rune(value)
+	if maxLen <= len(ELLIPSIS) {
+		return string(runes[:maxLen])
+	}
+
+	return string(runes[:maxLen-len(ELLIPSIS)]) + ELLIPSIS
+}
+
 func EscapeSpecials(target string) string {
 
 	if target != "" {
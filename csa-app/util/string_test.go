@@ -0,0 +1,34 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"testing"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateUnlimited(t *testing.T) {
+	assert.Equal(t, "com.acme.SomeLongClassName", util.Truncate("com.acme.SomeLongClassName", 0))
+}
+
+func TestTruncateShortValue(t *testing.T) {
+	assert.Equal(t, "short", util.Truncate("short", 10))
+}
+
+func TestTruncateAddsEllipsis(t *testing.T) {
+	assert.Equal(t, "com.ac...", util.Truncate("com.acme.SomeLongClassName", 9))
+}
+
+func TestTruncateNarrowerThanEllipsis(t *testing.T) {
+	assert.Equal(t, "co", util.Truncate("com.acme", 2))
+}
+
+func TestTruncateKeepsMultiByteCharacters(t *testing.T) {
+	assert.Equal(t, "Größe...", util.Truncate("GrößeDerDatei", 8))
+	assert.Equal(t, "日本", util.Truncate("日本語", 2))
+}
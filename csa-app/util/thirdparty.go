@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+//Only the top of a file is checked for generator markers
+const GENERATED_HEADER_SIZE = 1024
+
+var generatedCodeRegex = regexp.MustCompile(`(?i)(code generated .* do not edit|@generated|<auto-generated|auto-generated file|generated by the protocol buffer compiler|this file was automatically generated)`)
+
+//ThirdPartyRoot returns the path (relative to the application root) of the vendored/third-party tree the file lives in.
+//Configured paths are checked first followed by the third-party-dirs heuristic. An empty string means first-party code.
+func (fu *FileUtil) ThirdPartyRoot(appPath string, fqn string, configured []string) string {
+
+	rel, err := filepath.Rel(appPath, fqn)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, path := range configured {
+		path = strings.Trim(filepath.ToSlash(path), "/")
+		if path != "" && (rel == path || strings.HasPrefix(rel, path+"/")) {
+			return path
+		}
+	}
+
+	if *NoThirdPartyDetection || fu.ThirdPartyDirsRegExp == nil {
+		return ""
+	}
+
+	dirs := strings.Split(rel, "/")
+	for i, dir := range dirs[:len(dirs)-1] {
+		if fu.ThirdPartyDirsRegExp.MatchString(dir) {
+			return strings.Join(dirs[:i+1], "/")
+		}
+	}
+
+	return ""
+}
+
+//IsGeneratedFile checks the header of the file for the markers code generators leave behind
+func IsGeneratedFile(fqn string) bool {
+
+	if *NoThirdPartyDetection {
+		return false
+	}
+
+	file, err := os.Open(fqn)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, GENERATED_HEADER_SIZE)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return false
+	}
+
+	return generatedCodeRegex.Match(header[:n])
+}
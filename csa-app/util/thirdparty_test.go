@@ -0,0 +1,45 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThirdPartyRootFromDirectoryHeuristic(t *testing.T) {
+	fu := &util.FileUtil{ThirdPartyDirsRegExp: regexp.MustCompile("^(vendor|third_party)$")}
+
+	assert.Equal(t, "vendor", fu.ThirdPartyRoot("/apps/shop", "/apps/shop/vendor/github.com/lib/a.go", nil))
+	assert.Equal(t, "src/third_party", fu.ThirdPartyRoot("/apps/shop", "/apps/shop/src/third_party/zlib/zlib.c", nil))
+	assert.Equal(t, "", fu.ThirdPartyRoot("/apps/shop", "/apps/shop/src/main/vendor.go", nil))
+}
+
+func TestThirdPartyRootFromConfiguredPaths(t *testing.T) {
+	fu := &util.FileUtil{ThirdPartyDirsRegExp: regexp.MustCompile("^vendor$")}
+
+	assert.Equal(t, "web/static/lib", fu.ThirdPartyRoot("/apps/shop", "/apps/shop/web/static/lib/jquery.js", []string{"/web/static/lib/"}))
+	assert.Equal(t, "", fu.ThirdPartyRoot("/apps/shop", "/apps/shop/web/static/library.js", []string{"web/static/lib"}))
+}
+
+func TestIsGeneratedFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "csa-generated")
+	defer os.RemoveAll(dir)
+
+	generated := filepath.Join(dir, "client.pb.go")
+	_ = ioutil.WriteFile(generated, []byte("// Code generated by protoc-gen-go. DO NOT EDIT.\npackage client\n"), 0644)
+	handWritten := filepath.Join(dir, "client.go")
+	_ = ioutil.WriteFile(handWritten, []byte("package client\n"), 0644)
+
+	assert.True(t, util.IsGeneratedFile(generated))
+	assert.False(t, util.IsGeneratedFile(handWritten))
+}
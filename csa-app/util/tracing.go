@@ -0,0 +1,66 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TRACING_SHUTDOWN_TIMEOUT = 10 * time.Second
+
+//InitTracing exports spans to the OTLP/HTTP endpoint given by --otel-endpoint. When no endpoint is set the global
+//(no-op) tracer provider is left in place so spans cost next to nothing. The returned func flushes pending spans.
+func InitTracing(version string) func() {
+
+	if *OtelEndpoint == "" {
+		return func() {}
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(*OtelEndpoint)}
+	if *OtelInsecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to create OTLP exporter for [%s]! Tracing disabled. Details: %v\n", *OtelEndpoint, err)
+		return func() {}
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(APP_NAME),
+			semconv.ServiceVersionKey.String(version))))
+
+	otel.SetTracerProvider(provider)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), TRACING_SHUTDOWN_TIMEOUT)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed flushing trace spans to [%s]! Details: %v\n", *OtelEndpoint, err)
+		}
+	}
+}
+
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(APP_NAME).Start(ctx, name, trace.WithAttributes(attrs...))
+}
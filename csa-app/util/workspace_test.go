@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright (c) 2018 - Present VMware, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: BSD-2
+ ******************************************************************************/
+
+package util_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"csa-app/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceLifecycle(t *testing.T) {
+	base, err := ioutil.TempDir("", "workspaces")
+	assert.NoError(t, err)
+	defer os.RemoveAll(base)
+
+	first, err := util.NewWorkspace(base, "analyze", 1<<20)
+	assert.NoError(t, err)
+	second, err := util.NewWorkspace(base, "analyze", 1<<20)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Root, second.Root)
+
+	dest := filepath.Join(base, "report.csv")
+	err = first.Stage(dest, func(out io.Writer) error {
+		_, err := out.Write([]byte("a,b\n"))
+		return err
+	})
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b\n", string(content))
+
+	extract, err := second.Dir("decompile")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(extract, "big.bin"), make([]byte, 2<<20), 0644))
+	assert.Error(t, second.CheckLimit())
+
+	assert.NoError(t, first.Close())
+	assert.NoError(t, second.Close())
+	assert.False(t, util.Exists(first.Root))
+	assert.False(t, util.Exists(second.Root))
+}
+
+func TestReclaimStaleWorkspaces(t *testing.T) {
+	base, err := ioutil.TempDir("", "workspaces")
+	assert.NoError(t, err)
+	defer os.RemoveAll(base)
+
+	live, err := util.NewWorkspace(base, "ui", 0)
+	assert.NoError(t, err)
+	defer live.Close()
+
+	//Simulate a workspace whose owner died long ago
+	stale := filepath.Join(base, util.WORKSPACE_PREFIX+"analyze-1")
+	assert.NoError(t, os.MkdirAll(stale, 0755))
+	owner := filepath.Join(stale, util.WORKSPACE_OWNER_FILE)
+	assert.NoError(t, ioutil.WriteFile(owner, []byte("pid: 1\n"), 0644))
+	old := time.Now().Add(-2 * util.WORKSPACE_STALE_AFTER)
+	assert.NoError(t, os.Chtimes(owner, old, old))
+
+	assert.Equal(t, []string{stale}, util.ReclaimStaleWorkspaces(base))
+	assert.True(t, util.Exists(live.Root))
+}